@@ -9,6 +9,9 @@ import (
 	"net/http"
 	"os"
 	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -21,6 +24,10 @@ import (
 	"github.com/testcontainers/testcontainers-go/wait"
 )
 
+// pollInterval is how often the test checks for progress.  Polling often
+// keeps the test from waiting long after the services are done.
+const pollInterval = 100 * time.Millisecond
+
 func TestTranscodeEndToEnd(t *testing.T) {
 	ctx := context.Background()
 
@@ -116,6 +123,13 @@ func TestTranscodeEndToEnd(t *testing.T) {
 		"VI_SERVER_PORT": "8080",
 	}
 
+	// Cache statuses on the server, so polling below depends on change
+	// notifications reaching it
+	serverEnv := map[string]string{"VI_STATUS_CACHE_SIZE": "100"}
+	for k, v := range dbEnv {
+		serverEnv[k] = v
+	}
+
 	// Build and start server container
 	serverReq := testcontainers.ContainerRequest{
 		FromDockerfile: testcontainers.FromDockerfile{
@@ -127,7 +141,7 @@ func TestTranscodeEndToEnd(t *testing.T) {
 			},
 		},
 		ExposedPorts:   []string{"8080/tcp"},
-		Env:            dbEnv,
+		Env:            serverEnv,
 		Networks:       []string{networkName},
 		NetworkAliases: map[string][]string{networkName: {"server"}},
 		WaitingFor:     wait.ForLog("Starting HTTP server on port 8080"),
@@ -172,6 +186,16 @@ func TestTranscodeEndToEnd(t *testing.T) {
 		dumpContainerLogs(t, ctx, workerContainer, "worker")
 	})
 
+	// The worker's self-test should pass in the container it runs in
+	exitCode, selfTestOutput, err := workerContainer.Exec(ctx, []string{"/app/worker", "--self-test"})
+	if err != nil {
+		t.Fatalf("failed to run worker self-test: %v", err)
+	}
+	if exitCode != 0 {
+		report, _ := io.ReadAll(selfTestOutput)
+		t.Fatalf("worker self-test exited with %d:\n%s", exitCode, report)
+	}
+
 	// Get server mapped port
 	mappedPort, err := serverContainer.MappedPort(ctx, "8080")
 	if err != nil {
@@ -194,12 +218,24 @@ func TestTranscodeEndToEnd(t *testing.T) {
 	sourcePath := "/nas/media/testdata_sample_640x360.mkv"
 	webhookURI := "http://mockserver:1080/webhook"
 	webhookToken := []byte("test-webhook-token")
+	labels := virest.Labels{"libraryId": "movie-1234"}
+	externalID := "library-item-" + jobUUID.String()
+	analyzeCrop := true
+	analyzeLoudness := true
+	verify := true
+	includeRaw := true
 
 	createResp, err := client.CreateInfoWithResponse(ctx, virest.CreateInfoJSONRequestBody{
-		Uuid:         jobUUID,
-		VideoPath:    sourcePath,
-		WebhookUri:   &webhookURI,
-		WebhookToken: webhookToken,
+		Uuid:            jobUUID,
+		VideoPath:       sourcePath,
+		WebhookUri:      &webhookURI,
+		WebhookToken:    webhookToken,
+		Labels:          &labels,
+		ExternalId:      &externalID,
+		AnalyzeCrop:     &analyzeCrop,
+		AnalyzeLoudness: &analyzeLoudness,
+		Verify:          &verify,
+		IncludeRaw:      &includeRaw,
 	})
 	if err != nil {
 		t.Fatalf("failed to create info job: %v", err)
@@ -231,7 +267,7 @@ func TestTranscodeEndToEnd(t *testing.T) {
 			break
 		}
 
-		time.Sleep(2 * time.Second)
+		time.Sleep(pollInterval)
 	}
 
 	// Verify job completed successfully
@@ -239,6 +275,62 @@ func TestTranscodeEndToEnd(t *testing.T) {
 		t.Fatalf("expected job to complete successfully, but got status: %s", finalJob.Status)
 	}
 
+	if finalJob.Labels == nil || (*finalJob.Labels)["libraryId"] != "movie-1234" {
+		t.Errorf("job labels mismatch: got %v, want %v", finalJob.Labels, labels)
+	}
+
+	phases := make([]string, 0, len(finalJob.Timings))
+	for _, timing := range finalJob.Timings {
+		phases = append(phases, timing.Phase)
+	}
+	if !slices.Contains(phases, "ffprobe") {
+		t.Errorf("job timings missing ffprobe phase: got %v", phases)
+	}
+
+	// Verify the job can be looked up by its external ID
+	byExternalIDResp, err := client.GetInfoStatusByExternalIdWithResponse(ctx, externalID)
+	if err != nil {
+		t.Fatalf("failed to get info status by external ID: %v", err)
+	}
+	if byExternalIDResp.JSON200 == nil {
+		t.Fatalf("expected 200 response, got status %d: %s", byExternalIDResp.StatusCode(), string(byExternalIDResp.Body))
+	}
+	if byExternalIDResp.JSON200.Uuid != jobUUID {
+		t.Errorf("external ID lookup UUID mismatch: got %s, want %s", byExternalIDResp.JSON200.Uuid, jobUUID)
+	}
+
+	// Verify the video stream of the sample file was reported
+	if finalJob.Result == nil || len(finalJob.Result.VideoStreams) != 1 {
+		t.Fatalf("expected one video stream in result, got %+v", finalJob.Result)
+	}
+	if finalJob.Result.MediaKind != virest.Video {
+		t.Errorf("media kind mismatch: got %s, want %s", finalJob.Result.MediaKind, virest.Video)
+	}
+	if stream := finalJob.Result.VideoStreams[0]; stream.Width != 640 || stream.Height != 360 {
+		t.Errorf("video stream dimensions mismatch: got %dx%d, want 640x360", stream.Width, stream.Height)
+	}
+	if container := finalJob.Result.Container; container == nil || !strings.Contains(container.FormatName, "matroska") {
+		t.Errorf("expected a Matroska container, got %+v", container)
+	}
+	if crop := finalJob.Result.Crop; crop == nil || crop.Samples == 0 || crop.Width == 0 || crop.Width > 640 || crop.Height > 360 {
+		t.Errorf("expected a crop detection within the frame, got %+v (warnings %v)", crop, finalJob.Result.Warnings)
+	}
+	if v := finalJob.Result.Verification; v == nil || v.ErrorCount != 0 || !v.Complete {
+		t.Errorf("expected a clean decode verification, got %+v (warnings %v)", v, finalJob.Result.Warnings)
+	}
+	if v := finalJob.Result.Versions; v == nil || v.Ffprobe == "unknown" || v.Ffmpeg == "unknown" {
+		t.Errorf("expected the ffprobe and ffmpeg versions, got %+v", v)
+	}
+	for _, track := range finalJob.Result.AudioTracks {
+		if track.Loudness == nil {
+			t.Errorf("expected loudness for audio track %d (warnings %v)", track.Index, finalJob.Result.Warnings)
+		}
+	}
+
+	if _, ok := finalJob.Result.RawProbe["streams"]; !ok {
+		t.Errorf("expected raw ffprobe output with streams, got %v", finalJob.Result.RawProbe)
+	}
+
 	// TODO: Verify extracted info fields
 	t.Log(deep.Format(deep.NewEnv(), finalJob))
 
@@ -257,6 +349,9 @@ func TestTranscodeEndToEnd(t *testing.T) {
 	if !bytes.Equal(webhookPayload.Token, webhookToken) {
 		t.Errorf("webhook token mismatch: got %v, want %v", webhookPayload.Token, webhookToken)
 	}
+	if webhookPayload.Labels["libraryId"] != "movie-1234" {
+		t.Errorf("webhook labels mismatch: got %v, want %v", webhookPayload.Labels, labels)
+	}
 	if webhookPayload.Result == nil {
 		t.Errorf("webhook result should not be nil for successful job")
 	}
@@ -281,6 +376,49 @@ func TestTranscodeEndToEnd(t *testing.T) {
 	}
 	t.Logf("Duplicate UUID correctly rejected with 409: %s", duplicateResp.JSON409.Message)
 
+	// Concurrent submissions of the same job, as load-balanced server
+	// replicas would see, create it exactly once
+	concurrentUUID := uuid.New()
+	statusCodes := make([]int, 8)
+	var wg sync.WaitGroup
+	for i := range statusCodes {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp, err := client.CreateInfoWithResponse(ctx, virest.CreateInfoJSONRequestBody{
+				Uuid:      concurrentUUID,
+				VideoPath: sourcePath,
+			})
+			if err != nil {
+				t.Errorf("failed to send concurrent info request: %v", err)
+				return
+			}
+			statusCodes[i] = resp.StatusCode()
+		}()
+	}
+	wg.Wait()
+	slices.Sort(statusCodes)
+	if want := []int{201, 409, 409, 409, 409, 409, 409, 409}; !slices.Equal(statusCodes, want) {
+		t.Errorf("concurrent submissions of one UUID: got statuses %v, want %v", statusCodes, want)
+	}
+
+	// Results outlive River's retention of the finished job itself
+	exitCode, _, err = postgresContainer.Exec(ctx, []string{
+		"psql", "-U", dbUser, "-d", dbName, "-c", "DELETE FROM river_job WHERE kind = 'info'",
+	})
+	if err != nil || exitCode != 0 {
+		t.Fatalf("failed to delete info jobs: exit code %d: %v", exitCode, err)
+	}
+	storedResp, err := client.GetInfoStatusWithResponse(ctx, jobUUID)
+	if err != nil {
+		t.Fatalf("failed to get stored info status: %v", err)
+	}
+	if storedResp.JSON200 == nil {
+		t.Fatalf("expected 200 response for stored result, got status %d: %s", storedResp.StatusCode(), string(storedResp.Body))
+	}
+	if storedResp.JSON200.Status != virest.Completed || storedResp.JSON200.Result == nil {
+		t.Errorf("expected a completed job with a result once River removed it, got %s", deep.Format(deep.NewEnv(), storedResp.JSON200))
+	}
 }
 
 // copyFile copies a file from src to dst
@@ -321,10 +459,12 @@ func dumpContainerLogs(t *testing.T, ctx context.Context, container testcontaine
 
 // WebhookPayload matches the structure sent by the webhook worker
 type WebhookPayload struct {
-	Token  []byte            `json:"token,omitempty"`
-	Uuid   uuid.UUID         `json:"uuid"`
-	Result *virest.VideoInfo `json:"result,omitempty"`
-	Error  *string           `json:"error,omitempty"`
+	Token      []byte            `json:"token,omitempty"`
+	Uuid       uuid.UUID         `json:"uuid"`
+	ExternalID *string           `json:"externalId,omitempty"`
+	Labels     map[string]string `json:"labels,omitempty"`
+	Result     *virest.MediaInfo `json:"result,omitempty"`
+	Error      *string           `json:"error,omitempty"`
 }
 
 // setupMockServerExpectation configures MockServer to accept POST requests
@@ -373,7 +513,7 @@ func waitForWebhook(t *testing.T, ctx context.Context, mockServerURL, path strin
 		if found {
 			return payload
 		}
-		time.Sleep(500 * time.Millisecond)
+		time.Sleep(pollInterval)
 	}
 
 	return nil
@@ -0,0 +1,77 @@
+package internal
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// ErrPathNotAllowed is returned for video paths outside the allowed roots.
+var ErrPathNotAllowed = errors.New("path not allowed")
+
+// AllowedRoots restricts video paths to the directories it lists, each
+// normalized by NormalizeMount.  An empty list allows any path.
+type AllowedRoots []string
+
+// ParseAllowedRoots returns the AllowedRoots for the given absolute
+// directory paths.
+func ParseAllowedRoots(roots []string) (AllowedRoots, error) {
+	var allowed AllowedRoots
+	for _, root := range roots {
+		normalized, err := NormalizeMount(root)
+		if err != nil {
+			return nil, err
+		}
+		allowed = append(allowed, normalized)
+	}
+	return allowed, nil
+}
+
+// Check returns ErrPathNotAllowed unless videoPath is an absolute path under
+// one of the roots once cleaned, so that ".." components can't escape them.
+// Symbolic links are not followed.
+func (r AllowedRoots) Check(videoPath string) error {
+	if len(r) == 0 {
+		return nil
+	}
+	if !path.IsAbs(videoPath) {
+		return fmt.Errorf("%w: %q is not an absolute path", ErrPathNotAllowed, videoPath)
+	}
+	cleaned := path.Clean(videoPath)
+	if !strings.HasSuffix(cleaned, "/") {
+		cleaned += "/"
+	}
+	for _, root := range r {
+		if strings.HasPrefix(cleaned, root) {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %q is not under an allowed root", ErrPathNotAllowed, videoPath)
+}
+
+// CheckResolved is like Check, but also requires videoPath to stay under the
+// roots once symbolic links in it and in the roots are resolved, so a link
+// can't point outside them.  Paths that can't be resolved, such as missing
+// files, are only checked as given; probing them fails anyway.
+func (r AllowedRoots) CheckResolved(videoPath string) error {
+	if err := r.Check(videoPath); err != nil || len(r) == 0 {
+		return err
+	}
+	resolvedPath, err := filepath.EvalSymlinks(videoPath)
+	if err != nil {
+		return nil
+	}
+	resolved := make(AllowedRoots, 0, len(r))
+	for _, root := range r {
+		if resolvedRoot, err := filepath.EvalSymlinks(root); err == nil {
+			root, _ = NormalizeMount(resolvedRoot)
+		}
+		resolved = append(resolved, root)
+	}
+	if err := resolved.Check(resolvedPath); err != nil {
+		return fmt.Errorf("%w: %q links outside the allowed roots", ErrPathNotAllowed, videoPath)
+	}
+	return nil
+}
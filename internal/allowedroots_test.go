@@ -0,0 +1,116 @@
+package internal_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/krelinga/go-libs/deep"
+	"github.com/krelinga/go-libs/exam"
+	"github.com/krelinga/go-libs/match"
+	"github.com/krelinga/video-info/internal"
+)
+
+func TestAllowedRoots(t *testing.T) {
+	e := exam.New(t)
+	env := deep.NewEnv()
+
+	roots, err := internal.ParseAllowedRoots([]string{"/videos", "/mnt/media/"})
+	exam.Nil(e, env, err)
+
+	tests := []struct {
+		loc     exam.Loc
+		name    string
+		roots   internal.AllowedRoots
+		path    string
+		wantErr bool
+	}{
+		{
+			loc:   exam.Here(),
+			name:  "No roots allow any path",
+			roots: nil,
+			path:  "/etc/passwd",
+		},
+		{
+			loc:   exam.Here(),
+			name:  "File under a root",
+			roots: roots,
+			path:  "/mnt/media/movies/a.mkv",
+		},
+		{
+			loc:   exam.Here(),
+			name:  "Root itself",
+			roots: roots,
+			path:  "/videos",
+		},
+		{
+			loc:     exam.Here(),
+			name:    "File outside the roots",
+			roots:   roots,
+			path:    "/etc/passwd",
+			wantErr: true,
+		},
+		{
+			loc:     exam.Here(),
+			name:    "Sibling sharing a prefix",
+			roots:   roots,
+			path:    "/videos-private/a.mkv",
+			wantErr: true,
+		},
+		{
+			loc:     exam.Here(),
+			name:    "Escape through dot-dot",
+			roots:   roots,
+			path:    "/videos/../etc/passwd",
+			wantErr: true,
+		},
+		{
+			loc:     exam.Here(),
+			name:    "Relative path",
+			roots:   roots,
+			path:    "videos/a.mkv",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		e.Run(tt.name, func(e exam.E) {
+			e.Log("Running test at", tt.loc)
+
+			err := tt.roots.Check(tt.path)
+			if tt.wantErr {
+				exam.Match(e, env, err, match.ErrorIs(internal.ErrPathNotAllowed))
+			} else {
+				exam.Nil(e, env, err)
+			}
+		})
+	}
+
+	e.Run("Invalid root", func(e exam.E) {
+		_, err := internal.ParseAllowedRoots([]string{"videos"})
+		exam.Match(e, env, err, match.ErrorIs(internal.ErrInvalidMount))
+	})
+
+	e.Run("Symbolic links are resolved", func(e exam.E) {
+		dir := e.TempDir()
+		root := filepath.Join(dir, "videos")
+		outside := filepath.Join(dir, "secret.mkv")
+		if err := os.Mkdir(root, 0o755); err != nil {
+			e.Fatal(err)
+		}
+		if err := os.WriteFile(outside, nil, 0o644); err != nil {
+			e.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(root, "a.mkv"), nil, 0o644); err != nil {
+			e.Fatal(err)
+		}
+		if err := os.Symlink(outside, filepath.Join(root, "link.mkv")); err != nil {
+			e.Fatal(err)
+		}
+		roots, err := internal.ParseAllowedRoots([]string{root})
+		exam.Nil(e, env, err)
+
+		exam.Nil(e, env, roots.CheckResolved(filepath.Join(root, "a.mkv")))
+		exam.Nil(e, env, roots.Check(filepath.Join(root, "link.mkv")))
+		exam.Match(e, env, roots.CheckResolved(filepath.Join(root, "link.mkv")), match.ErrorIs(internal.ErrPathNotAllowed))
+	})
+}
@@ -0,0 +1,159 @@
+package internal
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"time"
+)
+
+// MaxAnalyzersPerJob bounds the analyzer plugins one info job may select.
+const MaxAnalyzersPerJob = 16
+
+// MaxAnalyzerFrames bounds the frames sampled for an analyzer plugin.
+const MaxAnalyzerFrames = 32
+
+// defaultAnalyzerTimeout bounds analyzer plugins that don't set a timeout.
+const defaultAnalyzerTimeout = 5 * time.Minute
+
+var ErrInvalidAnalyzers = errors.New("invalid analyzer plugins")
+
+// analyzerNamePattern restricts analyzer names to ones that are safe as JSON
+// keys and phase names.
+var analyzerNamePattern = regexp.MustCompile(`^[a-z][a-z0-9_-]{0,62}$`)
+
+// ValidateAnalyzerNames checks analyzer names selected for a job.
+func ValidateAnalyzerNames(names []string) error {
+	if len(names) > MaxAnalyzersPerJob {
+		return fmt.Errorf("%w: at most %d analyzers may be selected", ErrInvalidAnalyzers, MaxAnalyzersPerJob)
+	}
+	seen := make(map[string]bool, len(names))
+	for _, name := range names {
+		if !analyzerNamePattern.MatchString(name) {
+			return fmt.Errorf("%w: invalid name %q", ErrInvalidAnalyzers, name)
+		}
+		if seen[name] {
+			return fmt.Errorf("%w: %q selected twice", ErrInvalidAnalyzers, name)
+		}
+		seen[name] = true
+	}
+	return nil
+}
+
+// AnalyzerPlugin is a program that adds its own analysis to results, either
+// an executable or a WASI module.  It is given a request describing the file
+// as JSON on its standard input and must write a single JSON value to its
+// standard output, which is added to the result's extensions under the
+// plugin's name.  A plugin may instead run MakeMKV, whose findings are added
+// as a MakeMKVInfo.
+type AnalyzerPlugin struct {
+	// Name is how jobs select the plugin.
+	Name string `json:"name"`
+
+	// Path is the executable to run.
+	Path string `json:"path,omitempty"`
+
+	// Wasm is the WASI module to run instead of an executable.  Modules are
+	// sandboxed: they can read their request and any sampled frames, but
+	// not the file itself, the rest of the filesystem or the network.
+	Wasm string `json:"wasm,omitempty"`
+
+	// MakeMKV is the makemkvcon executable to run instead of a plugin, to
+	// report the titles and segment maps of the file with `makemkvcon info`.
+	// It runs confined like executable plugins, with an empty home
+	// directory, so a registration key must be supplied by a wrapper.
+	MakeMKV string `json:"makemkv,omitempty"`
+
+	// Frames is the number of evenly spaced video frames sampled for the
+	// plugin to inspect.
+	Frames int `json:"frames,omitempty"`
+
+	// TimeoutSeconds bounds each run, or five minutes if unset.
+	TimeoutSeconds int `json:"timeoutSeconds,omitempty"`
+}
+
+// Timeout returns the bound on each run of the plugin.
+func (p AnalyzerPlugin) Timeout() time.Duration {
+	if p.TimeoutSeconds <= 0 {
+		return defaultAnalyzerTimeout
+	}
+	return time.Duration(p.TimeoutSeconds) * time.Second
+}
+
+// AnalyzerPlugins are the analyzer plugins configured on a worker.
+//
+// A nil AnalyzerPlugins has no plugins.
+type AnalyzerPlugins struct {
+	plugins map[string]AnalyzerPlugin
+}
+
+// NewAnalyzerPlugins returns AnalyzerPlugins holding plugins, which must
+// have valid, distinct names and exactly one of a path, a WASI module and a
+// makemkvcon executable.
+func NewAnalyzerPlugins(plugins []AnalyzerPlugin) (*AnalyzerPlugins, error) {
+	a := &AnalyzerPlugins{plugins: make(map[string]AnalyzerPlugin, len(plugins))}
+	for i, plugin := range plugins {
+		if !analyzerNamePattern.MatchString(plugin.Name) {
+			return nil, fmt.Errorf("%w: plugin %d has invalid name %q", ErrInvalidAnalyzers, i, plugin.Name)
+		}
+		if _, ok := a.plugins[plugin.Name]; ok {
+			return nil, fmt.Errorf("%w: plugin %q defined twice", ErrInvalidAnalyzers, plugin.Name)
+		}
+		programs := 0
+		for _, program := range []string{plugin.Path, plugin.Wasm, plugin.MakeMKV} {
+			if program != "" {
+				programs++
+			}
+		}
+		if programs != 1 {
+			return nil, fmt.Errorf("%w: plugin %q needs exactly one of path, wasm and makemkv", ErrInvalidAnalyzers, plugin.Name)
+		}
+		if plugin.Frames < 0 || plugin.Frames > MaxAnalyzerFrames {
+			return nil, fmt.Errorf("%w: plugin %q frames must be between 0 and %d", ErrInvalidAnalyzers, plugin.Name, MaxAnalyzerFrames)
+		}
+		a.plugins[plugin.Name] = plugin
+	}
+	return a, nil
+}
+
+// LoadAnalyzerPlugins reads AnalyzerPlugins from a JSON file holding an
+// array of plugins, or returns nil if path is empty.
+func LoadAnalyzerPlugins(path string) (*AnalyzerPlugins, error) {
+	if path == "" {
+		return nil, nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read analyzer plugins: %w", err)
+	}
+	var plugins []AnalyzerPlugin
+	if err := json.Unmarshal(data, &plugins); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidAnalyzers, err)
+	}
+	return NewAnalyzerPlugins(plugins)
+}
+
+// Wasm returns the plugins that are WASI modules, in no particular order.
+func (a *AnalyzerPlugins) Wasm() []AnalyzerPlugin {
+	if a == nil {
+		return nil
+	}
+	var plugins []AnalyzerPlugin
+	for _, plugin := range a.plugins {
+		if plugin.Wasm != "" {
+			plugins = append(plugins, plugin)
+		}
+	}
+	return plugins
+}
+
+// Get returns the plugin with the given name, if there is one.
+func (a *AnalyzerPlugins) Get(name string) (AnalyzerPlugin, bool) {
+	if a == nil {
+		return AnalyzerPlugin{}, false
+	}
+	plugin, ok := a.plugins[name]
+	return plugin, ok
+}
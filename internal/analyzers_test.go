@@ -0,0 +1,134 @@
+package internal_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/krelinga/go-libs/deep"
+	"github.com/krelinga/go-libs/exam"
+	"github.com/krelinga/go-libs/match"
+	"github.com/krelinga/video-info/internal"
+)
+
+func TestAnalyzerPlugins(t *testing.T) {
+	e := exam.New(t)
+	env := deep.NewEnv()
+
+	path := filepath.Join(t.TempDir(), "analyzers.json")
+	exam.Nil(e, env, os.WriteFile(path, []byte(`[
+		{"name": "classifier", "path": "/opt/analyzers/classify", "timeoutSeconds": 60},
+		{"name": "scene-count", "path": "/opt/analyzers/scenes"},
+		{"name": "logo", "wasm": "/opt/analyzers/logo.wasm", "frames": 8},
+		{"name": "makemkv", "makemkv": "/usr/bin/makemkvcon"}
+	]`), 0o600))
+	plugins, err := internal.LoadAnalyzerPlugins(path)
+	exam.Nil(e, env, err)
+
+	classifier, ok := plugins.Get("classifier")
+	exam.Equal(e, env, true, ok)
+	exam.Equal(e, env, "/opt/analyzers/classify", classifier.Path)
+	exam.Equal(e, env, time.Minute, classifier.Timeout())
+
+	scenes, ok := plugins.Get("scene-count")
+	exam.Equal(e, env, true, ok)
+	exam.Equal(e, env, 5*time.Minute, scenes.Timeout())
+
+	logo, ok := plugins.Get("logo")
+	exam.Equal(e, env, true, ok)
+	exam.Equal(e, env, []internal.AnalyzerPlugin{logo}, plugins.Wasm())
+	exam.Equal(e, env, 8, logo.Frames)
+
+	makemkv, ok := plugins.Get("makemkv")
+	exam.Equal(e, env, true, ok)
+	exam.Equal(e, env, "/usr/bin/makemkvcon", makemkv.MakeMKV)
+
+	_, ok = plugins.Get("missing")
+	exam.Equal(e, env, false, ok)
+
+	var none *internal.AnalyzerPlugins
+	_, ok = none.Get("classifier")
+	exam.Equal(e, env, false, ok)
+}
+
+func TestNewAnalyzerPluginsInvalid(t *testing.T) {
+	e := exam.New(t)
+	env := deep.NewEnv()
+
+	tests := []struct {
+		loc     exam.Loc
+		name    string
+		plugins []internal.AnalyzerPlugin
+	}{
+		{
+			loc:     exam.Here(),
+			name:    "Invalid name",
+			plugins: []internal.AnalyzerPlugin{{Name: "Classifier", Path: "/bin/true"}},
+		},
+		{
+			loc:  exam.Here(),
+			name: "Duplicate name",
+			plugins: []internal.AnalyzerPlugin{
+				{Name: "classifier", Path: "/bin/true"},
+				{Name: "classifier", Path: "/bin/false"},
+			},
+		},
+		{
+			loc:     exam.Here(),
+			name:    "No path",
+			plugins: []internal.AnalyzerPlugin{{Name: "classifier"}},
+		},
+		{
+			loc:     exam.Here(),
+			name:    "Path and wasm",
+			plugins: []internal.AnalyzerPlugin{{Name: "classifier", Path: "/bin/true", Wasm: "/opt/classifier.wasm"}},
+		},
+		{
+			loc:     exam.Here(),
+			name:    "Path and makemkv",
+			plugins: []internal.AnalyzerPlugin{{Name: "classifier", Path: "/bin/true", MakeMKV: "/usr/bin/makemkvcon"}},
+		},
+		{
+			loc:     exam.Here(),
+			name:    "Too many frames",
+			plugins: []internal.AnalyzerPlugin{{Name: "classifier", Path: "/bin/true", Frames: internal.MaxAnalyzerFrames + 1}},
+		},
+	}
+	for _, tt := range tests {
+		e.Run(tt.name, func(e exam.E) {
+			e.Log("Running test at", tt.loc)
+			_, err := internal.NewAnalyzerPlugins(tt.plugins)
+			exam.Match(e, env, err, match.ErrorIs(internal.ErrInvalidAnalyzers))
+		})
+	}
+}
+
+func TestValidateAnalyzerNames(t *testing.T) {
+	e := exam.New(t)
+	env := deep.NewEnv()
+
+	tests := []struct {
+		loc     exam.Loc
+		name    string
+		names   []string
+		wantErr bool
+	}{
+		{loc: exam.Here(), name: "None", names: nil},
+		{loc: exam.Here(), name: "Valid", names: []string{"classifier", "scene_count"}},
+		{loc: exam.Here(), name: "Invalid", names: []string{"../classifier"}, wantErr: true},
+		{loc: exam.Here(), name: "Duplicate", names: []string{"classifier", "classifier"}, wantErr: true},
+		{loc: exam.Here(), name: "Too many", names: make([]string, internal.MaxAnalyzersPerJob+1), wantErr: true},
+	}
+	for _, tt := range tests {
+		e.Run(tt.name, func(e exam.E) {
+			e.Log("Running test at", tt.loc)
+			err := internal.ValidateAnalyzerNames(tt.names)
+			if tt.wantErr {
+				exam.Match(e, env, err, match.ErrorIs(internal.ErrInvalidAnalyzers))
+				return
+			}
+			exam.Nil(e, env, err)
+		})
+	}
+}
@@ -0,0 +1,305 @@
+package internal
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// backupFormatVersion identifies the layout of backup archives.
+const backupFormatVersion = 1
+
+// backupManifestName is the name of the manifest entry within a backup archive.
+const backupManifestName = "manifest.json"
+
+// maxBackupRowSize bounds the size of a single row when restoring.
+const maxBackupRowSize = 64 << 20
+
+// backupTables lists the service-owned tables included in backups, in the
+// order they must be restored to satisfy foreign keys.
+var backupTables = []string{
+	"river_job",
+	"webhook_batch_item",
+	"uuid_job_mapping",
+	"worker_agent",
+	"webhook_dead_letter",
+	"info_result",
+	"schedules",
+	"scan_catalog",
+	"reprobe_campaigns",
+	"kafka_sink_cursor",
+	"comparisons",
+}
+
+// backupSkippedTables lists the service-owned tables left out of backups on
+// purpose.  They hold caches and webhook delivery throttling state that
+// rebuild themselves: a restored webhook job without its rate limit slot
+// claims a new one, and breakers start closed.
+var backupSkippedTables = []string{
+	"probe_cache",
+	"webhook_breaker",
+	"webhook_rate_limit",
+	"webhook_rate_slot",
+}
+
+// backupSequences lists the serial columns whose sequences must be advanced
+// after a restore so that newly inserted rows don't collide with restored ones.
+var backupSequences = map[string]string{
+	"river_job":           "id",
+	"webhook_dead_letter": "id",
+}
+
+var ErrRestoreTargetNotEmpty = errors.New("restore target is not empty")
+
+// BackupManifest describes the contents of a backup archive.
+type BackupManifest struct {
+	FormatVersion    int            `json:"format_version"`
+	CreatedAt        time.Time      `json:"created_at"`
+	MigrationVersion int64          `json:"migration_version"`
+	Tables           map[string]int `json:"tables"`
+}
+
+// Backup writes every service-owned table to w as a gzipped tar archive.
+// Each table is stored as newline-delimited JSON, one object per row.
+// The export runs in a single repeatable-read transaction so that the
+// archive is a consistent snapshot.  The manifest and archive entries are
+// stamped with now.
+func Backup(ctx context.Context, pool *pgxpool.Pool, w io.Writer, now time.Time) (*BackupManifest, error) {
+	tx, err := pool.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.RepeatableRead, AccessMode: pgx.ReadOnly})
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	manifest := &BackupManifest{
+		FormatVersion: backupFormatVersion,
+		CreatedAt:     now.UTC(),
+		Tables:        make(map[string]int, len(backupTables)),
+	}
+	if err := tx.QueryRow(ctx, "SELECT version FROM schema_migrations").Scan(&manifest.MigrationVersion); err != nil {
+		return nil, fmt.Errorf("failed to read migration version: %w", err)
+	}
+
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+
+	for _, table := range backupTables {
+		count, err := backupTable(ctx, tx, tw, table, now)
+		if err != nil {
+			return nil, err
+		}
+		manifest.Tables[table] = count
+	}
+
+	manifestJSON, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal manifest: %w", err)
+	}
+	if err := writeTarEntry(tw, backupManifestName, int64(len(manifestJSON)), bytes.NewReader(manifestJSON), now); err != nil {
+		return nil, err
+	}
+
+	if err := tw.Close(); err != nil {
+		return nil, fmt.Errorf("failed to finish archive: %w", err)
+	}
+	if err := gz.Close(); err != nil {
+		return nil, fmt.Errorf("failed to finish archive: %w", err)
+	}
+	return manifest, nil
+}
+
+// Restore loads a backup archive written by Backup into the database.
+// The schema must already be migrated and every backed-up table must be empty.
+// All rows are restored in a single transaction.
+func Restore(ctx context.Context, pool *pgxpool.Pool, r io.Reader) (*BackupManifest, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open archive: %w", err)
+	}
+	defer gz.Close()
+
+	tx, err := pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	for _, table := range backupTables {
+		var exists bool
+		if err := tx.QueryRow(ctx, fmt.Sprintf("SELECT EXISTS (SELECT 1 FROM %s)", pgx.Identifier{table}.Sanitize())).Scan(&exists); err != nil {
+			return nil, fmt.Errorf("failed to check table %s: %w", table, err)
+		}
+		if exists {
+			return nil, fmt.Errorf("%w: table %s has rows", ErrRestoreTargetNotEmpty, table)
+		}
+	}
+
+	// Tables are written in restore order, so entries can be streamed.
+	var manifest *BackupManifest
+	restored := make(map[string]int, len(backupTables))
+	next := 0
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("failed to read archive: %w", err)
+		}
+
+		if hdr.Name == backupManifestName {
+			manifest = &BackupManifest{}
+			if err := json.NewDecoder(tr).Decode(manifest); err != nil {
+				return nil, fmt.Errorf("failed to parse manifest: %w", err)
+			}
+			continue
+		}
+
+		if next >= len(backupTables) || hdr.Name != backupTables[next]+".jsonl" {
+			return nil, fmt.Errorf("unexpected archive entry %q", hdr.Name)
+		}
+		table := backupTables[next]
+		next++
+		count, err := importTable(ctx, tx, table, tr)
+		if err != nil {
+			return nil, err
+		}
+		restored[table] = count
+	}
+
+	if manifest == nil {
+		return nil, fmt.Errorf("archive has no %s", backupManifestName)
+	}
+	if manifest.FormatVersion != backupFormatVersion {
+		return nil, fmt.Errorf("unsupported backup format version %d", manifest.FormatVersion)
+	}
+	for table, want := range manifest.Tables {
+		if got := restored[table]; got != want {
+			return nil, fmt.Errorf("table %s: restored %d rows but manifest lists %d", table, got, want)
+		}
+	}
+
+	for table, column := range backupSequences {
+		query := fmt.Sprintf(
+			"SELECT setval(pg_get_serial_sequence($1, $2), COALESCE(max(%s), 0) + 1, false) FROM %s",
+			pgx.Identifier{column}.Sanitize(), pgx.Identifier{table}.Sanitize())
+		if _, err := tx.Exec(ctx, query, table, column); err != nil {
+			return nil, fmt.Errorf("failed to reset sequence for %s: %w", table, err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return manifest, nil
+}
+
+// backupTable exports table into a tar entry.  Rows are spooled to a
+// temporary file first since tar entries must declare their size up front.
+func backupTable(ctx context.Context, tx pgx.Tx, tw *tar.Writer, table string, now time.Time) (int, error) {
+	spool, err := os.CreateTemp("", "vi-backup-*.jsonl")
+	if err != nil {
+		return 0, fmt.Errorf("failed to create spool file: %w", err)
+	}
+	defer os.Remove(spool.Name())
+	defer spool.Close()
+
+	count, err := exportTable(ctx, tx, table, spool)
+	if err != nil {
+		return 0, err
+	}
+	size, err := spool.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return 0, fmt.Errorf("failed to size spool file: %w", err)
+	}
+	if _, err := spool.Seek(0, io.SeekStart); err != nil {
+		return 0, fmt.Errorf("failed to rewind spool file: %w", err)
+	}
+	if err := writeTarEntry(tw, table+".jsonl", size, spool, now); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// exportTable writes every row of table to w as newline-delimited JSON.
+func exportTable(ctx context.Context, tx pgx.Tx, table string, w io.Writer) (int, error) {
+	query := fmt.Sprintf("SELECT row_to_json(t) FROM %s t", pgx.Identifier{table}.Sanitize())
+	rows, err := tx.Query(ctx, query)
+	if err != nil {
+		return 0, fmt.Errorf("failed to export table %s: %w", table, err)
+	}
+	defer rows.Close()
+
+	bw := bufio.NewWriter(w)
+	count := 0
+	for rows.Next() {
+		var row []byte
+		if err := rows.Scan(&row); err != nil {
+			return 0, fmt.Errorf("failed to export table %s: %w", table, err)
+		}
+		if _, err := bw.Write(append(row, '\n')); err != nil {
+			return 0, fmt.Errorf("failed to export table %s: %w", table, err)
+		}
+		count++
+	}
+	if err := rows.Err(); err != nil {
+		return 0, fmt.Errorf("failed to export table %s: %w", table, err)
+	}
+	if err := bw.Flush(); err != nil {
+		return 0, fmt.Errorf("failed to export table %s: %w", table, err)
+	}
+	return count, nil
+}
+
+// importTable inserts each newline-delimited JSON row read from r into table.
+// Columns missing from a row, for example because the backup predates a
+// migration, are set to NULL.
+func importTable(ctx context.Context, tx pgx.Tx, table string, r io.Reader) (int, error) {
+	ident := pgx.Identifier{table}.Sanitize()
+	query := fmt.Sprintf("INSERT INTO %s SELECT * FROM json_populate_record(NULL::%s, $1::json)", ident, ident)
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64<<10), maxBackupRowSize)
+	count := 0
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+		if _, err := tx.Exec(ctx, query, string(line)); err != nil {
+			return 0, fmt.Errorf("failed to restore row %d of table %s: %w", count+1, table, err)
+		}
+		count++
+	}
+	if err := scanner.Err(); err != nil {
+		return 0, fmt.Errorf("failed to read table %s: %w", table, err)
+	}
+	return count, nil
+}
+
+func writeTarEntry(tw *tar.Writer, name string, size int64, r io.Reader, modTime time.Time) error {
+	hdr := &tar.Header{
+		Name:    name,
+		Mode:    0o644,
+		Size:    size,
+		ModTime: modTime,
+	}
+	if err := tw.WriteHeader(hdr); err != nil {
+		return fmt.Errorf("failed to write archive entry %s: %w", name, err)
+	}
+	if _, err := io.Copy(tw, r); err != nil {
+		return fmt.Errorf("failed to write archive entry %s: %w", name, err)
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+package internal_test
+
+import (
+	"io/fs"
+	"regexp"
+	"slices"
+	"testing"
+
+	"github.com/krelinga/go-libs/deep"
+	"github.com/krelinga/go-libs/exam"
+	"github.com/krelinga/video-info/internal"
+)
+
+var (
+	createTableRE = regexp.MustCompile(`CREATE TABLE (\w+) \(`)
+	referencesRE  = regexp.MustCompile(`REFERENCES (\w+)\(`)
+)
+
+func TestBackupTables(t *testing.T) {
+	e := exam.New(t)
+	env := deep.NewEnv()
+
+	// Each migration creating a table is matched with the tables it references.
+	references := map[string][]string{}
+	files, err := fs.Glob(internal.MigrationsFS, "migrations/*.up.sql")
+	exam.Nil(e, env, err).Log("failed to list migrations")
+	for _, name := range files {
+		sql, err := fs.ReadFile(internal.MigrationsFS, name)
+		exam.Nil(e, env, err).Log("failed to read", name)
+		for _, stmt := range regexp.MustCompile(`;\s*`).Split(string(sql), -1) {
+			m := createTableRE.FindStringSubmatch(stmt)
+			if m == nil {
+				continue
+			}
+			references[m[1]] = nil
+			for _, ref := range referencesRE.FindAllStringSubmatch(stmt, -1) {
+				references[m[1]] = append(references[m[1]], ref[1])
+			}
+		}
+	}
+
+	e.Run("Every table is backed up or skipped on purpose", func(e exam.E) {
+		for table := range references {
+			backedUp := slices.Contains(internal.BackupTables, table)
+			skipped := slices.Contains(internal.BackupSkippedTables, table)
+			exam.Equal(e, env, true, backedUp != skipped).Log(table)
+		}
+	})
+
+	e.Run("Batched webhook items are backed up", func(e exam.E) {
+		exam.Equal(e, env, true, slices.Contains(internal.BackupTables, "webhook_batch_item"))
+	})
+
+	e.Run("Referenced tables are restored first", func(e exam.E) {
+		for i, table := range internal.BackupTables {
+			for _, ref := range references[table] {
+				exam.Equal(e, env, true, slices.Contains(internal.BackupTables[:i], ref)).Log(table, "references", ref)
+			}
+		}
+	})
+}
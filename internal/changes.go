@@ -0,0 +1,195 @@
+package internal
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strconv"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/krelinga/video-info/virest"
+	"github.com/riverqueue/river/rivertype"
+)
+
+// ResultChanges compares a result with the previous result for the same
+// video path.
+type ResultChanges struct {
+	// PreviousUUID identifies the info job that produced the previous result.
+	PreviousUUID uuid.UUID `json:"previous_uuid"`
+
+	// ChangedFields names the top-level MediaInfo fields that differ, in
+	// alphabetical order.  It is empty if nothing changed.
+	ChangedFields []string `json:"changed_fields"`
+}
+
+// ChangedFields returns the names of the top-level MediaInfo fields that
+// differ between two results, in alphabetical order.
+func ChangedFields(previous, current *InfoJobResult) ([]string, error) {
+	previousFields, err := mediaInfoFields(previous)
+	if err != nil {
+		return nil, err
+	}
+	currentFields, err := mediaInfoFields(current)
+	if err != nil {
+		return nil, err
+	}
+	changed := []string{}
+	for name, value := range currentFields {
+		if !bytes.Equal(value, previousFields[name]) {
+			changed = append(changed, name)
+		}
+	}
+	for name := range previousFields {
+		if _, ok := currentFields[name]; !ok {
+			changed = append(changed, name)
+		}
+	}
+	slices.Sort(changed)
+	return changed, nil
+}
+
+// mediaInfoFields returns the encoded top-level fields of the REST form of
+// result, keyed by their JSON names.
+func mediaInfoFields(result *InfoJobResult) (map[string]json.RawMessage, error) {
+	encoded, err := json.Marshal(result.RESTMediaInfo())
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal result: %w", err)
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(encoded, &fields); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal result: %w", err)
+	}
+	return fields, nil
+}
+
+// CompareWithPreviousResult compares result, produced by the info job with
+// ID jobID, with the most recent result for the same video path.  It returns
+// nil if the path has no earlier result.
+func CompareWithPreviousResult(ctx context.Context, tx pgx.Tx, jobID int64, jobArgs InfoJobArgs, result *InfoJobResult) (*ResultChanges, error) {
+	var (
+		previousUUID uuid.UUID
+		output       []byte
+	)
+	err := tx.QueryRow(ctx, `
+		SELECT args->>'uuid', metadata->$4 FROM river_job
+		WHERE kind = $1 AND state = 'completed' AND args->>'path' = $2 AND id <> $3
+			AND metadata->$4->'result' IS NOT NULL
+		ORDER BY finalized_at DESC, id DESC
+		LIMIT 1`,
+		jobArgs.Kind(), jobArgs.Path, jobID, rivertype.MetadataKeyOutput).Scan(&previousUUID, &output)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to look up previous result: %w", err)
+	}
+
+	var previous InfoJobStatus
+	if err := json.Unmarshal(output, &previous); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal previous result: %w", err)
+	}
+	changed, err := ChangedFields(previous.Result, result)
+	if err != nil {
+		return nil, err
+	}
+	return &ResultChanges{PreviousUUID: previousUUID, ChangedFields: changed}, nil
+}
+
+// Kinds of change reported by DiffMediaInfo.
+const (
+	ResultAdded   = "added"
+	ResultRemoved = "removed"
+	ResultChanged = "changed"
+)
+
+// DiffMediaInfo returns the differences between two results, comparing
+// their JSON forms field by field and lists position by position.  Paths
+// name fields by their JSON names, such as "audioTracks[1].codecName".
+func DiffMediaInfo(from, to *virest.MediaInfo) ([]virest.ResultDifference, error) {
+	fromValue, err := jsonValue(from)
+	if err != nil {
+		return nil, err
+	}
+	toValue, err := jsonValue(to)
+	if err != nil {
+		return nil, err
+	}
+	differences := []virest.ResultDifference{}
+	diffJSONValues("", fromValue, toValue, &differences)
+	return differences, nil
+}
+
+// jsonValue returns v as decoded from its JSON form, keeping numbers as
+// written.
+func jsonValue(v any) (any, error) {
+	encoded, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal result: %w", err)
+	}
+	decoder := json.NewDecoder(bytes.NewReader(encoded))
+	decoder.UseNumber()
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal result: %w", err)
+	}
+	return value, nil
+}
+
+// diffJSONValues appends the differences between two decoded JSON values at
+// path to differences.
+func diffJSONValues(path string, from, to any, differences *[]virest.ResultDifference) {
+	fromObject, fromIsObject := from.(map[string]any)
+	toObject, toIsObject := to.(map[string]any)
+	if fromIsObject && toIsObject {
+		names := slices.Collect(maps.Keys(fromObject))
+		for name := range toObject {
+			if _, ok := fromObject[name]; !ok {
+				names = append(names, name)
+			}
+		}
+		slices.Sort(names)
+		for _, name := range names {
+			fieldPath := name
+			if path != "" {
+				fieldPath = path + "." + name
+			}
+			fromField, inFrom := fromObject[name]
+			toField, inTo := toObject[name]
+			switch {
+			case !inFrom:
+				*differences = append(*differences, virest.ResultDifference{Path: fieldPath, Change: ResultAdded, To: toField})
+			case !inTo:
+				*differences = append(*differences, virest.ResultDifference{Path: fieldPath, Change: ResultRemoved, From: fromField})
+			default:
+				diffJSONValues(fieldPath, fromField, toField, differences)
+			}
+		}
+		return
+	}
+
+	fromList, fromIsList := from.([]any)
+	toList, toIsList := to.([]any)
+	if fromIsList && toIsList {
+		for i := range max(len(fromList), len(toList)) {
+			itemPath := path + "[" + strconv.Itoa(i) + "]"
+			switch {
+			case i >= len(fromList):
+				*differences = append(*differences, virest.ResultDifference{Path: itemPath, Change: ResultAdded, To: toList[i]})
+			case i >= len(toList):
+				*differences = append(*differences, virest.ResultDifference{Path: itemPath, Change: ResultRemoved, From: fromList[i]})
+			default:
+				diffJSONValues(itemPath, fromList[i], toList[i], differences)
+			}
+		}
+		return
+	}
+
+	if !reflect.DeepEqual(from, to) {
+		*differences = append(*differences, virest.ResultDifference{Path: path, Change: ResultChanged, From: from, To: to})
+	}
+}
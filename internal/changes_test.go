@@ -0,0 +1,130 @@
+package internal_test
+
+import (
+	"testing"
+
+	"github.com/krelinga/go-libs/deep"
+	"github.com/krelinga/go-libs/exam"
+	"github.com/krelinga/video-info/internal"
+	"github.com/krelinga/video-info/virest"
+)
+
+func TestChangedFields(t *testing.T) {
+	e := exam.New(t)
+	env := deep.NewEnv()
+
+	result := func(duration float64, subtitles ...internal.SubtitleTrack) *internal.InfoJobResult {
+		return &internal.InfoJobResult{
+			MediaKind:               virest.Video,
+			DurationSeconds:         duration,
+			ChapterDurationsSeconds: []float64{duration},
+			VideoStreams:            []internal.VideoStream{{CodecName: "h264", Width: 1920, Height: 1080}},
+			SubtitleTracks:          subtitles,
+		}
+	}
+	tests := []struct {
+		loc      exam.Loc
+		name     string
+		previous *internal.InfoJobResult
+		current  *internal.InfoJobResult
+		want     []string
+	}{
+		{
+			loc:      exam.Here(),
+			name:     "Unchanged",
+			previous: result(60),
+			current:  result(60),
+			want:     []string{},
+		},
+		{
+			loc:      exam.Here(),
+			name:     "Changed duration",
+			previous: result(60),
+			current:  result(61),
+			want:     []string{"chapterDurationsSeconds", "totalDurationSeconds"},
+		},
+		{
+			loc:      exam.Here(),
+			name:     "Added subtitles",
+			previous: result(60),
+			current:  result(60, internal.SubtitleTrack{CodecName: "subrip"}),
+			want:     []string{"subtitleTracks"},
+		},
+		{
+			loc:      exam.Here(),
+			name:     "Removed subtitles",
+			previous: result(60, internal.SubtitleTrack{CodecName: "subrip"}),
+			current:  result(60),
+			want:     []string{"subtitleTracks"},
+		},
+	}
+	for _, tt := range tests {
+		e.Run(tt.name, func(e exam.E) {
+			e.Log("Running test at", tt.loc)
+			got, err := internal.ChangedFields(tt.previous, tt.current)
+			exam.Nil(e, env, err)
+			exam.Equal(e, env, tt.want, got)
+		})
+	}
+}
+
+func TestDiffMediaInfo(t *testing.T) {
+	e := exam.New(t)
+	env := deep.NewEnv()
+
+	result := func(codecs ...string) *virest.MediaInfo {
+		info := &virest.MediaInfo{MediaKind: virest.Video, TotalDurationSeconds: 60}
+		for _, codec := range codecs {
+			info.AudioTracks = append(info.AudioTracks, virest.AudioTrack{CodecName: codec, Channels: 2})
+		}
+		return info
+	}
+	tests := []struct {
+		loc  exam.Loc
+		name string
+		from *virest.MediaInfo
+		to   *virest.MediaInfo
+		want []string
+	}{
+		{
+			loc:  exam.Here(),
+			name: "Unchanged",
+			from: result("aac"),
+			to:   result("aac"),
+			want: []string{},
+		},
+		{
+			loc:  exam.Here(),
+			name: "Changed codec",
+			from: result("aac", "ac3"),
+			to:   result("aac", "eac3"),
+			want: []string{"changed audioTracks[1].codecName"},
+		},
+		{
+			loc:  exam.Here(),
+			name: "Removed track",
+			from: result("aac", "ac3"),
+			to:   result("aac"),
+			want: []string{"removed audioTracks[1]"},
+		},
+		{
+			loc:  exam.Here(),
+			name: "Added tracks",
+			from: result(),
+			to:   result("aac"),
+			want: []string{"added audioTracks"},
+		},
+	}
+	for _, tt := range tests {
+		e.Run(tt.name, func(e exam.E) {
+			e.Log("Running test at", tt.loc)
+			differences, err := internal.DiffMediaInfo(tt.from, tt.to)
+			exam.Nil(e, env, err)
+			got := make([]string, len(differences))
+			for i, d := range differences {
+				got[i] = d.Change + " " + d.Path
+			}
+			exam.Equal(e, env, tt.want, got)
+		})
+	}
+}
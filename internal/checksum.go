@@ -0,0 +1,111 @@
+package internal
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"io"
+	"os"
+
+	"github.com/cespare/xxhash/v2"
+	"github.com/krelinga/video-info/virest"
+)
+
+// partialChecksumChunk is how much of each end of a file a partial checksum
+// covers.
+const partialChecksumChunk = 1 << 20
+
+// FileChecksum is a checksum of a file's contents.
+type FileChecksum struct {
+	Algorithm virest.ChecksumAlgorithm `json:"algorithm"`
+
+	// Value is the checksum in lowercase hexadecimal.
+	Value string `json:"value"`
+}
+
+// ComputeChecksum computes a checksum of the file at path, reading it once
+// and stopping early if ctx is cancelled.
+func ComputeChecksum(ctx context.Context, path string, algorithm virest.ChecksumAlgorithm) (*FileChecksum, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open file: %w", err)
+	}
+	defer f.Close()
+
+	var h hash.Hash
+	switch algorithm {
+	case virest.Xxhash64:
+		h = xxhash.New()
+		err = copyContext(ctx, h, f)
+	case virest.Sha256:
+		h = sha256.New()
+		err = copyContext(ctx, h, f)
+	case virest.Partial:
+		h = xxhash.New()
+		err = hashEnds(ctx, h, f)
+	default:
+		return nil, fmt.Errorf("unsupported checksum algorithm %q", algorithm)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &FileChecksum{Algorithm: algorithm, Value: hex.EncodeToString(h.Sum(nil))}, nil
+}
+
+// hashEnds hashes the size of f followed by its first and last
+// partialChecksumChunk bytes, or all of it if it is no bigger than both.
+func hashEnds(ctx context.Context, h hash.Hash, f *os.File) error {
+	info, err := f.Stat()
+	if err != nil {
+		return fmt.Errorf("failed to stat file: %w", err)
+	}
+	size := info.Size()
+	binary.Write(h, binary.BigEndian, size)
+	if size <= 2*partialChecksumChunk {
+		return copyContext(ctx, h, f)
+	}
+	if err := copyContext(ctx, h, io.NewSectionReader(f, 0, partialChecksumChunk)); err != nil {
+		return err
+	}
+	return copyContext(ctx, h, io.NewSectionReader(f, size-partialChecksumChunk, partialChecksumChunk))
+}
+
+// copyContext copies r to w, checking between reads whether ctx is done.
+func copyContext(ctx context.Context, w io.Writer, r io.Reader) error {
+	buf := make([]byte, 1<<20)
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		n, err := r.Read(buf)
+		if n > 0 {
+			w.Write(buf[:n])
+		}
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return fmt.Errorf("failed to read file: %w", err)
+		}
+	}
+}
+
+// RESTFileChecksum converts the checksum to its REST form, or nil if c is
+// nil.
+func (c *FileChecksum) RESTFileChecksum() *virest.FileChecksum {
+	if c == nil {
+		return nil
+	}
+	return &virest.FileChecksum{Algorithm: c.Algorithm, Value: c.Value}
+}
+
+// NewFileChecksumFromREST converts a REST FileChecksum, or returns nil if v
+// is nil.
+func NewFileChecksumFromREST(v *virest.FileChecksum) *FileChecksum {
+	if v == nil {
+		return nil
+	}
+	return &FileChecksum{Algorithm: v.Algorithm, Value: v.Value}
+}
@@ -0,0 +1,68 @@
+package internal_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/krelinga/go-libs/deep"
+	"github.com/krelinga/go-libs/exam"
+	"github.com/krelinga/go-libs/match"
+	"github.com/krelinga/video-info/internal"
+	"github.com/krelinga/video-info/virest"
+)
+
+func TestComputeChecksum(t *testing.T) {
+	e := exam.New(t)
+	env := deep.NewEnv()
+
+	dir := t.TempDir()
+	small := filepath.Join(dir, "small")
+	exam.Nil(e, env, os.WriteFile(small, []byte("hello"), 0o600))
+
+	tests := []struct {
+		loc       exam.Loc
+		algorithm virest.ChecksumAlgorithm
+		want      string
+	}{
+		{loc: exam.Here(), algorithm: virest.Xxhash64, want: "26c7827d889f6da3"},
+		{loc: exam.Here(), algorithm: virest.Sha256, want: "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824"},
+	}
+	for _, tt := range tests {
+		e.Run(string(tt.algorithm), func(e exam.E) {
+			e.Log("Running test at", tt.loc)
+			got, err := internal.ComputeChecksum(context.Background(), small, tt.algorithm)
+			exam.Nil(e, env, err)
+			exam.Equal(e, env, &internal.FileChecksum{Algorithm: tt.algorithm, Value: tt.want}, got)
+		})
+	}
+
+	e.Run("Partial covers the ends only", func(e exam.E) {
+		content := make([]byte, 3<<20)
+		original := filepath.Join(dir, "original")
+		exam.Nil(e, env, os.WriteFile(original, content, 0o600))
+		content[len(content)/2] = 1
+		middle := filepath.Join(dir, "middle")
+		exam.Nil(e, env, os.WriteFile(middle, content, 0o600))
+		content[len(content)-1] = 1
+		end := filepath.Join(dir, "end")
+		exam.Nil(e, env, os.WriteFile(end, content, 0o600))
+
+		checksum := func(path string, algorithm virest.ChecksumAlgorithm) string {
+			c, err := internal.ComputeChecksum(context.Background(), path, algorithm)
+			exam.Nil(e, env, err)
+			return c.Value
+		}
+		exam.Equal(e, env, checksum(original, virest.Partial), checksum(middle, virest.Partial))
+		exam.Equal(e, env, false, checksum(original, virest.Partial) == checksum(end, virest.Partial))
+		exam.Equal(e, env, false, checksum(original, virest.Xxhash64) == checksum(middle, virest.Xxhash64))
+	})
+
+	e.Run("Cancelled", func(e exam.E) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		_, err := internal.ComputeChecksum(ctx, small, virest.Sha256)
+		exam.Match(e, env, err, match.ErrorIs(context.Canceled))
+	})
+}
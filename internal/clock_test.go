@@ -0,0 +1,57 @@
+package internal_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/krelinga/go-libs/deep"
+	"github.com/krelinga/go-libs/exam"
+	"github.com/krelinga/video-info/internal"
+)
+
+func TestFakeClock(t *testing.T) {
+	e := exam.New(t)
+	env := deep.NewEnv()
+	start := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	e.Run("Now only moves when advanced", func(e exam.E) {
+		clock := internal.NewFakeClock(start)
+		exam.Equal(e, env, true, clock.Now().Equal(start))
+		clock.Advance(time.Minute)
+		exam.Equal(e, env, true, clock.Now().Equal(start.Add(time.Minute)))
+	})
+
+	e.Run("After fires once due", func(e exam.E) {
+		clock := internal.NewFakeClock(start)
+		ch := clock.After(time.Minute)
+		clock.Advance(59 * time.Second)
+		select {
+		case <-ch:
+			e.Fatal("After fired early")
+		default:
+		}
+		clock.Advance(time.Second)
+		select {
+		case got := <-ch:
+			exam.Equal(e, env, true, got.Equal(start.Add(time.Minute)))
+		default:
+			e.Fatal("After did not fire")
+		}
+	})
+
+	e.Run("After without a delay fires immediately", func(e exam.E) {
+		clock := internal.NewFakeClock(start)
+		select {
+		case got := <-clock.After(0):
+			exam.Equal(e, env, true, got.Equal(start))
+		default:
+			e.Fatal("After did not fire")
+		}
+	})
+
+	e.Run("Nil clock defaults to system time", func(e exam.E) {
+		exam.Equal(e, env, internal.SystemClock, internal.OrSystemClock(nil))
+		clock := internal.NewFakeClock(start)
+		exam.Equal(e, env, true, internal.OrSystemClock(clock).Now().Equal(start))
+	})
+}
@@ -1,35 +1,347 @@
 package internal
 
 import (
+	"crypto/ed25519"
+	"encoding/base64"
 	"errors"
 	"fmt"
+	"net"
+	"net/url"
 	"os"
 	"strconv"
+	"strings"
+	"time"
+
+	"github.com/riverqueue/river"
 )
 
 var (
-	ErrPanicEnvNotSet = errors.New("environment variable not set")
-	ErrPanicEnvNotInt = errors.New("environment variable is not an integer")
+	ErrPanicEnvNotSet  = errors.New("environment variable not set")
+	ErrPanicEnvNotInt  = errors.New("environment variable is not an integer")
+	ErrPanicEnvNotURL  = errors.New("environment variable is not a valid URL")
+	ErrPanicEnvNotBool = errors.New("environment variable is not a boolean")
+	ErrPanicEnvNotKey  = errors.New("environment variable is not a valid key")
+
+	ErrPanicEnvNotHWAccel   = errors.New("environment variable is not a supported hardware acceleration method")
+	ErrPanicEnvNotQueueList = errors.New("environment variable is not a valid queue list")
+	ErrPanicEnvNotRootList  = errors.New("environment variable is not a valid list of absolute paths")
+	ErrPanicEnvNotSchemes   = errors.New("environment variable is not a valid list of URL schemes")
+	ErrPanicEnvNotRateList  = errors.New("environment variable is not a valid list of rate limits")
 )
 
 const (
-	EnvServerPort       = "VI_SERVER_PORT"
-	EnvDatabaseHost     = "VI_DB_HOST"
-	EnvDatabasePort     = "VI_DB_PORT"
-	EnvDatabaseUser     = "VI_DB_USER"
-	EnvDatabasePassword = "VI_DB_PASSWORD"
-	EnvDatabaseName     = "VI_DB_NAME"
+	EnvServerPort           = "VI_SERVER_PORT"
+	EnvDatabaseHost         = "VI_DB_HOST"
+	EnvDatabasePort         = "VI_DB_PORT"
+	EnvDatabaseUser         = "VI_DB_USER"
+	EnvDatabasePassword     = "VI_DB_PASSWORD"
+	EnvDatabaseName         = "VI_DB_NAME"
+	EnvOutboundProxy        = "VI_OUTBOUND_PROXY"
+	EnvDatabaseReplicaHost  = "VI_DB_REPLICA_HOST"
+	EnvDatabaseReplicaPort  = "VI_DB_REPLICA_PORT"
+	EnvWebhookAllowedHosts  = "VI_WEBHOOK_ALLOWED_HOSTS"
+	EnvWebhookDenyPrivate   = "VI_WEBHOOK_DENY_PRIVATE"
+	EnvWorkerToken          = "VI_WORKER_TOKEN"
+	EnvServerURL            = "VI_SERVER_URL"
+	EnvWorkerMounts         = "VI_WORKER_MOUNTS"
+	EnvWorkerCapacity       = "VI_WORKER_CAPACITY"
+	EnvWorkerGPUCapacity    = "VI_WORKER_GPU_CAPACITY"
+	EnvSigningKey           = "VI_SIGNING_KEY"
+	EnvSecretsKeys          = "VI_SECRETS_KEYS"
+	EnvMaxFileSize          = "VI_MAX_FILE_SIZE"
+	EnvDeepAnalysisMaxSize  = "VI_DEEP_ANALYSIS_MAX_SIZE"
+	EnvAnalysisParallelism  = "VI_ANALYSIS_PARALLELISM"
+	EnvFFprobePath          = "VI_FFPROBE_PATH"
+	EnvFFmpegPath           = "VI_FFMPEG_PATH"
+	EnvHWAccel              = "VI_HWACCEL"
+	EnvHWAccelDevice        = "VI_HWACCEL_DEVICE"
+	EnvWorkerCacheDir       = "VI_WORKER_CACHE_DIR"
+	EnvProbeAudio           = "VI_PROBE_AUDIO"
+	EnvPresetRules          = "VI_PRESET_RULES"
+	EnvWebhookMaxAttempts   = "VI_WEBHOOK_MAX_ATTEMPTS"
+	EnvWebhookBackoff       = "VI_WEBHOOK_BACKOFF"
+	EnvWebhookTimeout       = "VI_WEBHOOK_TIMEOUT"
+	EnvBreakerThreshold     = "VI_WEBHOOK_BREAKER_THRESHOLD"
+	EnvBreakerCooldown      = "VI_WEBHOOK_BREAKER_COOLDOWN"
+	EnvWebhookRateLimits    = "VI_WEBHOOK_RATE_LIMITS"
+	EnvPriorityAging        = "VI_PRIORITY_AGING"
+	EnvHealthPort           = "VI_HEALTH_PORT"
+	EnvPostProbeHook        = "VI_POST_PROBE_HOOK"
+	EnvPostProbeHookTimeout = "VI_POST_PROBE_HOOK_TIMEOUT"
+	EnvAnalyzerPlugins      = "VI_ANALYZER_PLUGINS"
+	EnvResultTTL            = "VI_RESULT_TTL"
+	EnvStatusCacheSize      = "VI_STATUS_CACHE_SIZE"
+	EnvWorkerQueues         = "VI_WORKER_QUEUES"
+	EnvJobTimeout           = "VI_JOB_TIMEOUT"
+	EnvMaxJobTimeout        = "VI_MAX_JOB_TIMEOUT"
+	EnvAllowedRoots         = "VI_ALLOWED_ROOTS"
+	EnvURLInputSchemes      = "VI_URL_INPUT_SCHEMES"
+	EnvURLInputTimeout      = "VI_URL_INPUT_TIMEOUT"
+	EnvCanaryInterval       = "VI_CANARY_INTERVAL"
+	EnvCanaryMaxLatency     = "VI_CANARY_MAX_LATENCY"
+	EnvCanaryWebhookURI     = "VI_CANARY_WEBHOOK_URI"
+	EnvFetchDir             = "VI_FETCH_DIR"
+	EnvSFTPUser             = "VI_SFTP_USER"
+	EnvSFTPPassword         = "VI_SFTP_PASSWORD"
+	EnvSFTPKeyFile          = "VI_SFTP_KEY_FILE"
+	EnvSFTPKnownHosts       = "VI_SFTP_KNOWN_HOSTS"
+	EnvSMBUser              = "VI_SMB_USER"
+	EnvSMBPassword          = "VI_SMB_PASSWORD"
+	EnvSMBDomain            = "VI_SMB_DOMAIN"
+	EnvMaxQueueDepth        = "VI_MAX_QUEUE_DEPTH"
+	EnvWebSocketOrigins     = "VI_WS_ORIGINS"
+	EnvNATSURL              = "VI_NATS_URL"
+	EnvCanaryQueue          = "VI_CANARY_QUEUE"
+	EnvCanaryPercent        = "VI_CANARY_PERCENT"
+	EnvKafkaRESTURL         = "VI_KAFKA_REST_URL"
+	EnvKafkaTopic           = "VI_KAFKA_TOPIC"
 )
 
 // ServerConfig contains configuration for the HTTP server.
 type ServerConfig struct {
 	Port     int
 	Database *DatabaseConfig
+
+	// ReadReplica, if set, is used for status reads while writes go to
+	// Database.  It shares the user, password and name of Database.
+	ReadReplica *DatabaseConfig
+
+	// WebhookPolicy restricts the webhook URIs accepted at submission time,
+	// and the hosts of URLs accepted in place of video paths.  A nil policy
+	// accepts any http or https URI.
+	WebhookPolicy *URLPolicy
+
+	// WorkerToken is the shared secret pull-mode workers must present.  The
+	// worker API is disabled when it is empty.
+	WorkerToken string
+
+	// SigningKey, if set, signs results reported by pull-mode workers and
+	// is published for verification.  It must match the workers' key.
+	SigningKey ed25519.PrivateKey
+
+	// SecretsKeys, if set, encrypt webhook URIs and tokens before they are
+	// stored.  The first key encrypts; all keys decrypt.
+	SecretsKeys [][]byte
+
+	// StatusCacheSize is the number of job statuses the server keeps in
+	// memory, invalidated through database notifications.  Zero disables
+	// the cache.
+	StatusCacheSize int
+
+	// JobTimeout bounds jobs submitted without a timeout.  Zero leaves them
+	// unbounded.
+	JobTimeout time.Duration
+
+	// MaxJobTimeout, if positive, is the longest timeout a job may request.
+	MaxJobTimeout time.Duration
+
+	// AllowedRoots, if set, are the directories video paths must be under.
+	// Requests for other paths are rejected.
+	AllowedRoots AllowedRoots
+
+	// URLInputSchemes are the schemes of URLs accepted in place of video
+	// paths.  URLs are rejected when it is empty.
+	URLInputSchemes []string
+
+	// MaxQueueDepth, if positive, is the number of info jobs waiting to run
+	// at which new submissions are rejected with 429 Too Many Requests.
+	MaxQueueDepth int
+
+	// WebSocketOrigins are host patterns, such as "ui.example.com" or
+	// "*.example.com", of the other origins whose pages may open job event
+	// WebSockets.  Pages served from the server's own host always may.
+	WebSocketOrigins []string
+
+	// NATSURL, if set, is the NATS server info job lifecycle events are
+	// published to.
+	NATSURL string
+
+	// CanaryQueue, if CanaryPercent is positive, is the queue that
+	// CanaryPercent percent of new jobs bound for the default queue are
+	// routed to instead, so a new worker build working that queue can be
+	// tried on real traffic.
+	CanaryQueue   string
+	CanaryPercent int
 }
 
 // WorkerConfig contains configuration for the worker.
 type WorkerConfig struct {
+	// Database is nil when the worker runs in pull mode.
 	Database *DatabaseConfig
+
+	// ServerURL, if set, puts the worker in pull mode: jobs are claimed from
+	// and reported to the server over HTTP instead of through the database.
+	ServerURL *url.URL
+
+	// WorkerToken authenticates the worker to the server in pull mode.
+	WorkerToken string
+
+	// Mounts lists the directories the worker can read, reported to the
+	// server in pull mode so it only hands out jobs under them.  An empty
+	// list means any path.
+	Mounts []string
+
+	// AllowedRoots, if set, are the directories the worker will probe
+	// files under.  Jobs for other paths fail without touching the file.
+	AllowedRoots AllowedRoots
+
+	// URLInputSchemes are the schemes of URLs the worker will probe in place
+	// of video paths, passing them to ffprobe.  Jobs for URLs fail when it is
+	// empty.
+	URLInputSchemes []string
+
+	// URLInputTimeout, if positive, bounds each network read and write made
+	// while probing a URL, instead of ffprobe's default.
+	URLInputTimeout time.Duration
+
+	// FetchDir, if set, is the local directory where files named by URLs
+	// are copied while analyses that read the whole file run, instead of the
+	// system temporary directory.  SFTP and SMB hold the credentials used to
+	// read the files named by sftp and smb URLs.
+	FetchDir string
+	SFTP     SFTPConfig
+	SMB      SMBConfig
+
+	// Capacity is the number of jobs the worker runs concurrently.
+	Capacity int
+
+	// Queues, if set, maps the queues the worker consumes to the number of
+	// jobs it runs concurrently from each, replacing Capacity.
+	Queues map[string]int
+
+	// GPUCapacity is the number of jobs requiring a GPU the worker runs
+	// concurrently, in addition to Capacity.  Workers without GPU capacity
+	// never run them.
+	GPUCapacity int
+
+	// HealthPort, if positive, is the port on which the worker serves
+	// liveness and readiness probes at /healthz and /readyz.
+	HealthPort int
+
+	// MaxFileSize, if positive, is the size in bytes above which video files
+	// are rejected without being probed.
+	MaxFileSize int64
+
+	// DeepAnalysisMaxSize, if positive, is the size in bytes above which
+	// requested analyses that decode video, such as crop detection, are
+	// skipped with a warning in the result.
+	DeepAnalysisMaxSize int64
+
+	// AnalysisParallelism is the number of a job's analyses that read the
+	// file the worker runs at once.  Values below 1 run them one at a time.
+	AnalysisParallelism int
+
+	// FFprobePath and FFmpegPath override the executables to run.  When
+	// empty, ffprobe and ffmpeg are looked up on the PATH.
+	FFprobePath string
+	FFmpegPath  string
+
+	// HWAccel selects hardware decoding for analyses that decode video, on
+	// the device named by HWAccelDevice if set.
+	HWAccel       HWAccel
+	HWAccelDevice string
+
+	// ProbeAudio allows audio-only files to be probed.  Otherwise they are
+	// rejected as unsupported along with other non-video files.
+	ProbeAudio bool
+
+	// CacheDir, if set, is a local directory where results are cached so
+	// jobs for unchanged files skip probing.
+	CacheDir string
+
+	// PresetRules, if set, is the path of a JSON file of rules mapping
+	// results to suggested encoding presets.
+	PresetRules string
+
+	// PostProbeHook, if set, is the path of an executable run after every
+	// info job with the outcome as JSON on its standard input.  It runs
+	// without a shell, in an empty directory and with only PATH set, and is
+	// killed after PostProbeHookTimeout, or 30 seconds if that is unset.  It
+	// runs as the worker's user, so it must be trusted like the worker.
+	PostProbeHook        string
+	PostProbeHookTimeout time.Duration
+
+	// AnalyzerPlugins, if set, is the path of a JSON file of analyzer
+	// plugins that jobs may select.  Executable plugins and makemkvcon run
+	// like PostProbeHook, and WASI modules are fully sandboxed.
+	AnalyzerPlugins string
+
+	// PriorityAging, if positive, is how long an info job waits before its
+	// priority is raised by one level, and again for every further interval.
+	// Only the worker elected leader by River applies it.
+	PriorityAging time.Duration
+
+	// ResultTTL, if positive, is how long finished info jobs, their stored
+	// results, records of undelivered webhooks, cached results, comparisons
+	// and the batch items of finished webhook jobs are kept.  Only the worker
+	// elected leader by River prunes them.
+	ResultTTL time.Duration
+
+	// CanaryInterval, if positive, is how often the worker probes a bundled
+	// sample and checks its mounts, to catch a broken ffprobe or a dead
+	// mount before jobs do.  A canary fails if the probe takes longer than
+	// CanaryMaxLatency, if positive.  Failures and recoveries are recorded
+	// as metrics and, if CanaryWebhookURI is set, posted to it.
+	CanaryInterval   time.Duration
+	CanaryMaxLatency time.Duration
+	CanaryWebhookURI *url.URL
+
+	// OutboundProxy, if set, is used for all outbound HTTP requests made by
+	// the worker.  When nil, the standard HTTP_PROXY, HTTPS_PROXY and NO_PROXY
+	// environment variables are honored instead.
+	OutboundProxy *url.URL
+
+	// WebhookPolicy restricts the webhook URIs the worker will deliver to,
+	// and the hosts of the URLs it will probe, including those ffprobe is
+	// redirected to.  A nil policy accepts any http or https URI.
+	WebhookPolicy *URLPolicy
+
+	// WebhookRetry is the retry policy for webhook deliveries that don't
+	// set their own.  Backoff and timeout are given in seconds.
+	WebhookRetry WebhookRetryPolicy
+
+	// WebhookBreaker pauses deliveries to webhook targets that keep
+	// failing.  The cooldown is given in seconds.
+	WebhookBreaker WebhookBreakerPolicy
+
+	// WebhookRateLimits caps the rate of deliveries to each webhook target.
+	// Deliveries over the limit wait their turn.
+	WebhookRateLimits WebhookRateLimits
+
+	// SigningKey, if set, signs stored results and webhook payloads.
+	SigningKey ed25519.PrivateKey
+
+	// SecretsKeys decrypt webhook URIs and tokens sealed by the server.
+	SecretsKeys [][]byte
+
+	// NATSURL, if set, is the NATS server info job lifecycle events are
+	// published to.  Pull-mode workers leave publishing to the server.
+	NATSURL string
+	// KafkaRESTURL, if set, is the Kafka REST Proxy every stored result is
+	// published through, to KafkaTopic.
+	KafkaRESTURL *url.URL
+	KafkaTopic   string
+}
+
+// SFTPConfig holds the credentials the worker fetches sftp URLs with.
+type SFTPConfig struct {
+	User     string
+	Password string
+
+	// KeyFile, if set, is the path of a private key to authenticate with,
+	// tried before Password.
+	KeyFile string
+
+	// KnownHosts is the path of a known_hosts file that servers' host keys
+	// are checked against.  Fetches fail when it is unset.
+	KnownHosts string
+}
+
+// SMBConfig holds the credentials the worker fetches smb URLs with.
+type SMBConfig struct {
+	User     string
+	Password string
+	Domain   string
 }
 
 type DatabaseConfig struct {
@@ -57,27 +369,281 @@ func mustGetenvAtoi(key string) int {
 	return value
 }
 
+// getenvURL returns the parsed URL stored in the given environment variable,
+// or nil if the variable is not set.
+func getenvURL(key string) *url.URL {
+	value, ok := os.LookupEnv(key)
+	if !ok || value == "" {
+		return nil
+	}
+	u, err := url.Parse(value)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		panic(fmt.Errorf("%w: %q", ErrPanicEnvNotURL, key))
+	}
+	return u
+}
+
+// getenvAtoi returns the integer stored in the given environment variable,
+// or def if the variable is not set.
+func getenvAtoi(key string, def int) int {
+	if _, ok := os.LookupEnv(key); !ok {
+		return def
+	}
+	return mustGetenvAtoi(key)
+}
+
+// getenvBool returns the boolean stored in the given environment variable,
+// or false if the variable is not set.
+func getenvBool(key string) bool {
+	value, ok := os.LookupEnv(key)
+	if !ok || value == "" {
+		return false
+	}
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		panic(fmt.Errorf("%w: %q", ErrPanicEnvNotBool, key))
+	}
+	return b
+}
+
+// getenvSigningKey returns the Ed25519 key whose base64-encoded seed is
+// stored in the given environment variable, or nil if the variable is not set.
+func getenvSigningKey(key string) ed25519.PrivateKey {
+	value, ok := os.LookupEnv(key)
+	if !ok || value == "" {
+		return nil
+	}
+	seed, err := base64.StdEncoding.DecodeString(value)
+	if err != nil || len(seed) != ed25519.SeedSize {
+		panic(fmt.Errorf("%w: %q", ErrPanicEnvNotKey, key))
+	}
+	return ed25519.NewKeyFromSeed(seed)
+}
+
+// getenvSecretsKeys returns the base64-encoded secrets keys stored as a
+// comma-separated list in the given environment variable, or nil if the
+// variable is not set.
+func getenvSecretsKeys(key string) [][]byte {
+	var keys [][]byte
+	for _, value := range getenvList(key) {
+		k, err := base64.StdEncoding.DecodeString(value)
+		if err != nil || len(k) != SecretKeySize {
+			panic(fmt.Errorf("%w: %q", ErrPanicEnvNotKey, key))
+		}
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+// getenvList returns the comma-separated values stored in the given
+// environment variable, or nil if the variable is not set.
+func getenvList(key string) []string {
+	var values []string
+	for value := range strings.SplitSeq(os.Getenv(key), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+// getenvHWAccel returns the hardware acceleration method stored in the given
+// environment variable, or HWAccelNone if the variable is not set.
+func getenvHWAccel(key string) HWAccel {
+	h, err := ParseHWAccel(os.Getenv(key))
+	if err != nil {
+		panic(fmt.Errorf("%w: %q", ErrPanicEnvNotHWAccel, key))
+	}
+	return h
+}
+
+// QueueConcurrency returns the number of jobs the worker runs concurrently
+// from each queue it consumes, other than the GPU queue.
+func (c *WorkerConfig) QueueConcurrency() map[string]int {
+	if len(c.Queues) > 0 {
+		return c.Queues
+	}
+	return map[string]int{river.QueueDefault: c.Capacity}
+}
+
+// getenvQueues returns the queue list stored in the given environment
+// variable, or nil if the variable is not set.
+func getenvQueues(key string) map[string]int {
+	queues, err := ParseQueueList(os.Getenv(key))
+	if err != nil {
+		panic(fmt.Errorf("%w: %q", ErrPanicEnvNotQueueList, key))
+	}
+	return queues
+}
+
+// getenvAllowedRoots returns the allowed roots stored in the given
+// environment variable, or nil if the variable is not set.
+func getenvAllowedRoots(key string) AllowedRoots {
+	roots, err := ParseAllowedRoots(getenvList(key))
+	if err != nil {
+		panic(fmt.Errorf("%w: %q", ErrPanicEnvNotRootList, key))
+	}
+	return roots
+}
+
+// getenvURLInputSchemes returns the URL schemes stored in the given
+// environment variable, or nil if the variable is not set.
+func getenvURLInputSchemes(key string) []string {
+	schemes, err := ParseURLInputSchemes(getenvList(key))
+	if err != nil {
+		panic(fmt.Errorf("%w: %q", ErrPanicEnvNotSchemes, key))
+	}
+	return schemes
+}
+
+// getenvWebhookRateLimits returns the webhook rate limits stored in the
+// given environment variable, or nil if the variable is not set.
+func getenvWebhookRateLimits(key string) WebhookRateLimits {
+	limits, err := ParseWebhookRateLimits(os.Getenv(key))
+	if err != nil {
+		panic(fmt.Errorf("%w: %q", ErrPanicEnvNotRateList, key))
+	}
+	return limits
+}
+
+// getenvWebhookPolicy builds a URLPolicy for webhook targets, or returns nil
+// if no restrictions are configured.
+func getenvWebhookPolicy() *URLPolicy {
+	allowed := getenvList(EnvWebhookAllowedHosts)
+	denyPrivate := getenvBool(EnvWebhookDenyPrivate)
+	if len(allowed) == 0 && !denyPrivate {
+		return nil
+	}
+
+	policy := &URLPolicy{DenyPrivate: denyPrivate}
+	for _, entry := range allowed {
+		if _, ipNet, err := net.ParseCIDR(entry); err == nil {
+			policy.AllowedNets = append(policy.AllowedNets, ipNet)
+		} else if ip := net.ParseIP(entry); ip != nil {
+			bits := 8 * len(ip.To16())
+			if ip4 := ip.To4(); ip4 != nil {
+				ip, bits = ip4, 32
+			}
+			policy.AllowedNets = append(policy.AllowedNets, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+		} else {
+			policy.AllowedHosts = append(policy.AllowedHosts, entry)
+		}
+	}
+	return policy
+}
+
+func NewDatabaseConfigFromEnv() *DatabaseConfig {
+	return &DatabaseConfig{
+		Host:     mustGetenv(EnvDatabaseHost),
+		Port:     mustGetenvAtoi(EnvDatabasePort),
+		User:     mustGetenv(EnvDatabaseUser),
+		Password: mustGetenv(EnvDatabasePassword),
+		Name:     mustGetenv(EnvDatabaseName),
+	}
+}
+
+// getenvReplicaConfig returns the read replica configuration derived from
+// primary, or nil if no replica is configured.
+func getenvReplicaConfig(primary *DatabaseConfig) *DatabaseConfig {
+	host, ok := os.LookupEnv(EnvDatabaseReplicaHost)
+	if !ok || host == "" {
+		return nil
+	}
+	return &DatabaseConfig{
+		Host:     host,
+		Port:     getenvAtoi(EnvDatabaseReplicaPort, primary.Port),
+		User:     primary.User,
+		Password: primary.Password,
+		Name:     primary.Name,
+	}
+}
+
 func NewServerConfigFromEnv() *ServerConfig {
+	database := NewDatabaseConfigFromEnv()
 	return &ServerConfig{
-		Port: mustGetenvAtoi(EnvServerPort),
-		Database: &DatabaseConfig{
-			Host:     mustGetenv(EnvDatabaseHost),
-			Port:     mustGetenvAtoi(EnvDatabasePort),
-			User:     mustGetenv(EnvDatabaseUser),
-			Password: mustGetenv(EnvDatabasePassword),
-			Name:     mustGetenv(EnvDatabaseName),
-		},
+		Port:             mustGetenvAtoi(EnvServerPort),
+		Database:         database,
+		ReadReplica:      getenvReplicaConfig(database),
+		WebhookPolicy:    getenvWebhookPolicy(),
+		WorkerToken:      os.Getenv(EnvWorkerToken),
+		SigningKey:       getenvSigningKey(EnvSigningKey),
+		SecretsKeys:      getenvSecretsKeys(EnvSecretsKeys),
+		StatusCacheSize:  getenvAtoi(EnvStatusCacheSize, 0),
+		JobTimeout:       time.Duration(getenvAtoi(EnvJobTimeout, 0)) * time.Second,
+		MaxJobTimeout:    time.Duration(getenvAtoi(EnvMaxJobTimeout, 0)) * time.Second,
+		AllowedRoots:     getenvAllowedRoots(EnvAllowedRoots),
+		URLInputSchemes:  getenvURLInputSchemes(EnvURLInputSchemes),
+		MaxQueueDepth:    getenvAtoi(EnvMaxQueueDepth, 0),
+		WebSocketOrigins: getenvList(EnvWebSocketOrigins),
+		NATSURL:          os.Getenv(EnvNATSURL),
+		CanaryQueue:      os.Getenv(EnvCanaryQueue),
+		CanaryPercent:    getenvAtoi(EnvCanaryPercent, 0),
 	}
 }
 
 func NewWorkerConfigFromEnv() *WorkerConfig {
-	return &WorkerConfig{
-		Database: &DatabaseConfig{
-			Host:     mustGetenv(EnvDatabaseHost),
-			Port:     mustGetenvAtoi(EnvDatabasePort),
-			User:     mustGetenv(EnvDatabaseUser),
-			Password: mustGetenv(EnvDatabasePassword),
-			Name:     mustGetenv(EnvDatabaseName),
+	cfg := &WorkerConfig{
+		ServerURL:       getenvURL(EnvServerURL),
+		Mounts:          getenvList(EnvWorkerMounts),
+		AllowedRoots:    getenvAllowedRoots(EnvAllowedRoots),
+		URLInputSchemes: getenvURLInputSchemes(EnvURLInputSchemes),
+		URLInputTimeout: time.Duration(getenvAtoi(EnvURLInputTimeout, 0)) * time.Second,
+		FetchDir:        os.Getenv(EnvFetchDir),
+		SFTP: SFTPConfig{
+			User:       os.Getenv(EnvSFTPUser),
+			Password:   os.Getenv(EnvSFTPPassword),
+			KeyFile:    os.Getenv(EnvSFTPKeyFile),
+			KnownHosts: os.Getenv(EnvSFTPKnownHosts),
+		},
+		SMB: SMBConfig{
+			User:     os.Getenv(EnvSMBUser),
+			Password: os.Getenv(EnvSMBPassword),
+			Domain:   os.Getenv(EnvSMBDomain),
+		},
+		Capacity:             getenvAtoi(EnvWorkerCapacity, 1),
+		Queues:               getenvQueues(EnvWorkerQueues),
+		GPUCapacity:          getenvAtoi(EnvWorkerGPUCapacity, 0),
+		HealthPort:           getenvAtoi(EnvHealthPort, 0),
+		MaxFileSize:          int64(getenvAtoi(EnvMaxFileSize, 0)),
+		DeepAnalysisMaxSize:  int64(getenvAtoi(EnvDeepAnalysisMaxSize, 0)),
+		AnalysisParallelism:  getenvAtoi(EnvAnalysisParallelism, 2),
+		FFprobePath:          os.Getenv(EnvFFprobePath),
+		FFmpegPath:           os.Getenv(EnvFFmpegPath),
+		HWAccel:              getenvHWAccel(EnvHWAccel),
+		HWAccelDevice:        os.Getenv(EnvHWAccelDevice),
+		CacheDir:             os.Getenv(EnvWorkerCacheDir),
+		ProbeAudio:           getenvBool(EnvProbeAudio),
+		PresetRules:          os.Getenv(EnvPresetRules),
+		PostProbeHook:        os.Getenv(EnvPostProbeHook),
+		PostProbeHookTimeout: time.Duration(getenvAtoi(EnvPostProbeHookTimeout, 0)) * time.Second,
+		AnalyzerPlugins:      os.Getenv(EnvAnalyzerPlugins),
+		PriorityAging:        time.Duration(getenvAtoi(EnvPriorityAging, 0)) * time.Second,
+		ResultTTL:            time.Duration(getenvAtoi(EnvResultTTL, 0)) * time.Second,
+		CanaryInterval:       time.Duration(getenvAtoi(EnvCanaryInterval, 0)) * time.Second,
+		CanaryMaxLatency:     time.Duration(getenvAtoi(EnvCanaryMaxLatency, 0)) * time.Second,
+		CanaryWebhookURI:     getenvURL(EnvCanaryWebhookURI),
+		OutboundProxy:        getenvURL(EnvOutboundProxy),
+		WebhookPolicy:        getenvWebhookPolicy(),
+		WebhookRateLimits:    getenvWebhookRateLimits(EnvWebhookRateLimits),
+		WebhookRetry: WebhookRetryPolicy{
+			MaxAttempts:    getenvAtoi(EnvWebhookMaxAttempts, 0),
+			BackoffSeconds: getenvAtoi(EnvWebhookBackoff, 0),
+			TimeoutSeconds: getenvAtoi(EnvWebhookTimeout, 0),
 		},
+		WebhookBreaker: WebhookBreakerPolicy{
+			Threshold:       getenvAtoi(EnvBreakerThreshold, 0),
+			CooldownSeconds: getenvAtoi(EnvBreakerCooldown, 0),
+		},
+		SigningKey:   getenvSigningKey(EnvSigningKey),
+		SecretsKeys:  getenvSecretsKeys(EnvSecretsKeys),
+		NATSURL:      os.Getenv(EnvNATSURL),
+		KafkaRESTURL: getenvURL(EnvKafkaRESTURL),
+		KafkaTopic:   os.Getenv(EnvKafkaTopic),
+	}
+	if cfg.ServerURL != nil {
+		cfg.WorkerToken = mustGetenv(EnvWorkerToken)
+	} else {
+		cfg.Database = NewDatabaseConfigFromEnv()
 	}
-}
\ No newline at end of file
+	return cfg
+}
@@ -1,7 +1,12 @@
 package internal_test
 
 import (
+	"bytes"
+	"crypto/ed25519"
+	"net"
+	"net/url"
 	"testing"
+	"time"
 
 	"github.com/krelinga/go-libs/deep"
 	"github.com/krelinga/go-libs/exam"
@@ -13,6 +18,11 @@ func TestConfig(t *testing.T) {
 	e := exam.New(t)
 	env := deep.NewEnv()
 
+	signingSeed := make([]byte, ed25519.SeedSize)
+	for i := range signingSeed {
+		signingSeed[i] = byte(i)
+	}
+
 	e.Run("NewServerConfigFromEnv", func(e exam.E) {
 		// Set up environment variables for the test
 		exam.SetEnv(e, internal.EnvServerPort, "80")
@@ -86,6 +96,267 @@ func TestConfig(t *testing.T) {
 				envVarsToClear: []string{internal.EnvDatabaseName},
 				wantPanic:      internal.ErrPanicEnvNotSet,
 			},
+			{
+				loc:  exam.Here(),
+				name: "Webhook policy set",
+				envVarsToSet: map[string]string{
+					internal.EnvWebhookAllowedHosts: "hooks.example.com, 10.0.0.0/8,192.168.1.5",
+					internal.EnvWebhookDenyPrivate:  "true",
+				},
+				wantConfig: &internal.ServerConfig{
+					Port: 80,
+					Database: &internal.DatabaseConfig{
+						Host:     "db-host",
+						Port:     5432,
+						User:     "db-user",
+						Password: "db-password",
+						Name:     "db-name",
+					},
+					WebhookPolicy: &internal.URLPolicy{
+						AllowedHosts: []string{"hooks.example.com"},
+						AllowedNets: []*net.IPNet{
+							{IP: net.IP{10, 0, 0, 0}, Mask: net.CIDRMask(8, 32)},
+							{IP: net.IP{192, 168, 1, 5}, Mask: net.CIDRMask(32, 32)},
+						},
+						DenyPrivate: true,
+					},
+				},
+			},
+			{
+				loc:  exam.Here(),
+				name: "Read replica set",
+				envVarsToSet: map[string]string{
+					internal.EnvDatabaseReplicaHost: "db-replica",
+					internal.EnvDatabaseReplicaPort: "5433",
+				},
+				wantConfig: &internal.ServerConfig{
+					Port: 80,
+					Database: &internal.DatabaseConfig{
+						Host:     "db-host",
+						Port:     5432,
+						User:     "db-user",
+						Password: "db-password",
+						Name:     "db-name",
+					},
+					ReadReplica: &internal.DatabaseConfig{
+						Host:     "db-replica",
+						Port:     5433,
+						User:     "db-user",
+						Password: "db-password",
+						Name:     "db-name",
+					},
+				},
+			},
+			{
+				loc:          exam.Here(),
+				name:         "Non-integer VI_DB_REPLICA_PORT",
+				envVarsToSet: map[string]string{internal.EnvDatabaseReplicaHost: "db-replica", internal.EnvDatabaseReplicaPort: "not-an-int"},
+				wantPanic:    internal.ErrPanicEnvNotInt,
+			},
+			{
+				loc:          exam.Here(),
+				name:         "Non-boolean VI_WEBHOOK_DENY_PRIVATE",
+				envVarsToSet: map[string]string{internal.EnvWebhookDenyPrivate: "maybe"},
+				wantPanic:    internal.ErrPanicEnvNotBool,
+			},
+			{
+				loc:          exam.Here(),
+				name:         "Signing key set",
+				envVarsToSet: map[string]string{internal.EnvSigningKey: "AAECAwQFBgcICQoLDA0ODxAREhMUFRYXGBkaGxwdHh8="},
+				wantConfig: &internal.ServerConfig{
+					Port: 80,
+					Database: &internal.DatabaseConfig{
+						Host:     "db-host",
+						Port:     5432,
+						User:     "db-user",
+						Password: "db-password",
+						Name:     "db-name",
+					},
+					SigningKey: ed25519.NewKeyFromSeed(signingSeed),
+				},
+			},
+			{
+				loc:          exam.Here(),
+				name:         "Short VI_SIGNING_KEY",
+				envVarsToSet: map[string]string{internal.EnvSigningKey: "c2hvcnQ="},
+				wantPanic:    internal.ErrPanicEnvNotKey,
+			},
+			{
+				loc:          exam.Here(),
+				name:         "Secrets keys set",
+				envVarsToSet: map[string]string{internal.EnvSecretsKeys: "AQEBAQEBAQEBAQEBAQEBAQEBAQEBAQEBAQEBAQEBAQE=,AgICAgICAgICAgICAgICAgICAgICAgICAgICAgICAgI="},
+				wantConfig: &internal.ServerConfig{
+					Port: 80,
+					Database: &internal.DatabaseConfig{
+						Host:     "db-host",
+						Port:     5432,
+						User:     "db-user",
+						Password: "db-password",
+						Name:     "db-name",
+					},
+					SecretsKeys: [][]byte{bytes.Repeat([]byte{1}, 32), bytes.Repeat([]byte{2}, 32)},
+				},
+			},
+			{
+				loc:          exam.Here(),
+				name:         "Invalid VI_SECRETS_KEYS",
+				envVarsToSet: map[string]string{internal.EnvSecretsKeys: "not-base64!"},
+				wantPanic:    internal.ErrPanicEnvNotKey,
+			},
+			{
+				loc:          exam.Here(),
+				name:         "Status cache size set",
+				envVarsToSet: map[string]string{internal.EnvStatusCacheSize: "1000"},
+				wantConfig: &internal.ServerConfig{
+					Port: 80,
+					Database: &internal.DatabaseConfig{
+						Host:     "db-host",
+						Port:     5432,
+						User:     "db-user",
+						Password: "db-password",
+						Name:     "db-name",
+					},
+					StatusCacheSize: 1000,
+				},
+			},
+			{
+				loc:          exam.Here(),
+				name:         "Invalid VI_STATUS_CACHE_SIZE",
+				envVarsToSet: map[string]string{internal.EnvStatusCacheSize: "lots"},
+				wantPanic:    internal.ErrPanicEnvNotInt,
+			},
+			{
+				loc:          exam.Here(),
+				name:         "Queue depth limited",
+				envVarsToSet: map[string]string{internal.EnvMaxQueueDepth: "5000"},
+				wantConfig: &internal.ServerConfig{
+					Port: 80,
+					Database: &internal.DatabaseConfig{
+						Host:     "db-host",
+						Port:     5432,
+						User:     "db-user",
+						Password: "db-password",
+						Name:     "db-name",
+					},
+					MaxQueueDepth: 5000,
+				},
+			},
+			{
+				loc:          exam.Here(),
+				name:         "WebSocket origins",
+				envVarsToSet: map[string]string{internal.EnvWebSocketOrigins: "ui.example.com, *.example.net"},
+				wantConfig: &internal.ServerConfig{
+					Port: 80,
+					Database: &internal.DatabaseConfig{
+						Host:     "db-host",
+						Port:     5432,
+						User:     "db-user",
+						Password: "db-password",
+						Name:     "db-name",
+					},
+					WebSocketOrigins: []string{"ui.example.com", "*.example.net"},
+				},
+			},
+			{
+				loc:          exam.Here(),
+				name:         "NATS URL",
+				envVarsToSet: map[string]string{internal.EnvNATSURL: "nats://nats:4222"},
+				wantConfig: &internal.ServerConfig{
+					Port: 80,
+					Database: &internal.DatabaseConfig{
+						Host:     "db-host",
+						Port:     5432,
+						User:     "db-user",
+						Password: "db-password",
+						Name:     "db-name",
+					},
+					NATSURL: "nats://nats:4222",
+				},
+			},
+			{
+				loc:  exam.Here(),
+				name: "Canary routing",
+				envVarsToSet: map[string]string{
+					internal.EnvCanaryQueue:   "canary",
+					internal.EnvCanaryPercent: "5",
+				},
+				wantConfig: &internal.ServerConfig{
+					Port: 80,
+					Database: &internal.DatabaseConfig{
+						Host:     "db-host",
+						Port:     5432,
+						User:     "db-user",
+						Password: "db-password",
+						Name:     "db-name",
+					},
+					CanaryQueue:   "canary",
+					CanaryPercent: 5,
+				},
+			},
+			{
+				loc:  exam.Here(),
+				name: "Job timeouts set",
+				envVarsToSet: map[string]string{
+					internal.EnvJobTimeout:    "600",
+					internal.EnvMaxJobTimeout: "3600",
+				},
+				wantConfig: &internal.ServerConfig{
+					Port: 80,
+					Database: &internal.DatabaseConfig{
+						Host:     "db-host",
+						Port:     5432,
+						User:     "db-user",
+						Password: "db-password",
+						Name:     "db-name",
+					},
+					JobTimeout:    10 * time.Minute,
+					MaxJobTimeout: time.Hour,
+				},
+			},
+			{
+				loc:          exam.Here(),
+				name:         "VI_ALLOWED_ROOTS set",
+				envVarsToSet: map[string]string{internal.EnvAllowedRoots: "/videos, /mnt/media/"},
+				wantConfig: &internal.ServerConfig{
+					Port: 80,
+					Database: &internal.DatabaseConfig{
+						Host:     "db-host",
+						Port:     5432,
+						User:     "db-user",
+						Password: "db-password",
+						Name:     "db-name",
+					},
+					AllowedRoots: internal.AllowedRoots{"/videos/", "/mnt/media/"},
+				},
+			},
+			{
+				loc:          exam.Here(),
+				name:         "VI_URL_INPUT_SCHEMES set",
+				envVarsToSet: map[string]string{internal.EnvURLInputSchemes: "https"},
+				wantConfig: &internal.ServerConfig{
+					Port: 80,
+					Database: &internal.DatabaseConfig{
+						Host:     "db-host",
+						Port:     5432,
+						User:     "db-user",
+						Password: "db-password",
+						Name:     "db-name",
+					},
+					URLInputSchemes: []string{"https"},
+				},
+			},
+			{
+				loc:          exam.Here(),
+				name:         "Unsupported scheme in VI_URL_INPUT_SCHEMES",
+				envVarsToSet: map[string]string{internal.EnvURLInputSchemes: "ftp"},
+				wantPanic:    internal.ErrPanicEnvNotSchemes,
+			},
+			{
+				loc:          exam.Here(),
+				name:         "Relative path in VI_ALLOWED_ROOTS",
+				envVarsToSet: map[string]string{internal.EnvAllowedRoots: "videos"},
+				wantPanic:    internal.ErrPanicEnvNotRootList,
+			},
 		}
 		for _, tt := range tests {
 			e.Run(tt.name, func(e exam.E) {
@@ -132,6 +403,8 @@ func TestConfig(t *testing.T) {
 				loc:  exam.Here(),
 				name: "All environment variables set correctly",
 				wantConfig: &internal.WorkerConfig{
+					Capacity:            1,
+					AnalysisParallelism: 2,
 					Database: &internal.DatabaseConfig{
 						Host:     "db-host",
 						Port:     5432,
@@ -171,6 +444,460 @@ func TestConfig(t *testing.T) {
 				envVarsToClear: []string{internal.EnvDatabaseName},
 				wantPanic:      internal.ErrPanicEnvNotSet,
 			},
+			{
+				loc:          exam.Here(),
+				name:         "VI_OUTBOUND_PROXY set",
+				envVarsToSet: map[string]string{internal.EnvOutboundProxy: "http://proxy.example.com:3128"},
+				wantConfig: &internal.WorkerConfig{
+					Capacity:            1,
+					AnalysisParallelism: 2,
+					Database: &internal.DatabaseConfig{
+						Host:     "db-host",
+						Port:     5432,
+						User:     "db-user",
+						Password: "db-password",
+						Name:     "db-name",
+					},
+					OutboundProxy: &url.URL{Scheme: "http", Host: "proxy.example.com:3128"},
+				},
+			},
+			{
+				loc:          exam.Here(),
+				name:         "Invalid VI_OUTBOUND_PROXY",
+				envVarsToSet: map[string]string{internal.EnvOutboundProxy: "not a url"},
+				wantPanic:    internal.ErrPanicEnvNotURL,
+			},
+			{
+				loc:  exam.Here(),
+				name: "Pull mode",
+				envVarsToSet: map[string]string{
+					internal.EnvServerURL:   "https://video-info.example.com",
+					internal.EnvWorkerToken: "secret",
+				},
+				envVarsToClear: []string{internal.EnvDatabaseHost},
+				wantConfig: &internal.WorkerConfig{
+					ServerURL:           &url.URL{Scheme: "https", Host: "video-info.example.com"},
+					WorkerToken:         "secret",
+					Capacity:            1,
+					AnalysisParallelism: 2,
+				},
+			},
+			{
+				loc:  exam.Here(),
+				name: "Pull mode with mounts and capacity",
+				envVarsToSet: map[string]string{
+					internal.EnvServerURL:         "https://video-info.example.com",
+					internal.EnvWorkerToken:       "secret",
+					internal.EnvWorkerMounts:      "/videos/site-2, /archive",
+					internal.EnvWorkerCapacity:    "4",
+					internal.EnvWorkerGPUCapacity: "1",
+					internal.EnvHealthPort:        "8081",
+				},
+				envVarsToClear: []string{internal.EnvDatabaseHost},
+				wantConfig: &internal.WorkerConfig{
+					ServerURL:           &url.URL{Scheme: "https", Host: "video-info.example.com"},
+					WorkerToken:         "secret",
+					Mounts:              []string{"/videos/site-2", "/archive"},
+					Capacity:            4,
+					AnalysisParallelism: 2,
+					GPUCapacity:         1,
+					HealthPort:          8081,
+				},
+			},
+			{
+				loc:  exam.Here(),
+				name: "VI_MAX_FILE_SIZE, VI_DEEP_ANALYSIS_MAX_SIZE and VI_ANALYSIS_PARALLELISM set",
+				envVarsToSet: map[string]string{
+					internal.EnvMaxFileSize:         "107374182400",
+					internal.EnvDeepAnalysisMaxSize: "10737418240",
+					internal.EnvAnalysisParallelism: "4",
+				},
+				wantConfig: &internal.WorkerConfig{
+					Capacity:            1,
+					AnalysisParallelism: 4,
+					MaxFileSize:         100 << 30,
+					DeepAnalysisMaxSize: 10 << 30,
+					Database: &internal.DatabaseConfig{
+						Host:     "db-host",
+						Port:     5432,
+						User:     "db-user",
+						Password: "db-password",
+						Name:     "db-name",
+					},
+				},
+			},
+			{
+				loc:  exam.Here(),
+				name: "Pull mode with result cache, audio probing and presets",
+				envVarsToSet: map[string]string{
+					internal.EnvServerURL:      "https://video-info.example.com",
+					internal.EnvWorkerToken:    "secret",
+					internal.EnvWorkerCacheDir: "/var/cache/video-info",
+					internal.EnvProbeAudio:     "true",
+					internal.EnvPresetRules:    "/etc/video-info/presets.json",
+				},
+				envVarsToClear: []string{internal.EnvDatabaseHost},
+				wantConfig: &internal.WorkerConfig{
+					ServerURL:           &url.URL{Scheme: "https", Host: "video-info.example.com"},
+					WorkerToken:         "secret",
+					Capacity:            1,
+					AnalysisParallelism: 2,
+					CacheDir:            "/var/cache/video-info",
+					ProbeAudio:          true,
+					PresetRules:         "/etc/video-info/presets.json",
+				},
+			},
+			{
+				loc:  exam.Here(),
+				name: "Webhook retry policy",
+				envVarsToSet: map[string]string{
+					internal.EnvWebhookMaxAttempts: "5",
+					internal.EnvWebhookBackoff:     "60",
+					internal.EnvWebhookTimeout:     "10",
+				},
+				wantConfig: &internal.WorkerConfig{
+					Capacity:            1,
+					AnalysisParallelism: 2,
+					WebhookRetry: internal.WebhookRetryPolicy{
+						MaxAttempts:    5,
+						BackoffSeconds: 60,
+						TimeoutSeconds: 10,
+					},
+					Database: &internal.DatabaseConfig{
+						Host:     "db-host",
+						Port:     5432,
+						User:     "db-user",
+						Password: "db-password",
+						Name:     "db-name",
+					},
+				},
+			},
+			{
+				loc:  exam.Here(),
+				name: "Webhook breaker policy",
+				envVarsToSet: map[string]string{
+					internal.EnvBreakerThreshold: "5",
+					internal.EnvBreakerCooldown:  "30",
+				},
+				wantConfig: &internal.WorkerConfig{
+					Capacity:            1,
+					AnalysisParallelism: 2,
+					WebhookBreaker: internal.WebhookBreakerPolicy{
+						Threshold:       5,
+						CooldownSeconds: 30,
+					},
+					Database: &internal.DatabaseConfig{
+						Host:     "db-host",
+						Port:     5432,
+						User:     "db-user",
+						Password: "db-password",
+						Name:     "db-name",
+					},
+				},
+			},
+			{
+				loc:  exam.Here(),
+				name: "Webhook rate limits",
+				envVarsToSet: map[string]string{
+					internal.EnvWebhookRateLimits: "hooks.example.com=60,*=600",
+				},
+				wantConfig: &internal.WorkerConfig{
+					Capacity:            1,
+					AnalysisParallelism: 2,
+					WebhookRateLimits: internal.WebhookRateLimits{
+						"hooks.example.com": 60,
+						"*":                 600,
+					},
+					Database: &internal.DatabaseConfig{
+						Host:     "db-host",
+						Port:     5432,
+						User:     "db-user",
+						Password: "db-password",
+						Name:     "db-name",
+					},
+				},
+			},
+			{
+				loc:  exam.Here(),
+				name: "VI_POST_PROBE_HOOK set",
+				envVarsToSet: map[string]string{
+					internal.EnvPostProbeHook:        "/opt/hooks/flag.sh",
+					internal.EnvPostProbeHookTimeout: "5",
+				},
+				wantConfig: &internal.WorkerConfig{
+					Capacity:             1,
+					AnalysisParallelism:  2,
+					PostProbeHook:        "/opt/hooks/flag.sh",
+					PostProbeHookTimeout: 5 * time.Second,
+					Database: &internal.DatabaseConfig{
+						Host:     "db-host",
+						Port:     5432,
+						User:     "db-user",
+						Password: "db-password",
+						Name:     "db-name",
+					},
+				},
+			},
+			{
+				loc:          exam.Here(),
+				name:         "VI_ANALYZER_PLUGINS set",
+				envVarsToSet: map[string]string{internal.EnvAnalyzerPlugins: "/etc/video-info/analyzers.json"},
+				wantConfig: &internal.WorkerConfig{
+					Capacity:            1,
+					AnalysisParallelism: 2,
+					AnalyzerPlugins:     "/etc/video-info/analyzers.json",
+					Database: &internal.DatabaseConfig{
+						Host:     "db-host",
+						Port:     5432,
+						User:     "db-user",
+						Password: "db-password",
+						Name:     "db-name",
+					},
+				},
+			},
+			{
+				loc:          exam.Here(),
+				name:         "VI_WORKER_QUEUES set",
+				envVarsToSet: map[string]string{internal.EnvWorkerQueues: "default:4,deep-analysis:1"},
+				wantConfig: &internal.WorkerConfig{
+					Capacity:            1,
+					AnalysisParallelism: 2,
+					Queues:              map[string]int{"default": 4, "deep-analysis": 1},
+					Database: &internal.DatabaseConfig{
+						Host:     "db-host",
+						Port:     5432,
+						User:     "db-user",
+						Password: "db-password",
+						Name:     "db-name",
+					},
+				},
+			},
+			{
+				loc:          exam.Here(),
+				name:         "VI_ALLOWED_ROOTS set",
+				envVarsToSet: map[string]string{internal.EnvAllowedRoots: "/videos"},
+				wantConfig: &internal.WorkerConfig{
+					Capacity:            1,
+					AnalysisParallelism: 2,
+					AllowedRoots:        internal.AllowedRoots{"/videos/"},
+					Database: &internal.DatabaseConfig{
+						Host:     "db-host",
+						Port:     5432,
+						User:     "db-user",
+						Password: "db-password",
+						Name:     "db-name",
+					},
+				},
+			},
+			{
+				loc:  exam.Here(),
+				name: "URL inputs enabled",
+				envVarsToSet: map[string]string{
+					internal.EnvURLInputSchemes: "http,https",
+					internal.EnvURLInputTimeout: "15",
+				},
+				wantConfig: &internal.WorkerConfig{
+					Capacity:            1,
+					AnalysisParallelism: 2,
+					URLInputSchemes:     []string{"http", "https"},
+					URLInputTimeout:     15 * time.Second,
+					Database: &internal.DatabaseConfig{
+						Host:     "db-host",
+						Port:     5432,
+						User:     "db-user",
+						Password: "db-password",
+						Name:     "db-name",
+					},
+				},
+			},
+			{
+				loc:  exam.Here(),
+				name: "Fetched inputs configured",
+				envVarsToSet: map[string]string{
+					internal.EnvURLInputSchemes: "sftp,smb",
+					internal.EnvFetchDir:        "/spool",
+					internal.EnvSFTPUser:        "media",
+					internal.EnvSFTPKeyFile:     "/secrets/id_ed25519",
+					internal.EnvSFTPKnownHosts:  "/secrets/known_hosts",
+					internal.EnvSMBUser:         "guest",
+					internal.EnvSMBPassword:     "smb-password",
+					internal.EnvSMBDomain:       "WORKGROUP",
+				},
+				wantConfig: &internal.WorkerConfig{
+					Capacity:            1,
+					AnalysisParallelism: 2,
+					URLInputSchemes:     []string{"sftp", "smb"},
+					FetchDir:            "/spool",
+					SFTP: internal.SFTPConfig{
+						User:       "media",
+						KeyFile:    "/secrets/id_ed25519",
+						KnownHosts: "/secrets/known_hosts",
+					},
+					SMB: internal.SMBConfig{
+						User:     "guest",
+						Password: "smb-password",
+						Domain:   "WORKGROUP",
+					},
+					Database: &internal.DatabaseConfig{
+						Host:     "db-host",
+						Port:     5432,
+						User:     "db-user",
+						Password: "db-password",
+						Name:     "db-name",
+					},
+				},
+			},
+			{
+				loc:  exam.Here(),
+				name: "Kafka sink",
+				envVarsToSet: map[string]string{
+					internal.EnvKafkaRESTURL: "http://kafka-rest:8082",
+					internal.EnvKafkaTopic:   "video-info.results",
+				},
+				wantConfig: &internal.WorkerConfig{
+					Capacity:            1,
+					AnalysisParallelism: 2,
+					KafkaRESTURL:        &url.URL{Scheme: "http", Host: "kafka-rest:8082"},
+					KafkaTopic:          "video-info.results",
+					Database: &internal.DatabaseConfig{
+						Host:     "db-host",
+						Port:     5432,
+						User:     "db-user",
+						Password: "db-password",
+						Name:     "db-name",
+					},
+				},
+			},
+			{
+				loc:  exam.Here(),
+				name: "Canary enabled",
+				envVarsToSet: map[string]string{
+					internal.EnvCanaryInterval:   "300",
+					internal.EnvCanaryMaxLatency: "5",
+					internal.EnvCanaryWebhookURI: "https://alerts.example.com/canary",
+				},
+				wantConfig: &internal.WorkerConfig{
+					Capacity:            1,
+					AnalysisParallelism: 2,
+					CanaryInterval:      5 * time.Minute,
+					CanaryMaxLatency:    5 * time.Second,
+					CanaryWebhookURI:    &url.URL{Scheme: "https", Host: "alerts.example.com", Path: "/canary"},
+					Database: &internal.DatabaseConfig{
+						Host:     "db-host",
+						Port:     5432,
+						User:     "db-user",
+						Password: "db-password",
+						Name:     "db-name",
+					},
+				},
+			},
+			{
+				loc:          exam.Here(),
+				name:         "Invalid VI_WORKER_QUEUES",
+				envVarsToSet: map[string]string{internal.EnvWorkerQueues: "default"},
+				wantPanic:    internal.ErrPanicEnvNotQueueList,
+			},
+			{
+				loc:          exam.Here(),
+				name:         "VI_RESULT_TTL set",
+				envVarsToSet: map[string]string{internal.EnvResultTTL: "2592000"},
+				wantConfig: &internal.WorkerConfig{
+					Capacity:            1,
+					AnalysisParallelism: 2,
+					ResultTTL:           30 * 24 * time.Hour,
+					Database: &internal.DatabaseConfig{
+						Host:     "db-host",
+						Port:     5432,
+						User:     "db-user",
+						Password: "db-password",
+						Name:     "db-name",
+					},
+				},
+			},
+			{
+				loc:          exam.Here(),
+				name:         "VI_PRIORITY_AGING set",
+				envVarsToSet: map[string]string{internal.EnvPriorityAging: "3600"},
+				wantConfig: &internal.WorkerConfig{
+					Capacity:            1,
+					AnalysisParallelism: 2,
+					PriorityAging:       time.Hour,
+					Database: &internal.DatabaseConfig{
+						Host:     "db-host",
+						Port:     5432,
+						User:     "db-user",
+						Password: "db-password",
+						Name:     "db-name",
+					},
+				},
+			},
+			{
+				loc:          exam.Here(),
+				name:         "Non-integer VI_WEBHOOK_TIMEOUT",
+				envVarsToSet: map[string]string{internal.EnvWebhookTimeout: "10s"},
+				wantPanic:    internal.ErrPanicEnvNotInt,
+			},
+			{
+				loc:          exam.Here(),
+				name:         "Non-integer VI_MAX_FILE_SIZE",
+				envVarsToSet: map[string]string{internal.EnvMaxFileSize: "100GB"},
+				wantPanic:    internal.ErrPanicEnvNotInt,
+			},
+			{
+				loc:  exam.Here(),
+				name: "Binary paths and hardware acceleration",
+				envVarsToSet: map[string]string{
+					internal.EnvFFprobePath:   "/opt/ffmpeg/bin/ffprobe",
+					internal.EnvFFmpegPath:    "/opt/ffmpeg/bin/ffmpeg",
+					internal.EnvHWAccel:       "vaapi",
+					internal.EnvHWAccelDevice: "/dev/dri/renderD128",
+				},
+				wantConfig: &internal.WorkerConfig{
+					Capacity:            1,
+					AnalysisParallelism: 2,
+					FFprobePath:         "/opt/ffmpeg/bin/ffprobe",
+					FFmpegPath:          "/opt/ffmpeg/bin/ffmpeg",
+					HWAccel:             internal.HWAccelVAAPI,
+					HWAccelDevice:       "/dev/dri/renderD128",
+					Database: &internal.DatabaseConfig{
+						Host:     "db-host",
+						Port:     5432,
+						User:     "db-user",
+						Password: "db-password",
+						Name:     "db-name",
+					},
+				},
+			},
+			{
+				loc:          exam.Here(),
+				name:         "Unsupported VI_HWACCEL",
+				envVarsToSet: map[string]string{internal.EnvHWAccel: "3dfx"},
+				wantPanic:    internal.ErrPanicEnvNotHWAccel,
+			},
+			{
+				loc:          exam.Here(),
+				name:         "Non-integer VI_WORKER_CAPACITY",
+				envVarsToSet: map[string]string{internal.EnvWorkerCapacity: "many"},
+				wantPanic:    internal.ErrPanicEnvNotInt,
+			},
+			{
+				loc:          exam.Here(),
+				name:         "Non-boolean VI_PROBE_AUDIO",
+				envVarsToSet: map[string]string{internal.EnvProbeAudio: "sometimes"},
+				wantPanic:    internal.ErrPanicEnvNotBool,
+			},
+			{
+				loc:          exam.Here(),
+				name:         "Non-integer VI_WORKER_GPU_CAPACITY",
+				envVarsToSet: map[string]string{internal.EnvWorkerGPUCapacity: "yes"},
+				wantPanic:    internal.ErrPanicEnvNotInt,
+			},
+			{
+				loc:          exam.Here(),
+				name:         "Pull mode without VI_WORKER_TOKEN",
+				envVarsToSet: map[string]string{internal.EnvServerURL: "https://video-info.example.com"},
+				wantPanic:    internal.ErrPanicEnvNotSet,
+			},
 		}
 		for _, tt := range tests {
 			e.Run(tt.name, func(e exam.E) {
@@ -197,3 +924,18 @@ func TestConfig(t *testing.T) {
 		}
 	})
 }
+
+func TestWorkerConfigQueueConcurrency(t *testing.T) {
+	e := exam.New(t)
+	env := deep.NewEnv()
+
+	e.Run("Capacity applies to the default queue", func(e exam.E) {
+		cfg := &internal.WorkerConfig{Capacity: 3}
+		exam.Equal(e, env, map[string]int{"default": 3}, cfg.QueueConcurrency())
+	})
+
+	e.Run("Queues replace capacity", func(e exam.E) {
+		cfg := &internal.WorkerConfig{Capacity: 3, Queues: map[string]int{"deep-analysis": 1}}
+		exam.Equal(e, env, map[string]int{"deep-analysis": 1}, cfg.QueueConcurrency())
+	})
+}
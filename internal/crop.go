@@ -0,0 +1,134 @@
+package internal
+
+import (
+	"regexp"
+	"strconv"
+
+	"github.com/krelinga/video-info/virest"
+)
+
+// CropDetection is the active picture area of a video, found by sampling it
+// with ffmpeg's cropdetect filter, and the black bars around it.  A video
+// decoded in full for other analyses is sampled as a single window.
+type CropDetection struct {
+	Width  int `json:"width"`
+	Height int `json:"height"`
+	X      int `json:"x"`
+	Y      int `json:"y"`
+
+	Top    int `json:"top"`
+	Bottom int `json:"bottom"`
+	Left   int `json:"left"`
+	Right  int `json:"right"`
+
+	// Samples is the number of windows of the video that were analyzed, and
+	// SampledSeconds their combined length.
+	Samples        int     `json:"samples"`
+	SampledSeconds float64 `json:"sampled_seconds"`
+}
+
+// CropRect is a rectangle reported by cropdetect.
+type CropRect struct {
+	Width, Height, X, Y int
+}
+
+// cropPattern matches the rectangle a cropdetect filter instance logs for each
+// frame, tagged with the instance's position in the graph.
+var cropPattern = regexp.MustCompile(`\[Parsed_cropdetect_(\d+) @ [^\]]*\] .*crop=(-?\d+):(-?\d+):(-?\d+):(-?\d+)`)
+
+// ParseCropDetect returns the last rectangle logged in output by the
+// cropdetect filter at position filter in the graph, which covers every
+// frame the filter saw when it is not reset.  Rectangles from entirely black
+// frames, which have no area, are ignored.
+func ParseCropDetect(output string, filter int) (CropRect, bool) {
+	matches := cropPattern.FindAllStringSubmatch(output, -1)
+	for i := len(matches) - 1; i >= 0; i-- {
+		if position, err := strconv.Atoi(matches[i][1]); err != nil || position != filter {
+			continue
+		}
+		var values [4]int
+		for j := range values {
+			values[j], _ = strconv.Atoi(matches[i][j+2])
+		}
+		rect := CropRect{Width: values[0], Height: values[1], X: values[2], Y: values[3]}
+		if rect.Width > 0 && rect.Height > 0 {
+			return rect, true
+		}
+	}
+	return CropRect{}, false
+}
+
+// NewCropDetection combines the rectangles found in samples of a video whose
+// frames are frameWidth by frameHeight.  The active area is the smallest
+// rectangle containing them all, so a dark scene in one sample doesn't crop
+// into the picture.  It returns nil if there are no rectangles.
+func NewCropDetection(frameWidth, frameHeight int, rects []CropRect, sampledSeconds float64) *CropDetection {
+	if len(rects) == 0 {
+		return nil
+	}
+	left, top := frameWidth, frameHeight
+	right, bottom := 0, 0
+	for _, r := range rects {
+		left = min(left, r.X)
+		top = min(top, r.Y)
+		right = max(right, r.X+r.Width)
+		bottom = max(bottom, r.Y+r.Height)
+	}
+	left, top = max(left, 0), max(top, 0)
+	right, bottom = min(right, frameWidth), min(bottom, frameHeight)
+
+	return &CropDetection{
+		Width:          right - left,
+		Height:         bottom - top,
+		X:              left,
+		Y:              top,
+		Top:            top,
+		Bottom:         frameHeight - bottom,
+		Left:           left,
+		Right:          frameWidth - right,
+		Samples:        len(rects),
+		SampledSeconds: sampledSeconds,
+	}
+}
+
+// RESTCropDetection converts the detection to its REST form, or nil if c is
+// nil.
+func (c *CropDetection) RESTCropDetection() *virest.CropDetection {
+	if c == nil {
+		return nil
+	}
+	return &virest.CropDetection{
+		Width:  c.Width,
+		Height: c.Height,
+		X:      c.X,
+		Y:      c.Y,
+		BlackBars: virest.BlackBars{
+			Top:    c.Top,
+			Bottom: c.Bottom,
+			Left:   c.Left,
+			Right:  c.Right,
+		},
+		Samples:        c.Samples,
+		SampledSeconds: c.SampledSeconds,
+	}
+}
+
+// NewCropDetectionFromREST converts a REST CropDetection, or returns nil if
+// v is nil.
+func NewCropDetectionFromREST(v *virest.CropDetection) *CropDetection {
+	if v == nil {
+		return nil
+	}
+	return &CropDetection{
+		Width:          v.Width,
+		Height:         v.Height,
+		X:              v.X,
+		Y:              v.Y,
+		Top:            v.BlackBars.Top,
+		Bottom:         v.BlackBars.Bottom,
+		Left:           v.BlackBars.Left,
+		Right:          v.BlackBars.Right,
+		Samples:        v.Samples,
+		SampledSeconds: v.SampledSeconds,
+	}
+}
@@ -0,0 +1,90 @@
+package internal_test
+
+import (
+	"testing"
+
+	"github.com/krelinga/go-libs/deep"
+	"github.com/krelinga/go-libs/exam"
+	"github.com/krelinga/video-info/internal"
+)
+
+func TestParseCropDetect(t *testing.T) {
+	e := exam.New(t)
+	env := deep.NewEnv()
+
+	tests := []struct {
+		loc    exam.Loc
+		name   string
+		output string
+		filter int
+		want   internal.CropRect
+		wantOk bool
+	}{
+		{
+			loc:  exam.Here(),
+			name: "Last rectangle wins",
+			output: "[Parsed_cropdetect_0 @ 0x55d0] x1:0 x2:1919 y1:142 y2:937 w:1920 h:796 x:0 y:142 pts:1001 t:0.041708 limit:0.094000 crop=1920:796:0:142\n" +
+				"[Parsed_cropdetect_0 @ 0x55d0] x1:0 x2:1919 y1:140 y2:939 w:1920 h:800 x:0 y:140 pts:2002 t:0.083417 limit:0.094000 crop=1920:800:0:140\n",
+			want:   internal.CropRect{Width: 1920, Height: 800, X: 0, Y: 140},
+			wantOk: true,
+		},
+		{
+			loc:  exam.Here(),
+			name: "Black frames ignored",
+			output: "[Parsed_cropdetect_0 @ 0x55d0] crop=1920:800:0:140\n" +
+				"[Parsed_cropdetect_0 @ 0x55d0] x1:1919 x2:0 y1:1079 y2:0 w:-1904 h:-1072 x:1912 y:1076 crop=-1904:-1072:1912:1076\n",
+			want:   internal.CropRect{Width: 1920, Height: 800, X: 0, Y: 140},
+			wantOk: true,
+		},
+		{
+			loc:  exam.Here(),
+			name: "Other filters ignored",
+			output: "[Parsed_cropdetect_0 @ 0x55d0] [info] x1:0 x2:1919 y1:140 y2:939 w:1920 h:800 x:0 y:140 pts:1001 t:0.041708 limit:0.094000 crop=1920:800:0:140\n" +
+				"[Parsed_cropdetect_1 @ 0x55e0] [info] x1:0 x2:1919 y1:132 y2:947 w:1920 h:816 x:0 y:132 pts:1001 t:0.041708 limit:0.094000 crop=1920:816:0:132\n" +
+				"[Parsed_cropdetect_0 @ 0x55d0] [info] x1:0 x2:1919 y1:138 y2:941 w:1920 h:804 x:0 y:138 pts:2002 t:0.083417 limit:0.094000 crop=1920:804:0:138\n",
+			filter: 1,
+			want:   internal.CropRect{Width: 1920, Height: 816, X: 0, Y: 132},
+			wantOk: true,
+		},
+		{
+			loc:    exam.Here(),
+			name:   "No rectangles",
+			output: "Output #0, null, to 'pipe:':\n",
+		},
+	}
+	for _, tt := range tests {
+		e.Run(tt.name, func(e exam.E) {
+			e.Log("Running test at", tt.loc)
+			got, ok := internal.ParseCropDetect(tt.output, tt.filter)
+			exam.Equal(e, env, tt.wantOk, ok)
+			exam.Equal(e, env, tt.want, got)
+		})
+	}
+}
+
+func TestNewCropDetection(t *testing.T) {
+	e := exam.New(t)
+	env := deep.NewEnv()
+
+	exam.Nil(e, env, internal.NewCropDetection(1920, 1080, nil, 0))
+
+	// A dark scene in the second sample must not crop into the picture
+	got := internal.NewCropDetection(1920, 1080, []internal.CropRect{
+		{Width: 1920, Height: 800, X: 0, Y: 140},
+		{Width: 1600, Height: 600, X: 160, Y: 240},
+		{Width: 1916, Height: 804, X: 2, Y: 138},
+	}, 6)
+	want := &internal.CropDetection{
+		Width:          1920,
+		Height:         804,
+		X:              0,
+		Y:              138,
+		Top:            138,
+		Bottom:         138,
+		Left:           0,
+		Right:          0,
+		Samples:        3,
+		SampledSeconds: 6,
+	}
+	exam.Equal(e, env, want, got)
+}
@@ -0,0 +1,196 @@
+package internal
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/krelinga/video-info/virest"
+	"github.com/nats-io/nats.go"
+	"github.com/riverqueue/river"
+	"github.com/riverqueue/river/rivertype"
+)
+
+// Types of JobEvent.  Each is published on the subject "video-info.job."
+// followed by the type.
+const (
+	JobEventCreated   = "created"
+	JobEventStarted   = "started"
+	JobEventCompleted = "completed"
+	JobEventFailed    = "failed"
+	JobEventCancelled = "cancelled"
+)
+
+// jobEventSubjectPrefix prefixes the subjects job events are published on.
+const jobEventSubjectPrefix = "video-info.job."
+
+// JobEvent is the JSON payload of an info job lifecycle event.
+type JobEvent struct {
+	Type       string            `json:"type"`
+	Uuid       uuid.UUID         `json:"uuid"`
+	ExternalID *string           `json:"externalId,omitempty"`
+	VideoPath  string            `json:"videoPath"`
+	Labels     map[string]string `json:"labels,omitempty"`
+	Time       time.Time         `json:"time"`
+
+	// Result, Error and ErrorCode are set on completed and failed events.
+	Result    *virest.MediaInfo `json:"result,omitempty"`
+	Error     *string           `json:"error,omitempty"`
+	ErrorCode *string           `json:"errorCode,omitempty"`
+}
+
+// JobEventSubject returns the subject events of the given type are published
+// on.
+func JobEventSubject(eventType string) string {
+	return jobEventSubjectPrefix + eventType
+}
+
+// EventConn is the part of a NATS connection events are published through.
+type EventConn interface {
+	Publish(subject string, data []byte) error
+}
+
+// EventPublisher publishes info job lifecycle events.  Publishing is best
+// effort: failures are logged rather than failing the job.  A nil
+// EventPublisher publishes nothing.
+type EventPublisher struct {
+	conn EventConn
+
+	// Clock stamps the events the publisher times itself, such as those of
+	// its River middleware.  It defaults to the system clock.
+	Clock Clock
+}
+
+// NewEventPublisher returns an EventPublisher that publishes through conn.
+func NewEventPublisher(conn EventConn) *EventPublisher {
+	return &EventPublisher{conn: conn}
+}
+
+// ConnectEventPublisher connects to the NATS server at url, or returns nil if
+// url is empty.  The connection reconnects on its own for as long as it is
+// open.  The returned function closes it.
+func ConnectEventPublisher(url string) (*EventPublisher, func(), error) {
+	if url == "" {
+		return nil, func() {}, nil
+	}
+	conn, err := nats.Connect(url, nats.Name("video-info"), nats.MaxReconnects(-1))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to connect to NATS: %w", err)
+	}
+	return NewEventPublisher(conn), func() {
+		if err := conn.Drain(); err != nil {
+			log.Printf("Failed to drain NATS connection: %v", err)
+		}
+	}, nil
+}
+
+// Publish publishes an event of the given type about the info job with args
+// that happened at now.  status is the job's outcome, if it has finished.
+func (p *EventPublisher) Publish(eventType string, args InfoJobArgs, status *InfoJobStatus, now time.Time) {
+	if p == nil {
+		return
+	}
+	event := JobEvent{
+		Type:       eventType,
+		Uuid:       args.UUID,
+		ExternalID: args.ExternalID,
+		VideoPath:  args.Path,
+		Labels:     args.Labels,
+		Time:       now,
+	}
+	if status != nil {
+		event.Result = status.Result.RESTMediaInfo()
+		event.Error = status.Error
+		event.ErrorCode = status.ErrorCode
+	}
+	data, err := json.Marshal(event)
+	if err != nil {
+		log.Printf("Failed to marshal %s event for job %s: %v", eventType, args.UUID, err)
+		return
+	}
+	if err := p.conn.Publish(JobEventSubject(eventType), data); err != nil {
+		log.Printf("Failed to publish %s event for job %s: %v", eventType, args.UUID, err)
+	}
+}
+
+// PublishFinished publishes a completed or failed event for an info job that
+// finished with status.
+func (p *EventPublisher) PublishFinished(args InfoJobArgs, status *InfoJobStatus, now time.Time) {
+	eventType := JobEventCompleted
+	if status.Error != nil {
+		eventType = JobEventFailed
+	}
+	p.Publish(eventType, args, status, now)
+}
+
+// PublishCreated publishes created events for the info jobs among inserted,
+// skipping jobs of other kinds and duplicates that weren't inserted.  It is
+// called once the transaction that inserted them commits, so that no event
+// is published for a job that is rolled back.
+func (p *EventPublisher) PublishCreated(inserted ...*rivertype.JobInsertResult) {
+	if p == nil {
+		return
+	}
+	for _, result := range inserted {
+		if result.UniqueSkippedAsDuplicate {
+			continue
+		}
+		if args, ok := infoJobArgs(result.Job); ok {
+			p.Publish(JobEventCreated, args, nil, result.Job.CreatedAt)
+		}
+	}
+}
+
+// RiverMiddleware returns River middleware that publishes started and
+// failed events for info jobs worked by the client.  Jobs that finish report
+// their own outcome with PublishFinished, and jobs that are inserted with
+// PublishCreated.  It returns nil for a nil EventPublisher.
+func (p *EventPublisher) RiverMiddleware() []rivertype.Middleware {
+	if p == nil {
+		return nil
+	}
+	return []rivertype.Middleware{&jobEventMiddleware{publisher: p}}
+}
+
+// jobEventMiddleware is the River middleware returned by RiverMiddleware.
+type jobEventMiddleware struct {
+	river.MiddlewareDefaults
+	publisher *EventPublisher
+}
+
+// Work publishes a started event before working an info job, and a failed
+// event if its last attempt fails without finishing it.
+func (m *jobEventMiddleware) Work(ctx context.Context, job *rivertype.JobRow, doInner func(context.Context) error) error {
+	args, ok := infoJobArgs(job)
+	if !ok {
+		return doInner(ctx)
+	}
+	clock := OrSystemClock(m.publisher.Clock)
+	m.publisher.Publish(JobEventStarted, args, nil, clock.Now())
+
+	err := doInner(ctx)
+	var cancelErr *rivertype.JobCancelError
+	var snoozeErr *rivertype.JobSnoozeError
+	if err != nil && job.Attempt >= job.MaxAttempts && !errors.As(err, &cancelErr) && !errors.As(err, &snoozeErr) {
+		errMsg := Redact(err.Error())
+		m.publisher.Publish(JobEventFailed, args, &InfoJobStatus{Error: &errMsg}, clock.Now())
+	}
+	return err
+}
+
+// infoJobArgs returns the arguments of job, if it is an info job.
+func infoJobArgs(job *rivertype.JobRow) (InfoJobArgs, bool) {
+	var args InfoJobArgs
+	if job == nil || job.Kind != args.Kind() {
+		return args, false
+	}
+	if err := json.Unmarshal(job.EncodedArgs, &args); err != nil {
+		log.Printf("Failed to unmarshal args of info job %d for its event: %v", job.ID, err)
+		return args, false
+	}
+	return args, true
+}
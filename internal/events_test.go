@@ -0,0 +1,177 @@
+package internal_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/krelinga/go-libs/deep"
+	"github.com/krelinga/go-libs/exam"
+	"github.com/krelinga/video-info/internal"
+	"github.com/riverqueue/river"
+	"github.com/riverqueue/river/rivertype"
+)
+
+// fakeEventConn records the messages published through it.
+type fakeEventConn struct {
+	subjects []string
+	events   []internal.JobEvent
+}
+
+func (c *fakeEventConn) Publish(subject string, data []byte) error {
+	var event internal.JobEvent
+	if err := json.Unmarshal(data, &event); err != nil {
+		return err
+	}
+	c.subjects = append(c.subjects, subject)
+	c.events = append(c.events, event)
+	return nil
+}
+
+func TestEventPublisher(t *testing.T) {
+	e := exam.New(t)
+	env := deep.NewEnv()
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	externalID := "ext-1"
+	args := internal.InfoJobArgs{UUID: uuid.New(), ExternalID: &externalID, Path: "/videos/a.mkv"}
+
+	e.Run("Completed", func(e exam.E) {
+		conn := &fakeEventConn{}
+		internal.NewEventPublisher(conn).PublishFinished(args, &internal.InfoJobStatus{Result: &internal.InfoJobResult{}}, now)
+		exam.Equal(e, env, []string{"video-info.job.completed"}, conn.subjects)
+		event := conn.events[0]
+		exam.Equal(e, env, internal.JobEventCompleted, event.Type)
+		exam.Equal(e, env, args.UUID.String(), event.Uuid.String())
+		exam.Equal(e, env, "/videos/a.mkv", event.VideoPath)
+		exam.Equal(e, env, true, event.Time.Equal(now))
+		exam.Equal(e, env, true, event.Result != nil)
+	})
+
+	e.Run("Failed", func(e exam.E) {
+		conn := &fakeEventConn{}
+		errMsg := "no such file"
+		internal.NewEventPublisher(conn).PublishFinished(args, &internal.InfoJobStatus{Error: &errMsg}, now)
+		exam.Equal(e, env, []string{"video-info.job.failed"}, conn.subjects)
+		exam.Equal(e, env, &errMsg, conn.events[0].Error)
+	})
+
+	e.Run("Nil publisher", func(e exam.E) {
+		var p *internal.EventPublisher
+		p.Publish(internal.JobEventCreated, args, nil, now)
+		exam.Equal(e, env, 0, len(p.RiverMiddleware()))
+	})
+}
+
+func TestEventPublisherPublishCreated(t *testing.T) {
+	e := exam.New(t)
+	env := deep.NewEnv()
+	createdAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	args := internal.InfoJobArgs{UUID: uuid.New(), Path: "/videos/a.mkv"}
+	encodedArgs, err := json.Marshal(args)
+	if err != nil {
+		e.Fatal(err)
+	}
+	infoJob := &rivertype.JobRow{ID: 1, Kind: args.Kind(), EncodedArgs: encodedArgs, CreatedAt: createdAt}
+	otherJob := &rivertype.JobRow{ID: 2, Kind: internal.WebhookJobArgs{}.Kind(), EncodedArgs: []byte(`{}`)}
+
+	conn := &fakeEventConn{}
+	internal.NewEventPublisher(conn).PublishCreated(
+		&rivertype.JobInsertResult{Job: infoJob},
+		&rivertype.JobInsertResult{Job: otherJob},
+		&rivertype.JobInsertResult{Job: infoJob, UniqueSkippedAsDuplicate: true},
+	)
+	exam.Equal(e, env, []string{"video-info.job.created"}, conn.subjects)
+	exam.Equal(e, env, args.UUID.String(), conn.events[0].Uuid.String())
+	exam.Equal(e, env, true, conn.events[0].Time.Equal(createdAt))
+
+	var p *internal.EventPublisher
+	p.PublishCreated(&rivertype.JobInsertResult{Job: infoJob})
+}
+
+func TestEventPublisherRiverMiddleware(t *testing.T) {
+	e := exam.New(t)
+	env := deep.NewEnv()
+	args := internal.InfoJobArgs{UUID: uuid.New(), Path: "/videos/a.mkv"}
+	encodedArgs, err := json.Marshal(args)
+	if err != nil {
+		e.Fatal(err)
+	}
+	infoJob := &rivertype.JobRow{ID: 1, Kind: args.Kind(), EncodedArgs: encodedArgs, Attempt: 1, MaxAttempts: 2}
+	otherJob := &rivertype.JobRow{ID: 2, Kind: internal.WebhookJobArgs{}.Kind(), EncodedArgs: []byte(`{}`)}
+
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	newMiddleware := func(e exam.E) (*fakeEventConn, rivertype.Middleware) {
+		conn := &fakeEventConn{}
+		publisher := internal.NewEventPublisher(conn)
+		publisher.Clock = internal.NewFakeClock(now)
+		middleware := publisher.RiverMiddleware()
+		if len(middleware) != 1 {
+			e.Fatalf("got %d middleware, want 1", len(middleware))
+		}
+		return conn, middleware[0]
+	}
+
+	e.Run("Inserts are left to PublishCreated", func(e exam.E) {
+		_, middleware := newMiddleware(e)
+		_, ok := middleware.(rivertype.JobInsertMiddleware)
+		exam.Equal(e, env, false, ok)
+	})
+
+	tests := []struct {
+		loc          exam.Loc
+		name         string
+		job          *rivertype.JobRow
+		err          error
+		wantSubjects []string
+	}{
+		{
+			loc:          exam.Here(),
+			name:         "Worked",
+			job:          infoJob,
+			wantSubjects: []string{"video-info.job.started"},
+		},
+		{
+			loc:          exam.Here(),
+			name:         "Attempt failed",
+			job:          infoJob,
+			err:          errors.New("database unreachable"),
+			wantSubjects: []string{"video-info.job.started"},
+		},
+		{
+			loc:          exam.Here(),
+			name:         "Last attempt failed",
+			job:          &rivertype.JobRow{ID: 1, Kind: args.Kind(), EncodedArgs: encodedArgs, Attempt: 2, MaxAttempts: 2},
+			err:          errors.New("database unreachable"),
+			wantSubjects: []string{"video-info.job.started", "video-info.job.failed"},
+		},
+		{
+			loc:          exam.Here(),
+			name:         "Last attempt cancelled",
+			job:          &rivertype.JobRow{ID: 1, Kind: args.Kind(), EncodedArgs: encodedArgs, Attempt: 2, MaxAttempts: 2},
+			err:          river.JobCancel(errors.New("cancelled")),
+			wantSubjects: []string{"video-info.job.started"},
+		},
+		{
+			loc:  exam.Here(),
+			name: "Other kind",
+			job:  otherJob,
+		},
+	}
+	for _, tt := range tests {
+		e.Run(tt.name, func(e exam.E) {
+			e.Log("Running test at", tt.loc)
+			conn, middleware := newMiddleware(e)
+			err := middleware.(rivertype.WorkerMiddleware).Work(context.Background(), tt.job, func(context.Context) error {
+				return tt.err
+			})
+			exam.Equal(e, env, tt.err, err)
+			exam.Equal(e, env, tt.wantSubjects, conn.subjects)
+			for _, event := range conn.events {
+				exam.Equal(e, env, true, event.Time.Equal(now))
+			}
+		})
+	}
+}
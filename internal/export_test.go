@@ -0,0 +1,8 @@
+package internal
+
+var (
+	BackupTables        = backupTables
+	BackupSkippedTables = backupSkippedTables
+	MigrationsFS        = migrationsFS
+	UnchangedFiles      = unchangedFiles
+)
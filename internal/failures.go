@@ -0,0 +1,115 @@
+package internal
+
+import (
+	"cmp"
+	"crypto/sha256"
+	"encoding/hex"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// ErrorCodeOther is the error class of failures without an error code.
+const ErrorCodeOther = "OTHER"
+
+// maxFailureExamples is the number of example paths kept per FailureGroup.
+const maxFailureExamples = 5
+
+// Failure is one failed info job, as considered by GroupFailures.
+type Failure struct {
+	Path      string
+	Error     string
+	ErrorCode *string
+}
+
+// FailureGroup is a set of failures with the same error class and message
+// fingerprint.
+type FailureGroup struct {
+	ErrorCode        string
+	Fingerprint      string
+	Message          string
+	Count            int
+	ExamplePaths     []string
+	CommonPathPrefix string
+}
+
+var (
+	quotedPattern = regexp.MustCompile(`"(?:[^"\\]|\\.)*"`)
+	pathPattern   = regexp.MustCompile(`/[^\s:"]+`)
+	numberPattern = regexp.MustCompile(`\d+`)
+)
+
+// NormalizeErrorMessage replaces the parts of an error message that vary
+// between otherwise identical failures, such as paths, quoted values and
+// numbers, with placeholders.
+func NormalizeErrorMessage(message string) string {
+	message = quotedPattern.ReplaceAllString(message, `"…"`)
+	message = pathPattern.ReplaceAllString(message, "<path>")
+	return numberPattern.ReplaceAllString(message, "<n>")
+}
+
+// Class returns the error class of the failure: its error code, or
+// ErrorCodeOther if it has none.
+func (f Failure) Class() string {
+	if f.ErrorCode != nil {
+		return *f.ErrorCode
+	}
+	return ErrorCodeOther
+}
+
+// Fingerprint identifies the FailureGroup the failure belongs to.
+func (f Failure) Fingerprint() string {
+	return failureFingerprint(f.Class(), NormalizeErrorMessage(f.Error))
+}
+
+func failureFingerprint(class, message string) string {
+	sum := sha256.Sum256([]byte(class + "\x00" + message))
+	return hex.EncodeToString(sum[:8])
+}
+
+// GroupFailures groups failures by error code and normalized message, largest
+// group first.
+func GroupFailures(failures []Failure) []FailureGroup {
+	type key struct{ code, message string }
+	groups := make(map[key]*FailureGroup)
+	var order []key
+	for _, f := range failures {
+		code := f.Class()
+		k := key{code, NormalizeErrorMessage(f.Error)}
+		g, ok := groups[k]
+		if !ok {
+			g = &FailureGroup{
+				ErrorCode:        k.code,
+				Fingerprint:      failureFingerprint(k.code, k.message),
+				Message:          k.message,
+				CommonPathPrefix: f.Path,
+			}
+			groups[k] = g
+			order = append(order, k)
+		}
+		g.Count++
+		if len(g.ExamplePaths) < maxFailureExamples {
+			g.ExamplePaths = append(g.ExamplePaths, f.Path)
+		}
+		g.CommonPathPrefix = commonDirPrefix(g.CommonPathPrefix, f.Path)
+	}
+
+	result := make([]FailureGroup, 0, len(order))
+	for _, k := range order {
+		result = append(result, *groups[k])
+	}
+	slices.SortStableFunc(result, func(a, b FailureGroup) int {
+		return cmp.Compare(b.Count, a.Count)
+	})
+	return result
+}
+
+// commonDirPrefix returns the longest prefix of a and b that ends with a
+// slash, or "" if they share none.
+func commonDirPrefix(a, b string) string {
+	n := 0
+	for n < len(a) && n < len(b) && a[n] == b[n] {
+		n++
+	}
+	return a[:strings.LastIndex(a[:n], "/")+1]
+}
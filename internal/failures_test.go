@@ -0,0 +1,71 @@
+package internal_test
+
+import (
+	"testing"
+
+	"github.com/krelinga/go-libs/deep"
+	"github.com/krelinga/go-libs/exam"
+	"github.com/krelinga/video-info/internal"
+)
+
+func TestNormalizeErrorMessage(t *testing.T) {
+	e := exam.New(t)
+	env := deep.NewEnv()
+
+	tests := []struct {
+		loc     exam.Loc
+		name    string
+		message string
+		want    string
+	}{
+		{
+			loc:     exam.Here(),
+			name:    "Path",
+			message: "failed to stat video file: stat /nas/archive/a.mkv: permission denied",
+			want:    "failed to stat video file: stat <path>: permission denied",
+		},
+		{
+			loc:     exam.Here(),
+			name:    "Sizes",
+			message: "video file is 2147483648 bytes, over the 1073741824 byte limit",
+			want:    "video file is <n> bytes, over the <n> byte limit",
+		},
+		{
+			loc:     exam.Here(),
+			name:    "Quoted value",
+			message: `unsupported format: directory mixes ".png" and ".jpg" files`,
+			want:    `unsupported format: directory mixes "…" and "…" files`,
+		},
+	}
+	for _, tt := range tests {
+		e.Run(tt.name, func(e exam.E) {
+			e.Log("Running test at", tt.loc)
+			exam.Equal(e, env, tt.want, internal.NormalizeErrorMessage(tt.message))
+		})
+	}
+}
+
+func TestGroupFailures(t *testing.T) {
+	e := exam.New(t)
+	env := deep.NewEnv()
+
+	permissionDenied := "PERMISSION_DENIED"
+	groups := internal.GroupFailures([]internal.Failure{
+		{Path: "/nas/archive/a.mkv", Error: "failed to stat video file: stat /nas/archive/a.mkv: permission denied", ErrorCode: &permissionDenied},
+		{Path: "/videos/b.mkv", Error: "ffprobe failed: exit status 1"},
+		{Path: "/nas/archive/old/c.mkv", Error: "failed to stat video file: stat /nas/archive/old/c.mkv: permission denied", ErrorCode: &permissionDenied},
+	})
+
+	exam.Equal(e, env, 2, len(groups))
+	exam.Equal(e, env, "PERMISSION_DENIED", groups[0].ErrorCode)
+	exam.Equal(e, env, 2, groups[0].Count)
+	exam.Equal(e, env, "failed to stat video file: stat <path>: permission denied", groups[0].Message)
+	exam.Equal(e, env, []string{"/nas/archive/a.mkv", "/nas/archive/old/c.mkv"}, groups[0].ExamplePaths)
+	exam.Equal(e, env, "/nas/archive/", groups[0].CommonPathPrefix)
+	exam.Equal(e, env, internal.ErrorCodeOther, groups[1].ErrorCode)
+	exam.Equal(e, env, 1, groups[1].Count)
+	exam.Equal(e, env, "/videos/", groups[1].CommonPathPrefix)
+
+	failure := internal.Failure{Path: "/nas/archive/d.mkv", Error: "failed to stat video file: stat /nas/archive/d.mkv: permission denied", ErrorCode: &permissionDenied}
+	exam.Equal(e, env, groups[0].Fingerprint, failure.Fingerprint())
+}
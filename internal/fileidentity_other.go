@@ -0,0 +1,11 @@
+//go:build !unix
+
+package internal
+
+import "os"
+
+// fileIdentity reports false where device and inode numbers are unavailable,
+// which disables the result cache.
+func fileIdentity(info os.FileInfo) (device, inode uint64, ok bool) {
+	return 0, 0, false
+}
@@ -0,0 +1,156 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+
+	"golang.org/x/net/http/httpproxy"
+)
+
+// maxRedirects is how many redirects a client follows, matching the
+// default of net/http.
+const maxRedirects = 10
+
+// NewHTTPClient creates an http.Client for outbound requests.
+// If proxy is non-nil, all requests are sent through it; otherwise the
+// standard HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment variables apply.
+// If policy is non-nil, every redirect is checked against it, as are the
+// hosts of requests sent through a proxy, which connects to them out of
+// reach of the client.  If policy denies private addresses and no explicit
+// proxy is configured, connections to non-public addresses are also refused
+// at dial time, except for the hop to a proxy named by the environment,
+// which may be private.  Requests made straight to a proxy's own address
+// are refused like any other.
+func NewHTTPClient(proxy *url.URL, policy *URLPolicy, timeout time.Duration) *http.Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	client := &http.Client{
+		Transport: transport,
+		Timeout:   timeout,
+	}
+	if proxy != nil {
+		transport.Proxy = http.ProxyURL(proxy)
+	} else {
+		transport.Proxy = http.ProxyFromEnvironment
+	}
+	if policy == nil {
+		return client
+	}
+
+	client.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+		if len(via) >= maxRedirects {
+			return fmt.Errorf("stopped after %d redirects", maxRedirects)
+		}
+		return policy.Check(req.Context(), req.URL)
+	}
+
+	proxyFunc := transport.Proxy
+	var proxyAddrs map[string]bool
+	if proxy == nil {
+		env := httpproxy.FromEnvironment()
+		envProxyFunc := env.ProxyFunc()
+		proxyFunc = func(req *http.Request) (*url.URL, error) {
+			return envProxyFunc(req.URL)
+		}
+		proxyAddrs = environmentProxyAddrs(env)
+	}
+	transport.Proxy = func(req *http.Request) (*url.URL, error) {
+		proxyURL, err := proxyFunc(req)
+		if err != nil || proxyURL == nil {
+			return proxyURL, err
+		}
+		if err := policy.CheckHost(req.Context(), req.URL.Hostname()); err != nil {
+			return nil, err
+		}
+		return proxyURL, nil
+	}
+
+	// An explicit proxy is the only address the client dials
+	if policy.DenyPrivate && proxy == nil {
+		dialer := &net.Dialer{
+			Timeout:   30 * time.Second,
+			KeepAlive: 30 * time.Second,
+		}
+		policyDial := func(ctx context.Context, network, address string) (net.Conn, error) {
+			return policy.DialContext(ctx, dialer.Timeout, network, address)
+		}
+		// Requests that go direct use a transport without the proxy
+		// exemption, so that only the hop to a proxy may dial its address
+		direct := transport.Clone()
+		direct.Proxy = nil
+		direct.DialContext = policyDial
+		transport.DialContext = func(ctx context.Context, network, address string) (net.Conn, error) {
+			if proxyAddrs[address] {
+				return dialer.DialContext(ctx, network, address)
+			}
+			return policyDial(ctx, network, address)
+		}
+		client.Transport = &proxyRoutingTransport{
+			proxied: transport,
+			direct:  direct,
+			proxy:   proxyFunc,
+		}
+	}
+	return client
+}
+
+// proxyRoutingTransport sends requests that proxy returns a proxy for
+// through proxied, and all others through direct.
+type proxyRoutingTransport struct {
+	proxied *http.Transport
+	direct  *http.Transport
+	proxy   func(*http.Request) (*url.URL, error)
+}
+
+func (t *proxyRoutingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	proxyURL, err := t.proxy(req)
+	if err != nil {
+		return nil, err
+	}
+	if proxyURL == nil {
+		return t.direct.RoundTrip(req)
+	}
+	return t.proxied.RoundTrip(req)
+}
+
+// CloseIdleConnections closes the idle connections of both transports,
+// for http.Client.CloseIdleConnections.
+func (t *proxyRoutingTransport) CloseIdleConnections() {
+	t.proxied.CloseIdleConnections()
+	t.direct.CloseIdleConnections()
+}
+
+// environmentProxyAddrs returns the addresses of the proxies named by env,
+// as they are passed to the dialer.
+func environmentProxyAddrs(env *httpproxy.Config) map[string]bool {
+	addrs := make(map[string]bool)
+	for _, raw := range []string{env.HTTPProxy, env.HTTPSProxy} {
+		if raw == "" {
+			continue
+		}
+		// The variables may omit the scheme, which defaults to http
+		u, err := url.Parse(raw)
+		if err != nil || u.Host == "" {
+			u, err = url.Parse("http://" + raw)
+			if err != nil {
+				continue
+			}
+		}
+		port := u.Port()
+		if port == "" {
+			switch u.Scheme {
+			case "https":
+				port = "443"
+			case "socks5", "socks5h":
+				port = "1080"
+			default:
+				port = "80"
+			}
+		}
+		addrs[net.JoinHostPort(u.Hostname(), port)] = true
+	}
+	return addrs
+}
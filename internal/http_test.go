@@ -0,0 +1,136 @@
+package internal_test
+
+import (
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/krelinga/go-libs/deep"
+	"github.com/krelinga/go-libs/exam"
+	"github.com/krelinga/go-libs/match"
+	"github.com/krelinga/video-info/internal"
+)
+
+func TestNewHTTPClient(t *testing.T) {
+	e := exam.New(t)
+	env := deep.NewEnv()
+
+	// The proxy listens on loopback, a private address the policy denies
+	var proxied []string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxied = append(proxied, r.URL.String())
+		io.WriteString(w, "proxied")
+	}))
+	defer proxy.Close()
+	t.Setenv("HTTP_PROXY", proxy.URL)
+	t.Setenv("NO_PROXY", "")
+
+	client := internal.NewHTTPClient(nil, &internal.URLPolicy{DenyPrivate: true}, 0)
+
+	e.Run("Public hosts are reached through a private environment proxy", func(e exam.E) {
+		resp, err := client.Get("http://93.184.215.14/video")
+		if err != nil {
+			e.Fatal(err)
+		}
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		exam.Equal(e, env, "proxied", string(body))
+		exam.Equal(e, env, []string{"http://93.184.215.14/video"}, proxied)
+	})
+
+	e.Run("Private hosts are refused before reaching the proxy", func(e exam.E) {
+		proxied = nil
+		_, err := client.Get("http://10.0.0.1/video")
+		exam.Match(e, env, err, match.ErrorIs(internal.ErrURLNotAllowed))
+		exam.Equal(e, env, []string(nil), proxied)
+	})
+
+	e.Run("Requests straight to the proxy's address are refused", func(e exam.E) {
+		// Loopback hosts are never proxied, so this request goes direct
+		proxied = nil
+		_, err := client.Get(proxy.URL + "/video")
+		exam.Match(e, env, err, match.ErrorIs(internal.ErrURLNotAllowed))
+		exam.Equal(e, env, []string(nil), proxied)
+	})
+}
+
+func TestNewHTTPClientRedirects(t *testing.T) {
+	e := exam.New(t)
+	env := deep.NewEnv()
+	t.Setenv("HTTP_PROXY", "")
+	t.Setenv("NO_PROXY", "")
+
+	e.Run("Redirects to denied hosts are refused", func(e exam.E) {
+		var secretRequests int
+		mux := http.NewServeMux()
+		server := httptest.NewServer(mux)
+		defer server.Close()
+		serverURL, err := url.Parse(server.URL)
+		if err != nil {
+			e.Fatal(err)
+		}
+		mux.HandleFunc("/secret", func(w http.ResponseWriter, r *http.Request) {
+			secretRequests++
+		})
+		mux.HandleFunc("/allowed", func(w http.ResponseWriter, r *http.Request) {
+			io.WriteString(w, "allowed")
+		})
+		mux.HandleFunc("/webhook", func(w http.ResponseWriter, r *http.Request) {
+			// The same server by a name the policy doesn't allow
+			http.Redirect(w, r, "http://localhost:"+serverURL.Port()+"/secret", http.StatusFound)
+		})
+		mux.HandleFunc("/moved", func(w http.ResponseWriter, r *http.Request) {
+			http.Redirect(w, r, "/allowed", http.StatusFound)
+		})
+
+		_, loopback, _ := net.ParseCIDR("127.0.0.0/8")
+		client := internal.NewHTTPClient(nil, &internal.URLPolicy{AllowedNets: []*net.IPNet{loopback}}, 0)
+		_, err = client.Get(server.URL + "/webhook")
+		exam.Match(e, env, err, match.ErrorIs(internal.ErrURLNotAllowed))
+		exam.Equal(e, env, 0, secretRequests)
+
+		resp, err := client.Get(server.URL + "/moved")
+		if err != nil {
+			e.Fatal(err)
+		}
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		exam.Equal(e, env, "allowed", string(body))
+	})
+
+	e.Run("Hosts are checked before reaching an explicit proxy", func(e exam.E) {
+		var proxied []string
+		proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			proxied = append(proxied, r.URL.String())
+			if strings.HasSuffix(r.URL.Path, "/webhook") {
+				http.Redirect(w, r, "http://169.254.169.254/latest/meta-data/", http.StatusFound)
+				return
+			}
+			io.WriteString(w, "proxied")
+		}))
+		defer proxy.Close()
+		proxyURL, err := url.Parse(proxy.URL)
+		if err != nil {
+			e.Fatal(err)
+		}
+		client := internal.NewHTTPClient(proxyURL, &internal.URLPolicy{DenyPrivate: true}, 0)
+
+		resp, err := client.Get("http://93.184.215.14/video")
+		if err != nil {
+			e.Fatal(err)
+		}
+		resp.Body.Close()
+
+		_, err = client.Get("http://93.184.215.14/webhook")
+		exam.Match(e, env, err, match.ErrorIs(internal.ErrURLNotAllowed))
+
+		_, err = client.Get("http://10.0.0.1/webhook")
+		exam.Match(e, env, err, match.ErrorIs(internal.ErrURLNotAllowed))
+
+		exam.Equal(e, env, []string{"http://93.184.215.14/video", "http://93.184.215.14/webhook"}, proxied)
+	})
+}
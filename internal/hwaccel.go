@@ -0,0 +1,50 @@
+package internal
+
+import (
+	"errors"
+	"fmt"
+)
+
+var ErrInvalidHWAccel = errors.New("invalid hardware acceleration method")
+
+// HWAccel is a hardware decoding method used by analyses that decode video.
+type HWAccel string
+
+const (
+	HWAccelNone  HWAccel = ""
+	HWAccelVAAPI HWAccel = "vaapi"
+	HWAccelNVDEC HWAccel = "nvdec"
+)
+
+// ParseHWAccel validates a hardware acceleration method name.  An empty name
+// or "none" disables hardware decoding.
+func ParseHWAccel(name string) (HWAccel, error) {
+	switch HWAccel(name) {
+	case HWAccelNone, "none":
+		return HWAccelNone, nil
+	case HWAccelVAAPI, HWAccelNVDEC:
+		return HWAccel(name), nil
+	default:
+		return HWAccelNone, fmt.Errorf("%w: %q", ErrInvalidHWAccel, name)
+	}
+}
+
+// InputArgs returns the ffmpeg options that enable the method, to be placed
+// before the input they apply to.  device optionally selects the device, such
+// as a DRM render node for VAAPI or a GPU index for NVDEC.
+func (h HWAccel) InputArgs(device string) []string {
+	var args []string
+	switch h {
+	case HWAccelVAAPI:
+		args = []string{"-hwaccel", "vaapi"}
+	case HWAccelNVDEC:
+		// nvdec is an alias of cuda in current ffmpeg releases
+		args = []string{"-hwaccel", "cuda"}
+	default:
+		return nil
+	}
+	if device != "" {
+		args = append(args, "-hwaccel_device", device)
+	}
+	return args
+}
@@ -0,0 +1,75 @@
+package internal_test
+
+import (
+	"testing"
+
+	"github.com/krelinga/go-libs/deep"
+	"github.com/krelinga/go-libs/exam"
+	"github.com/krelinga/go-libs/match"
+	"github.com/krelinga/video-info/internal"
+)
+
+func TestHWAccel(t *testing.T) {
+	e := exam.New(t)
+	env := deep.NewEnv()
+
+	tests := []struct {
+		loc      exam.Loc
+		name     string
+		hwaccel  string
+		device   string
+		wantArgs []string
+		wantErr  bool
+	}{
+		{
+			loc:     exam.Here(),
+			name:    "Unset",
+			hwaccel: "",
+		},
+		{
+			loc:     exam.Here(),
+			name:    "None",
+			hwaccel: "none",
+			device:  "/dev/dri/renderD128",
+		},
+		{
+			loc:      exam.Here(),
+			name:     "VAAPI",
+			hwaccel:  "vaapi",
+			wantArgs: []string{"-hwaccel", "vaapi"},
+		},
+		{
+			loc:      exam.Here(),
+			name:     "VAAPI with device",
+			hwaccel:  "vaapi",
+			device:   "/dev/dri/renderD128",
+			wantArgs: []string{"-hwaccel", "vaapi", "-hwaccel_device", "/dev/dri/renderD128"},
+		},
+		{
+			loc:      exam.Here(),
+			name:     "NVDEC with device",
+			hwaccel:  "nvdec",
+			device:   "1",
+			wantArgs: []string{"-hwaccel", "cuda", "-hwaccel_device", "1"},
+		},
+		{
+			loc:     exam.Here(),
+			name:    "Unsupported",
+			hwaccel: "videotoolbox",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		e.Run(tt.name, func(e exam.E) {
+			e.Log("Running test at", tt.loc)
+
+			got, err := internal.ParseHWAccel(tt.hwaccel)
+			if tt.wantErr {
+				exam.Match(e, env, err, match.ErrorIs(internal.ErrInvalidHWAccel))
+				return
+			}
+			exam.Nil(e, env, err)
+			exam.Equal(e, env, tt.wantArgs, got.InputArgs(tt.device))
+		})
+	}
+}
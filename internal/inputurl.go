@@ -0,0 +1,79 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+)
+
+// ErrInvalidURLScheme is returned for URL schemes that can't be probed.
+var ErrInvalidURLScheme = errors.New("invalid URL scheme")
+
+// urlInputSchemes are the schemes of URLs that may be given as video paths.
+var urlInputSchemes = []string{"http", "https", "sftp", "smb"}
+
+// fetchedURLSchemes are the schemes of URLs whose files the worker copies to
+// local disk before probing, since ffprobe can't read them itself.
+var fetchedURLSchemes = []string{"sftp", "smb"}
+
+// ParseURLInputSchemes validates a list of URL schemes that may be given as
+// video paths and returns them in lower case.
+func ParseURLInputSchemes(schemes []string) ([]string, error) {
+	var parsed []string
+	for _, scheme := range schemes {
+		scheme = strings.ToLower(scheme)
+		if !slices.Contains(urlInputSchemes, scheme) {
+			return nil, fmt.Errorf("%w: %q is not one of %s", ErrInvalidURLScheme, scheme, strings.Join(urlInputSchemes, ", "))
+		}
+		parsed = append(parsed, scheme)
+	}
+	return parsed, nil
+}
+
+// IsInputURL reports whether videoPath is a URL rather than a file path.
+func IsInputURL(videoPath string) bool {
+	scheme, _, ok := strings.Cut(videoPath, "://")
+	return ok && scheme != "" && !strings.Contains(scheme, "/")
+}
+
+// IsFetchedURLScheme reports whether the files named by URLs with the given
+// scheme are fetched before being probed rather than streamed to ffprobe.
+func IsFetchedURLScheme(scheme string) bool {
+	return slices.Contains(fetchedURLSchemes, strings.ToLower(scheme))
+}
+
+// IsFetchedInputURL reports whether videoURL is a URL whose file is fetched
+// before being probed.
+func IsFetchedInputURL(videoURL string) bool {
+	scheme, _, _ := strings.Cut(videoURL, "://")
+	return IsInputURL(videoURL) && IsFetchedURLScheme(scheme)
+}
+
+// CheckInputURL returns ErrPathNotAllowed unless videoURL is a well-formed
+// URL with a host and one of the allowed schemes, and its host passes
+// policy if policy is non-nil.  No schemes are allowed if allowed is empty.
+// Credentials belong in the worker's configuration rather than in jobs,
+// which store their paths in the clear, and callers may not choose the
+// account the worker logs in as, so no URL may carry a user or password.
+func CheckInputURL(ctx context.Context, videoURL string, allowed []string, policy *URLPolicy) error {
+	u, err := url.Parse(videoURL)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrPathNotAllowed, err)
+	}
+	if u.Host == "" {
+		return fmt.Errorf("%w: URL has no host", ErrPathNotAllowed)
+	}
+	if !slices.Contains(allowed, strings.ToLower(u.Scheme)) {
+		return fmt.Errorf("%w: %s URLs are not allowed", ErrPathNotAllowed, u.Scheme)
+	}
+	if u.User != nil {
+		return fmt.Errorf("%w: %s URLs may not include credentials", ErrPathNotAllowed, u.Scheme)
+	}
+	if err := policy.CheckHost(ctx, u.Hostname()); err != nil {
+		return fmt.Errorf("%w: %w", ErrPathNotAllowed, err)
+	}
+	return nil
+}
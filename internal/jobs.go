@@ -1,17 +1,140 @@
 package internal
 
 import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
+
 	"github.com/google/uuid"
 	"github.com/krelinga/video-info/virest"
+	"github.com/riverqueue/river"
 )
 
+// QueueGPU is the River queue for info jobs that require a GPU.  Only workers
+// with GPU capacity work it.
+const QueueGPU = "gpu"
+
 // InfoJobArgs contains the arguments for an info job.
 // This is used as the River job args payload.
 type InfoJobArgs struct {
-	UUID         uuid.UUID `json:"uuid"`
-	Path         string    `json:"path"`
-	WebhookURI   *string   `json:"webhook_uri,omitempty"`
-	WebhookToken []byte    `json:"webhook_token,omitempty"`
+	UUID         uuid.UUID         `json:"uuid"`
+	ExternalID   *string           `json:"external_id,omitempty"`
+	Path         string            `json:"path"`
+	WebhookURI   *string           `json:"webhook_uri,omitempty"`
+	WebhookToken []byte            `json:"webhook_token,omitempty"`
+	Labels       map[string]string `json:"labels,omitempty"`
+
+	// WebhookHeaders are extra HTTP headers sent with the webhook, such as
+	// an Authorization header for a receiver behind an auth gateway.
+	WebhookHeaders map[string]string `json:"webhook_headers,omitempty"`
+
+	// SealedWebhook holds the webhook URI, token and headers encrypted with
+	// the secrets keyring, in place of WebhookURI, WebhookToken and
+	// WebhookHeaders.
+	SealedWebhook []byte `json:"sealed_webhook,omitempty"`
+
+	// Webhooks are further receivers of the webhook, each delivered by a
+	// webhook job of its own.
+	Webhooks []WebhookReceiver `json:"webhooks,omitempty"`
+
+	// Resources is the resource class the job requires.  Empty means CPU
+	// only, matching jobs created before resource classes existed.
+	Resources virest.Resources `json:"resources,omitempty"`
+
+	// WebhookRetry, if set, overrides the worker's webhook retry policy.
+	WebhookRetry *WebhookRetryPolicy `json:"webhook_retry,omitempty"`
+
+	// WebhookBatch, if set, delivers the webhook together with others to
+	// the same URI.
+	WebhookBatch *WebhookBatching `json:"webhook_batch,omitempty"`
+
+	// WebhookEvents, if set, are the statuses reported by webhooks, such as
+	// WebhookStarted.  Unset means every status the job may finish with.
+	WebhookEvents []string `json:"webhook_events,omitempty"`
+
+	// Priority is the River priority the job was submitted with, from
+	// PriorityHighest to PriorityLowest.  Zero means PriorityHighest.  The
+	// job's actual priority rises as it ages; see AgeInfoJobPriorities.
+	Priority int `json:"priority,omitempty"`
+
+	// AnalyzeCrop requests crop detection.
+	AnalyzeCrop bool `json:"analyze_crop,omitempty"`
+
+	// AnalyzeLoudness requests loudness analysis of every audio track.
+	AnalyzeLoudness bool `json:"analyze_loudness,omitempty"`
+
+	// Verify requests decoding the whole file to check it for corruption.
+	Verify bool `json:"verify,omitempty"`
+
+	// SampleWindows, if positive, limits loudness analysis and decode
+	// verification to that many windows of the file.
+	SampleWindows int `json:"sample_windows,omitempty"`
+
+	// Checksum, if set, requests a checksum of the file's contents.
+	Checksum virest.ChecksumAlgorithm `json:"checksum,omitempty"`
+
+	// Analyzers names the analyzer plugins to run.
+	Analyzers []string `json:"analyzers,omitempty"`
+
+	// IncludeRaw requests the complete ffprobe output in the result.
+	IncludeRaw bool `json:"include_raw,omitempty"`
+
+	// QueueName, if set, is the River queue for jobs that don't require a
+	// GPU.  Empty means River's default queue.
+	QueueName string `json:"queue,omitempty"`
+
+	// Canary is set for jobs the server routed to its canary queue.  They
+	// are always probed, and their results are compared with those of other
+	// workers rather than cached.
+	Canary bool `json:"canary,omitempty"`
+
+	// Comparison, if set, is the ID of the comparison the job is a side of.
+	// Like canaries, these jobs are always probed and their results aren't
+	// cached.
+	Comparison *uuid.UUID `json:"comparison,omitempty"`
+
+	// TimeoutSeconds, if positive, bounds how long the job may run.
+	TimeoutSeconds int `json:"timeout_seconds,omitempty"`
+
+	// Force requests probing the file even if a result for its current
+	// state is cached.
+	Force bool `json:"force,omitempty"`
+
+	// Supersedes, if set, is the UUID of the earlier job that this job
+	// probes the same video again for.
+	Supersedes *uuid.UUID `json:"supersedes,omitempty"`
+}
+
+// Analyses selects the optional analyses an info job runs in addition to
+// reading the file's metadata.
+type Analyses struct {
+	Crop          bool
+	Loudness      bool
+	Verify        bool
+	SampleWindows int
+	Checksum      virest.ChecksumAlgorithm
+	Analyzers     []string
+	Raw           bool
+}
+
+// ReadsContent reports whether any of the analyses read the media itself,
+// rather than only the container metadata that ffprobe reads.
+func (a Analyses) ReadsContent() bool {
+	return a.Crop || a.Loudness || a.Verify || a.Checksum != "" || len(a.Analyzers) > 0
+}
+
+// Analyses returns the optional analyses requested for the job.
+func (a InfoJobArgs) Analyses() Analyses {
+	return Analyses{
+		Crop:          a.AnalyzeCrop,
+		Loudness:      a.AnalyzeLoudness,
+		Verify:        a.Verify,
+		SampleWindows: a.SampleWindows,
+		Checksum:      a.Checksum,
+		Analyzers:     a.Analyzers,
+		Raw:           a.IncludeRaw,
+	}
 }
 
 // Kind returns the job kind identifier for River.
@@ -19,35 +142,695 @@ func (InfoJobArgs) Kind() string {
 	return "info"
 }
 
+// InsertOpts places the job on its queue, at its priority.
+func (a InfoJobArgs) InsertOpts() river.InsertOpts {
+	return river.InsertOpts{Queue: a.Queue(), Priority: a.Priority}
+}
+
+// Queue returns the River queue for the job: the GPU queue if it requires a
+// GPU, otherwise its named queue.
+func (a InfoJobArgs) Queue() string {
+	if a.Resources == virest.Gpu {
+		return QueueGPU
+	}
+	if a.QueueName != "" {
+		return a.QueueName
+	}
+	return river.QueueDefault
+}
+
+// Timeout returns how long the job may run, or zero if it isn't bounded.
+func (a InfoJobArgs) Timeout() time.Duration {
+	return time.Duration(a.TimeoutSeconds) * time.Second
+}
+
+// RESTPriority returns the priority the job was submitted with.
+func (a InfoJobArgs) RESTPriority() int {
+	if a.Priority == 0 {
+		return PriorityHighest
+	}
+	return a.Priority
+}
+
+// RESTResources returns the job's resource class, defaulting to CPU.
+func (a InfoJobArgs) RESTResources() virest.Resources {
+	if a.Resources == "" {
+		return virest.Cpu
+	}
+	return a.Resources
+}
+
+// SealWebhook moves the webhook URI, token and headers into SealedWebhook,
+// and those of each of Webhooks into its Sealed field, so they are not
+// stored in plaintext.  It does nothing if keyring is nil.
+func (a *InfoJobArgs) SealWebhook(keyring *Keyring) error {
+	if keyring == nil {
+		return nil
+	}
+	if a.WebhookURI != nil {
+		sealed, err := sealWebhookSecrets(keyring, a.UUID, WebhookSecrets{URI: *a.WebhookURI, Token: a.WebhookToken, Headers: a.WebhookHeaders})
+		if err != nil {
+			return err
+		}
+		a.SealedWebhook = sealed
+		a.WebhookURI = nil
+		a.WebhookToken = nil
+		a.WebhookHeaders = nil
+	}
+	for i := range a.Webhooks {
+		target := &a.Webhooks[i]
+		if target.Sealed != nil {
+			continue
+		}
+		sealed, err := sealWebhookSecrets(keyring, a.UUID, WebhookSecrets{URI: target.URI, Token: target.Token, Headers: target.Headers})
+		if err != nil {
+			return err
+		}
+		target.Sealed = sealed
+		target.URI = ""
+		target.Token = nil
+		target.Headers = nil
+	}
+	return nil
+}
+
+// sealWebhookSecrets seals the webhook secrets of the info job with the
+// given UUID, as WebhookJobArgs.Secrets opens them.
+func sealWebhookSecrets(keyring *Keyring, jobUUID uuid.UUID, secrets WebhookSecrets) ([]byte, error) {
+	plaintext, err := json.Marshal(secrets)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal webhook secrets: %w", err)
+	}
+	sealed, err := keyring.Seal(plaintext, jobUUID[:])
+	if err != nil {
+		return nil, fmt.Errorf("failed to seal webhook secrets: %w", err)
+	}
+	return sealed, nil
+}
+
+// WebhookArgs returns the webhook jobs that report status for this info job,
+// one for each target, with the given outcome as in WebhookJobArgs.  It
+// returns none if no webhook was requested, or if the job's webhook events
+// exclude the status reported.
+func (a InfoJobArgs) WebhookArgs(outcome string, status *InfoJobStatus) []WebhookJobArgs {
+	base := WebhookJobArgs{
+		Uuid:       a.UUID,
+		ExternalID: a.ExternalID,
+		Labels:     a.Labels,
+		Status:     status,
+		Retry:      a.WebhookRetry,
+		Outcome:    outcome,
+	}
+	if !a.notifiesWebhook(base.NotificationStatus()) {
+		return nil
+	}
+	var webhooks []WebhookJobArgs
+	if a.WebhookURI != nil || a.SealedWebhook != nil {
+		args := base
+		if a.WebhookURI != nil {
+			args.URI = *a.WebhookURI
+		}
+		args.Token = a.WebhookToken
+		args.Headers = a.WebhookHeaders
+		args.Sealed = a.SealedWebhook
+		args.Batch = a.WebhookBatch
+		webhooks = append(webhooks, args)
+	}
+	for _, target := range a.Webhooks {
+		args := base
+		args.URI = target.URI
+		args.Token = target.Token
+		args.Headers = target.Headers
+		args.Sealed = target.Sealed
+		args.Batch = target.Batch
+		webhooks = append(webhooks, args)
+	}
+	return webhooks
+}
+
+// notifiesWebhook reports whether webhooks report the given status.
+func (a InfoJobArgs) notifiesWebhook(status string) bool {
+	if len(a.WebhookEvents) == 0 {
+		return status != WebhookStarted
+	}
+	return slices.Contains(a.WebhookEvents, status)
+}
+
 type InfoJobResult struct {
-	DurationSeconds         float64   `json:"duration_seconds"`
-	ChapterDurationsSeconds []float64 `json:"chapter_durations_seconds"`
+	// MediaKind is empty for results recorded before other kinds of media
+	// were supported, which were all video.
+	MediaKind               virest.MediaKind `json:"media_kind,omitempty"`
+	DurationSeconds         float64          `json:"duration_seconds"`
+	ChapterDurationsSeconds []float64        `json:"chapter_durations_seconds"`
+	Chapters                []Chapter        `json:"chapters,omitempty"`
+	Container               *Container       `json:"container,omitempty"`
+	VideoStreams            []VideoStream    `json:"video_streams,omitempty"`
+	AudioTracks             []AudioTrack     `json:"audio_tracks,omitempty"`
+	SubtitleTracks          []SubtitleTrack  `json:"subtitle_tracks,omitempty"`
+	FrameCount              *int             `json:"frame_count,omitempty"`
+
+	// TimelineDurationSeconds is set when the default edition uses ordered
+	// chapters, whose virtual timeline differs from the file's duration.
+	TimelineDurationSeconds *float64       `json:"timeline_duration_seconds,omitempty"`
+	Editions                []MediaEdition `json:"editions,omitempty"`
+
+	// LinkedSegments lists other Matroska segments the file refers to.
+	LinkedSegments []LinkedSegment `json:"linked_segments,omitempty"`
+
+	// SuggestedPreset is the encoding preset suggested by the worker's
+	// preset rules, if any matched.
+	SuggestedPreset *string `json:"suggested_preset,omitempty"`
+
+	// Crop is set when crop detection was requested and succeeded.
+	Crop *CropDetection `json:"crop,omitempty"`
+
+	// Verification is set when decode verification was requested and ran.
+	Verification *DecodeVerification `json:"verification,omitempty"`
+
+	// Sampling is set when loudness analysis or decode verification sampled
+	// windows of the file.
+	Sampling *SamplingCoverage `json:"sampling,omitempty"`
+
+	// Checksum is set when a checksum was requested and computed.
+	Checksum *FileChecksum `json:"checksum,omitempty"`
+
+	// Extensions holds the output of analyzer plugins, keyed by their names.
+	Extensions map[string]any `json:"extensions,omitempty"`
+
+	// RawProbe is the complete ffprobe output the result was built from.
+	// It is only kept when requested.
+	RawProbe map[string]any `json:"raw_probe,omitempty"`
+
+	// Warnings describe requested analyses that were skipped or failed
+	// without failing the job.
+	Warnings []string `json:"warnings,omitempty"`
+
+	// Versions is set when the result was probed, and cached with it.
+	Versions *ToolVersions `json:"versions,omitempty"`
+}
+
+// VideoStream describes one video stream of a probed file.
+type VideoStream struct {
+	Index       int      `json:"index"`
+	CodecName   string   `json:"codec_name"`
+	Profile     *string  `json:"profile,omitempty"`
+	Level       *int     `json:"level,omitempty"`
+	Width       int      `json:"width"`
+	Height      int      `json:"height"`
+	PixelFormat *string  `json:"pixel_format,omitempty"`
+	FrameRate   *float64 `json:"frame_rate,omitempty"`
+	BitRate     *int64   `json:"bit_rate,omitempty"`
+}
+
+// Container describes the container format of a probed file.
+type Container struct {
+	FormatName     string     `json:"format_name"`
+	FormatLongName *string    `json:"format_long_name,omitempty"`
+	BitRate        *int64     `json:"bit_rate,omitempty"`
+	SizeBytes      int64      `json:"size_bytes"`
+	Title          *string    `json:"title,omitempty"`
+	Encoder        *string    `json:"encoder,omitempty"`
+	CreationTime   *time.Time `json:"creation_time,omitempty"`
+}
+
+// Chapter is a chapter of a probed file as reported by ffprobe.
+type Chapter struct {
+	Title        *string `json:"title,omitempty"`
+	StartSeconds float64 `json:"start_seconds"`
+	EndSeconds   float64 `json:"end_seconds"`
+}
+
+// MediaEdition is a Matroska chapter edition of a probed file.
+type MediaEdition struct {
+	UID             string           `json:"uid"`
+	Default         bool             `json:"default"`
+	Ordered         bool             `json:"ordered"`
+	Hidden          bool             `json:"hidden"`
+	DurationSeconds float64          `json:"duration_seconds"`
+	Chapters        []EditionChapter `json:"chapters"`
+}
+
+// EditionChapter is a top-level chapter of a MediaEdition.
+type EditionChapter struct {
+	Title        *string `json:"title,omitempty"`
+	StartSeconds float64 `json:"start_seconds"`
+	EndSeconds   float64 `json:"end_seconds"`
+	Enabled      bool    `json:"enabled"`
+	SegmentUID   *string `json:"segment_uid,omitempty"`
+}
+
+// LinkedSegment is another Matroska segment a probed file refers to by UID.
+// Present and File record whether a file holding it was found next to the
+// probed file.
+type LinkedSegment struct {
+	UID     string  `json:"uid"`
+	Present bool    `json:"present"`
+	File    *string `json:"file,omitempty"`
+}
+
+// AudioTrack describes one audio stream of a probed file.
+type AudioTrack struct {
+	Index         int     `json:"index"`
+	CodecName     string  `json:"codec_name"`
+	Channels      int     `json:"channels"`
+	ChannelLayout *string `json:"channel_layout,omitempty"`
+	SampleRate    *int    `json:"sample_rate,omitempty"`
+	BitRate       *int64  `json:"bit_rate,omitempty"`
+	Language      *string `json:"language,omitempty"`
+	Default       bool    `json:"default"`
+	Forced        bool    `json:"forced"`
+
+	// Loudness is set when loudness analysis was requested and succeeded.
+	Loudness *AudioLoudness `json:"loudness,omitempty"`
 }
 
-func (r *InfoJobResult) RESTVideoInfo() *virest.VideoInfo {
+// SubtitleTrack describes one subtitle stream of a probed file.
+type SubtitleTrack struct {
+	Index           int     `json:"index"`
+	CodecName       string  `json:"codec_name"`
+	Language        *string `json:"language,omitempty"`
+	Title           *string `json:"title,omitempty"`
+	Default         bool    `json:"default"`
+	Forced          bool    `json:"forced"`
+	HearingImpaired bool    `json:"hearing_impaired"`
+}
+
+func (r *InfoJobResult) RESTMediaInfo() *virest.MediaInfo {
 	if r == nil {
 		return nil
 	}
-	return &virest.VideoInfo{
+	var chapters []virest.Chapter
+	for _, c := range r.Chapters {
+		chapters = append(chapters, virest.Chapter{
+			Title:        c.Title,
+			StartSeconds: c.StartSeconds,
+			EndSeconds:   c.EndSeconds,
+		})
+	}
+	var linkedSegments []virest.LinkedSegment
+	for _, s := range r.LinkedSegments {
+		linkedSegments = append(linkedSegments, virest.LinkedSegment{
+			Uid:     s.UID,
+			Present: s.Present,
+			File:    s.File,
+		})
+	}
+	var container *virest.Container
+	if c := r.Container; c != nil {
+		container = &virest.Container{
+			FormatName:     c.FormatName,
+			FormatLongName: c.FormatLongName,
+			BitRate:        c.BitRate,
+			SizeBytes:      c.SizeBytes,
+			Title:          c.Title,
+			Encoder:        c.Encoder,
+			CreationTime:   c.CreationTime,
+		}
+	}
+	var videoStreams []virest.VideoStream
+	for _, s := range r.VideoStreams {
+		videoStreams = append(videoStreams, virest.VideoStream{
+			Index:       s.Index,
+			CodecName:   s.CodecName,
+			Profile:     s.Profile,
+			Level:       s.Level,
+			Width:       s.Width,
+			Height:      s.Height,
+			PixelFormat: s.PixelFormat,
+			FrameRate:   s.FrameRate,
+			BitRate:     s.BitRate,
+		})
+	}
+	var audioTracks []virest.AudioTrack
+	for _, t := range r.AudioTracks {
+		audioTracks = append(audioTracks, virest.AudioTrack{
+			Index:         t.Index,
+			CodecName:     t.CodecName,
+			Channels:      t.Channels,
+			ChannelLayout: t.ChannelLayout,
+			SampleRate:    t.SampleRate,
+			BitRate:       t.BitRate,
+			Language:      t.Language,
+			Default:       t.Default,
+			Forced:        t.Forced,
+			Loudness:      t.Loudness.RESTAudioLoudness(),
+		})
+	}
+	var subtitleTracks []virest.SubtitleTrack
+	for _, t := range r.SubtitleTracks {
+		subtitleTracks = append(subtitleTracks, virest.SubtitleTrack{
+			Index:           t.Index,
+			CodecName:       t.CodecName,
+			Language:        t.Language,
+			Title:           t.Title,
+			Default:         t.Default,
+			Forced:          t.Forced,
+			HearingImpaired: t.HearingImpaired,
+		})
+	}
+	var editions []virest.Edition
+	for _, ed := range r.Editions {
+		editionChapters := make([]virest.EditionChapter, 0, len(ed.Chapters))
+		for _, c := range ed.Chapters {
+			editionChapters = append(editionChapters, virest.EditionChapter{
+				Title:        c.Title,
+				StartSeconds: c.StartSeconds,
+				EndSeconds:   c.EndSeconds,
+				Enabled:      c.Enabled,
+				SegmentUid:   c.SegmentUID,
+			})
+		}
+		editions = append(editions, virest.Edition{
+			Uid:             ed.UID,
+			Default:         ed.Default,
+			Ordered:         ed.Ordered,
+			Hidden:          ed.Hidden,
+			DurationSeconds: ed.DurationSeconds,
+			Chapters:        editionChapters,
+		})
+	}
+	mediaKind := r.MediaKind
+	if mediaKind == "" {
+		mediaKind = virest.Video
+	}
+	// The property is required, so files without chapters report none
+	chapterDurations := r.ChapterDurationsSeconds
+	if chapterDurations == nil {
+		chapterDurations = []float64{}
+	}
+	return &virest.MediaInfo{
+		MediaKind:               mediaKind,
 		TotalDurationSeconds:    r.DurationSeconds,
-		ChapterDurationsSeconds: r.ChapterDurationsSeconds,
+		ChapterDurationsSeconds: chapterDurations,
+		Chapters:                chapters,
+		Container:               container,
+		VideoStreams:            videoStreams,
+		AudioTracks:             audioTracks,
+		SubtitleTracks:          subtitleTracks,
+		FrameCount:              r.FrameCount,
+		TimelineDurationSeconds: r.TimelineDurationSeconds,
+		Editions:                editions,
+		LinkedSegments:          linkedSegments,
+		SuggestedPreset:         r.SuggestedPreset,
+		Crop:                    r.Crop.RESTCropDetection(),
+		Verification:            r.Verification.RESTDecodeVerification(),
+		Sampling:                r.Sampling.RESTSamplingCoverage(),
+		Checksum:                r.Checksum.RESTFileChecksum(),
+		Extensions:              r.Extensions,
+		RawProbe:                r.RawProbe,
+		Warnings:                r.Warnings,
+		Versions:                r.Versions.RESTToolVersions(),
+	}
+}
+
+// NewInfoJobResult converts a REST MediaInfo into an InfoJobResult.
+func NewInfoJobResult(v *virest.MediaInfo) *InfoJobResult {
+	if v == nil {
+		return nil
+	}
+	var chapters []Chapter
+	for _, c := range v.Chapters {
+		chapters = append(chapters, Chapter{
+			Title:        c.Title,
+			StartSeconds: c.StartSeconds,
+			EndSeconds:   c.EndSeconds,
+		})
+	}
+	var linkedSegments []LinkedSegment
+	for _, s := range v.LinkedSegments {
+		linkedSegments = append(linkedSegments, LinkedSegment{
+			UID:     s.Uid,
+			Present: s.Present,
+			File:    s.File,
+		})
+	}
+	var container *Container
+	if c := v.Container; c != nil {
+		container = &Container{
+			FormatName:     c.FormatName,
+			FormatLongName: c.FormatLongName,
+			BitRate:        c.BitRate,
+			SizeBytes:      c.SizeBytes,
+			Title:          c.Title,
+			Encoder:        c.Encoder,
+			CreationTime:   c.CreationTime,
+		}
+	}
+	var videoStreams []VideoStream
+	for _, s := range v.VideoStreams {
+		videoStreams = append(videoStreams, VideoStream{
+			Index:       s.Index,
+			CodecName:   s.CodecName,
+			Profile:     s.Profile,
+			Level:       s.Level,
+			Width:       s.Width,
+			Height:      s.Height,
+			PixelFormat: s.PixelFormat,
+			FrameRate:   s.FrameRate,
+			BitRate:     s.BitRate,
+		})
+	}
+	var audioTracks []AudioTrack
+	for _, t := range v.AudioTracks {
+		audioTracks = append(audioTracks, AudioTrack{
+			Index:         t.Index,
+			CodecName:     t.CodecName,
+			Channels:      t.Channels,
+			ChannelLayout: t.ChannelLayout,
+			SampleRate:    t.SampleRate,
+			BitRate:       t.BitRate,
+			Language:      t.Language,
+			Default:       t.Default,
+			Forced:        t.Forced,
+			Loudness:      NewAudioLoudnessFromREST(t.Loudness),
+		})
+	}
+	var subtitleTracks []SubtitleTrack
+	for _, t := range v.SubtitleTracks {
+		subtitleTracks = append(subtitleTracks, SubtitleTrack{
+			Index:           t.Index,
+			CodecName:       t.CodecName,
+			Language:        t.Language,
+			Title:           t.Title,
+			Default:         t.Default,
+			Forced:          t.Forced,
+			HearingImpaired: t.HearingImpaired,
+		})
+	}
+	var editions []MediaEdition
+	for _, ed := range v.Editions {
+		editionChapters := make([]EditionChapter, 0, len(ed.Chapters))
+		for _, c := range ed.Chapters {
+			editionChapters = append(editionChapters, EditionChapter{
+				Title:        c.Title,
+				StartSeconds: c.StartSeconds,
+				EndSeconds:   c.EndSeconds,
+				Enabled:      c.Enabled,
+				SegmentUID:   c.SegmentUid,
+			})
+		}
+		editions = append(editions, MediaEdition{
+			UID:             ed.Uid,
+			Default:         ed.Default,
+			Ordered:         ed.Ordered,
+			Hidden:          ed.Hidden,
+			DurationSeconds: ed.DurationSeconds,
+			Chapters:        editionChapters,
+		})
+	}
+	return &InfoJobResult{
+		MediaKind:               v.MediaKind,
+		DurationSeconds:         v.TotalDurationSeconds,
+		ChapterDurationsSeconds: v.ChapterDurationsSeconds,
+		Chapters:                chapters,
+		Container:               container,
+		VideoStreams:            videoStreams,
+		AudioTracks:             audioTracks,
+		SubtitleTracks:          subtitleTracks,
+		FrameCount:              v.FrameCount,
+		TimelineDurationSeconds: v.TimelineDurationSeconds,
+		Editions:                editions,
+		LinkedSegments:          linkedSegments,
+		SuggestedPreset:         v.SuggestedPreset,
+		Crop:                    NewCropDetectionFromREST(v.Crop),
+		Verification:            NewDecodeVerificationFromREST(v.Verification),
+		Sampling:                NewSamplingCoverageFromREST(v.Sampling),
+		Checksum:                NewFileChecksumFromREST(v.Checksum),
+		Extensions:              v.Extensions,
+		RawProbe:                v.RawProbe,
+		Warnings:                v.Warnings,
+		Versions:                NewToolVersionsFromREST(v.Versions),
+	}
+}
+
+// Phases of an info job, as recorded in PhaseTiming.
+const (
+	PhaseFetch    = "fetch"
+	PhaseStat     = "stat"
+	PhaseSniff    = "sniff"
+	PhaseCache    = "cache"
+	PhaseFFprobe  = "ffprobe"
+	PhaseMatroska = "matroska"
+	PhaseSegments = "segments"
+	PhaseCrop     = "crop"
+	PhaseChecksum = "checksum"
+
+	// PhaseDecode is the pass over the whole file shared by the analyses
+	// that decode all of it.
+	PhaseDecode = "decode"
+
+	// PhaseAnalyzer is followed by ":" and the plugin's name.
+	PhaseAnalyzer = "analyzer"
+	PhaseHook     = "hook"
+)
+
+// PhaseTiming records how long one phase of an info job took.
+type PhaseTiming struct {
+	Phase           string  `json:"phase"`
+	DurationSeconds float64 `json:"duration_seconds"`
+}
+
+// RESTPhaseTimings converts timings to their REST representation.
+func RESTPhaseTimings(timings []PhaseTiming) []virest.PhaseTiming {
+	if len(timings) == 0 {
+		return nil
+	}
+	rest := make([]virest.PhaseTiming, len(timings))
+	for i, t := range timings {
+		rest[i] = virest.PhaseTiming{Phase: t.Phase, DurationSeconds: t.DurationSeconds}
+	}
+	return rest
+}
+
+// NewPhaseTimings converts REST phase timings into PhaseTimings.
+func NewPhaseTimings(rest []virest.PhaseTiming) []PhaseTiming {
+	if len(rest) == 0 {
+		return nil
+	}
+	timings := make([]PhaseTiming, len(rest))
+	for i, t := range rest {
+		timings[i] = PhaseTiming{Phase: t.Phase, DurationSeconds: t.DurationSeconds}
 	}
+	return timings
 }
 
 type InfoJobStatus struct {
-	Error  *string        `json:"error,omitempty"`
-	Result *InfoJobResult `json:"result,omitempty"`
+	Error     *string        `json:"error,omitempty"`
+	ErrorCode *string        `json:"error_code,omitempty"`
+	Result    *InfoJobResult `json:"result,omitempty"`
+	Timings   []PhaseTiming  `json:"timings,omitempty"`
+	Signed    *SignedPayload `json:"signed,omitempty"`
+}
+
+// WebhookSecrets are the parts of a webhook request that are sealed at rest.
+type WebhookSecrets struct {
+	URI     string            `json:"uri"`
+	Token   []byte            `json:"token,omitempty"`
+	Headers map[string]string `json:"headers,omitempty"`
+}
+
+// WebhookReceiver is a further receiver of an info job's webhook.  When
+// Sealed is set, URI, Token and Headers are empty, as with the job's own
+// webhook.
+type WebhookReceiver struct {
+	URI     string            `json:"uri,omitempty"`
+	Token   []byte            `json:"token,omitempty"`
+	Headers map[string]string `json:"headers,omitempty"`
+	Sealed  []byte            `json:"sealed,omitempty"`
+
+	// Batch, if set, delivers the webhook together with others to the same
+	// target.
+	Batch *WebhookBatching `json:"batch,omitempty"`
 }
 
 // WebhookJobArgs contains the arguments for a webhook notification job.
+// When Sealed is set, URI and Token are empty and must be recovered with
+// Secrets.
 type WebhookJobArgs struct {
-	URI    string         `json:"uri"`
-	Token  []byte         `json:"token,omitempty"`
-	Uuid   uuid.UUID      `json:"info_uuid"`
-	Status *InfoJobStatus `json:"status,omitempty"`
+	URI        string            `json:"uri"`
+	Token      []byte            `json:"token,omitempty"`
+	Headers    map[string]string `json:"headers,omitempty"`
+	Sealed     []byte            `json:"sealed,omitempty"`
+	Uuid       uuid.UUID         `json:"info_uuid"`
+	ExternalID *string           `json:"external_id,omitempty"`
+	Labels     map[string]string `json:"labels,omitempty"`
+	Status     *InfoJobStatus    `json:"status,omitempty"`
+
+	// Changes, if set, compares the result with the previous result for
+	// the same video path.
+	Changes *ResultChanges `json:"changes,omitempty"`
+
+	// Retry, if set, overrides the worker's retry policy for the delivery.
+	Retry *WebhookRetryPolicy `json:"retry,omitempty"`
+
+	// Batch, if set, delivers the webhook together with others to the same
+	// URI.
+	Batch *WebhookBatching `json:"batch,omitempty"`
+
+	// Outcome is set for info jobs that finished without completing, to
+	// WebhookCancelled, WebhookFailed or WebhookExpired, and to
+	// WebhookStarted for jobs that have just started.  Empty means the job
+	// completed, reporting a result or an error in Status.
+	Outcome string `json:"outcome,omitempty"`
+}
+
+// NotificationStatus returns how the info job finished, as reported by the
+// webhook.
+func (a WebhookJobArgs) NotificationStatus() string {
+	if a.Outcome != "" {
+		return a.Outcome
+	}
+	if a.Status != nil && a.Status.Error != nil {
+		return WebhookFailed
+	}
+	return WebhookCompleted
+}
+
+// WebhookOutput is recorded as the output of delivered webhook jobs.
+type WebhookOutput struct {
+	// Target identifies the receiver, as returned by WebhookTarget.
+	Target string `json:"target"`
+
+	// LatencySeconds is the time from the job being enqueued, when the info
+	// job finished, to the receiver acknowledging the delivery.
+	LatencySeconds float64 `json:"latency_seconds"`
 }
 
 // Kind returns the job kind identifier for River.
 func (WebhookJobArgs) Kind() string {
 	return "webhook"
 }
+
+// InsertOpts limits the job's attempts to those allowed by its retry policy.
+func (a WebhookJobArgs) InsertOpts() river.InsertOpts {
+	if a.Retry == nil {
+		return river.InsertOpts{}
+	}
+	return river.InsertOpts{MaxAttempts: a.Retry.MaxAttempts}
+}
+
+// RetryPolicy returns the job's retry policy, with unset fields taken from
+// defaults.
+func (a WebhookJobArgs) RetryPolicy(defaults WebhookRetryPolicy) WebhookRetryPolicy {
+	if a.Retry == nil {
+		return defaults
+	}
+	return a.Retry.WithDefaults(defaults)
+}
+
+// Secrets returns the webhook URI, token and headers, opening them with
+// keyring if they were sealed.
+func (a WebhookJobArgs) Secrets(keyring *Keyring) (WebhookSecrets, error) {
+	if a.Sealed == nil {
+		return WebhookSecrets{URI: a.URI, Token: a.Token, Headers: a.Headers}, nil
+	}
+	plaintext, err := keyring.Open(a.Sealed, a.Uuid[:])
+	if err != nil {
+		return WebhookSecrets{}, err
+	}
+	var secrets WebhookSecrets
+	if err := json.Unmarshal(plaintext, &secrets); err != nil {
+		return WebhookSecrets{}, fmt.Errorf("failed to unmarshal webhook secrets: %w", err)
+	}
+	return secrets, nil
+}
@@ -0,0 +1,197 @@
+package internal
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/riverqueue/river"
+)
+
+// Limits on publishing results to Kafka.
+const (
+	// kafkaSinkBatchSize bounds the results published per request.
+	kafkaSinkBatchSize = 500
+
+	// kafkaSinkSettleDelay holds results back until transactions that
+	// stored results finalized before them have had time to commit, since
+	// the cursor won't go back for them.
+	kafkaSinkSettleDelay = time.Minute
+)
+
+// kafkaJSONContentType is the content type of JSON records sent to a Kafka
+// REST Proxy.
+const kafkaJSONContentType = "application/vnd.kafka.json.v2+json"
+
+// KafkaSinkTickArgs are the arguments of the periodic job that publishes new
+// results to Kafka.
+type KafkaSinkTickArgs struct{}
+
+// Kind returns the job kind identifier for River.
+func (KafkaSinkTickArgs) Kind() string {
+	return "kafka_sink_tick"
+}
+
+// InsertOpts places the job on the maintenance queue.
+func (KafkaSinkTickArgs) InsertOpts() river.InsertOpts {
+	return river.InsertOpts{Queue: QueueMaintenance}
+}
+
+// KafkaSink publishes stored results to a Kafka topic through a Kafka REST
+// Proxy.  Each record is keyed by the UUID of its info job, and its value is
+// a JobEvent of type completed or failed carrying the full result.
+type KafkaSink struct {
+	// URL is the base URL of the REST Proxy.
+	URL *url.URL
+
+	// Topic is the topic records are produced to.
+	Topic string
+
+	Client *http.Client
+}
+
+// kafkaRecord is a record produced to a REST Proxy.
+type kafkaRecord struct {
+	Key   string   `json:"key"`
+	Value JobEvent `json:"value"`
+}
+
+// kafkaProduceResponse is the response of a REST Proxy to a produce request.
+type kafkaProduceResponse struct {
+	Offsets []struct {
+		ErrorCode *int    `json:"error_code"`
+		Error     *string `json:"error"`
+	} `json:"offsets"`
+}
+
+// Produce produces events to the sink's topic, keyed by their job UUIDs.
+func (k *KafkaSink) Produce(ctx context.Context, events []JobEvent) error {
+	records := make([]kafkaRecord, len(events))
+	for i, event := range events {
+		records[i] = kafkaRecord{Key: event.Uuid.String(), Value: event}
+	}
+	body, err := json.Marshal(map[string]any{"records": records})
+	if err != nil {
+		return fmt.Errorf("failed to marshal records: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, k.URL.JoinPath("topics", k.Topic).String(), bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create produce request: %w", err)
+	}
+	req.Header.Set("Content-Type", kafkaJSONContentType)
+	req.Header.Set("Accept", "application/vnd.kafka.v2+json")
+	resp, err := k.Client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to produce records: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("failed to produce records: REST Proxy returned %s: %s", resp.Status, bytes.TrimSpace(message))
+	}
+
+	// Records fail individually, but are retried together
+	var produced kafkaProduceResponse
+	if err := json.NewDecoder(resp.Body).Decode(&produced); err != nil {
+		return fmt.Errorf("failed to decode produce response: %w", err)
+	}
+	for _, offset := range produced.Offsets {
+		if offset.ErrorCode != nil {
+			message := ""
+			if offset.Error != nil {
+				message = *offset.Error
+			}
+			return fmt.Errorf("failed to produce record: error code %d: %s", *offset.ErrorCode, message)
+		}
+	}
+	return nil
+}
+
+// PublishResultsToKafka publishes the next batch of stored results to sink,
+// in the order they were finalized, and advances the topic's cursor past
+// them.  Results finalized within kafkaSinkSettleDelay of now are left for a
+// later call.  A topic without a cursor starts from the oldest result.  It
+// returns the number of results published.
+func PublishResultsToKafka(ctx context.Context, tx pgx.Tx, sink *KafkaSink, now time.Time) (int, error) {
+	_, err := tx.Exec(ctx, "INSERT INTO kafka_sink_cursor (topic) VALUES ($1) ON CONFLICT DO NOTHING", sink.Topic)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create Kafka sink cursor: %w", err)
+	}
+	var (
+		afterFinalizedAt time.Time
+		afterUUID        uuid.UUID
+	)
+	err = tx.QueryRow(ctx, "SELECT after_finalized_at, after_uuid FROM kafka_sink_cursor WHERE topic = $1 FOR UPDATE", sink.Topic).
+		Scan(&afterFinalizedAt, &afterUUID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to lock Kafka sink cursor: %w", err)
+	}
+
+	rows, err := tx.Query(ctx, `
+		SELECT uuid, args, output, finalized_at FROM info_result
+		WHERE (finalized_at, uuid) > ($1, $2) AND finalized_at < $3
+		ORDER BY finalized_at, uuid
+		LIMIT $4`,
+		afterFinalizedAt, afterUUID, now.Add(-kafkaSinkSettleDelay), kafkaSinkBatchSize)
+	if err != nil {
+		return 0, fmt.Errorf("failed to query new results: %w", err)
+	}
+	type storedResult struct {
+		uuid        uuid.UUID
+		args        InfoJobArgs
+		status      InfoJobStatus
+		finalizedAt time.Time
+	}
+	results, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (storedResult, error) {
+		var r storedResult
+		err := row.Scan(&r.uuid, &r.args, &r.status, &r.finalizedAt)
+		return r, err
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to query new results: %w", err)
+	}
+	if len(results) == 0 {
+		return 0, nil
+	}
+
+	events := make([]JobEvent, len(results))
+	for i, r := range results {
+		eventType := JobEventCompleted
+		if r.status.Error != nil {
+			eventType = JobEventFailed
+		}
+		events[i] = JobEvent{
+			Type:       eventType,
+			Uuid:       r.uuid,
+			ExternalID: r.args.ExternalID,
+			VideoPath:  r.args.Path,
+			Labels:     r.args.Labels,
+			Time:       r.finalizedAt,
+			Result:     r.status.Result.RESTMediaInfo(),
+			Error:      r.status.Error,
+			ErrorCode:  r.status.ErrorCode,
+		}
+	}
+	if err := sink.Produce(ctx, events); err != nil {
+		return 0, err
+	}
+
+	last := results[len(results)-1]
+	_, err = tx.Exec(ctx, `
+		UPDATE kafka_sink_cursor
+		SET after_finalized_at = $2, after_uuid = $3, published = published + $4
+		WHERE topic = $1`,
+		sink.Topic, last.finalizedAt, last.uuid, len(results))
+	if err != nil {
+		return 0, fmt.Errorf("failed to advance Kafka sink cursor: %w", err)
+	}
+	return len(results), nil
+}
@@ -0,0 +1,98 @@
+package internal_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/krelinga/go-libs/deep"
+	"github.com/krelinga/go-libs/exam"
+	"github.com/krelinga/video-info/internal"
+)
+
+func TestKafkaSinkProduce(t *testing.T) {
+	e := exam.New(t)
+	env := deep.NewEnv()
+	jobUUID := uuid.New()
+	events := []internal.JobEvent{{
+		Type:      internal.JobEventCompleted,
+		Uuid:      jobUUID,
+		VideoPath: "/videos/a.mkv",
+		Time:      time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+	}}
+
+	tests := []struct {
+		loc      exam.Loc
+		name     string
+		status   int
+		response string
+		wantErr  bool
+	}{
+		{
+			loc:      exam.Here(),
+			name:     "Produced",
+			status:   http.StatusOK,
+			response: `{"offsets": [{"partition": 0, "offset": 7, "error_code": null, "error": null}]}`,
+		},
+		{
+			loc:      exam.Here(),
+			name:     "Record failed",
+			status:   http.StatusOK,
+			response: `{"offsets": [{"partition": null, "offset": null, "error_code": 50002, "error": "Kafka error"}]}`,
+			wantErr:  true,
+		},
+		{
+			loc:      exam.Here(),
+			name:     "Unknown topic",
+			status:   http.StatusNotFound,
+			response: `{"error_code": 40401, "message": "Topic not found."}`,
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		e.Run(tt.name, func(e exam.E) {
+			e.Log("Running test at", tt.loc)
+			var (
+				gotPath        string
+				gotContentType string
+				gotBody        []byte
+			)
+			proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				gotPath = r.URL.Path
+				gotContentType = r.Header.Get("Content-Type")
+				gotBody, _ = io.ReadAll(r.Body)
+				w.WriteHeader(tt.status)
+				io.WriteString(w, tt.response)
+			}))
+			defer proxy.Close()
+			proxyURL, err := url.Parse(proxy.URL)
+			exam.Nil(e, env, err)
+
+			sink := &internal.KafkaSink{URL: proxyURL, Topic: "video-info.results", Client: proxy.Client()}
+			err = sink.Produce(context.Background(), events)
+			exam.Equal(e, env, tt.wantErr, err != nil)
+			exam.Equal(e, env, "/topics/video-info.results", gotPath)
+			exam.Equal(e, env, "application/vnd.kafka.json.v2+json", gotContentType)
+
+			var body struct {
+				Records []struct {
+					Key   string            `json:"key"`
+					Value internal.JobEvent `json:"value"`
+				} `json:"records"`
+			}
+			exam.Nil(e, env, json.Unmarshal(gotBody, &body))
+			if len(body.Records) != 1 {
+				e.Fatalf("got %d records, want 1", len(body.Records))
+			}
+			exam.Equal(e, env, jobUUID.String(), body.Records[0].Key)
+			exam.Equal(e, env, internal.JobEventCompleted, body.Records[0].Value.Type)
+			exam.Equal(e, env, "/videos/a.mkv", body.Records[0].Value.VideoPath)
+		})
+	}
+}
@@ -0,0 +1,30 @@
+package internal
+
+import (
+	"errors"
+	"fmt"
+)
+
+const (
+	MaxLabels           = 32
+	MaxLabelKeyLength   = 64
+	MaxLabelValueLength = 256
+)
+
+var ErrInvalidLabels = errors.New("invalid labels")
+
+// ValidateLabels checks that caller-supplied labels are within size limits.
+func ValidateLabels(labels map[string]string) error {
+	if len(labels) > MaxLabels {
+		return fmt.Errorf("%w: at most %d labels are allowed, got %d", ErrInvalidLabels, MaxLabels, len(labels))
+	}
+	for k, v := range labels {
+		if len(k) == 0 || len(k) > MaxLabelKeyLength {
+			return fmt.Errorf("%w: key %q must be between 1 and %d characters", ErrInvalidLabels, k, MaxLabelKeyLength)
+		}
+		if len(v) > MaxLabelValueLength {
+			return fmt.Errorf("%w: value for key %q must be at most %d characters", ErrInvalidLabels, k, MaxLabelValueLength)
+		}
+	}
+	return nil
+}
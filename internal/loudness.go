@@ -0,0 +1,112 @@
+package internal
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"slices"
+	"strconv"
+
+	"github.com/krelinga/video-info/virest"
+)
+
+// AudioLoudness is the EBU R128 loudness of an audio track, as measured by
+// ffmpeg's loudnorm filter.  Measurements are nil for silent tracks, where
+// they are negative infinity.
+type AudioLoudness struct {
+	IntegratedLUFS  *float64 `json:"integrated_lufs,omitempty"`
+	TruePeakDBTP    *float64 `json:"true_peak_dbtp,omitempty"`
+	LoudnessRangeLU *float64 `json:"loudness_range_lu,omitempty"`
+	ThresholdLUFS   *float64 `json:"threshold_lufs,omitempty"`
+}
+
+var ErrNoLoudnormSummary = errors.New("no loudnorm summary")
+
+// loudnormPattern matches the JSON summary each loudnorm filter instance
+// prints when it finishes, tagged with the instance's position in the graph
+// and, if ffmpeg was logging levels, with the summary's.
+var loudnormPattern = regexp.MustCompile(`\[Parsed_loudnorm_(\d+) @ [^\]]*\]\s*(?:\[\w+\]\s*)?(\{[^}]*\})`)
+
+// loudnormSummary is the JSON printed by loudnorm with print_format=json.
+// Only the input measurements are of interest.
+type loudnormSummary struct {
+	InputI      string `json:"input_i"`
+	InputTP     string `json:"input_tp"`
+	InputLRA    string `json:"input_lra"`
+	InputThresh string `json:"input_thresh"`
+}
+
+// ParseLoudnorm returns the measurements printed by loudnorm filters with
+// print_format=json, in the order of filters, which holds the position of
+// each filter in the graph.
+func ParseLoudnorm(output string, filters []int) ([]AudioLoudness, error) {
+	loudness := make([]AudioLoudness, len(filters))
+	found := make([]bool, len(filters))
+	for _, match := range loudnormPattern.FindAllStringSubmatch(output, -1) {
+		position, err := strconv.Atoi(match[1])
+		if err != nil {
+			continue
+		}
+		i := slices.Index(filters, position)
+		if i < 0 {
+			continue
+		}
+		var summary loudnormSummary
+		if err := json.Unmarshal([]byte(match[2]), &summary); err != nil {
+			return nil, fmt.Errorf("failed to parse loudnorm summary %d: %w", position, err)
+		}
+		loudness[i] = AudioLoudness{
+			IntegratedLUFS:  parseLoudnormValue(summary.InputI),
+			TruePeakDBTP:    parseLoudnormValue(summary.InputTP),
+			LoudnessRangeLU: parseLoudnormValue(summary.InputLRA),
+			ThresholdLUFS:   parseLoudnormValue(summary.InputThresh),
+		}
+		found[i] = true
+	}
+	for i, ok := range found {
+		if !ok {
+			return nil, fmt.Errorf("%w for filter %d", ErrNoLoudnormSummary, filters[i])
+		}
+	}
+	return loudness, nil
+}
+
+// parseLoudnormValue parses one measurement, returning nil if it is not a
+// finite number.
+func parseLoudnormValue(s string) *float64 {
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil || math.IsInf(v, 0) || math.IsNaN(v) {
+		return nil
+	}
+	return &v
+}
+
+// RESTAudioLoudness converts the loudness to its REST form, or nil if l is
+// nil.
+func (l *AudioLoudness) RESTAudioLoudness() *virest.AudioLoudness {
+	if l == nil {
+		return nil
+	}
+	return &virest.AudioLoudness{
+		IntegratedLufs:  l.IntegratedLUFS,
+		TruePeakDbtp:    l.TruePeakDBTP,
+		LoudnessRangeLu: l.LoudnessRangeLU,
+		ThresholdLufs:   l.ThresholdLUFS,
+	}
+}
+
+// NewAudioLoudnessFromREST converts a REST AudioLoudness, or returns nil if v
+// is nil.
+func NewAudioLoudnessFromREST(v *virest.AudioLoudness) *AudioLoudness {
+	if v == nil {
+		return nil
+	}
+	return &AudioLoudness{
+		IntegratedLUFS:  v.IntegratedLufs,
+		TruePeakDBTP:    v.TruePeakDbtp,
+		LoudnessRangeLU: v.LoudnessRangeLu,
+		ThresholdLUFS:   v.ThresholdLufs,
+	}
+}
@@ -0,0 +1,91 @@
+package internal_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/krelinga/go-libs/deep"
+	"github.com/krelinga/go-libs/exam"
+	"github.com/krelinga/go-libs/match"
+	"github.com/krelinga/video-info/internal"
+)
+
+func loudnormSummary(filter, i, tp, lra, thresh string) string {
+	return "[Parsed_loudnorm_" + filter + " @ 0x55d0] \n{\n" +
+		"\t\"input_i\" : \"" + i + "\",\n" +
+		"\t\"input_tp\" : \"" + tp + "\",\n" +
+		"\t\"input_lra\" : \"" + lra + "\",\n" +
+		"\t\"input_thresh\" : \"" + thresh + "\",\n" +
+		"\t\"output_i\" : \"-24.00\",\n" +
+		"\t\"normalization_type\" : \"dynamic\",\n" +
+		"\t\"target_offset\" : \"0.00\"\n}\n"
+}
+
+func TestParseLoudnorm(t *testing.T) {
+	e := exam.New(t)
+	env := deep.NewEnv()
+
+	float := func(v float64) *float64 { return &v }
+
+	tests := []struct {
+		loc     exam.Loc
+		name    string
+		output  string
+		filters []int
+		want    []internal.AudioLoudness
+		wantErr bool
+	}{
+		{
+			loc:  exam.Here(),
+			name: "Summaries matched to their filters",
+			output: "Output #0, null, to 'pipe:':\n" +
+				loudnormSummary("1", "-18.20", "-0.90", "9.40", "-28.40") +
+				loudnormSummary("0", "-23.61", "-4.47", "18.06", "-34.20"),
+			filters: []int{0, 1},
+			want: []internal.AudioLoudness{
+				{IntegratedLUFS: float(-23.61), TruePeakDBTP: float(-4.47), LoudnessRangeLU: float(18.06), ThresholdLUFS: float(-34.2)},
+				{IntegratedLUFS: float(-18.2), TruePeakDBTP: float(-0.9), LoudnessRangeLU: float(9.4), ThresholdLUFS: float(-28.4)},
+			},
+		},
+		{
+			loc:  exam.Here(),
+			name: "Filters sharing a graph with others",
+			output: "[Parsed_cropdetect_0 @ 0x55c0] [info] crop=1920:800:0:140\n" +
+				strings.Replace(loudnormSummary("3", "-18.20", "-0.90", "9.40", "-28.40"), "] ", "] [info] ", 1) +
+				strings.Replace(loudnormSummary("1", "-23.61", "-4.47", "18.06", "-34.20"), "] ", "] [info] ", 1),
+			filters: []int{1, 3},
+			want: []internal.AudioLoudness{
+				{IntegratedLUFS: float(-23.61), TruePeakDBTP: float(-4.47), LoudnessRangeLU: float(18.06), ThresholdLUFS: float(-34.2)},
+				{IntegratedLUFS: float(-18.2), TruePeakDBTP: float(-0.9), LoudnessRangeLU: float(9.4), ThresholdLUFS: float(-28.4)},
+			},
+		},
+		{
+			loc:     exam.Here(),
+			name:    "Silent track",
+			output:  loudnormSummary("0", "-inf", "-inf", "0.00", "-70.00"),
+			filters: []int{0},
+			want: []internal.AudioLoudness{
+				{LoudnessRangeLU: float(0), ThresholdLUFS: float(-70)},
+			},
+		},
+		{
+			loc:     exam.Here(),
+			name:    "Missing summary",
+			output:  loudnormSummary("0", "-23.00", "-1.00", "5.00", "-33.00"),
+			filters: []int{0, 1},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		e.Run(tt.name, func(e exam.E) {
+			e.Log("Running test at", tt.loc)
+			got, err := internal.ParseLoudnorm(tt.output, tt.filters)
+			if tt.wantErr {
+				exam.Match(e, env, err, match.ErrorIs(internal.ErrNoLoudnormSummary))
+				return
+			}
+			exam.Nil(e, env, err)
+			exam.Equal(e, env, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,226 @@
+package internal
+
+import (
+	"bufio"
+	"encoding/csv"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// MakeMKV attribute IDs reported in makemkvcon's robot output.
+const (
+	makeMKVType          = 1
+	makeMKVName          = 2
+	makeMKVLangCode      = 3
+	makeMKVCodecShort    = 6
+	makeMKVChapterCount  = 8
+	makeMKVDuration      = 9
+	makeMKVSizeBytes     = 11
+	makeMKVSourceFile    = 16
+	makeMKVSegmentsCount = 25
+	makeMKVSegmentsMap   = 26
+	makeMKVOutputFile    = 27
+)
+
+// Bounds on the titles and streams per title read from makemkvcon, well
+// above what discs hold.
+const (
+	maxMakeMKVTitles  = 1000
+	maxMakeMKVStreams = 256
+)
+
+// makeMKVMatchTolerance is how far apart, in seconds, the durations of a
+// MakeMKV title and a probed file may be for the title to match the file.
+// MakeMKV reports durations to the second.
+const makeMKVMatchTolerance = 1.0
+
+// MakeMKVInfo is what `makemkvcon info` reports about a file or disc: the
+// titles MakeMKV would rip, and how each is put together from segments,
+// which ffprobe can't tell.
+type MakeMKVInfo struct {
+	Name   string         `json:"name,omitempty"`
+	Titles []MakeMKVTitle `json:"titles"`
+
+	// MatchedTitle is the index of the only title as long as the probed
+	// file, if exactly one is.
+	MatchedTitle *int `json:"matchedTitle,omitempty"`
+}
+
+// MakeMKVTitle is one title found by MakeMKV.
+type MakeMKVTitle struct {
+	Index           int     `json:"index"`
+	Name            string  `json:"name,omitempty"`
+	DurationSeconds float64 `json:"durationSeconds,omitempty"`
+	ChapterCount    int     `json:"chapterCount,omitempty"`
+	SizeBytes       int64   `json:"sizeBytes,omitempty"`
+
+	// SourceFile is the playlist or file the title is read from, such as
+	// 00800.mpls, and OutputFile the name MakeMKV would give the rip.
+	SourceFile string `json:"sourceFile,omitempty"`
+	OutputFile string `json:"outputFile,omitempty"`
+
+	// SegmentCount and SegmentMap describe the segments the title plays,
+	// in order, as MakeMKV reports them.
+	SegmentCount int    `json:"segmentCount,omitempty"`
+	SegmentMap   string `json:"segmentMap,omitempty"`
+
+	Streams []MakeMKVStream `json:"streams,omitempty"`
+}
+
+// MakeMKVStream is one stream of a MakeMKV title.
+type MakeMKVStream struct {
+	Type     string `json:"type,omitempty"`
+	Codec    string `json:"codec,omitempty"`
+	Language string `json:"language,omitempty"`
+}
+
+// ParseMakeMKVInfo parses the robot output of `makemkvcon -r info`.  Lines
+// other than disc, title and stream information, such as messages and
+// drive listings, are ignored.
+func ParseMakeMKVInfo(output string) (*MakeMKVInfo, error) {
+	info := &MakeMKVInfo{Titles: []MakeMKVTitle{}}
+	title := func(index int) *MakeMKVTitle {
+		for len(info.Titles) <= index {
+			info.Titles = append(info.Titles, MakeMKVTitle{Index: len(info.Titles)})
+		}
+		return &info.Titles[index]
+	}
+
+	scanner := bufio.NewScanner(strings.NewReader(output))
+	for scanner.Scan() {
+		kind, rest, ok := strings.Cut(scanner.Text(), ":")
+		if !ok || (kind != "CINFO" && kind != "TINFO" && kind != "SINFO") {
+			continue
+		}
+		ids, value, err := parseMakeMKVFields(rest)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse makemkvcon %s line: %w", kind, err)
+		}
+		// Each kind has the IDs of its title and stream, if any, then an
+		// attribute ID and a message code
+		want := map[string]int{"CINFO": 2, "TINFO": 3, "SINFO": 4}[kind]
+		if len(ids) != want {
+			return nil, fmt.Errorf("makemkvcon %s line has %d IDs, want %d", kind, len(ids), want)
+		}
+		if kind != "CINFO" && (ids[0] < 0 || ids[0] >= maxMakeMKVTitles) {
+			return nil, fmt.Errorf("makemkvcon title %d is out of range", ids[0])
+		}
+		if kind == "SINFO" && (ids[1] < 0 || ids[1] >= maxMakeMKVStreams) {
+			return nil, fmt.Errorf("makemkvcon stream %d is out of range", ids[1])
+		}
+		attr := ids[len(ids)-2]
+		switch kind {
+		case "CINFO":
+			if attr == makeMKVName {
+				info.Name = value
+			}
+		case "TINFO":
+			if err := title(ids[0]).set(attr, value); err != nil {
+				return nil, err
+			}
+		case "SINFO":
+			t := title(ids[0])
+			for len(t.Streams) <= ids[1] {
+				t.Streams = append(t.Streams, MakeMKVStream{})
+			}
+			t.Streams[ids[1]].set(attr, value)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read makemkvcon output: %w", err)
+	}
+	return info, nil
+}
+
+// parseMakeMKVFields splits the fields of a robot output line after its
+// kind: numeric IDs, ending with an attribute ID and a message code, then a
+// quoted value.
+func parseMakeMKVFields(line string) ([]int, string, error) {
+	r := csv.NewReader(strings.NewReader(line))
+	r.LazyQuotes = true
+	fields, err := r.Read()
+	if err != nil {
+		return nil, "", err
+	}
+	if len(fields) < 2 {
+		return nil, "", fmt.Errorf("too few fields in %q", line)
+	}
+	ids := make([]int, len(fields)-1)
+	for i, field := range fields[:len(fields)-1] {
+		if ids[i], err = strconv.Atoi(field); err != nil {
+			return nil, "", fmt.Errorf("invalid ID in %q", line)
+		}
+	}
+	return ids, fields[len(fields)-1], nil
+}
+
+// set records the title attribute attr.
+func (t *MakeMKVTitle) set(attr int, value string) error {
+	var err error
+	switch attr {
+	case makeMKVName:
+		t.Name = value
+	case makeMKVDuration:
+		t.DurationSeconds, err = parseMakeMKVDuration(value)
+	case makeMKVChapterCount:
+		t.ChapterCount, err = strconv.Atoi(value)
+	case makeMKVSizeBytes:
+		t.SizeBytes, err = strconv.ParseInt(value, 10, 64)
+	case makeMKVSourceFile:
+		t.SourceFile = value
+	case makeMKVOutputFile:
+		t.OutputFile = value
+	case makeMKVSegmentsCount:
+		t.SegmentCount, err = strconv.Atoi(value)
+	case makeMKVSegmentsMap:
+		t.SegmentMap = value
+	}
+	if err != nil {
+		return fmt.Errorf("failed to parse attribute %d of makemkvcon title %d: %w", attr, t.Index, err)
+	}
+	return nil
+}
+
+// set records the stream attribute attr.
+func (s *MakeMKVStream) set(attr int, value string) {
+	switch attr {
+	case makeMKVType:
+		s.Type = value
+	case makeMKVCodecShort:
+		s.Codec = value
+	case makeMKVLangCode:
+		s.Language = value
+	}
+}
+
+// parseMakeMKVDuration parses a duration such as 1:52:33 into seconds.
+func parseMakeMKVDuration(value string) (float64, error) {
+	var seconds float64
+	for part := range strings.SplitSeq(value, ":") {
+		n, err := strconv.ParseFloat(part, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid duration %q", value)
+		}
+		seconds = seconds*60 + n
+	}
+	return seconds, nil
+}
+
+// MatchTitle sets MatchedTitle to the title whose duration is that of the
+// probed file, if exactly one title's is.
+func (info *MakeMKVInfo) MatchTitle(durationSeconds float64) {
+	info.MatchedTitle = nil
+	for _, title := range info.Titles {
+		if math.Abs(title.DurationSeconds-durationSeconds) > makeMKVMatchTolerance {
+			continue
+		}
+		if info.MatchedTitle != nil {
+			info.MatchedTitle = nil
+			return
+		}
+		index := title.Index
+		info.MatchedTitle = &index
+	}
+}
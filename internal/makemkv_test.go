@@ -0,0 +1,89 @@
+package internal_test
+
+import (
+	"testing"
+
+	"github.com/krelinga/go-libs/deep"
+	"github.com/krelinga/go-libs/exam"
+	"github.com/krelinga/video-info/internal"
+)
+
+// makeMKVOutput is trimmed robot output of makemkvcon info for a Blu-ray
+// with two titles, one of them playing its segments out of order.
+const makeMKVOutput = `MSG:1005,0,1,"MakeMKV v1.17.7 linux(x64-release) started","%1 started","MakeMKV v1.17.7 linux(x64-release)"
+DRV:0,256,999,0,"","",""
+TCOUNT:2
+CINFO:1,6209,"Blu-ray disc"
+CINFO:2,0,"Big Movie"
+TINFO:0,2,0,"Big Movie"
+TINFO:0,8,0,"24"
+TINFO:0,9,0,"1:52:33"
+TINFO:0,11,0,"32212254720"
+TINFO:0,16,0,"00800.mpls"
+TINFO:0,25,0,"3"
+TINFO:0,26,0,"1,3,2"
+TINFO:0,27,0,"Big_Movie_t00.mkv"
+SINFO:0,0,1,6201,"Video"
+SINFO:0,0,6,0,"Mpeg4"
+SINFO:0,1,1,6202,"Audio"
+SINFO:0,1,3,0,"eng"
+SINFO:0,1,6,0,"DTS-HD MA"
+TINFO:1,9,0,"0:02:10"
+TINFO:1,16,0,"00801.mpls"
+MSG:5011,0,0,"Operation successfully completed","Operation successfully completed"
+`
+
+func TestParseMakeMKVInfo(t *testing.T) {
+	e := exam.New(t)
+	env := deep.NewEnv()
+
+	info, err := internal.ParseMakeMKVInfo(makeMKVOutput)
+	exam.Nil(e, env, err)
+	exam.Equal(e, env, &internal.MakeMKVInfo{
+		Name: "Big Movie",
+		Titles: []internal.MakeMKVTitle{
+			{
+				Index:           0,
+				Name:            "Big Movie",
+				DurationSeconds: 6753,
+				ChapterCount:    24,
+				SizeBytes:       32212254720,
+				SourceFile:      "00800.mpls",
+				OutputFile:      "Big_Movie_t00.mkv",
+				SegmentCount:    3,
+				SegmentMap:      "1,3,2",
+				Streams: []internal.MakeMKVStream{
+					{Type: "Video", Codec: "Mpeg4"},
+					{Type: "Audio", Codec: "DTS-HD MA", Language: "eng"},
+				},
+			},
+			{Index: 1, DurationSeconds: 130, SourceFile: "00801.mpls"},
+		},
+	}, info)
+
+	e.Run("Matching title", func(e exam.E) {
+		info.MatchTitle(6753.4)
+		exam.Equal(e, env, 0, *info.MatchedTitle)
+		info.MatchTitle(60)
+		exam.Equal(e, env, true, info.MatchedTitle == nil)
+	})
+
+	e.Run("Ambiguous match", func(e exam.E) {
+		info, err := internal.ParseMakeMKVInfo("TINFO:0,9,0,\"0:45:00\"\nTINFO:1,9,0,\"0:45:00\"\n")
+		exam.Nil(e, env, err)
+		info.MatchTitle(2700)
+		exam.Equal(e, env, true, info.MatchedTitle == nil)
+	})
+
+	e.Run("Malformed lines", func(e exam.E) {
+		for _, output := range []string{
+			`TINFO:0,9,"1:00:00"`,
+			`TINFO:x,9,0,"1:00:00"`,
+			`TINFO:0,9,0,"an hour"`,
+			`TINFO:5000000,2,0,"Huge"`,
+		} {
+			_, err := internal.ParseMakeMKVInfo(output)
+			exam.NotNil(e, env, err).Log(output)
+		}
+	})
+}
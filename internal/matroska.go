@@ -0,0 +1,573 @@
+package internal
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+var (
+	ErrNotMatroska     = errors.New("not a Matroska file")
+	ErrInvalidMatroska = errors.New("invalid Matroska file")
+)
+
+// Matroska element IDs, as defined by RFC 9559.
+const (
+	mkvEBML           = 0x1A45DFA3
+	mkvDocType        = 0x4282
+	mkvSegment        = 0x18538067
+	mkvSeekHead       = 0x114D9B74
+	mkvSeek           = 0x4DBB
+	mkvSeekID         = 0x53AB
+	mkvSeekPosition   = 0x53AC
+	mkvInfo           = 0x1549A966
+	mkvSegmentUID     = 0x73A4
+	mkvPrevUID        = 0x3CB923
+	mkvNextUID        = 0x3EB923
+	mkvTimestampScale = 0x2AD7B1
+	mkvDuration       = 0x4489
+	mkvCluster        = 0x1F43B675
+	mkvChapters       = 0x1043A770
+	mkvEditionEntry   = 0x45B9
+	mkvEditionUID     = 0x45BC
+	mkvEditionHidden  = 0x45BD
+	mkvEditionDefault = 0x45DB
+	mkvEditionOrdered = 0x45DD
+	mkvChapterAtom    = 0xB6
+	mkvChapterUID     = 0x73C4
+	mkvChapterStart   = 0x91
+	mkvChapterEnd     = 0x92
+	mkvChapterHidden  = 0x98
+	mkvChapterEnabled = 0x4598
+	mkvChapterSegment = 0x6E67
+	mkvChapterDisplay = 0x80
+	mkvChapString     = 0x85
+)
+
+// maxMatroskaElementSize bounds the size of the elements ReadMatroska loads
+// into memory, so a corrupt size can't exhaust memory.
+const maxMatroskaElementSize = 16 << 20
+
+// MatroskaInfo holds the parts of a Matroska file's metadata that ffprobe
+// doesn't report.
+type MatroskaInfo struct {
+	SegmentUID      []byte
+	PrevUID         []byte
+	NextUID         []byte
+	DurationSeconds float64
+	Editions        []MatroskaEdition
+}
+
+// MatroskaEdition is one edition of a Matroska file's chapters.  Ordered
+// editions define a virtual timeline made of their enabled chapters, played
+// in order, which may come from other segments.
+type MatroskaEdition struct {
+	UID      uint64
+	Default  bool
+	Ordered  bool
+	Hidden   bool
+	Chapters []MatroskaChapter
+}
+
+// MatroskaChapter is a top-level chapter of an edition.  Times are in
+// nanoseconds on the timeline of the segment the chapter is taken from.
+type MatroskaChapter struct {
+	UID        uint64
+	Title      string
+	Start      uint64
+	End        uint64
+	Enabled    bool
+	Hidden     bool
+	SegmentUID []byte
+}
+
+// TimelineSeconds returns the duration of an ordered edition's virtual
+// timeline, which is the total duration of its enabled chapters.
+func (e MatroskaEdition) TimelineSeconds() float64 {
+	var total uint64
+	for _, c := range e.Chapters {
+		if c.Enabled && c.End > c.Start {
+			total += c.End - c.Start
+		}
+	}
+	return float64(total) / 1e9
+}
+
+// defaultEdition returns the edition players pick by default: the first one
+// flagged as default, or else the first one.  It returns nil if there are no
+// editions.
+func (m *MatroskaInfo) defaultEdition() *MatroskaEdition {
+	for i := range m.Editions {
+		if m.Editions[i].Default {
+			return &m.Editions[i]
+		}
+	}
+	if len(m.Editions) > 0 {
+		return &m.Editions[0]
+	}
+	return nil
+}
+
+// TimelineSeconds returns the duration of the default edition's virtual
+// timeline, or nil if the default edition isn't ordered.
+func (m *MatroskaInfo) TimelineSeconds() *float64 {
+	edition := m.defaultEdition()
+	if edition == nil || !edition.Ordered {
+		return nil
+	}
+	seconds := edition.TimelineSeconds()
+	return &seconds
+}
+
+// MediaEditions converts the editions into their form in an InfoJobResult.
+func (m *MatroskaInfo) MediaEditions() []MediaEdition {
+	var editions []MediaEdition
+	for _, e := range m.Editions {
+		edition := MediaEdition{
+			UID:             fmt.Sprintf("%016x", e.UID),
+			Default:         e.Default,
+			Ordered:         e.Ordered,
+			Hidden:          e.Hidden,
+			DurationSeconds: m.DurationSeconds,
+			Chapters:        make([]EditionChapter, 0, len(e.Chapters)),
+		}
+		if e.Ordered {
+			edition.DurationSeconds = e.TimelineSeconds()
+		}
+		for _, c := range e.Chapters {
+			chapter := EditionChapter{
+				StartSeconds: float64(c.Start) / 1e9,
+				EndSeconds:   float64(c.End) / 1e9,
+				Enabled:      c.Enabled,
+			}
+			if c.Title != "" {
+				chapter.Title = &c.Title
+			}
+			if c.SegmentUID != nil {
+				uid := hex.EncodeToString(c.SegmentUID)
+				chapter.SegmentUID = &uid
+			}
+			edition.Chapters = append(edition.Chapters, chapter)
+		}
+		editions = append(editions, edition)
+	}
+	return editions
+}
+
+// LinkedSegments returns the other segments the file refers to by UID, either
+// as the previous or next part of a split file or as the source of an ordered
+// chapter.  Each UID is reported once, in the order it is first referenced.
+func (m *MatroskaInfo) LinkedSegments() []LinkedSegment {
+	var segments []LinkedSegment
+	add := func(uid []byte) {
+		if len(uid) == 0 || string(uid) == string(m.SegmentUID) {
+			return
+		}
+		hexUID := hex.EncodeToString(uid)
+		for _, s := range segments {
+			if s.UID == hexUID {
+				return
+			}
+		}
+		segments = append(segments, LinkedSegment{UID: hexUID})
+	}
+	add(m.PrevUID)
+	add(m.NextUID)
+	for _, e := range m.Editions {
+		for _, c := range e.Chapters {
+			add(c.SegmentUID)
+		}
+	}
+	return segments
+}
+
+// matroskaExtensions are the file extensions FindLinkedSegments considers.
+var matroskaExtensions = map[string]bool{
+	".mkv":  true,
+	".mka":  true,
+	".mks":  true,
+	".mk3d": true,
+}
+
+// FindLinkedSegments looks for the files holding segments in the directory of
+// path, which is how players locate them, and sets Present and File on each
+// segment found.  Files that can't be read are ignored.
+func FindLinkedSegments(path string, segments []LinkedSegment) error {
+	dir := filepath.Dir(path)
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return fmt.Errorf("failed to list directory: %w", err)
+	}
+	for i := range segments {
+		segments[i].Present = false
+		segments[i].File = nil
+	}
+	for _, entry := range entries {
+		name := entry.Name()
+		if !entry.Type().IsRegular() || name == filepath.Base(path) || !matroskaExtensions[strings.ToLower(filepath.Ext(name))] {
+			continue
+		}
+		info, err := ReadMatroska(filepath.Join(dir, name))
+		if err != nil {
+			continue
+		}
+		uid := hex.EncodeToString(info.SegmentUID)
+		for i := range segments {
+			if segments[i].UID == uid && !segments[i].Present {
+				segments[i].Present = true
+				segments[i].File = &name
+			}
+		}
+	}
+	return nil
+}
+
+// ReadMatroska reads the segment information and chapter editions of the
+// Matroska or WebM file at path.  It returns ErrNotMatroska for other files.
+func ReadMatroska(path string) (*MatroskaInfo, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	stat, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+
+	header, err := readEBMLHeader(f, 0)
+	if err != nil || header.id != mkvEBML || header.size < 0 {
+		return nil, ErrNotMatroska
+	}
+	headerBody, err := readEBMLBody(f, header)
+	if err != nil {
+		return nil, err
+	}
+	var docType string
+	if err := eachEBMLChild(headerBody, func(id uint64, body []byte) error {
+		if id == mkvDocType {
+			docType = ebmlString(body)
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	if docType != "matroska" && docType != "webm" {
+		return nil, ErrNotMatroska
+	}
+
+	segment, err := readEBMLHeader(f, header.dataOffset+header.size)
+	if err != nil || segment.id != mkvSegment {
+		return nil, fmt.Errorf("%w: missing segment", ErrInvalidMatroska)
+	}
+	segmentEnd := stat.Size()
+	if segment.size >= 0 && segment.dataOffset+segment.size < segmentEnd {
+		segmentEnd = segment.dataOffset + segment.size
+	}
+
+	// Walk the top-level elements up to the first cluster, then use the seek
+	// head to find whatever is stored after the clusters
+	var infoBody, chaptersBody []byte
+	seekPositions := map[uint64]int64{}
+	for pos := segment.dataOffset; pos < segmentEnd; {
+		el, err := readEBMLHeader(f, pos)
+		if err != nil {
+			return nil, err
+		}
+		if el.id == mkvCluster || el.size < 0 {
+			break
+		}
+		switch el.id {
+		case mkvSeekHead:
+			body, err := readEBMLBody(f, el)
+			if err != nil {
+				return nil, err
+			}
+			if err := parseSeekHead(body, seekPositions); err != nil {
+				return nil, err
+			}
+		case mkvInfo:
+			if infoBody, err = readEBMLBody(f, el); err != nil {
+				return nil, err
+			}
+		case mkvChapters:
+			if chaptersBody, err = readEBMLBody(f, el); err != nil {
+				return nil, err
+			}
+		}
+		pos = el.dataOffset + el.size
+	}
+	for id, body := range map[uint64]*[]byte{mkvInfo: &infoBody, mkvChapters: &chaptersBody} {
+		position, ok := seekPositions[id]
+		if *body != nil || !ok {
+			continue
+		}
+		el, err := readEBMLHeader(f, segment.dataOffset+position)
+		if err != nil {
+			return nil, err
+		}
+		if el.id != id {
+			return nil, fmt.Errorf("%w: seek head points at the wrong element", ErrInvalidMatroska)
+		}
+		if *body, err = readEBMLBody(f, el); err != nil {
+			return nil, err
+		}
+	}
+
+	info := &MatroskaInfo{}
+	if infoBody != nil {
+		if err := parseMatroskaInfo(infoBody, info); err != nil {
+			return nil, err
+		}
+	}
+	if chaptersBody != nil {
+		if err := eachEBMLChild(chaptersBody, func(id uint64, body []byte) error {
+			if id != mkvEditionEntry {
+				return nil
+			}
+			edition, err := parseMatroskaEdition(body)
+			if err != nil {
+				return err
+			}
+			info.Editions = append(info.Editions, edition)
+			return nil
+		}); err != nil {
+			return nil, err
+		}
+	}
+	return info, nil
+}
+
+func parseSeekHead(body []byte, positions map[uint64]int64) error {
+	return eachEBMLChild(body, func(id uint64, seek []byte) error {
+		if id != mkvSeek {
+			return nil
+		}
+		var (
+			seekID   uint64
+			position uint64
+		)
+		err := eachEBMLChild(seek, func(id uint64, body []byte) error {
+			switch id {
+			case mkvSeekID:
+				seekID = ebmlUint(body)
+			case mkvSeekPosition:
+				position = ebmlUint(body)
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		if _, ok := positions[seekID]; !ok && position <= math.MaxInt64 {
+			positions[seekID] = int64(position)
+		}
+		return nil
+	})
+}
+
+func parseMatroskaInfo(body []byte, info *MatroskaInfo) error {
+	timestampScale := uint64(1000000)
+	var duration float64
+	err := eachEBMLChild(body, func(id uint64, body []byte) error {
+		switch id {
+		case mkvSegmentUID:
+			info.SegmentUID = body
+		case mkvPrevUID:
+			info.PrevUID = body
+		case mkvNextUID:
+			info.NextUID = body
+		case mkvTimestampScale:
+			timestampScale = ebmlUint(body)
+		case mkvDuration:
+			duration = ebmlFloat(body)
+		}
+		return nil
+	})
+	info.DurationSeconds = duration * float64(timestampScale) / 1e9
+	return err
+}
+
+func parseMatroskaEdition(body []byte) (MatroskaEdition, error) {
+	var edition MatroskaEdition
+	err := eachEBMLChild(body, func(id uint64, body []byte) error {
+		switch id {
+		case mkvEditionUID:
+			edition.UID = ebmlUint(body)
+		case mkvEditionDefault:
+			edition.Default = ebmlUint(body) != 0
+		case mkvEditionOrdered:
+			edition.Ordered = ebmlUint(body) != 0
+		case mkvEditionHidden:
+			edition.Hidden = ebmlUint(body) != 0
+		case mkvChapterAtom:
+			chapter, err := parseMatroskaChapter(body)
+			if err != nil {
+				return err
+			}
+			edition.Chapters = append(edition.Chapters, chapter)
+		}
+		return nil
+	})
+	return edition, err
+}
+
+func parseMatroskaChapter(body []byte) (MatroskaChapter, error) {
+	chapter := MatroskaChapter{Enabled: true}
+	err := eachEBMLChild(body, func(id uint64, body []byte) error {
+		switch id {
+		case mkvChapterUID:
+			chapter.UID = ebmlUint(body)
+		case mkvChapterStart:
+			chapter.Start = ebmlUint(body)
+		case mkvChapterEnd:
+			chapter.End = ebmlUint(body)
+		case mkvChapterEnabled:
+			chapter.Enabled = ebmlUint(body) != 0
+		case mkvChapterHidden:
+			chapter.Hidden = ebmlUint(body) != 0
+		case mkvChapterSegment:
+			chapter.SegmentUID = body
+		case mkvChapterDisplay:
+			// Use the first title, whatever its language
+			if chapter.Title != "" {
+				return nil
+			}
+			return eachEBMLChild(body, func(id uint64, body []byte) error {
+				if id == mkvChapString {
+					chapter.Title = ebmlString(body)
+				}
+				return nil
+			})
+		}
+		return nil
+	})
+	return chapter, err
+}
+
+// ebmlElement is the header of an element read from a file.  size is -1 if
+// the element's size is unknown.
+type ebmlElement struct {
+	id         uint64
+	size       int64
+	dataOffset int64
+}
+
+// readEBMLHeader reads the header of the element at off.
+func readEBMLHeader(r io.ReaderAt, off int64) (ebmlElement, error) {
+	var buf [12]byte
+	n, err := r.ReadAt(buf[:], off)
+	if n == 0 {
+		if err == nil || errors.Is(err, io.EOF) {
+			err = fmt.Errorf("%w: truncated element", ErrInvalidMatroska)
+		}
+		return ebmlElement{}, err
+	}
+	id, idLen, err := decodeEBMLVint(buf[:n], true)
+	if err != nil {
+		return ebmlElement{}, err
+	}
+	size, sizeLen, err := decodeEBMLVint(buf[idLen:n], false)
+	if err != nil {
+		return ebmlElement{}, err
+	}
+	el := ebmlElement{id: id, size: int64(size), dataOffset: off + int64(idLen+sizeLen)}
+	if size == 1<<(7*sizeLen)-1 {
+		el.size = -1
+	} else if size > math.MaxInt64 {
+		return ebmlElement{}, fmt.Errorf("%w: element too large", ErrInvalidMatroska)
+	}
+	return el, nil
+}
+
+// readEBMLBody reads the body of el, which must have a known size.
+func readEBMLBody(r io.ReaderAt, el ebmlElement) ([]byte, error) {
+	if el.size < 0 || el.size > maxMatroskaElementSize {
+		return nil, fmt.Errorf("%w: element %#x has unsupported size", ErrInvalidMatroska, el.id)
+	}
+	body := make([]byte, el.size)
+	if _, err := r.ReadAt(body, el.dataOffset); err != nil {
+		if errors.Is(err, io.EOF) {
+			err = fmt.Errorf("%w: truncated element %#x", ErrInvalidMatroska, el.id)
+		}
+		return nil, err
+	}
+	return body, nil
+}
+
+// eachEBMLChild calls f with the ID and body of each child in the body of a
+// master element.
+func eachEBMLChild(data []byte, f func(id uint64, body []byte) error) error {
+	for len(data) > 0 {
+		id, idLen, err := decodeEBMLVint(data, true)
+		if err != nil {
+			return err
+		}
+		size, sizeLen, err := decodeEBMLVint(data[idLen:], false)
+		if err != nil {
+			return err
+		}
+		start := idLen + sizeLen
+		if size > uint64(len(data)-start) {
+			return fmt.Errorf("%w: element %#x overruns its parent", ErrInvalidMatroska, id)
+		}
+		end := start + int(size)
+		if err := f(id, data[start:end]); err != nil {
+			return err
+		}
+		data = data[end:]
+	}
+	return nil
+}
+
+// decodeEBMLVint decodes a variable-length integer.  Element IDs keep their
+// length marker bit; sizes and other values don't.
+func decodeEBMLVint(data []byte, keepMarker bool) (uint64, int, error) {
+	if len(data) == 0 || data[0] == 0 {
+		return 0, 0, fmt.Errorf("%w: invalid variable-length integer", ErrInvalidMatroska)
+	}
+	length := 1
+	for mask := byte(0x80); data[0]&mask == 0; mask >>= 1 {
+		length++
+	}
+	if len(data) < length {
+		return 0, 0, fmt.Errorf("%w: truncated variable-length integer", ErrInvalidMatroska)
+	}
+	value := uint64(data[0])
+	if !keepMarker {
+		value &^= 0x80 >> (length - 1)
+	}
+	for _, b := range data[1:length] {
+		value = value<<8 | uint64(b)
+	}
+	return value, length, nil
+}
+
+func ebmlUint(body []byte) uint64 {
+	var value uint64
+	for _, b := range body {
+		value = value<<8 | uint64(b)
+	}
+	return value
+}
+
+func ebmlFloat(body []byte) float64 {
+	switch len(body) {
+	case 4:
+		return float64(math.Float32frombits(binary.BigEndian.Uint32(body)))
+	case 8:
+		return math.Float64frombits(binary.BigEndian.Uint64(body))
+	default:
+		return 0
+	}
+}
+
+func ebmlString(body []byte) string {
+	return strings.TrimRight(string(body), "\x00")
+}
@@ -0,0 +1,207 @@
+package internal_test
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"math"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/krelinga/go-libs/deep"
+	"github.com/krelinga/go-libs/exam"
+	"github.com/krelinga/go-libs/match"
+	"github.com/krelinga/video-info/internal"
+)
+
+// ebml encodes an element with the given ID and body.  Sizes are always
+// written in eight bytes, which is valid if wasteful.
+func ebml(id uint32, body ...[]byte) []byte {
+	var out []byte
+	for shift := 24; shift >= 0; shift -= 8 {
+		if b := byte(id >> shift); b != 0 || len(out) > 0 {
+			out = append(out, b)
+		}
+	}
+	var size int
+	for _, b := range body {
+		size += len(b)
+	}
+	sizeBytes := binary.BigEndian.AppendUint64(nil, uint64(size))
+	sizeBytes[0] = 0x01
+	out = append(out, sizeBytes...)
+	for _, b := range body {
+		out = append(out, b...)
+	}
+	return out
+}
+
+func ebmlUint(id uint32, value uint64) []byte {
+	return ebml(id, binary.BigEndian.AppendUint64(nil, value))
+}
+
+func ebmlFloat(id uint32, value float64) []byte {
+	return ebml(id, binary.BigEndian.AppendUint64(nil, math.Float64bits(value)))
+}
+
+func ebmlString(id uint32, value string) []byte {
+	return ebml(id, []byte(value))
+}
+
+func TestReadMatroska(t *testing.T) {
+	e := exam.New(t)
+	env := deep.NewEnv()
+
+	header := ebml(0x1A45DFA3, ebmlString(0x4282, "matroska"))
+	segmentUID := []byte("0123456789abcdef")
+	linkedUID := []byte("fedcba9876543210")
+	info := ebml(0x1549A966,
+		ebml(0x73A4, segmentUID),
+		ebmlFloat(0x4489, 90000),
+	)
+	chapters := ebml(0x1043A770,
+		ebml(0x45B9,
+			ebmlUint(0x45BC, 1),
+			ebmlUint(0x45DB, 1),
+			ebmlUint(0x45DD, 1),
+			ebml(0xB6,
+				ebmlUint(0x73C4, 11),
+				ebmlUint(0x91, 0),
+				ebmlUint(0x92, 60e9),
+				ebml(0x80, ebmlString(0x85, "Part A")),
+			),
+			ebml(0xB6,
+				ebmlUint(0x73C4, 12),
+				ebmlUint(0x91, 0),
+				ebmlUint(0x92, 90e9),
+				ebml(0x6E67, linkedUID),
+				ebml(0x80, ebmlString(0x85, "Opening")),
+			),
+			ebml(0xB6,
+				ebmlUint(0x73C4, 13),
+				ebmlUint(0x91, 60e9),
+				ebmlUint(0x92, 90e9),
+				ebmlUint(0x4598, 0),
+			),
+		),
+		ebml(0x45B9,
+			ebmlUint(0x45BC, 2),
+			ebml(0xB6,
+				ebmlUint(0x73C4, 21),
+				ebmlUint(0x91, 0),
+				ebmlUint(0x92, 90e9),
+			),
+		),
+	)
+	cluster := ebml(0x1F43B675, ebmlUint(0xE7, 0))
+
+	wantInfo := &internal.MatroskaInfo{
+		SegmentUID:      segmentUID,
+		DurationSeconds: 90,
+		Editions: []internal.MatroskaEdition{
+			{
+				UID:     1,
+				Default: true,
+				Ordered: true,
+				Chapters: []internal.MatroskaChapter{
+					{UID: 11, Title: "Part A", Start: 0, End: 60e9, Enabled: true},
+					{UID: 12, Title: "Opening", Start: 0, End: 90e9, Enabled: true, SegmentUID: linkedUID},
+					{UID: 13, Start: 60e9, End: 90e9},
+				},
+			},
+			{
+				UID:      2,
+				Chapters: []internal.MatroskaChapter{{UID: 21, Start: 0, End: 90e9, Enabled: true}},
+			},
+		},
+	}
+
+	write := func(data []byte) string {
+		path := filepath.Join(t.TempDir(), "video.mkv")
+		exam.Nil(e, env, os.WriteFile(path, data, 0o600))
+		return path
+	}
+
+	e.Run("Chapters before clusters", func(e exam.E) {
+		path := write(slices.Concat(header, ebml(0x18538067, info, chapters, cluster)))
+		got, err := internal.ReadMatroska(path)
+		exam.Nil(e, env, err)
+		exam.Equal(e, env, wantInfo, got)
+		exam.Equal(e, env, 150.0, got.Editions[0].TimelineSeconds())
+	})
+
+	e.Run("Chapters after clusters", func(e exam.E) {
+		// The seek head is a fixed size, so the chapters' position can be
+		// computed before it is encoded
+		seekHeadSize := len(ebml(0x114D9B74, ebml(0x4DBB, ebmlUint(0x53AB, 0x1043A770), ebmlUint(0x53AC, 0))))
+		position := uint64(seekHeadSize + len(info) + len(cluster))
+		seekHead := ebml(0x114D9B74, ebml(0x4DBB, ebmlUint(0x53AB, 0x1043A770), ebmlUint(0x53AC, position)))
+		path := write(slices.Concat(header, ebml(0x18538067, seekHead, info, cluster, chapters)))
+		got, err := internal.ReadMatroska(path)
+		exam.Nil(e, env, err)
+		exam.Equal(e, env, wantInfo, got)
+	})
+
+	e.Run("Sample file", func(e exam.E) {
+		got, err := internal.ReadMatroska("../testdata/testdata_sample_640x360.mkv")
+		exam.Nil(e, env, err)
+		exam.NotNil(e, env, got)
+	})
+
+	e.Run("Not Matroska", func(e exam.E) {
+		path := write([]byte("\x00\x00\x00\x18ftypmp42\x00\x00\x00\x00mp42isom"))
+		_, err := internal.ReadMatroska(path)
+		exam.Match(e, env, err, match.ErrorIs(internal.ErrNotMatroska))
+	})
+
+	e.Run("Truncated", func(e exam.E) {
+		data := slices.Concat(header, ebml(0x18538067, info, chapters))
+		path := write(data[:len(data)-10])
+		_, err := internal.ReadMatroska(path)
+		exam.Match(e, env, err, match.ErrorIs(internal.ErrInvalidMatroska))
+	})
+}
+
+func TestLinkedSegments(t *testing.T) {
+	e := exam.New(t)
+	env := deep.NewEnv()
+
+	header := ebml(0x1A45DFA3, ebmlString(0x4282, "matroska"))
+	segment := func(uid string, children ...[]byte) []byte {
+		info := ebml(0x1549A966, ebml(0x73A4, []byte(uid)))
+		return slices.Concat(header, ebml(0x18538067, slices.Concat([][]byte{info}, children)...))
+	}
+	// The main file takes its opening from another segment, and the ending
+	// from a segment that isn't on disk
+	chapters := ebml(0x1043A770,
+		ebml(0x45B9,
+			ebmlUint(0x45DD, 1),
+			ebml(0xB6, ebmlUint(0x91, 0), ebmlUint(0x92, 90e9), ebml(0x6E67, []byte("opening_segment_"))),
+			ebml(0xB6, ebmlUint(0x91, 0), ebmlUint(0x92, 60e9)),
+			ebml(0xB6, ebmlUint(0x91, 0), ebmlUint(0x92, 90e9), ebml(0x6E67, []byte("ending_segment__"))),
+			ebml(0xB6, ebmlUint(0x91, 0), ebmlUint(0x92, 90e9), ebml(0x6E67, []byte("opening_segment_"))),
+		),
+	)
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "Episode.mkv")
+	exam.Nil(e, env, os.WriteFile(path, segment("episode_segment_", chapters), 0o600))
+	exam.Nil(e, env, os.WriteFile(filepath.Join(dir, "Opening.mkv"), segment("opening_segment_"), 0o600))
+	exam.Nil(e, env, os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("ending_segment__"), 0o600))
+
+	info, err := internal.ReadMatroska(path)
+	exam.Nil(e, env, err)
+	segments := info.LinkedSegments()
+	exam.Equal(e, env, []internal.LinkedSegment{
+		{UID: hex.EncodeToString([]byte("opening_segment_"))},
+		{UID: hex.EncodeToString([]byte("ending_segment__"))},
+	}, segments)
+
+	exam.Nil(e, env, internal.FindLinkedSegments(path, segments))
+	opening := "Opening.mkv"
+	exam.Equal(e, env, []internal.LinkedSegment{
+		{UID: hex.EncodeToString([]byte("opening_segment_")), Present: true, File: &opening},
+		{UID: hex.EncodeToString([]byte("ending_segment__"))},
+	}, segments)
+}
@@ -21,18 +21,27 @@ var migrationsFS embed.FS
 // to prevent concurrent migrations from running.
 const advisoryLockID = 7294815603
 
-// acquireAdvisoryLock attempts to acquire a postgres advisory lock.
-func acquireAdvisoryLock(ctx context.Context, pool *pgxpool.Pool) error {
-	_, err := pool.Exec(ctx, "SELECT pg_advisory_lock($1)", advisoryLockID)
+// acquireAdvisoryLock acquires a postgres advisory lock on a connection
+// reserved from pool.  Advisory locks belong to the session that took them,
+// so the lock must be released with releaseAdvisoryLock on the same
+// connection.
+func acquireAdvisoryLock(ctx context.Context, pool *pgxpool.Pool) (*pgxpool.Conn, error) {
+	conn, err := pool.Acquire(ctx)
 	if err != nil {
-		return fmt.Errorf("failed to acquire advisory lock: %w", err)
+		return nil, fmt.Errorf("failed to acquire connection for advisory lock: %w", err)
 	}
-	return nil
+	if _, err := conn.Exec(ctx, "SELECT pg_advisory_lock($1)", advisoryLockID); err != nil {
+		conn.Release()
+		return nil, fmt.Errorf("failed to acquire advisory lock: %w", err)
+	}
+	return conn, nil
 }
 
-// releaseAdvisoryLock releases the postgres advisory lock.
-func releaseAdvisoryLock(ctx context.Context, pool *pgxpool.Pool) error {
-	_, err := pool.Exec(ctx, "SELECT pg_advisory_unlock($1)", advisoryLockID)
+// releaseAdvisoryLock releases the postgres advisory lock and returns its
+// connection to the pool.
+func releaseAdvisoryLock(ctx context.Context, conn *pgxpool.Conn) error {
+	defer conn.Release()
+	_, err := conn.Exec(ctx, "SELECT pg_advisory_unlock($1)", advisoryLockID)
 	if err != nil {
 		return fmt.Errorf("failed to release advisory lock: %w", err)
 	}
@@ -58,10 +67,11 @@ func createMigrator(pool *pgxpool.Pool) (*migrate.Migrate, error) {
 // MigrateUp runs all pending migrations (both River and application migrations).
 // It acquires a postgres advisory lock to prevent concurrent migrations.
 func MigrateUp(ctx context.Context, pool *pgxpool.Pool) error {
-	if err := acquireAdvisoryLock(ctx, pool); err != nil {
+	lockConn, err := acquireAdvisoryLock(ctx, pool)
+	if err != nil {
 		return err
 	}
-	defer releaseAdvisoryLock(ctx, pool)
+	defer releaseAdvisoryLock(ctx, lockConn)
 
 	// Run River migrations first
 	riverMigrator, err := rivermigrate.New(riverpgxv5.New(pool), nil)
@@ -91,10 +101,11 @@ func MigrateUp(ctx context.Context, pool *pgxpool.Pool) error {
 // MigrateDown rolls back all migrations (both application and River migrations).
 // It acquires a postgres advisory lock to prevent concurrent migrations.
 func MigrateDown(ctx context.Context, pool *pgxpool.Pool) error {
-	if err := acquireAdvisoryLock(ctx, pool); err != nil {
+	lockConn, err := acquireAdvisoryLock(ctx, pool)
+	if err != nil {
 		return err
 	}
-	defer releaseAdvisoryLock(ctx, pool)
+	defer releaseAdvisoryLock(ctx, lockConn)
 
 	// Run application migrations down first
 	m, err := createMigrator(pool)
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS uuid_job_mapping_external_id_idx;
+ALTER TABLE uuid_job_mapping DROP COLUMN IF EXISTS external_id;
@@ -0,0 +1,2 @@
+ALTER TABLE uuid_job_mapping ADD COLUMN external_id TEXT;
+CREATE UNIQUE INDEX uuid_job_mapping_external_id_idx ON uuid_job_mapping (external_id) WHERE external_id IS NOT NULL;
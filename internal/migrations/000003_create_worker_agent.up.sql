@@ -0,0 +1,9 @@
+CREATE TABLE worker_agent (
+    worker_id TEXT PRIMARY KEY,
+    hostname TEXT NOT NULL,
+    mounts TEXT[] NOT NULL DEFAULT '{}',
+    ffprobe_version TEXT NOT NULL,
+    capacity INTEGER NOT NULL,
+    registered_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    last_seen_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
@@ -0,0 +1 @@
+ALTER TABLE worker_agent DROP COLUMN IF EXISTS gpu_capacity;
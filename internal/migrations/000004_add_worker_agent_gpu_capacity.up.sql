@@ -0,0 +1 @@
+ALTER TABLE worker_agent ADD COLUMN gpu_capacity INTEGER NOT NULL DEFAULT 0;
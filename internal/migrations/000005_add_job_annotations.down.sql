@@ -0,0 +1,3 @@
+ALTER TABLE uuid_job_mapping DROP COLUMN IF EXISTS annotated_at;
+ALTER TABLE uuid_job_mapping DROP COLUMN IF EXISTS acknowledged;
+ALTER TABLE uuid_job_mapping DROP COLUMN IF EXISTS annotation_note;
@@ -0,0 +1,3 @@
+ALTER TABLE uuid_job_mapping ADD COLUMN annotation_note TEXT;
+ALTER TABLE uuid_job_mapping ADD COLUMN acknowledged BOOLEAN NOT NULL DEFAULT false;
+ALTER TABLE uuid_job_mapping ADD COLUMN annotated_at TIMESTAMPTZ;
@@ -0,0 +1,12 @@
+CREATE TABLE webhook_dead_letter (
+    id BIGSERIAL PRIMARY KEY,
+    river_job_id BIGINT NOT NULL,
+    info_uuid UUID NOT NULL,
+    target TEXT NOT NULL,
+    attempts INTEGER NOT NULL,
+    error TEXT NOT NULL,
+    failed_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX webhook_dead_letter_info_uuid_idx ON webhook_dead_letter (info_uuid);
+CREATE INDEX webhook_dead_letter_failed_at_idx ON webhook_dead_letter (failed_at);
@@ -0,0 +1,22 @@
+CREATE TABLE info_result (
+    uuid UUID PRIMARY KEY,
+    river_job_id BIGINT NOT NULL,
+    external_id TEXT,
+    video_path TEXT NOT NULL,
+    args JSONB NOT NULL,
+    output JSONB NOT NULL,
+    priority SMALLINT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    finalized_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX info_result_external_id_idx ON info_result (external_id) WHERE external_id IS NOT NULL;
+CREATE INDEX info_result_video_path_idx ON info_result (video_path);
+
+-- Keep the results of jobs completed before the table existed
+INSERT INTO info_result (uuid, river_job_id, external_id, video_path, args, output, priority, created_at, finalized_at)
+SELECT DISTINCT ON ((args->>'uuid')::uuid)
+    (args->>'uuid')::uuid, id, args->>'external_id', args->>'path', args, metadata->'output', priority, created_at, finalized_at
+FROM river_job
+WHERE kind = 'info' AND state = 'completed' AND metadata ? 'output' AND finalized_at IS NOT NULL
+ORDER BY (args->>'uuid')::uuid, finalized_at DESC;
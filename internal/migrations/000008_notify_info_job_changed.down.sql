@@ -0,0 +1,4 @@
+DROP TRIGGER IF EXISTS info_job_annotated ON uuid_job_mapping;
+DROP FUNCTION IF EXISTS notify_info_job_annotated();
+DROP TRIGGER IF EXISTS info_job_changed ON river_job;
+DROP FUNCTION IF EXISTS notify_info_job_changed();
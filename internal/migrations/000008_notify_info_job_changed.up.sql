@@ -0,0 +1,23 @@
+CREATE FUNCTION notify_info_job_changed() RETURNS trigger AS $$
+BEGIN
+    PERFORM pg_notify('info_job_changed', OLD.id::text);
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER info_job_changed
+    AFTER UPDATE OR DELETE ON river_job
+    FOR EACH ROW WHEN (OLD.kind = 'info')
+    EXECUTE FUNCTION notify_info_job_changed();
+
+CREATE FUNCTION notify_info_job_annotated() RETURNS trigger AS $$
+BEGIN
+    PERFORM pg_notify('info_job_changed', OLD.river_job_id::text);
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER info_job_annotated
+    AFTER UPDATE OR DELETE ON uuid_job_mapping
+    FOR EACH ROW
+    EXECUTE FUNCTION notify_info_job_annotated();
@@ -0,0 +1,11 @@
+CREATE TABLE schedules (
+    id UUID PRIMARY KEY,
+    cron TEXT NOT NULL,
+    directory TEXT NOT NULL,
+    args JSONB NOT NULL,
+    next_run_at TIMESTAMPTZ NOT NULL,
+    last_run_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX schedules_next_run_at_idx ON schedules (next_run_at);
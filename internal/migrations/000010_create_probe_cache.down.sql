@@ -0,0 +1 @@
+DROP TABLE IF EXISTS probe_cache;
@@ -0,0 +1,11 @@
+CREATE TABLE probe_cache (
+    video_path TEXT NOT NULL,
+    options_key TEXT NOT NULL,
+    size BIGINT NOT NULL,
+    mod_time_ns BIGINT NOT NULL,
+    result JSONB NOT NULL,
+    cached_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (video_path, options_key)
+);
+
+CREATE INDEX probe_cache_cached_at_idx ON probe_cache (cached_at);
@@ -0,0 +1,9 @@
+CREATE TABLE webhook_batch_item (
+    river_job_id BIGINT PRIMARY KEY REFERENCES river_job(id) ON DELETE CASCADE,
+    batch_key TEXT NOT NULL,
+    delivered_at TIMESTAMPTZ,
+    target TEXT
+);
+
+CREATE INDEX webhook_batch_item_undelivered_idx ON webhook_batch_item (batch_key, river_job_id)
+    WHERE delivered_at IS NULL;
@@ -0,0 +1,9 @@
+CREATE TABLE webhook_breaker (
+    target TEXT PRIMARY KEY,
+    failures INTEGER NOT NULL,
+    trips INTEGER NOT NULL,
+    opened_at TIMESTAMPTZ,
+    open_until TIMESTAMPTZ,
+    probing_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ NOT NULL
+);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS webhook_rate_slot;
+DROP TABLE IF EXISTS webhook_rate_limit;
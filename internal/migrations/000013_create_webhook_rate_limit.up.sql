@@ -0,0 +1,9 @@
+CREATE TABLE webhook_rate_limit (
+    target TEXT PRIMARY KEY,
+    next_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE TABLE webhook_rate_slot (
+    river_job_id BIGINT PRIMARY KEY REFERENCES river_job(id) ON DELETE CASCADE,
+    slot_at TIMESTAMPTZ NOT NULL
+);
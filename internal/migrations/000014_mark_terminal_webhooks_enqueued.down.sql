@@ -0,0 +1,2 @@
+UPDATE river_job SET metadata = metadata - 'webhook_enqueued'
+WHERE kind = 'info';
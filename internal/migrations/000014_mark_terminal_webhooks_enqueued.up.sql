@@ -0,0 +1,4 @@
+-- Jobs that finished before cancelled and failed jobs were notified aren't
+-- notified now
+UPDATE river_job SET metadata = metadata || '{"webhook_enqueued": true}'
+WHERE kind = 'info' AND state IN ('cancelled', 'discarded');
@@ -0,0 +1,14 @@
+CREATE TABLE reprobe_campaigns (
+    id UUID PRIMARY KEY,
+    ffprobe_version TEXT NOT NULL,
+    fields TEXT[],
+    path_prefix TEXT,
+    per_minute INTEGER NOT NULL,
+    -- Video paths up to and including this one have been dealt with
+    after_path TEXT NOT NULL DEFAULT '',
+    enqueued INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    finished_at TIMESTAMPTZ
+);
+
+CREATE INDEX reprobe_campaigns_running_idx ON reprobe_campaigns (created_at) WHERE finished_at IS NULL;
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS info_result_finalized_at_idx;
+DROP TABLE IF EXISTS kafka_sink_cursor;
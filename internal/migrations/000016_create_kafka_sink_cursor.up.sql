@@ -0,0 +1,9 @@
+CREATE TABLE kafka_sink_cursor (
+    topic TEXT PRIMARY KEY,
+    -- Results up to and including this one have been published
+    after_finalized_at TIMESTAMPTZ NOT NULL DEFAULT '-infinity',
+    after_uuid UUID NOT NULL DEFAULT '00000000-0000-0000-0000-000000000000',
+    published BIGINT NOT NULL DEFAULT 0
+);
+
+CREATE INDEX info_result_finalized_at_idx ON info_result (finalized_at, uuid);
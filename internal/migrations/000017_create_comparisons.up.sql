@@ -0,0 +1,7 @@
+CREATE TABLE comparisons (
+    id UUID PRIMARY KEY,
+    video_path TEXT NOT NULL,
+    baseline_uuid UUID NOT NULL,
+    candidate_uuid UUID NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS info_result_video_path_trgm_idx;
+
+-- pg_trgm is left installed, as other database objects may have come to
+-- depend on it
@@ -0,0 +1,13 @@
+-- CREATE EXTENSION requires a superuser, or on PostgreSQL 13 and later the
+-- database owner, as pg_trgm is a trusted extension.  Where the server's
+-- role is neither, install pg_trgm before upgrading:
+--
+--     CREATE EXTENSION IF NOT EXISTS pg_trgm;
+--
+-- after which this statement does nothing.
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+-- A trigram index serves the case-insensitive substring searches of video
+-- paths that the btree indexes can't.  River's job table gets the same
+-- index in 000021, which builds it concurrently.
+CREATE INDEX info_result_video_path_trgm_idx ON info_result USING gin (video_path gin_trgm_ops);
@@ -0,0 +1 @@
+ALTER TABLE schedules ADD COLUMN exclude TEXT[] NOT NULL DEFAULT '{}';
@@ -0,0 +1 @@
+ALTER TABLE schedules ADD COLUMN min_age_seconds INTEGER NOT NULL DEFAULT 0;
@@ -0,0 +1 @@
+DROP INDEX CONCURRENTLY IF EXISTS river_job_path_trgm_idx;
@@ -0,0 +1,13 @@
+-- Trigram index for substring searches of the paths of queued and running
+-- jobs, like that of info_result in 000018.  River's job table is written by
+-- every job claim, so the index is built concurrently rather than under a
+-- lock that would stop workers claiming jobs for as long as the build takes.
+-- CREATE INDEX CONCURRENTLY can't run in a transaction, so it must be the
+-- only statement of this migration.
+--
+-- IF NOT EXISTS lets an operator build the index by hand ahead of the
+-- upgrade, at a quiet time, by running this statement.  A build that fails
+-- leaves an invalid index of the same name behind, which IF NOT EXISTS would
+-- accept, so it must be dropped before the migration is retried, as the
+-- README describes.
+CREATE INDEX CONCURRENTLY IF NOT EXISTS river_job_path_trgm_idx ON river_job USING gin ((args->>'path') gin_trgm_ops);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS scan_catalog;
+ALTER TABLE schedules DROP COLUMN IF EXISTS incremental;
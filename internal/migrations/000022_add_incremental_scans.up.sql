@@ -0,0 +1,11 @@
+ALTER TABLE schedules ADD COLUMN incremental BOOLEAN NOT NULL DEFAULT false;
+
+CREATE TABLE scan_catalog (
+    schedule_id UUID NOT NULL REFERENCES schedules(id) ON DELETE CASCADE,
+    video_path TEXT NOT NULL,
+    size BIGINT NOT NULL,
+    mod_time_ns BIGINT NOT NULL,
+    info_uuid UUID,
+    cataloged_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (schedule_id, video_path)
+);
@@ -0,0 +1,24 @@
+package internal
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+)
+
+var ErrInvalidMount = errors.New("invalid mount")
+
+// NormalizeMount cleans an absolute directory path and gives it a trailing
+// slash, so that a prefix match against a video path only matches whole
+// path components.
+func NormalizeMount(mount string) (string, error) {
+	if !path.IsAbs(mount) {
+		return "", fmt.Errorf("%w: %q is not an absolute path", ErrInvalidMount, mount)
+	}
+	mount = path.Clean(mount)
+	if !strings.HasSuffix(mount, "/") {
+		mount += "/"
+	}
+	return mount, nil
+}
@@ -0,0 +1,61 @@
+package internal_test
+
+import (
+	"testing"
+
+	"github.com/krelinga/go-libs/deep"
+	"github.com/krelinga/go-libs/exam"
+	"github.com/krelinga/go-libs/match"
+	"github.com/krelinga/video-info/internal"
+)
+
+func TestNormalizeMount(t *testing.T) {
+	e := exam.New(t)
+	env := deep.NewEnv()
+
+	tests := []struct {
+		loc     exam.Loc
+		name    string
+		mount   string
+		want    string
+		wantErr bool
+	}{
+		{
+			loc:   exam.Here(),
+			name:  "Adds trailing slash",
+			mount: "/videos",
+			want:  "/videos/",
+		},
+		{
+			loc:   exam.Here(),
+			name:  "Cleans path",
+			mount: "/videos//movies/../tv/",
+			want:  "/videos/tv/",
+		},
+		{
+			loc:   exam.Here(),
+			name:  "Root",
+			mount: "/",
+			want:  "/",
+		},
+		{
+			loc:     exam.Here(),
+			name:    "Relative path",
+			mount:   "videos",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		e.Run(tt.name, func(e exam.E) {
+			e.Log("Running test at", tt.loc)
+
+			got, err := internal.NormalizeMount(tt.mount)
+			if tt.wantErr {
+				exam.Match(e, env, err, match.ErrorIs(internal.ErrInvalidMount))
+			} else {
+				exam.Nil(e, env, err)
+				exam.Equal(e, env, tt.want, got)
+			}
+		})
+	}
+}
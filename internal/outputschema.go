@@ -0,0 +1,96 @@
+package internal
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/krelinga/video-info/virest"
+)
+
+// ErrorCodeInvalidOutput is the error code of jobs whose result didn't match
+// the published MediaInfo schema, and was discarded rather than stored.
+const ErrorCodeInvalidOutput = "INVALID_OUTPUT"
+
+// mediaInfoSchema returns the MediaInfo schema of the published API.
+var mediaInfoSchema = sync.OnceValues(func() (*openapi3.Schema, error) {
+	spec, err := virest.GetSwagger()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load API spec: %w", err)
+	}
+	ref, ok := spec.Components.Schemas["MediaInfo"]
+	if !ok || ref.Value == nil {
+		return nil, fmt.Errorf("API spec has no MediaInfo schema")
+	}
+	return ref.Value, nil
+})
+
+// ValidateMediaInfo checks info against the published MediaInfo schema.
+func ValidateMediaInfo(info *virest.MediaInfo) error {
+	schema, err := mediaInfoSchema()
+	if err != nil {
+		return err
+	}
+	// Validation works on decoded JSON rather than Go values
+	encoded, err := json.Marshal(info)
+	if err != nil {
+		return fmt.Errorf("failed to marshal result: %w", err)
+	}
+	var value any
+	if err := json.Unmarshal(encoded, &value); err != nil {
+		return fmt.Errorf("failed to unmarshal result: %w", err)
+	}
+	return schema.VisitJSON(value)
+}
+
+// EnforceOutputSchema replaces a result in status that doesn't match the
+// published MediaInfo schema with an ErrorCodeInvalidOutput error, so that
+// clients are never handed results they can't parse.
+func EnforceOutputSchema(status *InfoJobStatus) {
+	if status.Result == nil {
+		return
+	}
+	if err := ValidateMediaInfo(status.Result.RESTMediaInfo()); err != nil {
+		errMsg := Redact(fmt.Sprintf("result does not match the published schema: %v", err))
+		errorCode := ErrorCodeInvalidOutput
+		status.Result = nil
+		status.Error = &errMsg
+		status.ErrorCode = &errorCode
+	}
+}
+
+// UnmarshalJSON decodes a stored result.  Results written by newer workers
+// may have properties this version doesn't know, which are ignored, or whose
+// representation has changed.  Properties that can't be decoded are left
+// unset and noted in Warnings, rather than failing the whole result.
+func (r *InfoJobResult) UnmarshalJSON(data []byte) error {
+	type plainResult InfoJobResult
+	if err := json.Unmarshal(data, (*plainResult)(r)); err == nil {
+		return nil
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+	*r = InfoJobResult{}
+	var unreadable []string
+	v := reflect.ValueOf(r).Elem()
+	for i := range v.NumField() {
+		name, _, _ := strings.Cut(v.Type().Field(i).Tag.Get("json"), ",")
+		raw, ok := fields[name]
+		if !ok {
+			continue
+		}
+		field := v.Field(i)
+		if err := json.Unmarshal(raw, field.Addr().Interface()); err != nil {
+			field.SetZero()
+			unreadable = append(unreadable, fmt.Sprintf("result property %s could not be read: %v", name, err))
+		}
+	}
+	r.Warnings = append(r.Warnings, unreadable...)
+	return nil
+}
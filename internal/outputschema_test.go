@@ -0,0 +1,104 @@
+package internal_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/krelinga/go-libs/deep"
+	"github.com/krelinga/go-libs/exam"
+	"github.com/krelinga/video-info/internal"
+	"github.com/krelinga/video-info/virest"
+)
+
+func TestEnforceOutputSchema(t *testing.T) {
+	e := exam.New(t)
+	env := deep.NewEnv()
+	title := "Opening"
+	language := "eng"
+	invalidOutput := internal.ErrorCodeInvalidOutput
+
+	tests := []struct {
+		loc           exam.Loc
+		name          string
+		result        *internal.InfoJobResult
+		wantErrorCode *string
+	}{
+		{
+			loc:    exam.Here(),
+			name:   "No chapters",
+			result: &internal.InfoJobResult{DurationSeconds: 60},
+		},
+		{
+			loc:  exam.Here(),
+			name: "Full result",
+			result: &internal.InfoJobResult{
+				MediaKind:               virest.Video,
+				DurationSeconds:         120,
+				ChapterDurationsSeconds: []float64{60, 60},
+				Chapters:                []internal.Chapter{{Title: &title, StartSeconds: 0, EndSeconds: 60}},
+				Container:               &internal.Container{FormatName: "matroska,webm"},
+				VideoStreams:            []internal.VideoStream{{Index: 0, CodecName: "h264", Width: 1920, Height: 1080}},
+				AudioTracks:             []internal.AudioTrack{{Index: 1, CodecName: "aac", Channels: 2, Language: &language}},
+				SubtitleTracks:          []internal.SubtitleTrack{{Index: 2, CodecName: "subrip"}},
+				Editions:                []internal.MediaEdition{{Default: true}},
+				Warnings:                []string{"crop detection skipped"},
+				Versions:                &internal.ToolVersions{Worker: "v1.2.3", FFprobe: "7.1", FFmpeg: "7.1"},
+			},
+		},
+		{
+			loc:           exam.Here(),
+			name:          "Unknown media kind",
+			result:        &internal.InfoJobResult{MediaKind: "hologram"},
+			wantErrorCode: &invalidOutput,
+		},
+	}
+	for _, tt := range tests {
+		e.Run(tt.name, func(e exam.E) {
+			e.Log("Running test at", tt.loc)
+			status := internal.InfoJobStatus{Result: tt.result}
+			internal.EnforceOutputSchema(&status)
+			exam.Equal(e, env, tt.wantErrorCode, status.ErrorCode)
+			exam.Equal(e, env, tt.wantErrorCode == nil, status.Result != nil)
+			exam.Equal(e, env, tt.wantErrorCode != nil, status.Error != nil)
+		})
+	}
+}
+
+func TestInfoJobResultUnmarshalJSON(t *testing.T) {
+	e := exam.New(t)
+	env := deep.NewEnv()
+
+	tests := []struct {
+		loc  exam.Loc
+		name string
+		data string
+		want internal.InfoJobResult
+	}{
+		{
+			loc:  exam.Here(),
+			name: "Unknown property",
+			data: `{"duration_seconds": 60, "chapter_durations_seconds": [60], "hdr_format": "dolby_vision"}`,
+			want: internal.InfoJobResult{DurationSeconds: 60, ChapterDurationsSeconds: []float64{60}},
+		},
+		{
+			loc:  exam.Here(),
+			name: "Changed property",
+			data: `{"duration_seconds": 60, "frame_count": "1440", "warnings": ["loudness skipped"]}`,
+			want: internal.InfoJobResult{
+				DurationSeconds: 60,
+				Warnings: []string{
+					"loudness skipped",
+					"result property frame_count could not be read: json: cannot unmarshal string into Go value of type int",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		e.Run(tt.name, func(e exam.E) {
+			e.Log("Running test at", tt.loc)
+			var got internal.InfoJobResult
+			exam.Nil(e, env, json.Unmarshal([]byte(tt.data), &got))
+			exam.Equal(e, env, tt.want, got)
+		})
+	}
+}
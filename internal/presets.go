@@ -0,0 +1,112 @@
+package internal
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+)
+
+var ErrInvalidPresetRules = errors.New("invalid preset rules")
+
+// PresetRule suggests an encoding preset for results matching all of its
+// conditions.  Unset conditions match anything.  Height conditions apply to
+// the first video stream, so they never match results without one.
+type PresetRule struct {
+	// Preset is the suggested preset, in whatever form the consumer expects,
+	// such as "1080p HQ, decomb, keep TrueHD".
+	Preset string `json:"preset"`
+
+	MinHeight *int `json:"minHeight,omitempty"`
+	MaxHeight *int `json:"maxHeight,omitempty"`
+
+	// VideoCodecs matches the codec of the first video stream.
+	VideoCodecs []string `json:"videoCodecs,omitempty"`
+
+	// AudioCodecs matches if any audio track uses one of the codecs.
+	AudioCodecs []string `json:"audioCodecs,omitempty"`
+
+	// MinAudioChannels matches if any audio track has at least this many
+	// channels.
+	MinAudioChannels *int `json:"minAudioChannels,omitempty"`
+}
+
+// matches reports whether r applies to result.
+func (r *PresetRule) matches(result *InfoJobResult) bool {
+	if r.MinHeight != nil || r.MaxHeight != nil || len(r.VideoCodecs) > 0 {
+		if len(result.VideoStreams) == 0 {
+			return false
+		}
+		video := result.VideoStreams[0]
+		if r.MinHeight != nil && video.Height < *r.MinHeight {
+			return false
+		}
+		if r.MaxHeight != nil && video.Height > *r.MaxHeight {
+			return false
+		}
+		if len(r.VideoCodecs) > 0 && !slices.Contains(r.VideoCodecs, video.CodecName) {
+			return false
+		}
+	}
+	if len(r.AudioCodecs) > 0 && !slices.ContainsFunc(result.AudioTracks, func(t AudioTrack) bool {
+		return slices.Contains(r.AudioCodecs, t.CodecName)
+	}) {
+		return false
+	}
+	if r.MinAudioChannels != nil && !slices.ContainsFunc(result.AudioTracks, func(t AudioTrack) bool {
+		return t.Channels >= *r.MinAudioChannels
+	}) {
+		return false
+	}
+	return true
+}
+
+// PresetRules maps probe results to suggested encoding presets.  The first
+// matching rule wins.
+//
+// A nil PresetRules never suggests a preset.
+type PresetRules struct {
+	rules []PresetRule
+}
+
+// NewPresetRules returns PresetRules applying rules in order.
+func NewPresetRules(rules []PresetRule) (*PresetRules, error) {
+	for i, rule := range rules {
+		if rule.Preset == "" {
+			return nil, fmt.Errorf("%w: rule %d has no preset", ErrInvalidPresetRules, i)
+		}
+	}
+	return &PresetRules{rules: rules}, nil
+}
+
+// LoadPresetRules reads PresetRules from a JSON file holding an array of
+// rules, or returns nil if path is empty.
+func LoadPresetRules(path string) (*PresetRules, error) {
+	if path == "" {
+		return nil, nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read preset rules: %w", err)
+	}
+	var rules []PresetRule
+	if err := json.Unmarshal(data, &rules); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPresetRules, err)
+	}
+	return NewPresetRules(rules)
+}
+
+// Suggest returns the preset of the first rule matching result, or nil if
+// none does.
+func (p *PresetRules) Suggest(result *InfoJobResult) *string {
+	if p == nil {
+		return nil
+	}
+	for i := range p.rules {
+		if p.rules[i].matches(result) {
+			return &p.rules[i].Preset
+		}
+	}
+	return nil
+}
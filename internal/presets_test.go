@@ -0,0 +1,105 @@
+package internal_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/krelinga/go-libs/deep"
+	"github.com/krelinga/go-libs/exam"
+	"github.com/krelinga/go-libs/match"
+	"github.com/krelinga/video-info/internal"
+)
+
+func TestPresetRules(t *testing.T) {
+	e := exam.New(t)
+	env := deep.NewEnv()
+
+	path := filepath.Join(t.TempDir(), "presets.json")
+	exam.Nil(e, env, os.WriteFile(path, []byte(`[
+		{"preset": "2160p HDR, keep TrueHD", "minHeight": 1600, "audioCodecs": ["truehd"]},
+		{"preset": "2160p HDR", "minHeight": 1600},
+		{"preset": "1080p HQ, surround", "minHeight": 720, "minAudioChannels": 6},
+		{"preset": "1080p HQ", "minHeight": 720},
+		{"preset": "SD, decomb", "maxHeight": 576, "videoCodecs": ["mpeg2video"]}
+	]`), 0o600))
+	rules, err := internal.LoadPresetRules(path)
+	exam.Nil(e, env, err)
+
+	video := func(codec string, height int, audio ...internal.AudioTrack) *internal.InfoJobResult {
+		return &internal.InfoJobResult{
+			VideoStreams: []internal.VideoStream{{CodecName: codec, Height: height}},
+			AudioTracks:  audio,
+		}
+	}
+	tests := []struct {
+		loc    exam.Loc
+		name   string
+		result *internal.InfoJobResult
+		want   string
+	}{
+		{
+			loc:    exam.Here(),
+			name:   "UHD with TrueHD",
+			result: video("hevc", 2160, internal.AudioTrack{CodecName: "ac3"}, internal.AudioTrack{CodecName: "truehd"}),
+			want:   "2160p HDR, keep TrueHD",
+		},
+		{
+			loc:    exam.Here(),
+			name:   "UHD without TrueHD",
+			result: video("hevc", 2160, internal.AudioTrack{CodecName: "eac3"}),
+			want:   "2160p HDR",
+		},
+		{
+			loc:    exam.Here(),
+			name:   "HD with surround",
+			result: video("h264", 1080, internal.AudioTrack{CodecName: "dts", Channels: 6}),
+			want:   "1080p HQ, surround",
+		},
+		{
+			loc:    exam.Here(),
+			name:   "HD with stereo",
+			result: video("h264", 1080, internal.AudioTrack{CodecName: "aac", Channels: 2}),
+			want:   "1080p HQ",
+		},
+		{
+			loc:    exam.Here(),
+			name:   "DVD",
+			result: video("mpeg2video", 480),
+			want:   "SD, decomb",
+		},
+		{
+			loc:    exam.Here(),
+			name:   "No match",
+			result: video("h264", 480),
+		},
+		{
+			loc:    exam.Here(),
+			name:   "Audio only",
+			result: &internal.InfoJobResult{AudioTracks: []internal.AudioTrack{{CodecName: "truehd", Channels: 8}}},
+		},
+	}
+	for _, tt := range tests {
+		e.Run(tt.name, func(e exam.E) {
+			e.Log("Running test at", tt.loc)
+			got := rules.Suggest(tt.result)
+			if tt.want == "" {
+				exam.Nil(e, env, got)
+			} else if exam.NotNil(e, env, got).Ok() {
+				exam.Equal(e, env, tt.want, *got)
+			}
+		})
+	}
+
+	e.Run("No rules", func(e exam.E) {
+		rules, err := internal.LoadPresetRules("")
+		exam.Nil(e, env, err)
+		exam.Nil(e, env, rules.Suggest(video("h264", 1080)))
+	})
+
+	e.Run("Rule without preset", func(e exam.E) {
+		minHeight := 720
+		_, err := internal.NewPresetRules([]internal.PresetRule{{MinHeight: &minHeight}})
+		exam.Match(e, env, err, match.ErrorIs(internal.ErrInvalidPresetRules))
+	})
+}
@@ -0,0 +1,61 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/riverqueue/river"
+)
+
+// River's range of job priorities.  Jobs with a lower number run first.
+const (
+	PriorityHighest = 1
+	PriorityLowest  = 4
+)
+
+// QueueMaintenance is the River queue for housekeeping jobs, kept apart so
+// they aren't stuck behind info jobs.
+const QueueMaintenance = "maintenance"
+
+// PriorityAgingArgs are the arguments of the periodic job that ages the
+// priority of waiting info jobs.
+type PriorityAgingArgs struct{}
+
+// Kind returns the job kind identifier for River.
+func (PriorityAgingArgs) Kind() string {
+	return "priority_aging"
+}
+
+// InsertOpts places the job on the maintenance queue.
+func (PriorityAgingArgs) InsertOpts() river.InsertOpts {
+	return river.InsertOpts{Queue: QueueMaintenance}
+}
+
+// AgeInfoJobPriorities raises the priority of info jobs waiting to run by one
+// level for every step they have waited since being created, up to
+// PriorityHighest, so that low priority jobs can't be starved by a steady
+// stream of higher priority ones.  It returns the number of jobs promoted.
+func AgeInfoJobPriorities(ctx context.Context, pool *pgxpool.Pool, step time.Duration) (int64, error) {
+	if step <= 0 {
+		return 0, nil
+	}
+	// The priority is recomputed from the submitted one rather than
+	// decremented, so running more often than step does no harm
+	tag, err := pool.Exec(ctx, `
+		WITH aged AS (
+			SELECT id, GREATEST($2::int,
+				COALESCE((args->>'priority')::int, $2) - floor(extract(epoch FROM now() - created_at)::float8 / $3)::int) AS priority
+			FROM river_job
+			WHERE kind = $1 AND state IN ('available', 'retryable') AND priority > $2
+		)
+		UPDATE river_job SET priority = aged.priority
+		FROM aged
+		WHERE river_job.id = aged.id AND aged.priority < river_job.priority`,
+		InfoJobArgs{}.Kind(), PriorityHighest, step.Seconds())
+	if err != nil {
+		return 0, fmt.Errorf("failed to age info job priorities: %w", err)
+	}
+	return tag.RowsAffected(), nil
+}
@@ -0,0 +1,122 @@
+package internal
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// ProbeCacheSettings are the worker settings that change the result of a
+// probe, so that workers configured differently don't share results.  The
+// worker's own version is left out, as resultCacheVersion marks the
+// changes to it that matter.
+type ProbeCacheSettings struct {
+	FFprobeVersion string  `json:"ffprobe,omitempty"`
+	FFmpegVersion  string  `json:"ffmpeg,omitempty"`
+	ProbeAudio     bool    `json:"probe_audio,omitempty"`
+	HWAccel        HWAccel `json:"hwaccel,omitempty"`
+}
+
+// probeCacheOptionsKey identifies the analyses a cached result includes and
+// the settings it was probed with, so that jobs asking for different
+// analyses and workers configured differently don't share results.  It
+// includes resultCacheVersion, so entries written by older workers are
+// never used.
+func probeCacheOptionsKey(analyses Analyses, settings ProbeCacheSettings) (string, error) {
+	analysesData, err := json.Marshal(analyses)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal analyses: %w", err)
+	}
+	settingsData, err := json.Marshal(settings)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal probe settings: %w", err)
+	}
+	return fmt.Sprintf("v%d:%s:%s", resultCacheVersion, analysesData, settingsData), nil
+}
+
+// LookupProbeCache returns the result stored by StoreProbeCache for the file
+// at videoPath, described by info, with the given analyses and settings, or
+// nil if there is none or the file's size or modification time has changed
+// since.
+func LookupProbeCache(ctx context.Context, pool *pgxpool.Pool, videoPath string, info os.FileInfo, analyses Analyses, settings ProbeCacheSettings) (*InfoJobResult, error) {
+	optionsKey, err := probeCacheOptionsKey(analyses, settings)
+	if err != nil {
+		return nil, err
+	}
+	var result InfoJobResult
+	err = pool.QueryRow(ctx, `
+		SELECT result FROM probe_cache
+		WHERE video_path = $1 AND options_key = $2 AND size = $3 AND mod_time_ns = $4`,
+		videoPath, optionsKey, info.Size(), info.ModTime().UnixNano()).Scan(&result)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to look up cached result: %w", err)
+	}
+	return &result, nil
+}
+
+// StoreProbeCache stores result as the result for the file at videoPath,
+// described by info, with the given analyses and settings, replacing any
+// result stored for an earlier state of the file.
+func StoreProbeCache(ctx context.Context, tx pgx.Tx, videoPath string, info os.FileInfo, analyses Analyses, settings ProbeCacheSettings, result *InfoJobResult) error {
+	optionsKey, err := probeCacheOptionsKey(analyses, settings)
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec(ctx, `
+		INSERT INTO probe_cache (video_path, options_key, size, mod_time_ns, result)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (video_path, options_key) DO UPDATE SET
+			size = excluded.size,
+			mod_time_ns = excluded.mod_time_ns,
+			result = excluded.result,
+			cached_at = now()`,
+		videoPath, optionsKey, info.Size(), info.ModTime().UnixNano(), result)
+	if err != nil {
+		return fmt.Errorf("failed to cache result: %w", err)
+	}
+	return nil
+}
+
+// FindCachedFiles returns the paths of the given files that have a result
+// stored by StoreProbeCache with the given analyses and settings for their
+// current size and modification time.
+func FindCachedFiles(ctx context.Context, tx pgx.Tx, files []ScannedFile, analyses Analyses, settings ProbeCacheSettings) (map[string]bool, error) {
+	optionsKey, err := probeCacheOptionsKey(analyses, settings)
+	if err != nil {
+		return nil, err
+	}
+	paths := make([]string, len(files))
+	sizes := make([]int64, len(files))
+	modTimes := make([]int64, len(files))
+	for i, file := range files {
+		paths[i] = file.Path
+		sizes[i] = file.Size
+		modTimes[i] = file.ModTime.UnixNano()
+	}
+	rows, err := tx.Query(ctx, `
+		SELECT probe_cache.video_path
+		FROM probe_cache
+		JOIN unnest($2::text[], $3::bigint[], $4::bigint[]) AS file (video_path, size, mod_time_ns)
+			USING (video_path, size, mod_time_ns)
+		WHERE probe_cache.options_key = $1`,
+		optionsKey, paths, sizes, modTimes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up cached results: %w", err)
+	}
+	cachedPaths, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up cached results: %w", err)
+	}
+	cached := make(map[string]bool, len(cachedPaths))
+	for _, path := range cachedPaths {
+		cached[path] = true
+	}
+	return cached, nil
+}
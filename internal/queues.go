@@ -0,0 +1,66 @@
+package internal
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrInvalidQueue     = errors.New("invalid queue")
+	ErrInvalidQueueList = errors.New("invalid queue list")
+)
+
+// queueNamePattern matches the queue names jobs may be submitted to.  It is
+// stricter than River's own rules.
+var queueNamePattern = regexp.MustCompile(`^[a-z0-9]+([_-][a-z0-9]+)*$`)
+
+// maxQueueNameLength is the maximum length of a queue name.
+const maxQueueNameLength = 64
+
+// ValidateQueueName checks that info jobs may be submitted to the named
+// queue.  The GPU and maintenance queues are reserved, since jobs are placed
+// on them by resource class and kind rather than by name.
+func ValidateQueueName(name string) error {
+	if len(name) > maxQueueNameLength || !queueNamePattern.MatchString(name) {
+		return fmt.Errorf("%w: %q must be lowercase letters and digits separated by single '-' or '_', at most %d characters", ErrInvalidQueue, name, maxQueueNameLength)
+	}
+	if name == QueueGPU || name == QueueMaintenance {
+		return fmt.Errorf("%w: %q is reserved", ErrInvalidQueue, name)
+	}
+	return nil
+}
+
+// ParseQueueList parses a comma-separated list of queue:concurrency pairs,
+// such as "default:4,deep-analysis:1", into the number of jobs to run
+// concurrently from each queue.  An empty list returns nil.
+func ParseQueueList(list string) (map[string]int, error) {
+	var queues map[string]int
+	for entry := range strings.SplitSeq(list, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		name, count, ok := strings.Cut(entry, ":")
+		if !ok {
+			return nil, fmt.Errorf("%w: %q is not queue:concurrency", ErrInvalidQueueList, entry)
+		}
+		if err := ValidateQueueName(name); err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidQueueList, err)
+		}
+		concurrency, err := strconv.Atoi(count)
+		if err != nil || concurrency < 1 {
+			return nil, fmt.Errorf("%w: concurrency of %q must be a positive integer", ErrInvalidQueueList, name)
+		}
+		if _, ok := queues[name]; ok {
+			return nil, fmt.Errorf("%w: %q is listed twice", ErrInvalidQueueList, name)
+		}
+		if queues == nil {
+			queues = make(map[string]int)
+		}
+		queues[name] = concurrency
+	}
+	return queues, nil
+}
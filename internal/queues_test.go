@@ -0,0 +1,83 @@
+package internal_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/krelinga/go-libs/deep"
+	"github.com/krelinga/go-libs/exam"
+	"github.com/krelinga/go-libs/match"
+	"github.com/krelinga/video-info/internal"
+)
+
+func TestValidateQueueName(t *testing.T) {
+	e := exam.New(t)
+	env := deep.NewEnv()
+
+	tests := []struct {
+		loc     exam.Loc
+		name    string
+		queue   string
+		wantErr bool
+	}{
+		{loc: exam.Here(), name: "Default", queue: "default"},
+		{loc: exam.Here(), name: "Separators", queue: "deep-analysis_2"},
+		{loc: exam.Here(), name: "Empty", queue: "", wantErr: true},
+		{loc: exam.Here(), name: "Uppercase", queue: "Deep", wantErr: true},
+		{loc: exam.Here(), name: "Leading separator", queue: "-deep", wantErr: true},
+		{loc: exam.Here(), name: "Doubled separator", queue: "deep--analysis", wantErr: true},
+		{loc: exam.Here(), name: "Too long", queue: strings.Repeat("a", 65), wantErr: true},
+		{loc: exam.Here(), name: "GPU is reserved", queue: internal.QueueGPU, wantErr: true},
+		{loc: exam.Here(), name: "Maintenance is reserved", queue: internal.QueueMaintenance, wantErr: true},
+	}
+	for _, tt := range tests {
+		e.Run(tt.name, func(e exam.E) {
+			e.Log("Running test at", tt.loc)
+			err := internal.ValidateQueueName(tt.queue)
+			if tt.wantErr {
+				exam.Match(e, env, err, match.ErrorIs(internal.ErrInvalidQueue))
+			} else {
+				exam.Nil(e, env, err)
+			}
+		})
+	}
+}
+
+func TestParseQueueList(t *testing.T) {
+	e := exam.New(t)
+	env := deep.NewEnv()
+
+	tests := []struct {
+		loc     exam.Loc
+		name    string
+		list    string
+		want    map[string]int
+		wantErr bool
+	}{
+		{loc: exam.Here(), name: "Empty", list: ""},
+		{
+			loc:  exam.Here(),
+			name: "Several queues",
+			list: "default:4, deep-analysis:1",
+			want: map[string]int{"default": 4, "deep-analysis": 1},
+		},
+		{loc: exam.Here(), name: "Missing concurrency", list: "default", wantErr: true},
+		{loc: exam.Here(), name: "Zero concurrency", list: "default:0", wantErr: true},
+		{loc: exam.Here(), name: "Non-numeric concurrency", list: "default:many", wantErr: true},
+		{loc: exam.Here(), name: "Invalid name", list: "Deep:1", wantErr: true},
+		{loc: exam.Here(), name: "Reserved name", list: "gpu:1", wantErr: true},
+		{loc: exam.Here(), name: "Duplicate", list: "default:1,default:2", wantErr: true},
+	}
+	for _, tt := range tests {
+		e.Run(tt.name, func(e exam.E) {
+			e.Log("Running test at", tt.loc)
+			got, err := internal.ParseQueueList(tt.list)
+			if tt.wantErr {
+				exam.Match(e, env, err, match.ErrorIs(internal.ErrInvalidQueueList))
+				return
+			}
+			exam.Nil(e, env, err)
+			exam.Equal(e, env, tt.want, got)
+		})
+	}
+}
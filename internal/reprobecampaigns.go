@@ -0,0 +1,286 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/krelinga/video-info/virest"
+	"github.com/riverqueue/river"
+	"github.com/riverqueue/river/rivertype"
+)
+
+// reprobeCampaignScanSize bounds the latest results a campaign examines per
+// tick, whether or not they turn out to need reprobing.
+const reprobeCampaignScanSize = 1000
+
+// ReprobeCampaignTickArgs are the arguments of the periodic job that enqueues
+// the next reprobes of every running reprobe campaign.
+type ReprobeCampaignTickArgs struct{}
+
+// Kind returns the job kind identifier for River.
+func (ReprobeCampaignTickArgs) Kind() string {
+	return "reprobe_campaign_tick"
+}
+
+// InsertOpts places the job on the maintenance queue.
+func (ReprobeCampaignTickArgs) InsertOpts() river.InsertOpts {
+	return river.InsertOpts{Queue: QueueMaintenance}
+}
+
+// IsMediaInfoField reports whether name is the JSON name of a MediaInfo
+// property, such as "subtitleTracks".
+func IsMediaInfoField(name string) bool {
+	t := reflect.TypeFor[virest.MediaInfo]()
+	for i := range t.NumField() {
+		tag, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		if tag == name {
+			return true
+		}
+	}
+	return false
+}
+
+// hasMediaInfoField reports whether result has a non-empty value for any of
+// the named MediaInfo properties.
+func hasMediaInfoField(result *InfoJobResult, names []string) (bool, error) {
+	fields, err := mediaInfoFields(result)
+	if err != nil {
+		return false, err
+	}
+	for _, name := range names {
+		switch string(fields[name]) {
+		case "", "null", "[]", "{}":
+		default:
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// reprobeCampaign is a running reprobe campaign.
+type reprobeCampaign struct {
+	id             uuid.UUID
+	ffprobeVersion string
+	fields         []string
+	pathPrefix     *string
+	perMinute      int
+	afterPath      string
+}
+
+// AdvanceReprobeCampaigns enqueues the next reprobes of every running reprobe
+// campaign, up to each campaign's perMinute, and marks campaigns that have
+// dealt with every video path finished at now.  Campaigns locked by a
+// concurrent call are skipped.  It returns the info jobs enqueued, whose
+// created events the caller publishes once tx commits.
+func AdvanceReprobeCampaigns(ctx context.Context, tx pgx.Tx, client *river.Client[pgx.Tx], now time.Time) ([]*rivertype.JobInsertResult, error) {
+	rows, err := tx.Query(ctx, `
+		SELECT id, ffprobe_version, fields, path_prefix, per_minute, after_path
+		FROM reprobe_campaigns
+		WHERE finished_at IS NULL
+		ORDER BY created_at
+		FOR UPDATE SKIP LOCKED`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query reprobe campaigns: %w", err)
+	}
+	campaigns, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (reprobeCampaign, error) {
+		var c reprobeCampaign
+		err := row.Scan(&c.id, &c.ffprobeVersion, &c.fields, &c.pathPrefix, &c.perMinute, &c.afterPath)
+		return c, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query reprobe campaigns: %w", err)
+	}
+
+	var enqueued []*rivertype.JobInsertResult
+	for _, c := range campaigns {
+		inserted, err := advanceReprobeCampaign(ctx, tx, client, c, now)
+		if err != nil {
+			return nil, fmt.Errorf("reprobe campaign %s: %w", c.id, err)
+		}
+		enqueued = append(enqueued, inserted...)
+	}
+	return enqueued, nil
+}
+
+// staleResult is the latest result for a video path, produced by a version
+// of ffprobe other than a campaign's.
+type staleResult struct {
+	uuid     uuid.UUID
+	path     string
+	args     InfoJobArgs
+	status   InfoJobStatus
+	reprobed bool
+}
+
+// advanceReprobeCampaign enqueues the next reprobes of campaign c, returning
+// their info jobs.
+func advanceReprobeCampaign(ctx context.Context, tx pgx.Tx, client *river.Client[pgx.Tx], c reprobeCampaign, now time.Time) ([]*rivertype.JobInsertResult, error) {
+	// Only a path's latest result is reprobed, and only if it has one
+	rows, err := tx.Query(ctx, `
+		SELECT uuid, video_path, args, output FROM (
+			SELECT DISTINCT ON (video_path) uuid, video_path, args, output
+			FROM info_result
+			WHERE video_path > $1 AND ($2::text IS NULL OR starts_with(video_path, $2))
+			ORDER BY video_path, finalized_at DESC
+		) AS latest
+		WHERE output ? 'result'
+			AND output->'result'->'versions'->>'ffprobe' IS DISTINCT FROM $3
+		ORDER BY video_path
+		LIMIT $4`,
+		c.afterPath, c.pathPrefix, c.ffprobeVersion, reprobeCampaignScanSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query stale results: %w", err)
+	}
+	stale, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (staleResult, error) {
+		var r staleResult
+		err := row.Scan(&r.uuid, &r.path, &r.args, &r.status)
+		return r, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query stale results: %w", err)
+	}
+
+	paths := make([]string, len(stale))
+	for i, r := range stale {
+		paths[i] = r.path
+	}
+	rows, err = tx.Query(ctx, `
+		SELECT DISTINCT args->>'path' FROM river_job
+		WHERE kind = $1
+			AND state IN ('available', 'pending', 'retryable', 'running', 'scheduled')
+			AND args->>'path' = ANY($2)`,
+		InfoJobArgs{}.Kind(), paths)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query pending info jobs: %w", err)
+	}
+	pending, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return nil, fmt.Errorf("failed to query pending info jobs: %w", err)
+	}
+
+	// Stop at the campaign's limit, leaving the rest for the next tick
+	var reprobes []*staleResult
+	afterPath := c.afterPath
+	finished := len(stale) < reprobeCampaignScanSize
+	for i := range stale {
+		if len(reprobes) == c.perMinute {
+			finished = false
+			break
+		}
+		r := &stale[i]
+		afterPath = r.path
+		if slices.Contains(pending, r.path) {
+			continue
+		}
+		if len(c.fields) > 0 {
+			has, err := hasMediaInfoField(r.status.Result, c.fields)
+			if err != nil {
+				return nil, err
+			}
+			if !has {
+				continue
+			}
+		}
+		reprobes = append(reprobes, r)
+	}
+
+	inserted, err := enqueueReprobes(ctx, tx, client, reprobes)
+	if err != nil {
+		return nil, err
+	}
+	var finishedAt *time.Time
+	if finished {
+		finishedAt = &now
+	}
+	_, err = tx.Exec(ctx, `
+		UPDATE reprobe_campaigns
+		SET after_path = $2, enqueued = enqueued + $3, finished_at = $4
+		WHERE id = $1`,
+		c.id, afterPath, len(reprobes), finishedAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update reprobe campaign: %w", err)
+	}
+	return inserted, nil
+}
+
+// enqueueReprobes enqueues an info job superseding each of the given results
+// at the lowest priority.  External IDs move to the new jobs, unless they
+// have already moved on to other jobs.  It returns the inserted info jobs.
+func enqueueReprobes(ctx context.Context, tx pgx.Tx, client *river.Client[pgx.Tx], reprobes []*staleResult) ([]*rivertype.JobInsertResult, error) {
+	if len(reprobes) == 0 {
+		return nil, nil
+	}
+	allJobArgs := make([]InfoJobArgs, len(reprobes))
+	supersededUUIDs := make([]uuid.UUID, len(reprobes))
+	var externalIDs []string
+	for i, r := range reprobes {
+		jobArgs := r.args
+		supersedes := r.uuid
+		jobArgs.UUID = uuid.New()
+		jobArgs.Supersedes = &supersedes
+		jobArgs.Force = true
+		jobArgs.Priority = PriorityLowest
+		allJobArgs[i] = jobArgs
+		supersededUUIDs[i] = r.uuid
+		if jobArgs.ExternalID != nil {
+			externalIDs = append(externalIDs, *jobArgs.ExternalID)
+		}
+	}
+	if err := LockInfoJobIDs(ctx, tx, allJobArgs); err != nil {
+		return nil, err
+	}
+
+	_, err := tx.Exec(ctx, "UPDATE uuid_job_mapping SET external_id = NULL WHERE uuid = ANY($1)", supersededUUIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to release external IDs: %w", err)
+	}
+	rows, err := tx.Query(ctx, "SELECT external_id FROM uuid_job_mapping WHERE external_id = ANY($1)", externalIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check existing external IDs: %w", err)
+	}
+	taken, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return nil, fmt.Errorf("failed to check existing external IDs: %w", err)
+	}
+
+	params := make([]river.InsertManyParams, len(allJobArgs))
+	uuids := make([]uuid.UUID, len(allJobArgs))
+	jobExternalIDs := make([]*string, len(allJobArgs))
+	for i := range allJobArgs {
+		jobArgs := &allJobArgs[i]
+		if jobArgs.ExternalID != nil {
+			if slices.Contains(taken, *jobArgs.ExternalID) {
+				jobArgs.ExternalID = nil
+			} else {
+				// Two of the results may share an external ID
+				taken = append(taken, *jobArgs.ExternalID)
+			}
+		}
+		params[i] = river.InsertManyParams{Args: *jobArgs}
+		uuids[i] = jobArgs.UUID
+		jobExternalIDs[i] = jobArgs.ExternalID
+	}
+
+	insertedJobs, err := client.InsertManyTx(ctx, tx, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to insert info jobs: %w", err)
+	}
+	riverJobIDs := make([]int64, len(insertedJobs))
+	for i, inserted := range insertedJobs {
+		riverJobIDs[i] = inserted.Job.ID
+	}
+	_, err = tx.Exec(ctx, `
+		INSERT INTO uuid_job_mapping (uuid, river_job_id, external_id)
+		SELECT * FROM unnest($1::uuid[], $2::bigint[], $3::text[])`,
+		uuids, riverJobIDs, jobExternalIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to insert uuid mappings: %w", err)
+	}
+	return insertedJobs, nil
+}
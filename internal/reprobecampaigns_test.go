@@ -0,0 +1,19 @@
+package internal_test
+
+import (
+	"testing"
+
+	"github.com/krelinga/go-libs/deep"
+	"github.com/krelinga/go-libs/exam"
+	"github.com/krelinga/video-info/internal"
+)
+
+func TestIsMediaInfoField(t *testing.T) {
+	e := exam.New(t)
+	env := deep.NewEnv()
+
+	exam.Equal(e, env, true, internal.IsMediaInfoField("subtitleTracks"))
+	exam.Equal(e, env, true, internal.IsMediaInfoField("versions"))
+	exam.Equal(e, env, false, internal.IsMediaInfoField("subtitle_tracks"))
+	exam.Equal(e, env, false, internal.IsMediaInfoField(""))
+}
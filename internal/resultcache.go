@@ -0,0 +1,104 @@
+package internal
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// resultCacheVersion is part of every cache key, so bumping it when
+// InfoJobResult changes invalidates entries written by older workers.
+const resultCacheVersion = 17
+
+// ResultCache stores successful info job results on the worker's local disk,
+// keyed by the identity and modification state of the probed file, so that
+// retried or resubmitted jobs for unchanged files skip ffprobe.
+//
+// A nil ResultCache never hits and stores nothing.
+type ResultCache struct {
+	dir string
+}
+
+// NewResultCache returns a ResultCache storing entries under dir, creating it
+// if needed, or nil if dir is empty.
+func NewResultCache(dir string) (*ResultCache, error) {
+	if dir == "" {
+		return nil, nil
+	}
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create result cache directory: %w", err)
+	}
+	return &ResultCache{dir: dir}, nil
+}
+
+// Get returns the cached result for the file described by info, or nil if
+// there is none.
+func (c *ResultCache) Get(info os.FileInfo) (*InfoJobResult, error) {
+	path, ok := c.entryPath(info)
+	if !ok {
+		return nil, nil
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to read result cache entry: %w", err)
+	}
+	var result InfoJobResult
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse result cache entry: %w", err)
+	}
+	return &result, nil
+}
+
+// Put stores result for the file described by info.
+func (c *ResultCache) Put(info os.FileInfo, result *InfoJobResult) error {
+	path, ok := c.entryPath(info)
+	if !ok {
+		return nil
+	}
+	data, err := json.Marshal(result)
+	if err != nil {
+		return fmt.Errorf("failed to marshal result cache entry: %w", err)
+	}
+
+	// Write to a temporary file and rename it into place, so concurrent jobs
+	// never read a partial entry
+	tmp, err := os.CreateTemp(c.dir, ".tmp-*")
+	if err != nil {
+		return fmt.Errorf("failed to create result cache entry: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write result cache entry: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write result cache entry: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to store result cache entry: %w", err)
+	}
+	return nil
+}
+
+// entryPath returns the path of the entry for the file described by info.
+// It reports false if the cache is disabled or the file's identity can't be
+// determined.
+func (c *ResultCache) entryPath(info os.FileInfo) (string, bool) {
+	if c == nil {
+		return "", false
+	}
+	device, inode, ok := fileIdentity(info)
+	if !ok {
+		return "", false
+	}
+	key := fmt.Sprintf("v%d:%d:%d:%d:%d", resultCacheVersion, device, inode, info.Size(), info.ModTime().UnixNano())
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(c.dir, hex.EncodeToString(sum[:])+".json"), true
+}
@@ -0,0 +1,62 @@
+package internal_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/krelinga/go-libs/deep"
+	"github.com/krelinga/go-libs/exam"
+	"github.com/krelinga/video-info/internal"
+)
+
+func TestResultCache(t *testing.T) {
+	e := exam.New(t)
+	env := deep.NewEnv()
+
+	videoPath := filepath.Join(t.TempDir(), "movie.mkv")
+	exam.Nil(e, env, os.WriteFile(videoPath, []byte("video"), 0o600))
+	stat := func() os.FileInfo {
+		info, err := os.Stat(videoPath)
+		exam.Nil(e, env, err)
+		return info
+	}
+	result := &internal.InfoJobResult{
+		DurationSeconds:         120,
+		ChapterDurationsSeconds: []float64{60, 60},
+	}
+
+	e.Run("Disabled", func(e exam.E) {
+		cache, err := internal.NewResultCache("")
+		exam.Nil(e, env, err)
+		exam.Nil(e, env, cache.Put(stat(), result))
+		got, err := cache.Get(stat())
+		exam.Nil(e, env, err)
+		exam.Nil(e, env, got)
+	})
+
+	cache, err := internal.NewResultCache(filepath.Join(t.TempDir(), "cache"))
+	exam.Nil(e, env, err)
+
+	e.Run("Miss", func(e exam.E) {
+		got, err := cache.Get(stat())
+		exam.Nil(e, env, err)
+		exam.Nil(e, env, got)
+	})
+
+	e.Run("Hit", func(e exam.E) {
+		exam.Nil(e, env, cache.Put(stat(), result))
+		got, err := cache.Get(stat())
+		exam.Nil(e, env, err)
+		exam.Equal(e, env, result, got)
+	})
+
+	e.Run("Modified file", func(e exam.E) {
+		exam.Nil(e, env, cache.Put(stat(), result))
+		exam.Nil(e, env, os.Chtimes(videoPath, time.Time{}, time.Now().Add(time.Hour)))
+		got, err := cache.Get(stat())
+		exam.Nil(e, env, err)
+		exam.Nil(e, env, got)
+	})
+}
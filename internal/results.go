@@ -0,0 +1,68 @@
+package internal
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// StoreInfoResult records the outcome of the info job with ID jobID in the
+// info_result table, which unlike River's job table isn't subject to
+// retention.  It must be called in the transaction that completes the job.
+// A result stored earlier under the same UUID, by a job River has since
+// removed, is replaced.
+func StoreInfoResult(ctx context.Context, tx pgx.Tx, jobID int64, jobArgs InfoJobArgs, status *InfoJobStatus, priority int, createdAt time.Time) error {
+	args, err := json.Marshal(jobArgs)
+	if err != nil {
+		return fmt.Errorf("failed to marshal job args: %w", err)
+	}
+	output, err := json.Marshal(status)
+	if err != nil {
+		return fmt.Errorf("failed to marshal job output: %w", err)
+	}
+	_, err = tx.Exec(ctx, `
+		INSERT INTO info_result (uuid, river_job_id, external_id, video_path, args, output, priority, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (uuid) DO UPDATE SET
+			river_job_id = excluded.river_job_id,
+			external_id = excluded.external_id,
+			video_path = excluded.video_path,
+			args = excluded.args,
+			output = excluded.output,
+			priority = excluded.priority,
+			created_at = excluded.created_at,
+			finalized_at = now()`,
+		jobArgs.UUID, jobID, jobArgs.ExternalID, jobArgs.Path, args, output, priority, createdAt)
+	if err != nil {
+		return fmt.Errorf("failed to store result: %w", err)
+	}
+	return nil
+}
+
+// infoJobIDLockClass namespaces the advisory locks taken by LockInfoJobIDs.
+const infoJobIDLockClass = 0x76690001
+
+// LockInfoJobIDs takes transaction-level advisory locks on the UUIDs and
+// external IDs of the given jobs, so that concurrent submissions of the same
+// IDs, possibly to different server replicas, check for and insert them one
+// at a time.  Locks are taken in a fixed order to avoid deadlocks.
+func LockInfoJobIDs(ctx context.Context, tx pgx.Tx, allJobArgs []InfoJobArgs) error {
+	var ids []string
+	for _, jobArgs := range allJobArgs {
+		ids = append(ids, "uuid:"+jobArgs.UUID.String())
+		if jobArgs.ExternalID != nil {
+			ids = append(ids, "external_id:"+*jobArgs.ExternalID)
+		}
+	}
+	_, err := tx.Exec(ctx, `
+		SELECT count(pg_advisory_xact_lock($1, key))
+		FROM (SELECT DISTINCT hashtext(id) AS key FROM unnest($2::text[]) AS id ORDER BY key) AS keys`,
+		infoJobIDLockClass, ids)
+	if err != nil {
+		return fmt.Errorf("failed to lock job IDs: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,130 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/riverqueue/river"
+)
+
+// retentionBatchSize bounds the rows deleted per statement, so pruning a
+// large backlog doesn't hold locks for long.
+const retentionBatchSize = 1000
+
+// ResultRetentionArgs are the arguments of the periodic job that prunes old
+// results.
+type ResultRetentionArgs struct{}
+
+// Kind returns the job kind identifier for River.
+func (ResultRetentionArgs) Kind() string {
+	return "result_retention"
+}
+
+// InsertOpts places the job on the maintenance queue.
+func (ResultRetentionArgs) InsertOpts() river.InsertOpts {
+	return river.InsertOpts{Queue: QueueMaintenance}
+}
+
+// PrunedResults counts the rows removed by PruneResults.
+type PrunedResults struct {
+	// InfoJobs counts finished info jobs, along with their UUID mappings.
+	InfoJobs int64
+
+	// StoredResults counts rows of info_result.
+	StoredResults int64
+
+	// DeadLetters counts records of undelivered webhooks.
+	DeadLetters int64
+
+	// CachedResults counts rows of probe_cache.
+	CachedResults int64
+
+	// Comparisons counts rows of comparisons.
+	Comparisons int64
+
+	// WebhookBatchItems counts the batch items of finished webhook jobs.
+	WebhookBatchItems int64
+}
+
+// PruneResults deletes finished info jobs, stored results, undelivered
+// webhook records, cached results, comparisons and the batch items of
+// finished webhook jobs that were older than ttl at now.  Running jobs and
+// jobs waiting to run are kept however old they are.
+func PruneResults(ctx context.Context, pool *pgxpool.Pool, now time.Time, ttl time.Duration) (PrunedResults, error) {
+	var pruned PrunedResults
+	if ttl <= 0 {
+		return pruned, nil
+	}
+	cutoff := now.Add(-ttl)
+
+	var err error
+	// uuid_job_mapping rows are removed by ON DELETE CASCADE
+	pruned.InfoJobs, err = deleteInBatches(ctx, pool, `
+		DELETE FROM river_job WHERE id IN (
+			SELECT id FROM river_job
+			WHERE kind = $1 AND state IN ('completed', 'discarded', 'cancelled') AND finalized_at < $2
+			LIMIT $3
+		)`, InfoJobArgs{}.Kind(), cutoff)
+	if err != nil {
+		return pruned, fmt.Errorf("failed to prune info jobs: %w", err)
+	}
+	pruned.StoredResults, err = deleteInBatches(ctx, pool, `
+		DELETE FROM info_result WHERE uuid IN (
+			SELECT uuid FROM info_result WHERE finalized_at < $1 LIMIT $2
+		)`, cutoff)
+	if err != nil {
+		return pruned, fmt.Errorf("failed to prune stored results: %w", err)
+	}
+	pruned.DeadLetters, err = deleteInBatches(ctx, pool, `
+		DELETE FROM webhook_dead_letter WHERE id IN (
+			SELECT id FROM webhook_dead_letter WHERE failed_at < $1 LIMIT $2
+		)`, cutoff)
+	if err != nil {
+		return pruned, fmt.Errorf("failed to prune undelivered webhook records: %w", err)
+	}
+	pruned.CachedResults, err = deleteInBatches(ctx, pool, `
+		DELETE FROM probe_cache WHERE (video_path, options_key) IN (
+			SELECT video_path, options_key FROM probe_cache WHERE cached_at < $1 LIMIT $2
+		)`, cutoff)
+	if err != nil {
+		return pruned, fmt.Errorf("failed to prune cached results: %w", err)
+	}
+	// A comparison is of info jobs that are pruned by the same TTL
+	pruned.Comparisons, err = deleteInBatches(ctx, pool, `
+		DELETE FROM comparisons WHERE id IN (
+			SELECT id FROM comparisons WHERE created_at < $1 LIMIT $2
+		)`, cutoff)
+	if err != nil {
+		return pruned, fmt.Errorf("failed to prune comparisons: %w", err)
+	}
+	pruned.WebhookBatchItems, err = deleteInBatches(ctx, pool, `
+		DELETE FROM webhook_batch_item WHERE river_job_id IN (
+			SELECT i.river_job_id FROM webhook_batch_item i
+			JOIN river_job j ON j.id = i.river_job_id
+			WHERE j.state IN ('completed', 'discarded', 'cancelled') AND j.finalized_at < $1
+			LIMIT $2
+		)`, cutoff)
+	if err != nil {
+		return pruned, fmt.Errorf("failed to prune webhook batch items: %w", err)
+	}
+	return pruned, nil
+}
+
+// deleteInBatches runs a DELETE statement taking args followed by a batch
+// size until it deletes nothing, and returns the number of rows deleted.
+func deleteInBatches(ctx context.Context, pool *pgxpool.Pool, query string, args ...any) (int64, error) {
+	args = append(args, retentionBatchSize)
+	var total int64
+	for {
+		tag, err := pool.Exec(ctx, query, args...)
+		if err != nil {
+			return total, err
+		}
+		total += tag.RowsAffected()
+		if tag.RowsAffected() < retentionBatchSize {
+			return total, nil
+		}
+	}
+}
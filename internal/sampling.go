@@ -0,0 +1,57 @@
+package internal
+
+import "github.com/krelinga/video-info/virest"
+
+// MaxSampleWindows bounds the number of windows an info job may sample.
+const MaxSampleWindows = 60
+
+// SampleWindowSeconds is the length of each window sampled by loudness
+// analysis and decode verification.
+const SampleWindowSeconds = 30.0
+
+// SamplingCoverage describes how much of a file loudness analysis and decode
+// verification covered when they sampled windows of it rather than decoding
+// all of it.
+type SamplingCoverage struct {
+	Windows        int     `json:"windows"`
+	SampledSeconds float64 `json:"sampled_seconds"`
+
+	// Fraction is the share of the file's duration the windows cover.
+	Fraction float64 `json:"fraction"`
+}
+
+// NewSamplingCoverage describes windows windows of a file lasting duration
+// seconds, sampledSeconds long in all.
+func NewSamplingCoverage(windows int, sampledSeconds, duration float64) *SamplingCoverage {
+	coverage := &SamplingCoverage{Windows: windows, SampledSeconds: sampledSeconds}
+	if duration > 0 {
+		coverage.Fraction = min(sampledSeconds/duration, 1)
+	}
+	return coverage
+}
+
+// RESTSamplingCoverage converts the coverage to its REST form, or nil if c is
+// nil.
+func (c *SamplingCoverage) RESTSamplingCoverage() *virest.SamplingCoverage {
+	if c == nil {
+		return nil
+	}
+	return &virest.SamplingCoverage{
+		Windows:        c.Windows,
+		SampledSeconds: c.SampledSeconds,
+		Fraction:       c.Fraction,
+	}
+}
+
+// NewSamplingCoverageFromREST converts a REST SamplingCoverage, or returns
+// nil if v is nil.
+func NewSamplingCoverageFromREST(v *virest.SamplingCoverage) *SamplingCoverage {
+	if v == nil {
+		return nil
+	}
+	return &SamplingCoverage{
+		Windows:        v.Windows,
+		SampledSeconds: v.SampledSeconds,
+		Fraction:       v.Fraction,
+	}
+}
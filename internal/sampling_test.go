@@ -0,0 +1,53 @@
+package internal_test
+
+import (
+	"testing"
+
+	"github.com/krelinga/go-libs/deep"
+	"github.com/krelinga/go-libs/exam"
+	"github.com/krelinga/video-info/internal"
+)
+
+func TestNewSamplingCoverage(t *testing.T) {
+	e := exam.New(t)
+	env := deep.NewEnv()
+
+	tests := []struct {
+		loc      exam.Loc
+		name     string
+		windows  int
+		sampled  float64
+		duration float64
+		want     *internal.SamplingCoverage
+	}{
+		{
+			loc:      exam.Here(),
+			name:     "Part of the file",
+			windows:  12,
+			sampled:  360,
+			duration: 7200,
+			want:     &internal.SamplingCoverage{Windows: 12, SampledSeconds: 360, Fraction: 0.05},
+		},
+		{
+			loc:      exam.Here(),
+			name:     "Windows past the end",
+			windows:  2,
+			sampled:  60,
+			duration: 59.5,
+			want:     &internal.SamplingCoverage{Windows: 2, SampledSeconds: 60, Fraction: 1},
+		},
+		{
+			loc:     exam.Here(),
+			name:    "Unknown duration",
+			windows: 4,
+			sampled: 120,
+			want:    &internal.SamplingCoverage{Windows: 4, SampledSeconds: 120},
+		},
+	}
+	for _, tt := range tests {
+		e.Run(tt.name, func(e exam.E) {
+			e.Log("Running test at", tt.loc)
+			exam.Equal(e, env, tt.want, internal.NewSamplingCoverage(tt.windows, tt.sampled, tt.duration))
+		})
+	}
+}
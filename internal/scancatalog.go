@@ -0,0 +1,105 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// ScanCatalogEntry is the state of a file when an incremental scan last
+// enqueued it or found its result cached.
+type ScanCatalogEntry struct {
+	Size      int64
+	ModTimeNs int64
+}
+
+// FindUnchangedFiles returns the paths of the given files that are in the
+// state the incremental scans of the schedule with the given ID cataloged
+// them in.  Files whose info job was discarded or cancelled are left out,
+// so that the next scan enqueues them again.
+func FindUnchangedFiles(ctx context.Context, tx pgx.Tx, scheduleID uuid.UUID, files []ScannedFile) (map[string]bool, error) {
+	paths := make([]string, len(files))
+	for i, file := range files {
+		paths[i] = file.Path
+	}
+	rows, err := tx.Query(ctx, `
+		SELECT c.video_path, c.size, c.mod_time_ns FROM scan_catalog c
+		WHERE c.schedule_id = $1 AND c.video_path = ANY($2)
+			AND NOT EXISTS (
+				SELECT 1 FROM uuid_job_mapping m
+				JOIN river_job j ON j.id = m.river_job_id
+				WHERE m.uuid = c.info_uuid AND j.state IN ('discarded', 'cancelled')
+			)`,
+		scheduleID, paths)
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up scan catalog: %w", err)
+	}
+	type catalogedFile struct {
+		path  string
+		entry ScanCatalogEntry
+	}
+	cataloged, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (catalogedFile, error) {
+		var c catalogedFile
+		err := row.Scan(&c.path, &c.entry.Size, &c.entry.ModTimeNs)
+		return c, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up scan catalog: %w", err)
+	}
+	catalog := make(map[string]ScanCatalogEntry, len(cataloged))
+	for _, c := range cataloged {
+		catalog[c.path] = c.entry
+	}
+	return unchangedFiles(files, catalog), nil
+}
+
+// unchangedFiles returns the paths of the given files whose size and
+// modification time are those in their catalog entries.
+func unchangedFiles(files []ScannedFile, catalog map[string]ScanCatalogEntry) map[string]bool {
+	unchanged := make(map[string]bool)
+	for _, file := range files {
+		entry, ok := catalog[file.Path]
+		if ok && entry.Size == file.Size && entry.ModTimeNs == file.ModTime.UnixNano() {
+			unchanged[file.Path] = true
+		}
+	}
+	return unchanged
+}
+
+// CatalogScannedFiles records the current state of the given files in the
+// catalog of the schedule with the given ID at now, along with the UUIDs of
+// the info jobs enqueued for them, if any.
+func CatalogScannedFiles(ctx context.Context, tx pgx.Tx, scheduleID uuid.UUID, files []ScannedFile, infoUUIDs map[string]uuid.UUID, now time.Time) error {
+	if len(files) == 0 {
+		return nil
+	}
+	paths := make([]string, len(files))
+	sizes := make([]int64, len(files))
+	modTimes := make([]int64, len(files))
+	uuids := make([]string, len(files))
+	for i, file := range files {
+		paths[i] = file.Path
+		sizes[i] = file.Size
+		modTimes[i] = file.ModTime.UnixNano()
+		if u, ok := infoUUIDs[file.Path]; ok {
+			uuids[i] = u.String()
+		}
+	}
+	_, err := tx.Exec(ctx, `
+		INSERT INTO scan_catalog (schedule_id, video_path, size, mod_time_ns, info_uuid, cataloged_at)
+		SELECT $1, file.video_path, file.size, file.mod_time_ns, NULLIF(file.info_uuid, '')::uuid, $6
+		FROM unnest($2::text[], $3::bigint[], $4::bigint[], $5::text[]) AS file (video_path, size, mod_time_ns, info_uuid)
+		ON CONFLICT (schedule_id, video_path) DO UPDATE SET
+			size = excluded.size,
+			mod_time_ns = excluded.mod_time_ns,
+			info_uuid = excluded.info_uuid,
+			cataloged_at = excluded.cataloged_at`,
+		scheduleID, paths, sizes, modTimes, uuids, now)
+	if err != nil {
+		return fmt.Errorf("failed to catalog scanned files: %w", err)
+	}
+	return nil
+}
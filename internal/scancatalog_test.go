@@ -0,0 +1,28 @@
+package internal_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/krelinga/go-libs/deep"
+	"github.com/krelinga/go-libs/exam"
+	"github.com/krelinga/video-info/internal"
+)
+
+func TestUnchangedFiles(t *testing.T) {
+	e := exam.New(t)
+	env := deep.NewEnv()
+	modified := time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC)
+	catalog := map[string]internal.ScanCatalogEntry{
+		"/nas/media/same.mkv":    {Size: 100, ModTimeNs: modified.UnixNano()},
+		"/nas/media/resized.mkv": {Size: 100, ModTimeNs: modified.UnixNano()},
+		"/nas/media/touched.mkv": {Size: 100, ModTimeNs: modified.UnixNano()},
+	}
+	files := []internal.ScannedFile{
+		{Path: "/nas/media/new.mkv", Size: 100, ModTime: modified},
+		{Path: "/nas/media/resized.mkv", Size: 200, ModTime: modified},
+		{Path: "/nas/media/same.mkv", Size: 100, ModTime: modified},
+		{Path: "/nas/media/touched.mkv", Size: 100, ModTime: modified.Add(time.Second)},
+	}
+	exam.Equal(e, env, map[string]bool{"/nas/media/same.mkv": true}, internal.UnchangedFiles(files, catalog))
+}
@@ -0,0 +1,127 @@
+package internal
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// ErrInvalidExclude is returned for exclusion patterns that aren't valid
+// globs.
+var ErrInvalidExclude = errors.New("invalid exclusion pattern")
+
+// ScanIgnoreFile is the name of the file listing the exclusion patterns of
+// the directory it is in and those below it.  Blank lines and lines starting
+// with # are ignored.
+const ScanIgnoreFile = ".viignore"
+
+// ValidateScanExclude checks that each of patterns is a valid exclusion
+// pattern.  Patterns are globs as understood by path.Match.  A pattern
+// without a slash matches the names of files and directories at any depth,
+// and one with a slash matches their paths relative to the directory the
+// pattern applies to.  A pattern ending in a slash matches only
+// directories, whose contents are skipped along with them.
+func ValidateScanExclude(patterns []string) error {
+	for _, pattern := range patterns {
+		if _, err := newScanExclusion("", pattern); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// scanExclusion is an exclusion pattern that applies to the directory dir
+// and those below it.
+type scanExclusion struct {
+	dir     string
+	pattern string
+	dirOnly bool
+	// anchored patterns match paths relative to dir rather than names
+	anchored bool
+}
+
+// newScanExclusion parses pattern, which applies to dir.
+func newScanExclusion(dir, pattern string) (scanExclusion, error) {
+	e := scanExclusion{dir: dir, pattern: pattern}
+	if strings.HasSuffix(e.pattern, "/") {
+		e.dirOnly = true
+		e.pattern = strings.TrimSuffix(e.pattern, "/")
+	}
+	if strings.Contains(e.pattern, "/") {
+		e.anchored = true
+		e.pattern = strings.TrimPrefix(e.pattern, "/")
+	}
+	if e.pattern == "" {
+		return scanExclusion{}, fmt.Errorf("%w %q: pattern is empty", ErrInvalidExclude, pattern)
+	}
+	if _, err := path.Match(e.pattern, ""); err != nil {
+		return scanExclusion{}, fmt.Errorf("%w %q: %v", ErrInvalidExclude, pattern, err)
+	}
+	return e, nil
+}
+
+// matches reports whether the exclusion matches the file or directory at
+// filePath.
+func (e scanExclusion) matches(filePath string, isDir bool) bool {
+	if e.dirOnly && !isDir {
+		return false
+	}
+	name := filepath.Base(filePath)
+	if e.anchored {
+		rel, err := filepath.Rel(e.dir, filePath)
+		if err != nil {
+			return false
+		}
+		name = filepath.ToSlash(rel)
+	}
+	matched, _ := path.Match(e.pattern, name)
+	return matched
+}
+
+// scanExclusions returns the exclusions that apply to the directory dir:
+// those that apply to its parent, inherited, followed by those listed in
+// its ScanIgnoreFile, if it has one.
+func scanExclusions(dir string, inherited []scanExclusion) ([]scanExclusion, error) {
+	contents, err := os.ReadFile(filepath.Join(dir, ScanIgnoreFile))
+	if errors.Is(err, fs.ErrNotExist) {
+		return inherited, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to read exclusion patterns: %w", err)
+	}
+
+	exclusions := inherited
+	scanner := bufio.NewScanner(bytes.NewReader(contents))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		e, err := newScanExclusion(dir, line)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", filepath.Join(dir, ScanIgnoreFile), err)
+		}
+		// Don't append to the parent's slice, which its other children share
+		exclusions = append(exclusions[:len(exclusions):len(exclusions)], e)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read exclusion patterns: %w", err)
+	}
+	return exclusions, nil
+}
+
+// excluded reports whether any of exclusions matches the file or directory
+// at filePath.
+func excluded(exclusions []scanExclusion, filePath string, isDir bool) bool {
+	for _, e := range exclusions {
+		if e.matches(filePath, isDir) {
+			return true
+		}
+	}
+	return false
+}
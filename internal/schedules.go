@@ -0,0 +1,643 @@
+package internal
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/krelinga/video-info/virest"
+	"github.com/riverqueue/river"
+	"github.com/riverqueue/river/rivertype"
+	"github.com/robfig/cron/v3"
+)
+
+// ErrInvalidCron is returned for cron expressions that can't be parsed.
+var ErrInvalidCron = errors.New("invalid cron expression")
+
+// ParseCron parses a standard five field cron expression, such as
+// "0 3 * * *", or a descriptor such as "@daily".  Times are in UTC unless the
+// expression starts with CRON_TZ=<zone>.
+func ParseCron(expr string) (cron.Schedule, error) {
+	schedule, err := cron.ParseStandard(expr)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidCron, err)
+	}
+	return schedule, nil
+}
+
+// NextRun returns the first time after now that schedule fires.
+func NextRun(schedule cron.Schedule, now time.Time) time.Time {
+	// Schedules without a time zone fire in the zone of the time given
+	return schedule.Next(now.UTC())
+}
+
+// ScheduleTickArgs are the arguments of the periodic job that starts the
+// scans of schedules that are due.
+type ScheduleTickArgs struct{}
+
+// Kind returns the job kind identifier for River.
+func (ScheduleTickArgs) Kind() string {
+	return "schedule_tick"
+}
+
+// InsertOpts places the job on the maintenance queue.
+func (ScheduleTickArgs) InsertOpts() river.InsertOpts {
+	return river.InsertOpts{Queue: QueueMaintenance}
+}
+
+// ScanArgs are the arguments of a job that enqueues an info job for every
+// video file under a directory.
+type ScanArgs struct {
+	ScheduleID uuid.UUID `json:"schedule_id"`
+	Directory  string    `json:"directory"`
+
+	// Template holds the arguments shared by the info jobs.  Its UUID and
+	// path are unset.
+	Template InfoJobArgs `json:"template"`
+
+	// Exclude holds exclusion patterns, which apply to Directory, for the
+	// scan to skip.
+	Exclude []string `json:"exclude,omitempty"`
+
+	// MinAgeSeconds, if positive, is how long ago files must have last
+	// been modified for the scan to enqueue them.  Newer files are likely
+	// still being written.
+	MinAgeSeconds int `json:"min_age_seconds,omitempty"`
+
+	// Incremental skips files that are as the schedule's catalog last
+	// recorded them, and records the files the scan enqueues or finds
+	// cached in the catalog.
+	Incremental bool `json:"incremental,omitempty"`
+
+	// FollowUp marks a scan scheduled by another to pick up the files it
+	// found too new.  Follow-up scans schedule no follow-ups of their own.
+	FollowUp bool `json:"follow_up,omitempty"`
+
+	// DryRun requests a ScanPreview of what the scan would enqueue,
+	// recorded as the job's output, instead of enqueueing anything.  Dry
+	// runs belong to no schedule.
+	DryRun bool `json:"dry_run,omitempty"`
+}
+
+// Kind returns the job kind identifier for River.
+func (ScanArgs) Kind() string {
+	return "scan"
+}
+
+// InsertOpts places the job on the maintenance queue.
+func (ScanArgs) InsertOpts() river.InsertOpts {
+	return river.InsertOpts{Queue: QueueMaintenance}
+}
+
+// StartDueSchedules enqueues a scan job for each schedule due to run at now,
+// and moves the schedule on to its next run.  Runs missed while no worker
+// was running are made up by a single scan.  It returns the number of scans
+// enqueued.  Schedules locked by a concurrent call are skipped.
+func StartDueSchedules(ctx context.Context, tx pgx.Tx, client *river.Client[pgx.Tx], now time.Time) (int, error) {
+	type dueSchedule struct {
+		id          uuid.UUID
+		cron        string
+		directory   string
+		template    InfoJobArgs
+		exclude     []string
+		minAge      int
+		incremental bool
+	}
+	rows, err := tx.Query(ctx, `
+		SELECT id, cron, directory, args, exclude, min_age_seconds, incremental FROM schedules
+		WHERE next_run_at <= $1
+		ORDER BY next_run_at
+		FOR UPDATE SKIP LOCKED`, now)
+	if err != nil {
+		return 0, fmt.Errorf("failed to query due schedules: %w", err)
+	}
+	due, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (dueSchedule, error) {
+		var d dueSchedule
+		err := row.Scan(&d.id, &d.cron, &d.directory, &d.template, &d.exclude, &d.minAge, &d.incremental)
+		return d, err
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to query due schedules: %w", err)
+	}
+	if len(due) == 0 {
+		return 0, nil
+	}
+
+	params := make([]river.InsertManyParams, len(due))
+	for i, d := range due {
+		schedule, err := ParseCron(d.cron)
+		if err != nil {
+			return 0, fmt.Errorf("schedule %s: %w", d.id, err)
+		}
+		_, err = tx.Exec(ctx, "UPDATE schedules SET last_run_at = $2, next_run_at = $3 WHERE id = $1",
+			d.id, now, NextRun(schedule, now))
+		if err != nil {
+			return 0, fmt.Errorf("failed to update schedule %s: %w", d.id, err)
+		}
+		params[i] = river.InsertManyParams{Args: ScanArgs{
+			ScheduleID:    d.id,
+			Directory:     d.directory,
+			Template:      d.template,
+			Exclude:       d.exclude,
+			MinAgeSeconds: d.minAge,
+			Incremental:   d.incremental,
+		}}
+	}
+	if _, err := client.InsertManyTx(ctx, tx, params); err != nil {
+		return 0, fmt.Errorf("failed to insert scan jobs: %w", err)
+	}
+	return len(due), nil
+}
+
+// scanExtensions are the file extensions ScanDirectory considers video files.
+var scanExtensions = map[string]bool{
+	".avi":  true,
+	".flv":  true,
+	".m2ts": true,
+	".m4v":  true,
+	".mk3d": true,
+	".mkv":  true,
+	".mov":  true,
+	".mp4":  true,
+	".mpeg": true,
+	".mpg":  true,
+	".mts":  true,
+	".ogv":  true,
+	".ts":   true,
+	".vob":  true,
+	".webm": true,
+	".wmv":  true,
+}
+
+// ScannedFile is a video file found by ScanDirectory.
+type ScannedFile struct {
+	Path    string
+	Size    int64
+	ModTime time.Time
+}
+
+// ScanDirectory returns the video files under dir whose paths sort after
+// after, in lexical order of their paths.  Subdirectories whose files all
+// sort before after aren't walked, so a scan resumes without reading them
+// again.  Files and directories matching the exclusion patterns in exclude,
+// which apply to dir, or in the ScanIgnoreFile of a directory are skipped,
+// as described by ValidateScanExclude.  So are hidden files and
+// directories, such as those NAS devices keep thumbnails in, symbolic
+// links, and subdirectories that can't be read.
+func ScanDirectory(dir, after string, exclude []string) ([]ScannedFile, error) {
+	dir = filepath.Clean(dir)
+	var rootExclusions []scanExclusion
+	for _, pattern := range exclude {
+		e, err := newScanExclusion(dir, pattern)
+		if err != nil {
+			return nil, err
+		}
+		rootExclusions = append(rootExclusions, e)
+	}
+
+	// The exclusions of each directory walked, by path
+	exclusions := make(map[string][]scanExclusion)
+	var files []ScannedFile
+	err := filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			if path == dir || entry == nil || !entry.IsDir() {
+				return err
+			}
+			return fs.SkipDir
+		}
+		if path == dir {
+			exclusions[path], err = scanExclusions(path, rootExclusions)
+			return err
+		}
+		if strings.HasPrefix(entry.Name(), ".") || excluded(exclusions[filepath.Dir(path)], path, entry.IsDir()) {
+			if entry.IsDir() {
+				return fs.SkipDir
+			}
+			return nil
+		}
+		if entry.IsDir() {
+			prefix := path + string(filepath.Separator)
+			if prefix < after && !strings.HasPrefix(after, prefix) {
+				return fs.SkipDir
+			}
+			exclusions[path], err = scanExclusions(path, exclusions[filepath.Dir(path)])
+			return err
+		}
+		if path <= after || !entry.Type().IsRegular() || !scanExtensions[strings.ToLower(filepath.Ext(entry.Name()))] {
+			return nil
+		}
+		info, err := entry.Info()
+		if errors.Is(err, fs.ErrNotExist) {
+			// Removed since the directory was read
+			return nil
+		} else if err != nil {
+			return err
+		}
+		files = append(files, ScannedFile{Path: path, Size: info.Size(), ModTime: info.ModTime()})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan %s: %w", dir, err)
+	}
+
+	// Walks visit "a/b.mkv" before "a.mkv", which sorts first
+	slices.SortFunc(files, func(a, b ScannedFile) int {
+		return strings.Compare(a.Path, b.Path)
+	})
+	return files, nil
+}
+
+// Reasons a scan skips a video file it found.
+const (
+	ScanSkipPendingJob = "pending_job"
+	ScanSkipCached     = "cached"
+	ScanSkipTooNew     = "too_new"
+	ScanSkipUnchanged  = "unchanged"
+)
+
+// MinAge returns how long ago files must have last been modified for the
+// scan to enqueue them.
+func (a ScanArgs) MinAge() time.Duration {
+	return time.Duration(a.MinAgeSeconds) * time.Second
+}
+
+// ScanSkipReasons returns why the scan with the given arguments, running at
+// now, would skip those of the given files it skips, keyed by path.  Files
+// that already have an info job waiting or running are skipped, as are
+// files modified more recently than the scan's minimum age.  Unless the
+// scan forces probing, so are files whose result for their current state is
+// cached by workers with the given settings.  Incremental scans also skip
+// files unchanged since the schedule's catalog recorded them.
+func ScanSkipReasons(ctx context.Context, tx pgx.Tx, args ScanArgs, files []ScannedFile, now time.Time, settings ProbeCacheSettings) (map[string]string, error) {
+	template := args.Template
+	paths := make([]string, len(files))
+	for i, file := range files {
+		paths[i] = file.Path
+	}
+	rows, err := tx.Query(ctx, `
+		SELECT DISTINCT args->>'path' FROM river_job
+		WHERE kind = $1
+			AND state IN ('available', 'pending', 'retryable', 'running', 'scheduled')
+			AND args->>'path' = ANY($2)`,
+		InfoJobArgs{}.Kind(), paths)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query pending info jobs: %w", err)
+	}
+	pending, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return nil, fmt.Errorf("failed to query pending info jobs: %w", err)
+	}
+	skip := make(map[string]string, len(pending))
+	if !template.Force {
+		cached, err := FindCachedFiles(ctx, tx, files, template.Analyses(), settings)
+		if err != nil {
+			return nil, err
+		}
+		for path := range cached {
+			skip[path] = ScanSkipCached
+		}
+	}
+	if minAge := args.MinAge(); minAge > 0 {
+		for _, file := range files {
+			if now.Sub(file.ModTime) < minAge {
+				skip[file.Path] = ScanSkipTooNew
+			}
+		}
+	}
+	if args.Incremental {
+		unchanged, err := FindUnchangedFiles(ctx, tx, args.ScheduleID, files)
+		if err != nil {
+			return nil, err
+		}
+		for path := range unchanged {
+			skip[path] = ScanSkipUnchanged
+		}
+	}
+	for _, path := range pending {
+		skip[path] = ScanSkipPendingJob
+	}
+	return skip, nil
+}
+
+// EnqueueScannedFiles enqueues an info job, built from the template of the
+// scan with the given arguments, for each of the given files that
+// ScanSkipReasons doesn't skip at now with the given settings, and adds the
+// files to progress.  Incremental scans catalog the files they enqueue or
+// find cached.  It returns the jobs enqueued, whose created events the
+// caller publishes once tx commits.
+func EnqueueScannedFiles(ctx context.Context, tx pgx.Tx, client *river.Client[pgx.Tx], args ScanArgs, files []ScannedFile, now time.Time, settings ProbeCacheSettings, progress *ScanProgress) ([]*rivertype.JobInsertResult, error) {
+	skip, err := ScanSkipReasons(ctx, tx, args, files, now, settings)
+	if err != nil {
+		return nil, err
+	}
+	progress.Add(files, skip)
+	template := args.Template
+
+	var (
+		params    []river.InsertManyParams
+		uuids     []uuid.UUID
+		cataloged []ScannedFile
+		infoUUIDs = make(map[string]uuid.UUID)
+	)
+	for _, file := range files {
+		reason := skip[file.Path]
+		if reason == ScanSkipCached {
+			cataloged = append(cataloged, file)
+		}
+		if reason != "" {
+			continue
+		}
+		jobArgs := template
+		jobArgs.UUID = uuid.New()
+		jobArgs.Path = file.Path
+		params = append(params, river.InsertManyParams{Args: jobArgs})
+		uuids = append(uuids, jobArgs.UUID)
+		cataloged = append(cataloged, file)
+		infoUUIDs[file.Path] = jobArgs.UUID
+	}
+	if args.Incremental {
+		if err := CatalogScannedFiles(ctx, tx, args.ScheduleID, cataloged, infoUUIDs, now); err != nil {
+			return nil, err
+		}
+	}
+	if len(params) == 0 {
+		return nil, nil
+	}
+
+	insertedJobs, err := client.InsertManyTx(ctx, tx, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to insert info jobs: %w", err)
+	}
+	riverJobIDs := make([]int64, len(insertedJobs))
+	for i, inserted := range insertedJobs {
+		riverJobIDs[i] = inserted.Job.ID
+	}
+	_, err = tx.Exec(ctx, `
+		INSERT INTO uuid_job_mapping (uuid, river_job_id)
+		SELECT * FROM unnest($1::uuid[], $2::bigint[])`,
+		uuids, riverJobIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to insert uuid mappings: %w", err)
+	}
+	return insertedJobs, nil
+}
+
+// scanProgressMetadataKey is the key of the metadata of a scan job that
+// holds its ScanProgress until it finishes.
+const scanProgressMetadataKey = "scan_progress"
+
+// ScanProgress counts the files a scan has dealt with.  It is checkpointed
+// in the scan job's metadata along with each batch of info jobs, so that a
+// retried scan resumes after the last batch rather than starting over, and
+// recorded as the job's output once the scan finishes.
+type ScanProgress struct {
+	// AfterPath is the last file dealt with.  Files are dealt with in
+	// lexical order of their paths.
+	AfterPath string         `json:"after_path,omitempty"`
+	Found     int            `json:"found"`
+	Enqueued  int            `json:"enqueued"`
+	Skipped   map[string]int `json:"skipped,omitempty"`
+
+	// NewestTooNew is when the most recently modified of the files skipped
+	// as too new was modified.
+	NewestTooNew *time.Time `json:"newest_too_new,omitempty"`
+
+	// FollowUpAt is when the follow-up scan for the files skipped as too
+	// new is scheduled, if one has been.
+	FollowUpAt *time.Time `json:"follow_up_at,omitempty"`
+}
+
+// Add counts files, which a scan skips for the reasons returned by
+// ScanSkipReasons, in the progress.
+func (p *ScanProgress) Add(files []ScannedFile, skip map[string]string) {
+	for _, file := range files {
+		p.Found++
+		p.AfterPath = file.Path
+		reason := skip[file.Path]
+		if reason == "" {
+			p.Enqueued++
+			continue
+		}
+		if p.Skipped == nil {
+			p.Skipped = make(map[string]int)
+		}
+		p.Skipped[reason]++
+		if reason == ScanSkipTooNew && (p.NewestTooNew == nil || file.ModTime.After(*p.NewestTooNew)) {
+			modTime := file.ModTime
+			p.NewestTooNew = &modTime
+		}
+	}
+}
+
+// FollowUp returns the arguments and options of the follow-up scan that
+// picks up the files the scan with the given arguments skipped as too new,
+// once the newest of them is old enough.  It returns false if the scan
+// needs no follow-up.
+func (p *ScanProgress) FollowUp(args ScanArgs) (ScanArgs, *river.InsertOpts, bool) {
+	if args.FollowUp || args.DryRun || p.NewestTooNew == nil || p.FollowUpAt != nil {
+		return ScanArgs{}, nil, false
+	}
+	followUp := args
+	followUp.FollowUp = true
+	return followUp, &river.InsertOpts{ScheduledAt: p.NewestTooNew.Add(args.MinAge())}, true
+}
+
+// RESTProgress returns the REST representation of the progress of a scan,
+// which has finished if finished is true.  errMsg is why it failed, if it
+// did.
+func (p *ScanProgress) RESTProgress(finished bool, errMsg *string) *virest.ScanProgress {
+	return &virest.ScanProgress{
+		Finished:   finished,
+		Error:      errMsg,
+		Found:      p.Found,
+		Enqueued:   p.Enqueued,
+		Skipped:    p.Skipped,
+		FollowUpAt: p.FollowUpAt,
+	}
+}
+
+// ScanCheckpoint returns the progress checkpointed in the metadata of a scan
+// job, or no progress if the scan hasn't checkpointed any.
+func ScanCheckpoint(metadata []byte) (ScanProgress, error) {
+	var decoded struct {
+		Progress *ScanProgress `json:"scan_progress"`
+	}
+	if len(metadata) > 0 {
+		if err := json.Unmarshal(metadata, &decoded); err != nil {
+			return ScanProgress{}, fmt.Errorf("failed to unmarshal scan checkpoint: %w", err)
+		}
+	}
+	if decoded.Progress == nil {
+		return ScanProgress{}, nil
+	}
+	return *decoded.Progress, nil
+}
+
+// CheckpointScan records progress in the metadata of the scan job with the
+// given River ID.
+func CheckpointScan(ctx context.Context, tx pgx.Tx, jobID int64, progress ScanProgress) error {
+	_, err := tx.Exec(ctx, "UPDATE river_job SET metadata = metadata || jsonb_build_object($2::text, $3::jsonb) WHERE id = $1",
+		jobID, scanProgressMetadataKey, progress)
+	if err != nil {
+		return fmt.Errorf("failed to checkpoint scan: %w", err)
+	}
+	return nil
+}
+
+// maxScanPreviewSample bounds the files a ScanPreview samples.
+const maxScanPreviewSample = 100
+
+// ScanPreview is the output of a dry run scan, describing what the scan
+// would have enqueued.
+type ScanPreview struct {
+	Found        int            `json:"found"`
+	WouldEnqueue int            `json:"would_enqueue"`
+	Skipped      map[string]int `json:"skipped,omitempty"`
+
+	// EnqueueSample and SkipSample hold the first files, in path order,
+	// that the scan would enqueue and skip.
+	EnqueueSample []ScanPreviewFile `json:"enqueue_sample,omitempty"`
+	SkipSample    []ScanPreviewFile `json:"skip_sample,omitempty"`
+}
+
+// ScanPreviewFile is a file sampled by a ScanPreview.
+type ScanPreviewFile struct {
+	Path       string `json:"path"`
+	Size       int64  `json:"size"`
+	SkipReason string `json:"skip_reason,omitempty"`
+}
+
+// Add counts files, which a scan would skip for the reasons returned by
+// ScanSkipReasons, in the preview.
+func (p *ScanPreview) Add(files []ScannedFile, skip map[string]string) {
+	for _, file := range files {
+		p.Found++
+		reason := skip[file.Path]
+		if reason == "" {
+			p.WouldEnqueue++
+			if len(p.EnqueueSample) < maxScanPreviewSample {
+				p.EnqueueSample = append(p.EnqueueSample, ScanPreviewFile{Path: file.Path, Size: file.Size})
+			}
+			continue
+		}
+		if p.Skipped == nil {
+			p.Skipped = make(map[string]int)
+		}
+		p.Skipped[reason]++
+		if len(p.SkipSample) < maxScanPreviewSample {
+			p.SkipSample = append(p.SkipSample, ScanPreviewFile{Path: file.Path, Size: file.Size, SkipReason: reason})
+		}
+	}
+}
+
+// RESTSample returns up to maxScanPreviewSample sampled files, those the
+// scan would enqueue ahead of those it would skip.  Each kind gets at least
+// half of the sample if it has that many files.
+func (p *ScanPreview) RESTSample() []virest.ScanPreviewFile {
+	enqueued := min(len(p.EnqueueSample), max(maxScanPreviewSample/2, maxScanPreviewSample-len(p.SkipSample)))
+	skipped := min(len(p.SkipSample), maxScanPreviewSample-enqueued)
+	sample := make([]virest.ScanPreviewFile, 0, enqueued+skipped)
+	for _, file := range p.EnqueueSample[:enqueued] {
+		sample = append(sample, virest.ScanPreviewFile{Path: file.Path, Size: file.Size})
+	}
+	for _, file := range p.SkipSample[:skipped] {
+		sample = append(sample, virest.ScanPreviewFile{Path: file.Path, Size: file.Size, SkipReason: &file.SkipReason})
+	}
+	return sample
+}
+
+// PurgeScanPaths removes the paths for which purged returns true from the
+// metadata of a scan job: the path its checkpoint or recorded progress
+// resumes after, and the files sampled by a dry run's preview.  It returns
+// the new metadata and whether anything was removed.  A scan whose resume
+// path is removed starts over if it is retried.
+func PurgeScanPaths(metadata []byte, purged func(path string) bool) ([]byte, bool, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(metadata, &fields); err != nil {
+		return nil, false, fmt.Errorf("failed to unmarshal scan metadata: %w", err)
+	}
+	changed := false
+	for _, key := range []string{scanProgressMetadataKey, rivertype.MetadataKeyOutput} {
+		record, ok := fields[key]
+		if !ok {
+			continue
+		}
+		purgedRecord, recordChanged, err := purgeScanRecord(record, purged)
+		if err != nil {
+			return nil, false, err
+		}
+		if recordChanged {
+			fields[key] = purgedRecord
+			changed = true
+		}
+	}
+	if !changed {
+		return metadata, false, nil
+	}
+	purgedMetadata, err := json.Marshal(fields)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to marshal scan metadata: %w", err)
+	}
+	return purgedMetadata, true, nil
+}
+
+// purgeScanRecord removes purged paths from a ScanProgress or ScanPreview.
+// Fields are edited in place so that those it doesn't know are kept.
+func purgeScanRecord(record json.RawMessage, purged func(path string) bool) (json.RawMessage, bool, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(record, &fields); err != nil || fields == nil {
+		// Not an object, so it names no paths
+		return record, false, nil
+	}
+	changed := false
+	if raw, ok := fields["after_path"]; ok {
+		var afterPath string
+		if err := json.Unmarshal(raw, &afterPath); err != nil {
+			return nil, false, fmt.Errorf("failed to unmarshal scan progress: %w", err)
+		}
+		if purged(afterPath) {
+			delete(fields, "after_path")
+			changed = true
+		}
+	}
+	for _, key := range []string{"enqueue_sample", "skip_sample"} {
+		raw, ok := fields[key]
+		if !ok {
+			continue
+		}
+		var sample []ScanPreviewFile
+		if err := json.Unmarshal(raw, &sample); err != nil {
+			return nil, false, fmt.Errorf("failed to unmarshal scan preview: %w", err)
+		}
+		kept := slices.DeleteFunc(slices.Clone(sample), func(file ScanPreviewFile) bool { return purged(file.Path) })
+		if len(kept) == len(sample) {
+			continue
+		}
+		changed = true
+		if len(kept) == 0 {
+			delete(fields, key)
+			continue
+		}
+		raw, err := json.Marshal(kept)
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to marshal scan preview: %w", err)
+		}
+		fields[key] = raw
+	}
+	if !changed {
+		return record, false, nil
+	}
+	purgedRecord, err := json.Marshal(fields)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to marshal scan record: %w", err)
+	}
+	return purgedRecord, true, nil
+}
@@ -0,0 +1,360 @@
+package internal_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/krelinga/go-libs/deep"
+	"github.com/krelinga/go-libs/exam"
+	"github.com/krelinga/go-libs/match"
+	"github.com/krelinga/video-info/internal"
+	"github.com/krelinga/video-info/virest"
+)
+
+func TestParseCron(t *testing.T) {
+	e := exam.New(t)
+	env := deep.NewEnv()
+	now := time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		loc     exam.Loc
+		name    string
+		expr    string
+		want    time.Time
+		wantErr bool
+	}{
+		{loc: exam.Here(), name: "Nightly", expr: "0 3 * * *", want: time.Date(2024, 1, 16, 3, 0, 0, 0, time.UTC)},
+		{loc: exam.Here(), name: "Later today", expr: "45 10 * * *", want: time.Date(2024, 1, 15, 10, 45, 0, 0, time.UTC)},
+		{loc: exam.Here(), name: "Descriptor", expr: "@weekly", want: time.Date(2024, 1, 21, 0, 0, 0, 0, time.UTC)},
+		{loc: exam.Here(), name: "Time zone", expr: "CRON_TZ=America/New_York 0 3 * * *", want: time.Date(2024, 1, 16, 8, 0, 0, 0, time.UTC)},
+		{loc: exam.Here(), name: "Empty", expr: "", wantErr: true},
+		{loc: exam.Here(), name: "Seconds field", expr: "0 0 3 * * *", wantErr: true},
+		{loc: exam.Here(), name: "Out of range", expr: "0 25 * * *", wantErr: true},
+	}
+	for _, tt := range tests {
+		e.Run(tt.name, func(e exam.E) {
+			e.Log("Running test at", tt.loc)
+			schedule, err := internal.ParseCron(tt.expr)
+			if tt.wantErr {
+				exam.Match(e, env, err, match.ErrorIs(internal.ErrInvalidCron))
+				return
+			}
+			exam.Nil(e, env, err)
+			exam.Equal(e, env, tt.want, internal.NextRun(schedule, now).UTC())
+		})
+	}
+}
+
+func TestScanDirectory(t *testing.T) {
+	e := exam.New(t)
+	env := deep.NewEnv()
+
+	dir := t.TempDir()
+	for _, name := range []string{
+		"b.mkv",
+		"a/Movie.MP4",
+		"a/notes.txt",
+		"a/.hidden.mkv",
+		".thumbnails/b.mkv",
+		"c/d/episode.m2ts",
+	} {
+		path := filepath.Join(dir, name)
+		exam.Nil(e, env, os.MkdirAll(filepath.Dir(path), 0o755))
+		exam.Nil(e, env, os.WriteFile(path, nil, 0o644))
+	}
+	exam.Nil(e, env, os.Symlink(filepath.Join(dir, "b.mkv"), filepath.Join(dir, "link.mkv")))
+
+	files, err := internal.ScanDirectory(dir, "", nil)
+	exam.Nil(e, env, err)
+	var paths []string
+	for _, file := range files {
+		paths = append(paths, file.Path)
+	}
+	exam.Equal(e, env, []string{
+		filepath.Join(dir, "a/Movie.MP4"),
+		filepath.Join(dir, "b.mkv"),
+		filepath.Join(dir, "c/d/episode.m2ts"),
+	}, paths)
+
+	// A resumed scan finds only the files after the last one dealt with
+	files, err = internal.ScanDirectory(dir, filepath.Join(dir, "a/Movie.MP4"), nil)
+	exam.Nil(e, env, err)
+	paths = nil
+	for _, file := range files {
+		paths = append(paths, file.Path)
+	}
+	exam.Equal(e, env, []string{
+		filepath.Join(dir, "b.mkv"),
+		filepath.Join(dir, "c/d/episode.m2ts"),
+	}, paths)
+
+	_, err = internal.ScanDirectory(filepath.Join(dir, "missing"), "", nil)
+	exam.Match(e, env, err, match.ErrorIs(os.ErrNotExist))
+}
+
+func TestScanDirectoryExclude(t *testing.T) {
+	e := exam.New(t)
+	env := deep.NewEnv()
+
+	dir := t.TempDir()
+	for name, contents := range map[string]string{
+		"Movie/Movie.mkv":          "",
+		"Movie/Movie.mkv.part":     "",
+		"Movie/Extras/Trailer.mkv": "",
+		"Movie/Sample.mkv":         "",
+		"Show/Sample/Sample.mkv":   "",
+		"Show/S01/E01.mkv":         "",
+		"Show/S01/E01-sample.mkv":  "",
+		"Show/Sample.mkv":          "",
+		"Show/.viignore":           "# Samples of the season\n\nS01/*-sample.mkv\n/Sample.mkv\n",
+		"Extras.mkv":               "",
+	} {
+		path := filepath.Join(dir, name)
+		exam.Nil(e, env, os.MkdirAll(filepath.Dir(path), 0o755))
+		exam.Nil(e, env, os.WriteFile(path, []byte(contents), 0o644))
+	}
+
+	files, err := internal.ScanDirectory(dir, "", []string{"Extras/", "Sample/", "Movie/Sample.mkv"})
+	exam.Nil(e, env, err)
+	var paths []string
+	for _, file := range files {
+		paths = append(paths, file.Path)
+	}
+	exam.Equal(e, env, []string{
+		filepath.Join(dir, "Extras.mkv"),
+		filepath.Join(dir, "Movie/Movie.mkv"),
+		filepath.Join(dir, "Show/S01/E01.mkv"),
+	}, paths)
+
+	_, err = internal.ScanDirectory(dir, "", []string{"[Extras"})
+	exam.Match(e, env, err, match.ErrorIs(internal.ErrInvalidExclude))
+
+	exam.Nil(e, env, os.WriteFile(filepath.Join(dir, "Movie", internal.ScanIgnoreFile), []byte("\\"), 0o644))
+	_, err = internal.ScanDirectory(dir, "", nil)
+	exam.Match(e, env, err, match.ErrorIs(internal.ErrInvalidExclude))
+}
+
+func TestValidateScanExclude(t *testing.T) {
+	e := exam.New(t)
+	env := deep.NewEnv()
+
+	tests := []struct {
+		loc      exam.Loc
+		name     string
+		patterns []string
+		wantErr  bool
+	}{
+		{loc: exam.Here(), name: "None"},
+		{loc: exam.Here(), name: "Valid", patterns: []string{"*.part", "Extras/", "/Samples/", "Season ?/*-sample.mkv"}},
+		{loc: exam.Here(), name: "Empty", patterns: []string{""}, wantErr: true},
+		{loc: exam.Here(), name: "Only a slash", patterns: []string{"/"}, wantErr: true},
+		{loc: exam.Here(), name: "Unclosed class", patterns: []string{"*.part", "[Extras"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		e.Run(tt.name, func(e exam.E) {
+			e.Log("Running test at", tt.loc)
+			err := internal.ValidateScanExclude(tt.patterns)
+			if tt.wantErr {
+				exam.Match(e, env, err, match.ErrorIs(internal.ErrInvalidExclude))
+				return
+			}
+			exam.Nil(e, env, err)
+		})
+	}
+}
+
+func TestScanProgress(t *testing.T) {
+	e := exam.New(t)
+	env := deep.NewEnv()
+
+	e.Run("Counts files", func(e exam.E) {
+		var progress internal.ScanProgress
+		progress.Add([]internal.ScannedFile{
+			{Path: "/nas/media/a.mkv"},
+			{Path: "/nas/media/b.mkv"},
+			{Path: "/nas/media/c.mkv"},
+		}, map[string]string{"/nas/media/b.mkv": internal.ScanSkipCached})
+		exam.Equal(e, env, internal.ScanProgress{
+			AfterPath: "/nas/media/c.mkv",
+			Found:     3,
+			Enqueued:  2,
+			Skipped:   map[string]int{internal.ScanSkipCached: 1},
+		}, progress)
+	})
+
+	e.Run("Counts incremental skips", func(e exam.E) {
+		var progress internal.ScanProgress
+		progress.Add([]internal.ScannedFile{
+			{Path: "/nas/media/a.mkv"},
+			{Path: "/nas/media/b.mkv"},
+			{Path: "/nas/media/c.mkv"},
+		}, map[string]string{
+			"/nas/media/a.mkv": internal.ScanSkipUnchanged,
+			"/nas/media/c.mkv": internal.ScanSkipUnchanged,
+		})
+		exam.Equal(e, env, internal.ScanProgress{
+			AfterPath: "/nas/media/c.mkv",
+			Found:     3,
+			Enqueued:  1,
+			Skipped:   map[string]int{internal.ScanSkipUnchanged: 2},
+		}, progress)
+		exam.Equal(e, env, &virest.ScanProgress{
+			Finished: true,
+			Found:    3,
+			Enqueued: 1,
+			Skipped:  map[string]int{internal.ScanSkipUnchanged: 2},
+		}, progress.RESTProgress(true, nil))
+	})
+
+	e.Run("Follow-up scans", func(e exam.E) {
+		modified := time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC)
+		args := internal.ScanArgs{Directory: "/nas/media", MinAgeSeconds: 900}
+		var progress internal.ScanProgress
+		progress.Add([]internal.ScannedFile{
+			{Path: "/nas/media/a.mkv", ModTime: modified},
+			{Path: "/nas/media/b.mkv", ModTime: modified.Add(-time.Hour)},
+			{Path: "/nas/media/c.mkv", ModTime: modified.Add(time.Minute)},
+		}, map[string]string{
+			"/nas/media/a.mkv": internal.ScanSkipTooNew,
+			"/nas/media/c.mkv": internal.ScanSkipTooNew,
+		})
+		exam.Equal(e, env, map[string]int{internal.ScanSkipTooNew: 2}, progress.Skipped)
+
+		// Once the newest file skipped is old enough
+		followUp, opts, ok := progress.FollowUp(args)
+		exam.Equal(e, env, true, ok)
+		exam.Equal(e, env, true, followUp.FollowUp)
+		exam.Equal(e, env, "/nas/media", followUp.Directory)
+		exam.Equal(e, env, true, opts.ScheduledAt.Equal(modified.Add(16*time.Minute)))
+
+		// Follow-ups, and scans that have scheduled theirs, schedule no more
+		_, _, ok = progress.FollowUp(followUp)
+		exam.Equal(e, env, false, ok)
+		progress.FollowUpAt = &opts.ScheduledAt
+		_, _, ok = progress.FollowUp(args)
+		exam.Equal(e, env, false, ok)
+
+		// Nor do scans that skipped nothing as too new
+		_, _, ok = (&internal.ScanProgress{Found: 1, Enqueued: 1}).FollowUp(args)
+		exam.Equal(e, env, false, ok)
+	})
+
+	e.Run("Checkpoints", func(e exam.E) {
+		tests := []struct {
+			loc      exam.Loc
+			name     string
+			metadata string
+			want     internal.ScanProgress
+			wantErr  bool
+		}{
+			{loc: exam.Here(), name: "No metadata", metadata: ""},
+			{loc: exam.Here(), name: "No checkpoint", metadata: `{"output": {}}`},
+			{
+				loc:      exam.Here(),
+				name:     "Checkpoint",
+				metadata: `{"scan_progress": {"after_path": "/nas/media/c.mkv", "found": 3, "enqueued": 2, "skipped": {"cached": 1}}}`,
+				want: internal.ScanProgress{
+					AfterPath: "/nas/media/c.mkv",
+					Found:     3,
+					Enqueued:  2,
+					Skipped:   map[string]int{internal.ScanSkipCached: 1},
+				},
+			},
+			{loc: exam.Here(), name: "Invalid", metadata: `{"scan_progress": []}`, wantErr: true},
+		}
+		for _, tt := range tests {
+			e.Run(tt.name, func(e exam.E) {
+				e.Log("Running test at", tt.loc)
+				progress, err := internal.ScanCheckpoint([]byte(tt.metadata))
+				if tt.wantErr {
+					exam.NotNil(e, env, err)
+					return
+				}
+				exam.Nil(e, env, err)
+				exam.Equal(e, env, tt.want, progress)
+			})
+		}
+	})
+}
+
+func TestScanPreview(t *testing.T) {
+	e := exam.New(t)
+	env := deep.NewEnv()
+
+	var files []internal.ScannedFile
+	skip := make(map[string]string)
+	for i := range 150 {
+		path := fmt.Sprintf("/nas/media/%03d.mkv", i)
+		files = append(files, internal.ScannedFile{Path: path, Size: int64(i)})
+		switch {
+		case i < 20:
+			skip[path] = internal.ScanSkipPendingJob
+		case i < 30:
+			skip[path] = internal.ScanSkipCached
+		}
+	}
+	var preview internal.ScanPreview
+	preview.Add(files[:100], skip)
+	preview.Add(files[100:], skip)
+
+	exam.Equal(e, env, 150, preview.Found)
+	exam.Equal(e, env, 120, preview.WouldEnqueue)
+	exam.Equal(e, env, map[string]int{internal.ScanSkipPendingJob: 20, internal.ScanSkipCached: 10}, preview.Skipped)
+
+	// Skipped files keep their place in the sample when enqueued ones abound
+	sample := preview.RESTSample()
+	exam.Equal(e, env, 100, len(sample))
+	exam.Equal(e, env, "/nas/media/030.mkv", sample[0].Path)
+	exam.Equal(e, env, true, sample[0].SkipReason == nil)
+	exam.Equal(e, env, "/nas/media/000.mkv", sample[70].Path)
+	exam.Equal(e, env, internal.ScanSkipPendingJob, *sample[70].SkipReason)
+	exam.Equal(e, env, internal.ScanSkipCached, *sample[99].SkipReason)
+}
+
+func TestPurgeScanPaths(t *testing.T) {
+	e := exam.New(t)
+	env := deep.NewEnv()
+	purged := func(path string) bool { return strings.HasPrefix(path, "/nas/media/old/") }
+
+	e.Run("Checkpoint and preview", func(e exam.E) {
+		metadata, err := json.Marshal(map[string]any{
+			"scan_progress": internal.ScanProgress{AfterPath: "/nas/media/old/b.mkv", Found: 2, Enqueued: 2},
+			"output": internal.ScanPreview{
+				Found:         3,
+				EnqueueSample: []internal.ScanPreviewFile{{Path: "/nas/media/a.mkv"}, {Path: "/nas/media/old/b.mkv"}},
+				SkipSample:    []internal.ScanPreviewFile{{Path: "/nas/media/old/c.mkv", SkipReason: internal.ScanSkipCached}},
+			},
+			"other": "kept",
+		})
+		exam.Nil(e, env, err)
+
+		got, changed, err := internal.PurgeScanPaths(metadata, purged)
+		exam.Nil(e, env, err)
+		exam.Equal(e, env, true, changed)
+		var decoded struct {
+			Progress internal.ScanProgress `json:"scan_progress"`
+			Output   internal.ScanPreview  `json:"output"`
+			Other    string                `json:"other"`
+		}
+		exam.Nil(e, env, json.Unmarshal(got, &decoded))
+		exam.Equal(e, env, internal.ScanProgress{Found: 2, Enqueued: 2}, decoded.Progress)
+		exam.Equal(e, env, internal.ScanPreview{
+			Found:         3,
+			EnqueueSample: []internal.ScanPreviewFile{{Path: "/nas/media/a.mkv"}},
+		}, decoded.Output)
+		exam.Equal(e, env, "kept", decoded.Other)
+	})
+
+	e.Run("Nothing to purge", func(e exam.E) {
+		metadata := []byte(`{"scan_progress": {"after_path": "/nas/media/a.mkv", "found": 1, "enqueued": 1}}`)
+		got, changed, err := internal.PurgeScanPaths(metadata, purged)
+		exam.Nil(e, env, err)
+		exam.Equal(e, env, false, changed)
+		exam.Equal(e, env, string(metadata), string(got))
+	})
+}
@@ -0,0 +1,111 @@
+package internal
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+)
+
+// SecretKeySize is the size of the keys accepted by NewKeyring.
+const SecretKeySize = 32
+
+// secretKeyIDSize is the size of the key identifier prefixed to sealed values.
+const secretKeyIDSize = 8
+
+var (
+	ErrInvalidSecretKey = errors.New("invalid secret key")
+	ErrSecretNotSealed  = errors.New("secret was not sealed by a known key")
+)
+
+// Keyring seals and opens secrets stored in the database using AES-256-GCM.
+// The first key seals new secrets; every key can open existing ones, so old
+// keys can be kept around while they are rotated out.
+type Keyring struct {
+	primary [secretKeyIDSize]byte
+	aeads   map[[secretKeyIDSize]byte]cipher.AEAD
+
+	// fingerprintKey is derived from the primary key for Fingerprint.
+	fingerprintKey []byte
+}
+
+// NewKeyring returns a Keyring for the given keys, or nil if there are none.
+func NewKeyring(keys [][]byte) (*Keyring, error) {
+	if len(keys) == 0 {
+		return nil, nil
+	}
+	k := &Keyring{aeads: make(map[[secretKeyIDSize]byte]cipher.AEAD, len(keys))}
+	for i, key := range keys {
+		if len(key) != SecretKeySize {
+			return nil, fmt.Errorf("%w: key %d is %d bytes, want %d", ErrInvalidSecretKey, i, len(key), SecretKeySize)
+		}
+		block, err := aes.NewCipher(key)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidSecretKey, err)
+		}
+		aead, err := cipher.NewGCM(block)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidSecretKey, err)
+		}
+		id := secretKeyID(key)
+		if i == 0 {
+			k.primary = id
+			mac := hmac.New(sha256.New, key)
+			mac.Write([]byte("video-info fingerprint"))
+			k.fingerprintKey = mac.Sum(nil)
+		}
+		k.aeads[id] = aead
+	}
+	return k, nil
+}
+
+func secretKeyID(key []byte) [secretKeyIDSize]byte {
+	sum := sha256.Sum256(key)
+	return [secretKeyIDSize]byte(sum[:secretKeyIDSize])
+}
+
+// Fingerprint returns a hash of data that identifies it without revealing
+// it, keyed by the primary key so it can't be checked against guesses.  A
+// nil Keyring returns an unkeyed SHA-256 hash.  Fingerprints change when the
+// primary key is rotated.
+func (k *Keyring) Fingerprint(data []byte) []byte {
+	if k == nil {
+		sum := sha256.Sum256(data)
+		return sum[:]
+	}
+	mac := hmac.New(sha256.New, k.fingerprintKey)
+	mac.Write(data)
+	return mac.Sum(nil)
+}
+
+// Seal encrypts plaintext with the primary key.  The same additionalData
+// must be passed to Open, which binds the sealed value to its owner.
+func (k *Keyring) Seal(plaintext, additionalData []byte) ([]byte, error) {
+	aead := k.aeads[k.primary]
+	sealed := make([]byte, secretKeyIDSize+aead.NonceSize(), secretKeyIDSize+aead.NonceSize()+len(plaintext)+aead.Overhead())
+	copy(sealed, k.primary[:])
+	if _, err := rand.Read(sealed[secretKeyIDSize:]); err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	return aead.Seal(sealed, sealed[secretKeyIDSize:], plaintext, additionalData), nil
+}
+
+// Open decrypts a value returned by Seal.
+func (k *Keyring) Open(sealed, additionalData []byte) ([]byte, error) {
+	if k == nil || len(sealed) < secretKeyIDSize {
+		return nil, ErrSecretNotSealed
+	}
+	aead, ok := k.aeads[[secretKeyIDSize]byte(sealed[:secretKeyIDSize])]
+	if !ok || len(sealed) < secretKeyIDSize+aead.NonceSize() {
+		return nil, ErrSecretNotSealed
+	}
+	nonce := sealed[secretKeyIDSize : secretKeyIDSize+aead.NonceSize()]
+	plaintext, err := aead.Open(nil, nonce, sealed[secretKeyIDSize+aead.NonceSize():], additionalData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open secret: %w", err)
+	}
+	return plaintext, nil
+}
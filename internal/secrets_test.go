@@ -0,0 +1,77 @@
+package internal_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/krelinga/go-libs/deep"
+	"github.com/krelinga/go-libs/exam"
+	"github.com/krelinga/go-libs/match"
+	"github.com/krelinga/video-info/internal"
+)
+
+func TestKeyring(t *testing.T) {
+	e := exam.New(t)
+	env := deep.NewEnv()
+
+	oldKey := bytes.Repeat([]byte{1}, internal.SecretKeySize)
+	newKey := bytes.Repeat([]byte{2}, internal.SecretKeySize)
+
+	e.Run("No keys", func(e exam.E) {
+		keyring, err := internal.NewKeyring(nil)
+		exam.Nil(e, env, err)
+		exam.Nil(e, env, keyring)
+	})
+
+	e.Run("Short key", func(e exam.E) {
+		_, err := internal.NewKeyring([][]byte{[]byte("short")})
+		exam.Match(e, env, err, match.ErrorIs(internal.ErrInvalidSecretKey))
+	})
+
+	e.Run("Round trip", func(e exam.E) {
+		keyring, err := internal.NewKeyring([][]byte{newKey})
+		exam.Nil(e, env, err)
+		sealed, err := keyring.Seal([]byte("secret"), []byte("owner"))
+		exam.Nil(e, env, err)
+		exam.Equal(e, env, false, bytes.Contains(sealed, []byte("secret")))
+
+		opened, err := keyring.Open(sealed, []byte("owner"))
+		exam.Nil(e, env, err)
+		exam.Equal(e, env, "secret", string(opened))
+	})
+
+	e.Run("Wrong additional data", func(e exam.E) {
+		keyring, err := internal.NewKeyring([][]byte{newKey})
+		exam.Nil(e, env, err)
+		sealed, err := keyring.Seal([]byte("secret"), []byte("owner"))
+		exam.Nil(e, env, err)
+
+		_, err = keyring.Open(sealed, []byte("someone-else"))
+		exam.NotNil(e, env, err)
+	})
+
+	e.Run("Rotated key still opens", func(e exam.E) {
+		oldKeyring, err := internal.NewKeyring([][]byte{oldKey})
+		exam.Nil(e, env, err)
+		sealed, err := oldKeyring.Seal([]byte("secret"), nil)
+		exam.Nil(e, env, err)
+
+		rotated, err := internal.NewKeyring([][]byte{newKey, oldKey})
+		exam.Nil(e, env, err)
+		opened, err := rotated.Open(sealed, nil)
+		exam.Nil(e, env, err)
+		exam.Equal(e, env, "secret", string(opened))
+	})
+
+	e.Run("Unknown key", func(e exam.E) {
+		oldKeyring, err := internal.NewKeyring([][]byte{oldKey})
+		exam.Nil(e, env, err)
+		sealed, err := oldKeyring.Seal([]byte("secret"), nil)
+		exam.Nil(e, env, err)
+
+		newKeyring, err := internal.NewKeyring([][]byte{newKey})
+		exam.Nil(e, env, err)
+		_, err = newKeyring.Open(sealed, nil)
+		exam.Match(e, env, err, match.ErrorIs(internal.ErrSecretNotSealed))
+	})
+}
@@ -0,0 +1,122 @@
+package internal
+
+import (
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/krelinga/video-info/virest"
+)
+
+// SignatureHeader is the HTTP header carrying the signature of a webhook body.
+const SignatureHeader = "X-Video-Info-Signature"
+
+// Signer signs results and webhook payloads with an Ed25519 key.  A nil
+// Signer is valid and signs nothing.
+type Signer struct {
+	key   ed25519.PrivateKey
+	keyID string
+	clock Clock
+}
+
+// NewSigner returns a Signer for key, or nil if key is nil.
+func NewSigner(key ed25519.PrivateKey) *Signer {
+	if key == nil {
+		return nil
+	}
+	pub := key.Public().(ed25519.PublicKey)
+	sum := sha256.Sum256(pub)
+	return &Signer{
+		key:   key,
+		keyID: hex.EncodeToString(sum[:8]),
+		clock: SystemClock,
+	}
+}
+
+// UseClock makes s timestamp signed results with clock.
+func (s *Signer) UseClock(clock Clock) {
+	s.clock = clock
+}
+
+// PublicKey returns the key that verifies signatures made by s.
+func (s *Signer) PublicKey() ed25519.PublicKey {
+	return s.key.Public().(ed25519.PublicKey)
+}
+
+// KeyID returns a short identifier for the signing key.
+func (s *Signer) KeyID() string {
+	return s.keyID
+}
+
+// Sign returns payload together with its signature, or nil if s is nil.
+func (s *Signer) Sign(payload []byte) *SignedPayload {
+	if s == nil {
+		return nil
+	}
+	return &SignedPayload{
+		KeyID:     s.keyID,
+		Payload:   payload,
+		Signature: ed25519.Sign(s.key, payload),
+	}
+}
+
+// SignatureHeaderValue returns the value of SignatureHeader for body, or ""
+// if s is nil.
+func (s *Signer) SignatureHeaderValue(body []byte) string {
+	if s == nil {
+		return ""
+	}
+	return fmt.Sprintf("keyId=%s, ed25519=%s", s.keyID, base64.StdEncoding.EncodeToString(ed25519.Sign(s.key, body)))
+}
+
+// SignInfoJobStatus signs the outcome of the info job described by args and
+// stores the signature in status.  It does nothing if s is nil.
+func (s *Signer) SignInfoJobStatus(args InfoJobArgs, status *InfoJobStatus) error {
+	if s == nil {
+		return nil
+	}
+	payload, err := json.Marshal(SignedResult{
+		Uuid:      args.UUID,
+		VideoPath: args.Path,
+		Result:    status.Result.RESTMediaInfo(),
+		Error:     status.Error,
+		SignedAt:  s.clock.Now().UTC(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal signed result: %w", err)
+	}
+	status.Signed = s.Sign(payload)
+	return nil
+}
+
+// SignedResult is the content of the payload signed for each info job.
+type SignedResult struct {
+	Uuid      uuid.UUID         `json:"uuid"`
+	VideoPath string            `json:"videoPath"`
+	Result    *virest.MediaInfo `json:"result,omitempty"`
+	Error     *string           `json:"error,omitempty"`
+	SignedAt  time.Time         `json:"signedAt"`
+}
+
+// SignedPayload is a payload together with its Ed25519 signature.
+type SignedPayload struct {
+	KeyID     string `json:"key_id"`
+	Payload   []byte `json:"payload"`
+	Signature []byte `json:"signature"`
+}
+
+func (p *SignedPayload) RESTSignedPayload() *virest.SignedPayload {
+	if p == nil {
+		return nil
+	}
+	return &virest.SignedPayload{
+		KeyId:     p.KeyID,
+		Payload:   p.Payload,
+		Signature: p.Signature,
+	}
+}
@@ -0,0 +1,45 @@
+package internal_test
+
+import (
+	"crypto/ed25519"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/krelinga/go-libs/deep"
+	"github.com/krelinga/go-libs/exam"
+	"github.com/krelinga/video-info/internal"
+)
+
+func TestSigner(t *testing.T) {
+	e := exam.New(t)
+	env := deep.NewEnv()
+
+	e.Run("Nil signer signs nothing", func(e exam.E) {
+		var signer *internal.Signer
+		status := &internal.InfoJobStatus{Result: &internal.InfoJobResult{DurationSeconds: 1}}
+		exam.Nil(e, env, signer.SignInfoJobStatus(internal.InfoJobArgs{}, status))
+		exam.Nil(e, env, status.Signed)
+		exam.Equal(e, env, "", signer.SignatureHeaderValue([]byte("body")))
+	})
+
+	e.Run("Signed status verifies", func(e exam.E) {
+		signer := internal.NewSigner(ed25519.NewKeyFromSeed(make([]byte, ed25519.SeedSize)))
+		now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+		signer.UseClock(internal.NewFakeClock(now))
+		args := internal.InfoJobArgs{UUID: uuid.New(), Path: "/videos/movie.mkv"}
+		status := &internal.InfoJobStatus{Result: &internal.InfoJobResult{DurationSeconds: 42}}
+		exam.Nil(e, env, signer.SignInfoJobStatus(args, status))
+
+		exam.Equal(e, env, signer.KeyID(), status.Signed.KeyID)
+		exam.Equal(e, env, true, ed25519.Verify(signer.PublicKey(), status.Signed.Payload, status.Signed.Signature))
+
+		var signed internal.SignedResult
+		exam.Nil(e, env, json.Unmarshal(status.Signed.Payload, &signed))
+		exam.Equal(e, env, args.UUID.String(), signed.Uuid.String())
+		exam.Equal(e, env, args.Path, signed.VideoPath)
+		exam.Equal(e, env, 42.0, signed.Result.TotalDurationSeconds)
+		exam.Equal(e, env, true, signed.SignedAt.Equal(now))
+	})
+}
@@ -0,0 +1,105 @@
+package internal
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"strings"
+)
+
+var (
+	// ErrUnsupportedFormat is returned for files that are not worth probing.
+	ErrUnsupportedFormat = errors.New("unsupported format")
+
+	// ErrFileTooLarge is returned for files over the worker's size limit.
+	ErrFileTooLarge = errors.New("file too large")
+
+	// ErrJobTimeout is returned for jobs that ran past their timeout.
+	ErrJobTimeout = errors.New("job timed out")
+)
+
+// Job error codes, reported alongside job errors.
+const (
+	ErrorCodeUnsupportedFormat = "UNSUPPORTED_FORMAT"
+	ErrorCodeFileTooLarge      = "FILE_TOO_LARGE"
+	ErrorCodeNotFound          = "NOT_FOUND"
+	ErrorCodePermissionDenied  = "PERMISSION_DENIED"
+	ErrorCodeTimeout           = "TIMEOUT"
+	ErrorCodePathNotAllowed    = "PATH_NOT_ALLOWED"
+)
+
+// ErrorCode returns the machine-readable code for a job error, or nil if the
+// error has no code.
+func ErrorCode(err error) *string {
+	var code string
+	switch {
+	case errors.Is(err, ErrUnsupportedFormat):
+		code = ErrorCodeUnsupportedFormat
+	case errors.Is(err, ErrFileTooLarge):
+		code = ErrorCodeFileTooLarge
+	case errors.Is(err, fs.ErrNotExist):
+		code = ErrorCodeNotFound
+	case errors.Is(err, fs.ErrPermission):
+		code = ErrorCodePermissionDenied
+	case errors.Is(err, ErrJobTimeout):
+		code = ErrorCodeTimeout
+	case errors.Is(err, ErrPathNotAllowed):
+		code = ErrorCodePathNotAllowed
+	default:
+		return nil
+	}
+	return &code
+}
+
+// MediaClass is the broad kind of a file as judged from its first bytes.
+type MediaClass int
+
+const (
+	// MediaUnknown files can't be classified and are probed anyway, since
+	// many video containers, such as MPEG-TS, have no recognizable signature.
+	MediaUnknown MediaClass = iota
+	MediaVideo
+	MediaAudio
+	// MediaOther files are recognizably not audio or video, such as text,
+	// images and archives.
+	MediaOther
+)
+
+// sniffLen is the number of bytes examined by SniffMediaClass.
+const sniffLen = 512
+
+// SniffMediaClass classifies the file at path from its first bytes, returning
+// the detected MIME type along with its class.
+func SniffMediaClass(path string) (MediaClass, string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return MediaUnknown, "", err
+	}
+	defer f.Close()
+
+	header := make([]byte, sniffLen)
+	n, err := io.ReadFull(f, header)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) && !errors.Is(err, io.EOF) {
+		return MediaUnknown, "", fmt.Errorf("failed to read file header: %w", err)
+	}
+	mimeType := http.DetectContentType(header[:n])
+	return classifyMIMEType(mimeType), mimeType, nil
+}
+
+func classifyMIMEType(mimeType string) MediaClass {
+	mediaType, _, _ := strings.Cut(mimeType, ";")
+	switch {
+	case strings.HasPrefix(mediaType, "video/"):
+		return MediaVideo
+	case strings.HasPrefix(mediaType, "audio/"):
+		return MediaAudio
+	case mediaType == "application/octet-stream", mediaType == "application/ogg":
+		// Ogg holds either audio or video, so leave it to ffprobe
+		return MediaUnknown
+	default:
+		return MediaOther
+	}
+}
@@ -0,0 +1,98 @@
+package internal_test
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/krelinga/go-libs/deep"
+	"github.com/krelinga/go-libs/exam"
+	"github.com/krelinga/video-info/internal"
+)
+
+func TestSniffMediaClass(t *testing.T) {
+	e := exam.New(t)
+	env := deep.NewEnv()
+
+	tests := []struct {
+		loc     exam.Loc
+		name    string
+		content []byte
+		want    internal.MediaClass
+	}{
+		{
+			loc:     exam.Here(),
+			name:    "Matroska",
+			content: []byte("\x1a\x45\xdf\xa3\x9f\x42\x86\x81\x01webm"),
+			want:    internal.MediaVideo,
+		},
+		{
+			loc:     exam.Here(),
+			name:    "MP4",
+			content: []byte("\x00\x00\x00\x18ftypmp42\x00\x00\x00\x00mp42isom"),
+			want:    internal.MediaVideo,
+		},
+		{
+			loc:     exam.Here(),
+			name:    "MPEG-TS",
+			content: []byte("\x47\x40\x00\x10\x00\x00\xb0\x0d\x00\x01\xc1\x00\x00"),
+			want:    internal.MediaUnknown,
+		},
+		{
+			loc:     exam.Here(),
+			name:    "MP3",
+			content: []byte("ID3\x04\x00\x00\x00\x00\x00\x00"),
+			want:    internal.MediaAudio,
+		},
+		{
+			loc:     exam.Here(),
+			name:    "NFO",
+			content: []byte("<movie><title>Example</title></movie>\n"),
+			want:    internal.MediaOther,
+		},
+		{
+			loc:     exam.Here(),
+			name:    "Text",
+			content: []byte("Release notes\n"),
+			want:    internal.MediaOther,
+		},
+		{
+			loc:     exam.Here(),
+			name:    "JPEG",
+			content: []byte("\xff\xd8\xff\xe0\x00\x10JFIF\x00"),
+			want:    internal.MediaOther,
+		},
+	}
+	for _, tt := range tests {
+		e.Run(tt.name, func(e exam.E) {
+			e.Log("Running test at", tt.loc)
+
+			path := filepath.Join(t.TempDir(), "file")
+			exam.Nil(e, env, os.WriteFile(path, tt.content, 0o600))
+			got, mimeType, err := internal.SniffMediaClass(path)
+			exam.Nil(e, env, err)
+			e.Log("Detected", mimeType)
+			exam.Equal(e, env, tt.want, got)
+		})
+	}
+}
+
+func TestErrorCode(t *testing.T) {
+	e := exam.New(t)
+	env := deep.NewEnv()
+
+	code := internal.ErrorCode(fmt.Errorf("%w: text/plain", internal.ErrUnsupportedFormat))
+	exam.Equal(e, env, internal.ErrorCodeUnsupportedFormat, *code)
+	_, err := os.Stat(filepath.Join(t.TempDir(), "missing.mkv"))
+	code = internal.ErrorCode(fmt.Errorf("failed to stat video file: %w", err))
+	exam.Equal(e, env, internal.ErrorCodeNotFound, *code)
+	code = internal.ErrorCode(fmt.Errorf("failed to stat video file: %w", fs.ErrPermission))
+	exam.Equal(e, env, internal.ErrorCodePermissionDenied, *code)
+	code = internal.ErrorCode(fmt.Errorf("%w after 1m0s", internal.ErrJobTimeout))
+	exam.Equal(e, env, internal.ErrorCodeTimeout, *code)
+	code = internal.ErrorCode(fmt.Errorf("%w: \"/etc/passwd\" is not under an allowed root", internal.ErrPathNotAllowed))
+	exam.Equal(e, env, internal.ErrorCodePathNotAllowed, *code)
+	exam.Nil(e, env, internal.ErrorCode(fmt.Errorf("ffprobe failed")))
+}
@@ -0,0 +1,45 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+)
+
+// Standard OpenTelemetry environment variables that enable metric export.
+// The exporter reads these and the other OTEL_EXPORTER_OTLP_* variables
+// itself.
+const (
+	EnvOTLPEndpoint        = "OTEL_EXPORTER_OTLP_ENDPOINT"
+	EnvOTLPMetricsEndpoint = "OTEL_EXPORTER_OTLP_METRICS_ENDPOINT"
+)
+
+// StartMetrics installs a global OpenTelemetry meter provider that exports
+// over OTLP/HTTP, through proxy if set, when an OTLP endpoint is configured.
+// Otherwise metrics are discarded.  The returned function flushes and stops
+// the exporter.
+func StartMetrics(ctx context.Context, proxy *url.URL) (func(context.Context) error, error) {
+	if os.Getenv(EnvOTLPEndpoint) == "" && os.Getenv(EnvOTLPMetricsEndpoint) == "" {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	var opts []otlpmetrichttp.Option
+	if proxy != nil {
+		opts = append(opts, otlpmetrichttp.WithProxy(func(*http.Request) (*url.URL, error) {
+			return proxy, nil
+		}))
+	}
+	exporter, err := otlpmetrichttp.New(ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create metric exporter: %w", err)
+	}
+	provider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(sdkmetric.NewPeriodicReader(exporter)))
+	otel.SetMeterProvider(provider)
+	return provider.Shutdown, nil
+}
@@ -0,0 +1,151 @@
+package internal
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/riverqueue/river"
+	"github.com/riverqueue/river/rivertype"
+)
+
+// MetadataKeyWebhookEnqueued marks cancelled and discarded info jobs whose
+// webhook has been enqueued.  Retrying a job clears it.
+const MetadataKeyWebhookEnqueued = "webhook_enqueued"
+
+// terminalWebhookBatchSize bounds the info jobs notified per transaction.
+const terminalWebhookBatchSize = 500
+
+// Statuses of info jobs reported by webhooks.  Webhooks report
+// WebhookStarted only for jobs that request it in their webhook events.
+const (
+	WebhookStarted   = "started"
+	WebhookCompleted = "completed"
+	WebhookFailed    = "failed"
+	WebhookCancelled = "cancelled"
+	WebhookExpired   = "expired"
+)
+
+// riverRescuedError is the error River records for a job it rescues after
+// the job was stuck running, as happens when a worker dies or a remote
+// worker's lease expires.
+const riverRescuedError = "Stuck job rescued by JobRescuer"
+
+// TerminalWebhooksArgs are the arguments of the periodic job that enqueues
+// the webhooks of info jobs that were cancelled or that River gave up on.
+// Completed jobs enqueue their own as they complete.
+type TerminalWebhooksArgs struct{}
+
+// Kind returns the job kind identifier for River.
+func (TerminalWebhooksArgs) Kind() string {
+	return "terminal_webhooks"
+}
+
+// InsertOpts places the job on the maintenance queue.
+func (TerminalWebhooksArgs) InsertOpts() river.InsertOpts {
+	return river.InsertOpts{Queue: QueueMaintenance}
+}
+
+// TerminalWebhookStatus returns how an info job in the cancelled or
+// discarded state finished, given the error of its last attempt, if any.
+// Jobs discarded after timing out or being rescued have expired.
+func TerminalWebhookStatus(state rivertype.JobState, lastError *string) string {
+	if state == rivertype.JobStateCancelled {
+		return WebhookCancelled
+	}
+	if lastError != nil && (*lastError == riverRescuedError || *lastError == context.DeadlineExceeded.Error()) {
+		return WebhookExpired
+	}
+	return WebhookFailed
+}
+
+// EnqueueTerminalWebhooks enqueues the webhooks of up to a batch of info jobs
+// that were cancelled or discarded since their webhook was last enqueued,
+// marking them with MetadataKeyWebhookEnqueued.  Jobs locked by another
+// transaction are left for the next call.  It returns the number of webhook
+// jobs inserted, which may be several per info job.
+func EnqueueTerminalWebhooks(ctx context.Context, tx pgx.Tx, client webhookInserter, now time.Time) (int, error) {
+	rows, err := tx.Query(ctx, `
+		SELECT id, state::text, args, metadata->$3, errors[array_length(errors, 1)]->>'error'
+		FROM river_job
+		WHERE kind = $1 AND state IN ('cancelled', 'discarded')
+			AND (args ? 'webhook_uri' OR args ? 'sealed_webhook' OR args ? 'webhooks')
+			AND NOT metadata ? $2
+		ORDER BY id
+		LIMIT $4
+		FOR UPDATE SKIP LOCKED`,
+		InfoJobArgs{}.Kind(), MetadataKeyWebhookEnqueued, rivertype.MetadataKeyOutput, terminalWebhookBatchSize)
+	if err != nil {
+		return 0, fmt.Errorf("failed to find finished info jobs: %w", err)
+	}
+	type terminalJob struct {
+		id        int64
+		state     rivertype.JobState
+		args      InfoJobArgs
+		output    []byte
+		lastError *string
+	}
+	jobs, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (terminalJob, error) {
+		var job terminalJob
+		err := row.Scan(&job.id, &job.state, &job.args, &job.output, &job.lastError)
+		return job, err
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to find finished info jobs: %w", err)
+	}
+
+	ids := make([]int64, len(jobs))
+	enqueued := 0
+	for i, job := range jobs {
+		ids[i] = job.id
+		var status InfoJobStatus
+		if job.output != nil {
+			if err := json.Unmarshal(job.output, &status); err != nil {
+				return 0, fmt.Errorf("failed to unmarshal output of job %d: %w", job.id, err)
+			}
+		}
+		// Cancelled jobs have no error to report, whatever River recorded
+		if job.state == rivertype.JobStateDiscarded && status.Error == nil && job.lastError != nil {
+			lastError := Redact(*job.lastError)
+			status.Error = &lastError
+		}
+		outcome := TerminalWebhookStatus(job.state, job.lastError)
+		for _, webhookArgs := range job.args.WebhookArgs(outcome, &status) {
+			if err := EnqueueWebhook(ctx, tx, client, webhookArgs, now); err != nil {
+				return 0, err
+			}
+			enqueued++
+		}
+	}
+	if len(ids) == 0 {
+		return 0, nil
+	}
+	_, err = tx.Exec(ctx, "UPDATE river_job SET metadata = metadata || jsonb_build_object($2::text, true) WHERE id = ANY($1)",
+		ids, MetadataKeyWebhookEnqueued)
+	if err != nil {
+		return 0, fmt.Errorf("failed to mark webhooks enqueued: %w", err)
+	}
+	return enqueued, nil
+}
+
+// EnqueueCancelledWebhooks enqueues the webhooks of the info job with the
+// given ID and args, which tx has just cancelled, and marks it with
+// MetadataKeyWebhookEnqueued so that EnqueueTerminalWebhooks doesn't enqueue
+// them again.  It returns the number of webhook jobs inserted.
+func EnqueueCancelledWebhooks(ctx context.Context, tx pgx.Tx, client webhookInserter, jobID int64, args InfoJobArgs, now time.Time) (int, error) {
+	enqueued := 0
+	for _, webhookArgs := range args.WebhookArgs(WebhookCancelled, &InfoJobStatus{}) {
+		if err := EnqueueWebhook(ctx, tx, client, webhookArgs, now); err != nil {
+			return 0, err
+		}
+		enqueued++
+	}
+	_, err := tx.Exec(ctx, "UPDATE river_job SET metadata = metadata || jsonb_build_object($2::text, true) WHERE id = $1",
+		jobID, MetadataKeyWebhookEnqueued)
+	if err != nil {
+		return 0, fmt.Errorf("failed to mark webhooks enqueued: %w", err)
+	}
+	return enqueued, nil
+}
@@ -0,0 +1,78 @@
+package internal_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/krelinga/go-libs/deep"
+	"github.com/krelinga/go-libs/exam"
+	"github.com/krelinga/video-info/internal"
+	"github.com/riverqueue/river/rivertype"
+)
+
+func TestTerminalWebhookStatus(t *testing.T) {
+	e := exam.New(t)
+	env := deep.NewEnv()
+
+	rescued := "Stuck job rescued by JobRescuer"
+	timedOut := context.DeadlineExceeded.Error()
+	crashed := "failed to begin transaction: connection refused"
+	tests := []struct {
+		loc       exam.Loc
+		name      string
+		state     rivertype.JobState
+		lastError *string
+		want      string
+	}{
+		{
+			loc:   exam.Here(),
+			name:  "Cancelled before running",
+			state: rivertype.JobStateCancelled,
+			want:  internal.WebhookCancelled,
+		},
+		{
+			loc:       exam.Here(),
+			name:      "Cancelled while running",
+			state:     rivertype.JobStateCancelled,
+			lastError: &timedOut,
+			want:      internal.WebhookCancelled,
+		},
+		{
+			loc:       exam.Here(),
+			name:      "Rescued",
+			state:     rivertype.JobStateDiscarded,
+			lastError: &rescued,
+			want:      internal.WebhookExpired,
+		},
+		{
+			loc:       exam.Here(),
+			name:      "Timed out",
+			state:     rivertype.JobStateDiscarded,
+			lastError: &timedOut,
+			want:      internal.WebhookExpired,
+		},
+		{
+			loc:       exam.Here(),
+			name:      "Failed",
+			state:     rivertype.JobStateDiscarded,
+			lastError: &crashed,
+			want:      internal.WebhookFailed,
+		},
+	}
+	for _, tt := range tests {
+		e.Run(tt.name, func(e exam.E) {
+			e.Log("Running test at", tt.loc)
+			exam.Equal(e, env, tt.want, internal.TerminalWebhookStatus(tt.state, tt.lastError))
+		})
+	}
+}
+
+func TestWebhookNotificationStatus(t *testing.T) {
+	e := exam.New(t)
+	env := deep.NewEnv()
+
+	probeError := "not a video"
+	exam.Equal(e, env, internal.WebhookCompleted, internal.WebhookJobArgs{Status: &internal.InfoJobStatus{}}.NotificationStatus())
+	exam.Equal(e, env, internal.WebhookFailed, internal.WebhookJobArgs{Status: &internal.InfoJobStatus{Error: &probeError}}.NotificationStatus())
+	exam.Equal(e, env, internal.WebhookExpired, internal.WebhookJobArgs{Outcome: internal.WebhookExpired}.NotificationStatus())
+}
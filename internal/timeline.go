@@ -0,0 +1,248 @@
+package internal
+
+import (
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// JobTiming is when an info job was enqueued, last started and finished.
+// StartedAt and FinishedAt are nil until the job starts and finishes.
+type JobTiming struct {
+	UUID       uuid.UUID
+	Path       string
+	Queue      string
+	State      string
+	Attempt    int
+	Worker     string
+	Failed     bool
+	EnqueuedAt time.Time
+	StartedAt  *time.Time
+	FinishedAt *time.Time
+}
+
+// queuedUntil returns when the job stopped waiting in its queue, or now if
+// it hasn't started.
+func (t JobTiming) queuedUntil(now time.Time) time.Time {
+	if t.StartedAt != nil {
+		return *t.StartedAt
+	}
+	if t.FinishedAt != nil {
+		// Cancelled or discarded without being started
+		return *t.FinishedAt
+	}
+	return now
+}
+
+// finishedBy returns when the job finished, or now if it hasn't.
+func (t JobTiming) finishedBy(now time.Time) time.Time {
+	if t.FinishedAt != nil {
+		return *t.FinishedAt
+	}
+	return now
+}
+
+// timelineCSVHeader names the columns written by WriteTimelineCSV.
+var timelineCSVHeader = []string{
+	"uuid", "path", "queue", "state", "attempt", "worker", "failed",
+	"enqueued_at", "started_at", "finished_at", "queued_seconds", "running_seconds",
+}
+
+// WriteTimelineCSV writes timings to w as CSV, one row per job.  Times are
+// RFC 3339, and the times of jobs that haven't started or finished are left
+// empty.  Durations of unfinished phases are measured up to now.
+func WriteTimelineCSV(w io.Writer, timings []JobTiming, now time.Time) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(timelineCSVHeader); err != nil {
+		return err
+	}
+	for _, t := range timings {
+		running := ""
+		if t.StartedAt != nil {
+			running = formatSeconds(t.finishedBy(now).Sub(*t.StartedAt))
+		}
+		err := cw.Write([]string{
+			t.UUID.String(),
+			t.Path,
+			t.Queue,
+			t.State,
+			strconv.Itoa(t.Attempt),
+			t.Worker,
+			strconv.FormatBool(t.Failed),
+			t.EnqueuedAt.UTC().Format(time.RFC3339Nano),
+			formatOptionalTime(t.StartedAt),
+			formatOptionalTime(t.FinishedAt),
+			formatSeconds(t.queuedUntil(now).Sub(t.EnqueuedAt)),
+			running,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func formatOptionalTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339Nano)
+}
+
+func formatSeconds(d time.Duration) string {
+	return strconv.FormatFloat(d.Seconds(), 'f', 3, 64)
+}
+
+// OTLP JSON encoding of traces, as accepted by OTLP/HTTP collectors.  Only
+// the fields written by WriteTimelineOTLP are described.
+type (
+	otlpTraces struct {
+		ResourceSpans []otlpResourceSpans `json:"resourceSpans"`
+	}
+	otlpResourceSpans struct {
+		Resource   otlpResource     `json:"resource"`
+		ScopeSpans []otlpScopeSpans `json:"scopeSpans"`
+	}
+	otlpResource struct {
+		Attributes []otlpAttribute `json:"attributes"`
+	}
+	otlpScopeSpans struct {
+		Scope otlpScope  `json:"scope"`
+		Spans []otlpSpan `json:"spans"`
+	}
+	otlpScope struct {
+		Name string `json:"name"`
+	}
+	otlpSpan struct {
+		TraceID           string          `json:"traceId"`
+		SpanID            string          `json:"spanId"`
+		ParentSpanID      string          `json:"parentSpanId,omitempty"`
+		Name              string          `json:"name"`
+		Kind              int             `json:"kind"`
+		StartTimeUnixNano string          `json:"startTimeUnixNano"`
+		EndTimeUnixNano   string          `json:"endTimeUnixNano"`
+		Attributes        []otlpAttribute `json:"attributes,omitempty"`
+		Status            *otlpStatus     `json:"status,omitempty"`
+	}
+	otlpAttribute struct {
+		Key   string    `json:"key"`
+		Value otlpValue `json:"value"`
+	}
+	otlpValue struct {
+		StringValue *string `json:"stringValue,omitempty"`
+		IntValue    *string `json:"intValue,omitempty"`
+		BoolValue   *bool   `json:"boolValue,omitempty"`
+	}
+	otlpStatus struct {
+		Code int `json:"code"`
+	}
+)
+
+// OTLP span kind and status code values.
+const (
+	otlpSpanKindInternal = 1
+	otlpStatusCodeError  = 2
+)
+
+// Timeline span names.
+const (
+	timelineJobSpan     = "info job"
+	timelineQueuedSpan  = "queued"
+	timelineRunningSpan = "running"
+)
+
+func otlpString(key, value string) otlpAttribute {
+	return otlpAttribute{Key: key, Value: otlpValue{StringValue: &value}}
+}
+
+func otlpInt(key string, value int) otlpAttribute {
+	s := strconv.Itoa(value)
+	return otlpAttribute{Key: key, Value: otlpValue{IntValue: &s}}
+}
+
+func otlpBool(key string, value bool) otlpAttribute {
+	return otlpAttribute{Key: key, Value: otlpValue{BoolValue: &value}}
+}
+
+func otlpTime(t time.Time) string {
+	return strconv.FormatInt(t.UnixNano(), 10)
+}
+
+// timelineSpanID derives the ID of one of a job's spans from its UUID, so
+// exports of overlapping windows agree.  The UUID variant bits keep it from
+// being zero, which OTLP reserves.
+func timelineSpanID(id uuid.UUID, n byte) string {
+	spanID := append([]byte(nil), id[8:]...)
+	spanID[7] ^= n
+	return hex.EncodeToString(spanID)
+}
+
+// WriteTimelineOTLP writes timings to w as an OTLP JSON trace export.  Each
+// job is a trace, identified by its UUID, with a root span for its whole
+// life and queued and running child spans.  Phases that haven't finished
+// end at now.
+func WriteTimelineOTLP(w io.Writer, timings []JobTiming, now time.Time) error {
+	spans := make([]otlpSpan, 0, 3*len(timings))
+	for _, t := range timings {
+		traceID := hex.EncodeToString(t.UUID[:])
+		rootID := timelineSpanID(t.UUID, 0)
+		root := otlpSpan{
+			TraceID:           traceID,
+			SpanID:            rootID,
+			Name:              timelineJobSpan,
+			Kind:              otlpSpanKindInternal,
+			StartTimeUnixNano: otlpTime(t.EnqueuedAt),
+			EndTimeUnixNano:   otlpTime(t.finishedBy(now)),
+			Attributes: []otlpAttribute{
+				otlpString("video_info.uuid", t.UUID.String()),
+				otlpString("video_info.path", t.Path),
+				otlpString("video_info.queue", t.Queue),
+				otlpString("video_info.state", t.State),
+				otlpInt("video_info.attempt", t.Attempt),
+				otlpBool("video_info.failed", t.Failed),
+			},
+		}
+		if t.Failed {
+			root.Status = &otlpStatus{Code: otlpStatusCodeError}
+		}
+		spans = append(spans, root, otlpSpan{
+			TraceID:           traceID,
+			SpanID:            timelineSpanID(t.UUID, 1),
+			ParentSpanID:      rootID,
+			Name:              timelineQueuedSpan,
+			Kind:              otlpSpanKindInternal,
+			StartTimeUnixNano: otlpTime(t.EnqueuedAt),
+			EndTimeUnixNano:   otlpTime(t.queuedUntil(now)),
+		})
+		if t.StartedAt != nil {
+			running := otlpSpan{
+				TraceID:           traceID,
+				SpanID:            timelineSpanID(t.UUID, 2),
+				ParentSpanID:      rootID,
+				Name:              timelineRunningSpan,
+				Kind:              otlpSpanKindInternal,
+				StartTimeUnixNano: otlpTime(*t.StartedAt),
+				EndTimeUnixNano:   otlpTime(t.finishedBy(now)),
+			}
+			if t.Worker != "" {
+				running.Attributes = []otlpAttribute{otlpString("video_info.worker", t.Worker)}
+			}
+			spans = append(spans, running)
+		}
+	}
+
+	traces := otlpTraces{ResourceSpans: []otlpResourceSpans{{
+		Resource: otlpResource{Attributes: []otlpAttribute{otlpString("service.name", "video-info")}},
+		ScopeSpans: []otlpScopeSpans{{
+			Scope: otlpScope{Name: "github.com/krelinga/video-info/timeline"},
+			Spans: spans,
+		}},
+	}}}
+	return json.NewEncoder(w).Encode(traces)
+}
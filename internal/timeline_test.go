@@ -0,0 +1,103 @@
+package internal_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/krelinga/go-libs/deep"
+	"github.com/krelinga/go-libs/exam"
+	"github.com/krelinga/video-info/internal"
+)
+
+func TestTimeline(t *testing.T) {
+	e := exam.New(t)
+	env := deep.NewEnv()
+
+	base := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	started := base.Add(2 * time.Second)
+	finished := base.Add(5 * time.Second)
+	now := base.Add(time.Minute)
+	finishedID := uuid.MustParse("6f1c5d2e-8a3b-4c7d-9e0f-112233445566")
+	queuedID := uuid.MustParse("0a1b2c3d-4e5f-4a6b-8c7d-8e9fa0b1c2d3")
+	timings := []internal.JobTiming{
+		{
+			UUID:       finishedID,
+			Path:       "/videos/a.mkv",
+			Queue:      "default",
+			State:      "discarded",
+			Attempt:    3,
+			Worker:     "worker-1",
+			Failed:     true,
+			EnqueuedAt: base,
+			StartedAt:  &started,
+			FinishedAt: &finished,
+		},
+		{
+			UUID:       queuedID,
+			Path:       "/videos/b.mkv",
+			Queue:      "default",
+			State:      "available",
+			EnqueuedAt: base,
+		},
+	}
+
+	e.Run("CSV", func(e exam.E) {
+		var buf bytes.Buffer
+		exam.Nil(e, env, internal.WriteTimelineCSV(&buf, timings, now))
+		exam.Equal(e, env, "uuid,path,queue,state,attempt,worker,failed,enqueued_at,started_at,finished_at,queued_seconds,running_seconds\n"+
+			"6f1c5d2e-8a3b-4c7d-9e0f-112233445566,/videos/a.mkv,default,discarded,3,worker-1,true,2025-01-02T03:04:05Z,2025-01-02T03:04:07Z,2025-01-02T03:04:10Z,2.000,3.000\n"+
+			"0a1b2c3d-4e5f-4a6b-8c7d-8e9fa0b1c2d3,/videos/b.mkv,default,available,0,,false,2025-01-02T03:04:05Z,,,60.000,\n",
+			buf.String())
+	})
+
+	e.Run("OTLP", func(e exam.E) {
+		var buf bytes.Buffer
+		exam.Nil(e, env, internal.WriteTimelineOTLP(&buf, timings, now))
+		var traces struct {
+			ResourceSpans []struct {
+				ScopeSpans []struct {
+					Spans []struct {
+						TraceID           string `json:"traceId"`
+						SpanID            string `json:"spanId"`
+						ParentSpanID      string `json:"parentSpanId"`
+						Name              string `json:"name"`
+						StartTimeUnixNano string `json:"startTimeUnixNano"`
+						EndTimeUnixNano   string `json:"endTimeUnixNano"`
+						Status            *struct {
+							Code int `json:"code"`
+						} `json:"status"`
+					} `json:"spans"`
+				} `json:"scopeSpans"`
+			} `json:"resourceSpans"`
+		}
+		exam.Nil(e, env, json.Unmarshal(buf.Bytes(), &traces))
+		spans := traces.ResourceSpans[0].ScopeSpans[0].Spans
+
+		// The finished job has all three spans, the queued one no running span
+		var names []string
+		for _, span := range spans {
+			names = append(names, span.Name)
+		}
+		exam.Equal(e, env, []string{"info job", "queued", "running", "info job", "queued"}, names)
+
+		root, queued, running := spans[0], spans[1], spans[2]
+		exam.Equal(e, env, "6f1c5d2e8a3b4c7d9e0f112233445566", root.TraceID)
+		exam.Equal(e, env, "9e0f112233445566", root.SpanID)
+		exam.Equal(e, env, root.SpanID, queued.ParentSpanID)
+		exam.Equal(e, env, root.SpanID, running.ParentSpanID)
+		exam.Equal(e, env, "9e0f112233445567", queued.SpanID)
+		exam.Equal(e, env, "9e0f112233445564", running.SpanID)
+		exam.Equal(e, env, 2, root.Status.Code)
+		exam.Equal(e, env, "1735787047000000000", running.StartTimeUnixNano)
+		exam.Equal(e, env, "1735787050000000000", running.EndTimeUnixNano)
+
+		// Unfinished phases end now
+		exam.Equal(e, env, "1735787105000000000", spans[4].EndTimeUnixNano)
+		if spans[3].Status != nil {
+			e.Fatalf("queued job has status %v", spans[3].Status)
+		}
+	})
+}
@@ -0,0 +1,160 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"slices"
+	"strings"
+	"syscall"
+	"time"
+)
+
+var ErrURLNotAllowed = errors.New("URL not allowed")
+
+// URLPolicy restricts which URLs the service is willing to contact on behalf
+// of a caller, such as webhook targets.  The zero value allows any http or
+// https URL.
+type URLPolicy struct {
+	// AllowedHosts, if non-empty, restricts URLs to these host names.
+	// A leading "*." matches any subdomain of the given domain.
+	AllowedHosts []string
+
+	// AllowedNets, if non-empty, restricts URLs whose host is an IP literal
+	// to addresses within these networks.
+	AllowedNets []*net.IPNet
+
+	// DenyPrivate rejects URLs that resolve to loopback, private, link-local
+	// or otherwise non-public addresses.
+	DenyPrivate bool
+}
+
+// Check validates u against the policy, resolving the host name if required.
+func (p *URLPolicy) Check(ctx context.Context, u *url.URL) error {
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("%w: unsupported scheme %q", ErrURLNotAllowed, u.Scheme)
+	}
+	return p.CheckHost(ctx, u.Hostname())
+}
+
+// CheckHost validates a host name or IP literal against the policy,
+// resolving the host name if required.
+func (p *URLPolicy) CheckHost(ctx context.Context, host string) error {
+	if host == "" {
+		return fmt.Errorf("%w: missing host", ErrURLNotAllowed)
+	}
+	if p == nil {
+		return nil
+	}
+
+	ip := net.ParseIP(host)
+	if len(p.AllowedHosts) > 0 || len(p.AllowedNets) > 0 {
+		if !p.hostAllowed(host, ip) {
+			return fmt.Errorf("%w: host %q is not in the allowlist", ErrURLNotAllowed, host)
+		}
+	}
+
+	if !p.DenyPrivate {
+		return nil
+	}
+	ips := []net.IP{ip}
+	if ip == nil {
+		addrs, err := net.DefaultResolver.LookupIPAddr(ctx, host)
+		if err != nil {
+			return fmt.Errorf("%w: failed to resolve host %q: %v", ErrURLNotAllowed, host, err)
+		}
+		ips = ips[:0]
+		for _, addr := range addrs {
+			ips = append(ips, addr.IP)
+		}
+	}
+	for _, ip := range ips {
+		if !isPublicIP(ip) {
+			return fmt.Errorf("%w: host %q resolves to non-public address %s", ErrURLNotAllowed, host, ip)
+		}
+	}
+	return nil
+}
+
+// CheckString parses rawURL and validates it against the policy.
+func (p *URLPolicy) CheckString(ctx context.Context, rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		// The parse error quotes the URL, which may embed credentials
+		return fmt.Errorf("%w: %s", ErrURLNotAllowed, Redact(err.Error()))
+	}
+	return p.Check(ctx, u)
+}
+
+func (p *URLPolicy) hostAllowed(host string, ip net.IP) bool {
+	if ip != nil {
+		return slices.ContainsFunc(p.AllowedNets, func(n *net.IPNet) bool {
+			return n.Contains(ip)
+		})
+	}
+	host = strings.ToLower(host)
+	return slices.ContainsFunc(p.AllowedHosts, func(allowed string) bool {
+		allowed = strings.ToLower(allowed)
+		if suffix, ok := strings.CutPrefix(allowed, "*"); ok {
+			return strings.HasSuffix(host, suffix)
+		}
+		return host == allowed
+	})
+}
+
+// DialContext connects to address like a net.Dialer with the given
+// timeout, refusing non-public addresses at connect time if the policy
+// denies private addresses.
+func (p *URLPolicy) DialContext(ctx context.Context, timeout time.Duration, network, address string) (net.Conn, error) {
+	dialer := &net.Dialer{Timeout: timeout, KeepAlive: 30 * time.Second}
+	if p != nil && p.DenyPrivate {
+		dialer.Control = p.dialControl
+	}
+	return dialer.DialContext(ctx, network, address)
+}
+
+// dialControl is used as a net.Dialer Control function to reject connections
+// to non-public addresses at connect time.  This guards against DNS records
+// that change between validation and delivery.
+func (p *URLPolicy) dialControl(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	ip := net.ParseIP(host)
+	if ip == nil || !isPublicIP(ip) {
+		return fmt.Errorf("%w: connection to non-public address %s", ErrURLNotAllowed, host)
+	}
+	return nil
+}
+
+// nonPublicNets are the ranges that aren't publicly routable but that the
+// predicates of net.IP don't cover.
+var nonPublicNets = []*net.IPNet{
+	mustParseCIDR("0.0.0.0/8"),     // "This network"
+	mustParseCIDR("100.64.0.0/10"), // Carrier-grade NAT, used by cloud and cluster networks
+	mustParseCIDR("198.18.0.0/15"), // Benchmarking
+}
+
+func mustParseCIDR(s string) *net.IPNet {
+	_, n, err := net.ParseCIDR(s)
+	if err != nil {
+		panic(err)
+	}
+	return n
+}
+
+func isPublicIP(ip net.IP) bool {
+	if slices.ContainsFunc(nonPublicNets, func(n *net.IPNet) bool { return n.Contains(ip) }) {
+		return false
+	}
+	return !(ip.IsLoopback() ||
+		ip.IsPrivate() ||
+		ip.IsLinkLocalUnicast() ||
+		ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() ||
+		ip.IsMulticast() ||
+		ip.IsUnspecified())
+}
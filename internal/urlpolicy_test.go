@@ -0,0 +1,146 @@
+package internal_test
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/krelinga/go-libs/deep"
+	"github.com/krelinga/go-libs/exam"
+	"github.com/krelinga/go-libs/match"
+	"github.com/krelinga/video-info/internal"
+)
+
+func TestURLPolicy(t *testing.T) {
+	e := exam.New(t)
+	env := deep.NewEnv()
+
+	_, tenNet, _ := net.ParseCIDR("10.0.0.0/8")
+
+	tests := []struct {
+		loc     exam.Loc
+		name    string
+		policy  *internal.URLPolicy
+		url     string
+		wantErr bool
+	}{
+		{
+			loc:    exam.Here(),
+			name:   "Nil policy allows any http URL",
+			policy: nil,
+			url:    "http://127.0.0.1/webhook",
+		},
+		{
+			loc:     exam.Here(),
+			name:    "Nil policy rejects non-http scheme",
+			policy:  nil,
+			url:     "file:///etc/passwd",
+			wantErr: true,
+		},
+		{
+			loc:     exam.Here(),
+			name:    "Missing host",
+			policy:  nil,
+			url:     "http:///webhook",
+			wantErr: true,
+		},
+		{
+			loc:    exam.Here(),
+			name:   "Allowed host",
+			policy: &internal.URLPolicy{AllowedHosts: []string{"hooks.example.com"}},
+			url:    "https://hooks.example.com/webhook",
+		},
+		{
+			loc:    exam.Here(),
+			name:   "Allowed wildcard host",
+			policy: &internal.URLPolicy{AllowedHosts: []string{"*.example.com"}},
+			url:    "https://a.b.example.com/webhook",
+		},
+		{
+			loc:     exam.Here(),
+			name:    "Host not in allowlist",
+			policy:  &internal.URLPolicy{AllowedHosts: []string{"hooks.example.com"}},
+			url:     "https://evil.example.org/webhook",
+			wantErr: true,
+		},
+		{
+			loc:    exam.Here(),
+			name:   "IP in allowed network",
+			policy: &internal.URLPolicy{AllowedNets: []*net.IPNet{tenNet}},
+			url:    "http://10.1.2.3:8080/webhook",
+		},
+		{
+			loc:     exam.Here(),
+			name:    "IP outside allowed network",
+			policy:  &internal.URLPolicy{AllowedNets: []*net.IPNet{tenNet}},
+			url:     "http://192.168.1.1/webhook",
+			wantErr: true,
+		},
+		{
+			loc:     exam.Here(),
+			name:    "Deny private rejects loopback",
+			policy:  &internal.URLPolicy{DenyPrivate: true},
+			url:     "http://127.0.0.1/webhook",
+			wantErr: true,
+		},
+		{
+			loc:     exam.Here(),
+			name:    "Deny private rejects link-local metadata address",
+			policy:  &internal.URLPolicy{DenyPrivate: true},
+			url:     "http://169.254.169.254/latest/meta-data",
+			wantErr: true,
+		},
+		{
+			loc:     exam.Here(),
+			name:    "Deny private rejects carrier-grade NAT address",
+			policy:  &internal.URLPolicy{DenyPrivate: true},
+			url:     "http://100.100.100.200/latest/meta-data",
+			wantErr: true,
+		},
+		{
+			loc:     exam.Here(),
+			name:    "Deny private rejects IPv4-mapped carrier-grade NAT address",
+			policy:  &internal.URLPolicy{DenyPrivate: true},
+			url:     "http://[::ffff:100.64.0.1]/webhook",
+			wantErr: true,
+		},
+		{
+			loc:     exam.Here(),
+			name:    "Deny private rejects this-network address",
+			policy:  &internal.URLPolicy{DenyPrivate: true},
+			url:     "http://0.1.2.3/webhook",
+			wantErr: true,
+		},
+		{
+			loc:     exam.Here(),
+			name:    "Deny private rejects benchmarking address",
+			policy:  &internal.URLPolicy{DenyPrivate: true},
+			url:     "http://198.19.255.1/webhook",
+			wantErr: true,
+		},
+		{
+			loc:    exam.Here(),
+			name:   "Deny private allows address just past carrier-grade NAT",
+			policy: &internal.URLPolicy{DenyPrivate: true},
+			url:    "http://100.128.0.1/webhook",
+		},
+		{
+			loc:    exam.Here(),
+			name:   "Deny private allows public IP",
+			policy: &internal.URLPolicy{DenyPrivate: true},
+			url:    "https://203.0.113.10/webhook",
+		},
+	}
+	for _, tt := range tests {
+		e.Run(tt.name, func(e exam.E) {
+			e.Log("Running test at", tt.loc)
+
+			err := tt.policy.CheckString(context.Background(), tt.url)
+			if tt.wantErr {
+				exam.Match(e, env, err, match.ErrorIs(internal.ErrURLNotAllowed))
+			} else {
+				exam.Nil(e, env, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,147 @@
+package internal
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/krelinga/video-info/virest"
+)
+
+// MaxDecodeErrorExcerptLines bounds the decoder output kept with a
+// DecodeVerification.
+const MaxDecodeErrorExcerptLines = 20
+
+// DecodeVerification is the outcome of decoding every video and audio stream
+// of a file in full, looking for corruption.
+type DecodeVerification struct {
+	// ErrorCount is the number of errors ffmpeg logged while decoding.
+	ErrorCount int `json:"error_count"`
+
+	// FirstErrorSeconds is roughly where in the file the first error was
+	// logged, if any were and ffmpeg had reported its progress by then.
+	FirstErrorSeconds *float64 `json:"first_error_seconds,omitempty"`
+
+	// Excerpt holds the first errors ffmpeg logged.
+	Excerpt string `json:"excerpt,omitempty"`
+
+	// DecodedSeconds is how far into the file decoding got.
+	DecodedSeconds float64 `json:"decoded_seconds"`
+
+	// Complete is false if ffmpeg gave up before the end of the file.
+	Complete bool `json:"complete"`
+}
+
+// progressPattern matches the key=value lines ffmpeg writes with -progress.
+var progressPattern = regexp.MustCompile(`^\w+=\S*$`)
+
+// logLinePattern matches a message ffmpeg logged with the level flag set, as
+// with -loglevel level+info: the contexts it was logged from, its level in
+// brackets, and the message itself.
+var logLinePattern = regexp.MustCompile(`^((?:\[[^\]]+ @ [^\]]+\] )*)\[(\w+)\] (.*)$`)
+
+// parseLogError returns the message ffmpeg logged in line, with its contexts
+// but without its level, if it was logged at error level or above.
+func parseLogError(line string) (string, bool) {
+	match := logLinePattern.FindStringSubmatch(line)
+	if match == nil {
+		return "", false
+	}
+	switch match[2] {
+	case "error", "fatal", "panic":
+		return match[1] + match[3], true
+	}
+	return "", false
+}
+
+// LastFFmpegError returns the last error ffmpeg logged in output, which was
+// written with the level flag set, or "" if there is none.  It says why
+// ffmpeg failed.
+func LastFFmpegError(output string) string {
+	var last string
+	for line := range strings.Lines(output) {
+		if message, ok := parseLogError(strings.TrimSpace(line)); ok {
+			last = message
+		}
+	}
+	return last
+}
+
+// ParseDecodeErrors builds a DecodeVerification from the output of ffmpeg
+// run with the level flag set and -progress writing to the same stream, so
+// that each error follows the progress reported before it.  Messages logged
+// below error level, such as the output of analysis filters sharing the run,
+// are ignored, as are lines that aren't log messages.  complete reports
+// whether ffmpeg exited successfully.
+func ParseDecodeErrors(output string, complete bool) *DecodeVerification {
+	v := &DecodeVerification{Complete: complete}
+	var (
+		position    float64
+		hasPosition bool
+		excerpt     []string
+	)
+	for line := range strings.Lines(output) {
+		line = strings.TrimSpace(line)
+		if progressPattern.MatchString(line) {
+			if value, ok := strings.CutPrefix(line, "out_time_us="); ok {
+				if us, err := strconv.ParseInt(value, 10, 64); err == nil && us >= 0 {
+					position = float64(us) / 1e6
+					hasPosition = true
+				}
+			}
+			continue
+		}
+		message, ok := parseLogError(line)
+		if !ok {
+			continue
+		}
+		if v.ErrorCount == 0 && hasPosition {
+			first := position
+			v.FirstErrorSeconds = &first
+		}
+		v.ErrorCount++
+		if len(excerpt) < MaxDecodeErrorExcerptLines {
+			excerpt = append(excerpt, message)
+		}
+	}
+	v.DecodedSeconds = position
+	v.Excerpt = strings.Join(excerpt, "\n")
+	return v
+}
+
+// RESTDecodeVerification converts the verification to its REST form, or nil
+// if v is nil.
+func (v *DecodeVerification) RESTDecodeVerification() *virest.DecodeVerification {
+	if v == nil {
+		return nil
+	}
+	var excerpt *string
+	if v.Excerpt != "" {
+		excerpt = &v.Excerpt
+	}
+	return &virest.DecodeVerification{
+		ErrorCount:        v.ErrorCount,
+		FirstErrorSeconds: v.FirstErrorSeconds,
+		Excerpt:           excerpt,
+		DecodedSeconds:    v.DecodedSeconds,
+		Complete:          v.Complete,
+	}
+}
+
+// NewDecodeVerificationFromREST converts a REST DecodeVerification, or
+// returns nil if v is nil.
+func NewDecodeVerificationFromREST(v *virest.DecodeVerification) *DecodeVerification {
+	if v == nil {
+		return nil
+	}
+	verification := &DecodeVerification{
+		ErrorCount:        v.ErrorCount,
+		FirstErrorSeconds: v.FirstErrorSeconds,
+		DecodedSeconds:    v.DecodedSeconds,
+		Complete:          v.Complete,
+	}
+	if v.Excerpt != nil {
+		verification.Excerpt = *v.Excerpt
+	}
+	return verification
+}
@@ -0,0 +1,104 @@
+package internal_test
+
+import (
+	"testing"
+
+	"github.com/krelinga/go-libs/deep"
+	"github.com/krelinga/go-libs/exam"
+	"github.com/krelinga/video-info/internal"
+)
+
+func TestParseDecodeErrors(t *testing.T) {
+	e := exam.New(t)
+	env := deep.NewEnv()
+
+	seconds := func(v float64) *float64 { return &v }
+
+	tests := []struct {
+		loc      exam.Loc
+		name     string
+		output   string
+		complete bool
+		want     *internal.DecodeVerification
+	}{
+		{
+			loc:  exam.Here(),
+			name: "Clean file",
+			output: "frame=240\nout_time_us=10000000\nprogress=continue\n" +
+				"frame=480\nout_time_us=20000000\nprogress=end\n",
+			complete: true,
+			want:     &internal.DecodeVerification{DecodedSeconds: 20, Complete: true},
+		},
+		{
+			loc:  exam.Here(),
+			name: "Errors placed after progress",
+			output: "out_time_us=1500000\nprogress=continue\n" +
+				"[h264 @ 0x55d0] [error] error while decoding MB 53 20, bytestream -7\n" +
+				"[h264 @ 0x55d0] [error] concealing 1200 DC, 1200 AC, 1200 MV errors in P frame\n" +
+				"out_time_us=3000000\nprogress=end\n",
+			complete: true,
+			want: &internal.DecodeVerification{
+				ErrorCount:        2,
+				FirstErrorSeconds: seconds(1.5),
+				Excerpt: "[h264 @ 0x55d0] error while decoding MB 53 20, bytestream -7\n" +
+					"[h264 @ 0x55d0] concealing 1200 DC, 1200 AC, 1200 MV errors in P frame",
+				DecodedSeconds: 3,
+				Complete:       true,
+			},
+		},
+		{
+			loc:      exam.Here(),
+			name:     "Error before any progress",
+			output:   "[in#0 @ 0x55d0] [error] Error opening input: Invalid data found when processing input\n",
+			complete: false,
+			want: &internal.DecodeVerification{
+				ErrorCount: 1,
+				Excerpt:    "[in#0 @ 0x55d0] Error opening input: Invalid data found when processing input",
+			},
+		},
+		{
+			loc:      exam.Here(),
+			name:     "Unknown position ignored",
+			output:   "out_time_us=N/A\n[aac @ 0x55d0] [error] Input buffer exhausted before END element found\n",
+			complete: true,
+			want: &internal.DecodeVerification{
+				ErrorCount: 1,
+				Excerpt:    "[aac @ 0x55d0] Input buffer exhausted before END element found",
+				Complete:   true,
+			},
+		},
+		{
+			loc:  exam.Here(),
+			name: "Other messages ignored",
+			output: "[info] Input #0, matroska,webm, from '/nas/movie.mkv':\n" +
+				"[matroska,webm @ 0x55c0] [warning] Could not find codec parameters for stream 3\n" +
+				"out_time_us=2000000\nprogress=continue\n" +
+				"[Parsed_loudnorm_0 @ 0x55e0] [info] \n{\n\t\"input_i\" : \"-23.61\",\n\t\"target_offset\" : \"0.00\"\n}\n" +
+				"[fatal] Conversion failed!\n",
+			want: &internal.DecodeVerification{
+				ErrorCount:        1,
+				FirstErrorSeconds: seconds(2),
+				Excerpt:           "Conversion failed!",
+				DecodedSeconds:    2,
+			},
+		},
+	}
+	for _, tt := range tests {
+		e.Run(tt.name, func(e exam.E) {
+			e.Log("Running test at", tt.loc)
+			exam.Equal(e, env, tt.want, internal.ParseDecodeErrors(tt.output, tt.complete))
+		})
+	}
+}
+
+func TestLastFFmpegError(t *testing.T) {
+	e := exam.New(t)
+	env := deep.NewEnv()
+
+	output := "[h264 @ 0x55d0] [error] error while decoding MB 53 20, bytestream -7\n" +
+		"out_time_us=3000000\n" +
+		"[vost#0:0/wrapped_avframe @ 0x55e0] [error] Error while filtering: Cannot allocate memory\n" +
+		"[out#0/null @ 0x55f0] [info] video:0KiB audio:0KiB\n"
+	exam.Equal(e, env, "[vost#0:0/wrapped_avframe @ 0x55e0] Error while filtering: Cannot allocate memory", internal.LastFFmpegError(output))
+	exam.Equal(e, env, "", internal.LastFFmpegError("out_time_us=3000000\nprogress=end\n"))
+}
@@ -0,0 +1,67 @@
+package internal
+
+import (
+	"runtime/debug"
+	"strings"
+
+	"github.com/krelinga/video-info/virest"
+)
+
+// unknownVersion stands in for versions that can't be determined.
+const unknownVersion = "unknown"
+
+// ToolVersions records the versions of the worker and the tools it ran when
+// it produced a result, so results affected by an upgrade can be found.
+type ToolVersions struct {
+	Worker  string `json:"worker"`
+	FFprobe string `json:"ffprobe"`
+	FFmpeg  string `json:"ffmpeg"`
+}
+
+// RESTToolVersions converts v to its REST representation, or returns nil if
+// v is nil.
+func (v *ToolVersions) RESTToolVersions() *virest.ToolVersions {
+	if v == nil {
+		return nil
+	}
+	return &virest.ToolVersions{Worker: v.Worker, Ffprobe: v.FFprobe, Ffmpeg: v.FFmpeg}
+}
+
+// NewToolVersionsFromREST converts REST ToolVersions, or returns nil if v is
+// nil.
+func NewToolVersionsFromREST(v *virest.ToolVersions) *ToolVersions {
+	if v == nil {
+		return nil
+	}
+	return &ToolVersions{Worker: v.Worker, FFprobe: v.Ffprobe, FFmpeg: v.Ffmpeg}
+}
+
+// BuildVersion returns the version of the running binary: its module version
+// if it was built from a tagged release, otherwise the revision it was built
+// from, or "unknown".
+func BuildVersion() string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return unknownVersion
+	}
+	if info.Main.Version != "" && info.Main.Version != "(devel)" {
+		return info.Main.Version
+	}
+	for _, setting := range info.Settings {
+		if setting.Key == "vcs.revision" {
+			return setting.Value
+		}
+	}
+	return unknownVersion
+}
+
+// ParseToolVersion returns the version in the output of ffprobe -version or
+// ffmpeg -version, or "unknown" if it has none.
+func ParseToolVersion(output string) string {
+	// The first line looks like "ffprobe version 5.1.6-0+deb12u1 Copyright ..."
+	fields := strings.Fields(output)
+	if len(fields) < 3 || fields[1] != "version" {
+		return unknownVersion
+	}
+	return fields[2]
+}
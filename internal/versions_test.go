@@ -0,0 +1,69 @@
+package internal_test
+
+import (
+	"testing"
+
+	"github.com/krelinga/go-libs/deep"
+	"github.com/krelinga/go-libs/exam"
+	"github.com/krelinga/video-info/internal"
+)
+
+func TestParseToolVersion(t *testing.T) {
+	e := exam.New(t)
+	env := deep.NewEnv()
+
+	tests := []struct {
+		loc    exam.Loc
+		name   string
+		output string
+		want   string
+	}{
+		{
+			loc:    exam.Here(),
+			name:   "ffprobe",
+			output: "ffprobe version 5.1.6-0+deb12u1 Copyright (c) 2007-2024 the FFmpeg developers\nbuilt with gcc 12\n",
+			want:   "5.1.6-0+deb12u1",
+		},
+		{
+			loc:    exam.Here(),
+			name:   "ffmpeg",
+			output: "ffmpeg version n7.1 Copyright (c) 2000-2024 the FFmpeg developers\n",
+			want:   "n7.1",
+		},
+		{
+			loc:    exam.Here(),
+			name:   "Unrecognized",
+			output: "usage: ffprobe [options]\n",
+			want:   "unknown",
+		},
+		{
+			loc:    exam.Here(),
+			name:   "Empty",
+			output: "",
+			want:   "unknown",
+		},
+	}
+	for _, tt := range tests {
+		e.Run(tt.name, func(e exam.E) {
+			e.Log("Running test at", tt.loc)
+			exam.Equal(e, env, tt.want, internal.ParseToolVersion(tt.output))
+		})
+	}
+}
+
+func TestToolVersionsREST(t *testing.T) {
+	e := exam.New(t)
+	env := deep.NewEnv()
+
+	result := &internal.InfoJobResult{
+		ChapterDurationsSeconds: []float64{},
+		Versions:                &internal.ToolVersions{Worker: "v1.4.0", FFprobe: "5.1.6", FFmpeg: "5.1.6"},
+	}
+	rest := result.RESTMediaInfo()
+	exam.Equal(e, env, "v1.4.0", rest.Versions.Worker)
+	exam.Equal(e, env, "5.1.6", rest.Versions.Ffprobe)
+	exam.Equal(e, env, result.Versions, internal.NewInfoJobResult(rest).Versions)
+
+	// Results recorded before versions were have none
+	exam.Equal(e, env, true, (&internal.InfoJobResult{}).RESTMediaInfo().Versions == nil)
+}
@@ -0,0 +1,194 @@
+package internal
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/riverqueue/river"
+	"github.com/riverqueue/river/rivertype"
+)
+
+// Defaults and bounds for WebhookBatching fields.
+const (
+	DefaultWebhookBatchSize        = 100
+	MaxWebhookBatchSize            = 1000
+	DefaultWebhookBatchWaitSeconds = 10
+	MaxWebhookBatchWaitSeconds     = 5 * 60
+)
+
+var ErrInvalidWebhookBatch = errors.New("invalid webhook batching")
+
+// WebhookBatching collects the webhooks of many info jobs into fewer
+// deliveries.  Webhook jobs with the same Key are delivered together, each
+// delivery holding up to MaxSize notifications and waiting up to
+// MaxWaitSeconds for them to accumulate.
+type WebhookBatching struct {
+	// Key identifies the webhook URI, token and headers, as returned by
+	// WebhookBatchKey.
+	Key string `json:"key"`
+
+	MaxSize        int `json:"max_size"`
+	MaxWaitSeconds int `json:"max_wait_seconds"`
+}
+
+// NewWebhookBatching returns the batching of webhooks with the given
+// secrets.  Zero sizes and waits take their defaults; others must be within
+// bounds.
+func NewWebhookBatching(keyring *Keyring, secrets WebhookSecrets, maxSize, maxWaitSeconds int) (*WebhookBatching, error) {
+	if maxSize == 0 {
+		maxSize = DefaultWebhookBatchSize
+	}
+	if maxWaitSeconds == 0 {
+		maxWaitSeconds = DefaultWebhookBatchWaitSeconds
+	}
+	if maxSize < 1 || maxSize > MaxWebhookBatchSize {
+		return nil, fmt.Errorf("%w: max size must be between 1 and %d", ErrInvalidWebhookBatch, MaxWebhookBatchSize)
+	}
+	if maxWaitSeconds < 1 || maxWaitSeconds > MaxWebhookBatchWaitSeconds {
+		return nil, fmt.Errorf("%w: max wait must be between 1 and %d seconds", ErrInvalidWebhookBatch, MaxWebhookBatchWaitSeconds)
+	}
+	return &WebhookBatching{
+		Key:            WebhookBatchKey(keyring, secrets),
+		MaxSize:        maxSize,
+		MaxWaitSeconds: maxWaitSeconds,
+	}, nil
+}
+
+// WebhookBatchKey identifies webhooks with the given secrets, which may be
+// delivered together.  It is computed before the secrets are sealed, and
+// doesn't reveal them.
+func WebhookBatchKey(keyring *Keyring, secrets WebhookSecrets) string {
+	data := append([]byte(secrets.URI), 0)
+	data = append(data, secrets.Token...)
+	// Webhooks without headers keep the keys they had before headers existed
+	for _, name := range slices.Sorted(maps.Keys(secrets.Headers)) {
+		data = append(data, 0)
+		data = append(data, name...)
+		data = append(data, 0)
+		data = append(data, secrets.Headers[name]...)
+	}
+	return hex.EncodeToString(keyring.Fingerprint(data))
+}
+
+// MaxWait returns how long a webhook waits for others to batch with.
+func (b WebhookBatching) MaxWait() time.Duration {
+	return time.Duration(b.MaxWaitSeconds) * time.Second
+}
+
+// webhookInserter inserts jobs, as River clients do.
+type webhookInserter interface {
+	InsertTx(ctx context.Context, tx pgx.Tx, args river.JobArgs, opts *river.InsertOpts) (*rivertype.JobInsertResult, error)
+}
+
+// EnqueueWebhook inserts a webhook job with args.  A batched webhook is
+// scheduled to run once its batch has had time to fill, and is recorded in
+// the webhook_batch_item table so another job in its batch can deliver it
+// sooner.
+func EnqueueWebhook(ctx context.Context, tx pgx.Tx, client webhookInserter, args WebhookJobArgs, now time.Time) error {
+	if args.Batch == nil {
+		if _, err := client.InsertTx(ctx, tx, args, nil); err != nil {
+			return fmt.Errorf("failed to enqueue webhook job: %w", err)
+		}
+		return nil
+	}
+
+	inserted, err := client.InsertTx(ctx, tx, args, &river.InsertOpts{ScheduledAt: now.Add(args.Batch.MaxWait())})
+	if err != nil {
+		return fmt.Errorf("failed to enqueue webhook job: %w", err)
+	}
+	_, err = tx.Exec(ctx, "INSERT INTO webhook_batch_item (river_job_id, batch_key) VALUES ($1, $2)",
+		inserted.Job.ID, args.Batch.Key)
+	if err != nil {
+		return fmt.Errorf("failed to insert webhook batch item: %w", err)
+	}
+	return nil
+}
+
+// WebhookBatchItem is a webhook job waiting to be delivered in a batch.
+type WebhookBatchItem struct {
+	JobID int64
+	Args  WebhookJobArgs
+}
+
+// LockWebhookBatchItem locks the batch item of the webhook job with the
+// given ID.  If another job in its batch has delivered it, it returns when
+// and to which target.  It returns nil if the webhook is undelivered or has
+// no batch item.
+func LockWebhookBatchItem(ctx context.Context, tx pgx.Tx, jobID int64) (*time.Time, string, error) {
+	var (
+		deliveredAt *time.Time
+		target      *string
+	)
+	err := tx.QueryRow(ctx, `
+		SELECT delivered_at, target FROM webhook_batch_item
+		WHERE river_job_id = $1
+		FOR UPDATE`, jobID).Scan(&deliveredAt, &target)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, "", nil
+	} else if err != nil {
+		return nil, "", fmt.Errorf("failed to lock webhook batch item: %w", err)
+	}
+	if deliveredAt == nil || target == nil {
+		return deliveredAt, "", nil
+	}
+	return deliveredAt, *target, nil
+}
+
+// ClaimWebhookBatch locks up to limit undelivered webhooks in the batch with
+// the given key, other than that of the job with the given ID, in the order
+// they were enqueued.  Webhooks locked by another delivery are skipped.
+func ClaimWebhookBatch(ctx context.Context, tx pgx.Tx, jobID int64, key string, limit int) ([]WebhookBatchItem, error) {
+	rows, err := tx.Query(ctx, `
+		SELECT i.river_job_id, j.args
+		FROM webhook_batch_item i
+		JOIN river_job j ON j.id = i.river_job_id
+		WHERE i.batch_key = $1
+			AND i.delivered_at IS NULL
+			AND i.river_job_id <> $2
+			AND j.state IN ('available', 'retryable', 'scheduled')
+		ORDER BY i.river_job_id
+		LIMIT $3
+		FOR UPDATE OF i SKIP LOCKED`,
+		key, jobID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim webhook batch: %w", err)
+	}
+	items, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (WebhookBatchItem, error) {
+		var item WebhookBatchItem
+		err := row.Scan(&item.JobID, &item.Args)
+		return item, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim webhook batch: %w", err)
+	}
+	return items, nil
+}
+
+// MarkWebhookBatchDelivered records that the webhooks of the jobs with the
+// given IDs were delivered to target, so the jobs finish without delivering
+// them again, and removes the batch item of the job that delivered them.
+func MarkWebhookBatchDelivered(ctx context.Context, tx pgx.Tx, deliveredBy int64, jobIDs []int64, target string, now time.Time) error {
+	_, err := tx.Exec(ctx, `
+		UPDATE webhook_batch_item SET delivered_at = $2, target = $3
+		WHERE river_job_id = ANY($1)`,
+		jobIDs, now, target)
+	if err != nil {
+		return fmt.Errorf("failed to mark webhook batch delivered: %w", err)
+	}
+	return DeleteWebhookBatchItem(ctx, tx, deliveredBy)
+}
+
+// DeleteWebhookBatchItem removes the batch item of the webhook job with the
+// given ID, once it has finished.
+func DeleteWebhookBatchItem(ctx context.Context, tx pgx.Tx, jobID int64) error {
+	if _, err := tx.Exec(ctx, "DELETE FROM webhook_batch_item WHERE river_job_id = $1", jobID); err != nil {
+		return fmt.Errorf("failed to delete webhook batch item: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,45 @@
+package internal_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/krelinga/go-libs/deep"
+	"github.com/krelinga/go-libs/exam"
+	"github.com/krelinga/go-libs/match"
+	"github.com/krelinga/video-info/internal"
+)
+
+func TestNewWebhookBatching(t *testing.T) {
+	e := exam.New(t)
+	env := deep.NewEnv()
+	const uri = "https://hooks.example.com/notify"
+
+	e.Run("Defaults", func(e exam.E) {
+		batch, err := internal.NewWebhookBatching(nil, internal.WebhookSecrets{URI: uri}, 0, 0)
+		exam.Nil(e, env, err)
+		exam.Equal(e, env, internal.DefaultWebhookBatchSize, batch.MaxSize)
+		exam.Equal(e, env, 10*time.Second, batch.MaxWait())
+	})
+
+	e.Run("Out of bounds", func(e exam.E) {
+		_, err := internal.NewWebhookBatching(nil, internal.WebhookSecrets{URI: uri}, internal.MaxWebhookBatchSize+1, 0)
+		exam.Match(e, env, err, match.ErrorIs(internal.ErrInvalidWebhookBatch))
+		_, err = internal.NewWebhookBatching(nil, internal.WebhookSecrets{URI: uri}, 0, -1)
+		exam.Match(e, env, err, match.ErrorIs(internal.ErrInvalidWebhookBatch))
+	})
+
+	e.Run("Keys", func(e exam.E) {
+		keyring, err := internal.NewKeyring([][]byte{bytes.Repeat([]byte{1}, internal.SecretKeySize)})
+		exam.Nil(e, env, err)
+		secrets := internal.WebhookSecrets{URI: uri, Token: []byte("token")}
+		key := internal.WebhookBatchKey(keyring, secrets)
+		exam.Equal(e, env, key, internal.WebhookBatchKey(keyring, internal.WebhookSecrets{URI: uri, Token: []byte("token")}))
+		exam.Equal(e, env, false, key == internal.WebhookBatchKey(keyring, internal.WebhookSecrets{URI: uri, Token: []byte("other")}))
+		exam.Equal(e, env, false, key == internal.WebhookBatchKey(nil, secrets))
+		withHeaders := internal.WebhookSecrets{URI: uri, Token: []byte("token"), Headers: map[string]string{"Authorization": "Bearer abc"}}
+		exam.Equal(e, env, false, key == internal.WebhookBatchKey(keyring, withHeaders))
+		exam.Equal(e, env, false, bytes.Contains([]byte(key), []byte("hooks.example.com")))
+	})
+}
@@ -0,0 +1,151 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// defaultWebhookBreakerCooldown is the cooldown of a breaker that first
+// trips when CooldownSeconds is unset.
+const defaultWebhookBreakerCooldown = time.Minute
+
+// maxWebhookBreakerCooldown caps the exponential cooldown of a breaker that
+// keeps tripping.
+const maxWebhookBreakerCooldown = time.Hour
+
+// webhookBreakerJitter is the largest fraction of a cooldown added at random,
+// so the deliveries waiting on a breaker don't all wake together.
+const webhookBreakerJitter = 0.2
+
+// WebhookBreakerJitter returns d lengthened by a random fraction of up to
+// webhookBreakerJitter.
+func WebhookBreakerJitter(d time.Duration) time.Duration {
+	return d + time.Duration(rand.Float64()*webhookBreakerJitter*float64(d))
+}
+
+// WebhookBreakerPolicy controls the circuit breakers that pause deliveries to
+// failing webhook targets.  A breaker opens after Threshold consecutive
+// failed deliveries to its target, holding deliveries back for the cooldown.
+// Then it lets a single delivery through as a probe, closing if the probe
+// succeeds and opening again for twice as long if it fails.  A zero Threshold
+// disables the breakers, and a zero CooldownSeconds means a minute.
+type WebhookBreakerPolicy struct {
+	Threshold       int
+	CooldownSeconds int
+}
+
+// Enabled reports whether breakers ever open.
+func (p WebhookBreakerPolicy) Enabled() bool {
+	return p.Threshold > 0
+}
+
+// Cooldown returns how long a breaker stays open after it trips, where trips
+// counts the times it has tripped since the last successful delivery, before
+// jitter is added.
+func (p WebhookBreakerPolicy) Cooldown(trips int) time.Duration {
+	cooldown := defaultWebhookBreakerCooldown
+	if p.CooldownSeconds > 0 {
+		cooldown = time.Duration(p.CooldownSeconds) * time.Second
+	}
+	for i := 1; i < trips && cooldown < maxWebhookBreakerCooldown; i++ {
+		cooldown *= 2
+	}
+	return min(cooldown, maxWebhookBreakerCooldown)
+}
+
+// AcquireWebhookBreaker checks the breaker of target before a delivery at
+// now.  If the breaker is open, it returns when the delivery should be tried
+// again.  If its cooldown has passed, the caller's delivery becomes the probe
+// and holds the breaker open for probeTimeout, after which another delivery
+// may probe in case the caller never reports its outcome.  It returns the
+// zero time if the delivery may proceed.
+func AcquireWebhookBreaker(ctx context.Context, pool *pgxpool.Pool, target string, now time.Time, probeTimeout time.Duration) (time.Time, error) {
+	tx, err := pool.Begin(ctx)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	var openUntil *time.Time
+	err = tx.QueryRow(ctx, "SELECT open_until FROM webhook_breaker WHERE target = $1 FOR UPDATE", target).Scan(&openUntil)
+	if errors.Is(err, pgx.ErrNoRows) || err == nil && openUntil == nil {
+		return time.Time{}, nil
+	} else if err != nil {
+		return time.Time{}, fmt.Errorf("failed to look up webhook breaker: %w", err)
+	}
+	if openUntil.After(now) {
+		return *openUntil, nil
+	}
+
+	_, err = tx.Exec(ctx, "UPDATE webhook_breaker SET open_until = $2, probing_at = $3 WHERE target = $1",
+		target, now.Add(probeTimeout), now)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to update webhook breaker: %w", err)
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return time.Time{}, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return time.Time{}, nil
+}
+
+// RecordWebhookDelivery updates the breaker of target with the outcome of a
+// delivery at now.  A success closes the breaker.  A failure opens it once
+// policy's threshold is reached, and reopens it if it was probing.  Failures
+// of deliveries that started before the breaker opened leave it as it is.
+func RecordWebhookDelivery(ctx context.Context, pool *pgxpool.Pool, policy WebhookBreakerPolicy, target string, delivered bool, now time.Time) error {
+	if delivered {
+		if _, err := pool.Exec(ctx, "DELETE FROM webhook_breaker WHERE target = $1", target); err != nil {
+			return fmt.Errorf("failed to close webhook breaker: %w", err)
+		}
+		return nil
+	}
+
+	tx, err := pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	var (
+		failures, trips     int
+		openedAt, openUntil *time.Time
+		probingAt           *time.Time
+	)
+	err = tx.QueryRow(ctx, `
+		SELECT failures, trips, opened_at, open_until, probing_at FROM webhook_breaker
+		WHERE target = $1
+		FOR UPDATE`, target).Scan(&failures, &trips, &openedAt, &openUntil, &probingAt)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return fmt.Errorf("failed to look up webhook breaker: %w", err)
+	}
+	failures++
+	if probingAt != nil || openUntil == nil && failures >= policy.Threshold {
+		trips++
+		until := now.Add(WebhookBreakerJitter(policy.Cooldown(trips)))
+		openedAt, openUntil, probingAt = &now, &until, nil
+	}
+	_, err = tx.Exec(ctx, `
+		INSERT INTO webhook_breaker (target, failures, trips, opened_at, open_until, probing_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (target) DO UPDATE SET
+			failures = excluded.failures,
+			trips = excluded.trips,
+			opened_at = excluded.opened_at,
+			open_until = excluded.open_until,
+			probing_at = excluded.probing_at,
+			updated_at = excluded.updated_at`,
+		target, failures, trips, openedAt, openUntil, probingAt, now)
+	if err != nil {
+		return fmt.Errorf("failed to update webhook breaker: %w", err)
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
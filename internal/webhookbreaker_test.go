@@ -0,0 +1,32 @@
+package internal_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/krelinga/go-libs/deep"
+	"github.com/krelinga/go-libs/exam"
+	"github.com/krelinga/video-info/internal"
+)
+
+func TestWebhookBreakerPolicyCooldown(t *testing.T) {
+	e := exam.New(t)
+	env := deep.NewEnv()
+
+	policy := internal.WebhookBreakerPolicy{Threshold: 5, CooldownSeconds: 30}
+	exam.Equal(e, env, 30*time.Second, policy.Cooldown(1))
+	exam.Equal(e, env, 2*time.Minute, policy.Cooldown(3))
+	exam.Equal(e, env, time.Hour, policy.Cooldown(20))
+	exam.Equal(e, env, time.Minute, internal.WebhookBreakerPolicy{Threshold: 5}.Cooldown(1))
+	exam.Equal(e, env, false, internal.WebhookBreakerPolicy{}.Enabled())
+}
+
+func TestWebhookBreakerJitter(t *testing.T) {
+	e := exam.New(t)
+	env := deep.NewEnv()
+
+	for range 100 {
+		jittered := internal.WebhookBreakerJitter(time.Minute)
+		exam.Equal(e, env, true, jittered >= time.Minute && jittered <= time.Minute+12*time.Second)
+	}
+}
@@ -0,0 +1,46 @@
+package internal
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"golang.org/x/net/http/httpguts"
+)
+
+// MaxWebhookHeaders is the most extra headers a webhook may send.
+const MaxWebhookHeaders = 16
+
+// ErrInvalidWebhookHeaders is returned for webhook headers that can't be sent.
+var ErrInvalidWebhookHeaders = errors.New("invalid webhook headers")
+
+// reservedWebhookHeaders are the canonical names of headers that describe
+// the webhook request itself, which callers may not set.
+var reservedWebhookHeaders = map[string]bool{
+	"Connection":        true,
+	"Content-Encoding":  true,
+	"Content-Length":    true,
+	"Content-Type":      true,
+	"Host":              true,
+	"Transfer-Encoding": true,
+	SignatureHeader:     true,
+}
+
+// CheckWebhookHeaders checks that headers can be sent with a webhook.
+func CheckWebhookHeaders(headers map[string]string) error {
+	if len(headers) > MaxWebhookHeaders {
+		return fmt.Errorf("%w: at most %d headers may be set", ErrInvalidWebhookHeaders, MaxWebhookHeaders)
+	}
+	for name, value := range headers {
+		if !httpguts.ValidHeaderFieldName(name) {
+			return fmt.Errorf("%w: %q is not a valid header name", ErrInvalidWebhookHeaders, name)
+		}
+		if reservedWebhookHeaders[http.CanonicalHeaderKey(name)] {
+			return fmt.Errorf("%w: %s is set by the worker", ErrInvalidWebhookHeaders, http.CanonicalHeaderKey(name))
+		}
+		if !httpguts.ValidHeaderFieldValue(value) {
+			return fmt.Errorf("%w: the value of %s is not a valid header value", ErrInvalidWebhookHeaders, name)
+		}
+	}
+	return nil
+}
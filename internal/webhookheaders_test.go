@@ -0,0 +1,79 @@
+package internal_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/krelinga/go-libs/deep"
+	"github.com/krelinga/go-libs/exam"
+	"github.com/krelinga/go-libs/match"
+	"github.com/krelinga/video-info/internal"
+)
+
+func TestCheckWebhookHeaders(t *testing.T) {
+	e := exam.New(t)
+	env := deep.NewEnv()
+
+	tooMany := make(map[string]string)
+	for i := range internal.MaxWebhookHeaders + 1 {
+		tooMany[fmt.Sprintf("X-Header-%d", i)] = "value"
+	}
+
+	tests := []struct {
+		loc     exam.Loc
+		name    string
+		headers map[string]string
+		wantErr bool
+	}{
+		{
+			loc:     exam.Here(),
+			name:    "Bearer auth",
+			headers: map[string]string{"Authorization": "Bearer abc123", "X-Tenant": "media"},
+		},
+		{
+			loc:  exam.Here(),
+			name: "None",
+		},
+		{
+			loc:     exam.Here(),
+			name:    "Too many",
+			headers: tooMany,
+			wantErr: true,
+		},
+		{
+			loc:     exam.Here(),
+			name:    "Invalid name",
+			headers: map[string]string{"X Tenant": "media"},
+			wantErr: true,
+		},
+		{
+			loc:     exam.Here(),
+			name:    "Reserved name",
+			headers: map[string]string{"content-type": "text/plain"},
+			wantErr: true,
+		},
+		{
+			loc:     exam.Here(),
+			name:    "Signature",
+			headers: map[string]string{internal.SignatureHeader: "forged"},
+			wantErr: true,
+		},
+		{
+			loc:     exam.Here(),
+			name:    "Invalid value",
+			headers: map[string]string{"X-Tenant": "media\r\nX-Injected: yes"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		e.Run(tt.name, func(e exam.E) {
+			e.Log("Running test at", tt.loc)
+			err := internal.CheckWebhookHeaders(tt.headers)
+			if tt.wantErr {
+				exam.Match(e, env, err, match.ErrorIs(internal.ErrInvalidWebhookHeaders))
+			} else {
+				exam.Nil(e, env, err)
+			}
+		})
+	}
+}
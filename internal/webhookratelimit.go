@@ -0,0 +1,127 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+var ErrInvalidWebhookRateLimits = errors.New("invalid webhook rate limits")
+
+// WebhookRateLimits maps webhook host patterns to the deliveries per minute
+// allowed to each matching target.  A pattern is a host name, a host name
+// suffix such as "*.example.com", or "*" for every other host.
+type WebhookRateLimits map[string]int
+
+// ParseWebhookRateLimits parses a comma-separated list of pattern=rate
+// pairs, such as "hooks.example.com=60,*.lan=10", where rate is deliveries
+// per minute.  An empty list returns nil.
+func ParseWebhookRateLimits(list string) (WebhookRateLimits, error) {
+	var limits WebhookRateLimits
+	for entry := range strings.SplitSeq(list, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		pattern, rate, ok := strings.Cut(entry, "=")
+		pattern = strings.ToLower(pattern)
+		if !ok || pattern == "" {
+			return nil, fmt.Errorf("%w: %q is not host=rate", ErrInvalidWebhookRateLimits, entry)
+		}
+		perMinute, err := strconv.Atoi(rate)
+		if err != nil || perMinute < 1 {
+			return nil, fmt.Errorf("%w: rate of %q must be a positive integer", ErrInvalidWebhookRateLimits, pattern)
+		}
+		if _, ok := limits[pattern]; ok {
+			return nil, fmt.Errorf("%w: %q is listed twice", ErrInvalidWebhookRateLimits, pattern)
+		}
+		if limits == nil {
+			limits = make(WebhookRateLimits)
+		}
+		limits[pattern] = perMinute
+	}
+	return limits, nil
+}
+
+// PerMinute returns the deliveries per minute allowed to target, as
+// returned by WebhookTarget, or zero if it is unlimited.  A host name takes
+// precedence over the longest matching suffix, which takes precedence over
+// "*".
+func (l WebhookRateLimits) PerMinute(target string) int {
+	if len(l) == 0 {
+		return 0
+	}
+	u, err := url.Parse(target)
+	if err != nil {
+		return 0
+	}
+	host := strings.ToLower(u.Hostname())
+	if perMinute, ok := l[host]; ok {
+		return perMinute
+	}
+	var longest string
+	for pattern := range l {
+		suffix, ok := strings.CutPrefix(pattern, "*")
+		if ok && suffix != "" && strings.HasSuffix(host, suffix) && len(suffix) > len(longest) {
+			longest = suffix
+		}
+	}
+	if longest != "" {
+		return l["*"+longest]
+	}
+	return l["*"]
+}
+
+// ReserveWebhookDelivery reserves the next delivery slot of target for the
+// webhook job with the given ID, spacing deliveries to target evenly at
+// perMinute.  It returns how long the job must wait for its slot, or zero if
+// it may deliver now.  A job that waited for a slot it reserved earlier
+// delivers without reserving another.
+func ReserveWebhookDelivery(ctx context.Context, pool *pgxpool.Pool, target string, perMinute int, jobID int64, now time.Time) (time.Duration, error) {
+	tx, err := pool.Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	// A job may be woken slightly before its slot, which it keeps anyway
+	var slotAt time.Time
+	err = tx.QueryRow(ctx, "DELETE FROM webhook_rate_slot WHERE river_job_id = $1 RETURNING slot_at", jobID).Scan(&slotAt)
+	if err == nil {
+		if err := tx.Commit(ctx); err != nil {
+			return 0, fmt.Errorf("failed to commit transaction: %w", err)
+		}
+		return 0, nil
+	} else if !errors.Is(err, pgx.ErrNoRows) {
+		return 0, fmt.Errorf("failed to look up webhook delivery slot: %w", err)
+	}
+
+	interval := time.Minute / time.Duration(perMinute)
+	err = tx.QueryRow(ctx, `
+		INSERT INTO webhook_rate_limit (target, next_at) VALUES ($1, $2::timestamptz + $3::interval)
+		ON CONFLICT (target) DO UPDATE SET
+			next_at = GREATEST(webhook_rate_limit.next_at, $2) + $3::interval
+		RETURNING next_at - $3::interval`,
+		target, now, interval).Scan(&slotAt)
+	if err != nil {
+		return 0, fmt.Errorf("failed to reserve webhook delivery slot: %w", err)
+	}
+	wait := slotAt.Sub(now)
+	if wait > 0 {
+		_, err = tx.Exec(ctx, "INSERT INTO webhook_rate_slot (river_job_id, slot_at) VALUES ($1, $2)", jobID, slotAt)
+		if err != nil {
+			return 0, fmt.Errorf("failed to record webhook delivery slot: %w", err)
+		}
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return 0, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return max(wait, 0), nil
+}
@@ -0,0 +1,86 @@
+package internal_test
+
+import (
+	"testing"
+
+	"github.com/krelinga/go-libs/deep"
+	"github.com/krelinga/go-libs/exam"
+	"github.com/krelinga/go-libs/match"
+	"github.com/krelinga/video-info/internal"
+)
+
+func TestParseWebhookRateLimits(t *testing.T) {
+	e := exam.New(t)
+	env := deep.NewEnv()
+
+	tests := []struct {
+		loc     exam.Loc
+		name    string
+		list    string
+		want    internal.WebhookRateLimits
+		wantErr bool
+	}{
+		{
+			loc:  exam.Here(),
+			name: "Empty",
+			list: "",
+		},
+		{
+			loc:  exam.Here(),
+			name: "Patterns",
+			list: "Hooks.Example.com=60, *.lan=10,*=600",
+			want: internal.WebhookRateLimits{
+				"hooks.example.com": 60,
+				"*.lan":             10,
+				"*":                 600,
+			},
+		},
+		{
+			loc:     exam.Here(),
+			name:    "Missing rate",
+			list:    "hooks.example.com",
+			wantErr: true,
+		},
+		{
+			loc:     exam.Here(),
+			name:    "Zero rate",
+			list:    "hooks.example.com=0",
+			wantErr: true,
+		},
+		{
+			loc:     exam.Here(),
+			name:    "Listed twice",
+			list:    "*=10,*=20",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		e.Run(tt.name, func(e exam.E) {
+			e.Log("Running test at", tt.loc)
+			got, err := internal.ParseWebhookRateLimits(tt.list)
+			if tt.wantErr {
+				exam.Match(e, env, err, match.ErrorIs(internal.ErrInvalidWebhookRateLimits))
+				return
+			}
+			exam.Nil(e, env, err)
+			exam.Equal(e, env, tt.want, got)
+		})
+	}
+}
+
+func TestWebhookRateLimitsPerMinute(t *testing.T) {
+	e := exam.New(t)
+	env := deep.NewEnv()
+
+	limits := internal.WebhookRateLimits{
+		"hooks.example.com": 60,
+		"*.example.com":     30,
+		"*.api.example.com": 20,
+		"*":                 600,
+	}
+	exam.Equal(e, env, 60, limits.PerMinute("https://hooks.example.com"))
+	exam.Equal(e, env, 30, limits.PerMinute("https://www.example.com"))
+	exam.Equal(e, env, 20, limits.PerMinute("https://v1.api.example.com:8443"))
+	exam.Equal(e, env, 600, limits.PerMinute("http://localhost:8080"))
+	exam.Equal(e, env, 0, internal.WebhookRateLimits(nil).PerMinute("https://hooks.example.com"))
+}
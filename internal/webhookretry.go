@@ -0,0 +1,92 @@
+package internal
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// Bounds on WebhookRetryPolicy fields.  MaxWebhookAttempts matches River's
+// default attempt limit.
+const (
+	MaxWebhookAttempts       = 25
+	MaxWebhookBackoffSeconds = 24 * 60 * 60
+	MaxWebhookTimeoutSeconds = 10 * 60
+)
+
+// maxWebhookRetryDelay caps the exponential backoff between deliveries.
+const maxWebhookRetryDelay = 24 * time.Hour
+
+var ErrInvalidWebhookRetryPolicy = errors.New("invalid webhook retry policy")
+
+// WebhookRetryPolicy controls how webhook deliveries are retried.  Zero
+// fields are unset, and fall back to the worker's defaults or else River's.
+type WebhookRetryPolicy struct {
+	// MaxAttempts is the number of delivery attempts before giving up.
+	MaxAttempts int `json:"max_attempts,omitempty"`
+
+	// BackoffSeconds is the delay before the first retry, doubling with
+	// each further retry.
+	BackoffSeconds int `json:"backoff_seconds,omitempty"`
+
+	// TimeoutSeconds bounds each delivery attempt.
+	TimeoutSeconds int `json:"timeout_seconds,omitempty"`
+}
+
+// Validate checks that every field is unset or within bounds.
+func (p WebhookRetryPolicy) Validate() error {
+	if p.MaxAttempts < 0 || p.MaxAttempts > MaxWebhookAttempts {
+		return fmt.Errorf("%w: max attempts must be between 1 and %d", ErrInvalidWebhookRetryPolicy, MaxWebhookAttempts)
+	}
+	if p.BackoffSeconds < 0 || p.BackoffSeconds > MaxWebhookBackoffSeconds {
+		return fmt.Errorf("%w: backoff must be between 1 and %d seconds", ErrInvalidWebhookRetryPolicy, MaxWebhookBackoffSeconds)
+	}
+	if p.TimeoutSeconds < 0 || p.TimeoutSeconds > MaxWebhookTimeoutSeconds {
+		return fmt.Errorf("%w: timeout must be between 1 and %d seconds", ErrInvalidWebhookRetryPolicy, MaxWebhookTimeoutSeconds)
+	}
+	return nil
+}
+
+// WithDefaults returns the policy with unset fields taken from defaults.
+func (p WebhookRetryPolicy) WithDefaults(defaults WebhookRetryPolicy) WebhookRetryPolicy {
+	if p.MaxAttempts == 0 {
+		p.MaxAttempts = defaults.MaxAttempts
+	}
+	if p.BackoffSeconds == 0 {
+		p.BackoffSeconds = defaults.BackoffSeconds
+	}
+	if p.TimeoutSeconds == 0 {
+		p.TimeoutSeconds = defaults.TimeoutSeconds
+	}
+	return p
+}
+
+// Backoff returns the delay before retrying a delivery that failed on the
+// given attempt, counting from 1, or zero if BackoffSeconds is unset.
+func (p WebhookRetryPolicy) Backoff(attempt int) time.Duration {
+	if p.BackoffSeconds <= 0 {
+		return 0
+	}
+	delay := time.Duration(p.BackoffSeconds) * time.Second
+	for i := 1; i < attempt && delay < maxWebhookRetryDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, maxWebhookRetryDelay)
+}
+
+// Timeout returns the bound on each delivery attempt, or zero if
+// TimeoutSeconds is unset.
+func (p WebhookRetryPolicy) Timeout() time.Duration {
+	return time.Duration(p.TimeoutSeconds) * time.Second
+}
+
+// WebhookTarget identifies the receiver of a webhook URI by its scheme and
+// host, leaving out the path and query where callers often embed secrets.
+func WebhookTarget(uri string) string {
+	u, err := url.Parse(uri)
+	if err != nil || u.Host == "" {
+		return ""
+	}
+	return u.Scheme + "://" + u.Host
+}
@@ -0,0 +1,94 @@
+package internal_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/krelinga/go-libs/deep"
+	"github.com/krelinga/go-libs/exam"
+	"github.com/krelinga/go-libs/match"
+	"github.com/krelinga/video-info/internal"
+)
+
+func TestWebhookRetryPolicyValidate(t *testing.T) {
+	e := exam.New(t)
+	env := deep.NewEnv()
+
+	tests := []struct {
+		loc     exam.Loc
+		name    string
+		policy  internal.WebhookRetryPolicy
+		wantErr bool
+	}{
+		{
+			loc:  exam.Here(),
+			name: "Unset",
+		},
+		{
+			loc:    exam.Here(),
+			name:   "Within bounds",
+			policy: internal.WebhookRetryPolicy{MaxAttempts: 5, BackoffSeconds: 30, TimeoutSeconds: 10},
+		},
+		{
+			loc:     exam.Here(),
+			name:    "Too many attempts",
+			policy:  internal.WebhookRetryPolicy{MaxAttempts: internal.MaxWebhookAttempts + 1},
+			wantErr: true,
+		},
+		{
+			loc:     exam.Here(),
+			name:    "Negative backoff",
+			policy:  internal.WebhookRetryPolicy{BackoffSeconds: -1},
+			wantErr: true,
+		},
+		{
+			loc:     exam.Here(),
+			name:    "Timeout too long",
+			policy:  internal.WebhookRetryPolicy{TimeoutSeconds: internal.MaxWebhookTimeoutSeconds + 1},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		e.Run(tt.name, func(e exam.E) {
+			e.Log("Running test at", tt.loc)
+			err := tt.policy.Validate()
+			if tt.wantErr {
+				exam.Match(e, env, err, match.ErrorIs(internal.ErrInvalidWebhookRetryPolicy))
+			} else {
+				exam.Nil(e, env, err)
+			}
+		})
+	}
+}
+
+func TestWebhookRetryPolicyBackoff(t *testing.T) {
+	e := exam.New(t)
+	env := deep.NewEnv()
+
+	policy := internal.WebhookRetryPolicy{BackoffSeconds: 30}
+	exam.Equal(e, env, 30*time.Second, policy.Backoff(1))
+	exam.Equal(e, env, 2*time.Minute, policy.Backoff(3))
+	exam.Equal(e, env, 24*time.Hour, policy.Backoff(internal.MaxWebhookAttempts))
+	exam.Equal(e, env, time.Duration(0), internal.WebhookRetryPolicy{}.Backoff(3))
+}
+
+func TestWebhookJobArgsRetryPolicy(t *testing.T) {
+	e := exam.New(t)
+	env := deep.NewEnv()
+
+	defaults := internal.WebhookRetryPolicy{MaxAttempts: 10, BackoffSeconds: 60, TimeoutSeconds: 30}
+	exam.Equal(e, env, defaults, internal.WebhookJobArgs{}.RetryPolicy(defaults))
+
+	args := internal.WebhookJobArgs{Retry: &internal.WebhookRetryPolicy{MaxAttempts: 3, TimeoutSeconds: 5}}
+	want := internal.WebhookRetryPolicy{MaxAttempts: 3, BackoffSeconds: 60, TimeoutSeconds: 5}
+	exam.Equal(e, env, want, args.RetryPolicy(defaults))
+	exam.Equal(e, env, 3, args.InsertOpts().MaxAttempts)
+}
+
+func TestWebhookTarget(t *testing.T) {
+	e := exam.New(t)
+	env := deep.NewEnv()
+
+	exam.Equal(e, env, "https://hooks.example.com:8443", internal.WebhookTarget("https://hooks.example.com:8443/notify/s3cr3t?token=abc"))
+	exam.Equal(e, env, "", internal.WebhookTarget("not a uri"))
+}
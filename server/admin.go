@@ -0,0 +1,246 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/krelinga/video-info/internal"
+	"github.com/krelinga/video-info/virest"
+)
+
+// PurgeInfo handles POST /admin/purge requests.
+func (s *Server) PurgeInfo(ctx context.Context, request virest.PurgeInfoRequestObject) (virest.PurgeInfoResponseObject, error) {
+	if request.Body == nil || request.Body.VideoPath == "" {
+		return virest.PurgeInfo400JSONResponse{
+			Code:    "INVALID_REQUEST",
+			Message: "videoPath is required",
+		}, nil
+	}
+	path := request.Body.VideoPath
+	prefix := request.Body.Prefix != nil && *request.Body.Prefix
+
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return virest.PurgeInfo500JSONResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: fmt.Sprintf("failed to begin transaction: %v", err),
+		}, nil
+	}
+	defer tx.Rollback(ctx)
+
+	// Count running jobs that will be left in place
+	var skipped int
+	err = tx.QueryRow(ctx, `
+		SELECT count(*) FROM river_job
+		WHERE kind = $1 AND state = 'running'
+			AND (args->>'path' = $2 OR ($3 AND starts_with(args->>'path', $2)))`,
+		internal.InfoJobArgs{}.Kind(), path, prefix).Scan(&skipped)
+	if err != nil {
+		return virest.PurgeInfo500JSONResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: fmt.Sprintf("failed to count running jobs: %v", err),
+		}, nil
+	}
+
+	// Delete info jobs; uuid_job_mapping rows are removed by ON DELETE CASCADE
+	rows, err := tx.Query(ctx, `
+		DELETE FROM river_job
+		WHERE kind = $1 AND state <> 'running'
+			AND (args->>'path' = $2 OR ($3 AND starts_with(args->>'path', $2)))
+		RETURNING args->>'uuid'`,
+		internal.InfoJobArgs{}.Kind(), path, prefix)
+	if err != nil {
+		return virest.PurgeInfo500JSONResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: fmt.Sprintf("failed to delete info jobs: %v", err),
+		}, nil
+	}
+	var purgedUUIDs []string
+	for rows.Next() {
+		var u string
+		if err := rows.Scan(&u); err != nil {
+			rows.Close()
+			return virest.PurgeInfo500JSONResponse{
+				Code:    "INTERNAL_ERROR",
+				Message: fmt.Sprintf("failed to scan deleted job: %v", err),
+			}, nil
+		}
+		purgedUUIDs = append(purgedUUIDs, u)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return virest.PurgeInfo500JSONResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: fmt.Sprintf("failed to delete info jobs: %v", err),
+		}, nil
+	}
+
+	// Stored results outlive River's cleanup of finished jobs, so some may
+	// have no job left to delete
+	rows, err = tx.Query(ctx, `
+		DELETE FROM info_result
+		WHERE video_path = $1 OR ($2 AND starts_with(video_path, $1))
+		RETURNING uuid::text`,
+		path, prefix)
+	if err != nil {
+		return virest.PurgeInfo500JSONResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: fmt.Sprintf("failed to delete stored results: %v", err),
+		}, nil
+	}
+	storedUUIDs, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return virest.PurgeInfo500JSONResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: fmt.Sprintf("failed to delete stored results: %v", err),
+		}, nil
+	}
+	purged := make(map[string]bool, len(purgedUUIDs))
+	for _, u := range purgedUUIDs {
+		purged[u] = true
+	}
+	for _, u := range storedUUIDs {
+		if !purged[u] {
+			purgedUUIDs = append(purgedUUIDs, u)
+		}
+	}
+
+	_, err = tx.Exec(ctx, `
+		DELETE FROM probe_cache
+		WHERE video_path = $1 OR ($2 AND starts_with(video_path, $1))`,
+		path, prefix)
+	if err != nil {
+		return virest.PurgeInfo500JSONResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: fmt.Sprintf("failed to delete cached results: %v", err),
+		}, nil
+	}
+
+	// Incremental scans enqueue purged files again once they are out of
+	// their schedules' catalogs
+	_, err = tx.Exec(ctx, `
+		DELETE FROM scan_catalog
+		WHERE video_path = $1 OR ($2 AND starts_with(video_path, $1))`,
+		path, prefix)
+	if err != nil {
+		return virest.PurgeInfo500JSONResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: fmt.Sprintf("failed to delete scan catalog entries: %v", err),
+		}, nil
+	}
+
+	// Batched webhooks are listed apart from their jobs, for other deliveries
+	// in their batch to pick up
+	_, err = tx.Exec(ctx, `
+		DELETE FROM webhook_batch_item
+		WHERE river_job_id IN (
+			SELECT id FROM river_job
+			WHERE kind = $1 AND state <> 'running' AND args->>'info_uuid' = ANY($2))`,
+		internal.WebhookJobArgs{}.Kind(), purgedUUIDs)
+	if err != nil {
+		return virest.PurgeInfo500JSONResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: fmt.Sprintf("failed to delete batched webhooks: %v", err),
+		}, nil
+	}
+
+	// Delete webhook deliveries triggered by the purged jobs
+	tag, err := tx.Exec(ctx, `
+		DELETE FROM river_job
+		WHERE kind = $1 AND state <> 'running' AND args->>'info_uuid' = ANY($2)`,
+		internal.WebhookJobArgs{}.Kind(), purgedUUIDs)
+	if err != nil {
+		return virest.PurgeInfo500JSONResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: fmt.Sprintf("failed to delete webhook jobs: %v", err),
+		}, nil
+	}
+
+	// Undelivered webhooks are recorded apart from the jobs, so they outlive
+	// River's cleanup of finished jobs
+	_, err = tx.Exec(ctx, "DELETE FROM webhook_dead_letter WHERE info_uuid = ANY($1::uuid[])", purgedUUIDs)
+	if err != nil {
+		return virest.PurgeInfo500JSONResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: fmt.Sprintf("failed to delete undelivered webhook records: %v", err),
+		}, nil
+	}
+
+	// Comparisons name the purged path or results, and would otherwise
+	// keep reporting them
+	_, err = tx.Exec(ctx, `
+		DELETE FROM comparisons
+		WHERE video_path = $1 OR ($2 AND starts_with(video_path, $1))
+			OR baseline_uuid = ANY($3::uuid[]) OR candidate_uuid = ANY($3::uuid[])`,
+		path, prefix, purgedUUIDs)
+	if err != nil {
+		return virest.PurgeInfo500JSONResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: fmt.Sprintf("failed to delete comparisons: %v", err),
+		}, nil
+	}
+
+	// Scans record the paths they resume after and, for dry runs, sample.
+	// Running scans are left in place like running info jobs.
+	rows, err = tx.Query(ctx, "SELECT id, metadata FROM river_job WHERE kind = $1 AND state <> 'running'",
+		internal.ScanArgs{}.Kind())
+	if err != nil {
+		return virest.PurgeInfo500JSONResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: fmt.Sprintf("failed to list scan jobs: %v", err),
+		}, nil
+	}
+	scans, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (scanMetadata, error) {
+		var scan scanMetadata
+		err := row.Scan(&scan.id, &scan.metadata)
+		return scan, err
+	})
+	if err != nil {
+		return virest.PurgeInfo500JSONResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: fmt.Sprintf("failed to list scan jobs: %v", err),
+		}, nil
+	}
+	matches := func(videoPath string) bool {
+		return videoPath == path || (prefix && strings.HasPrefix(videoPath, path))
+	}
+	for _, scan := range scans {
+		metadata, changed, err := internal.PurgeScanPaths(scan.metadata, matches)
+		if err != nil {
+			return virest.PurgeInfo500JSONResponse{
+				Code:    "INTERNAL_ERROR",
+				Message: fmt.Sprintf("failed to purge scan job %d: %v", scan.id, err),
+			}, nil
+		}
+		if !changed {
+			continue
+		}
+		if _, err := tx.Exec(ctx, "UPDATE river_job SET metadata = $2 WHERE id = $1", scan.id, metadata); err != nil {
+			return virest.PurgeInfo500JSONResponse{
+				Code:    "INTERNAL_ERROR",
+				Message: fmt.Sprintf("failed to purge scan job %d: %v", scan.id, err),
+			}, nil
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return virest.PurgeInfo500JSONResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: fmt.Sprintf("failed to commit transaction: %v", err),
+		}, nil
+	}
+
+	return virest.PurgeInfo200JSONResponse{
+		PurgedJobs:         len(purgedUUIDs),
+		PurgedWebhookJobs:  int(tag.RowsAffected()),
+		SkippedRunningJobs: skipped,
+	}, nil
+}
+
+// scanMetadata is the metadata of a scan job.
+type scanMetadata struct {
+	id       int64
+	metadata []byte
+}
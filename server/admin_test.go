@@ -0,0 +1,112 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/krelinga/go-libs/deep"
+	"github.com/krelinga/go-libs/exam"
+	"github.com/krelinga/video-info/internal"
+	"github.com/krelinga/video-info/virest"
+)
+
+func TestPurgeInfo(t *testing.T) {
+	e := exam.New(t)
+	env := deep.NewEnv()
+	jobUUID := "7f2b1a44-63a5-4a39-9a36-5d0c1a6f4f1e"
+	storedUUID := "0c6e3f0e-6a7b-4d0b-8f55-2e3d6c9b7a10"
+
+	checkpoint, err := json.Marshal(map[string]any{
+		"scan_progress": internal.ScanProgress{AfterPath: "/videos/old/b.mkv", Found: 2, Enqueued: 2},
+	})
+	if err != nil {
+		e.Fatal(err)
+	}
+	preview, err := json.Marshal(map[string]any{
+		"output": internal.ScanPreview{
+			Found:         2,
+			WouldEnqueue:  2,
+			EnqueueSample: []internal.ScanPreviewFile{{Path: "/videos/a.mkv"}, {Path: "/videos/old/b.mkv"}},
+		},
+	})
+	if err != nil {
+		e.Fatal(err)
+	}
+	untouched := []byte(`{"scan_progress": {"after_path": "/videos/a.mkv", "found": 1, "enqueued": 1}}`)
+
+	execs := make(map[string][]any)
+	updated := make(map[int64][]byte)
+	tx := &fakeTx{
+		queryRow: func(string, []any) pgx.Row {
+			return fakeRow{values: []any{0}}
+		},
+		query: func(sql string, _ []any) (pgx.Rows, error) {
+			switch {
+			case strings.Contains(sql, "DELETE FROM river_job"):
+				return &fakeRows{rows: [][]any{{jobUUID}}}, nil
+			case strings.Contains(sql, "DELETE FROM info_result"):
+				return &fakeRows{rows: [][]any{{jobUUID}, {storedUUID}}}, nil
+			case strings.Contains(sql, "SELECT id, metadata FROM river_job"):
+				return &fakeRows{rows: [][]any{{int64(1), checkpoint}, {int64(2), preview}, {int64(3), untouched}}}, nil
+			}
+			return &fakeRows{}, nil
+		},
+		exec: func(sql string, args []any) error {
+			if strings.HasPrefix(strings.TrimSpace(sql), "UPDATE river_job SET metadata") {
+				updated[args[0].(int64)] = args[1].([]byte)
+				return nil
+			}
+			table := strings.Fields(strings.TrimSpace(sql))[2]
+			execs[table] = args
+			return nil
+		},
+	}
+	store := &fakeStore{begin: func() (pgx.Tx, error) { return tx, nil }}
+	s := newTestServer(e, store, &fakeQueue{}, &internal.ServerConfig{})
+	prefix := true
+	resp, err := s.PurgeInfo(context.Background(), virest.PurgeInfoRequestObject{
+		Body: &virest.PurgeRequest{VideoPath: "/videos/old/", Prefix: &prefix},
+	})
+	exam.Nil(e, env, err)
+	got, ok := resp.(virest.PurgeInfo200JSONResponse)
+	if !ok {
+		e.Fatalf("got %T, want 200", resp)
+	}
+	exam.Equal(e, env, 2, got.PurgedJobs)
+	exam.Equal(e, env, true, tx.committed)
+	purgedUUIDs := []string{jobUUID, storedUUID}
+
+	e.Run("Batched webhooks", func(e exam.E) {
+		exam.Equal(e, env, []any{internal.WebhookJobArgs{}.Kind(), purgedUUIDs}, execs["webhook_batch_item"])
+	})
+
+	e.Run("Scan catalogs", func(e exam.E) {
+		exam.Equal(e, env, []any{"/videos/old/", true}, execs["scan_catalog"])
+	})
+
+	e.Run("Comparisons", func(e exam.E) {
+		exam.Equal(e, env, []any{"/videos/old/", true, purgedUUIDs}, execs["comparisons"])
+	})
+
+	e.Run("Scan checkpoints", func(e exam.E) {
+		progress, err := internal.ScanCheckpoint(updated[1])
+		exam.Nil(e, env, err)
+		exam.Equal(e, env, internal.ScanProgress{Found: 2, Enqueued: 2}, progress)
+	})
+
+	e.Run("Dry-run samples", func(e exam.E) {
+		var decoded struct {
+			Output internal.ScanPreview `json:"output"`
+		}
+		exam.Nil(e, env, json.Unmarshal(updated[2], &decoded))
+		exam.Equal(e, env, []internal.ScanPreviewFile{{Path: "/videos/a.mkv"}}, decoded.Output.EnqueueSample)
+	})
+
+	e.Run("Scans naming other paths", func(e exam.E) {
+		_, ok := updated[3]
+		exam.Equal(e, env, false, ok)
+	})
+}
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/krelinga/video-info/internal"
+	"github.com/krelinga/video-info/virest"
+)
+
+// RegisterAgent handles PUT /worker/agents/{workerId} requests.
+func (s *Server) RegisterAgent(ctx context.Context, request virest.RegisterAgentRequestObject) (virest.RegisterAgentResponseObject, error) {
+	if request.Body == nil {
+		return virest.RegisterAgent400JSONResponse{
+			Code:    "INVALID_REQUEST",
+			Message: "Request body is required",
+		}, nil
+	}
+	var gpuCapacity int
+	if request.Body.GpuCapacity != nil {
+		gpuCapacity = *request.Body.GpuCapacity
+	}
+	if request.WorkerId == "" || request.Body.Hostname == "" || request.Body.Capacity < 1 || gpuCapacity < 0 {
+		return virest.RegisterAgent400JSONResponse{
+			Code:    "INVALID_REQUEST",
+			Message: "workerId and hostname are required, capacity must be at least 1 and gpuCapacity must not be negative",
+		}, nil
+	}
+
+	mounts := make([]string, 0, len(request.Body.Mounts))
+	for _, m := range request.Body.Mounts {
+		mount, err := internal.NormalizeMount(m)
+		if err != nil {
+			return virest.RegisterAgent400JSONResponse{
+				Code:    "INVALID_MOUNT",
+				Message: err.Error(),
+			}, nil
+		}
+		mounts = append(mounts, mount)
+	}
+
+	agent := virest.Agent{
+		WorkerId:       request.WorkerId,
+		Hostname:       request.Body.Hostname,
+		Mounts:         mounts,
+		FfprobeVersion: request.Body.FfprobeVersion,
+		Capacity:       request.Body.Capacity,
+		GpuCapacity:    gpuCapacity,
+	}
+	err := s.pool.QueryRow(ctx, `
+		INSERT INTO worker_agent (worker_id, hostname, mounts, ffprobe_version, capacity, gpu_capacity)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (worker_id) DO UPDATE SET
+			hostname = EXCLUDED.hostname,
+			mounts = EXCLUDED.mounts,
+			ffprobe_version = EXCLUDED.ffprobe_version,
+			capacity = EXCLUDED.capacity,
+			gpu_capacity = EXCLUDED.gpu_capacity,
+			last_seen_at = now()
+		RETURNING registered_at, last_seen_at,
+			(SELECT count(*) FROM river_job WHERE state = 'running' AND metadata->>$7 = $1)`,
+		agent.WorkerId, agent.Hostname, agent.Mounts, agent.FfprobeVersion, agent.Capacity, agent.GpuCapacity,
+		remoteWorkerMetadataKey).
+		Scan(&agent.RegisteredAt, &agent.LastSeenAt, &agent.RunningJobs)
+	if err != nil {
+		return virest.RegisterAgent500JSONResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: fmt.Sprintf("failed to register agent: %v", err),
+		}, nil
+	}
+
+	return virest.RegisterAgent200JSONResponse(agent), nil
+}
+
+// ListAgents handles GET /admin/agents requests.
+func (s *Server) ListAgents(ctx context.Context, request virest.ListAgentsRequestObject) (virest.ListAgentsResponseObject, error) {
+	rows, err := s.readPool.Query(ctx, `
+		SELECT a.worker_id, a.hostname, a.mounts, a.ffprobe_version, a.capacity, a.gpu_capacity, a.registered_at, a.last_seen_at,
+			(SELECT count(*) FROM river_job j WHERE j.state = 'running' AND j.metadata->>$1 = a.worker_id)
+		FROM worker_agent a
+		ORDER BY a.worker_id`,
+		remoteWorkerMetadataKey)
+	if err != nil {
+		return virest.ListAgents500JSONResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: fmt.Sprintf("failed to list agents: %v", err),
+		}, nil
+	}
+	defer rows.Close()
+
+	agents := []virest.Agent{}
+	for rows.Next() {
+		var agent virest.Agent
+		if err := rows.Scan(&agent.WorkerId, &agent.Hostname, &agent.Mounts, &agent.FfprobeVersion, &agent.Capacity,
+			&agent.GpuCapacity, &agent.RegisteredAt, &agent.LastSeenAt, &agent.RunningJobs); err != nil {
+			return virest.ListAgents500JSONResponse{
+				Code:    "INTERNAL_ERROR",
+				Message: fmt.Sprintf("failed to scan agent: %v", err),
+			}, nil
+		}
+		agents = append(agents, agent)
+	}
+	if err := rows.Err(); err != nil {
+		return virest.ListAgents500JSONResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: fmt.Sprintf("failed to list agents: %v", err),
+		}, nil
+	}
+
+	return virest.ListAgents200JSONResponse{Agents: agents}, nil
+}
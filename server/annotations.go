@@ -0,0 +1,102 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/krelinga/video-info/virest"
+)
+
+// maxAnnotationNoteLength is the maximum length of an annotation note.
+const maxAnnotationNoteLength = 4096
+
+// AnnotateInfo handles PATCH /info/{uuid}/annotations requests.
+func (s *Server) AnnotateInfo(ctx context.Context, request virest.AnnotateInfoRequestObject) (virest.AnnotateInfoResponseObject, error) {
+	if request.Body == nil {
+		return virest.AnnotateInfo400JSONResponse{
+			Code:    "INVALID_REQUEST",
+			Message: "Request body is required",
+		}, nil
+	}
+	if request.Body.Note != nil && len(*request.Body.Note) > maxAnnotationNoteLength {
+		return virest.AnnotateInfo400JSONResponse{
+			Code:    "INVALID_NOTE",
+			Message: fmt.Sprintf("note must be at most %d characters", maxAnnotationNoteLength),
+		}, nil
+	}
+
+	var (
+		note         *string
+		acknowledged bool
+		annotatedAt  *time.Time
+	)
+	err := s.pool.QueryRow(ctx, `
+		UPDATE uuid_job_mapping
+		SET annotation_note = CASE WHEN $2 THEN NULLIF($3, '') ELSE annotation_note END,
+			acknowledged = COALESCE($4, acknowledged),
+			annotated_at = now()
+		WHERE uuid = $1
+		RETURNING annotation_note, acknowledged, annotated_at`,
+		request.Uuid, request.Body.Note != nil, request.Body.Note, request.Body.Acknowledged).Scan(&note, &acknowledged, &annotatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return virest.AnnotateInfo404JSONResponse{
+			Code:    "NOT_FOUND",
+			Message: fmt.Sprintf("Info job with UUID %s not found", request.Uuid),
+		}, nil
+	} else if err != nil {
+		return virest.AnnotateInfo500JSONResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: fmt.Sprintf("failed to update annotations: %v", err),
+		}, nil
+	}
+
+	return virest.AnnotateInfo200JSONResponse(newAnnotations(note, acknowledged, annotatedAt)), nil
+}
+
+// loadAnnotations returns the annotations of the info jobs with the given
+// River job IDs, keyed by River job ID.
+func (s *Server) loadAnnotations(ctx context.Context, riverJobIDs []int64) (map[int64]*virest.Annotations, error) {
+	rows, err := s.readPool.Query(ctx, `
+		SELECT river_job_id, annotation_note, acknowledged, annotated_at
+		FROM uuid_job_mapping WHERE river_job_id = ANY($1)`,
+		riverJobIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load annotations: %w", err)
+	}
+	defer rows.Close()
+
+	annotations := make(map[int64]*virest.Annotations, len(riverJobIDs))
+	for rows.Next() {
+		var (
+			riverJobID   int64
+			note         *string
+			acknowledged bool
+			annotatedAt  *time.Time
+		)
+		if err := rows.Scan(&riverJobID, &note, &acknowledged, &annotatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan annotations: %w", err)
+		}
+		a := newAnnotations(note, acknowledged, annotatedAt)
+		annotations[riverJobID] = &a
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to load annotations: %w", err)
+	}
+	return annotations, nil
+}
+
+// newAnnotations builds the REST representation of a job's annotations.
+func newAnnotations(note *string, acknowledged bool, annotatedAt *time.Time) virest.Annotations {
+	a := virest.Annotations{
+		Note:         note,
+		Acknowledged: acknowledged,
+	}
+	if annotatedAt != nil {
+		updatedAt := annotatedAt.UTC()
+		a.UpdatedAt = &updatedAt
+	}
+	return a
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/krelinga/video-info/internal"
+	"github.com/krelinga/video-info/virest"
+)
+
+// queueDepthTTL is how long a counted queue depth is reused, so a burst of
+// submissions doesn't count the queue once per request.
+const queueDepthTTL = time.Second
+
+// queueFullRetryAfter is how long submitters are asked to wait once the
+// queue is full.
+const queueFullRetryAfter = 30 * time.Second
+
+// queueDepth caches the number of info jobs waiting to run.
+type queueDepth struct {
+	mu        sync.Mutex
+	depth     int
+	countedAt time.Time
+}
+
+// queueDepth returns the number of info jobs waiting to run, or zero if the
+// queue depth isn't limited.
+func (s *Server) queueDepth(ctx context.Context) (int, error) {
+	if s.cfg.MaxQueueDepth <= 0 {
+		return 0, nil
+	}
+	s.depth.mu.Lock()
+	defer s.depth.mu.Unlock()
+	now := s.clock.Now()
+	if !s.depth.countedAt.IsZero() && now.Sub(s.depth.countedAt) < queueDepthTTL {
+		return s.depth.depth, nil
+	}
+	var depth int
+	err := s.readPool.QueryRow(ctx, `
+		SELECT count(*) FROM river_job
+		WHERE kind = $1 AND state IN ('available', 'pending', 'retryable', 'scheduled')`,
+		internal.InfoJobArgs{}.Kind()).Scan(&depth)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count queued jobs: %w", err)
+	}
+	s.depth.depth, s.depth.countedAt = depth, now
+	return depth, nil
+}
+
+// queueFull returns the response for submissions rejected because depth
+// jobs are waiting, or nil if depth is under the limit.
+func (s *Server) queueFull(depth int) *virest.QueueFullJSONResponse {
+	if s.cfg.MaxQueueDepth <= 0 || depth < s.cfg.MaxQueueDepth {
+		return nil
+	}
+	return &virest.QueueFullJSONResponse{
+		Body: virest.Error{
+			Code:    "QUEUE_FULL",
+			Message: fmt.Sprintf("%d info jobs are waiting to run, at or over the limit of %d", depth, s.cfg.MaxQueueDepth),
+		},
+		Headers: virest.QueueFullResponseHeaders{
+			RetryAfter:  int(queueFullRetryAfter.Seconds()),
+			XQueueDepth: depth,
+		},
+	}
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+
+	"github.com/krelinga/video-info/internal"
+)
+
+// runBackup implements the "backup" subcommand, which exports all
+// service-owned tables to a portable archive.
+func runBackup(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("backup", flag.ContinueOnError)
+	output := fs.String("o", "", "path of the archive to write, or - for stdout")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *output == "" {
+		return fmt.Errorf("backup: -o is required")
+	}
+
+	pool, err := internal.NewDBPool(ctx, internal.NewDatabaseConfigFromEnv())
+	if err != nil {
+		return fmt.Errorf("failed to create database pool: %w", err)
+	}
+	defer pool.Close()
+
+	var manifest *internal.BackupManifest
+	now := internal.SystemClock.Now()
+	if *output == "-" {
+		manifest, err = internal.Backup(ctx, pool, os.Stdout, now)
+	} else {
+		var f *os.File
+		f, err = os.Create(*output)
+		if err != nil {
+			return fmt.Errorf("failed to create archive: %w", err)
+		}
+		manifest, err = internal.Backup(ctx, pool, f, now)
+		if closeErr := f.Close(); err == nil && closeErr != nil {
+			err = closeErr
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("backup failed: %w", err)
+	}
+
+	for table, count := range manifest.Tables {
+		log.Printf("Backed up %d rows from %s", count, table)
+	}
+	return nil
+}
+
+// runRestore implements the "restore" subcommand, which loads an archive
+// written by the backup subcommand into an empty database.
+func runRestore(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("restore", flag.ContinueOnError)
+	input := fs.String("i", "", "path of the archive to read, or - for stdin")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *input == "" {
+		return fmt.Errorf("restore: -i is required")
+	}
+
+	pool, err := internal.NewDBPool(ctx, internal.NewDatabaseConfigFromEnv())
+	if err != nil {
+		return fmt.Errorf("failed to create database pool: %w", err)
+	}
+	defer pool.Close()
+
+	var r io.Reader = os.Stdin
+	if *input != "-" {
+		f, err := os.Open(*input)
+		if err != nil {
+			return fmt.Errorf("failed to open archive: %w", err)
+		}
+		defer f.Close()
+		r = f
+	}
+
+	// Bring the schema up to date so the restored rows have somewhere to go
+	log.Println("Running database migrations...")
+	if err := internal.MigrateUp(ctx, pool); err != nil {
+		return fmt.Errorf("failed to run migrations: %w", err)
+	}
+
+	manifest, err := internal.Restore(ctx, pool, r)
+	if err != nil {
+		return fmt.Errorf("restore failed: %w", err)
+	}
+
+	for table, count := range manifest.Tables {
+		log.Printf("Restored %d rows into %s", count, table)
+	}
+	return nil
+}
@@ -0,0 +1,209 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/krelinga/video-info/internal"
+	"github.com/krelinga/video-info/virest"
+	"github.com/riverqueue/river"
+	"github.com/riverqueue/river/rivertype"
+)
+
+// maxBatchSize is the maximum number of items in a batch create request.
+const maxBatchSize = 1000
+
+// CreateInfoBatch handles POST /info/batch requests.
+func (s *Server) CreateInfoBatch(ctx context.Context, request virest.CreateInfoBatchRequestObject) (virest.CreateInfoBatchResponseObject, error) {
+	if request.Body == nil || len(request.Body.Items) == 0 {
+		return virest.CreateInfoBatch400JSONResponse{
+			Code:    "INVALID_REQUEST",
+			Message: "At least one item is required",
+		}, nil
+	}
+	items := request.Body.Items
+	if len(items) > maxBatchSize {
+		return virest.CreateInfoBatch400JSONResponse{
+			Code:    "BATCH_TOO_LARGE",
+			Message: fmt.Sprintf("A batch may contain at most %d items", maxBatchSize),
+		}, nil
+	}
+
+	// Validate every item up front, so an invalid item rejects the whole batch
+	allJobArgs := make([]internal.InfoJobArgs, len(items))
+	for i := range items {
+		jobArgs, invalid, err := s.infoJobArgs(ctx, &items[i])
+		if err != nil {
+			return virest.CreateInfoBatch500JSONResponse{
+				Code:    "INTERNAL_ERROR",
+				Message: err.Error(),
+			}, nil
+		} else if invalid != nil {
+			return virest.CreateInfoBatch400JSONResponse{
+				Code:    invalid.Code,
+				Message: fmt.Sprintf("items[%d]: %s", i, invalid.Message),
+			}, nil
+		}
+		s.routeToCanary(&jobArgs)
+		allJobArgs[i] = jobArgs
+	}
+
+	depth, err := s.queueDepth(ctx)
+	if err != nil {
+		return virest.CreateInfoBatch500JSONResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: err.Error(),
+		}, nil
+	} else if full := s.queueFull(depth); full != nil {
+		return virest.CreateInfoBatch429JSONResponse{QueueFullJSONResponse: *full}, nil
+	}
+
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return virest.CreateInfoBatch500JSONResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: fmt.Sprintf("failed to begin transaction: %v", err),
+		}, nil
+	}
+	defer tx.Rollback(ctx)
+
+	// Without the lock, concurrent requests could both pass the checks below
+	if err := internal.LockInfoJobIDs(ctx, tx, allJobArgs); err != nil {
+		return virest.CreateInfoBatch500JSONResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: err.Error(),
+		}, nil
+	}
+
+	takenUUIDs, takenExternalIDs, err := findTakenIDs(ctx, tx, allJobArgs)
+	if err != nil {
+		return virest.CreateInfoBatch500JSONResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: err.Error(),
+		}, nil
+	}
+
+	// Report conflicts, including with earlier items in the batch, and
+	// insert everything else
+	results := make([]virest.InfoBatchItemResult, len(items))
+	var (
+		params   []river.InsertManyParams
+		accepted []int
+	)
+	for i, jobArgs := range allJobArgs {
+		results[i].Uuid = items[i].Uuid
+		switch {
+		case takenUUIDs[jobArgs.UUID]:
+			results[i].Status = http.StatusConflict
+			results[i].Error = &virest.Error{
+				Code:    "DUPLICATE_UUID",
+				Message: fmt.Sprintf("An info job with UUID %s already exists", jobArgs.UUID),
+			}
+		case jobArgs.ExternalID != nil && takenExternalIDs[*jobArgs.ExternalID]:
+			results[i].Status = http.StatusConflict
+			results[i].Error = &virest.Error{
+				Code:    "DUPLICATE_EXTERNAL_ID",
+				Message: fmt.Sprintf("An info job with external ID %q already exists", *jobArgs.ExternalID),
+			}
+		default:
+			takenUUIDs[jobArgs.UUID] = true
+			if jobArgs.ExternalID != nil {
+				takenExternalIDs[*jobArgs.ExternalID] = true
+			}
+			params = append(params, river.InsertManyParams{Args: jobArgs})
+			accepted = append(accepted, i)
+		}
+	}
+
+	var insertedJobs []*rivertype.JobInsertResult
+	if len(params) > 0 {
+		insertedJobs, err = s.riverClient.InsertManyTx(ctx, tx, params)
+		if err != nil {
+			return virest.CreateInfoBatch500JSONResponse{
+				Code:    "INTERNAL_ERROR",
+				Message: fmt.Sprintf("failed to insert river jobs: %v", err),
+			}, nil
+		}
+
+		uuids := make([]uuid.UUID, len(accepted))
+		riverJobIDs := make([]int64, len(accepted))
+		externalIDs := make([]*string, len(accepted))
+		for k, i := range accepted {
+			uuids[k] = allJobArgs[i].UUID
+			riverJobIDs[k] = insertedJobs[k].Job.ID
+			externalIDs[k] = allJobArgs[i].ExternalID
+		}
+		_, err = tx.Exec(ctx, `
+			INSERT INTO uuid_job_mapping (uuid, river_job_id, external_id)
+			SELECT * FROM unnest($1::uuid[], $2::bigint[], $3::text[])`,
+			uuids, riverJobIDs, externalIDs)
+		if err != nil {
+			return virest.CreateInfoBatch500JSONResponse{
+				Code:    "INTERNAL_ERROR",
+				Message: fmt.Sprintf("failed to insert uuid mappings: %v", err),
+			}, nil
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return virest.CreateInfoBatch500JSONResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: fmt.Sprintf("failed to commit transaction: %v", err),
+		}, nil
+	}
+	s.publisher.PublishCreated(insertedJobs...)
+
+	for k, i := range accepted {
+		job := newPendingInfoJob(&items[i], &allJobArgs[i], insertedJobs[k].Job.CreatedAt)
+		results[i].Status = http.StatusCreated
+		results[i].Job = &job
+	}
+	return virest.CreateInfoBatch200JSONResponse{
+		Body:    virest.InfoBatchResult{Items: results},
+		Headers: virest.CreateInfoBatch200ResponseHeaders{XQueueDepth: depth},
+	}, nil
+}
+
+// findTakenIDs returns which of the UUIDs and external IDs of the given jobs
+// already belong to existing jobs.
+func findTakenIDs(ctx context.Context, tx pgx.Tx, allJobArgs []internal.InfoJobArgs) (map[uuid.UUID]bool, map[string]bool, error) {
+	var (
+		uuids       []uuid.UUID
+		externalIDs []string
+	)
+	for _, jobArgs := range allJobArgs {
+		uuids = append(uuids, jobArgs.UUID)
+		if jobArgs.ExternalID != nil {
+			externalIDs = append(externalIDs, *jobArgs.ExternalID)
+		}
+	}
+
+	rows, err := tx.Query(ctx, "SELECT uuid, external_id FROM uuid_job_mapping WHERE uuid = ANY($1) OR external_id = ANY($2)", uuids, externalIDs)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to check existing IDs: %w", err)
+	}
+	defer rows.Close()
+
+	takenUUIDs := make(map[uuid.UUID]bool)
+	takenExternalIDs := make(map[string]bool)
+	for rows.Next() {
+		var (
+			id         uuid.UUID
+			externalID *string
+		)
+		if err := rows.Scan(&id, &externalID); err != nil {
+			return nil, nil, fmt.Errorf("failed to scan existing IDs: %w", err)
+		}
+		takenUUIDs[id] = true
+		if externalID != nil {
+			takenExternalIDs[*externalID] = true
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, fmt.Errorf("failed to check existing IDs: %w", err)
+	}
+	return takenUUIDs, takenExternalIDs, nil
+}
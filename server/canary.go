@@ -0,0 +1,167 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/krelinga/video-info/internal"
+	"github.com/krelinga/video-info/virest"
+)
+
+// Limits on canary reports.
+const (
+	defaultCanaryReportWindow = 24 * time.Hour
+	maxCanaryReportResults    = 1000
+	maxCanaryDifferences      = 100
+)
+
+// routeToCanary routes the configured share of new jobs bound for the
+// default queue to the canary queue.  Jobs that asked for a queue or a GPU
+// keep them.
+func (s *Server) routeToCanary(jobArgs *internal.InfoJobArgs) {
+	if s.cfg.CanaryPercent <= 0 || jobArgs.QueueName != "" || jobArgs.Resources == virest.Gpu {
+		return
+	}
+	if rand.IntN(100) >= s.cfg.CanaryPercent {
+		return
+	}
+	jobArgs.QueueName = s.cfg.CanaryQueue
+	jobArgs.Canary = true
+	jobArgs.Force = true
+}
+
+// GetCanaryReport handles GET /admin/canary-report requests.
+func (s *Server) GetCanaryReport(ctx context.Context, request virest.GetCanaryReportRequestObject) (virest.GetCanaryReportResponseObject, error) {
+	since := s.clock.Now().Add(-defaultCanaryReportWindow)
+	if request.Params.Since != nil {
+		since = *request.Params.Since
+	}
+
+	// Each canary result is compared with the latest result for its path
+	// that no canary or comparison produced
+	rows, err := s.readPool.Query(ctx, `
+		SELECT canary.uuid, canary.video_path, canary.output, baseline.uuid, baseline.output
+		FROM info_result AS canary
+		LEFT JOIN LATERAL (
+			SELECT uuid, output FROM info_result
+			WHERE video_path = canary.video_path AND NOT args ? 'canary' AND NOT args ? 'comparison'
+			ORDER BY finalized_at DESC, uuid DESC
+			LIMIT 1
+		) AS baseline ON true
+		WHERE canary.args ? 'canary' AND canary.finalized_at >= $1
+		ORDER BY canary.finalized_at DESC, canary.uuid DESC
+		LIMIT $2`,
+		since, maxCanaryReportResults+1)
+	if err != nil {
+		return virest.GetCanaryReport500JSONResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: fmt.Sprintf("failed to query canary results: %v", err),
+		}, nil
+	}
+	defer rows.Close()
+
+	report := virest.GetCanaryReport200JSONResponse{Differences: []virest.CanaryDifference{}}
+	if s.cfg.CanaryPercent > 0 {
+		report.Queue = &s.cfg.CanaryQueue
+		report.Percent = &s.cfg.CanaryPercent
+	}
+	var n int
+	for rows.Next() {
+		if n++; n > maxCanaryReportResults {
+			report.Truncated = true
+			break
+		}
+		var (
+			canaryUUID     uuid.UUID
+			videoPath      string
+			canaryOutput   []byte
+			baselineUUID   *uuid.UUID
+			baselineOutput []byte
+		)
+		if err := rows.Scan(&canaryUUID, &videoPath, &canaryOutput, &baselineUUID, &baselineOutput); err != nil {
+			return virest.GetCanaryReport500JSONResponse{
+				Code:    "INTERNAL_ERROR",
+				Message: fmt.Sprintf("failed to scan canary result: %v", err),
+			}, nil
+		}
+		if baselineUUID == nil {
+			report.NoBaseline++
+			continue
+		}
+
+		var canary, baseline internal.InfoJobStatus
+		if err := json.Unmarshal(canaryOutput, &canary); err != nil {
+			return virest.GetCanaryReport500JSONResponse{
+				Code:    "INTERNAL_ERROR",
+				Message: fmt.Sprintf("failed to unmarshal canary result %s: %v", canaryUUID, err),
+			}, nil
+		}
+		if err := json.Unmarshal(baselineOutput, &baseline); err != nil {
+			return virest.GetCanaryReport500JSONResponse{
+				Code:    "INTERNAL_ERROR",
+				Message: fmt.Sprintf("failed to unmarshal baseline result %s: %v", *baselineUUID, err),
+			}, nil
+		}
+		changed, err := canaryChangedFields(&canary, &baseline)
+		if err != nil {
+			return virest.GetCanaryReport500JSONResponse{
+				Code:    "INTERNAL_ERROR",
+				Message: err.Error(),
+			}, nil
+		}
+
+		report.Compared++
+		if len(changed) == 0 {
+			report.Identical++
+			continue
+		}
+		report.Differing++
+		if len(report.Differences) < maxCanaryDifferences {
+			report.Differences = append(report.Differences, virest.CanaryDifference{
+				VideoPath:     videoPath,
+				CanaryUuid:    canaryUUID,
+				BaselineUuid:  *baselineUUID,
+				ChangedFields: changed,
+				CanaryError:   canary.Error,
+				BaselineError: baseline.Error,
+			})
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return virest.GetCanaryReport500JSONResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: fmt.Sprintf("failed to query canary results: %v", err),
+		}, nil
+	}
+	return report, nil
+}
+
+// canaryChangedFields returns the MediaInfo properties that differ between a
+// canary's outcome and its baseline's, ignoring their versions, or "error" if
+// either failed and they didn't fail the same way.
+func canaryChangedFields(canary, baseline *internal.InfoJobStatus) ([]string, error) {
+	if canary.Result == nil || baseline.Result == nil {
+		if canary.Result == nil && baseline.Result == nil && equalStrings(canary.Error, baseline.Error) && equalStrings(canary.ErrorCode, baseline.ErrorCode) {
+			return nil, nil
+		}
+		return []string{"error"}, nil
+	}
+	changed, err := internal.ChangedFields(baseline.Result, canary.Result)
+	if err != nil {
+		return nil, err
+	}
+	return slices.DeleteFunc(changed, func(name string) bool { return name == "versions" }), nil
+}
+
+// equalStrings reports whether two optional strings are both unset or equal.
+func equalStrings(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
@@ -0,0 +1,137 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/krelinga/go-libs/deep"
+	"github.com/krelinga/go-libs/exam"
+	"github.com/krelinga/video-info/internal"
+	"github.com/krelinga/video-info/virest"
+)
+
+func TestRouteToCanary(t *testing.T) {
+	e := exam.New(t)
+	env := deep.NewEnv()
+
+	tests := []struct {
+		loc        exam.Loc
+		name       string
+		percent    int
+		args       internal.InfoJobArgs
+		wantQueue  string
+		wantCanary bool
+	}{
+		{
+			loc:        exam.Here(),
+			name:       "Routed",
+			percent:    100,
+			wantQueue:  "canary",
+			wantCanary: true,
+		},
+		{
+			loc:     exam.Here(),
+			name:    "Disabled",
+			percent: 0,
+		},
+		{
+			loc:       exam.Here(),
+			name:      "Named queue",
+			percent:   100,
+			args:      internal.InfoJobArgs{QueueName: "bulk"},
+			wantQueue: "bulk",
+		},
+		{
+			loc:     exam.Here(),
+			name:    "GPU",
+			percent: 100,
+			args:    internal.InfoJobArgs{Resources: virest.Gpu},
+		},
+	}
+	for _, tt := range tests {
+		e.Run(tt.name, func(e exam.E) {
+			e.Log("Running test at", tt.loc)
+			s := newTestServer(e, &fakeStore{}, &fakeQueue{}, &internal.ServerConfig{CanaryQueue: "canary", CanaryPercent: tt.percent})
+			args := tt.args
+			s.routeToCanary(&args)
+			exam.Equal(e, env, tt.wantQueue, args.QueueName)
+			exam.Equal(e, env, tt.wantCanary, args.Canary)
+			exam.Equal(e, env, tt.wantCanary, args.Force)
+		})
+	}
+
+	e.Run("Invalid config", func(e exam.E) {
+		_, err := NewServer(&fakeStore{}, &fakeQueue{}, &internal.ServerConfig{CanaryPercent: 5})
+		exam.Equal(e, env, true, err != nil)
+	})
+}
+
+func TestGetCanaryReport(t *testing.T) {
+	e := exam.New(t)
+	env := deep.NewEnv()
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	output := func(status internal.InfoJobStatus) []byte {
+		encoded, err := json.Marshal(status)
+		if err != nil {
+			e.Fatal(err)
+		}
+		return encoded
+	}
+	result := func(codec string, worker string) internal.InfoJobStatus {
+		return internal.InfoJobStatus{Result: &internal.InfoJobResult{
+			DurationSeconds: 60,
+			VideoStreams:    []internal.VideoStream{{CodecName: codec}},
+			Versions:        &internal.ToolVersions{Worker: worker},
+		}}
+	}
+	errMsg := "no such file"
+	failed := internal.InfoJobStatus{Error: &errMsg}
+
+	identical, differing, regressed, missing := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	baseline1, baseline2, baseline3 := uuid.New(), uuid.New(), uuid.New()
+	rows := [][]any{
+		{identical, "/a.mkv", output(result("h264", "v2")), &baseline1, output(result("h264", "v1"))},
+		{differing, "/b.mkv", output(result("hevc", "v2")), &baseline2, output(result("h264", "v1"))},
+		{regressed, "/c.mkv", output(failed), &baseline3, output(result("h264", "v1"))},
+		{missing, "/d.mkv", output(result("h264", "v2")), nil, nil},
+	}
+
+	var gotArgs []any
+	store := &fakeStore{
+		query: func(sql string, args []any) (pgx.Rows, error) {
+			if strings.Contains(sql, "info_result") {
+				gotArgs = args
+				return &fakeRows{rows: rows}, nil
+			}
+			return &fakeRows{}, nil
+		},
+	}
+	s := newTestServer(e, store, &fakeQueue{}, &internal.ServerConfig{CanaryQueue: "canary", CanaryPercent: 5})
+	s.clock = internal.NewFakeClock(now)
+	resp, err := s.GetCanaryReport(context.Background(), virest.GetCanaryReportRequestObject{})
+	exam.Nil(e, env, err)
+	got, ok := resp.(virest.GetCanaryReport200JSONResponse)
+	if !ok {
+		e.Fatalf("got %T, want 200", resp)
+	}
+	exam.Equal(e, env, true, gotArgs[0].(time.Time).Equal(now.Add(-24*time.Hour)))
+	exam.Equal(e, env, 3, got.Compared)
+	exam.Equal(e, env, 1, got.Identical)
+	exam.Equal(e, env, 2, got.Differing)
+	exam.Equal(e, env, 1, got.NoBaseline)
+	exam.Equal(e, env, false, got.Truncated)
+	if len(got.Differences) != 2 {
+		e.Fatalf("got %d differences, want 2", len(got.Differences))
+	}
+	exam.Equal(e, env, differing.String(), got.Differences[0].CanaryUuid.String())
+	exam.Equal(e, env, []string{"videoStreams"}, got.Differences[0].ChangedFields)
+	exam.Equal(e, env, regressed.String(), got.Differences[1].CanaryUuid.String())
+	exam.Equal(e, env, []string{"error"}, got.Differences[1].ChangedFields)
+	exam.Equal(e, env, &errMsg, got.Differences[1].CanaryError)
+}
@@ -0,0 +1,201 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/krelinga/video-info/internal"
+	"github.com/krelinga/video-info/virest"
+	"github.com/riverqueue/river/rivertype"
+)
+
+// CreateComparison handles POST /admin/comparisons requests.
+func (s *Server) CreateComparison(ctx context.Context, request virest.CreateComparisonRequestObject) (virest.CreateComparisonResponseObject, error) {
+	body := request.Body
+	if body == nil {
+		return virest.CreateComparison400JSONResponse{
+			Code:    "INVALID_REQUEST",
+			Message: "Request body is required",
+		}, nil
+	}
+
+	id := uuid.New()
+	sides := []struct {
+		name    string
+		request virest.InfoRequest
+		args    internal.InfoJobArgs
+	}{
+		{name: "baseline", request: comparisonInfoRequest(body, body.Baseline)},
+		{name: "candidate", request: comparisonInfoRequest(body, body.Candidate)},
+	}
+	for i := range sides {
+		jobArgs, invalid, err := s.infoJobArgs(ctx, &sides[i].request)
+		if err != nil {
+			return virest.CreateComparison500JSONResponse{
+				Code:    "INTERNAL_ERROR",
+				Message: err.Error(),
+			}, nil
+		} else if invalid != nil {
+			return virest.CreateComparison400JSONResponse{
+				Code:    invalid.Code,
+				Message: fmt.Sprintf("%s: %s", sides[i].name, invalid.Message),
+			}, nil
+		}
+		jobArgs.Comparison = &id
+		sides[i].args = jobArgs
+	}
+
+	depth, err := s.queueDepth(ctx)
+	if err != nil {
+		return virest.CreateComparison500JSONResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: err.Error(),
+		}, nil
+	} else if full := s.queueFull(depth); full != nil {
+		return virest.CreateComparison429JSONResponse{QueueFullJSONResponse: *full}, nil
+	}
+
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return virest.CreateComparison500JSONResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: fmt.Sprintf("failed to begin transaction: %v", err),
+		}, nil
+	}
+	defer tx.Rollback(ctx)
+
+	// The jobs' UUIDs are new, so they can't be taken
+	var insertedJobs []*rivertype.JobInsertResult
+	for _, side := range sides {
+		insertedJob, err := s.riverClient.InsertTx(ctx, tx, side.args, nil)
+		if err != nil {
+			return virest.CreateComparison500JSONResponse{
+				Code:    "INTERNAL_ERROR",
+				Message: fmt.Sprintf("failed to insert river job: %v", err),
+			}, nil
+		}
+		_, err = tx.Exec(ctx, "INSERT INTO uuid_job_mapping (uuid, river_job_id) VALUES ($1, $2)", side.args.UUID, insertedJob.Job.ID)
+		if err != nil {
+			return virest.CreateComparison500JSONResponse{
+				Code:    "INTERNAL_ERROR",
+				Message: fmt.Sprintf("failed to insert uuid mapping: %v", err),
+			}, nil
+		}
+		insertedJobs = append(insertedJobs, insertedJob)
+	}
+
+	now := s.clock.Now()
+	_, err = tx.Exec(ctx, `
+		INSERT INTO comparisons (id, video_path, baseline_uuid, candidate_uuid, created_at)
+		VALUES ($1, $2, $3, $4, $5)`,
+		id, body.VideoPath, sides[0].args.UUID, sides[1].args.UUID, now)
+	if err != nil {
+		return virest.CreateComparison500JSONResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: fmt.Sprintf("failed to insert comparison: %v", err),
+		}, nil
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return virest.CreateComparison500JSONResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: fmt.Sprintf("failed to commit transaction: %v", err),
+		}, nil
+	}
+	s.publisher.PublishCreated(insertedJobs...)
+
+	return virest.CreateComparison201JSONResponse{
+		Id:        id,
+		VideoPath: body.VideoPath,
+		CreatedAt: now,
+		Baseline:  newPendingInfoJob(&sides[0].request, &sides[0].args, insertedJobs[0].Job.CreatedAt),
+		Candidate: newPendingInfoJob(&sides[1].request, &sides[1].args, insertedJobs[1].Job.CreatedAt),
+	}, nil
+}
+
+// comparisonInfoRequest returns the request for the info job of one side of
+// a comparison.  Both sides always probe the file.
+func comparisonInfoRequest(body *virest.ComparisonRequest, side virest.ComparisonSide) virest.InfoRequest {
+	force := true
+	return virest.InfoRequest{
+		Uuid:            uuid.New(),
+		VideoPath:       body.VideoPath,
+		Labels:          body.Labels,
+		Force:           &force,
+		Queue:           side.Queue,
+		Resources:       side.Resources,
+		AnalyzeCrop:     side.AnalyzeCrop,
+		AnalyzeLoudness: side.AnalyzeLoudness,
+		Verify:          side.Verify,
+		SampleWindows:   side.SampleWindows,
+		Checksum:        side.Checksum,
+		Analyzers:       side.Analyzers,
+		IncludeRaw:      side.IncludeRaw,
+	}
+}
+
+// GetComparison handles GET /admin/comparisons/{id} requests.
+func (s *Server) GetComparison(ctx context.Context, request virest.GetComparisonRequestObject) (virest.GetComparisonResponseObject, error) {
+	const query = "SELECT video_path, baseline_uuid, candidate_uuid, created_at FROM comparisons WHERE id = $1"
+	var (
+		comparison                  virest.Comparison
+		baselineUUID, candidateUUID uuid.UUID
+	)
+	scan := func(pool JobStore) error {
+		return pool.QueryRow(ctx, query, request.Id).Scan(&comparison.VideoPath, &baselineUUID, &candidateUUID, &comparison.CreatedAt)
+	}
+	err := scan(s.readPool)
+	if errors.Is(err, pgx.ErrNoRows) && s.hasReadReplica() {
+		err = scan(s.pool)
+	}
+	if errors.Is(err, pgx.ErrNoRows) {
+		return virest.GetComparison404JSONResponse{
+			Code:    "NOT_FOUND",
+			Message: fmt.Sprintf("Comparison %s not found", request.Id),
+		}, nil
+	} else if err != nil {
+		return virest.GetComparison500JSONResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: fmt.Sprintf("failed to look up comparison: %v", err),
+		}, nil
+	}
+	comparison.Id = request.Id
+
+	for _, side := range []struct {
+		uuid uuid.UUID
+		job  *virest.InfoJob
+	}{
+		{baselineUUID, &comparison.Baseline},
+		{candidateUUID, &comparison.Candidate},
+	} {
+		infoJob, err := s.findInfoJob(ctx, "uuid", side.uuid)
+		if errors.Is(err, errJobNotFound) {
+			return virest.GetComparison404JSONResponse{
+				Code:    "NOT_FOUND",
+				Message: fmt.Sprintf("Info job with UUID %s of comparison %s no longer exists", side.uuid, request.Id),
+			}, nil
+		} else if err != nil {
+			return virest.GetComparison500JSONResponse{
+				Code:    "INTERNAL_ERROR",
+				Message: err.Error(),
+			}, nil
+		}
+		*side.job = *infoJob
+	}
+
+	if comparison.Baseline.Result != nil && comparison.Candidate.Result != nil {
+		differences, err := internal.DiffMediaInfo(comparison.Baseline.Result, comparison.Candidate.Result)
+		if err != nil {
+			return virest.GetComparison500JSONResponse{
+				Code:    "INTERNAL_ERROR",
+				Message: err.Error(),
+			}, nil
+		}
+		comparison.Compared = true
+		comparison.Differences = differences
+	}
+	return virest.GetComparison200JSONResponse(comparison), nil
+}
@@ -0,0 +1,188 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/krelinga/go-libs/deep"
+	"github.com/krelinga/go-libs/exam"
+	"github.com/krelinga/video-info/internal"
+	"github.com/krelinga/video-info/virest"
+	"github.com/riverqueue/river/rivertype"
+)
+
+func TestCreateComparison(t *testing.T) {
+	e := exam.New(t)
+	env := deep.NewEnv()
+	cfg := &internal.ServerConfig{AllowedRoots: internal.AllowedRoots{"/videos/"}}
+	newQueue := virest.Queue("ffprobe-next")
+	invalidQueue := virest.Queue("Not A Queue")
+	analyzeCrop := true
+
+	e.Run("Created", func(e exam.E) {
+		tx := newCreateTx()
+		var comparisonArgs []any
+		tx.exec = func(sql string, args []any) error {
+			if strings.Contains(sql, "INSERT INTO comparisons") {
+				comparisonArgs = args
+			}
+			return nil
+		}
+		store := &fakeStore{begin: func() (pgx.Tx, error) { return tx, nil }}
+		createdAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+		queue := &fakeQueue{createdAt: createdAt}
+		s := newTestServer(e, store, queue, cfg)
+		resp, err := s.CreateComparison(context.Background(), virest.CreateComparisonRequestObject{Body: &virest.ComparisonRequest{
+			VideoPath: "/videos/a.mkv",
+			Baseline:  virest.ComparisonSide{AnalyzeCrop: &analyzeCrop},
+			Candidate: virest.ComparisonSide{Queue: &newQueue, AnalyzeCrop: &analyzeCrop},
+		}})
+		exam.Nil(e, env, err)
+		got, ok := resp.(virest.CreateComparison201JSONResponse)
+		if !ok {
+			e.Fatalf("got %T, want 201", resp)
+		}
+		exam.Equal(e, env, true, tx.committed)
+		if len(queue.inserted) != 2 {
+			e.Fatalf("got %d jobs, want 2", len(queue.inserted))
+		}
+		baseline := queue.inserted[0].(internal.InfoJobArgs)
+		candidate := queue.inserted[1].(internal.InfoJobArgs)
+		exam.Equal(e, env, "", baseline.QueueName)
+		exam.Equal(e, env, "ffprobe-next", candidate.QueueName)
+		for _, jobArgs := range []internal.InfoJobArgs{baseline, candidate} {
+			exam.Equal(e, env, "/videos/a.mkv", jobArgs.Path)
+			exam.Equal(e, env, true, jobArgs.Force)
+			exam.Equal(e, env, true, jobArgs.AnalyzeCrop)
+			exam.Equal(e, env, got.Id.String(), jobArgs.Comparison.String())
+		}
+		exam.Equal(e, env, baseline.UUID.String(), got.Baseline.Uuid.String())
+		exam.Equal(e, env, candidate.UUID.String(), got.Candidate.Uuid.String())
+		exam.Equal(e, env, true, got.Baseline.CreatedAt.Equal(createdAt))
+		exam.Equal(e, env, true, got.Candidate.CreatedAt.Equal(createdAt))
+		if len(comparisonArgs) != 5 {
+			e.Fatalf("got %d comparison args, want 5", len(comparisonArgs))
+		}
+		exam.Equal(e, env, true, comparisonArgs[0] == got.Id)
+		exam.Equal(e, env, true, comparisonArgs[2] == baseline.UUID)
+		exam.Equal(e, env, true, comparisonArgs[3] == candidate.UUID)
+	})
+
+	e.Run("Invalid side", func(e exam.E) {
+		s := newTestServer(e, &fakeStore{}, &fakeQueue{}, cfg)
+		resp, err := s.CreateComparison(context.Background(), virest.CreateComparisonRequestObject{Body: &virest.ComparisonRequest{
+			VideoPath: "/videos/a.mkv",
+			Candidate: virest.ComparisonSide{Queue: &invalidQueue},
+		}})
+		exam.Nil(e, env, err)
+		got, ok := resp.(virest.CreateComparison400JSONResponse)
+		if !ok {
+			e.Fatalf("got %T, want 400", resp)
+		}
+		exam.Equal(e, env, true, strings.HasPrefix(got.Message, "candidate: "))
+	})
+
+	e.Run("Path outside roots", func(e exam.E) {
+		s := newTestServer(e, &fakeStore{}, &fakeQueue{}, cfg)
+		resp, err := s.CreateComparison(context.Background(), virest.CreateComparisonRequestObject{Body: &virest.ComparisonRequest{
+			VideoPath: "/etc/passwd",
+		}})
+		exam.Nil(e, env, err)
+		if _, ok := resp.(virest.CreateComparison400JSONResponse); !ok {
+			e.Fatalf("got %T, want 400", resp)
+		}
+	})
+}
+
+func TestGetComparison(t *testing.T) {
+	e := exam.New(t)
+	env := deep.NewEnv()
+	createdAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	compared, pending, missing := uuid.New(), uuid.New(), uuid.New()
+	baseline, candidate, running := uuid.New(), uuid.New(), uuid.New()
+
+	outputs := make(map[uuid.UUID][]byte)
+	for id, status := range map[uuid.UUID]internal.InfoJobStatus{
+		baseline:  {Result: &internal.InfoJobResult{DurationSeconds: 60}},
+		candidate: {Result: &internal.InfoJobResult{DurationSeconds: 61}},
+	} {
+		output, err := json.Marshal(status)
+		exam.Nil(e, env, err)
+		outputs[id] = output
+	}
+	sides := map[uuid.UUID][2]uuid.UUID{
+		compared: {baseline, candidate},
+		pending:  {baseline, running},
+	}
+	store := &fakeStore{
+		queryRow: func(sql string, args []any) pgx.Row {
+			switch {
+			case strings.Contains(sql, "FROM comparisons"):
+				if ids, ok := sides[args[0].(uuid.UUID)]; ok {
+					return fakeRow{values: []any{"/videos/a.mkv", ids[0], ids[1], createdAt}}
+				}
+			case strings.Contains(sql, "uuid_job_mapping"):
+				if args[0].(uuid.UUID) == running {
+					return fakeRow{values: []any{int64(1)}}
+				}
+			case strings.Contains(sql, "info_result"):
+				id := args[0].(uuid.UUID)
+				if output, ok := outputs[id]; ok {
+					encodedArgs, _ := json.Marshal(internal.InfoJobArgs{UUID: id, Path: "/videos/a.mkv"})
+					return fakeRow{values: []any{encodedArgs, output, 1, createdAt, createdAt.Add(time.Minute)}}
+				}
+			}
+			return fakeRow{err: pgx.ErrNoRows}
+		},
+		query: func(string, []any) (pgx.Rows, error) {
+			return &fakeRows{}, nil
+		},
+	}
+	runningArgs, err := json.Marshal(internal.InfoJobArgs{UUID: running, Path: "/videos/a.mkv"})
+	exam.Nil(e, env, err)
+	queue := &fakeQueue{jobs: map[int64]*rivertype.JobRow{
+		1: {ID: 1, State: rivertype.JobStateRunning, EncodedArgs: runningArgs, CreatedAt: createdAt},
+	}}
+	s := newTestServer(e, store, queue, &internal.ServerConfig{})
+	get := func(id uuid.UUID) virest.GetComparisonResponseObject {
+		resp, err := s.GetComparison(context.Background(), virest.GetComparisonRequestObject{Id: id})
+		exam.Nil(e, env, err)
+		return resp
+	}
+
+	e.Run("Compared", func(e exam.E) {
+		got, ok := get(compared).(virest.GetComparison200JSONResponse)
+		if !ok {
+			e.Fatalf("got %T, want 200", got)
+		}
+		exam.Equal(e, env, true, got.Compared)
+		paths := make([]string, len(got.Differences))
+		for i, d := range got.Differences {
+			paths[i] = d.Path
+		}
+		exam.Equal(e, env, []string{"totalDurationSeconds"}, paths)
+	})
+
+	e.Run("Pending", func(e exam.E) {
+		got, ok := get(pending).(virest.GetComparison200JSONResponse)
+		if !ok {
+			e.Fatalf("got %T, want 200", got)
+		}
+		exam.Equal(e, env, false, got.Compared)
+		exam.Equal(e, env, virest.Running, got.Candidate.Status)
+		exam.Equal(e, env, 0, len(got.Differences))
+	})
+
+	e.Run("Not found", func(e exam.E) {
+		if resp := get(missing); resp == nil {
+			e.Fatal("got nil response")
+		} else if _, ok := resp.(virest.GetComparison404JSONResponse); !ok {
+			e.Fatalf("got %T, want 404", resp)
+		}
+	})
+}
@@ -0,0 +1,170 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/krelinga/video-info/internal"
+	"github.com/krelinga/video-info/virest"
+	"github.com/riverqueue/river/rivertype"
+)
+
+// failedJob is a failed info job found by queryFailures.
+type failedJob struct {
+	RiverJobID int64
+	internal.Failure
+}
+
+// failedJobCondition is an SQL condition on river_job that holds for failed
+// info jobs.  $2 must be the output metadata key.
+const failedJobCondition = `(river_job.state = 'discarded'
+	OR (river_job.state = 'completed' AND river_job.metadata->$2 ? 'error'))`
+
+// queryFailures returns the failed info jobs whose path starts with
+// pathPrefix and that were created at or after createdAfter, when those are
+// set.  Jobs fail either by completing with an error, when the probe itself
+// failed, or by being discarded after River ran out of attempts.
+func queryFailures(ctx context.Context, pool JobStore, pathPrefix *string, createdAfter *time.Time) ([]failedJob, error) {
+	rows, err := pool.Query(ctx, `
+		SELECT id, args->>'path',
+			COALESCE(metadata->$2->>'error', errors[array_length(errors, 1)]->>'error', ''),
+			metadata->$2->>'error_code'
+		FROM river_job
+		WHERE kind = $1 AND `+failedJobCondition+`
+			AND EXISTS (SELECT 1 FROM uuid_job_mapping WHERE uuid_job_mapping.river_job_id = river_job.id)
+			AND ($3::text IS NULL OR starts_with(args->>'path', $3))
+			AND ($4::timestamptz IS NULL OR created_at >= $4)
+		ORDER BY id`,
+		internal.InfoJobArgs{}.Kind(), rivertype.MetadataKeyOutput, pathPrefix, createdAfter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query failed jobs: %w", err)
+	}
+	defer rows.Close()
+
+	var failures []failedJob
+	for rows.Next() {
+		var f failedJob
+		if err := rows.Scan(&f.RiverJobID, &f.Path, &f.Error, &f.ErrorCode); err != nil {
+			return nil, fmt.Errorf("failed to scan failed job: %w", err)
+		}
+		// River's own errors are stored unredacted
+		f.Error = internal.Redact(f.Error)
+		failures = append(failures, f)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to query failed jobs: %w", err)
+	}
+	return failures, nil
+}
+
+// ListFailures handles GET /admin/failures requests.
+func (s *Server) ListFailures(ctx context.Context, request virest.ListFailuresRequestObject) (virest.ListFailuresResponseObject, error) {
+	failedJobs, err := queryFailures(ctx, s.readPool, request.Params.PathPrefix, request.Params.CreatedAfter)
+	if err != nil {
+		return virest.ListFailures500JSONResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: err.Error(),
+		}, nil
+	}
+
+	failures := make([]internal.Failure, 0, len(failedJobs))
+	for _, f := range failedJobs {
+		failures = append(failures, f.Failure)
+	}
+	groups := internal.GroupFailures(failures)
+
+	summary := virest.FailureSummary{
+		TotalFailures: len(failures),
+		Groups:        make([]virest.FailureGroup, 0, len(groups)),
+	}
+	for _, g := range groups {
+		summary.Groups = append(summary.Groups, virest.FailureGroup{
+			ErrorCode:        g.ErrorCode,
+			Fingerprint:      g.Fingerprint,
+			Message:          g.Message,
+			Count:            g.Count,
+			ExamplePaths:     g.ExamplePaths,
+			CommonPathPrefix: g.CommonPathPrefix,
+		})
+	}
+	return virest.ListFailures200JSONResponse(summary), nil
+}
+
+// RetryFailures handles POST /admin/failures/retry requests.
+func (s *Server) RetryFailures(ctx context.Context, request virest.RetryFailuresRequestObject) (virest.RetryFailuresResponseObject, error) {
+	if request.Body == nil || (request.Body.ErrorCode == nil && request.Body.Fingerprint == nil && request.Body.PathPrefix == nil) {
+		return virest.RetryFailures400JSONResponse{
+			Code:    "INVALID_REQUEST",
+			Message: "at least one of errorCode, fingerprint or pathPrefix is required",
+		}, nil
+	}
+	body := request.Body
+
+	failedJobs, err := queryFailures(ctx, s.pool, body.PathPrefix, nil)
+	if err != nil {
+		return virest.RetryFailures500JSONResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: err.Error(),
+		}, nil
+	}
+	var riverJobIDs []int64
+	for _, f := range failedJobs {
+		if body.ErrorCode != nil && f.Class() != *body.ErrorCode {
+			continue
+		}
+		if body.Fingerprint != nil && f.Fingerprint() != *body.Fingerprint {
+			continue
+		}
+		riverJobIDs = append(riverJobIDs, f.RiverJobID)
+	}
+
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return virest.RetryFailures500JSONResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: fmt.Sprintf("failed to begin transaction: %v", err),
+		}, nil
+	}
+	defer tx.Rollback(ctx)
+
+	// Lock the jobs, skipping any that stopped being failures since they were
+	// queried, such as those retried concurrently
+	rows, err := tx.Query(ctx, `
+		SELECT id FROM river_job
+		WHERE id = ANY($1) AND `+failedJobCondition+`
+		ORDER BY id
+		FOR UPDATE`,
+		riverJobIDs, rivertype.MetadataKeyOutput)
+	if err != nil {
+		return virest.RetryFailures500JSONResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: fmt.Sprintf("failed to lock failed jobs: %v", err),
+		}, nil
+	}
+	lockedIDs, err := pgx.CollectRows(rows, pgx.RowTo[int64])
+	if err != nil {
+		return virest.RetryFailures500JSONResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: fmt.Sprintf("failed to lock failed jobs: %v", err),
+		}, nil
+	}
+
+	for _, riverJobID := range lockedIDs {
+		if err := s.retryJobTx(ctx, tx, riverJobID); err != nil {
+			return virest.RetryFailures500JSONResponse{
+				Code:    "INTERNAL_ERROR",
+				Message: err.Error(),
+			}, nil
+		}
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return virest.RetryFailures500JSONResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: fmt.Sprintf("failed to commit transaction: %v", err),
+		}, nil
+	}
+
+	return virest.RetryFailures200JSONResponse{RetriedJobs: len(lockedIDs)}, nil
+}
@@ -0,0 +1,201 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/riverqueue/river"
+	"github.com/riverqueue/river/rivertype"
+)
+
+// errUnexpectedCall is returned by fakes for calls a test didn't set up.
+var errUnexpectedCall = errors.New("unexpected call")
+
+// fakeStore is an in-memory JobStore.  Each method calls the matching
+// function, failing with errUnexpectedCall if it isn't set.
+type fakeStore struct {
+	begin    func() (pgx.Tx, error)
+	exec     func(sql string, args []any) error
+	query    func(sql string, args []any) (pgx.Rows, error)
+	queryRow func(sql string, args []any) pgx.Row
+	ping     error
+}
+
+func (f *fakeStore) Begin(context.Context) (pgx.Tx, error) {
+	if f.begin == nil {
+		return nil, fmt.Errorf("%w: Begin", errUnexpectedCall)
+	}
+	return f.begin()
+}
+
+func (f *fakeStore) Exec(_ context.Context, sql string, args ...any) (pgconn.CommandTag, error) {
+	if f.exec == nil {
+		return pgconn.CommandTag{}, fmt.Errorf("%w: Exec %s", errUnexpectedCall, sql)
+	}
+	return pgconn.CommandTag{}, f.exec(sql, args)
+}
+
+func (f *fakeStore) Query(_ context.Context, sql string, args ...any) (pgx.Rows, error) {
+	if f.query == nil {
+		return nil, fmt.Errorf("%w: Query %s", errUnexpectedCall, sql)
+	}
+	return f.query(sql, args)
+}
+
+func (f *fakeStore) QueryRow(_ context.Context, sql string, args ...any) pgx.Row {
+	if f.queryRow == nil {
+		return fakeRow{err: fmt.Errorf("%w: QueryRow %s", errUnexpectedCall, sql)}
+	}
+	return f.queryRow(sql, args)
+}
+
+func (f *fakeStore) Ping(context.Context) error {
+	return f.ping
+}
+
+// fakeTx is an in-memory transaction.  Methods it doesn't override panic.
+type fakeTx struct {
+	pgx.Tx
+	exec      func(sql string, args []any) error
+	query     func(sql string, args []any) (pgx.Rows, error)
+	queryRow  func(sql string, args []any) pgx.Row
+	committed bool
+}
+
+func (f *fakeTx) Exec(_ context.Context, sql string, args ...any) (pgconn.CommandTag, error) {
+	if f.exec == nil {
+		return pgconn.CommandTag{}, fmt.Errorf("%w: Exec %s", errUnexpectedCall, sql)
+	}
+	return pgconn.CommandTag{}, f.exec(sql, args)
+}
+
+func (f *fakeTx) Query(_ context.Context, sql string, args ...any) (pgx.Rows, error) {
+	if f.query == nil {
+		return nil, fmt.Errorf("%w: Query %s", errUnexpectedCall, sql)
+	}
+	return f.query(sql, args)
+}
+
+func (f *fakeTx) QueryRow(_ context.Context, sql string, args ...any) pgx.Row {
+	if f.queryRow == nil {
+		return fakeRow{err: fmt.Errorf("%w: QueryRow %s", errUnexpectedCall, sql)}
+	}
+	return f.queryRow(sql, args)
+}
+
+func (f *fakeTx) Commit(context.Context) error {
+	f.committed = true
+	return nil
+}
+
+func (f *fakeTx) Rollback(context.Context) error {
+	return nil
+}
+
+// fakeRow is a single result row, or an error such as pgx.ErrNoRows.
+type fakeRow struct {
+	values []any
+	err    error
+}
+
+func (r fakeRow) Scan(dest ...any) error {
+	if r.err != nil {
+		return r.err
+	}
+	return scanValues(r.values, dest)
+}
+
+// fakeRows is a result set.  Methods it doesn't override panic.
+type fakeRows struct {
+	pgx.Rows
+	rows [][]any
+	next int
+}
+
+func (r *fakeRows) Next() bool {
+	r.next++
+	return r.next <= len(r.rows)
+}
+
+func (r *fakeRows) Scan(dest ...any) error {
+	return scanValues(r.rows[r.next-1], dest)
+}
+
+func (r *fakeRows) Err() error {
+	return nil
+}
+
+func (r *fakeRows) Close() {}
+
+// scanValues stores values in the pointers of dest, whose types must match.
+func scanValues(values []any, dest []any) error {
+	if len(values) != len(dest) {
+		return fmt.Errorf("scanning %d values into %d destinations", len(values), len(dest))
+	}
+	for i, v := range values {
+		d := reflect.ValueOf(dest[i]).Elem()
+		if v == nil {
+			d.SetZero()
+			continue
+		}
+		if !reflect.TypeOf(v).AssignableTo(d.Type()) {
+			return fmt.Errorf("cannot scan %T into %s", v, d.Type())
+		}
+		d.Set(reflect.ValueOf(v))
+	}
+	return nil
+}
+
+// fakeQueue is an in-memory QueueClient.  Methods it doesn't override panic.
+type fakeQueue struct {
+	QueueClient
+	jobs     map[int64]*rivertype.JobRow
+	inserted []river.JobArgs
+	nextID   int64
+	listErr  error
+
+	// createdAt is the creation time of inserted jobs.
+	createdAt time.Time
+
+	// listed is returned by JobList, whatever its parameters.
+	listed []*rivertype.JobRow
+}
+
+func (f *fakeQueue) InsertTx(_ context.Context, _ pgx.Tx, args river.JobArgs, _ *river.InsertOpts) (*rivertype.JobInsertResult, error) {
+	f.inserted = append(f.inserted, args)
+	f.nextID++
+	return &rivertype.JobInsertResult{Job: &rivertype.JobRow{ID: f.nextID, Kind: args.Kind(), CreatedAt: f.createdAt}}, nil
+}
+
+func (f *fakeQueue) JobGet(_ context.Context, id int64) (*rivertype.JobRow, error) {
+	job, ok := f.jobs[id]
+	if !ok {
+		return nil, rivertype.ErrNotFound
+	}
+	return job, nil
+}
+
+// JobCancelTx cancels the job as River does: a running job is left running
+// for the client running it to stop, and any other is cancelled at once.
+func (f *fakeQueue) JobCancelTx(_ context.Context, _ pgx.Tx, id int64) (*rivertype.JobRow, error) {
+	job, ok := f.jobs[id]
+	if !ok {
+		return nil, rivertype.ErrNotFound
+	}
+	if job.State != rivertype.JobStateRunning {
+		job.State = rivertype.JobStateCancelled
+	}
+	return job, nil
+}
+
+func (f *fakeQueue) JobList(context.Context, *river.JobListParams) (*river.JobListResult, error) {
+	if f.listErr != nil {
+		return nil, f.listErr
+	}
+	return &river.JobListResult{Jobs: f.listed}, nil
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/krelinga/video-info/virest"
+	"github.com/riverqueue/river"
+)
+
+// readinessTimeout bounds the checks made by readiness probes, so a hung
+// database fails the probe rather than hanging it.
+const readinessTimeout = 5 * time.Second
+
+// GetHealth handles GET /healthz requests.
+func (s *Server) GetHealth(ctx context.Context, request virest.GetHealthRequestObject) (virest.GetHealthResponseObject, error) {
+	return virest.GetHealth200JSONResponse{Status: "ok"}, nil
+}
+
+// GetReadiness handles GET /readyz requests.
+func (s *Server) GetReadiness(ctx context.Context, request virest.GetReadinessRequestObject) (virest.GetReadinessResponseObject, error) {
+	if err := s.checkReadiness(ctx); err != nil {
+		return virest.GetReadiness503JSONResponse{
+			Code:    "NOT_READY",
+			Message: err.Error(),
+		}, nil
+	}
+	return virest.GetReadiness200JSONResponse{Status: "ok"}, nil
+}
+
+func (s *Server) checkReadiness(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, readinessTimeout)
+	defer cancel()
+
+	if err := s.pool.Ping(ctx); err != nil {
+		return fmt.Errorf("database unreachable: %w", err)
+	}
+	if s.hasReadReplica() {
+		if err := s.readPool.Ping(ctx); err != nil {
+			return fmt.Errorf("read replica unreachable: %w", err)
+		}
+	}
+	if _, err := s.riverClient.JobList(ctx, river.NewJobListParams().First(1)); err != nil {
+		return fmt.Errorf("river unavailable: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,176 @@
+package main
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/krelinga/video-info/internal"
+	"github.com/krelinga/video-info/virest"
+	"github.com/riverqueue/river/rivertype"
+)
+
+// historyPageToken continues a listing of the results for a video path
+// after the result of the job with the given UUID, finalized at the given
+// time.
+type historyPageToken struct {
+	BeforeFinalizedAt time.Time `json:"before_finalized_at"`
+	BeforeUUID        uuid.UUID `json:"before_uuid"`
+}
+
+// ListInfoHistory handles GET /info/history requests.
+func (s *Server) ListInfoHistory(ctx context.Context, request virest.ListInfoHistoryRequestObject) (virest.ListInfoHistoryResponseObject, error) {
+	params := request.Params
+	if params.VideoPath == "" {
+		return virest.ListInfoHistory400JSONResponse{
+			Code:    "INVALID_VIDEO_PATH",
+			Message: "videoPath is required",
+		}, nil
+	}
+
+	limit := defaultListLimit
+	if params.Limit != nil {
+		limit = *params.Limit
+		if limit < 1 || limit > maxListLimit {
+			return virest.ListInfoHistory400JSONResponse{
+				Code:    "INVALID_LIMIT",
+				Message: fmt.Sprintf("limit must be between 1 and %d", maxListLimit),
+			}, nil
+		}
+	}
+
+	var (
+		beforeFinalizedAt *time.Time
+		beforeUUID        *uuid.UUID
+	)
+	if params.PageToken != nil {
+		var token historyPageToken
+		decoded, err := base64.RawURLEncoding.DecodeString(*params.PageToken)
+		if err == nil {
+			err = json.Unmarshal(decoded, &token)
+		}
+		if err != nil || token.BeforeFinalizedAt.IsZero() {
+			return virest.ListInfoHistory400JSONResponse{
+				Code:    "INVALID_PAGE_TOKEN",
+				Message: "pageToken is not a token returned by this endpoint",
+			}, nil
+		}
+		beforeFinalizedAt, beforeUUID = &token.BeforeFinalizedAt, &token.BeforeUUID
+	}
+
+	rows, err := s.readPool.Query(ctx, `
+		SELECT uuid, river_job_id, args, output, priority, created_at, finalized_at FROM info_result
+		WHERE video_path = $1
+			AND ($2::timestamptz IS NULL OR (finalized_at, uuid) < ($2, $3::uuid))
+		ORDER BY finalized_at DESC, uuid DESC
+		LIMIT $4`,
+		params.VideoPath, beforeFinalizedAt, beforeUUID, limit)
+	if err != nil {
+		return virest.ListInfoHistory500JSONResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: fmt.Sprintf("failed to list stored results: %v", err),
+		}, nil
+	}
+	type storedResult struct {
+		uuid        uuid.UUID
+		riverJobID  int64
+		job         rivertype.JobRow
+		output      []byte
+		finalizedAt time.Time
+	}
+	results, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (storedResult, error) {
+		var r storedResult
+		err := row.Scan(&r.uuid, &r.riverJobID, &r.job.EncodedArgs, &r.output, &r.job.Priority, &r.job.CreatedAt, &r.finalizedAt)
+		return r, err
+	})
+	if err != nil {
+		return virest.ListInfoHistory500JSONResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: fmt.Sprintf("failed to list stored results: %v", err),
+		}, nil
+	}
+
+	riverJobIDs := make([]int64, len(results))
+	for i, r := range results {
+		riverJobIDs[i] = r.riverJobID
+	}
+	annotations, err := s.loadAnnotations(ctx, riverJobIDs)
+	if err != nil {
+		return virest.ListInfoHistory500JSONResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: err.Error(),
+		}, nil
+	}
+
+	items := make([]virest.InfoJob, 0, len(results))
+	for _, r := range results {
+		infoJob, err := newStoredInfoJob(&r.job, r.output, r.finalizedAt)
+		if err != nil {
+			return virest.ListInfoHistory500JSONResponse{
+				Code:    "INTERNAL_ERROR",
+				Message: err.Error(),
+			}, nil
+		}
+		infoJob.Annotations = annotations[r.riverJobID]
+		items = append(items, *infoJob)
+	}
+
+	response := virest.ListInfoHistory200JSONResponse{Items: items}
+	if len(results) == limit {
+		last := results[len(results)-1]
+		token, err := json.Marshal(historyPageToken{BeforeFinalizedAt: last.finalizedAt, BeforeUUID: last.uuid})
+		if err != nil {
+			return virest.ListInfoHistory500JSONResponse{
+				Code:    "INTERNAL_ERROR",
+				Message: fmt.Sprintf("failed to encode page token: %v", err),
+			}, nil
+		}
+		nextPageToken := base64.RawURLEncoding.EncodeToString(token)
+		response.NextPageToken = &nextPageToken
+	}
+	return response, nil
+}
+
+// DiffInfo handles GET /info/diff requests.
+func (s *Server) DiffInfo(ctx context.Context, request virest.DiffInfoRequestObject) (virest.DiffInfoResponseObject, error) {
+	var results [2]*virest.MediaInfo
+	for i, id := range []uuid.UUID{request.Params.From, request.Params.To} {
+		infoJob, err := s.findInfoJob(ctx, "uuid", id)
+		if errors.Is(err, errJobNotFound) {
+			return virest.DiffInfo404JSONResponse{
+				Code:    "NOT_FOUND",
+				Message: fmt.Sprintf("Info job with UUID %s not found", id),
+			}, nil
+		} else if err != nil {
+			return virest.DiffInfo500JSONResponse{
+				Code:    "INTERNAL_ERROR",
+				Message: err.Error(),
+			}, nil
+		}
+		if infoJob.Result == nil {
+			return virest.DiffInfo409JSONResponse{
+				Code:    "NO_RESULT",
+				Message: fmt.Sprintf("Info job with UUID %s has no result", id),
+			}, nil
+		}
+		results[i] = infoJob.Result
+	}
+
+	differences, err := internal.DiffMediaInfo(results[0], results[1])
+	if err != nil {
+		return virest.DiffInfo500JSONResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: err.Error(),
+		}, nil
+	}
+	return virest.DiffInfo200JSONResponse{
+		From:        request.Params.From,
+		To:          request.Params.To,
+		Differences: differences,
+	}, nil
+}
@@ -0,0 +1,175 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/krelinga/go-libs/deep"
+	"github.com/krelinga/go-libs/exam"
+	"github.com/krelinga/video-info/internal"
+	"github.com/krelinga/video-info/virest"
+)
+
+func TestListInfoHistory(t *testing.T) {
+	e := exam.New(t)
+	env := deep.NewEnv()
+
+	e.Run("Stored results", func(e exam.E) {
+		created := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+		ids := []uuid.UUID{uuid.New(), uuid.New()}
+		var rows [][]any
+		for i, id := range ids {
+			encodedArgs, err := json.Marshal(internal.InfoJobArgs{UUID: id, Path: "/nas/media/a.mkv"})
+			exam.Nil(e, env, err)
+			output, err := json.Marshal(internal.InfoJobStatus{Result: &internal.InfoJobResult{DurationSeconds: float64(60 + i)}})
+			exam.Nil(e, env, err)
+			rows = append(rows, []any{id, int64(i + 1), encodedArgs, output, 1, created, created.Add(time.Duration(2-i) * time.Hour)})
+		}
+
+		var gotArgs []any
+		store := &fakeStore{
+			query: func(sql string, args []any) (pgx.Rows, error) {
+				if strings.Contains(sql, "info_result") {
+					gotArgs = args
+					return &fakeRows{rows: rows}, nil
+				}
+				return &fakeRows{}, nil
+			},
+		}
+		s := newTestServer(e, store, &fakeQueue{}, &internal.ServerConfig{})
+		limit := 2
+		params := virest.ListInfoHistoryParams{VideoPath: "/nas/media/a.mkv", Limit: &limit}
+		resp, err := s.ListInfoHistory(context.Background(), virest.ListInfoHistoryRequestObject{Params: params})
+		exam.Nil(e, env, err)
+		got, ok := resp.(virest.ListInfoHistory200JSONResponse)
+		if !ok {
+			e.Fatalf("got %T, want 200", resp)
+		}
+		if len(got.Items) != 2 {
+			e.Fatalf("got %d jobs, want 2", len(got.Items))
+		}
+		exam.Equal(e, env, ids[0].String(), got.Items[0].Uuid.String())
+		exam.Equal(e, env, ids[1].String(), got.Items[1].Uuid.String())
+		exam.Equal(e, env, virest.Completed, got.Items[1].Status)
+		exam.Equal(e, env, any("/nas/media/a.mkv"), gotArgs[0])
+
+		// The next page starts after the last result listed
+		if got.NextPageToken == nil {
+			e.Fatal("got no page token")
+		}
+		params.PageToken = got.NextPageToken
+		_, err = s.ListInfoHistory(context.Background(), virest.ListInfoHistoryRequestObject{Params: params})
+		exam.Nil(e, env, err)
+		exam.Equal(e, env, true, gotArgs[1].(*time.Time).Equal(created.Add(time.Hour)))
+		exam.Equal(e, env, ids[1].String(), gotArgs[2].(*uuid.UUID).String())
+	})
+
+	e.Run("Invalid requests", func(e exam.E) {
+		s := newTestServer(e, &fakeStore{}, &fakeQueue{}, &internal.ServerConfig{})
+		token := "not-a-token"
+		limit := 0
+		tests := []struct {
+			loc    exam.Loc
+			name   string
+			params virest.ListInfoHistoryParams
+			want   string
+		}{
+			{
+				loc:    exam.Here(),
+				name:   "Missing video path",
+				params: virest.ListInfoHistoryParams{},
+				want:   "INVALID_VIDEO_PATH",
+			},
+			{
+				loc:    exam.Here(),
+				name:   "Invalid limit",
+				params: virest.ListInfoHistoryParams{VideoPath: "/a.mkv", Limit: &limit},
+				want:   "INVALID_LIMIT",
+			},
+			{
+				loc:    exam.Here(),
+				name:   "Invalid page token",
+				params: virest.ListInfoHistoryParams{VideoPath: "/a.mkv", PageToken: &token},
+				want:   "INVALID_PAGE_TOKEN",
+			},
+		}
+		for _, tt := range tests {
+			e.Run(tt.name, func(e exam.E) {
+				e.Log("Running test at", tt.loc)
+				resp, err := s.ListInfoHistory(context.Background(), virest.ListInfoHistoryRequestObject{Params: tt.params})
+				exam.Nil(e, env, err)
+				got, ok := resp.(virest.ListInfoHistory400JSONResponse)
+				if !ok {
+					e.Fatalf("got %T, want 400", resp)
+				}
+				exam.Equal(e, env, tt.want, got.Code)
+			})
+		}
+	})
+}
+
+func TestDiffInfo(t *testing.T) {
+	e := exam.New(t)
+	env := deep.NewEnv()
+	finalizedAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	from, to, failed, missing := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+
+	outputs := make(map[uuid.UUID][]byte)
+	for id, status := range map[uuid.UUID]internal.InfoJobStatus{
+		from:   {Result: &internal.InfoJobResult{DurationSeconds: 60}},
+		to:     {Result: &internal.InfoJobResult{DurationSeconds: 61}},
+		failed: {},
+	} {
+		output, err := json.Marshal(status)
+		exam.Nil(e, env, err)
+		outputs[id] = output
+	}
+	store := &fakeStore{
+		queryRow: func(sql string, args []any) pgx.Row {
+			if strings.Contains(sql, "info_result") {
+				id := args[0].(uuid.UUID)
+				if output, ok := outputs[id]; ok {
+					encodedArgs, _ := json.Marshal(internal.InfoJobArgs{UUID: id, Path: "/videos/a.mkv"})
+					return fakeRow{values: []any{encodedArgs, output, 1, finalizedAt.Add(-time.Minute), finalizedAt}}
+				}
+			}
+			return fakeRow{err: pgx.ErrNoRows}
+		},
+	}
+	s := newTestServer(e, store, &fakeQueue{}, &internal.ServerConfig{})
+
+	e.Run("Changed duration", func(e exam.E) {
+		resp, err := s.DiffInfo(context.Background(), virest.DiffInfoRequestObject{Params: virest.DiffInfoParams{From: from, To: to}})
+		exam.Nil(e, env, err)
+		got, ok := resp.(virest.DiffInfo200JSONResponse)
+		if !ok {
+			e.Fatalf("got %T, want 200", resp)
+		}
+		paths := make([]string, len(got.Differences))
+		for i, d := range got.Differences {
+			paths[i] = d.Path
+		}
+		exam.Equal(e, env, []string{"totalDurationSeconds"}, paths)
+	})
+
+	e.Run("No result", func(e exam.E) {
+		resp, err := s.DiffInfo(context.Background(), virest.DiffInfoRequestObject{Params: virest.DiffInfoParams{From: from, To: failed}})
+		exam.Nil(e, env, err)
+		if _, ok := resp.(virest.DiffInfo409JSONResponse); !ok {
+			e.Fatalf("got %T, want 409", resp)
+		}
+	})
+
+	e.Run("Not found", func(e exam.E) {
+		resp, err := s.DiffInfo(context.Background(), virest.DiffInfoRequestObject{Params: virest.DiffInfoParams{From: missing, To: to}})
+		exam.Nil(e, env, err)
+		if _, ok := resp.(virest.DiffInfo404JSONResponse); !ok {
+			e.Fatalf("got %T, want 404", resp)
+		}
+	})
+}
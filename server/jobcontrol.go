@@ -0,0 +1,237 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/krelinga/video-info/internal"
+	"github.com/krelinga/video-info/virest"
+	"github.com/riverqueue/river/rivertype"
+)
+
+// CancelInfo handles POST /info/{uuid}/cancel requests.
+func (s *Server) CancelInfo(ctx context.Context, request virest.CancelInfoRequestObject) (virest.CancelInfoResponseObject, error) {
+	// Use a transaction so that the job can't finish between being checked
+	// and cancelled, and so that its webhooks are enqueued with the cancel
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return virest.CancelInfo500JSONResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: fmt.Sprintf("failed to begin transaction: %v", err),
+		}, nil
+	}
+	defer tx.Rollback(ctx)
+
+	var riverJobID int64
+	err = tx.QueryRow(ctx, "SELECT river_job_id FROM uuid_job_mapping WHERE uuid = $1", request.Uuid).Scan(&riverJobID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return virest.CancelInfo404JSONResponse{
+			Code:    "NOT_FOUND",
+			Message: fmt.Sprintf("Info job with UUID %s not found", request.Uuid),
+		}, nil
+	} else if err != nil {
+		return virest.CancelInfo500JSONResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: fmt.Sprintf("failed to look up job mapping: %v", err),
+		}, nil
+	}
+
+	var (
+		state       rivertype.JobState
+		encodedArgs []byte
+		remote      bool
+	)
+	err = tx.QueryRow(ctx, `
+		SELECT state::text, args, metadata ? $2 FROM river_job
+		WHERE id = $1
+		FOR UPDATE`,
+		riverJobID, remoteWorkerMetadataKey).Scan(&state, &encodedArgs, &remote)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return virest.CancelInfo404JSONResponse{
+			Code:    "NOT_FOUND",
+			Message: fmt.Sprintf("Info job with UUID %s not found in queue", request.Uuid),
+		}, nil
+	} else if err != nil {
+		return virest.CancelInfo500JSONResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: fmt.Sprintf("failed to look up job: %v", err),
+		}, nil
+	}
+
+	// River leaves finalized jobs alone, so report those as conflicts rather
+	// than pretending they were cancelled
+	switch state {
+	case rivertype.JobStateCompleted, rivertype.JobStateCancelled, rivertype.JobStateDiscarded:
+		return virest.CancelInfo409JSONResponse{
+			Code:    "JOB_FINALIZED",
+			Message: fmt.Sprintf("Info job with UUID %s has already finished", request.Uuid),
+		}, nil
+	}
+
+	job, err := s.riverClient.JobCancelTx(ctx, tx, riverJobID)
+	if err != nil {
+		return virest.CancelInfo500JSONResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: fmt.Sprintf("failed to cancel river job: %v", err),
+		}, nil
+	}
+
+	// River cancels a running job by asking the client running it to stop,
+	// which never happens for jobs claimed through the worker API.  Finalize
+	// those directly; the worker's completion is then rejected.
+	cancelled := job.State == rivertype.JobStateCancelled
+	if job.State == rivertype.JobStateRunning && remote {
+		_, err = tx.Exec(ctx, "UPDATE river_job SET state = 'cancelled', finalized_at = now() WHERE id = $1", riverJobID)
+		if err != nil {
+			return virest.CancelInfo500JSONResponse{
+				Code:    "INTERNAL_ERROR",
+				Message: fmt.Sprintf("failed to cancel remote job: %v", err),
+			}, nil
+		}
+		cancelled = true
+	}
+
+	// Jobs a River client stops are notified once they have stopped, by the
+	// periodic terminal webhooks job.  Those cancelled here are notified
+	// now, as the worker API notifies completed jobs.
+	var jobArgs internal.InfoJobArgs
+	if cancelled {
+		if err := json.Unmarshal(encodedArgs, &jobArgs); err != nil {
+			return virest.CancelInfo500JSONResponse{
+				Code:    "INTERNAL_ERROR",
+				Message: fmt.Sprintf("failed to unmarshal job args: %v", err),
+			}, nil
+		}
+		if _, err := internal.EnqueueCancelledWebhooks(ctx, tx, s.riverClient, riverJobID, jobArgs, s.clock.Now()); err != nil {
+			return virest.CancelInfo500JSONResponse{
+				Code:    "INTERNAL_ERROR",
+				Message: err.Error(),
+			}, nil
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return virest.CancelInfo500JSONResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: fmt.Sprintf("failed to commit transaction: %v", err),
+		}, nil
+	}
+	if cancelled {
+		s.publisher.Publish(internal.JobEventCancelled, jobArgs, nil, s.clock.Now())
+	}
+
+	infoJob, err := s.getPrimaryInfoJob(ctx, riverJobID)
+	if err != nil {
+		return virest.CancelInfo500JSONResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: err.Error(),
+		}, nil
+	}
+	return virest.CancelInfo200JSONResponse(*infoJob), nil
+}
+
+// getPrimaryInfoJob is like getInfoJob, but reads the job from the primary so
+// that a change just made to it is visible.
+func (s *Server) getPrimaryInfoJob(ctx context.Context, riverJobID int64) (*virest.InfoJob, error) {
+	job, err := s.riverClient.JobGet(ctx, riverJobID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get river job: %w", err)
+	}
+	infoJob, err := newInfoJob(job)
+	if err != nil {
+		return nil, err
+	}
+
+	annotations, err := s.loadAnnotations(ctx, []int64{riverJobID})
+	if err != nil {
+		return nil, err
+	}
+	infoJob.Annotations = annotations[riverJobID]
+	return infoJob, nil
+}
+
+// RetryInfo handles POST /info/{uuid}/retry requests.
+func (s *Server) RetryInfo(ctx context.Context, request virest.RetryInfoRequestObject) (virest.RetryInfoResponseObject, error) {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return virest.RetryInfo500JSONResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: fmt.Sprintf("failed to begin transaction: %v", err),
+		}, nil
+	}
+	defer tx.Rollback(ctx)
+
+	var (
+		riverJobID int64
+		retryable  bool
+	)
+	err = tx.QueryRow(ctx, `
+		SELECT river_job.id, `+retryableJobCondition+`
+		FROM river_job JOIN uuid_job_mapping ON uuid_job_mapping.river_job_id = river_job.id
+		WHERE uuid_job_mapping.uuid = $1
+		FOR UPDATE OF river_job`,
+		request.Uuid, rivertype.MetadataKeyOutput).Scan(&riverJobID, &retryable)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return virest.RetryInfo404JSONResponse{
+			Code:    "NOT_FOUND",
+			Message: fmt.Sprintf("Info job with UUID %s not found", request.Uuid),
+		}, nil
+	} else if err != nil {
+		return virest.RetryInfo500JSONResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: fmt.Sprintf("failed to look up job: %v", err),
+		}, nil
+	}
+	if !retryable {
+		return virest.RetryInfo409JSONResponse{
+			Code:    "JOB_NOT_FAILED",
+			Message: fmt.Sprintf("Info job with UUID %s has not failed", request.Uuid),
+		}, nil
+	}
+
+	if err := s.retryJobTx(ctx, tx, riverJobID); err != nil {
+		return virest.RetryInfo500JSONResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: err.Error(),
+		}, nil
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return virest.RetryInfo500JSONResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: fmt.Sprintf("failed to commit transaction: %v", err),
+		}, nil
+	}
+
+	infoJob, err := s.getPrimaryInfoJob(ctx, riverJobID)
+	if err != nil {
+		return virest.RetryInfo500JSONResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: err.Error(),
+		}, nil
+	}
+	return virest.RetryInfo200JSONResponse(*infoJob), nil
+}
+
+// retryableJobCondition is an SQL condition on river_job that holds for info
+// jobs that may be retried: those discarded or cancelled, and those that
+// completed with a probe error.  $2 must be the output metadata key.
+const retryableJobCondition = `(river_job.state IN ('discarded', 'cancelled')
+	OR (river_job.state = 'completed' AND river_job.metadata->$2 ? 'error'))`
+
+// retryJobTx makes the info job with the given River job ID available to run
+// again, clearing the output of its previous run and the mark of its webhook
+// having been enqueued, so that it is notified again if it fails again.
+func (s *Server) retryJobTx(ctx context.Context, tx pgx.Tx, riverJobID int64) error {
+	if _, err := s.riverClient.JobRetryTx(ctx, tx, riverJobID); err != nil {
+		return fmt.Errorf("failed to retry river job: %w", err)
+	}
+	_, err := tx.Exec(ctx, "UPDATE river_job SET metadata = metadata - $2::text - $3::text WHERE id = $1",
+		riverJobID, rivertype.MetadataKeyOutput, internal.MetadataKeyWebhookEnqueued)
+	if err != nil {
+		return fmt.Errorf("failed to clear job output: %w", err)
+	}
+	return nil
+}
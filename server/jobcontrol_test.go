@@ -0,0 +1,150 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/krelinga/go-libs/deep"
+	"github.com/krelinga/go-libs/exam"
+	"github.com/krelinga/video-info/internal"
+	"github.com/krelinga/video-info/virest"
+	"github.com/riverqueue/river/rivertype"
+)
+
+// fakeEventConn records the subjects of the events published through it.
+type fakeEventConn struct {
+	subjects []string
+}
+
+func (c *fakeEventConn) Publish(subject string, _ []byte) error {
+	c.subjects = append(c.subjects, subject)
+	return nil
+}
+
+func TestCancelInfo(t *testing.T) {
+	e := exam.New(t)
+	env := deep.NewEnv()
+	webhookURI := "https://hooks.example.com/notify"
+
+	tests := []struct {
+		loc  exam.Loc
+		name string
+		// state and remote are those of the job when it is locked
+		state  rivertype.JobState
+		remote bool
+
+		wantCode      int
+		wantState     virest.InfoStatus
+		wantFinalized bool
+	}{
+		{
+			loc:           exam.Here(),
+			name:          "Waiting job",
+			state:         rivertype.JobStateAvailable,
+			wantCode:      200,
+			wantState:     virest.Cancelled,
+			wantFinalized: true,
+		},
+		{
+			loc:           exam.Here(),
+			name:          "Job running on a remote worker",
+			state:         rivertype.JobStateRunning,
+			remote:        true,
+			wantCode:      200,
+			wantState:     virest.Cancelled,
+			wantFinalized: true,
+		},
+		{
+			loc:       exam.Here(),
+			name:      "Job running on a River client",
+			state:     rivertype.JobStateRunning,
+			wantCode:  200,
+			wantState: virest.Running,
+		},
+		{
+			// Such as a job that finished after the request was made
+			loc:      exam.Here(),
+			name:     "Finished job",
+			state:    rivertype.JobStateCompleted,
+			wantCode: 409,
+		},
+	}
+	for _, tt := range tests {
+		e.Run(tt.name, func(e exam.E) {
+			e.Log("Running test at", tt.loc)
+			jobUUID := uuid.New()
+			encodedArgs, err := json.Marshal(internal.InfoJobArgs{UUID: jobUUID, Path: "/videos/a.mkv", WebhookURI: &webhookURI})
+			if err != nil {
+				e.Fatal(err)
+			}
+			queue := &fakeQueue{jobs: map[int64]*rivertype.JobRow{
+				7: {ID: 7, Kind: "info", State: tt.state, EncodedArgs: encodedArgs},
+			}}
+			var locked, finalized, marked bool
+			tx := &fakeTx{
+				queryRow: func(sql string, _ []any) pgx.Row {
+					if strings.Contains(sql, "FROM uuid_job_mapping") {
+						return fakeRow{values: []any{int64(7)}}
+					}
+					locked = strings.Contains(sql, "FOR UPDATE")
+					return fakeRow{values: []any{tt.state, encodedArgs, tt.remote}}
+				},
+				exec: func(sql string, args []any) error {
+					switch {
+					case strings.Contains(sql, "SET state = 'cancelled'"):
+						finalized = true
+						queue.jobs[7].State = rivertype.JobStateCancelled
+					case strings.Contains(sql, "metadata ||"):
+						marked = args[1] == internal.MetadataKeyWebhookEnqueued
+					}
+					return nil
+				},
+			}
+			store := &fakeStore{
+				begin: func() (pgx.Tx, error) { return tx, nil },
+				query: func(string, []any) (pgx.Rows, error) { return &fakeRows{}, nil },
+			}
+			s := newTestServer(e, store, queue, &internal.ServerConfig{})
+			conn := &fakeEventConn{}
+			s.publisher = internal.NewEventPublisher(conn)
+
+			resp, err := s.CancelInfo(context.Background(), virest.CancelInfoRequestObject{Uuid: jobUUID})
+			exam.Nil(e, env, err)
+			exam.Equal(e, env, true, locked)
+			if tt.wantCode == 409 {
+				_, ok := resp.(virest.CancelInfo409JSONResponse)
+				exam.Equal(e, env, true, ok)
+				exam.Equal(e, env, false, tx.committed)
+				exam.Equal(e, env, rivertype.JobStateCompleted, queue.jobs[7].State)
+				return
+			}
+			got, ok := resp.(virest.CancelInfo200JSONResponse)
+			if !ok {
+				e.Fatalf("got %T, want 200", resp)
+			}
+			exam.Equal(e, env, tt.wantState, got.Status)
+			exam.Equal(e, env, true, tx.committed)
+			exam.Equal(e, env, tt.remote, finalized)
+
+			// Jobs finalized here are notified at once
+			var webhooks []string
+			for _, args := range queue.inserted {
+				if webhook, ok := args.(internal.WebhookJobArgs); ok {
+					webhooks = append(webhooks, webhook.Outcome)
+				}
+			}
+			var wantWebhooks, wantSubjects []string
+			if tt.wantFinalized {
+				wantWebhooks = []string{internal.WebhookCancelled}
+				wantSubjects = []string{internal.JobEventSubject(internal.JobEventCancelled)}
+			}
+			exam.Equal(e, env, wantWebhooks, webhooks)
+			exam.Equal(e, env, tt.wantFinalized, marked)
+			exam.Equal(e, env, wantSubjects, conn.subjects)
+		})
+	}
+}
@@ -0,0 +1,284 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"sync"
+
+	"github.com/coder/websocket"
+	"github.com/coder/websocket/wsjson"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/krelinga/video-info/virest"
+)
+
+// maxJobSubscriptions bounds the info jobs one WebSocket may subscribe to.
+const maxJobSubscriptions = 500
+
+// Types of jobEvent.
+const (
+	jobEventStatus   = "status"
+	jobEventNotFound = "notFound"
+	jobEventError    = "error"
+)
+
+// jobEventsRequest is a message from a WebSocket client, subscribing to or
+// unsubscribing from info jobs by UUID.
+type jobEventsRequest struct {
+	Subscribe   []uuid.UUID `json:"subscribe"`
+	Unsubscribe []uuid.UUID `json:"unsubscribe"`
+}
+
+// jobEvent is a message to a WebSocket client.  Status events carry the
+// current status of a subscribed job, notFound events a UUID that matches no
+// job, and error events a problem with the client's last message.
+type jobEvent struct {
+	Type    string          `json:"type"`
+	Uuid    *uuid.UUID      `json:"uuid,omitempty"`
+	Job     *virest.InfoJob `json:"job,omitempty"`
+	Message string          `json:"message,omitempty"`
+}
+
+// jobEvents tells WebSocket subscribers which of their info jobs changed.
+type jobEvents struct {
+	mu          sync.Mutex
+	subscribers map[*jobSubscriber]struct{}
+}
+
+// newJobEvents returns a jobEvents with no subscribers.
+func newJobEvents() *jobEvents {
+	return &jobEvents{subscribers: make(map[*jobSubscriber]struct{})}
+}
+
+// add starts telling sub about changes.
+func (e *jobEvents) add(sub *jobSubscriber) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.subscribers[sub] = struct{}{}
+}
+
+// remove stops telling sub about changes.
+func (e *jobEvents) remove(sub *jobSubscriber) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	delete(e.subscribers, sub)
+}
+
+// reset has every subscriber resend all its statuses once listening starts,
+// since changes may have been missed.
+func (e *jobEvents) reset(listening bool) {
+	if !listening {
+		return
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	for sub := range e.subscribers {
+		sub.changedAll()
+	}
+}
+
+// invalidate tells the subscribers of the job with the given River job ID
+// that it changed.
+func (e *jobEvents) invalidate(riverJobID int64) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	for sub := range e.subscribers {
+		sub.changed(riverJobID)
+	}
+}
+
+// jobSubscriber holds the info jobs one WebSocket is subscribed to, and
+// which of them changed since their statuses were last sent.
+type jobSubscriber struct {
+	mu sync.Mutex
+	// riverJobIDs maps subscribed UUIDs to their River job IDs, which are
+	// zero for jobs that only have a stored result
+	riverJobIDs map[uuid.UUID]int64
+	pending     map[uuid.UUID]bool
+
+	// wake is signalled when pending becomes non-empty
+	wake chan struct{}
+}
+
+// newJobSubscriber returns a jobSubscriber with no subscriptions.
+func newJobSubscriber() *jobSubscriber {
+	return &jobSubscriber{
+		riverJobIDs: make(map[uuid.UUID]int64),
+		pending:     make(map[uuid.UUID]bool),
+		wake:        make(chan struct{}, 1),
+	}
+}
+
+// subscribe adds a subscription to the job with the given UUID and River job
+// ID.  It reports false if the subscriber already has maxJobSubscriptions.
+func (sub *jobSubscriber) subscribe(id uuid.UUID, riverJobID int64) bool {
+	sub.mu.Lock()
+	defer sub.mu.Unlock()
+	if _, ok := sub.riverJobIDs[id]; !ok && len(sub.riverJobIDs) >= maxJobSubscriptions {
+		return false
+	}
+	sub.riverJobIDs[id] = riverJobID
+	return true
+}
+
+// unsubscribe removes the subscription to the job with the given UUID.
+func (sub *jobSubscriber) unsubscribe(id uuid.UUID) {
+	sub.mu.Lock()
+	defer sub.mu.Unlock()
+	delete(sub.riverJobIDs, id)
+	delete(sub.pending, id)
+}
+
+// changed marks the subscriptions to the job with the given River job ID as
+// pending.
+func (sub *jobSubscriber) changed(riverJobID int64) {
+	sub.mu.Lock()
+	defer sub.mu.Unlock()
+	for id, subscribed := range sub.riverJobIDs {
+		if subscribed == riverJobID {
+			sub.markPending(id)
+		}
+	}
+}
+
+// changedAll marks every subscription as pending.
+func (sub *jobSubscriber) changedAll() {
+	sub.mu.Lock()
+	defer sub.mu.Unlock()
+	for id := range sub.riverJobIDs {
+		sub.markPending(id)
+	}
+}
+
+// markPending marks the subscription to id as pending and wakes the sender.
+// sub.mu must be held.
+func (sub *jobSubscriber) markPending(id uuid.UUID) {
+	sub.pending[id] = true
+	select {
+	case sub.wake <- struct{}{}:
+	default:
+	}
+}
+
+// takePending returns and clears the pending subscriptions.
+func (sub *jobSubscriber) takePending() []uuid.UUID {
+	sub.mu.Lock()
+	defer sub.mu.Unlock()
+	ids := make([]uuid.UUID, 0, len(sub.pending))
+	for id := range sub.pending {
+		ids = append(ids, id)
+	}
+	clear(sub.pending)
+	return ids
+}
+
+// ServeJobEvents handles GET /ws requests, upgrading them to WebSockets over
+// which clients subscribe to info jobs and receive their statuses as they
+// change.
+func (s *Server) ServeJobEvents(w http.ResponseWriter, r *http.Request) {
+	conn, err := websocket.Accept(w, r, &websocket.AcceptOptions{OriginPatterns: s.cfg.WebSocketOrigins})
+	if err != nil {
+		// Accept has already written the response
+		return
+	}
+	defer conn.CloseNow()
+
+	sub := newJobSubscriber()
+	s.events.add(sub)
+	defer s.events.remove(sub)
+
+	// Changes are announced by the primary, which a replica may not have
+	// caught up with
+	reader := s.primary()
+
+	// Read requests in the background, so statuses are sent as they change
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+	requests := make(chan []byte)
+	go func() {
+		defer cancel()
+		for {
+			_, data, err := conn.Read(ctx)
+			if err != nil {
+				return
+			}
+			select {
+			case requests <- data:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	for {
+		var events []jobEvent
+		select {
+		case <-ctx.Done():
+			conn.Close(websocket.StatusNormalClosure, "")
+			return
+		case data := <-requests:
+			events = reader.handleJobEventsRequest(ctx, sub, data)
+		case <-sub.wake:
+			for _, id := range sub.takePending() {
+				events = append(events, reader.jobStatusEvent(ctx, id))
+			}
+		}
+		for _, event := range events {
+			if err := wsjson.Write(ctx, conn, event); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// handleJobEventsRequest applies a client's request to sub, returning the
+// current statuses of the jobs it subscribes to.
+func (s *Server) handleJobEventsRequest(ctx context.Context, sub *jobSubscriber, data []byte) []jobEvent {
+	var request jobEventsRequest
+	if err := json.Unmarshal(data, &request); err != nil {
+		return []jobEvent{{Type: jobEventError, Message: fmt.Sprintf("invalid request: %v", err)}}
+	}
+	for _, id := range request.Unsubscribe {
+		sub.unsubscribe(id)
+	}
+
+	var events []jobEvent
+	for _, id := range request.Subscribe {
+		riverJobID, err := s.lookupRiverJobID(ctx, "uuid", id)
+		if errors.Is(err, pgx.ErrNoRows) {
+			// Jobs River has removed may still have a stored result, which
+			// won't change
+			riverJobID = 0
+		} else if err != nil {
+			events = append(events, jobEvent{Type: jobEventError, Uuid: &id, Message: fmt.Sprintf("failed to look up job mapping: %v", err)})
+			continue
+		}
+		if !sub.subscribe(id, riverJobID) {
+			events = append(events, jobEvent{Type: jobEventError, Uuid: &id, Message: fmt.Sprintf("at most %d jobs may be subscribed to", maxJobSubscriptions)})
+			continue
+		}
+		event := s.jobStatusEvent(ctx, id)
+		if event.Type == jobEventNotFound {
+			sub.unsubscribe(id)
+		}
+		events = append(events, event)
+	}
+	return events
+}
+
+// jobStatusEvent returns the event reporting the current status of the job
+// with the given UUID.
+func (s *Server) jobStatusEvent(ctx context.Context, id uuid.UUID) jobEvent {
+	infoJob, err := s.findInfoJob(ctx, "uuid", id)
+	if errors.Is(err, errJobNotFound) {
+		return jobEvent{Type: jobEventNotFound, Uuid: &id}
+	} else if err != nil {
+		log.Printf("Failed to read status of job %s for its subscribers: %v", id, err)
+		return jobEvent{Type: jobEventError, Uuid: &id, Message: err.Error()}
+	}
+	return jobEvent{Type: jobEventStatus, Uuid: &id, Job: infoJob}
+}
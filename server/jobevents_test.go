@@ -0,0 +1,178 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/krelinga/go-libs/deep"
+	"github.com/krelinga/go-libs/exam"
+	"github.com/krelinga/video-info/internal"
+	"github.com/krelinga/video-info/virest"
+	"github.com/riverqueue/river/rivertype"
+)
+
+func TestJobEvents(t *testing.T) {
+	e := exam.New(t)
+	env := deep.NewEnv()
+	a, b := uuid.New(), uuid.New()
+
+	newSubscribed := func(events *jobEvents) *jobSubscriber {
+		sub := newJobSubscriber()
+		sub.subscribe(a, 1)
+		sub.subscribe(b, 2)
+		events.add(sub)
+		return sub
+	}
+	sortedPending := func(sub *jobSubscriber) []string {
+		var ids []string
+		for _, id := range sub.takePending() {
+			ids = append(ids, id.String())
+		}
+		slices.Sort(ids)
+		return ids
+	}
+	woken := func(sub *jobSubscriber) bool {
+		select {
+		case <-sub.wake:
+			return true
+		default:
+			return false
+		}
+	}
+
+	e.Run("Invalidate", func(e exam.E) {
+		events := newJobEvents()
+		sub := newSubscribed(events)
+		events.invalidate(2)
+		exam.Equal(e, env, true, woken(sub))
+		exam.Equal(e, env, []string{b.String()}, sortedPending(sub))
+		exam.Equal(e, env, 0, len(sub.takePending()))
+	})
+
+	e.Run("Invalidate other job", func(e exam.E) {
+		events := newJobEvents()
+		sub := newSubscribed(events)
+		events.invalidate(3)
+		exam.Equal(e, env, false, woken(sub))
+		exam.Equal(e, env, 0, len(sub.takePending()))
+	})
+
+	e.Run("Reset", func(e exam.E) {
+		events := newJobEvents()
+		sub := newSubscribed(events)
+		events.reset(false)
+		exam.Equal(e, env, false, woken(sub))
+		events.reset(true)
+		exam.Equal(e, env, true, woken(sub))
+		want := []string{a.String(), b.String()}
+		slices.Sort(want)
+		exam.Equal(e, env, want, sortedPending(sub))
+	})
+
+	e.Run("Unsubscribe", func(e exam.E) {
+		events := newJobEvents()
+		sub := newSubscribed(events)
+		events.invalidate(1)
+		sub.unsubscribe(a)
+		exam.Equal(e, env, 0, len(sub.takePending()))
+		events.invalidate(1)
+		exam.Equal(e, env, 0, len(sub.takePending()))
+	})
+
+	e.Run("Removed", func(e exam.E) {
+		events := newJobEvents()
+		sub := newSubscribed(events)
+		events.remove(sub)
+		events.invalidate(1)
+		exam.Equal(e, env, 0, len(sub.takePending()))
+	})
+
+	e.Run("Too many subscriptions", func(e exam.E) {
+		sub := newJobSubscriber()
+		for i := range maxJobSubscriptions {
+			if !sub.subscribe(uuid.New(), int64(i)) {
+				e.Fatalf("subscription %d refused", i)
+			}
+		}
+		exam.Equal(e, env, false, sub.subscribe(uuid.New(), 0))
+	})
+}
+
+func TestHandleJobEventsRequest(t *testing.T) {
+	e := exam.New(t)
+	env := deep.NewEnv()
+	cfg := &internal.ServerConfig{}
+	queued, missing := uuid.New(), uuid.New()
+	encodedArgs, err := json.Marshal(internal.InfoJobArgs{UUID: queued, Path: "/videos/a.mkv"})
+	if err != nil {
+		e.Fatal(err)
+	}
+	store := &fakeStore{
+		queryRow: func(sql string, args []any) pgx.Row {
+			if strings.Contains(sql, "uuid_job_mapping") && args[0] == queued {
+				return fakeRow{values: []any{int64(42)}}
+			}
+			return fakeRow{err: pgx.ErrNoRows}
+		},
+		query: func(string, []any) (pgx.Rows, error) { return &fakeRows{}, nil },
+	}
+	queue := &fakeQueue{jobs: map[int64]*rivertype.JobRow{
+		42: {ID: 42, State: rivertype.JobStateRunning, EncodedArgs: encodedArgs},
+	}}
+
+	tests := []struct {
+		loc        exam.Loc
+		name       string
+		request    string
+		wantTypes  []string
+		wantStatus virest.InfoStatus
+		wantIDs    []string
+	}{
+		{
+			loc:        exam.Here(),
+			name:       "Subscribe",
+			request:    `{"subscribe":["` + queued.String() + `"]}`,
+			wantTypes:  []string{jobEventStatus},
+			wantStatus: virest.Running,
+			wantIDs:    []string{queued.String()},
+		},
+		{
+			loc:       exam.Here(),
+			name:      "Not found",
+			request:   `{"subscribe":["` + missing.String() + `"]}`,
+			wantTypes: []string{jobEventNotFound},
+		},
+		{
+			loc:       exam.Here(),
+			name:      "Invalid request",
+			request:   `{"subscribe":["not-a-uuid"]}`,
+			wantTypes: []string{jobEventError},
+		},
+	}
+	for _, tt := range tests {
+		e.Run(tt.name, func(e exam.E) {
+			e.Log("Running test at", tt.loc)
+			s := newTestServer(e, store, queue, cfg)
+			sub := newJobSubscriber()
+			events := s.handleJobEventsRequest(context.Background(), sub, []byte(tt.request))
+			var gotTypes []string
+			for _, event := range events {
+				gotTypes = append(gotTypes, event.Type)
+				if event.Job != nil {
+					exam.Equal(e, env, tt.wantStatus, event.Job.Status)
+				}
+			}
+			exam.Equal(e, env, tt.wantTypes, gotTypes)
+			var gotIDs []string
+			for id := range sub.riverJobIDs {
+				gotIDs = append(gotIDs, id.String())
+			}
+			exam.Equal(e, env, tt.wantIDs, gotIDs)
+		})
+	}
+}
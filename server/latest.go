@@ -0,0 +1,206 @@
+package main
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/krelinga/video-info/internal"
+	"github.com/krelinga/video-info/virest"
+	"github.com/riverqueue/river"
+	"github.com/riverqueue/river/rivertype"
+)
+
+// latestPageToken continues a listing of the latest job per video path after
+// the given path.
+type latestPageToken struct {
+	AfterPath string `json:"after_path"`
+}
+
+// latestInfoJob is the most recent job for a video path.  Completed jobs are
+// read from their stored result, which outlives River's job row, and other
+// jobs from River.
+type latestInfoJob struct {
+	videoPath   string
+	riverJobID  int64
+	stored      bool
+	job         rivertype.JobRow
+	output      []byte
+	finalizedAt *time.Time
+}
+
+// listLatestInfo handles GET /info requests that set latest, returning the
+// most recent job for each video path matching the filters in params.  labels
+// and pathSearch are the filters returned by labelFilter and
+// pathSearchPattern.
+func (s *Server) listLatestInfo(ctx context.Context, params virest.ListInfoParams, labels, pathSearch *string, limit int) (virest.ListInfoResponseObject, error) {
+	liveStates := make([]string, 0, len(rivertype.JobStates()))
+	includeStored := true
+	if params.Status != nil {
+		states := riverStatesForStatus(*params.Status)
+		if len(states) == 0 {
+			return virest.ListInfo400JSONResponse{
+				Code:    "INVALID_STATUS",
+				Message: fmt.Sprintf("unsupported status %q", *params.Status),
+			}, nil
+		}
+		includeStored = false
+		for _, state := range states {
+			if state == rivertype.JobStateCompleted {
+				includeStored = true
+			} else {
+				liveStates = append(liveStates, string(state))
+			}
+		}
+	} else {
+		for _, state := range rivertype.JobStates() {
+			if state != rivertype.JobStateCompleted {
+				liveStates = append(liveStates, string(state))
+			}
+		}
+	}
+
+	var afterPath string
+	if params.PageToken != nil {
+		var token latestPageToken
+		decoded, err := base64.RawURLEncoding.DecodeString(*params.PageToken)
+		if err == nil {
+			err = json.Unmarshal(decoded, &token)
+		}
+		if err != nil || token.AfterPath == "" {
+			return virest.ListInfo400JSONResponse{
+				Code:    "INVALID_PAGE_TOKEN",
+				Message: "pageToken is not a token returned by this endpoint",
+			}, nil
+		}
+		afterPath = token.AfterPath
+	}
+
+	// Completed jobs all have stored results, so River's copies are skipped.
+	// A job being retried has the same creation time as its stored result, and
+	// is listed in its place.
+	rows, err := s.readPool.Query(ctx, `
+		WITH candidates AS (
+			SELECT args->>'path' AS video_path, id AS river_job_id, created_at, false AS stored,
+				NULL::jsonb AS args, NULL::jsonb AS output, NULL::smallint AS priority, NULL::timestamptz AS finalized_at
+			FROM river_job
+			WHERE kind = $1 AND state::text = ANY($2)
+				AND EXISTS (SELECT 1 FROM uuid_job_mapping WHERE uuid_job_mapping.river_job_id = river_job.id)
+				AND ($4::text IS NULL OR args->>'path' = $4)
+				AND ($5::text IS NULL OR starts_with(args->>'path', $5))
+				AND ($6::timestamptz IS NULL OR created_at >= $6)
+				AND ($7::timestamptz IS NULL OR created_at < $7)
+				AND ($8::jsonb IS NULL OR args->'labels' @> $8)
+				AND ($9::boolean IS NULL OR EXISTS (SELECT 1 FROM uuid_job_mapping WHERE uuid_job_mapping.river_job_id = river_job.id AND acknowledged = $9))
+				AND ($12::text IS NULL OR args->>'path' ILIKE $12)
+			UNION ALL
+			SELECT video_path, river_job_id, created_at, true, args, output, priority, finalized_at
+			FROM info_result
+			WHERE $3
+				AND ($4::text IS NULL OR video_path = $4)
+				AND ($5::text IS NULL OR starts_with(video_path, $5))
+				AND ($6::timestamptz IS NULL OR created_at >= $6)
+				AND ($7::timestamptz IS NULL OR created_at < $7)
+				AND ($8::jsonb IS NULL OR args->'labels' @> $8)
+				AND ($9::boolean IS NULL OR EXISTS (SELECT 1 FROM uuid_job_mapping WHERE uuid_job_mapping.river_job_id = info_result.river_job_id AND acknowledged = $9))
+				AND ($12::text IS NULL OR video_path ILIKE $12)
+		)
+		SELECT DISTINCT ON (video_path) video_path, river_job_id, stored, args, output, priority, created_at, finalized_at
+		FROM candidates
+		WHERE video_path > $10
+		ORDER BY video_path, created_at DESC, stored, river_job_id DESC
+		LIMIT $11`,
+		internal.InfoJobArgs{}.Kind(), liveStates, includeStored,
+		params.VideoPath, params.PathPrefix, params.CreatedAfter, params.CreatedBefore, labels, params.Acknowledged,
+		afterPath, limit, pathSearch)
+	if err != nil {
+		return virest.ListInfo500JSONResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: fmt.Sprintf("failed to list latest info jobs: %v", err),
+		}, nil
+	}
+	latest, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (latestInfoJob, error) {
+		var (
+			l        latestInfoJob
+			priority *int
+		)
+		err := row.Scan(&l.videoPath, &l.riverJobID, &l.stored, &l.job.EncodedArgs, &l.output, &priority, &l.job.CreatedAt, &l.finalizedAt)
+		if priority != nil {
+			l.job.Priority = *priority
+		}
+		return l, err
+	})
+	if err != nil {
+		return virest.ListInfo500JSONResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: fmt.Sprintf("failed to list latest info jobs: %v", err),
+		}, nil
+	}
+
+	riverJobIDs := make([]int64, 0, len(latest))
+	var liveIDs []int64
+	for _, l := range latest {
+		riverJobIDs = append(riverJobIDs, l.riverJobID)
+		if !l.stored {
+			liveIDs = append(liveIDs, l.riverJobID)
+		}
+	}
+	liveJobs := make(map[int64]*rivertype.JobRow, len(liveIDs))
+	if len(liveIDs) > 0 {
+		result, err := s.readRiverClient.JobList(ctx, river.NewJobListParams().IDs(liveIDs...).First(len(liveIDs)))
+		if err != nil {
+			return virest.ListInfo500JSONResponse{
+				Code:    "INTERNAL_ERROR",
+				Message: fmt.Sprintf("failed to list river jobs: %v", err),
+			}, nil
+		}
+		for _, job := range result.Jobs {
+			liveJobs[job.ID] = job
+		}
+	}
+	annotations, err := s.loadAnnotations(ctx, riverJobIDs)
+	if err != nil {
+		return virest.ListInfo500JSONResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: err.Error(),
+		}, nil
+	}
+
+	items := make([]virest.InfoJob, 0, len(latest))
+	for _, l := range latest {
+		var infoJob *virest.InfoJob
+		if l.stored {
+			infoJob, err = newStoredInfoJob(&l.job, l.output, *l.finalizedAt)
+		} else if job, ok := liveJobs[l.riverJobID]; ok {
+			infoJob, err = newInfoJob(job)
+		} else {
+			// Cleaned up by River since it was listed
+			continue
+		}
+		if err != nil {
+			return virest.ListInfo500JSONResponse{
+				Code:    "INTERNAL_ERROR",
+				Message: err.Error(),
+			}, nil
+		}
+		infoJob.Annotations = annotations[l.riverJobID]
+		items = append(items, *infoJob)
+	}
+
+	response := virest.ListInfo200JSONResponse{Items: items}
+	if len(latest) == limit {
+		token, err := json.Marshal(latestPageToken{AfterPath: latest[len(latest)-1].videoPath})
+		if err != nil {
+			return virest.ListInfo500JSONResponse{
+				Code:    "INTERNAL_ERROR",
+				Message: fmt.Sprintf("failed to encode page token: %v", err),
+			}, nil
+		}
+		nextPageToken := base64.RawURLEncoding.EncodeToString(token)
+		response.NextPageToken = &nextPageToken
+	}
+	return response, nil
+}
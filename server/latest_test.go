@@ -0,0 +1,111 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/krelinga/go-libs/deep"
+	"github.com/krelinga/go-libs/exam"
+	"github.com/krelinga/video-info/internal"
+	"github.com/krelinga/video-info/virest"
+)
+
+func TestListLatestInfo(t *testing.T) {
+	e := exam.New(t)
+	env := deep.NewEnv()
+	latest := true
+
+	e.Run("Stored results", func(e exam.E) {
+		created := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+		finalized := created.Add(time.Minute)
+		priority := 2
+		id := uuid.New()
+		encodedArgs, err := json.Marshal(internal.InfoJobArgs{UUID: id, Path: "/nas/media/a.mkv"})
+		exam.Nil(e, env, err)
+		output, err := json.Marshal(internal.InfoJobStatus{Result: &internal.InfoJobResult{DurationSeconds: 60}})
+		exam.Nil(e, env, err)
+
+		var gotArgs []any
+		store := &fakeStore{
+			query: func(sql string, args []any) (pgx.Rows, error) {
+				if strings.Contains(sql, "info_result") {
+					gotArgs = args
+					return &fakeRows{rows: [][]any{
+						{"/nas/media/a.mkv", int64(7), true, encodedArgs, output, &priority, created, &finalized},
+						// River cleaned this job up after it was listed
+						{"/nas/media/b.mkv", int64(8), false, nil, nil, nil, created, nil},
+					}}, nil
+				}
+				return &fakeRows{}, nil
+			},
+		}
+		s := newTestServer(e, store, &fakeQueue{}, &internal.ServerConfig{})
+		videoPrefix := "/nas/media/"
+		limit := 2
+		params := virest.ListInfoParams{PathPrefix: &videoPrefix, Latest: &latest, Limit: &limit}
+		resp, err := s.ListInfo(context.Background(), virest.ListInfoRequestObject{Params: params})
+		exam.Nil(e, env, err)
+		got, ok := resp.(virest.ListInfo200JSONResponse)
+		if !ok {
+			e.Fatalf("got %T, want 200", resp)
+		}
+		if len(got.Items) != 1 {
+			e.Fatalf("got %d jobs, want 1", len(got.Items))
+		}
+		exam.Equal(e, env, id.String(), got.Items[0].Uuid.String())
+		exam.Equal(e, env, virest.Completed, got.Items[0].Status)
+		exam.Equal(e, env, 2, got.Items[0].Priority)
+		exam.Equal(e, env, true, got.Items[0].UpdatedAt.Equal(finalized))
+		exam.Equal(e, env, true, got.Items[0].Result != nil)
+		exam.Equal(e, env, any(true), gotArgs[2])
+
+		// The next page starts after the last path listed
+		if got.NextPageToken == nil {
+			e.Fatal("got no page token")
+		}
+		params.PageToken = got.NextPageToken
+		_, err = s.ListInfo(context.Background(), virest.ListInfoRequestObject{Params: params})
+		exam.Nil(e, env, err)
+		exam.Equal(e, env, any("/nas/media/b.mkv"), gotArgs[9])
+	})
+
+	e.Run("Status without stored results", func(e exam.E) {
+		var gotArgs []any
+		store := &fakeStore{
+			query: func(sql string, args []any) (pgx.Rows, error) {
+				if strings.Contains(sql, "info_result") {
+					gotArgs = args
+				}
+				return &fakeRows{}, nil
+			},
+		}
+		s := newTestServer(e, store, &fakeQueue{}, &internal.ServerConfig{})
+		status := virest.Failed
+		params := virest.ListInfoParams{Status: &status, Latest: &latest}
+		resp, err := s.ListInfo(context.Background(), virest.ListInfoRequestObject{Params: params})
+		exam.Nil(e, env, err)
+		if _, ok := resp.(virest.ListInfo200JSONResponse); !ok {
+			e.Fatalf("got %T, want 200", resp)
+		}
+		exam.Equal(e, env, any([]string{"discarded"}), gotArgs[1])
+		exam.Equal(e, env, any(false), gotArgs[2])
+	})
+
+	e.Run("Invalid page token", func(e exam.E) {
+		s := newTestServer(e, &fakeStore{}, &fakeQueue{}, &internal.ServerConfig{})
+		token := "not-a-token"
+		params := virest.ListInfoParams{Latest: &latest, PageToken: &token}
+		resp, err := s.ListInfo(context.Background(), virest.ListInfoRequestObject{Params: params})
+		exam.Nil(e, env, err)
+		got, ok := resp.(virest.ListInfo400JSONResponse)
+		if !ok {
+			e.Fatalf("got %T, want 400", resp)
+		}
+		exam.Equal(e, env, "INVALID_PAGE_TOKEN", got.Code)
+	})
+}
@@ -0,0 +1,216 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/krelinga/video-info/internal"
+	"github.com/krelinga/video-info/virest"
+	"github.com/riverqueue/river"
+)
+
+// Page sizes for listing info jobs.
+const (
+	defaultListLimit = 100
+	maxListLimit     = 1000
+)
+
+// Bounds on the length of path searches.  Shorter searches can't use the
+// trigram indexes.
+const (
+	minPathSearchLength = 3
+	maxPathSearchLength = 256
+)
+
+// ListInfo handles GET /info requests.
+func (s *Server) ListInfo(ctx context.Context, request virest.ListInfoRequestObject) (virest.ListInfoResponseObject, error) {
+	params := request.Params
+
+	limit := defaultListLimit
+	if params.Limit != nil {
+		limit = *params.Limit
+		if limit < 1 || limit > maxListLimit {
+			return virest.ListInfo400JSONResponse{
+				Code:    "INVALID_LIMIT",
+				Message: fmt.Sprintf("limit must be between 1 and %d", maxListLimit),
+			}, nil
+		}
+	}
+
+	labels, invalid, err := labelFilter(params.Label)
+	if err != nil {
+		return virest.ListInfo500JSONResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: err.Error(),
+		}, nil
+	} else if invalid != nil {
+		return virest.ListInfo400JSONResponse(*invalid), nil
+	}
+	pathSearch, invalid := pathSearchPattern(params.Q)
+	if invalid != nil {
+		return virest.ListInfo400JSONResponse(*invalid), nil
+	}
+	if params.Order != nil && params.Sort == nil {
+		return virest.ListInfo400JSONResponse{
+			Code:    "INVALID_SORT",
+			Message: "order requires sort",
+		}, nil
+	}
+	if params.Latest != nil && *params.Latest {
+		if params.Sort != nil {
+			return virest.ListInfo400JSONResponse{
+				Code:    "INVALID_SORT",
+				Message: "sort can't be combined with latest",
+			}, nil
+		}
+		return s.listLatestInfo(ctx, params, labels, pathSearch, limit)
+	}
+	if params.Sort != nil {
+		return s.listSortedInfo(ctx, params, labels, pathSearch, limit)
+	}
+
+	// Only jobs created through the API have a mapping, which also hides jobs
+	// that are being purged
+	listParams := river.NewJobListParams().
+		Kinds(internal.InfoJobArgs{}.Kind()).
+		Where("EXISTS (SELECT 1 FROM uuid_job_mapping WHERE uuid_job_mapping.river_job_id = river_job.id)").
+		OrderBy(river.JobListOrderByID, river.SortOrderDesc).
+		First(limit)
+
+	if params.Status != nil {
+		states := riverStatesForStatus(*params.Status)
+		if len(states) == 0 {
+			return virest.ListInfo400JSONResponse{
+				Code:    "INVALID_STATUS",
+				Message: fmt.Sprintf("unsupported status %q", *params.Status),
+			}, nil
+		}
+		listParams = listParams.States(states...)
+	}
+	if params.VideoPath != nil {
+		listParams = listParams.Where("args->>'path' = @video_path", river.NamedArgs{"video_path": *params.VideoPath})
+	}
+	if params.PathPrefix != nil {
+		listParams = listParams.Where("starts_with(args->>'path', @path_prefix)", river.NamedArgs{"path_prefix": *params.PathPrefix})
+	}
+	if params.CreatedAfter != nil {
+		listParams = listParams.Where("created_at >= @created_after", river.NamedArgs{"created_after": *params.CreatedAfter})
+	}
+	if params.CreatedBefore != nil {
+		listParams = listParams.Where("created_at < @created_before", river.NamedArgs{"created_before": *params.CreatedBefore})
+	}
+	if pathSearch != nil {
+		listParams = listParams.Where("args->>'path' ILIKE @path_search", river.NamedArgs{"path_search": *pathSearch})
+	}
+	if labels != nil {
+		listParams = listParams.Where("args->'labels' @> @labels::jsonb", river.NamedArgs{"labels": *labels})
+	}
+	if params.Acknowledged != nil {
+		listParams = listParams.Where("EXISTS (SELECT 1 FROM uuid_job_mapping WHERE uuid_job_mapping.river_job_id = river_job.id AND acknowledged = @acknowledged)", river.NamedArgs{"acknowledged": *params.Acknowledged})
+	}
+	if params.PageToken != nil {
+		var cursor river.JobListCursor
+		if err := cursor.UnmarshalText([]byte(*params.PageToken)); err != nil {
+			return virest.ListInfo400JSONResponse{
+				Code:    "INVALID_PAGE_TOKEN",
+				Message: "pageToken is not a token returned by this endpoint",
+			}, nil
+		}
+		listParams = listParams.After(&cursor)
+	}
+
+	result, err := s.readRiverClient.JobList(ctx, listParams)
+	if err != nil {
+		return virest.ListInfo500JSONResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: fmt.Sprintf("failed to list river jobs: %v", err),
+		}, nil
+	}
+
+	riverJobIDs := make([]int64, 0, len(result.Jobs))
+	for _, job := range result.Jobs {
+		riverJobIDs = append(riverJobIDs, job.ID)
+	}
+	annotations, err := s.loadAnnotations(ctx, riverJobIDs)
+	if err != nil {
+		return virest.ListInfo500JSONResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: err.Error(),
+		}, nil
+	}
+
+	items := make([]virest.InfoJob, 0, len(result.Jobs))
+	for _, job := range result.Jobs {
+		infoJob, err := newInfoJob(job)
+		if err != nil {
+			return virest.ListInfo500JSONResponse{
+				Code:    "INTERNAL_ERROR",
+				Message: err.Error(),
+			}, nil
+		}
+		infoJob.Annotations = annotations[job.ID]
+		items = append(items, *infoJob)
+	}
+
+	response := virest.ListInfo200JSONResponse{Items: items}
+	if len(result.Jobs) == limit && result.LastCursor != nil {
+		token, err := result.LastCursor.MarshalText()
+		if err != nil {
+			return virest.ListInfo500JSONResponse{
+				Code:    "INTERNAL_ERROR",
+				Message: fmt.Sprintf("failed to encode page token: %v", err),
+			}, nil
+		}
+		nextPageToken := string(token)
+		response.NextPageToken = &nextPageToken
+	}
+	return response, nil
+}
+
+// labelFilter parses label filters given as key:value into the JSON object
+// that the labels of matching jobs contain, or nil if none are given.
+func labelFilter(filters []string) (*string, *virest.Error, error) {
+	if len(filters) == 0 {
+		return nil, nil, nil
+	}
+	labels := make(map[string]string, len(filters))
+	for _, label := range filters {
+		key, value, ok := strings.Cut(label, ":")
+		if !ok || key == "" {
+			return nil, &virest.Error{
+				Code:    "INVALID_LABEL",
+				Message: fmt.Sprintf("label %q must be given as key:value", label),
+			}, nil
+		}
+		labels[key] = value
+	}
+	encoded, err := json.Marshal(labels)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to encode labels: %w", err)
+	}
+	filter := string(encoded)
+	return &filter, nil, nil
+}
+
+// pathSearchPattern converts a search of video paths into the ILIKE pattern
+// of the paths that contain its words in order, or nil if none is given.
+func pathSearchPattern(q *string) (*string, *virest.Error) {
+	if q == nil {
+		return nil, nil
+	}
+	words := strings.Fields(*q)
+	if len(*q) > maxPathSearchLength || len(strings.Join(words, "")) < minPathSearchLength {
+		return nil, &virest.Error{
+			Code:    "INVALID_QUERY",
+			Message: fmt.Sprintf("q must have between %d and %d characters other than spaces", minPathSearchLength, maxPathSearchLength),
+		}
+	}
+	escaper := strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+	for i, word := range words {
+		words[i] = escaper.Replace(word)
+	}
+	pattern := "%" + strings.Join(words, "%") + "%"
+	return &pattern, nil
+}
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/krelinga/go-libs/deep"
+	"github.com/krelinga/go-libs/exam"
+	"github.com/krelinga/video-info/internal"
+	"github.com/krelinga/video-info/virest"
+)
+
+func TestPathSearchPattern(t *testing.T) {
+	e := exam.New(t)
+	env := deep.NewEnv()
+
+	tests := []struct {
+		loc         exam.Loc
+		name        string
+		q           string
+		wantPattern string
+		wantInvalid bool
+	}{
+		{
+			loc:         exam.Here(),
+			name:        "Words in order",
+			q:           "  Breaking Bad  s02e05 ",
+			wantPattern: "%Breaking%Bad%s02e05%",
+		},
+		{
+			loc:         exam.Here(),
+			name:        "Wildcards are literal",
+			q:           `100%_done\`,
+			wantPattern: `%100\%\_done\\%`,
+		},
+		{
+			loc:         exam.Here(),
+			name:        "Too short",
+			q:           " a b ",
+			wantInvalid: true,
+		},
+		{
+			loc:         exam.Here(),
+			name:        "Too long",
+			q:           strings.Repeat("a", maxPathSearchLength+1),
+			wantInvalid: true,
+		},
+	}
+	for _, tt := range tests {
+		e.Run(tt.name, func(e exam.E) {
+			e.Log("Running test at", tt.loc)
+			pattern, invalid := pathSearchPattern(&tt.q)
+			if tt.wantInvalid {
+				if invalid == nil {
+					e.Fatalf("got pattern %q, want invalid", *pattern)
+				}
+				exam.Equal(e, env, "INVALID_QUERY", invalid.Code)
+				return
+			}
+			if invalid != nil {
+				e.Fatalf("got %s, want a pattern", invalid.Message)
+			}
+			exam.Equal(e, env, tt.wantPattern, *pattern)
+		})
+	}
+
+	e.Run("Unset", func(e exam.E) {
+		pattern, invalid := pathSearchPattern(nil)
+		exam.Equal(e, env, true, pattern == nil && invalid == nil)
+	})
+}
+
+func TestListInfoPathSearch(t *testing.T) {
+	e := exam.New(t)
+	env := deep.NewEnv()
+
+	e.Run("Latest", func(e exam.E) {
+		var gotArgs []any
+		store := &fakeStore{
+			query: func(sql string, args []any) (pgx.Rows, error) {
+				if strings.Contains(sql, "info_result") {
+					gotArgs = args
+				}
+				return &fakeRows{}, nil
+			},
+		}
+		s := newTestServer(e, store, &fakeQueue{}, &internal.ServerConfig{})
+		latest := true
+		q := "s02e05"
+		resp, err := s.ListInfo(context.Background(), virest.ListInfoRequestObject{Params: virest.ListInfoParams{Latest: &latest, Q: &q}})
+		exam.Nil(e, env, err)
+		if _, ok := resp.(virest.ListInfo200JSONResponse); !ok {
+			e.Fatalf("got %T, want 200", resp)
+		}
+		exam.Equal(e, env, "%s02e05%", *gotArgs[11].(*string))
+	})
+
+	e.Run("Invalid", func(e exam.E) {
+		s := newTestServer(e, &fakeStore{}, &fakeQueue{}, &internal.ServerConfig{})
+		q := "ab"
+		resp, err := s.ListInfo(context.Background(), virest.ListInfoRequestObject{Params: virest.ListInfoParams{Q: &q}})
+		exam.Nil(e, env, err)
+		got, ok := resp.(virest.ListInfo400JSONResponse)
+		if !ok {
+			e.Fatalf("got %T, want 400", resp)
+		}
+		exam.Equal(e, env, "INVALID_QUERY", got.Code)
+	})
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"log"
 	"net/http"
+	"os"
 	"os/signal"
 	"syscall"
 	"time"
@@ -16,6 +17,9 @@ import (
 )
 
 func main() {
+	// Keep credentials out of everything the process logs
+	log.SetOutput(internal.NewRedactingWriter(os.Stderr))
+
 	if err := run(); err != nil {
 		log.Fatalf("server error: %v", err)
 	}
@@ -26,6 +30,20 @@ func run() error {
 	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
 	defer stop()
 
+	// Dispatch maintenance subcommands
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "backup":
+			return runBackup(ctx, os.Args[2:])
+		case "restore":
+			return runRestore(ctx, os.Args[2:])
+		case "support-bundle":
+			return runSupportBundle(ctx, os.Args[2:])
+		default:
+			return fmt.Errorf("unknown command %q", os.Args[1])
+		}
+	}
+
 	// Load configuration
 	cfg := internal.NewServerConfigFromEnv()
 
@@ -43,6 +61,12 @@ func run() error {
 	}
 	log.Println("Migrations complete")
 
+	events, closeEvents, err := internal.ConnectEventPublisher(cfg.NATSURL)
+	if err != nil {
+		return err
+	}
+	defer closeEvents()
+
 	// Create River client (insert-only, no workers)
 	riverClient, err := river.NewClient(riverpgxv5.New(pool), &river.Config{
 		// No workers needed for the server - it only inserts jobs
@@ -53,14 +77,48 @@ func run() error {
 	}
 
 	// Create server and wire up HTTP handlers
-	server := NewServer(pool, riverClient)
-	strictHandler := virest.NewStrictHandler(server, nil)
-	httpHandler := virest.Handler(strictHandler)
+	server, err := NewServer(pool, riverClient, cfg)
+	if err != nil {
+		return err
+	}
+	server.publisher = events
+
+	// Send status reads to the read replica, if configured
+	if cfg.ReadReplica != nil {
+		replicaPool, err := internal.NewDBPool(ctx, cfg.ReadReplica)
+		if err != nil {
+			return fmt.Errorf("failed to create read replica pool: %w", err)
+		}
+		defer replicaPool.Close()
+
+		replicaRiverClient, err := river.NewClient(riverpgxv5.New(replicaPool), &river.Config{})
+		if err != nil {
+			return fmt.Errorf("failed to create read replica river client: %w", err)
+		}
+		server.UseReadReplica(replicaPool, replicaRiverClient)
+		log.Printf("Serving status reads from replica %s:%d", cfg.ReadReplica.Host, cfg.ReadReplica.Port)
+	}
+
+	// Keep the status cache and WebSocket subscribers in step with the
+	// database
+	handlers := []jobChangeHandler{server.events}
+	if server.statusCache != nil {
+		handlers = []jobChangeHandler{server.statusCache, server.events}
+		log.Printf("Caching up to %d job statuses", cfg.StatusCacheSize)
+	} else if cfg.StatusCacheSize > 0 {
+		log.Println("Status cache disabled: not supported with a read replica")
+	}
+	go listenForJobChanges(ctx, pool, handlers...)
+
+	strictHandler := virest.NewStrictHandler(server, []virest.StrictMiddlewareFunc{server.workerAuthMiddleware})
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /ws", server.ServeJobEvents)
+	mux.Handle("/", virest.Handler(strictHandler))
 
 	// Configure HTTP server
 	httpServer := &http.Server{
 		Addr:    fmt.Sprintf(":%d", cfg.Port),
-		Handler: httpHandler,
+		Handler: mux,
 	}
 
 	// Start HTTP server in goroutine
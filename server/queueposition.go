@@ -0,0 +1,96 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/krelinga/video-info/internal"
+	"github.com/krelinga/video-info/virest"
+)
+
+// etaThroughputWindow is how far back finished jobs are counted to estimate
+// how quickly a queue drains.
+const etaThroughputWindow = 15 * time.Minute
+
+// queueThroughput describes the jobs of one queue that finished recently.
+type queueThroughput struct {
+	// Finished is the number of jobs that finished within Window.
+	Finished int
+	Window   time.Duration
+
+	// AverageRun is how long those jobs took to run, on average.
+	AverageRun time.Duration
+}
+
+// estimateTimes estimates when a pending job with ahead jobs in front of it
+// in its queue will start and finish, given that it can't start before
+// scheduledAt.  Both are nil if no jobs finished recently, since the queue
+// may not be draining at all.
+func estimateTimes(now, scheduledAt time.Time, ahead int, throughput queueThroughput) (start, completion *time.Time) {
+	if throughput.Finished == 0 || throughput.Window <= 0 {
+		return nil, nil
+	}
+	perJob := throughput.Window / time.Duration(throughput.Finished)
+	startAt := now.Add(time.Duration(ahead) * perJob)
+	if startAt.Before(scheduledAt) {
+		startAt = scheduledAt
+	}
+	startAt = startAt.UTC().Truncate(time.Second)
+	completionAt := startAt.Add(throughput.AverageRun.Round(time.Second))
+	return &startAt, &completionAt
+}
+
+// addQueuePosition sets the queue position and the estimated start and
+// completion times of infoJob if it is pending.  A job that stops being
+// pending in the meantime is left without them.
+func (s *Server) addQueuePosition(ctx context.Context, infoJob *virest.InfoJob) error {
+	if infoJob.Status != virest.Pending {
+		return nil
+	}
+	now := s.clock.Now()
+
+	// River takes available jobs in order of priority, then scheduled time,
+	// then ID
+	var (
+		scheduledAt   time.Time
+		ahead         int
+		throughput    = queueThroughput{Window: etaThroughputWindow}
+		averageRunSec float64
+	)
+	err := s.readPool.QueryRow(ctx, `
+		WITH job AS (
+			SELECT river_job.id, river_job.queue, river_job.priority, river_job.scheduled_at
+			FROM river_job
+			JOIN uuid_job_mapping ON uuid_job_mapping.river_job_id = river_job.id
+			WHERE uuid_job_mapping.uuid = $1
+				AND river_job.state IN ('available', 'pending', 'retryable', 'scheduled')
+		)
+		SELECT job.scheduled_at,
+			(SELECT count(*) FROM river_job ahead
+				WHERE ahead.kind = $2 AND ahead.queue = job.queue
+					AND ahead.state IN ('available', 'pending', 'retryable', 'scheduled')
+					AND (ahead.priority, ahead.scheduled_at, ahead.id) < (job.priority, job.scheduled_at, job.id)),
+			finished.count, COALESCE(finished.average_run, 0)
+		FROM job, LATERAL (
+			SELECT count(*) AS count,
+				avg(extract(epoch FROM finalized_at - attempted_at)) AS average_run
+			FROM river_job
+			WHERE kind = $2 AND queue = job.queue
+				AND state IN ('completed', 'discarded') AND finalized_at >= $3
+		) finished`,
+		infoJob.Uuid, internal.InfoJobArgs{}.Kind(), now.Add(-etaThroughputWindow)).
+		Scan(&scheduledAt, &ahead, &throughput.Finished, &averageRunSec)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("failed to find queue position: %w", err)
+	}
+	position := ahead + 1
+	infoJob.QueuePosition = &position
+	throughput.AverageRun = time.Duration(averageRunSec * float64(time.Second))
+	infoJob.EstimatedStartAt, infoJob.EstimatedCompletionAt = estimateTimes(now, scheduledAt, ahead, throughput)
+	return nil
+}
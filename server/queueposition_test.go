@@ -0,0 +1,77 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/krelinga/go-libs/deep"
+	"github.com/krelinga/go-libs/exam"
+)
+
+func TestEstimateTimes(t *testing.T) {
+	e := exam.New(t)
+	env := deep.NewEnv()
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		loc            exam.Loc
+		name           string
+		scheduledAt    time.Time
+		ahead          int
+		throughput     queueThroughput
+		wantStart      time.Time
+		wantCompletion time.Time
+		wantNone       bool
+	}{
+		{
+			loc:            exam.Here(),
+			name:           "Next in line",
+			scheduledAt:    now.Add(-time.Minute),
+			throughput:     queueThroughput{Finished: 10, Window: 10 * time.Minute, AverageRun: 30 * time.Second},
+			wantStart:      now,
+			wantCompletion: now.Add(30 * time.Second),
+		},
+		{
+			loc:            exam.Here(),
+			name:           "Behind other jobs",
+			scheduledAt:    now.Add(-time.Minute),
+			ahead:          20,
+			throughput:     queueThroughput{Finished: 10, Window: 10 * time.Minute, AverageRun: 30 * time.Second},
+			wantStart:      now.Add(20 * time.Minute),
+			wantCompletion: now.Add(20*time.Minute + 30*time.Second),
+		},
+		{
+			loc:            exam.Here(),
+			name:           "Scheduled for later",
+			scheduledAt:    now.Add(time.Hour),
+			ahead:          1,
+			throughput:     queueThroughput{Finished: 10, Window: 10 * time.Minute, AverageRun: 30 * time.Second},
+			wantStart:      now.Add(time.Hour),
+			wantCompletion: now.Add(time.Hour + 30*time.Second),
+		},
+		{
+			loc:         exam.Here(),
+			name:        "Nothing finished recently",
+			scheduledAt: now,
+			ahead:       5,
+			throughput:  queueThroughput{Window: 10 * time.Minute},
+			wantNone:    true,
+		},
+	}
+	for _, tt := range tests {
+		e.Run(tt.name, func(e exam.E) {
+			e.Log("Running test at", tt.loc)
+
+			start, completion := estimateTimes(now, tt.scheduledAt, tt.ahead, tt.throughput)
+			if tt.wantNone {
+				exam.Equal(e, env, true, start == nil && completion == nil)
+				return
+			}
+			if start == nil || completion == nil {
+				e.Fatalf("got no estimates")
+			}
+			exam.Equal(e, env, true, start.Equal(tt.wantStart))
+			exam.Equal(e, env, true, completion.Equal(tt.wantCompletion))
+		})
+	}
+}
@@ -0,0 +1,225 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/krelinga/video-info/internal"
+	"github.com/krelinga/video-info/virest"
+	"github.com/riverqueue/river/rivertype"
+)
+
+// maxInfoHistory bounds the jobs listed by GetInfoHistory.
+const maxInfoHistory = 100
+
+// ReprobeInfo handles POST /info/{uuid}/reprobe requests.
+func (s *Server) ReprobeInfo(ctx context.Context, request virest.ReprobeInfoRequestObject) (virest.ReprobeInfoResponseObject, error) {
+	if request.Body == nil {
+		return virest.ReprobeInfo400JSONResponse{
+			Code:    "INVALID_REQUEST",
+			Message: "Request body is required",
+		}, nil
+	}
+
+	depth, err := s.queueDepth(ctx)
+	if err != nil {
+		return virest.ReprobeInfo500JSONResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: err.Error(),
+		}, nil
+	} else if full := s.queueFull(depth); full != nil {
+		return virest.ReprobeInfo429JSONResponse{QueueFullJSONResponse: *full}, nil
+	}
+
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return virest.ReprobeInfo500JSONResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: fmt.Sprintf("failed to begin transaction: %v", err),
+		}, nil
+	}
+	defer tx.Rollback(ctx)
+
+	// Locking the job keeps it from being retried while it is superseded
+	var (
+		encodedArgs []byte
+		finalized   bool
+	)
+	err = tx.QueryRow(ctx, `
+		SELECT river_job.args, river_job.finalized_at IS NOT NULL
+		FROM river_job JOIN uuid_job_mapping ON uuid_job_mapping.river_job_id = river_job.id
+		WHERE uuid_job_mapping.uuid = $1
+		FOR UPDATE OF river_job`, request.Uuid).Scan(&encodedArgs, &finalized)
+	if errors.Is(err, pgx.ErrNoRows) {
+		// River may have removed the job, leaving its stored result
+		finalized = true
+		err = tx.QueryRow(ctx, "SELECT args FROM info_result WHERE uuid = $1", request.Uuid).Scan(&encodedArgs)
+	}
+	if errors.Is(err, pgx.ErrNoRows) {
+		return virest.ReprobeInfo404JSONResponse{
+			Code:    "NOT_FOUND",
+			Message: fmt.Sprintf("Info job with UUID %s not found", request.Uuid),
+		}, nil
+	} else if err != nil {
+		return virest.ReprobeInfo500JSONResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: fmt.Sprintf("failed to look up job: %v", err),
+		}, nil
+	}
+	if !finalized {
+		return virest.ReprobeInfo409JSONResponse{
+			Code:    "JOB_NOT_FINISHED",
+			Message: fmt.Sprintf("Info job with UUID %s has not finished", request.Uuid),
+		}, nil
+	}
+
+	var jobArgs internal.InfoJobArgs
+	if err := json.Unmarshal(encodedArgs, &jobArgs); err != nil {
+		return virest.ReprobeInfo500JSONResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: fmt.Sprintf("failed to unmarshal job args: %v", err),
+		}, nil
+	}
+	// The allowed roots may have changed since the job was created
+	if err := s.checkVideoPath(ctx, jobArgs.Path); err != nil {
+		return virest.ReprobeInfo400JSONResponse{
+			Code:    "INVALID_VIDEO_PATH",
+			Message: err.Error(),
+		}, nil
+	}
+	supersedes := uuid.UUID(request.Uuid)
+	jobArgs.UUID = uuid.UUID(request.Body.Uuid)
+	jobArgs.Supersedes = &supersedes
+	jobArgs.Force = true
+
+	if err := internal.LockInfoJobIDs(ctx, tx, []internal.InfoJobArgs{jobArgs}); err != nil {
+		return virest.ReprobeInfo500JSONResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: err.Error(),
+		}, nil
+	}
+
+	// Reusing the superseded job's UUID would overwrite its stored result,
+	// which may outlive its mapping
+	var existingJobID int64
+	err = tx.QueryRow(ctx, "SELECT river_job_id FROM uuid_job_mapping WHERE uuid = $1", jobArgs.UUID).Scan(&existingJobID)
+	if err == nil || jobArgs.UUID == supersedes {
+		return virest.ReprobeInfo409JSONResponse{
+			Code:    "DUPLICATE_UUID",
+			Message: fmt.Sprintf("An info job with UUID %s already exists", jobArgs.UUID),
+		}, nil
+	} else if !errors.Is(err, pgx.ErrNoRows) {
+		return virest.ReprobeInfo500JSONResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: fmt.Sprintf("failed to check existing UUID: %v", err),
+		}, nil
+	}
+
+	// The external ID moves from the superseded job to the new one, unless
+	// it has already moved on to another job
+	if jobArgs.ExternalID != nil {
+		_, err = tx.Exec(ctx, "UPDATE uuid_job_mapping SET external_id = NULL WHERE uuid = $1", request.Uuid)
+		if err != nil {
+			return virest.ReprobeInfo500JSONResponse{
+				Code:    "INTERNAL_ERROR",
+				Message: fmt.Sprintf("failed to release external ID: %v", err),
+			}, nil
+		}
+		err = tx.QueryRow(ctx, "SELECT river_job_id FROM uuid_job_mapping WHERE external_id = $1", *jobArgs.ExternalID).Scan(&existingJobID)
+		if err == nil {
+			return virest.ReprobeInfo409JSONResponse{
+				Code:    "DUPLICATE_EXTERNAL_ID",
+				Message: fmt.Sprintf("External ID %q belongs to a newer info job", *jobArgs.ExternalID),
+			}, nil
+		} else if !errors.Is(err, pgx.ErrNoRows) {
+			return virest.ReprobeInfo500JSONResponse{
+				Code:    "INTERNAL_ERROR",
+				Message: fmt.Sprintf("failed to check existing external ID: %v", err),
+			}, nil
+		}
+	}
+
+	insertedJob, err := s.riverClient.InsertTx(ctx, tx, jobArgs, nil)
+	if err != nil {
+		return virest.ReprobeInfo500JSONResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: fmt.Sprintf("failed to insert river job: %v", err),
+		}, nil
+	}
+	_, err = tx.Exec(ctx, "INSERT INTO uuid_job_mapping (uuid, river_job_id, external_id) VALUES ($1, $2, $3)", jobArgs.UUID, insertedJob.Job.ID, jobArgs.ExternalID)
+	if err != nil {
+		return virest.ReprobeInfo500JSONResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: fmt.Sprintf("failed to insert uuid mapping: %v", err),
+		}, nil
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return virest.ReprobeInfo500JSONResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: fmt.Sprintf("failed to commit transaction: %v", err),
+		}, nil
+	}
+	s.publisher.PublishCreated(insertedJob)
+
+	encodedArgs, err = json.Marshal(jobArgs)
+	if err != nil {
+		return virest.ReprobeInfo500JSONResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: fmt.Sprintf("failed to marshal job args: %v", err),
+		}, nil
+	}
+	infoJob, err := newInfoJob(&rivertype.JobRow{
+		ID:          insertedJob.Job.ID,
+		State:       rivertype.JobStateAvailable,
+		EncodedArgs: encodedArgs,
+		Priority:    jobArgs.RESTPriority(),
+		CreatedAt:   s.clock.Now(),
+	})
+	if err != nil {
+		return virest.ReprobeInfo500JSONResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: err.Error(),
+		}, nil
+	}
+	return virest.ReprobeInfo201JSONResponse{
+		Body:    *infoJob,
+		Headers: virest.ReprobeInfo201ResponseHeaders{XQueueDepth: depth},
+	}, nil
+}
+
+// GetInfoHistory handles GET /info/{uuid}/history requests.
+func (s *Server) GetInfoHistory(ctx context.Context, request virest.GetInfoHistoryRequestObject) (virest.GetInfoHistoryResponseObject, error) {
+	infoJob, err := s.findInfoJob(ctx, "uuid", request.Uuid)
+	if errors.Is(err, errJobNotFound) {
+		return virest.GetInfoHistory404JSONResponse{
+			Code:    "NOT_FOUND",
+			Message: fmt.Sprintf("Info job with UUID %s not found", request.Uuid),
+		}, nil
+	} else if err != nil {
+		return virest.GetInfoHistory500JSONResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: err.Error(),
+		}, nil
+	}
+
+	// Chains are short, so each superseded job is looked up in turn
+	items := []virest.InfoJob{*infoJob}
+	for len(items) < maxInfoHistory && infoJob.Supersedes != nil {
+		infoJob, err = s.findInfoJob(ctx, "uuid", *infoJob.Supersedes)
+		if errors.Is(err, errJobNotFound) {
+			break
+		} else if err != nil {
+			return virest.GetInfoHistory500JSONResponse{
+				Code:    "INTERNAL_ERROR",
+				Message: err.Error(),
+			}, nil
+		}
+		items = append(items, *infoJob)
+	}
+	return virest.GetInfoHistory200JSONResponse{Items: items}, nil
+}
@@ -0,0 +1,179 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/krelinga/go-libs/deep"
+	"github.com/krelinga/go-libs/exam"
+	"github.com/krelinga/video-info/internal"
+	"github.com/krelinga/video-info/virest"
+)
+
+func TestReprobeInfo(t *testing.T) {
+	e := exam.New(t)
+	env := deep.NewEnv()
+	cfg := &internal.ServerConfig{AllowedRoots: internal.AllowedRoots{"/videos/"}}
+	oldID := uuid.New()
+	externalID := "movie-42"
+	encodedArgs, err := json.Marshal(internal.InfoJobArgs{
+		UUID:       oldID,
+		ExternalID: &externalID,
+		Path:       "/videos/a.mkv",
+		Labels:     map[string]string{"library": "movies"},
+		Verify:     true,
+	})
+	if err != nil {
+		e.Fatal(err)
+	}
+
+	// newReprobeTx returns a transaction in which the job to supersede has
+	// the given finalized state, or only a stored result if stored is set.
+	newReprobeTx := func(finalized, stored bool) *fakeTx {
+		tx := newCreateTx()
+		tx.queryRow = func(sql string, _ []any) pgx.Row {
+			switch {
+			case strings.Contains(sql, "FOR UPDATE OF river_job"):
+				if stored {
+					return fakeRow{err: pgx.ErrNoRows}
+				}
+				return fakeRow{values: []any{encodedArgs, finalized}}
+			case strings.Contains(sql, "info_result"):
+				return fakeRow{values: []any{encodedArgs}}
+			}
+			return fakeRow{err: pgx.ErrNoRows}
+		}
+		return tx
+	}
+	reprobe := func(s *Server, newID uuid.UUID) virest.ReprobeInfoResponseObject {
+		resp, err := s.ReprobeInfo(context.Background(), virest.ReprobeInfoRequestObject{
+			Uuid: oldID,
+			Body: &virest.ReprobeRequest{Uuid: newID},
+		})
+		exam.Nil(e, env, err)
+		return resp
+	}
+
+	e.Run("Not found", func(e exam.E) {
+		tx := newCreateTx()
+		store := &fakeStore{begin: func() (pgx.Tx, error) { return tx, nil }}
+		s := newTestServer(e, store, &fakeQueue{}, cfg)
+		resp := reprobe(s, uuid.New())
+		if _, ok := resp.(virest.ReprobeInfo404JSONResponse); !ok {
+			e.Fatalf("got %T, want 404", resp)
+		}
+	})
+
+	e.Run("Not finished", func(e exam.E) {
+		tx := newReprobeTx(false, false)
+		store := &fakeStore{begin: func() (pgx.Tx, error) { return tx, nil }}
+		queue := &fakeQueue{}
+		s := newTestServer(e, store, queue, cfg)
+		got, ok := reprobe(s, uuid.New()).(virest.ReprobeInfo409JSONResponse)
+		if !ok {
+			e.Fatalf("got %T, want 409", got)
+		}
+		exam.Equal(e, env, "JOB_NOT_FINISHED", got.Code)
+		exam.Equal(e, env, 0, len(queue.inserted))
+	})
+
+	e.Run("Own UUID", func(e exam.E) {
+		tx := newReprobeTx(true, true)
+		store := &fakeStore{begin: func() (pgx.Tx, error) { return tx, nil }}
+		s := newTestServer(e, store, &fakeQueue{}, cfg)
+		got, ok := reprobe(s, oldID).(virest.ReprobeInfo409JSONResponse)
+		if !ok {
+			e.Fatalf("got %T, want 409", got)
+		}
+		exam.Equal(e, env, "DUPLICATE_UUID", got.Code)
+	})
+
+	e.Run("Created", func(e exam.E) {
+		tx := newReprobeTx(true, false)
+		var released bool
+		tx.exec = func(sql string, _ []any) error {
+			if strings.Contains(sql, "external_id = NULL") {
+				released = true
+			}
+			return nil
+		}
+		store := &fakeStore{begin: func() (pgx.Tx, error) { return tx, nil }}
+		queue := &fakeQueue{}
+		s := newTestServer(e, store, queue, cfg)
+		s.clock = internal.NewFakeClock(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC))
+		newID := uuid.New()
+		got, ok := reprobe(s, newID).(virest.ReprobeInfo201JSONResponse)
+		if !ok {
+			e.Fatalf("got %T, want 201", got)
+		}
+		exam.Equal(e, env, true, tx.committed)
+		exam.Equal(e, env, true, released)
+		exam.Equal(e, env, virest.Pending, got.Body.Status)
+		exam.Equal(e, env, newID.String(), got.Body.Uuid.String())
+		exam.Equal(e, env, oldID.String(), got.Body.Supersedes.String())
+		exam.Equal(e, env, externalID, *got.Body.ExternalId)
+		if len(queue.inserted) != 1 {
+			e.Fatalf("inserted %d jobs, want 1", len(queue.inserted))
+		}
+		args := queue.inserted[0].(internal.InfoJobArgs)
+		exam.Equal(e, env, newID.String(), args.UUID.String())
+		exam.Equal(e, env, "/videos/a.mkv", args.Path)
+		exam.Equal(e, env, "movies", args.Labels["library"])
+		exam.Equal(e, env, true, args.Verify)
+		exam.Equal(e, env, true, args.Force)
+	})
+}
+
+func TestGetInfoHistory(t *testing.T) {
+	e := exam.New(t)
+	env := deep.NewEnv()
+	cfg := &internal.ServerConfig{}
+	ids := []uuid.UUID{uuid.New(), uuid.New(), uuid.New()}
+	finalizedAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	// Each job supersedes the next, and the last one's result is gone
+	results := make(map[uuid.UUID][]byte)
+	for i, id := range ids[:2] {
+		jobArgs := internal.InfoJobArgs{UUID: id, Path: "/videos/a.mkv", Supersedes: &ids[i+1]}
+		encodedArgs, err := json.Marshal(jobArgs)
+		if err != nil {
+			e.Fatal(err)
+		}
+		results[id] = encodedArgs
+	}
+	store := &fakeStore{
+		queryRow: func(sql string, args []any) pgx.Row {
+			if strings.Contains(sql, "info_result") {
+				if encodedArgs, ok := results[args[0].(uuid.UUID)]; ok {
+					return fakeRow{values: []any{encodedArgs, []byte(`{}`), 1, finalizedAt.Add(-time.Minute), finalizedAt}}
+				}
+			}
+			return fakeRow{err: pgx.ErrNoRows}
+		},
+	}
+	s := newTestServer(e, store, &fakeQueue{}, cfg)
+
+	resp, err := s.GetInfoHistory(context.Background(), virest.GetInfoHistoryRequestObject{Uuid: ids[0]})
+	exam.Nil(e, env, err)
+	got, ok := resp.(virest.GetInfoHistory200JSONResponse)
+	if !ok {
+		e.Fatalf("got %T, want 200", resp)
+	}
+	if len(got.Items) != 2 {
+		e.Fatalf("got %d items, want 2", len(got.Items))
+	}
+	exam.Equal(e, env, ids[0].String(), got.Items[0].Uuid.String())
+	exam.Equal(e, env, ids[1].String(), got.Items[1].Uuid.String())
+	exam.Equal(e, env, ids[2].String(), got.Items[1].Supersedes.String())
+
+	resp, err = s.GetInfoHistory(context.Background(), virest.GetInfoHistoryRequestObject{Uuid: ids[2]})
+	exam.Nil(e, env, err)
+	if _, ok := resp.(virest.GetInfoHistory404JSONResponse); !ok {
+		e.Fatalf("got %T, want 404", resp)
+	}
+}
@@ -0,0 +1,134 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/krelinga/video-info/internal"
+	"github.com/krelinga/video-info/virest"
+)
+
+// Limits on the rate of reprobe campaigns.
+const (
+	defaultReprobesPerMinute = 60
+	maxReprobesPerMinute     = 10000
+)
+
+// CreateReprobeCampaign handles POST /admin/reprobe-campaigns requests.
+func (s *Server) CreateReprobeCampaign(ctx context.Context, request virest.CreateReprobeCampaignRequestObject) (virest.CreateReprobeCampaignResponseObject, error) {
+	body := request.Body
+	if body == nil {
+		return virest.CreateReprobeCampaign400JSONResponse{
+			Code:    "INVALID_REQUEST",
+			Message: "Request body is required",
+		}, nil
+	}
+	if body.FfprobeVersion == "" {
+		return virest.CreateReprobeCampaign400JSONResponse{
+			Code:    "INVALID_REQUEST",
+			Message: "ffprobeVersion is required",
+		}, nil
+	}
+	for _, field := range body.Fields {
+		if !internal.IsMediaInfoField(field) {
+			return virest.CreateReprobeCampaign400JSONResponse{
+				Code:    "INVALID_FIELD",
+				Message: fmt.Sprintf("%q is not a MediaInfo property", field),
+			}, nil
+		}
+	}
+	perMinute := defaultReprobesPerMinute
+	if body.PerMinute != nil {
+		perMinute = *body.PerMinute
+		if perMinute < 1 || perMinute > maxReprobesPerMinute {
+			return virest.CreateReprobeCampaign400JSONResponse{
+				Code:    "INVALID_RATE",
+				Message: fmt.Sprintf("perMinute must be between 1 and %d", maxReprobesPerMinute),
+			}, nil
+		}
+	}
+
+	id := uuid.New()
+	now := s.clock.Now()
+	_, err := s.pool.Exec(ctx, `
+		INSERT INTO reprobe_campaigns (id, ffprobe_version, fields, path_prefix, per_minute, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)`,
+		id, body.FfprobeVersion, body.Fields, body.PathPrefix, perMinute, now)
+	if err != nil {
+		return virest.CreateReprobeCampaign500JSONResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: fmt.Sprintf("failed to insert reprobe campaign: %v", err),
+		}, nil
+	}
+
+	return virest.CreateReprobeCampaign201JSONResponse{
+		Id:             id,
+		FfprobeVersion: body.FfprobeVersion,
+		Fields:         body.Fields,
+		PathPrefix:     body.PathPrefix,
+		PerMinute:      perMinute,
+		CreatedAt:      now,
+	}, nil
+}
+
+// ListReprobeCampaigns handles GET /admin/reprobe-campaigns requests.
+func (s *Server) ListReprobeCampaigns(ctx context.Context, request virest.ListReprobeCampaignsRequestObject) (virest.ListReprobeCampaignsResponseObject, error) {
+	rows, err := s.readPool.Query(ctx, `
+		SELECT id, ffprobe_version, fields, path_prefix, per_minute, enqueued, created_at, finished_at
+		FROM reprobe_campaigns
+		ORDER BY created_at, id`)
+	if err != nil {
+		return virest.ListReprobeCampaigns500JSONResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: fmt.Sprintf("failed to list reprobe campaigns: %v", err),
+		}, nil
+	}
+	defer rows.Close()
+
+	campaigns := []virest.ReprobeCampaign{}
+	for rows.Next() {
+		var (
+			c          virest.ReprobeCampaign
+			finishedAt *time.Time
+		)
+		if err := rows.Scan(&c.Id, &c.FfprobeVersion, &c.Fields, &c.PathPrefix, &c.PerMinute, &c.Enqueued, &c.CreatedAt, &finishedAt); err != nil {
+			return virest.ListReprobeCampaigns500JSONResponse{
+				Code:    "INTERNAL_ERROR",
+				Message: fmt.Sprintf("failed to scan reprobe campaign: %v", err),
+			}, nil
+		}
+		c.FinishedAt = finishedAt
+		campaigns = append(campaigns, c)
+	}
+	if err := rows.Err(); err != nil {
+		return virest.ListReprobeCampaigns500JSONResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: fmt.Sprintf("failed to list reprobe campaigns: %v", err),
+		}, nil
+	}
+
+	return virest.ListReprobeCampaigns200JSONResponse{Campaigns: campaigns}, nil
+}
+
+// DeleteReprobeCampaign handles DELETE /admin/reprobe-campaigns/{id}
+// requests.
+func (s *Server) DeleteReprobeCampaign(ctx context.Context, request virest.DeleteReprobeCampaignRequestObject) (virest.DeleteReprobeCampaignResponseObject, error) {
+	var id uuid.UUID
+	err := s.pool.QueryRow(ctx, "DELETE FROM reprobe_campaigns WHERE id = $1 RETURNING id", request.Id).Scan(&id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return virest.DeleteReprobeCampaign404JSONResponse{
+			Code:    "NOT_FOUND",
+			Message: fmt.Sprintf("Reprobe campaign %s not found", request.Id),
+		}, nil
+	} else if err != nil {
+		return virest.DeleteReprobeCampaign500JSONResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: fmt.Sprintf("failed to delete reprobe campaign: %v", err),
+		}, nil
+	}
+	return virest.DeleteReprobeCampaign204Response{}, nil
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/krelinga/go-libs/deep"
+	"github.com/krelinga/go-libs/exam"
+	"github.com/krelinga/video-info/internal"
+	"github.com/krelinga/video-info/virest"
+)
+
+func TestCreateReprobeCampaign(t *testing.T) {
+	e := exam.New(t)
+	env := deep.NewEnv()
+
+	e.Run("Invalid requests", func(e exam.E) {
+		zero := 0
+		tests := []struct {
+			loc      exam.Loc
+			name     string
+			body     virest.ReprobeCampaignRequest
+			wantCode string
+		}{
+			{
+				loc:      exam.Here(),
+				name:     "Missing ffprobe version",
+				body:     virest.ReprobeCampaignRequest{},
+				wantCode: "INVALID_REQUEST",
+			},
+			{
+				loc:      exam.Here(),
+				name:     "Unknown field",
+				body:     virest.ReprobeCampaignRequest{FfprobeVersion: "7.1", Fields: []string{"subtitle_tracks"}},
+				wantCode: "INVALID_FIELD",
+			},
+			{
+				loc:      exam.Here(),
+				name:     "Zero rate",
+				body:     virest.ReprobeCampaignRequest{FfprobeVersion: "7.1", PerMinute: &zero},
+				wantCode: "INVALID_RATE",
+			},
+		}
+		for _, tt := range tests {
+			e.Run(tt.name, func(e exam.E) {
+				e.Log("Running test at", tt.loc)
+				s := newTestServer(e, &fakeStore{}, &fakeQueue{}, &internal.ServerConfig{})
+				resp, err := s.CreateReprobeCampaign(context.Background(), virest.CreateReprobeCampaignRequestObject{Body: &tt.body})
+				exam.Nil(e, env, err)
+				got, ok := resp.(virest.CreateReprobeCampaign400JSONResponse)
+				if !ok {
+					e.Fatalf("got %T, want 400", resp)
+				}
+				exam.Equal(e, env, tt.wantCode, got.Code)
+			})
+		}
+	})
+
+	e.Run("Subtitle fix", func(e exam.E) {
+		var insertArgs []any
+		store := &fakeStore{
+			exec: func(_ string, args []any) error {
+				insertArgs = args
+				return nil
+			},
+		}
+		s := newTestServer(e, store, &fakeQueue{}, &internal.ServerConfig{})
+		now := time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC)
+		s.clock = internal.NewFakeClock(now)
+		prefix := "/nas/media/"
+		resp, err := s.CreateReprobeCampaign(context.Background(), virest.CreateReprobeCampaignRequestObject{Body: &virest.ReprobeCampaignRequest{
+			FfprobeVersion: "7.1",
+			Fields:         []string{"subtitleTracks"},
+			PathPrefix:     &prefix,
+		}})
+		exam.Nil(e, env, err)
+		got, ok := resp.(virest.CreateReprobeCampaign201JSONResponse)
+		if !ok {
+			e.Fatalf("got %T, want 201", resp)
+		}
+		exam.Equal(e, env, "7.1", got.FfprobeVersion)
+		exam.Equal(e, env, defaultReprobesPerMinute, got.PerMinute)
+		exam.Equal(e, env, 0, got.Enqueued)
+		exam.Equal(e, env, true, got.CreatedAt.Equal(now))
+		exam.Equal(e, env, true, got.FinishedAt == nil)
+
+		exam.Equal(e, env, 6, len(insertArgs))
+		exam.Equal(e, env, uuid.UUID(got.Id).String(), insertArgs[0].(uuid.UUID).String())
+		exam.Equal(e, env, any([]string{"subtitleTracks"}), insertArgs[2])
+		exam.Equal(e, env, any(defaultReprobesPerMinute), insertArgs[4])
+	})
+}
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/krelinga/video-info/internal"
+	"github.com/krelinga/video-info/virest"
+	"github.com/riverqueue/river/rivertype"
+)
+
+// startScanPreview enqueues a dry run of scan, for GET /scan-previews/{id}
+// to report on.
+func (s *Server) startScanPreview(ctx context.Context, scan internal.ScanArgs) (virest.CreateScheduleResponseObject, error) {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return virest.CreateSchedule500JSONResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: fmt.Sprintf("failed to begin transaction: %v", err),
+		}, nil
+	}
+	defer tx.Rollback(ctx)
+
+	scan.DryRun = true
+	insertedJob, err := s.riverClient.InsertTx(ctx, tx, scan, nil)
+	if err != nil {
+		return virest.CreateSchedule500JSONResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: fmt.Sprintf("failed to insert river job: %v", err),
+		}, nil
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return virest.CreateSchedule500JSONResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: fmt.Sprintf("failed to commit transaction: %v", err),
+		}, nil
+	}
+
+	return virest.CreateSchedule202JSONResponse{
+		Id:        insertedJob.Job.ID,
+		Directory: scan.Directory,
+	}, nil
+}
+
+// GetScanPreview handles GET /scan-previews/{id} requests.  Previews are
+// the River jobs of dry run scans.
+func (s *Server) GetScanPreview(ctx context.Context, request virest.GetScanPreviewRequestObject) (virest.GetScanPreviewResponseObject, error) {
+	notFound := virest.GetScanPreview404JSONResponse{
+		Code:    "NOT_FOUND",
+		Message: fmt.Sprintf("Scan preview %d not found", request.Id),
+	}
+	job, err := s.readRiverClient.JobGet(ctx, request.Id)
+	if (errors.Is(err, rivertype.ErrNotFound) || (err == nil && job == nil)) && s.hasReadReplica() {
+		job, err = s.riverClient.JobGet(ctx, request.Id)
+	}
+	if errors.Is(err, rivertype.ErrNotFound) || (err == nil && job == nil) {
+		return notFound, nil
+	} else if err != nil {
+		return virest.GetScanPreview500JSONResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: fmt.Sprintf("failed to get river job: %v", err),
+		}, nil
+	}
+	if job.Kind != (internal.ScanArgs{}).Kind() {
+		return notFound, nil
+	}
+	var args internal.ScanArgs
+	if err := json.Unmarshal(job.EncodedArgs, &args); err != nil {
+		return virest.GetScanPreview500JSONResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: fmt.Sprintf("failed to unmarshal job args: %v", err),
+		}, nil
+	}
+	if !args.DryRun {
+		return notFound, nil
+	}
+
+	preview := virest.GetScanPreview200JSONResponse{Id: job.ID, Directory: args.Directory}
+	switch job.State {
+	case rivertype.JobStateCompleted:
+		var output internal.ScanPreview
+		if err := json.Unmarshal(job.Output(), &output); err != nil {
+			return virest.GetScanPreview500JSONResponse{
+				Code:    "INTERNAL_ERROR",
+				Message: fmt.Sprintf("failed to unmarshal job output: %v", err),
+			}, nil
+		}
+		preview.Finished = true
+		preview.Found = &output.Found
+		preview.WouldEnqueue = &output.WouldEnqueue
+		preview.Skipped = output.Skipped
+		preview.Sample = output.RESTSample()
+	case rivertype.JobStateDiscarded, rivertype.JobStateCancelled:
+		errMsg := "dry run was cancelled"
+		if len(job.Errors) > 0 {
+			errMsg = internal.Redact(job.Errors[len(job.Errors)-1].Error)
+		}
+		preview.Finished = true
+		preview.Error = &errMsg
+	}
+	return preview, nil
+}
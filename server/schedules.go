@@ -0,0 +1,227 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/krelinga/video-info/internal"
+	"github.com/krelinga/video-info/virest"
+)
+
+// CreateSchedule handles POST /schedules requests.
+func (s *Server) CreateSchedule(ctx context.Context, request virest.CreateScheduleRequestObject) (virest.CreateScheduleResponseObject, error) {
+	body := request.Body
+	if body == nil {
+		return virest.CreateSchedule400JSONResponse{
+			Code:    "INVALID_REQUEST",
+			Message: "Request body is required",
+		}, nil
+	}
+
+	schedule, err := internal.ParseCron(body.Cron)
+	if err != nil {
+		return virest.CreateSchedule400JSONResponse{
+			Code:    "INVALID_CRON",
+			Message: err.Error(),
+		}, nil
+	}
+	if internal.IsInputURL(body.Directory) || !path.IsAbs(body.Directory) {
+		return virest.CreateSchedule400JSONResponse{
+			Code:    "INVALID_DIRECTORY",
+			Message: fmt.Sprintf("directory %q is not an absolute path", body.Directory),
+		}, nil
+	}
+	if err := s.cfg.AllowedRoots.Check(body.Directory); err != nil {
+		return virest.CreateSchedule400JSONResponse{
+			Code:    "INVALID_DIRECTORY",
+			Message: err.Error(),
+		}, nil
+	}
+
+	// The column is not null
+	scan := internal.ScanArgs{Directory: body.Directory, Exclude: body.Exclude}
+	if scan.Exclude == nil {
+		scan.Exclude = []string{}
+	}
+	if err := internal.ValidateScanExclude(scan.Exclude); err != nil {
+		return virest.CreateSchedule400JSONResponse{
+			Code:    "INVALID_EXCLUDE",
+			Message: err.Error(),
+		}, nil
+	}
+	if body.MinAgeSeconds != nil {
+		if *body.MinAgeSeconds < 0 {
+			return virest.CreateSchedule400JSONResponse{
+				Code:    "INVALID_MIN_AGE",
+				Message: "minAgeSeconds must not be negative",
+			}, nil
+		}
+		scan.MinAgeSeconds = *body.MinAgeSeconds
+	}
+	if body.Incremental != nil {
+		scan.Incremental = *body.Incremental
+	}
+
+	// The info jobs of every scan share the arguments of a request for the
+	// directory itself, less its path
+	template, invalid, err := s.infoJobArgs(ctx, &virest.InfoRequest{
+		VideoPath:      body.Directory,
+		Labels:         body.Labels,
+		Queue:          body.Queue,
+		TimeoutSeconds: body.TimeoutSeconds,
+		Priority:       body.Priority,
+		Force:          body.Force,
+	})
+	if err != nil {
+		return virest.CreateSchedule500JSONResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: err.Error(),
+		}, nil
+	} else if invalid != nil {
+		return virest.CreateSchedule400JSONResponse(*invalid), nil
+	}
+	template.Path = ""
+	scan.Template = template
+	if request.Params.DryRun != nil && *request.Params.DryRun {
+		return s.startScanPreview(ctx, scan)
+	}
+
+	id := uuid.New()
+	now := s.clock.Now()
+	nextRunAt := internal.NextRun(schedule, now)
+	_, err = s.pool.Exec(ctx, `
+		INSERT INTO schedules (id, cron, directory, args, exclude, min_age_seconds, incremental, next_run_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`,
+		id, body.Cron, scan.Directory, scan.Template, scan.Exclude, scan.MinAgeSeconds, scan.Incremental, nextRunAt, now)
+	if err != nil {
+		return virest.CreateSchedule500JSONResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: fmt.Sprintf("failed to insert schedule: %v", err),
+		}, nil
+	}
+
+	return virest.CreateSchedule201JSONResponse(newSchedule(id, body.Cron, scan, nextRunAt, nil, now)), nil
+}
+
+// ListSchedules handles GET /schedules requests.
+func (s *Server) ListSchedules(ctx context.Context, request virest.ListSchedulesRequestObject) (virest.ListSchedulesResponseObject, error) {
+	// The progress of a running scan is checkpointed in its metadata, and
+	// that of a finished scan recorded as its output.  Follow-up scans
+	// aren't reported until they are due, as the scans that scheduled them
+	// report when they are.
+	rows, err := s.readPool.Query(ctx, `
+		SELECT s.id, s.cron, s.directory, s.args, s.exclude, s.min_age_seconds, s.incremental, s.next_run_at, s.last_run_at, s.created_at,
+			scan.state = 'completed', COALESCE(scan.metadata->'output', scan.metadata->'scan_progress'),
+			CASE WHEN scan.state IN ('retryable', 'discarded') THEN scan.errors->-1->>'error' END
+		FROM schedules s
+		LEFT JOIN LATERAL (
+			SELECT state, metadata, errors FROM river_job
+			WHERE kind = $1 AND args @> jsonb_build_object('schedule_id', s.id)
+				AND state <> 'scheduled'
+			ORDER BY id DESC
+			LIMIT 1
+		) scan ON true
+		ORDER BY s.created_at, s.id`,
+		internal.ScanArgs{}.Kind())
+	if err != nil {
+		return virest.ListSchedules500JSONResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: fmt.Sprintf("failed to list schedules: %v", err),
+		}, nil
+	}
+	defer rows.Close()
+
+	schedules := []virest.Schedule{}
+	for rows.Next() {
+		var (
+			id                   uuid.UUID
+			cron                 string
+			scan                 internal.ScanArgs
+			nextRunAt, createdAt time.Time
+			lastRunAt            *time.Time
+			scanFinished         *bool
+			scanProgress         *internal.ScanProgress
+			scanError            *string
+		)
+		if err := rows.Scan(&id, &cron, &scan.Directory, &scan.Template, &scan.Exclude, &scan.MinAgeSeconds, &scan.Incremental, &nextRunAt, &lastRunAt, &createdAt, &scanFinished, &scanProgress, &scanError); err != nil {
+			return virest.ListSchedules500JSONResponse{
+				Code:    "INTERNAL_ERROR",
+				Message: fmt.Sprintf("failed to scan schedule: %v", err),
+			}, nil
+		}
+		schedule := newSchedule(id, cron, scan, nextRunAt, lastRunAt, createdAt)
+		if scanFinished != nil {
+			if scanProgress == nil {
+				// Not yet through its first batch
+				scanProgress = &internal.ScanProgress{}
+			}
+			schedule.LastScan = scanProgress.RESTProgress(*scanFinished, scanError)
+		}
+		schedules = append(schedules, schedule)
+	}
+	if err := rows.Err(); err != nil {
+		return virest.ListSchedules500JSONResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: fmt.Sprintf("failed to list schedules: %v", err),
+		}, nil
+	}
+
+	return virest.ListSchedules200JSONResponse{Schedules: schedules}, nil
+}
+
+// DeleteSchedule handles DELETE /schedules/{id} requests.
+func (s *Server) DeleteSchedule(ctx context.Context, request virest.DeleteScheduleRequestObject) (virest.DeleteScheduleResponseObject, error) {
+	var id uuid.UUID
+	err := s.pool.QueryRow(ctx, "DELETE FROM schedules WHERE id = $1 RETURNING id", request.Id).Scan(&id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return virest.DeleteSchedule404JSONResponse{
+			Code:    "NOT_FOUND",
+			Message: fmt.Sprintf("Schedule %s not found", request.Id),
+		}, nil
+	} else if err != nil {
+		return virest.DeleteSchedule500JSONResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: fmt.Sprintf("failed to delete schedule: %v", err),
+		}, nil
+	}
+	return virest.DeleteSchedule204Response{}, nil
+}
+
+// newSchedule returns the REST representation of a schedule whose scans
+// share the arguments of scan.
+func newSchedule(id uuid.UUID, cron string, scan internal.ScanArgs, nextRunAt time.Time, lastRunAt *time.Time, createdAt time.Time) virest.Schedule {
+	template := scan.Template
+	schedule := virest.Schedule{
+		Id:          id,
+		Cron:        cron,
+		Directory:   scan.Directory,
+		Priority:    template.RESTPriority(),
+		Force:       template.Force,
+		Incremental: scan.Incremental,
+		NextRunAt:   nextRunAt,
+		LastRunAt:   lastRunAt,
+		CreatedAt:   createdAt,
+	}
+	if template.Labels != nil {
+		labels := virest.Labels(template.Labels)
+		schedule.Labels = &labels
+	}
+	if template.QueueName != "" {
+		schedule.Queue = &template.QueueName
+	}
+	if template.TimeoutSeconds > 0 {
+		schedule.TimeoutSeconds = &template.TimeoutSeconds
+	}
+	if len(scan.Exclude) > 0 {
+		schedule.Exclude = scan.Exclude
+	}
+	if scan.MinAgeSeconds > 0 {
+		schedule.MinAgeSeconds = &scan.MinAgeSeconds
+	}
+	return schedule
+}
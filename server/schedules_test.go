@@ -0,0 +1,340 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/krelinga/go-libs/deep"
+	"github.com/krelinga/go-libs/exam"
+	"github.com/krelinga/video-info/internal"
+	"github.com/krelinga/video-info/virest"
+	"github.com/riverqueue/river/rivertype"
+)
+
+func TestCreateSchedule(t *testing.T) {
+	e := exam.New(t)
+	env := deep.NewEnv()
+	cfg := &internal.ServerConfig{
+		JobTimeout:   10 * time.Minute,
+		AllowedRoots: internal.AllowedRoots{"/nas/"},
+	}
+
+	e.Run("Invalid requests", func(e exam.E) {
+		invalidQueue := "Not A Queue"
+		negativeAge := -1
+		tests := []struct {
+			loc      exam.Loc
+			name     string
+			body     virest.ScheduleRequest
+			wantCode string
+		}{
+			{
+				loc:      exam.Here(),
+				name:     "Invalid cron expression",
+				body:     virest.ScheduleRequest{Cron: "every night", Directory: "/nas/media"},
+				wantCode: "INVALID_CRON",
+			},
+			{
+				loc:      exam.Here(),
+				name:     "Relative directory",
+				body:     virest.ScheduleRequest{Cron: "@daily", Directory: "media"},
+				wantCode: "INVALID_DIRECTORY",
+			},
+			{
+				loc:      exam.Here(),
+				name:     "URL",
+				body:     virest.ScheduleRequest{Cron: "@daily", Directory: "smb://nas/media"},
+				wantCode: "INVALID_DIRECTORY",
+			},
+			{
+				loc:      exam.Here(),
+				name:     "Directory outside the allowed roots",
+				body:     virest.ScheduleRequest{Cron: "@daily", Directory: "/etc"},
+				wantCode: "INVALID_DIRECTORY",
+			},
+			{
+				loc:      exam.Here(),
+				name:     "Invalid queue",
+				body:     virest.ScheduleRequest{Cron: "@daily", Directory: "/nas/media", Queue: &invalidQueue},
+				wantCode: "INVALID_QUEUE",
+			},
+			{
+				loc:      exam.Here(),
+				name:     "Invalid exclusion pattern",
+				body:     virest.ScheduleRequest{Cron: "@daily", Directory: "/nas/media", Exclude: []string{"[extras"}},
+				wantCode: "INVALID_EXCLUDE",
+			},
+			{
+				loc:      exam.Here(),
+				name:     "Negative minimum age",
+				body:     virest.ScheduleRequest{Cron: "@daily", Directory: "/nas/media", MinAgeSeconds: &negativeAge},
+				wantCode: "INVALID_MIN_AGE",
+			},
+		}
+		for _, tt := range tests {
+			e.Run(tt.name, func(e exam.E) {
+				e.Log("Running test at", tt.loc)
+				s := newTestServer(e, &fakeStore{}, &fakeQueue{}, cfg)
+				resp, err := s.CreateSchedule(context.Background(), virest.CreateScheduleRequestObject{Body: &tt.body})
+				exam.Nil(e, env, err)
+				got, ok := resp.(virest.CreateSchedule400JSONResponse)
+				if !ok {
+					e.Fatalf("got %T, want 400", resp)
+				}
+				exam.Equal(e, env, tt.wantCode, got.Code)
+			})
+		}
+	})
+
+	e.Run("Nightly rescan", func(e exam.E) {
+		var insertArgs []any
+		store := &fakeStore{
+			exec: func(_ string, args []any) error {
+				insertArgs = args
+				return nil
+			},
+		}
+		s := newTestServer(e, store, &fakeQueue{}, cfg)
+		now := time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC)
+		s.clock = internal.NewFakeClock(now)
+		labels := virest.Labels{"source": "rescan"}
+		priority := internal.PriorityLowest
+		force := true
+		minAge := 900
+		incremental := true
+		resp, err := s.CreateSchedule(context.Background(), virest.CreateScheduleRequestObject{Body: &virest.ScheduleRequest{
+			Cron:          "0 3 * * *",
+			Directory:     "/nas/media/movies",
+			Labels:        &labels,
+			Priority:      &priority,
+			Force:         &force,
+			Exclude:       []string{"Extras/", "*.part"},
+			MinAgeSeconds: &minAge,
+			Incremental:   &incremental,
+		}})
+		exam.Nil(e, env, err)
+		got, ok := resp.(virest.CreateSchedule201JSONResponse)
+		if !ok {
+			e.Fatalf("got %T, want 201", resp)
+		}
+
+		nextRunAt := time.Date(2024, 1, 16, 3, 0, 0, 0, time.UTC)
+		exam.Equal(e, env, "/nas/media/movies", got.Directory)
+		exam.Equal(e, env, internal.PriorityLowest, got.Priority)
+		exam.Equal(e, env, 600, *got.TimeoutSeconds)
+		exam.Equal(e, env, true, got.Force)
+		exam.Equal(e, env, true, got.NextRunAt.Equal(nextRunAt))
+		exam.Equal(e, env, true, got.CreatedAt.Equal(now))
+		exam.Equal(e, env, true, got.LastRunAt == nil)
+		exam.Equal(e, env, []string{"Extras/", "*.part"}, got.Exclude)
+		exam.Equal(e, env, 900, *got.MinAgeSeconds)
+		exam.Equal(e, env, true, got.Incremental)
+
+		// Scans enqueue jobs for files, not the directory
+		exam.Equal(e, env, 9, len(insertArgs))
+		exam.Equal(e, env, uuid.UUID(got.Id).String(), insertArgs[0].(uuid.UUID).String())
+		template := insertArgs[3].(internal.InfoJobArgs)
+		exam.Equal(e, env, "", template.Path)
+		exam.Equal(e, env, map[string]string{"source": "rescan"}, template.Labels)
+		exam.Equal(e, env, internal.PriorityLowest, template.Priority)
+		exam.Equal(e, env, 600, template.TimeoutSeconds)
+		exam.Equal(e, env, true, template.Force)
+		exam.Equal(e, env, []string{"Extras/", "*.part"}, insertArgs[4].([]string))
+		exam.Equal(e, env, 900, insertArgs[5].(int))
+		exam.Equal(e, env, true, insertArgs[6].(bool))
+		exam.Equal(e, env, true, insertArgs[7].(time.Time).Equal(nextRunAt))
+	})
+}
+
+func TestDeleteSchedule(t *testing.T) {
+	e := exam.New(t)
+	env := deep.NewEnv()
+	id := uuid.New()
+
+	e.Run("Not found", func(e exam.E) {
+		store := &fakeStore{
+			queryRow: func(string, []any) pgx.Row {
+				return fakeRow{err: pgx.ErrNoRows}
+			},
+		}
+		s := newTestServer(e, store, &fakeQueue{}, &internal.ServerConfig{})
+		resp, err := s.DeleteSchedule(context.Background(), virest.DeleteScheduleRequestObject{Id: id})
+		exam.Nil(e, env, err)
+		got, ok := resp.(virest.DeleteSchedule404JSONResponse)
+		if !ok {
+			e.Fatalf("got %T, want 404", resp)
+		}
+		exam.Equal(e, env, "NOT_FOUND", got.Code)
+	})
+
+	e.Run("Deleted", func(e exam.E) {
+		store := &fakeStore{
+			queryRow: func(_ string, args []any) pgx.Row {
+				return fakeRow{values: []any{args[0].(uuid.UUID)}}
+			},
+		}
+		s := newTestServer(e, store, &fakeQueue{}, &internal.ServerConfig{})
+		resp, err := s.DeleteSchedule(context.Background(), virest.DeleteScheduleRequestObject{Id: id})
+		exam.Nil(e, env, err)
+		if _, ok := resp.(virest.DeleteSchedule204Response); !ok {
+			e.Fatalf("got %T, want 204", resp)
+		}
+	})
+}
+
+func TestListSchedules(t *testing.T) {
+	e := exam.New(t)
+	env := deep.NewEnv()
+	created := time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC)
+	next := created.Add(24 * time.Hour)
+	template := internal.InfoJobArgs{Priority: 1}
+
+	never, running, failed, finished := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	no, yes := false, true
+	lastError := "failed to scan /nas/tv: permission denied"
+	store := &fakeStore{
+		query: func(string, []any) (pgx.Rows, error) {
+			return &fakeRows{rows: [][]any{
+				{never, "@daily", "/nas/never", template, []string{"Extras/"}, 900, true, next, nil, created, nil, nil, nil},
+				{running, "@daily", "/nas/running", template, []string{}, 0, false, next, &created, created, &no, nil, nil},
+				{failed, "@daily", "/nas/failed", template, []string{}, 0, false, next, &created, created, &no,
+					&internal.ScanProgress{AfterPath: "/nas/failed/m.mkv", Found: 500, Enqueued: 480, Skipped: map[string]int{internal.ScanSkipCached: 20}}, &lastError},
+				{finished, "@daily", "/nas/finished", template, []string{}, 0, false, next, &created, created, &yes,
+					&internal.ScanProgress{AfterPath: "/nas/finished/z.mkv", Found: 3, Enqueued: 2, Skipped: map[string]int{internal.ScanSkipTooNew: 1}, FollowUpAt: &next}, nil},
+			}}, nil
+		},
+	}
+	s := newTestServer(e, store, &fakeQueue{}, &internal.ServerConfig{})
+	resp, err := s.ListSchedules(context.Background(), virest.ListSchedulesRequestObject{})
+	exam.Nil(e, env, err)
+	got, ok := resp.(virest.ListSchedules200JSONResponse)
+	if !ok {
+		e.Fatalf("got %T, want 200", resp)
+	}
+	exam.Equal(e, env, []string{"Extras/"}, got.Schedules[0].Exclude)
+	exam.Equal(e, env, []string(nil), got.Schedules[1].Exclude)
+	exam.Equal(e, env, 900, *got.Schedules[0].MinAgeSeconds)
+	exam.Equal(e, env, true, got.Schedules[1].MinAgeSeconds == nil)
+	exam.Equal(e, env, true, got.Schedules[0].Incremental)
+	exam.Equal(e, env, false, got.Schedules[1].Incremental)
+
+	var lastScans []*virest.ScanProgress
+	for _, schedule := range got.Schedules {
+		lastScans = append(lastScans, schedule.LastScan)
+	}
+	exam.Equal(e, env, []*virest.ScanProgress{
+		nil,
+		{},
+		{Error: &lastError, Found: 500, Enqueued: 480, Skipped: map[string]int{internal.ScanSkipCached: 20}},
+		{Finished: true, Found: 3, Enqueued: 2, Skipped: map[string]int{internal.ScanSkipTooNew: 1}, FollowUpAt: &next},
+	}, lastScans)
+}
+
+func TestScanPreview(t *testing.T) {
+	e := exam.New(t)
+	env := deep.NewEnv()
+	cfg := &internal.ServerConfig{AllowedRoots: internal.AllowedRoots{"/nas/"}}
+
+	e.Run("Dry run", func(e exam.E) {
+		tx := newCreateTx()
+		store := &fakeStore{begin: func() (pgx.Tx, error) { return tx, nil }}
+		queue := &fakeQueue{}
+		s := newTestServer(e, store, queue, cfg)
+		dryRun := true
+		minAge := 900
+		incremental := true
+		resp, err := s.CreateSchedule(context.Background(), virest.CreateScheduleRequestObject{
+			Params: virest.CreateScheduleParams{DryRun: &dryRun},
+			Body:   &virest.ScheduleRequest{Cron: "@daily", Directory: "/nas/media", Exclude: []string{"Extras/"}, MinAgeSeconds: &minAge, Incremental: &incremental},
+		})
+		exam.Nil(e, env, err)
+		got, ok := resp.(virest.CreateSchedule202JSONResponse)
+		if !ok {
+			e.Fatalf("got %T, want 202", resp)
+		}
+		exam.Equal(e, env, true, tx.committed)
+		exam.Equal(e, env, false, got.Finished)
+		if len(queue.inserted) != 1 {
+			e.Fatalf("got %d jobs, want 1", len(queue.inserted))
+		}
+		scanArgs := queue.inserted[0].(internal.ScanArgs)
+		exam.Equal(e, env, "/nas/media", scanArgs.Directory)
+		exam.Equal(e, env, []string{"Extras/"}, scanArgs.Exclude)
+		exam.Equal(e, env, 900, scanArgs.MinAgeSeconds)
+		exam.Equal(e, env, true, scanArgs.Incremental)
+		exam.Equal(e, env, true, scanArgs.DryRun)
+	})
+
+	encode := func(v any) []byte {
+		encoded, err := json.Marshal(v)
+		if err != nil {
+			e.Fatal(err)
+		}
+		return encoded
+	}
+	dryRunArgs := encode(internal.ScanArgs{Directory: "/nas/media", DryRun: true})
+	output := encode(map[string]any{rivertype.MetadataKeyOutput: internal.ScanPreview{
+		Found:         2,
+		WouldEnqueue:  1,
+		Skipped:       map[string]int{internal.ScanSkipCached: 1},
+		EnqueueSample: []internal.ScanPreviewFile{{Path: "/nas/media/a.mkv", Size: 10}},
+		SkipSample:    []internal.ScanPreviewFile{{Path: "/nas/media/b.mkv", Size: 20, SkipReason: internal.ScanSkipCached}},
+	}})
+	queue := &fakeQueue{jobs: map[int64]*rivertype.JobRow{
+		1: {ID: 1, Kind: "scan", State: rivertype.JobStateCompleted, EncodedArgs: dryRunArgs, Metadata: output},
+		2: {ID: 2, Kind: "scan", State: rivertype.JobStateRunning, EncodedArgs: dryRunArgs},
+		3: {ID: 3, Kind: "scan", State: rivertype.JobStateDiscarded, EncodedArgs: dryRunArgs, Errors: []rivertype.AttemptError{{Error: "failed to scan /nas/media: permission denied"}}},
+		4: {ID: 4, Kind: "scan", State: rivertype.JobStateCompleted, EncodedArgs: encode(internal.ScanArgs{Directory: "/nas/media"})},
+		5: {ID: 5, Kind: "info", State: rivertype.JobStateCompleted, EncodedArgs: encode(internal.InfoJobArgs{Path: "/nas/media/a.mkv"})},
+	}}
+	s := newTestServer(e, &fakeStore{}, queue, cfg)
+	get := func(id int64) virest.GetScanPreviewResponseObject {
+		resp, err := s.GetScanPreview(context.Background(), virest.GetScanPreviewRequestObject{Id: id})
+		exam.Nil(e, env, err)
+		return resp
+	}
+
+	e.Run("Finished", func(e exam.E) {
+		got, ok := get(1).(virest.GetScanPreview200JSONResponse)
+		if !ok {
+			e.Fatalf("got %T, want 200", got)
+		}
+		exam.Equal(e, env, true, got.Finished)
+		exam.Equal(e, env, 2, *got.Found)
+		exam.Equal(e, env, 1, *got.WouldEnqueue)
+		exam.Equal(e, env, map[string]int{internal.ScanSkipCached: 1}, got.Skipped)
+		exam.Equal(e, env, 2, len(got.Sample))
+		exam.Equal(e, env, "/nas/media/b.mkv", got.Sample[1].Path)
+	})
+
+	e.Run("Running", func(e exam.E) {
+		got, ok := get(2).(virest.GetScanPreview200JSONResponse)
+		if !ok {
+			e.Fatalf("got %T, want 200", got)
+		}
+		exam.Equal(e, env, false, got.Finished)
+		exam.Equal(e, env, true, got.Found == nil)
+	})
+
+	e.Run("Failed", func(e exam.E) {
+		got, ok := get(3).(virest.GetScanPreview200JSONResponse)
+		if !ok {
+			e.Fatalf("got %T, want 200", got)
+		}
+		exam.Equal(e, env, true, got.Finished)
+		exam.Equal(e, env, "failed to scan /nas/media: permission denied", *got.Error)
+	})
+
+	for _, id := range []int64{4, 5, 6} {
+		e.Run(fmt.Sprintf("Not a preview %d", id), func(e exam.E) {
+			if _, ok := get(id).(virest.GetScanPreview404JSONResponse); !ok {
+				e.Fatalf("got %T, want 404", get(id))
+			}
+		})
+	}
+}
@@ -5,31 +5,121 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"math"
+	"slices"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
-	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/krelinga/video-info/internal"
 	"github.com/krelinga/video-info/virest"
-	"github.com/riverqueue/river"
 	"github.com/riverqueue/river/rivertype"
 )
 
 // Server implements the vtrest.StrictServerInterface for handling transcode requests.
 type Server struct {
-	pool        *pgxpool.Pool
-	riverClient *river.Client[pgx.Tx]
+	pool        JobStore
+	riverClient QueueClient
+	cfg         *internal.ServerConfig
+	signer      *internal.Signer
+	keyring     *internal.Keyring
+
+	// readPool and readRiverClient serve status reads.  They point at the
+	// primary unless a read replica has been configured.
+	readPool        JobStore
+	readRiverClient QueueClient
+
+	// statusCache, if set, caches statuses read by UUID.
+	statusCache *statusCache
+
+	// events tells WebSocket subscribers about changes to their jobs.
+	events *jobEvents
+
+	// publisher, if set, publishes job lifecycle events for pull-mode
+	// workers.
+	publisher *internal.EventPublisher
+
+	depth queueDepth
+
+	clock internal.Clock
 }
 
 // NewServer creates a new Server instance.
-func NewServer(pool *pgxpool.Pool, riverClient *river.Client[pgx.Tx]) *Server {
+func NewServer(pool JobStore, riverClient QueueClient, cfg *internal.ServerConfig) (*Server, error) {
+	keyring, err := internal.NewKeyring(cfg.SecretsKeys)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create secrets keyring: %w", err)
+	}
+	if cfg.CanaryPercent < 0 || cfg.CanaryPercent > 100 {
+		return nil, fmt.Errorf("canary percent must be between 0 and 100, not %d", cfg.CanaryPercent)
+	}
+	if cfg.CanaryPercent > 0 {
+		if err := internal.ValidateQueueName(cfg.CanaryQueue); err != nil {
+			return nil, fmt.Errorf("invalid canary queue: %w", err)
+		}
+	}
+	s := &Server{
+		pool:            pool,
+		riverClient:     riverClient,
+		cfg:             cfg,
+		signer:          internal.NewSigner(cfg.SigningKey),
+		keyring:         keyring,
+		readPool:        pool,
+		readRiverClient: riverClient,
+		events:          newJobEvents(),
+		clock:           internal.SystemClock,
+	}
+	if cfg.StatusCacheSize > 0 {
+		s.statusCache = newStatusCache(cfg.StatusCacheSize)
+	}
+	return s, nil
+}
+
+// UseReadReplica directs status reads to the given replica pool and client.
+// Reads that miss on the replica are retried against the primary, so jobs
+// that have not yet replicated are still found.  The status cache is
+// disabled, since a lagging replica could fill it with statuses that
+// changes have already been announced for.
+func (s *Server) UseReadReplica(pool JobStore, riverClient QueueClient) {
+	s.readPool = pool
+	s.readRiverClient = riverClient
+	s.statusCache = nil
+}
+
+// hasReadReplica reports whether status reads go to a separate replica.
+func (s *Server) hasReadReplica() bool {
+	return s.readPool != s.pool
+}
+
+// primary returns a Server that reads statuses from the primary, for callers
+// that must not see a lagging replica's statuses.
+func (s *Server) primary() *Server {
+	if !s.hasReadReplica() {
+		return s
+	}
 	return &Server{
-		pool:        pool,
-		riverClient: riverClient,
+		pool:            s.pool,
+		riverClient:     s.riverClient,
+		cfg:             s.cfg,
+		signer:          s.signer,
+		keyring:         s.keyring,
+		readPool:        s.pool,
+		readRiverClient: s.riverClient,
+		events:          s.events,
+		publisher:       s.publisher,
+		clock:           s.clock,
 	}
 }
 
+// maxExternalIDLength is the maximum length of a caller-supplied external ID.
+const maxExternalIDLength = 256
+
+// maxWebhookReceivers bounds the number of entries in webhooks.
+const maxWebhookReceivers = 8
+
+// maxTimeoutSeconds is the longest timeoutSeconds a time.Duration holds.
+const maxTimeoutSeconds = math.MaxInt64 / int64(time.Second)
+
 // CreateInfo handles POST /info requests.
 func (s *Server) CreateInfo(ctx context.Context, request virest.CreateInfoRequestObject) (virest.CreateInfoResponseObject, error) {
 	if request.Body == nil {
@@ -39,11 +129,25 @@ func (s *Server) CreateInfo(ctx context.Context, request virest.CreateInfoReques
 		}, nil
 	}
 
-	jobArgs := internal.InfoJobArgs{
-		UUID:         uuid.UUID(request.Body.Uuid),
-		Path:         request.Body.VideoPath,
-		WebhookURI:   request.Body.WebhookUri,
-		WebhookToken: request.Body.WebhookToken,
+	jobArgs, invalid, err := s.infoJobArgs(ctx, request.Body)
+	if err != nil {
+		return virest.CreateInfo500JSONResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: err.Error(),
+		}, nil
+	} else if invalid != nil {
+		return virest.CreateInfo400JSONResponse(*invalid), nil
+	}
+	s.routeToCanary(&jobArgs)
+
+	depth, err := s.queueDepth(ctx)
+	if err != nil {
+		return virest.CreateInfo500JSONResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: err.Error(),
+		}, nil
+	} else if full := s.queueFull(depth); full != nil {
+		return virest.CreateInfo429JSONResponse{QueueFullJSONResponse: *full}, nil
 	}
 
 	// Use a transaction to insert job and mapping atomically
@@ -56,6 +160,14 @@ func (s *Server) CreateInfo(ctx context.Context, request virest.CreateInfoReques
 	}
 	defer tx.Rollback(ctx)
 
+	// Without the lock, concurrent requests could both pass the checks below
+	if err := internal.LockInfoJobIDs(ctx, tx, []internal.InfoJobArgs{jobArgs}); err != nil {
+		return virest.CreateInfo500JSONResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: err.Error(),
+		}, nil
+	}
+
 	// Check if UUID already exists
 	var existingJobID int64
 	err = tx.QueryRow(ctx, "SELECT river_job_id FROM uuid_job_mapping WHERE uuid = $1", jobArgs.UUID).Scan(&existingJobID)
@@ -72,6 +184,22 @@ func (s *Server) CreateInfo(ctx context.Context, request virest.CreateInfoReques
 		}, nil
 	}
 
+	// Check if external ID already exists
+	if jobArgs.ExternalID != nil {
+		err = tx.QueryRow(ctx, "SELECT river_job_id FROM uuid_job_mapping WHERE external_id = $1", *jobArgs.ExternalID).Scan(&existingJobID)
+		if err == nil {
+			return virest.CreateInfo409JSONResponse{
+				Code:    "DUPLICATE_EXTERNAL_ID",
+				Message: fmt.Sprintf("An info job with external ID %q already exists", *jobArgs.ExternalID),
+			}, nil
+		} else if !errors.Is(err, pgx.ErrNoRows) {
+			return virest.CreateInfo500JSONResponse{
+				Code:    "INTERNAL_ERROR",
+				Message: fmt.Sprintf("failed to check existing external ID: %v", err),
+			}, nil
+		}
+	}
+
 	// Insert job into River
 	insertedJob, err := s.riverClient.InsertTx(ctx, tx, jobArgs, nil)
 	if err != nil {
@@ -82,7 +210,7 @@ func (s *Server) CreateInfo(ctx context.Context, request virest.CreateInfoReques
 	}
 
 	// Insert UUID to job ID mapping
-	_, err = tx.Exec(ctx, "INSERT INTO uuid_job_mapping (uuid, river_job_id) VALUES ($1, $2)", jobArgs.UUID, insertedJob.Job.ID)
+	_, err = tx.Exec(ctx, "INSERT INTO uuid_job_mapping (uuid, river_job_id, external_id) VALUES ($1, $2, $3)", jobArgs.UUID, insertedJob.Job.ID, jobArgs.ExternalID)
 	if err != nil {
 		return virest.CreateInfo500JSONResponse{
 			Code:    "INTERNAL_ERROR",
@@ -96,23 +224,373 @@ func (s *Server) CreateInfo(ctx context.Context, request virest.CreateInfoReques
 			Message: fmt.Sprintf("failed to commit transaction: %v", err),
 		}, nil
 	}
+	s.publisher.PublishCreated(insertedJob)
 
-	now := time.Now()
 	return virest.CreateInfo201JSONResponse{
-		Uuid:      request.Body.Uuid,
-		Status:    virest.Pending,
-		VideoPath: request.Body.VideoPath,
-		CreatedAt: now,
-		UpdatedAt: now,
+		Body:    newPendingInfoJob(request.Body, &jobArgs, insertedJob.Job.CreatedAt),
+		Headers: virest.CreateInfo201ResponseHeaders{XQueueDepth: depth},
 	}, nil
 }
 
+// newPendingInfoJob returns the REST representation of a job that was just
+// created from body at createdAt.
+func newPendingInfoJob(body *virest.InfoRequest, jobArgs *internal.InfoJobArgs, createdAt time.Time) virest.InfoJob {
+	return virest.InfoJob{
+		Uuid:       body.Uuid,
+		ExternalId: body.ExternalId,
+		Status:     virest.Pending,
+		VideoPath:  body.VideoPath,
+		Labels:     body.Labels,
+		Resources:  jobArgs.RESTResources(),
+		Priority:   jobArgs.RESTPriority(),
+		Queue:      virest.Queue(jobArgs.Queue()),
+		CreatedAt:  createdAt,
+		UpdatedAt:  createdAt,
+	}
+}
+
+// checkVideoPath checks a requested video path against the allowed roots,
+// or a requested URL against the allowed URL schemes and the URL policy.
+func (s *Server) checkVideoPath(ctx context.Context, videoPath string) error {
+	if internal.IsInputURL(videoPath) {
+		return internal.CheckInputURL(ctx, videoPath, s.cfg.URLInputSchemes, s.cfg.WebhookPolicy)
+	}
+	return s.cfg.AllowedRoots.Check(videoPath)
+}
+
+// infoJobArgs validates an info request and builds the arguments of its job.
+// A request that fails validation is described by the returned Error.
+func (s *Server) infoJobArgs(ctx context.Context, body *virest.InfoRequest) (internal.InfoJobArgs, *virest.Error, error) {
+	if body.WebhookUri != nil {
+		if err := s.cfg.WebhookPolicy.CheckString(ctx, *body.WebhookUri); err != nil {
+			return internal.InfoJobArgs{}, &virest.Error{
+				Code:    "INVALID_WEBHOOK_URI",
+				Message: err.Error(),
+			}, nil
+		}
+	}
+
+	var labels map[string]string
+	if body.Labels != nil {
+		labels = *body.Labels
+		if err := internal.ValidateLabels(labels); err != nil {
+			return internal.InfoJobArgs{}, &virest.Error{
+				Code:    "INVALID_LABELS",
+				Message: err.Error(),
+			}, nil
+		}
+	}
+
+	if body.ExternalId != nil && (*body.ExternalId == "" || len(*body.ExternalId) > maxExternalIDLength) {
+		return internal.InfoJobArgs{}, &virest.Error{
+			Code:    "INVALID_EXTERNAL_ID",
+			Message: fmt.Sprintf("externalId must be between 1 and %d characters", maxExternalIDLength),
+		}, nil
+	}
+
+	if err := s.checkVideoPath(ctx, body.VideoPath); err != nil {
+		return internal.InfoJobArgs{}, &virest.Error{
+			Code:    "INVALID_VIDEO_PATH",
+			Message: err.Error(),
+		}, nil
+	}
+
+	var resources virest.Resources
+	if body.Resources != nil {
+		resources = *body.Resources
+		if resources != virest.Cpu && resources != virest.Gpu {
+			return internal.InfoJobArgs{}, &virest.Error{
+				Code:    "INVALID_RESOURCES",
+				Message: fmt.Sprintf("unsupported resources %q", resources),
+			}, nil
+		}
+	}
+
+	var queue string
+	if body.Queue != nil {
+		queue = *body.Queue
+		if err := internal.ValidateQueueName(queue); err != nil {
+			return internal.InfoJobArgs{}, &virest.Error{
+				Code:    "INVALID_QUEUE",
+				Message: err.Error(),
+			}, nil
+		}
+		if resources == virest.Gpu {
+			return internal.InfoJobArgs{}, &virest.Error{
+				Code:    "INVALID_QUEUE",
+				Message: "jobs that require a GPU always use the GPU queue",
+			}, nil
+		}
+	}
+
+	timeout := s.cfg.JobTimeout
+	if body.TimeoutSeconds != nil {
+		if *body.TimeoutSeconds <= 0 {
+			return internal.InfoJobArgs{}, &virest.Error{
+				Code:    "INVALID_TIMEOUT",
+				Message: "timeoutSeconds must be positive",
+			}, nil
+		}
+		if int64(*body.TimeoutSeconds) > maxTimeoutSeconds {
+			return internal.InfoJobArgs{}, &virest.Error{
+				Code:    "INVALID_TIMEOUT",
+				Message: fmt.Sprintf("timeoutSeconds must be at most %d", maxTimeoutSeconds),
+			}, nil
+		}
+		timeout = time.Duration(*body.TimeoutSeconds) * time.Second
+	}
+	if s.cfg.MaxJobTimeout > 0 && (timeout == 0 || timeout > s.cfg.MaxJobTimeout) {
+		if body.TimeoutSeconds != nil {
+			return internal.InfoJobArgs{}, &virest.Error{
+				Code:    "INVALID_TIMEOUT",
+				Message: fmt.Sprintf("timeoutSeconds must be at most %d", int(s.cfg.MaxJobTimeout.Seconds())),
+			}, nil
+		}
+		// The default may be unset or over the maximum
+		timeout = s.cfg.MaxJobTimeout
+	}
+
+	var checksum virest.ChecksumAlgorithm
+	if body.Checksum != nil {
+		checksum = *body.Checksum
+		if checksum != virest.Xxhash64 && checksum != virest.Sha256 && checksum != virest.Partial {
+			return internal.InfoJobArgs{}, &virest.Error{
+				Code:    "INVALID_CHECKSUM",
+				Message: fmt.Sprintf("unsupported checksum algorithm %q", checksum),
+			}, nil
+		}
+	}
+
+	var sampleWindows int
+	if body.SampleWindows != nil {
+		sampleWindows = *body.SampleWindows
+		if sampleWindows < 1 || sampleWindows > internal.MaxSampleWindows {
+			return internal.InfoJobArgs{}, &virest.Error{
+				Code:    "INVALID_SAMPLE_WINDOWS",
+				Message: fmt.Sprintf("sampleWindows must be between 1 and %d", internal.MaxSampleWindows),
+			}, nil
+		}
+	}
+
+	if err := internal.ValidateAnalyzerNames(body.Analyzers); err != nil {
+		return internal.InfoJobArgs{}, &virest.Error{
+			Code:    "INVALID_ANALYZERS",
+			Message: err.Error(),
+		}, nil
+	}
+
+	var priority int
+	if body.Priority != nil {
+		priority = *body.Priority
+		if priority < internal.PriorityHighest || priority > internal.PriorityLowest {
+			return internal.InfoJobArgs{}, &virest.Error{
+				Code:    "INVALID_PRIORITY",
+				Message: fmt.Sprintf("priority must be between %d and %d", internal.PriorityHighest, internal.PriorityLowest),
+			}, nil
+		}
+	}
+
+	var webhookRetry *internal.WebhookRetryPolicy
+	if body.WebhookRetry != nil {
+		policy, err := newWebhookRetryPolicy(*body.WebhookRetry)
+		if err != nil {
+			return internal.InfoJobArgs{}, &virest.Error{
+				Code:    "INVALID_WEBHOOK_RETRY",
+				Message: err.Error(),
+			}, nil
+		}
+		webhookRetry = &policy
+	}
+
+	var webhookHeaders map[string]string
+	if body.WebhookHeaders != nil {
+		if body.WebhookUri == nil {
+			return internal.InfoJobArgs{}, &virest.Error{
+				Code:    "INVALID_WEBHOOK_HEADERS",
+				Message: "webhookHeaders requires webhookUri",
+			}, nil
+		}
+		webhookHeaders = body.WebhookHeaders
+		if err := internal.CheckWebhookHeaders(webhookHeaders); err != nil {
+			return internal.InfoJobArgs{}, &virest.Error{
+				Code:    "INVALID_WEBHOOK_HEADERS",
+				Message: err.Error(),
+			}, nil
+		}
+	}
+
+	var webhookBatch *internal.WebhookBatching
+	if body.WebhookBatch != nil && body.WebhookUri == nil && len(body.Webhooks) == 0 {
+		return internal.InfoJobArgs{}, &virest.Error{
+			Code:    "INVALID_WEBHOOK_BATCH",
+			Message: "webhookBatch requires webhookUri or webhooks",
+		}, nil
+	}
+	if body.WebhookBatch != nil && body.WebhookUri != nil {
+		var err error
+		webhookBatch, err = s.newWebhookBatching(internal.WebhookSecrets{URI: *body.WebhookUri, Token: body.WebhookToken, Headers: webhookHeaders}, *body.WebhookBatch)
+		if err != nil {
+			return internal.InfoJobArgs{}, &virest.Error{
+				Code:    "INVALID_WEBHOOK_BATCH",
+				Message: err.Error(),
+			}, nil
+		}
+	}
+
+	webhooks, invalid := s.webhookReceivers(ctx, body.Webhooks, body.WebhookBatch)
+	if invalid != nil {
+		return internal.InfoJobArgs{}, invalid, nil
+	}
+
+	var webhookEvents []string
+	if body.WebhookEvents != nil {
+		if body.WebhookUri == nil && len(body.Webhooks) == 0 {
+			return internal.InfoJobArgs{}, &virest.Error{
+				Code:    "INVALID_WEBHOOK_EVENTS",
+				Message: "webhookEvents requires webhookUri or webhooks",
+			}, nil
+		}
+		for _, event := range body.WebhookEvents {
+			switch event {
+			case virest.WebhookEventStarted, virest.WebhookEventCompleted, virest.WebhookEventFailed, virest.WebhookEventCancelled, virest.WebhookEventExpired:
+			default:
+				return internal.InfoJobArgs{}, &virest.Error{
+					Code:    "INVALID_WEBHOOK_EVENTS",
+					Message: fmt.Sprintf("unsupported webhook event %q", event),
+				}, nil
+			}
+			if !slices.Contains(webhookEvents, string(event)) {
+				webhookEvents = append(webhookEvents, string(event))
+			}
+		}
+		if len(webhookEvents) == 0 {
+			return internal.InfoJobArgs{}, &virest.Error{
+				Code:    "INVALID_WEBHOOK_EVENTS",
+				Message: "webhookEvents must name at least one event",
+			}, nil
+		}
+	}
+
+	jobArgs := internal.InfoJobArgs{
+		UUID:            uuid.UUID(body.Uuid),
+		ExternalID:      body.ExternalId,
+		Path:            body.VideoPath,
+		WebhookURI:      body.WebhookUri,
+		WebhookToken:    body.WebhookToken,
+		WebhookHeaders:  webhookHeaders,
+		Webhooks:        webhooks,
+		Labels:          labels,
+		Resources:       resources,
+		WebhookRetry:    webhookRetry,
+		WebhookBatch:    webhookBatch,
+		WebhookEvents:   webhookEvents,
+		Priority:        priority,
+		AnalyzeCrop:     body.AnalyzeCrop != nil && *body.AnalyzeCrop,
+		AnalyzeLoudness: body.AnalyzeLoudness != nil && *body.AnalyzeLoudness,
+		Verify:          body.Verify != nil && *body.Verify,
+		SampleWindows:   sampleWindows,
+		Checksum:        checksum,
+		Analyzers:       body.Analyzers,
+		IncludeRaw:      body.IncludeRaw != nil && *body.IncludeRaw,
+		QueueName:       queue,
+		TimeoutSeconds:  int(timeout.Seconds()),
+		Force:           body.Force != nil && *body.Force,
+	}
+	if err := jobArgs.SealWebhook(s.keyring); err != nil {
+		return internal.InfoJobArgs{}, nil, err
+	}
+	return jobArgs, nil, nil
+}
+
+// webhookReceivers validates the further webhook receivers of an info
+// request, batching the webhooks to each as batch requests.  Receivers that
+// fail validation are described by the returned Error.
+func (s *Server) webhookReceivers(ctx context.Context, receivers []virest.WebhookReceiver, batch *virest.WebhookBatchOptions) ([]internal.WebhookReceiver, *virest.Error) {
+	if len(receivers) > maxWebhookReceivers {
+		return nil, &virest.Error{
+			Code:    "INVALID_WEBHOOKS",
+			Message: fmt.Sprintf("at most %d webhooks may be given", maxWebhookReceivers),
+		}
+	}
+	var webhooks []internal.WebhookReceiver
+	for i, receiver := range receivers {
+		if err := s.cfg.WebhookPolicy.CheckString(ctx, receiver.Uri); err != nil {
+			return nil, &virest.Error{
+				Code:    "INVALID_WEBHOOK_URI",
+				Message: fmt.Sprintf("webhooks[%d]: %v", i, err),
+			}
+		}
+		if err := internal.CheckWebhookHeaders(receiver.Headers); err != nil {
+			return nil, &virest.Error{
+				Code:    "INVALID_WEBHOOK_HEADERS",
+				Message: fmt.Sprintf("webhooks[%d]: %v", i, err),
+			}
+		}
+		webhook := internal.WebhookReceiver{
+			URI:     receiver.Uri,
+			Token:   receiver.Token,
+			Headers: receiver.Headers,
+		}
+		if batch != nil {
+			var err error
+			webhook.Batch, err = s.newWebhookBatching(internal.WebhookSecrets{URI: webhook.URI, Token: webhook.Token, Headers: webhook.Headers}, *batch)
+			if err != nil {
+				return nil, &virest.Error{
+					Code:    "INVALID_WEBHOOK_BATCH",
+					Message: err.Error(),
+				}
+			}
+		}
+		webhooks = append(webhooks, webhook)
+	}
+	return webhooks, nil
+}
+
+// newWebhookRetryPolicy converts a REST webhook retry policy, rejecting fields
+// that are set but out of bounds.
+func newWebhookRetryPolicy(r virest.WebhookRetryPolicy) (internal.WebhookRetryPolicy, error) {
+	// Zero means unset internally, so Validate can't catch it
+	for _, value := range []*int{r.MaxAttempts, r.BackoffSeconds, r.TimeoutSeconds} {
+		if value != nil && *value == 0 {
+			return internal.WebhookRetryPolicy{}, fmt.Errorf("%w: values must be positive", internal.ErrInvalidWebhookRetryPolicy)
+		}
+	}
+
+	var policy internal.WebhookRetryPolicy
+	if r.MaxAttempts != nil {
+		policy.MaxAttempts = *r.MaxAttempts
+	}
+	if r.BackoffSeconds != nil {
+		policy.BackoffSeconds = *r.BackoffSeconds
+	}
+	if r.TimeoutSeconds != nil {
+		policy.TimeoutSeconds = *r.TimeoutSeconds
+	}
+	return policy, policy.Validate()
+}
+
+// newWebhookBatching converts REST webhook batch options for webhooks with
+// the given secrets, rejecting fields that are set but out of bounds.
+func (s *Server) newWebhookBatching(secrets internal.WebhookSecrets, r virest.WebhookBatchOptions) (*internal.WebhookBatching, error) {
+	// Zero means the default internally, so NewWebhookBatching can't catch it
+	var maxSize, maxWaitSeconds int
+	if r.MaxSize != nil {
+		if maxSize = *r.MaxSize; maxSize == 0 {
+			return nil, fmt.Errorf("%w: values must be positive", internal.ErrInvalidWebhookBatch)
+		}
+	}
+	if r.MaxWaitSeconds != nil {
+		if maxWaitSeconds = *r.MaxWaitSeconds; maxWaitSeconds == 0 {
+			return nil, fmt.Errorf("%w: values must be positive", internal.ErrInvalidWebhookBatch)
+		}
+	}
+	return internal.NewWebhookBatching(s.keyring, secrets, maxSize, maxWaitSeconds)
+}
+
 // GetInfoStatus handles GET /info/{uuid} requests.
 func (s *Server) GetInfoStatus(ctx context.Context, request virest.GetInfoStatusRequestObject) (virest.GetInfoStatusResponseObject, error) {
-	// Look up river job ID from UUID
-	var riverJobID int64
-	err := s.pool.QueryRow(ctx, "SELECT river_job_id FROM uuid_job_mapping WHERE uuid = $1", request.Uuid).Scan(&riverJobID)
-	if errors.Is(err, pgx.ErrNoRows) {
+	infoJob, err := s.findInfoJob(ctx, "uuid", request.Uuid)
+	if errors.Is(err, errJobNotFound) {
 		return virest.GetInfoStatus404JSONResponse{
 			Code:    "NOT_FOUND",
 			Message: fmt.Sprintf("Info job with UUID %s not found", request.Uuid),
@@ -120,32 +598,164 @@ func (s *Server) GetInfoStatus(ctx context.Context, request virest.GetInfoStatus
 	} else if err != nil {
 		return virest.GetInfoStatus500JSONResponse{
 			Code:    "INTERNAL_ERROR",
-			Message: fmt.Sprintf("failed to look up job mapping: %v", err),
+			Message: err.Error(),
 		}, nil
 	}
 
-	// Get job from River
-	job, err := s.riverClient.JobGet(ctx, riverJobID)
-	if err != nil {
+	if err := s.addQueuePosition(ctx, infoJob); err != nil {
 		return virest.GetInfoStatus500JSONResponse{
 			Code:    "INTERNAL_ERROR",
-			Message: fmt.Sprintf("failed to get river job: %v", err),
+			Message: err.Error(),
 		}, nil
 	}
-	if job == nil {
-		return virest.GetInfoStatus404JSONResponse{
+	return virest.GetInfoStatus200JSONResponse(*infoJob), nil
+}
+
+// GetInfoStatusByExternalId handles GET /info/by-external-id/{externalId} requests.
+func (s *Server) GetInfoStatusByExternalId(ctx context.Context, request virest.GetInfoStatusByExternalIdRequestObject) (virest.GetInfoStatusByExternalIdResponseObject, error) {
+	infoJob, err := s.findInfoJob(ctx, "external_id", request.ExternalId)
+	if errors.Is(err, errJobNotFound) {
+		return virest.GetInfoStatusByExternalId404JSONResponse{
 			Code:    "NOT_FOUND",
-			Message: fmt.Sprintf("Info job with UUID %s not found in queue", request.Uuid),
+			Message: fmt.Sprintf("Info job with external ID %q not found", request.ExternalId),
+		}, nil
+	} else if err != nil {
+		return virest.GetInfoStatusByExternalId500JSONResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: err.Error(),
+		}, nil
+	}
+
+	if err := s.addQueuePosition(ctx, infoJob); err != nil {
+		return virest.GetInfoStatusByExternalId500JSONResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: err.Error(),
 		}, nil
 	}
+	return virest.GetInfoStatusByExternalId200JSONResponse(*infoJob), nil
+}
+
+// findInfoJob builds the REST representation of the info job whose column,
+// uuid or external_id, matches value.  Jobs River has removed since they
+// finished are served from their stored result.  Lookups by UUID go through
+// the status cache; external IDs can move to newer jobs, so they don't.
+func (s *Server) findInfoJob(ctx context.Context, column string, value any) (*virest.InfoJob, error) {
+	id, byUUID := value.(uuid.UUID)
+	if byUUID {
+		if infoJob, ok := s.statusCache.get(id); ok {
+			return infoJob, nil
+		}
+	}
+	generation := s.statusCache.begin()
+
+	riverJobID, err := s.lookupRiverJobID(ctx, column, value)
+	if err == nil {
+		infoJob, err := s.getInfoJob(ctx, riverJobID)
+		if err == nil && byUUID {
+			s.statusCache.put(id, riverJobID, infoJob, generation)
+		}
+		if !errors.Is(err, errJobNotFound) {
+			return infoJob, err
+		}
+	} else if !errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("failed to look up job mapping: %w", err)
+	}
+	return s.getStoredInfoJob(ctx, column, value)
+}
+
+// getStoredInfoJob builds the REST representation of the most recent info
+// job in info_result whose column matches value.  A miss on the read replica
+// falls back to the primary.
+func (s *Server) getStoredInfoJob(ctx context.Context, column string, value any) (*virest.InfoJob, error) {
+	query := fmt.Sprintf(`
+		SELECT args, output, priority, created_at, finalized_at FROM info_result
+		WHERE %s = $1
+		ORDER BY finalized_at DESC
+		LIMIT 1`, pgx.Identifier{column}.Sanitize())
+
+	var (
+		job         rivertype.JobRow
+		output      []byte
+		finalizedAt time.Time
+	)
+	scan := func(pool JobStore) error {
+		return pool.QueryRow(ctx, query, value).Scan(&job.EncodedArgs, &output, &job.Priority, &job.CreatedAt, &finalizedAt)
+	}
+	err := scan(s.readPool)
+	if errors.Is(err, pgx.ErrNoRows) && s.hasReadReplica() {
+		err = scan(s.pool)
+	}
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, errJobNotFound
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to look up stored result: %w", err)
+	}
+
+	return newStoredInfoJob(&job, output, finalizedAt)
+}
+
+// newStoredInfoJob builds the REST representation of a job from its stored
+// result.  job holds the job's args, priority and creation time.
+func newStoredInfoJob(job *rivertype.JobRow, output []byte, finalizedAt time.Time) (*virest.InfoJob, error) {
+	// Only completed jobs have stored results
+	job.State = rivertype.JobStateCompleted
+	job.FinalizedAt = &finalizedAt
+	var err error
+	job.Metadata, err = json.Marshal(map[string]json.RawMessage{rivertype.MetadataKeyOutput: output})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal stored output: %w", err)
+	}
+	return newInfoJob(job)
+}
+
+// lookupRiverJobID finds the River job ID of the mapping row whose column
+// matches value.  A miss on the read replica falls back to the primary.
+func (s *Server) lookupRiverJobID(ctx context.Context, column string, value any) (int64, error) {
+	query := fmt.Sprintf("SELECT river_job_id FROM uuid_job_mapping WHERE %s = $1", pgx.Identifier{column}.Sanitize())
+
+	var riverJobID int64
+	err := s.readPool.QueryRow(ctx, query, value).Scan(&riverJobID)
+	if errors.Is(err, pgx.ErrNoRows) && s.hasReadReplica() {
+		err = s.pool.QueryRow(ctx, query, value).Scan(&riverJobID)
+	}
+	return riverJobID, err
+}
+
+// errJobNotFound is returned by getInfoJob when the River job no longer
+// exists, and by findInfoJob when there is no job or stored result.
+var errJobNotFound = errors.New("job not found")
+
+// getInfoJob builds the REST representation of the info job with the given River job ID.
+func (s *Server) getInfoJob(ctx context.Context, riverJobID int64) (*virest.InfoJob, error) {
+	// Get job from River, falling back to the primary if the replica lags
+	job, err := s.readRiverClient.JobGet(ctx, riverJobID)
+	if (errors.Is(err, rivertype.ErrNotFound) || (err == nil && job == nil)) && s.hasReadReplica() {
+		job, err = s.riverClient.JobGet(ctx, riverJobID)
+	}
+	if errors.Is(err, rivertype.ErrNotFound) || (err == nil && job == nil) {
+		return nil, errJobNotFound
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to get river job: %w", err)
+	}
+	infoJob, err := newInfoJob(job)
+	if err != nil {
+		return nil, err
+	}
 
+	annotations, err := s.loadAnnotations(ctx, []int64{riverJobID})
+	if err != nil {
+		return nil, err
+	}
+	infoJob.Annotations = annotations[riverJobID]
+	return infoJob, nil
+}
+
+// newInfoJob builds the REST representation of an info job from its River job.
+func newInfoJob(job *rivertype.JobRow) (*virest.InfoJob, error) {
 	// Parse job args for source/destination paths
 	var jobArgs internal.InfoJobArgs
 	if err := json.Unmarshal(job.EncodedArgs, &jobArgs); err != nil {
-		return virest.GetInfoStatus500JSONResponse{
-			Code:    "INTERNAL_ERROR",
-			Message: fmt.Sprintf("failed to unmarshal job args: %v", err),
-		}, nil
+		return nil, fmt.Errorf("failed to unmarshal job args: %w", err)
 	}
 
 	// Parse job output for progress/error if present
@@ -153,10 +763,7 @@ func (s *Server) GetInfoStatus(ctx context.Context, request virest.GetInfoStatus
 	jobOutput := job.Output()
 	if len(jobOutput) > 0 {
 		if err := json.Unmarshal(jobOutput, &jobStatus); err != nil {
-			return virest.GetInfoStatus500JSONResponse{
-				Code:    "INTERNAL_ERROR",
-				Message: fmt.Sprintf("failed to unmarshal job output: %v", err),
-			}, nil
+			return nil, fmt.Errorf("failed to unmarshal job output: %w", err)
 		}
 	}
 
@@ -168,7 +775,7 @@ func (s *Server) GetInfoStatus(ctx context.Context, request virest.GetInfoStatus
 	if jobStatus.Error != nil {
 		jobError = jobStatus.Error
 	} else if status == virest.Failed && len(job.Errors) > 0 {
-		lastError := job.Errors[len(job.Errors)-1].Error
+		lastError := internal.Redact(job.Errors[len(job.Errors)-1].Error)
 		jobError = &lastError
 	}
 
@@ -176,21 +783,45 @@ func (s *Server) GetInfoStatus(ctx context.Context, request virest.GetInfoStatus
 	if job.FinalizedAt != nil {
 		finalTime = *job.FinalizedAt
 	}
-	var result *virest.VideoInfo
+	var result *virest.MediaInfo
 	if jobStatus.Result != nil {
-		result = jobStatus.Result.RESTVideoInfo()
-	}
-	return virest.GetInfoStatus200JSONResponse{
-		Uuid:      request.Uuid,
-		Status:    status,
-		VideoPath: jobArgs.Path,
-		Result:    result,
-		Error:     jobError,
-		CreatedAt: job.CreatedAt.UTC(),
-		UpdatedAt: finalTime.UTC(),
+		result = jobStatus.Result.RESTMediaInfo()
+	}
+	var labels *virest.Labels
+	if len(jobArgs.Labels) > 0 {
+		labels = (*virest.Labels)(&jobArgs.Labels)
+	}
+	return &virest.InfoJob{
+		Uuid:         jobArgs.UUID,
+		ExternalId:   jobArgs.ExternalID,
+		Supersedes:   jobArgs.Supersedes,
+		Status:       status,
+		VideoPath:    jobArgs.Path,
+		Result:       result,
+		Error:        jobError,
+		ErrorCode:    jobStatus.ErrorCode,
+		Labels:       labels,
+		Resources:    jobArgs.RESTResources(),
+		Priority:     job.Priority,
+		Queue:        virest.Queue(jobArgs.Queue()),
+		Timings:      internal.RESTPhaseTimings(jobStatus.Timings),
+		SignedResult: jobStatus.Signed.RESTSignedPayload(),
+		CreatedAt:    job.CreatedAt.UTC(),
+		UpdatedAt:    finalTime.UTC(),
 	}, nil
 }
 
+// riverStatesForStatus returns the River job states that map to status.
+func riverStatesForStatus(status virest.InfoStatus) []rivertype.JobState {
+	var states []rivertype.JobState
+	for _, state := range rivertype.JobStates() {
+		if mapRiverStateToTranscodeStatus(state) == status {
+			states = append(states, state)
+		}
+	}
+	return states
+}
+
 // mapRiverStateToTranscodeStatus converts River job state to API TranscodeStatus.
 func mapRiverStateToTranscodeStatus(state rivertype.JobState) virest.InfoStatus {
 	switch state {
@@ -200,8 +831,10 @@ func mapRiverStateToTranscodeStatus(state rivertype.JobState) virest.InfoStatus
 		return virest.Running
 	case rivertype.JobStateCompleted:
 		return virest.Completed
-	case rivertype.JobStateDiscarded, rivertype.JobStateCancelled:
+	case rivertype.JobStateDiscarded:
 		return virest.Failed
+	case rivertype.JobStateCancelled:
+		return virest.Cancelled
 	default:
 		return virest.Pending
 	}
@@ -0,0 +1,522 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/krelinga/go-libs/deep"
+	"github.com/krelinga/go-libs/exam"
+	"github.com/krelinga/video-info/internal"
+	"github.com/krelinga/video-info/virest"
+	"github.com/riverqueue/river/rivertype"
+)
+
+func newTestServer(e exam.E, store *fakeStore, queue *fakeQueue, cfg *internal.ServerConfig) *Server {
+	e.Helper()
+	s, err := NewServer(store, queue, cfg)
+	if err != nil {
+		e.Fatal(err)
+	}
+	return s
+}
+
+// newCreateTx returns a transaction in which no job has been created yet.
+func newCreateTx() *fakeTx {
+	return &fakeTx{
+		exec: func(string, []any) error { return nil },
+		queryRow: func(string, []any) pgx.Row {
+			return fakeRow{err: pgx.ErrNoRows}
+		},
+	}
+}
+
+func TestCreateInfo(t *testing.T) {
+	e := exam.New(t)
+	env := deep.NewEnv()
+	cfg := &internal.ServerConfig{
+		JobTimeout:      10 * time.Minute,
+		MaxJobTimeout:   time.Hour,
+		AllowedRoots:    internal.AllowedRoots{"/videos/"},
+		URLInputSchemes: []string{"https"},
+	}
+
+	e.Run("Invalid requests", func(e exam.E) {
+		invalidQueue := "Not A Queue"
+		namedQueue := "bulk"
+		gpu := virest.Gpu
+		zero := 0
+		tooLong := 2 * 60 * 60
+		priority := internal.PriorityLowest + 1
+		sampleWindows := internal.MaxSampleWindows + 1
+		checksum := virest.ChecksumAlgorithm("md5")
+		analyzers := []string{"tags", "tags"}
+		webhookURI := "https://hooks.example.com/notify"
+		batchTooLarge := internal.MaxWebhookBatchSize + 1
+		tests := []struct {
+			loc      exam.Loc
+			name     string
+			body     virest.InfoRequest
+			wantCode string
+		}{
+			{
+				loc:      exam.Here(),
+				name:     "Path outside the allowed roots",
+				body:     virest.InfoRequest{VideoPath: "/etc/passwd"},
+				wantCode: "INVALID_VIDEO_PATH",
+			},
+			{
+				loc:      exam.Here(),
+				name:     "URL with a disallowed scheme",
+				body:     virest.InfoRequest{VideoPath: "http://media.internal/a.mkv"},
+				wantCode: "INVALID_VIDEO_PATH",
+			},
+			{
+				loc:      exam.Here(),
+				name:     "Invalid queue",
+				body:     virest.InfoRequest{Queue: &invalidQueue},
+				wantCode: "INVALID_QUEUE",
+			},
+			{
+				loc:      exam.Here(),
+				name:     "Queue with a GPU",
+				body:     virest.InfoRequest{Queue: &namedQueue, Resources: &gpu},
+				wantCode: "INVALID_QUEUE",
+			},
+			{
+				loc:      exam.Here(),
+				name:     "Non-positive timeout",
+				body:     virest.InfoRequest{TimeoutSeconds: &zero},
+				wantCode: "INVALID_TIMEOUT",
+			},
+			{
+				loc:      exam.Here(),
+				name:     "Timeout over the maximum",
+				body:     virest.InfoRequest{TimeoutSeconds: &tooLong},
+				wantCode: "INVALID_TIMEOUT",
+			},
+			{
+				loc:      exam.Here(),
+				name:     "Invalid priority",
+				body:     virest.InfoRequest{Priority: &priority},
+				wantCode: "INVALID_PRIORITY",
+			},
+			{
+				loc:      exam.Here(),
+				name:     "Invalid checksum",
+				body:     virest.InfoRequest{Checksum: &checksum},
+				wantCode: "INVALID_CHECKSUM",
+			},
+			{
+				loc:      exam.Here(),
+				name:     "Too many sample windows",
+				body:     virest.InfoRequest{SampleWindows: &sampleWindows},
+				wantCode: "INVALID_SAMPLE_WINDOWS",
+			},
+			{
+				loc:      exam.Here(),
+				name:     "Non-positive sample windows",
+				body:     virest.InfoRequest{SampleWindows: &zero},
+				wantCode: "INVALID_SAMPLE_WINDOWS",
+			},
+			{
+				loc:      exam.Here(),
+				name:     "Invalid analyzers",
+				body:     virest.InfoRequest{Analyzers: analyzers},
+				wantCode: "INVALID_ANALYZERS",
+			},
+			{
+				loc:      exam.Here(),
+				name:     "Webhook batch without a webhook",
+				body:     virest.InfoRequest{WebhookBatch: &virest.WebhookBatchOptions{}},
+				wantCode: "INVALID_WEBHOOK_BATCH",
+			},
+			{
+				loc:      exam.Here(),
+				name:     "Webhook batch too large",
+				body:     virest.InfoRequest{WebhookUri: &webhookURI, WebhookBatch: &virest.WebhookBatchOptions{MaxSize: &batchTooLarge}},
+				wantCode: "INVALID_WEBHOOK_BATCH",
+			},
+			{
+				loc:      exam.Here(),
+				name:     "Webhook batch without a wait",
+				body:     virest.InfoRequest{WebhookUri: &webhookURI, WebhookBatch: &virest.WebhookBatchOptions{MaxWaitSeconds: &zero}},
+				wantCode: "INVALID_WEBHOOK_BATCH",
+			},
+			{
+				loc:      exam.Here(),
+				name:     "Webhook headers without a webhook",
+				body:     virest.InfoRequest{WebhookHeaders: map[string]string{"Authorization": "Bearer x"}},
+				wantCode: "INVALID_WEBHOOK_HEADERS",
+			},
+			{
+				loc:      exam.Here(),
+				name:     "Reserved webhook header",
+				body:     virest.InfoRequest{WebhookUri: &webhookURI, WebhookHeaders: map[string]string{"Content-Type": "text/plain"}},
+				wantCode: "INVALID_WEBHOOK_HEADERS",
+			},
+			{
+				loc:      exam.Here(),
+				name:     "Too many webhooks",
+				body:     virest.InfoRequest{Webhooks: make([]virest.WebhookReceiver, 9)},
+				wantCode: "INVALID_WEBHOOKS",
+			},
+			{
+				loc:      exam.Here(),
+				name:     "Webhook receiver with a disallowed URI",
+				body:     virest.InfoRequest{Webhooks: []virest.WebhookReceiver{{Uri: "ftp://hooks.example.com/notify"}}},
+				wantCode: "INVALID_WEBHOOK_URI",
+			},
+			{
+				loc:      exam.Here(),
+				name:     "Webhook receiver with a reserved header",
+				body:     virest.InfoRequest{Webhooks: []virest.WebhookReceiver{{Uri: webhookURI, Headers: map[string]string{"Host": "example.com"}}}},
+				wantCode: "INVALID_WEBHOOK_HEADERS",
+			},
+			{
+				loc:      exam.Here(),
+				name:     "Webhook events without a webhook",
+				body:     virest.InfoRequest{WebhookEvents: []virest.WebhookEvent{virest.WebhookEventStarted}},
+				wantCode: "INVALID_WEBHOOK_EVENTS",
+			},
+			{
+				loc:      exam.Here(),
+				name:     "Unsupported webhook event",
+				body:     virest.InfoRequest{WebhookUri: &webhookURI, WebhookEvents: []virest.WebhookEvent{"queued"}},
+				wantCode: "INVALID_WEBHOOK_EVENTS",
+			},
+			{
+				loc:      exam.Here(),
+				name:     "No webhook events",
+				body:     virest.InfoRequest{WebhookUri: &webhookURI, WebhookEvents: []virest.WebhookEvent{}},
+				wantCode: "INVALID_WEBHOOK_EVENTS",
+			},
+		}
+		for _, tt := range tests {
+			e.Run(tt.name, func(e exam.E) {
+				e.Log("Running test at", tt.loc)
+				queue := &fakeQueue{}
+				s := newTestServer(e, &fakeStore{}, queue, cfg)
+				tt.body.Uuid = uuid.New()
+				if tt.body.VideoPath == "" {
+					tt.body.VideoPath = "/videos/a.mkv"
+				}
+				resp, err := s.CreateInfo(context.Background(), virest.CreateInfoRequestObject{Body: &tt.body})
+				exam.Nil(e, env, err)
+				got, ok := resp.(virest.CreateInfo400JSONResponse)
+				if !ok {
+					e.Fatalf("got %T, want 400", resp)
+				}
+				exam.Equal(e, env, tt.wantCode, got.Code)
+				exam.Equal(e, env, 0, len(queue.inserted))
+			})
+		}
+	})
+
+	e.Run("Timeout too long for a duration", func(e exam.E) {
+		// Without a maximum, the multiplication by time.Second would
+		// overflow into an arbitrary timeout
+		cfg := &internal.ServerConfig{AllowedRoots: internal.AllowedRoots{"/videos/"}}
+		s := newTestServer(e, &fakeStore{}, &fakeQueue{}, cfg)
+		for _, seconds := range []int{math.MaxInt64/int(time.Second) + 1, math.MaxInt} {
+			resp, err := s.CreateInfo(context.Background(), virest.CreateInfoRequestObject{Body: &virest.InfoRequest{
+				Uuid:           uuid.New(),
+				VideoPath:      "/videos/a.mkv",
+				TimeoutSeconds: &seconds,
+			}})
+			exam.Nil(e, env, err)
+			got, ok := resp.(virest.CreateInfo400JSONResponse)
+			if !ok {
+				e.Fatalf("got %T, want 400", resp)
+			}
+			exam.Equal(e, env, "INVALID_TIMEOUT", got.Code)
+			exam.Equal(e, env, fmt.Sprintf("timeoutSeconds must be at most %d", math.MaxInt64/int(time.Second)), got.Message)
+		}
+	})
+
+	e.Run("Missing body", func(e exam.E) {
+		s := newTestServer(e, &fakeStore{}, &fakeQueue{}, cfg)
+		resp, err := s.CreateInfo(context.Background(), virest.CreateInfoRequestObject{})
+		exam.Nil(e, env, err)
+		got, ok := resp.(virest.CreateInfo400JSONResponse)
+		if !ok {
+			e.Fatalf("got %T, want 400", resp)
+		}
+		exam.Equal(e, env, "INVALID_REQUEST", got.Code)
+	})
+
+	e.Run("Duplicate UUID", func(e exam.E) {
+		tx := newCreateTx()
+		tx.queryRow = func(sql string, _ []any) pgx.Row {
+			if strings.Contains(sql, "WHERE uuid") {
+				return fakeRow{values: []any{int64(3)}}
+			}
+			return fakeRow{err: pgx.ErrNoRows}
+		}
+		store := &fakeStore{begin: func() (pgx.Tx, error) { return tx, nil }}
+		queue := &fakeQueue{}
+		s := newTestServer(e, store, queue, cfg)
+		body := virest.InfoRequest{Uuid: uuid.New(), VideoPath: "/videos/a.mkv"}
+		resp, err := s.CreateInfo(context.Background(), virest.CreateInfoRequestObject{Body: &body})
+		exam.Nil(e, env, err)
+		got, ok := resp.(virest.CreateInfo409JSONResponse)
+		if !ok {
+			e.Fatalf("got %T, want 409", resp)
+		}
+		exam.Equal(e, env, "DUPLICATE_UUID", got.Code)
+		exam.Equal(e, env, 0, len(queue.inserted))
+		exam.Equal(e, env, false, tx.committed)
+	})
+
+	e.Run("Created", func(e exam.E) {
+		tx := newCreateTx()
+		store := &fakeStore{begin: func() (pgx.Tx, error) { return tx, nil }}
+		createdAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+		queue := &fakeQueue{createdAt: createdAt}
+		s := newTestServer(e, store, queue, cfg)
+		s.clock = internal.NewFakeClock(createdAt.Add(time.Second))
+		namedQueue := "bulk"
+		body := virest.InfoRequest{Uuid: uuid.New(), VideoPath: "https://media.internal/a.mkv", Queue: &namedQueue}
+		resp, err := s.CreateInfo(context.Background(), virest.CreateInfoRequestObject{Body: &body})
+		exam.Nil(e, env, err)
+		got, ok := resp.(virest.CreateInfo201JSONResponse)
+		if !ok {
+			e.Fatalf("got %T, want 201", resp)
+		}
+		exam.Equal(e, env, virest.Pending, got.Body.Status)
+		exam.Equal(e, env, true, got.Body.CreatedAt.Equal(createdAt))
+		exam.Equal(e, env, virest.Queue("bulk"), got.Body.Queue)
+		exam.Equal(e, env, 0, got.Headers.XQueueDepth)
+		exam.Equal(e, env, true, tx.committed)
+		if len(queue.inserted) != 1 {
+			e.Fatalf("inserted %d jobs, want 1", len(queue.inserted))
+		}
+		args := queue.inserted[0].(internal.InfoJobArgs)
+		exam.Equal(e, env, body.Uuid.String(), args.UUID.String())
+		exam.Equal(e, env, "bulk", args.Queue())
+		exam.Equal(e, env, 600, args.TimeoutSeconds)
+		exam.Equal(e, env, false, args.Force)
+	})
+
+	e.Run("Several webhooks", func(e exam.E) {
+		tx := newCreateTx()
+		store := &fakeStore{begin: func() (pgx.Tx, error) { return tx, nil }}
+		queue := &fakeQueue{}
+		s := newTestServer(e, store, queue, cfg)
+		webhookURI := "https://hooks.example.com/notify"
+		body := virest.InfoRequest{
+			Uuid:         uuid.New(),
+			VideoPath:    "/videos/a.mkv",
+			WebhookUri:   &webhookURI,
+			WebhookBatch: &virest.WebhookBatchOptions{},
+			Webhooks: []virest.WebhookReceiver{
+				{Uri: "https://catalog.example.com/notify", Headers: map[string]string{"Authorization": "Bearer secret"}},
+				{Uri: "https://notify.example.com/notify", Token: []byte("token")},
+			},
+		}
+		resp, err := s.CreateInfo(context.Background(), virest.CreateInfoRequestObject{Body: &body})
+		exam.Nil(e, env, err)
+		if _, ok := resp.(virest.CreateInfo201JSONResponse); !ok {
+			e.Fatalf("got %T, want 201", resp)
+		}
+		args := queue.inserted[0].(internal.InfoJobArgs)
+		webhooks := args.WebhookArgs("", nil)
+		if len(webhooks) != 3 {
+			e.Fatalf("got %d webhooks, want 3", len(webhooks))
+		}
+		for i, want := range []string{webhookURI, body.Webhooks[0].Uri, body.Webhooks[1].Uri} {
+			exam.Equal(e, env, want, webhooks[i].URI)
+		}
+		exam.Equal(e, env, "Bearer secret", webhooks[1].Headers["Authorization"])
+		exam.Equal(e, env, "token", string(webhooks[2].Token))
+		exam.Equal(e, env, true, webhooks[0].Batch.Key != webhooks[1].Batch.Key)
+		exam.Equal(e, env, true, webhooks[1].Batch.Key != webhooks[2].Batch.Key)
+	})
+
+	e.Run("Queue depth limited", func(e exam.E) {
+		limited := *cfg
+		limited.MaxQueueDepth = 10
+		var depth, counts int
+		store := &fakeStore{
+			begin: func() (pgx.Tx, error) { return newCreateTx(), nil },
+			queryRow: func(sql string, _ []any) pgx.Row {
+				counts++
+				return fakeRow{values: []any{depth}}
+			},
+		}
+		s := newTestServer(e, store, &fakeQueue{}, &limited)
+		clock := internal.NewFakeClock(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC))
+		s.clock = clock
+		create := func() virest.CreateInfoResponseObject {
+			body := virest.InfoRequest{Uuid: uuid.New(), VideoPath: "/videos/a.mkv"}
+			resp, err := s.CreateInfo(context.Background(), virest.CreateInfoRequestObject{Body: &body})
+			exam.Nil(e, env, err)
+			return resp
+		}
+
+		depth = 9
+		created, ok := create().(virest.CreateInfo201JSONResponse)
+		if !ok {
+			e.Fatalf("got %T, want 201", created)
+		}
+		exam.Equal(e, env, 9, created.Headers.XQueueDepth)
+
+		// The depth is counted at most once a second
+		depth = 10
+		if resp, ok := create().(virest.CreateInfo201JSONResponse); !ok {
+			e.Fatalf("got %T, want 201", resp)
+		}
+		exam.Equal(e, env, 1, counts)
+
+		clock.Advance(time.Second)
+		full, ok := create().(virest.CreateInfo429JSONResponse)
+		if !ok {
+			e.Fatalf("got %T, want 429", full)
+		}
+		exam.Equal(e, env, "QUEUE_FULL", full.Body.Code)
+		exam.Equal(e, env, 30, full.Headers.RetryAfter)
+		exam.Equal(e, env, 10, full.Headers.XQueueDepth)
+		exam.Equal(e, env, 2, counts)
+	})
+}
+
+func TestGetInfoStatus(t *testing.T) {
+	e := exam.New(t)
+	env := deep.NewEnv()
+	cfg := &internal.ServerConfig{}
+	id := uuid.New()
+	encodedArgs, err := json.Marshal(internal.InfoJobArgs{UUID: id, Path: "/videos/a.mkv"})
+	if err != nil {
+		e.Fatal(err)
+	}
+	noAnnotations := func(string, []any) (pgx.Rows, error) { return &fakeRows{}, nil }
+
+	e.Run("Queued job", func(e exam.E) {
+		store := &fakeStore{
+			queryRow: func(sql string, _ []any) pgx.Row {
+				if strings.Contains(sql, "uuid_job_mapping") {
+					return fakeRow{values: []any{int64(42)}}
+				}
+				return fakeRow{err: pgx.ErrNoRows}
+			},
+			query: noAnnotations,
+		}
+		queue := &fakeQueue{jobs: map[int64]*rivertype.JobRow{
+			42: {ID: 42, State: rivertype.JobStateRunning, EncodedArgs: encodedArgs},
+		}}
+		s := newTestServer(e, store, queue, cfg)
+		resp, err := s.GetInfoStatus(context.Background(), virest.GetInfoStatusRequestObject{Uuid: id})
+		exam.Nil(e, env, err)
+		got, ok := resp.(virest.GetInfoStatus200JSONResponse)
+		if !ok {
+			e.Fatalf("got %T, want 200", resp)
+		}
+		exam.Equal(e, env, virest.Running, got.Status)
+		exam.Equal(e, env, "/videos/a.mkv", got.VideoPath)
+	})
+
+	e.Run("Pending job", func(e exam.E) {
+		now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+		store := &fakeStore{
+			queryRow: func(sql string, _ []any) pgx.Row {
+				if strings.Contains(sql, "LATERAL") {
+					// 30 jobs ahead, with 90 finishing in 15 minutes
+					return fakeRow{values: []any{now.Add(-time.Hour), 30, 90, 42.0}}
+				}
+				if strings.Contains(sql, "uuid_job_mapping") {
+					return fakeRow{values: []any{int64(42)}}
+				}
+				return fakeRow{err: pgx.ErrNoRows}
+			},
+			query: noAnnotations,
+		}
+		queue := &fakeQueue{jobs: map[int64]*rivertype.JobRow{
+			42: {ID: 42, State: rivertype.JobStateAvailable, EncodedArgs: encodedArgs},
+		}}
+		s := newTestServer(e, store, queue, cfg)
+		s.clock = internal.NewFakeClock(now)
+		resp, err := s.GetInfoStatus(context.Background(), virest.GetInfoStatusRequestObject{Uuid: id})
+		exam.Nil(e, env, err)
+		got, ok := resp.(virest.GetInfoStatus200JSONResponse)
+		if !ok {
+			e.Fatalf("got %T, want 200", resp)
+		}
+		exam.Equal(e, env, virest.Pending, got.Status)
+		exam.Equal(e, env, virest.Queue("default"), got.Queue)
+		if got.QueuePosition == nil {
+			e.Fatalf("got no queue position")
+		}
+		exam.Equal(e, env, 31, *got.QueuePosition)
+		if got.EstimatedStartAt == nil || got.EstimatedCompletionAt == nil {
+			e.Fatalf("got no estimates")
+		}
+		exam.Equal(e, env, true, got.EstimatedStartAt.Equal(now.Add(5*time.Minute)))
+		exam.Equal(e, env, true, got.EstimatedCompletionAt.Equal(now.Add(5*time.Minute+42*time.Second)))
+	})
+
+	e.Run("Stored result", func(e exam.E) {
+		finalizedAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+		store := &fakeStore{
+			queryRow: func(sql string, _ []any) pgx.Row {
+				if strings.Contains(sql, "info_result") {
+					return fakeRow{values: []any{encodedArgs, []byte(`{}`), 1, finalizedAt.Add(-time.Minute), finalizedAt}}
+				}
+				return fakeRow{err: pgx.ErrNoRows}
+			},
+		}
+		s := newTestServer(e, store, &fakeQueue{}, cfg)
+		resp, err := s.GetInfoStatus(context.Background(), virest.GetInfoStatusRequestObject{Uuid: id})
+		exam.Nil(e, env, err)
+		got, ok := resp.(virest.GetInfoStatus200JSONResponse)
+		if !ok {
+			e.Fatalf("got %T, want 200", resp)
+		}
+		exam.Equal(e, env, virest.Completed, got.Status)
+		exam.Equal(e, env, true, got.UpdatedAt.Equal(finalizedAt))
+	})
+
+	e.Run("Not found", func(e exam.E) {
+		store := &fakeStore{
+			queryRow: func(string, []any) pgx.Row { return fakeRow{err: pgx.ErrNoRows} },
+		}
+		s := newTestServer(e, store, &fakeQueue{}, cfg)
+		resp, err := s.GetInfoStatus(context.Background(), virest.GetInfoStatusRequestObject{Uuid: id})
+		exam.Nil(e, env, err)
+		if _, ok := resp.(virest.GetInfoStatus404JSONResponse); !ok {
+			e.Fatalf("got %T, want 404", resp)
+		}
+	})
+}
+
+func TestGetReadiness(t *testing.T) {
+	e := exam.New(t)
+	env := deep.NewEnv()
+	cfg := &internal.ServerConfig{}
+
+	e.Run("Ready", func(e exam.E) {
+		s := newTestServer(e, &fakeStore{}, &fakeQueue{}, cfg)
+		resp, err := s.GetReadiness(context.Background(), virest.GetReadinessRequestObject{})
+		exam.Nil(e, env, err)
+		if _, ok := resp.(virest.GetReadiness200JSONResponse); !ok {
+			e.Fatalf("got %T, want 200", resp)
+		}
+	})
+
+	e.Run("Database down", func(e exam.E) {
+		s := newTestServer(e, &fakeStore{ping: errors.New("connection refused")}, &fakeQueue{}, cfg)
+		resp, err := s.GetReadiness(context.Background(), virest.GetReadinessRequestObject{})
+		exam.Nil(e, env, err)
+		got, ok := resp.(virest.GetReadiness503JSONResponse)
+		if !ok {
+			e.Fatalf("got %T, want 503", resp)
+		}
+		exam.Equal(e, env, "NOT_READY", got.Code)
+	})
+}
@@ -0,0 +1,23 @@
+package main
+
+import (
+	"context"
+
+	"github.com/krelinga/video-info/virest"
+)
+
+// GetSigningKey handles GET /.well-known/video-info-signing-key requests.
+func (s *Server) GetSigningKey(ctx context.Context, request virest.GetSigningKeyRequestObject) (virest.GetSigningKeyResponseObject, error) {
+	if s.signer == nil {
+		return virest.GetSigningKey404JSONResponse{
+			Code:    "NOT_FOUND",
+			Message: "Result signing is not enabled",
+		}, nil
+	}
+
+	return virest.GetSigningKey200JSONResponse{
+		Algorithm: "Ed25519",
+		KeyId:     s.signer.KeyID(),
+		PublicKey: s.signer.PublicKey(),
+	}, nil
+}
@@ -0,0 +1,229 @@
+package main
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/krelinga/video-info/internal"
+	"github.com/krelinga/video-info/virest"
+	"github.com/riverqueue/river"
+	"github.com/riverqueue/river/rivertype"
+)
+
+// infoSortKey is an SQL expression over river_job that info jobs may be
+// sorted by, and the type its values are compared as.
+type infoSortKey struct {
+	expr string
+	typ  string
+}
+
+// infoSortKeys are the keys of the sort values of GET /info.  Durations are
+// measured up to @now, and are zero for jobs waiting to run.
+var infoSortKeys = map[virest.ListInfoParamsSort]infoSortKey{
+	virest.CreatedAt: {expr: "created_at", typ: "timestamptz"},
+	virest.UpdatedAt: {expr: "COALESCE(finalized_at, created_at)", typ: "timestamptz"},
+	virest.Duration: {
+		expr: `extract(epoch FROM CASE
+			WHEN state = 'running' THEN @now::timestamptz - attempted_at
+			WHEN finalized_at IS NOT NULL THEN finalized_at - COALESCE(attempted_at, finalized_at)
+			ELSE interval '0'
+		END)`,
+		typ: "numeric",
+	},
+	virest.Path: {expr: "args->>'path'", typ: "text"},
+}
+
+// sortedPageToken continues a sorted listing of info jobs after the job
+// with the given River ID, whose sort value is AfterValue.  Durations are
+// measured up to Now on every page, so running jobs don't move between
+// pages.
+type sortedPageToken struct {
+	Sort       virest.ListInfoParamsSort  `json:"sort"`
+	Order      virest.ListInfoParamsOrder `json:"order"`
+	AfterValue string                     `json:"after_value"`
+	AfterID    int64                      `json:"after_id"`
+	Now        time.Time                  `json:"now"`
+}
+
+// listSortedInfo handles GET /info requests that set sort, returning the
+// jobs matching the filters in params in the requested order.  labels and
+// pathSearch are the filters returned by labelFilter and pathSearchPattern.
+func (s *Server) listSortedInfo(ctx context.Context, params virest.ListInfoParams, labels, pathSearch *string, limit int) (virest.ListInfoResponseObject, error) {
+	key, ok := infoSortKeys[*params.Sort]
+	if !ok {
+		return virest.ListInfo400JSONResponse{
+			Code:    "INVALID_SORT",
+			Message: fmt.Sprintf("unsupported sort %q", *params.Sort),
+		}, nil
+	}
+	order := virest.Desc
+	if *params.Sort == virest.Path {
+		order = virest.Asc
+	}
+	if params.Order != nil {
+		order = *params.Order
+		if order != virest.Asc && order != virest.Desc {
+			return virest.ListInfo400JSONResponse{
+				Code:    "INVALID_SORT",
+				Message: fmt.Sprintf("unsupported order %q", order),
+			}, nil
+		}
+	}
+
+	var states []string
+	if params.Status != nil {
+		for _, state := range riverStatesForStatus(*params.Status) {
+			states = append(states, string(state))
+		}
+		if len(states) == 0 {
+			return virest.ListInfo400JSONResponse{
+				Code:    "INVALID_STATUS",
+				Message: fmt.Sprintf("unsupported status %q", *params.Status),
+			}, nil
+		}
+	} else {
+		for _, state := range rivertype.JobStates() {
+			states = append(states, string(state))
+		}
+	}
+
+	token := sortedPageToken{Sort: *params.Sort, Order: order, Now: s.clock.Now()}
+	var (
+		afterID    *int64
+		afterValue *string
+	)
+	if params.PageToken != nil {
+		decoded, err := base64.RawURLEncoding.DecodeString(*params.PageToken)
+		if err == nil {
+			err = json.Unmarshal(decoded, &token)
+		}
+		if err != nil || token.AfterID == 0 || token.Sort != *params.Sort || token.Order != order {
+			return virest.ListInfo400JSONResponse{
+				Code:    "INVALID_PAGE_TOKEN",
+				Message: "pageToken is not a token returned by this endpoint with the same sort and order",
+			}, nil
+		}
+		afterID = &token.AfterID
+		afterValue = &token.AfterValue
+	}
+
+	comparison := "<"
+	if order == virest.Asc {
+		comparison = ">"
+	}
+	query := fmt.Sprintf(`
+		SELECT id, (%[1]s)::text FROM river_job
+		WHERE kind = @kind AND state::text = ANY(@states)
+			AND EXISTS (SELECT 1 FROM uuid_job_mapping WHERE uuid_job_mapping.river_job_id = river_job.id)
+			AND (@video_path::text IS NULL OR args->>'path' = @video_path)
+			AND (@path_prefix::text IS NULL OR starts_with(args->>'path', @path_prefix))
+			AND (@path_search::text IS NULL OR args->>'path' ILIKE @path_search)
+			AND (@created_after::timestamptz IS NULL OR created_at >= @created_after)
+			AND (@created_before::timestamptz IS NULL OR created_at < @created_before)
+			AND (@labels::jsonb IS NULL OR args->'labels' @> @labels)
+			AND (@acknowledged::boolean IS NULL OR EXISTS (SELECT 1 FROM uuid_job_mapping WHERE uuid_job_mapping.river_job_id = river_job.id AND acknowledged = @acknowledged))
+			AND (@after_id::bigint IS NULL OR ((%[1]s)::%[2]s, id) %[3]s (@after_value::text::%[2]s, @after_id))
+		ORDER BY (%[1]s)::%[2]s %[4]s, id %[4]s
+		LIMIT @limit`,
+		key.expr, key.typ, comparison, order)
+	rows, err := s.readPool.Query(ctx, query, pgx.NamedArgs{
+		"kind":           internal.InfoJobArgs{}.Kind(),
+		"states":         states,
+		"video_path":     params.VideoPath,
+		"path_prefix":    params.PathPrefix,
+		"path_search":    pathSearch,
+		"created_after":  params.CreatedAfter,
+		"created_before": params.CreatedBefore,
+		"labels":         labels,
+		"acknowledged":   params.Acknowledged,
+		"after_id":       afterID,
+		"after_value":    afterValue,
+		"now":            token.Now,
+		"limit":          limit,
+	})
+	if err != nil {
+		return virest.ListInfo500JSONResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: fmt.Sprintf("failed to list info jobs: %v", err),
+		}, nil
+	}
+	type sortedJob struct {
+		id    int64
+		value string
+	}
+	sorted, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (sortedJob, error) {
+		var j sortedJob
+		err := row.Scan(&j.id, &j.value)
+		return j, err
+	})
+	if err != nil {
+		return virest.ListInfo500JSONResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: fmt.Sprintf("failed to list info jobs: %v", err),
+		}, nil
+	}
+
+	riverJobIDs := make([]int64, 0, len(sorted))
+	for _, j := range sorted {
+		riverJobIDs = append(riverJobIDs, j.id)
+	}
+	jobs := make(map[int64]*rivertype.JobRow, len(sorted))
+	if len(riverJobIDs) > 0 {
+		result, err := s.readRiverClient.JobList(ctx, river.NewJobListParams().IDs(riverJobIDs...).First(len(riverJobIDs)))
+		if err != nil {
+			return virest.ListInfo500JSONResponse{
+				Code:    "INTERNAL_ERROR",
+				Message: fmt.Sprintf("failed to list river jobs: %v", err),
+			}, nil
+		}
+		for _, job := range result.Jobs {
+			jobs[job.ID] = job
+		}
+	}
+	annotations, err := s.loadAnnotations(ctx, riverJobIDs)
+	if err != nil {
+		return virest.ListInfo500JSONResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: err.Error(),
+		}, nil
+	}
+
+	items := make([]virest.InfoJob, 0, len(sorted))
+	for _, j := range sorted {
+		job, ok := jobs[j.id]
+		if !ok {
+			// Cleaned up by River since it was listed
+			continue
+		}
+		infoJob, err := newInfoJob(job)
+		if err != nil {
+			return virest.ListInfo500JSONResponse{
+				Code:    "INTERNAL_ERROR",
+				Message: err.Error(),
+			}, nil
+		}
+		infoJob.Annotations = annotations[j.id]
+		items = append(items, *infoJob)
+	}
+
+	response := virest.ListInfo200JSONResponse{Items: items}
+	if len(sorted) == limit {
+		last := sorted[len(sorted)-1]
+		token.AfterValue = last.value
+		token.AfterID = last.id
+		encoded, err := json.Marshal(token)
+		if err != nil {
+			return virest.ListInfo500JSONResponse{
+				Code:    "INTERNAL_ERROR",
+				Message: fmt.Sprintf("failed to encode page token: %v", err),
+			}, nil
+		}
+		nextPageToken := base64.RawURLEncoding.EncodeToString(encoded)
+		response.NextPageToken = &nextPageToken
+	}
+	return response, nil
+}
@@ -0,0 +1,196 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/krelinga/go-libs/deep"
+	"github.com/krelinga/go-libs/exam"
+	"github.com/krelinga/video-info/internal"
+	"github.com/krelinga/video-info/virest"
+	"github.com/riverqueue/river/rivertype"
+)
+
+func TestListSortedInfo(t *testing.T) {
+	e := exam.New(t)
+	env := deep.NewEnv()
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	newJob := func(e exam.E, id int64, path string) (*rivertype.JobRow, uuid.UUID) {
+		e.Helper()
+		jobUUID := uuid.New()
+		encodedArgs, err := json.Marshal(internal.InfoJobArgs{UUID: jobUUID, Path: path})
+		exam.Nil(e, env, err)
+		return &rivertype.JobRow{ID: id, Kind: internal.InfoJobArgs{}.Kind(), State: rivertype.JobStateRunning, EncodedArgs: encodedArgs, CreatedAt: now}, jobUUID
+	}
+
+	e.Run("Longest running first", func(e exam.E) {
+		first, firstUUID := newJob(e, 7, "/videos/a.mkv")
+		second, secondUUID := newJob(e, 3, "/videos/b.mkv")
+		var (
+			gotSQL  string
+			gotArgs pgx.NamedArgs
+		)
+		store := &fakeStore{
+			query: func(sql string, args []any) (pgx.Rows, error) {
+				if strings.Contains(sql, "FROM river_job") {
+					gotSQL = sql
+					gotArgs = args[0].(pgx.NamedArgs)
+					return &fakeRows{rows: [][]any{{int64(7), "120.5"}, {int64(3), "60"}}}, nil
+				}
+				return &fakeRows{}, nil
+			},
+		}
+		// River returns jobs in its own order
+		queue := &fakeQueue{listed: []*rivertype.JobRow{second, first}}
+		s := newTestServer(e, store, queue, &internal.ServerConfig{})
+		s.clock = internal.NewFakeClock(now)
+		sort := virest.Duration
+		status := virest.Running
+		limit := 2
+		params := virest.ListInfoParams{Sort: &sort, Status: &status, Limit: &limit}
+		resp, err := s.ListInfo(context.Background(), virest.ListInfoRequestObject{Params: params})
+		exam.Nil(e, env, err)
+		got, ok := resp.(virest.ListInfo200JSONResponse)
+		if !ok {
+			e.Fatalf("got %T, want 200", resp)
+		}
+		if len(got.Items) != 2 {
+			e.Fatalf("got %d jobs, want 2", len(got.Items))
+		}
+		exam.Equal(e, env, firstUUID.String(), got.Items[0].Uuid.String())
+		exam.Equal(e, env, secondUUID.String(), got.Items[1].Uuid.String())
+		exam.Equal(e, env, true, strings.Contains(gotSQL, "id desc"))
+		exam.Equal(e, env, any([]string{"running"}), gotArgs["states"])
+		exam.Equal(e, env, true, gotArgs["now"].(time.Time).Equal(now))
+		exam.Equal(e, env, true, gotArgs["after_id"].(*int64) == nil)
+
+		// The next page continues after the last job listed, measuring
+		// durations up to the same time
+		if got.NextPageToken == nil {
+			e.Fatal("got no page token")
+		}
+		s.clock = internal.NewFakeClock(now.Add(time.Minute))
+		params.PageToken = got.NextPageToken
+		_, err = s.ListInfo(context.Background(), virest.ListInfoRequestObject{Params: params})
+		exam.Nil(e, env, err)
+		exam.Equal(e, env, int64(3), *gotArgs["after_id"].(*int64))
+		exam.Equal(e, env, "60", *gotArgs["after_value"].(*string))
+		exam.Equal(e, env, true, gotArgs["now"].(time.Time).Equal(now))
+	})
+
+	e.Run("Path ascending by default", func(e exam.E) {
+		var gotSQL string
+		store := &fakeStore{
+			query: func(sql string, args []any) (pgx.Rows, error) {
+				if strings.Contains(sql, "FROM river_job") {
+					gotSQL = sql
+				}
+				return &fakeRows{}, nil
+			},
+		}
+		s := newTestServer(e, store, &fakeQueue{}, &internal.ServerConfig{})
+		sort := virest.Path
+		resp, err := s.ListInfo(context.Background(), virest.ListInfoRequestObject{Params: virest.ListInfoParams{Sort: &sort}})
+		exam.Nil(e, env, err)
+		got, ok := resp.(virest.ListInfo200JSONResponse)
+		if !ok {
+			e.Fatalf("got %T, want 200", resp)
+		}
+		exam.Equal(e, env, 0, len(got.Items))
+		exam.Equal(e, env, true, got.NextPageToken == nil)
+		exam.Equal(e, env, true, strings.Contains(gotSQL, "id asc"))
+	})
+
+	e.Run("Invalid requests", func(e exam.E) {
+		latest := true
+		createdAt := virest.CreatedAt
+		updatedAt := virest.UpdatedAt
+		badSort := virest.ListInfoParamsSort("size")
+		badOrder := virest.ListInfoParamsOrder("up")
+		asc := virest.Asc
+		token := "not-a-token"
+		tests := []struct {
+			loc      exam.Loc
+			name     string
+			params   virest.ListInfoParams
+			wantCode string
+		}{
+			{
+				loc:      exam.Here(),
+				name:     "Unsupported sort",
+				params:   virest.ListInfoParams{Sort: &badSort},
+				wantCode: "INVALID_SORT",
+			},
+			{
+				loc:      exam.Here(),
+				name:     "Unsupported order",
+				params:   virest.ListInfoParams{Sort: &createdAt, Order: &badOrder},
+				wantCode: "INVALID_SORT",
+			},
+			{
+				loc:      exam.Here(),
+				name:     "Order without sort",
+				params:   virest.ListInfoParams{Order: &asc},
+				wantCode: "INVALID_SORT",
+			},
+			{
+				loc:      exam.Here(),
+				name:     "Sort with latest",
+				params:   virest.ListInfoParams{Sort: &createdAt, Latest: &latest},
+				wantCode: "INVALID_SORT",
+			},
+			{
+				loc:      exam.Here(),
+				name:     "Invalid page token",
+				params:   virest.ListInfoParams{Sort: &createdAt, PageToken: &token},
+				wantCode: "INVALID_PAGE_TOKEN",
+			},
+		}
+		for _, tt := range tests {
+			e.Run(tt.name, func(e exam.E) {
+				e.Log("Running test at", tt.loc)
+				s := newTestServer(e, &fakeStore{}, &fakeQueue{}, &internal.ServerConfig{})
+				resp, err := s.ListInfo(context.Background(), virest.ListInfoRequestObject{Params: tt.params})
+				exam.Nil(e, env, err)
+				got, ok := resp.(virest.ListInfo400JSONResponse)
+				if !ok {
+					e.Fatalf("got %T, want 400", resp)
+				}
+				exam.Equal(e, env, tt.wantCode, got.Code)
+			})
+		}
+
+		e.Run("Page token for another sort", func(e exam.E) {
+			store := &fakeStore{
+				query: func(sql string, _ []any) (pgx.Rows, error) {
+					if strings.Contains(sql, "FROM river_job") {
+						return &fakeRows{rows: [][]any{{int64(1), "2024-01-02 03:04:05+00"}}}, nil
+					}
+					return &fakeRows{}, nil
+				},
+			}
+			s := newTestServer(e, store, &fakeQueue{}, &internal.ServerConfig{})
+			limit := 1
+			resp, err := s.ListInfo(context.Background(), virest.ListInfoRequestObject{Params: virest.ListInfoParams{Sort: &createdAt, Limit: &limit}})
+			exam.Nil(e, env, err)
+			got, ok := resp.(virest.ListInfo200JSONResponse)
+			if !ok || got.NextPageToken == nil {
+				e.Fatalf("got %T without a page token, want 200 with one", resp)
+			}
+			params := virest.ListInfoParams{Sort: &updatedAt, PageToken: got.NextPageToken}
+			resp, err = s.ListInfo(context.Background(), virest.ListInfoRequestObject{Params: params})
+			exam.Nil(e, env, err)
+			invalid, ok := resp.(virest.ListInfo400JSONResponse)
+			if !ok {
+				e.Fatalf("got %T, want 400", resp)
+			}
+			exam.Equal(e, env, "INVALID_PAGE_TOKEN", invalid.Code)
+		})
+	})
+}
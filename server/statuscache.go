@@ -0,0 +1,193 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/krelinga/video-info/virest"
+)
+
+// infoJobChangedChannel is the notification channel the info_job_changed and
+// info_job_annotated triggers publish River job IDs on whenever an info job
+// or its annotations change.
+const infoJobChangedChannel = "info_job_changed"
+
+// jobChangesRetryDelay is how long listenForJobChanges waits before
+// listening again after losing its connection.
+const jobChangesRetryDelay = 5 * time.Second
+
+// statusCache keeps the statuses of recently read info jobs in memory, so
+// repeated reads don't query the database.  Entries are dropped when the
+// database reports a change to their job.  Notifications can be missed while
+// the cache isn't listening, so it is empty and disabled until it is.
+type statusCache struct {
+	size int
+
+	mu        sync.Mutex
+	listening bool
+	// generation changes on every invalidation, so reads that raced with
+	// one aren't cached
+	generation uint64
+	jobs       map[int64]*virest.InfoJob
+	uuids      map[uuid.UUID]int64
+}
+
+// newStatusCache returns a cache holding up to size statuses.
+func newStatusCache(size int) *statusCache {
+	return &statusCache{
+		size:  size,
+		jobs:  make(map[int64]*virest.InfoJob),
+		uuids: make(map[uuid.UUID]int64),
+	}
+}
+
+// get returns a copy of the cached status of the job with the given UUID.
+func (c *statusCache) get(id uuid.UUID) (*virest.InfoJob, bool) {
+	if c == nil {
+		return nil, false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	job, ok := c.jobs[c.uuids[id]]
+	if !ok {
+		return nil, false
+	}
+	infoJob := *job
+	return &infoJob, true
+}
+
+// begin returns the generation to pass to put for a status about to be read
+// from the database.
+func (c *statusCache) begin() uint64 {
+	if c == nil {
+		return 0
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.generation
+}
+
+// put caches the status of the job, read after begin returned generation.
+// It is dropped if anything was invalidated since, as the read may predate
+// the change.  An arbitrary entry is evicted when the cache is full.
+func (c *statusCache) put(id uuid.UUID, riverJobID int64, job *virest.InfoJob, generation uint64) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.listening || generation != c.generation {
+		return
+	}
+	if _, ok := c.jobs[riverJobID]; !ok && len(c.jobs) >= c.size {
+		for evicted := range c.jobs {
+			c.remove(evicted)
+			break
+		}
+	}
+	infoJob := *job
+	c.jobs[riverJobID] = &infoJob
+	c.uuids[id] = riverJobID
+}
+
+// invalidate drops the status of the job with the given River job ID.
+func (c *statusCache) invalidate(riverJobID int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.generation++
+	c.remove(riverJobID)
+}
+
+// remove drops a job's entries.  c.mu must be held.
+func (c *statusCache) remove(riverJobID int64) {
+	if job, ok := c.jobs[riverJobID]; ok {
+		delete(c.uuids, job.Uuid)
+		delete(c.jobs, riverJobID)
+	}
+}
+
+// reset empties the cache and enables or disables it.
+func (c *statusCache) reset(listening bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.listening = listening
+	c.generation++
+	clear(c.jobs)
+	clear(c.uuids)
+}
+
+// jobChangeHandler is told about changes to info jobs by
+// listenForJobChanges.
+type jobChangeHandler interface {
+	// reset is called when listening starts or stops.  Changes may have been
+	// missed while it wasn't listening.
+	reset(listening bool)
+
+	// invalidate is called with the River job ID of each changed job.
+	invalidate(riverJobID int64)
+}
+
+// listenForJobChanges passes change notifications to handlers until ctx is
+// done.  A lost connection is retried after jobChangesRetryDelay.
+func listenForJobChanges(ctx context.Context, pool *pgxpool.Pool, handlers ...jobChangeHandler) {
+	for {
+		err := listenForJobChangesOnce(ctx, pool, handlers)
+		for _, h := range handlers {
+			h.reset(false)
+		}
+		if ctx.Err() != nil {
+			return
+		}
+		log.Printf("Stopped listening for job changes, listening again in %s: %v", jobChangesRetryDelay, err)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(jobChangesRetryDelay):
+		}
+	}
+}
+
+// listenForJobChangesOnce listens for change notifications on a dedicated
+// connection until it fails.
+func listenForJobChangesOnce(ctx context.Context, pool *pgxpool.Pool, handlers []jobChangeHandler) error {
+	poolConn, err := pool.Acquire(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to acquire connection: %w", err)
+	}
+	// The connection keeps listening, so don't return it to the pool
+	conn := poolConn.Hijack()
+	defer conn.Close(context.Background())
+
+	if _, err := conn.Exec(ctx, "LISTEN "+infoJobChangedChannel); err != nil {
+		return fmt.Errorf("failed to listen for changes: %w", err)
+	}
+	for _, h := range handlers {
+		h.reset(true)
+	}
+
+	for {
+		notification, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to wait for changes: %w", err)
+		}
+		riverJobID, err := strconv.ParseInt(notification.Payload, 10, 64)
+		if err != nil {
+			// Don't know what changed, so start over
+			log.Printf("Ignoring malformed change notification %q", notification.Payload)
+			for _, h := range handlers {
+				h.reset(true)
+			}
+			continue
+		}
+		for _, h := range handlers {
+			h.invalidate(riverJobID)
+		}
+	}
+}
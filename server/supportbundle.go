@@ -0,0 +1,297 @@
+package main
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"runtime"
+	"runtime/debug"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/krelinga/video-info/internal"
+	"github.com/krelinga/video-info/virest"
+	"github.com/riverqueue/river"
+	"github.com/riverqueue/river/riverdriver/riverpgxv5"
+	"github.com/riverqueue/river/rivertype"
+)
+
+// supportBundleFailureWindow is how far back a support bundle summarizes
+// failures.
+const supportBundleFailureWindow = 7 * 24 * time.Hour
+
+// supportBundleFailedJobs is how many of the most recent failed jobs a
+// support bundle describes in detail.
+const supportBundleFailedJobs = 20
+
+// GetSupportBundle handles GET /admin/support-bundle requests.
+func (s *Server) GetSupportBundle(ctx context.Context, request virest.GetSupportBundleRequestObject) (virest.GetSupportBundleResponseObject, error) {
+	var buf bytes.Buffer
+	if err := s.writeSupportBundle(ctx, &buf); err != nil {
+		return virest.GetSupportBundle500JSONResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: err.Error(),
+		}, nil
+	}
+	return virest.GetSupportBundle200ApplicationgzipResponse{Body: &buf, ContentLength: int64(buf.Len())}, nil
+}
+
+// runSupportBundle implements the "support-bundle" subcommand, which writes
+// the archive served by GET /admin/support-bundle.
+func runSupportBundle(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("support-bundle", flag.ContinueOnError)
+	output := fs.String("o", "", "path of the archive to write, or - for stdout")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *output == "" {
+		return fmt.Errorf("support-bundle: -o is required")
+	}
+
+	cfg := internal.NewServerConfigFromEnv()
+	pool, err := internal.NewDBPool(ctx, cfg.Database)
+	if err != nil {
+		return fmt.Errorf("failed to create database pool: %w", err)
+	}
+	defer pool.Close()
+	riverClient, err := river.NewClient(riverpgxv5.New(pool), &river.Config{})
+	if err != nil {
+		return fmt.Errorf("failed to create river client: %w", err)
+	}
+	server, err := NewServer(pool, riverClient, cfg)
+	if err != nil {
+		return err
+	}
+
+	if *output == "-" {
+		return server.writeSupportBundle(ctx, os.Stdout)
+	}
+	f, err := os.Create(*output)
+	if err != nil {
+		return fmt.Errorf("failed to create archive: %w", err)
+	}
+	err = server.writeSupportBundle(ctx, f)
+	if closeErr := f.Close(); err == nil && closeErr != nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+	log.Printf("Wrote support bundle to %s", *output)
+	return nil
+}
+
+// supportBundlePart is one JSON file in a support bundle.
+type supportBundlePart struct {
+	name    string
+	collect func(ctx context.Context) (any, error)
+}
+
+// writeSupportBundle writes a gzipped tar archive of diagnostics to w.  A
+// part that can't be collected is replaced by its error, so one failing
+// query doesn't lose the rest.
+func (s *Server) writeSupportBundle(ctx context.Context, w io.Writer) error {
+	now := s.clock.Now().UTC()
+	parts := []supportBundlePart{
+		{"version.json", s.supportVersion},
+		{"config.json", func(context.Context) (any, error) { return internal.RedactEnv(os.Environ()), nil }},
+		{"queues.json", s.supportQueues},
+		{"failures.json", func(ctx context.Context) (any, error) { return s.supportFailures(ctx, now) }},
+		{"failed_jobs.json", s.supportFailedJobs},
+		{"agents.json", s.supportAgents},
+	}
+
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+	for _, part := range parts {
+		data, err := part.collect(ctx)
+		if err != nil {
+			data = map[string]string{"error": internal.Redact(err.Error())}
+		}
+		body, err := json.MarshalIndent(data, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal %s: %w", part.name, err)
+		}
+		hdr := &tar.Header{Name: part.name, Mode: 0o644, Size: int64(len(body)), ModTime: now}
+		if err := tw.WriteHeader(hdr); err != nil {
+			return fmt.Errorf("failed to write %s: %w", part.name, err)
+		}
+		if _, err := tw.Write(body); err != nil {
+			return fmt.Errorf("failed to write %s: %w", part.name, err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return fmt.Errorf("failed to finish support bundle: %w", err)
+	}
+	return gz.Close()
+}
+
+// supportVersion describes the build of the server and the database it uses.
+type supportVersion struct {
+	GeneratedAt   time.Time         `json:"generatedAt"`
+	Hostname      string            `json:"hostname"`
+	GoVersion     string            `json:"goVersion"`
+	Version       string            `json:"version"`
+	Revision      string            `json:"revision,omitempty"`
+	Modified      bool              `json:"modified,omitempty"`
+	Dependencies  map[string]string `json:"dependencies"`
+	Database      string            `json:"database"`
+	SchemaVersion *int64            `json:"schemaVersion"`
+	SchemaDirty   bool              `json:"schemaDirty,omitempty"`
+}
+
+func (s *Server) supportVersion(ctx context.Context) (any, error) {
+	version := supportVersion{
+		GeneratedAt:  s.clock.Now().UTC(),
+		GoVersion:    runtime.Version(),
+		Dependencies: make(map[string]string),
+	}
+	version.Hostname, _ = os.Hostname()
+	if info, ok := debug.ReadBuildInfo(); ok {
+		version.Version = info.Main.Version
+		for _, setting := range info.Settings {
+			switch setting.Key {
+			case "vcs.revision":
+				version.Revision = setting.Value
+			case "vcs.modified":
+				version.Modified = setting.Value == "true"
+			}
+		}
+		for _, dep := range info.Deps {
+			version.Dependencies[dep.Path] = dep.Version
+		}
+	}
+
+	// The database is the part most likely to be unreachable, so report
+	// its problems in place of its version
+	if err := s.readPool.QueryRow(ctx, "SELECT version()").Scan(&version.Database); err != nil {
+		version.Database = "unavailable: " + internal.Redact(err.Error())
+		return version, nil
+	}
+	var schemaVersion int64
+	err := s.readPool.QueryRow(ctx, "SELECT version, dirty FROM schema_migrations").Scan(&schemaVersion, &version.SchemaDirty)
+	if err == nil {
+		version.SchemaVersion = &schemaVersion
+	}
+	return version, nil
+}
+
+// supportQueueStat counts the River jobs in one state on one queue.
+type supportQueueStat struct {
+	Queue string `json:"queue"`
+	Kind  string `json:"kind"`
+	State string `json:"state"`
+	Count int64  `json:"count"`
+}
+
+func (s *Server) supportQueues(ctx context.Context) (any, error) {
+	rows, err := s.readPool.Query(ctx, `
+		SELECT queue, kind, state::text, count(*) FROM river_job
+		GROUP BY queue, kind, state
+		ORDER BY queue, kind, state`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count jobs: %w", err)
+	}
+	defer rows.Close()
+
+	stats := []supportQueueStat{}
+	for rows.Next() {
+		var stat supportQueueStat
+		if err := rows.Scan(&stat.Queue, &stat.Kind, &stat.State, &stat.Count); err != nil {
+			return nil, fmt.Errorf("failed to scan job counts: %w", err)
+		}
+		stats = append(stats, stat)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to count jobs: %w", err)
+	}
+	return stats, nil
+}
+
+func (s *Server) supportFailures(ctx context.Context, now time.Time) (any, error) {
+	createdAfter := now.Add(-supportBundleFailureWindow)
+	resp, err := s.ListFailures(ctx, virest.ListFailuresRequestObject{
+		Params: virest.ListFailuresParams{CreatedAfter: &createdAfter},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if failed, ok := resp.(virest.ListFailures500JSONResponse); ok {
+		return nil, errors.New(failed.Message)
+	}
+	return resp, nil
+}
+
+// supportFailedJob describes a failed info job in detail.
+type supportFailedJob struct {
+	ID          int64           `json:"id"`
+	UUID        uuid.UUID       `json:"uuid"`
+	State       string          `json:"state"`
+	Path        string          `json:"path"`
+	Attempt     int             `json:"attempt"`
+	MaxAttempts int             `json:"maxAttempts"`
+	AttemptedBy []string        `json:"attemptedBy"`
+	Errors      json.RawMessage `json:"errors"`
+	Output      json.RawMessage `json:"output,omitempty"`
+	CreatedAt   time.Time       `json:"createdAt"`
+	FinalizedAt *time.Time      `json:"finalizedAt,omitempty"`
+}
+
+func (s *Server) supportFailedJobs(ctx context.Context) (any, error) {
+	rows, err := s.readPool.Query(ctx, `
+		SELECT river_job.id, uuid_job_mapping.uuid, river_job.state::text, river_job.args->>'path',
+			river_job.attempt, river_job.max_attempts, COALESCE(river_job.attempted_by, '{}'),
+			to_jsonb(COALESCE(river_job.errors, '{}')), river_job.metadata->$2,
+			river_job.created_at, river_job.finalized_at
+		FROM river_job
+		JOIN uuid_job_mapping ON uuid_job_mapping.river_job_id = river_job.id
+		WHERE river_job.kind = $1 AND `+failedJobCondition+`
+		ORDER BY river_job.id DESC
+		LIMIT $3`,
+		internal.InfoJobArgs{}.Kind(), rivertype.MetadataKeyOutput, supportBundleFailedJobs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query failed jobs: %w", err)
+	}
+	defer rows.Close()
+
+	jobs := []supportFailedJob{}
+	for rows.Next() {
+		var (
+			job          supportFailedJob
+			errs, output []byte
+		)
+		if err := rows.Scan(&job.ID, &job.UUID, &job.State, &job.Path, &job.Attempt, &job.MaxAttempts,
+			&job.AttemptedBy, &errs, &output, &job.CreatedAt, &job.FinalizedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan failed job: %w", err)
+		}
+		// River's own errors are stored unredacted
+		job.Errors = json.RawMessage(internal.Redact(string(errs)))
+		if output != nil {
+			job.Output = json.RawMessage(internal.Redact(string(output)))
+		}
+		jobs = append(jobs, job)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to query failed jobs: %w", err)
+	}
+	return jobs, nil
+}
+
+func (s *Server) supportAgents(ctx context.Context) (any, error) {
+	resp, err := s.ListAgents(ctx, virest.ListAgentsRequestObject{})
+	if err != nil {
+		return nil, err
+	}
+	if failed, ok := resp.(virest.ListAgents500JSONResponse); ok {
+		return nil, errors.New(failed.Message)
+	}
+	return resp, nil
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/krelinga/go-libs/deep"
+	"github.com/krelinga/go-libs/exam"
+	"github.com/krelinga/video-info/internal"
+	"github.com/krelinga/video-info/virest"
+)
+
+func TestGetSupportBundle(t *testing.T) {
+	e := exam.New(t)
+	env := deep.NewEnv()
+
+	e.Run("Database down", func(e exam.E) {
+		exam.SetEnv(e, internal.EnvSigningKey, "hunter2")
+		s := newTestServer(e, &fakeStore{ping: errors.New("connection refused")}, &fakeQueue{}, &internal.ServerConfig{})
+		resp, err := s.GetSupportBundle(context.Background(), virest.GetSupportBundleRequestObject{})
+		exam.Nil(e, env, err)
+		got, ok := resp.(virest.GetSupportBundle200ApplicationgzipResponse)
+		if !ok {
+			e.Fatalf("got %T, want 200", resp)
+		}
+
+		gz, err := gzip.NewReader(got.Body)
+		exam.Nil(e, env, err)
+		tr := tar.NewReader(gz)
+		parts := make(map[string]map[string]any)
+		var names []string
+		for {
+			hdr, err := tr.Next()
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			exam.Nil(e, env, err)
+			var part map[string]any
+			exam.Nil(e, env, json.NewDecoder(tr).Decode(&part))
+			names = append(names, hdr.Name)
+			parts[hdr.Name] = part
+		}
+
+		// Every part is present even though no query succeeded
+		exam.Equal(e, env, []string{
+			"version.json", "config.json", "queues.json",
+			"failures.json", "failed_jobs.json", "agents.json",
+		}, names)
+		exam.Equal(e, env, "REDACTED", parts["config.json"][internal.EnvSigningKey])
+		if _, ok := parts["queues.json"]["error"]; !ok {
+			e.Fatalf("queues.json has no error: %v", parts["queues.json"])
+		}
+	})
+}
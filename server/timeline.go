@@ -0,0 +1,116 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/krelinga/video-info/internal"
+	"github.com/krelinga/video-info/virest"
+	"github.com/riverqueue/river/rivertype"
+)
+
+// defaultTimelineWindow is how long a timeline export covers by default.
+const defaultTimelineWindow = 24 * time.Hour
+
+// maxTimelineJobs bounds the number of jobs in a timeline export.
+const maxTimelineJobs = 100000
+
+// exportTimelineOTLPResponse is an OTLP JSON timeline export, which the
+// generated response types can only describe as a free-form object.
+type exportTimelineOTLPResponse struct {
+	body *bytes.Buffer
+}
+
+func (r exportTimelineOTLPResponse) VisitExportTimelineResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Length", fmt.Sprint(r.body.Len()))
+	w.WriteHeader(http.StatusOK)
+	_, err := r.body.WriteTo(w)
+	return err
+}
+
+// ExportTimeline handles GET /admin/timeline requests.
+func (s *Server) ExportTimeline(ctx context.Context, request virest.ExportTimelineRequestObject) (virest.ExportTimelineResponseObject, error) {
+	params := request.Params
+
+	now := s.clock.Now()
+	before := now
+	if params.Before != nil {
+		before = *params.Before
+	}
+	after := before.Add(-defaultTimelineWindow)
+	if params.After != nil {
+		after = *params.After
+	}
+	if !after.Before(before) {
+		return virest.ExportTimeline400JSONResponse{
+			Code:    "INVALID_WINDOW",
+			Message: "after must be before before",
+		}, nil
+	}
+	format := virest.Csv
+	if params.Format != nil {
+		format = *params.Format
+	}
+	if format != virest.Csv && format != virest.Otlp {
+		return virest.ExportTimeline400JSONResponse{
+			Code:    "INVALID_FORMAT",
+			Message: fmt.Sprintf("format must be %s or %s", virest.Csv, virest.Otlp),
+		}, nil
+	}
+
+	// Jobs are included if any part of their life overlaps the window
+	rows, err := s.readPool.Query(ctx, `
+		SELECT uuid_job_mapping.uuid, river_job.args->>'path', river_job.queue, river_job.state::text,
+			river_job.attempt, COALESCE(river_job.attempted_by[array_length(river_job.attempted_by, 1)], ''),
+			`+failedJobCondition+`,
+			river_job.created_at, river_job.attempted_at, river_job.finalized_at
+		FROM river_job
+		JOIN uuid_job_mapping ON uuid_job_mapping.river_job_id = river_job.id
+		WHERE river_job.kind = $1
+			AND river_job.created_at < $4
+			AND (river_job.finalized_at IS NULL OR river_job.finalized_at >= $3)
+		ORDER BY river_job.created_at, river_job.id
+		LIMIT $5`,
+		internal.InfoJobArgs{}.Kind(), rivertype.MetadataKeyOutput, after, before, maxTimelineJobs)
+	if err != nil {
+		return virest.ExportTimeline500JSONResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: fmt.Sprintf("failed to query job timeline: %v", err),
+		}, nil
+	}
+	timings, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (internal.JobTiming, error) {
+		var t internal.JobTiming
+		err := row.Scan(&t.UUID, &t.Path, &t.Queue, &t.State, &t.Attempt, &t.Worker, &t.Failed,
+			&t.EnqueuedAt, &t.StartedAt, &t.FinishedAt)
+		return t, err
+	})
+	if err != nil {
+		return virest.ExportTimeline500JSONResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: fmt.Sprintf("failed to query job timeline: %v", err),
+		}, nil
+	}
+
+	var buf bytes.Buffer
+	if format == virest.Otlp {
+		if err := internal.WriteTimelineOTLP(&buf, timings, now); err != nil {
+			return virest.ExportTimeline500JSONResponse{
+				Code:    "INTERNAL_ERROR",
+				Message: fmt.Sprintf("failed to write job timeline: %v", err),
+			}, nil
+		}
+		return exportTimelineOTLPResponse{body: &buf}, nil
+	}
+	if err := internal.WriteTimelineCSV(&buf, timings, now); err != nil {
+		return virest.ExportTimeline500JSONResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: fmt.Sprintf("failed to write job timeline: %v", err),
+		}, nil
+	}
+	return virest.ExportTimeline200TextcsvResponse{Body: &buf, ContentLength: int64(buf.Len())}, nil
+}
@@ -0,0 +1,165 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/krelinga/video-info/internal"
+	"github.com/krelinga/video-info/virest"
+	"github.com/riverqueue/river/rivertype"
+)
+
+// defaultWebhookStatsWindow is how far back webhook target statistics look
+// by default, matching River's default retention of completed jobs.
+const defaultWebhookStatsWindow = 24 * time.Hour
+
+// ListUndeliveredWebhooks handles GET /admin/webhooks/undelivered requests.
+func (s *Server) ListUndeliveredWebhooks(ctx context.Context, request virest.ListUndeliveredWebhooksRequestObject) (virest.ListUndeliveredWebhooksResponseObject, error) {
+	params := request.Params
+
+	limit := defaultListLimit
+	if params.Limit != nil {
+		limit = *params.Limit
+		if limit < 1 || limit > maxListLimit {
+			return virest.ListUndeliveredWebhooks400JSONResponse{
+				Code:    "INVALID_LIMIT",
+				Message: fmt.Sprintf("limit must be between 1 and %d", maxListLimit),
+			}, nil
+		}
+	}
+
+	rows, err := s.readPool.Query(ctx, `
+		SELECT info_uuid, target, attempts, error, failed_at
+		FROM webhook_dead_letter
+		WHERE ($1::uuid IS NULL OR info_uuid = $1)
+			AND ($2::text IS NULL OR target = $2)
+		ORDER BY failed_at DESC, id DESC
+		LIMIT $3`,
+		params.Uuid, params.Target, limit)
+	if err != nil {
+		return virest.ListUndeliveredWebhooks500JSONResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: fmt.Sprintf("failed to query undelivered webhooks: %v", err),
+		}, nil
+	}
+	webhooks, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (virest.UndeliveredWebhook, error) {
+		var w virest.UndeliveredWebhook
+		err := row.Scan(&w.Uuid, &w.Target, &w.Attempts, &w.Error, &w.FailedAt)
+		return w, err
+	})
+	if err != nil {
+		return virest.ListUndeliveredWebhooks500JSONResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: fmt.Sprintf("failed to query undelivered webhooks: %v", err),
+		}, nil
+	}
+	return virest.ListUndeliveredWebhooks200JSONResponse(webhooks), nil
+}
+
+// ListWebhookTargets handles GET /admin/webhooks/targets requests.
+func (s *Server) ListWebhookTargets(ctx context.Context, request virest.ListWebhookTargetsRequestObject) (virest.ListWebhookTargetsResponseObject, error) {
+	params := request.Params
+
+	limit := defaultListLimit
+	if params.Limit != nil {
+		limit = *params.Limit
+		if limit < 1 || limit > maxListLimit {
+			return virest.ListWebhookTargets400JSONResponse{
+				Code:    "INVALID_LIMIT",
+				Message: fmt.Sprintf("limit must be between 1 and %d", maxListLimit),
+			}, nil
+		}
+	}
+	since := s.clock.Now().Add(-defaultWebhookStatsWindow)
+	if params.Since != nil {
+		since = *params.Since
+	}
+
+	// Delivered webhooks are only known from the output of completed jobs,
+	// and undelivered ones from the dead letter table
+	rows, err := s.readPool.Query(ctx, `
+		WITH delivered AS (
+			SELECT metadata->$2->>'target' AS target,
+				count(*) AS delivered,
+				percentile_cont(0.5) WITHIN GROUP (ORDER BY (metadata->$2->>'latency_seconds')::float8) AS p50,
+				percentile_cont(0.95) WITHIN GROUP (ORDER BY (metadata->$2->>'latency_seconds')::float8) AS p95,
+				max((metadata->$2->>'latency_seconds')::float8) AS max
+			FROM river_job
+			WHERE kind = $1 AND state = 'completed' AND finalized_at >= $3
+				AND metadata->$2 ? 'latency_seconds'
+			GROUP BY 1
+		), undelivered AS (
+			SELECT target, count(*) AS undelivered
+			FROM webhook_dead_letter
+			WHERE failed_at >= $3
+			GROUP BY target
+		)
+		SELECT COALESCE(delivered.target, undelivered.target),
+			COALESCE(delivered.delivered, 0), COALESCE(undelivered.undelivered, 0),
+			delivered.p50, delivered.p95, delivered.max
+		FROM delivered FULL JOIN undelivered ON delivered.target = undelivered.target
+		ORDER BY delivered.p95 DESC NULLS LAST, 1
+		LIMIT $4`,
+		internal.WebhookJobArgs{}.Kind(), rivertype.MetadataKeyOutput, since, limit)
+	if err != nil {
+		return virest.ListWebhookTargets500JSONResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: fmt.Sprintf("failed to query webhook statistics: %v", err),
+		}, nil
+	}
+	targets, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (virest.WebhookTargetStats, error) {
+		var t virest.WebhookTargetStats
+		err := row.Scan(&t.Target, &t.Delivered, &t.Undelivered, &t.LatencyP50Seconds, &t.LatencyP95Seconds, &t.LatencyMaxSeconds)
+		if total := t.Delivered + t.Undelivered; total > 0 {
+			t.SuccessRate = float64(t.Delivered) / float64(total)
+		}
+		return t, err
+	})
+	if err != nil {
+		return virest.ListWebhookTargets500JSONResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: fmt.Sprintf("failed to query webhook statistics: %v", err),
+		}, nil
+	}
+	return virest.ListWebhookTargets200JSONResponse(targets), nil
+}
+
+// ListWebhookBreakers handles GET /admin/webhooks/breakers requests.
+func (s *Server) ListWebhookBreakers(ctx context.Context, request virest.ListWebhookBreakersRequestObject) (virest.ListWebhookBreakersResponseObject, error) {
+	rows, err := s.readPool.Query(ctx, `
+		SELECT target, failures, trips, opened_at, open_until, probing_at, updated_at
+		FROM webhook_breaker
+		ORDER BY open_until IS NULL, failures DESC, target`)
+	if err != nil {
+		return virest.ListWebhookBreakers500JSONResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: fmt.Sprintf("failed to query webhook breakers: %v", err),
+		}, nil
+	}
+	now := s.clock.Now()
+	breakers, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (virest.WebhookBreaker, error) {
+		var (
+			b         virest.WebhookBreaker
+			probingAt *time.Time
+		)
+		err := row.Scan(&b.Target, &b.ConsecutiveFailures, &b.Trips, &b.OpenedAt, &b.OpenUntil, &probingAt, &b.UpdatedAt)
+		switch {
+		case probingAt != nil || b.OpenUntil != nil && !b.OpenUntil.After(now):
+			b.State = virest.HalfOpen
+		case b.OpenUntil != nil:
+			b.State = virest.Open
+		default:
+			b.State = virest.Closed
+		}
+		return b, err
+	})
+	if err != nil {
+		return virest.ListWebhookBreakers500JSONResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: fmt.Sprintf("failed to query webhook breakers: %v", err),
+		}, nil
+	}
+	return virest.ListWebhookBreakers200JSONResponse(breakers), nil
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/krelinga/go-libs/deep"
+	"github.com/krelinga/go-libs/exam"
+	"github.com/krelinga/video-info/internal"
+	"github.com/krelinga/video-info/virest"
+)
+
+func TestListWebhookBreakers(t *testing.T) {
+	e := exam.New(t)
+	env := deep.NewEnv()
+
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	future := now.Add(time.Minute)
+	past := now.Add(-time.Minute)
+	store := &fakeStore{
+		query: func(string, []any) (pgx.Rows, error) {
+			return &fakeRows{rows: [][]any{
+				{"https://open.example.com", 9, 1, &past, &future, nil, past},
+				{"https://probing.example.com", 12, 2, &past, &future, &now, past},
+				{"https://cooled.example.com", 7, 1, &past, &past, nil, past},
+				{"https://flaky.example.com", 2, 0, nil, nil, nil, past},
+			}}, nil
+		},
+	}
+	s := newTestServer(e, store, &fakeQueue{}, &internal.ServerConfig{})
+	s.clock = internal.NewFakeClock(now)
+
+	resp, err := s.ListWebhookBreakers(context.Background(), virest.ListWebhookBreakersRequestObject{})
+	exam.Nil(e, env, err)
+	got, ok := resp.(virest.ListWebhookBreakers200JSONResponse)
+	if !ok {
+		e.Fatalf("got %T, want 200", resp)
+	}
+	var states []virest.WebhookBreakerState
+	for _, b := range got {
+		states = append(states, b.State)
+	}
+	exam.Equal(e, env, []virest.WebhookBreakerState{virest.Open, virest.HalfOpen, virest.HalfOpen, virest.Closed}, states)
+	exam.Equal(e, env, 12, got[1].ConsecutiveFailures)
+	exam.Equal(e, env, 2, got[1].Trips)
+}
@@ -0,0 +1,339 @@
+package main
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/krelinga/video-info/internal"
+	"github.com/krelinga/video-info/virest"
+	"github.com/riverqueue/river/rivertype"
+)
+
+// remoteJobLease is how long a job claimed through the worker API may stay
+// running before another worker may claim it.  It matches River's default
+// for rescuing stuck jobs.
+const remoteJobLease = time.Hour
+
+// remoteWorkerMetadataKey marks jobs claimed through the worker API so that
+// only those are subject to remoteJobLease.
+const remoteWorkerMetadataKey = "remote_worker"
+
+// workerAuthMiddleware rejects requests to operations secured by the worker
+// token unless they carry the configured token.
+func (s *Server) workerAuthMiddleware(f virest.StrictHandlerFunc, operationID string) virest.StrictHandlerFunc {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request, request any) (any, error) {
+		if ctx.Value(virest.WorkerTokenScopes) == nil {
+			return f(ctx, w, r, request)
+		}
+
+		var message string
+		token, hasToken := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		switch {
+		case s.cfg.WorkerToken == "":
+			message = "the worker API is disabled"
+		case !hasToken || subtle.ConstantTimeCompare([]byte(token), []byte(s.cfg.WorkerToken)) != 1:
+			message = "missing or invalid worker token"
+		default:
+			return f(ctx, w, r, request)
+		}
+
+		unauthorized := virest.Error{Code: "UNAUTHORIZED", Message: message}
+		switch operationID {
+		case "ClaimWorkerJob":
+			return virest.ClaimWorkerJob401JSONResponse(unauthorized), nil
+		case "CompleteWorkerJob":
+			return virest.CompleteWorkerJob401JSONResponse(unauthorized), nil
+		case "RegisterAgent":
+			return virest.RegisterAgent401JSONResponse(unauthorized), nil
+		default:
+			return nil, fmt.Errorf("no unauthorized response for operation %s", operationID)
+		}
+	}
+}
+
+// ClaimWorkerJob handles POST /worker/claim requests.
+func (s *Server) ClaimWorkerJob(ctx context.Context, request virest.ClaimWorkerJobRequestObject) (virest.ClaimWorkerJobResponseObject, error) {
+	if request.Body == nil || request.Body.WorkerId == "" {
+		return virest.ClaimWorkerJob400JSONResponse{
+			Code:    "INVALID_REQUEST",
+			Message: "workerId is required",
+		}, nil
+	}
+
+	// Registered workers are only given jobs under one of their mounts
+	var mounts []string
+	err := s.pool.QueryRow(ctx, "UPDATE worker_agent SET last_seen_at = now() WHERE worker_id = $1 RETURNING mounts",
+		request.Body.WorkerId).Scan(&mounts)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return virest.ClaimWorkerJob500JSONResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: fmt.Sprintf("failed to look up agent: %v", err),
+		}, nil
+	}
+	if mounts == nil {
+		mounts = []string{}
+	}
+
+	// Jobs are only handed out to a claim for their queue
+	var claimArgs internal.InfoJobArgs
+	if request.Body.Resources != nil {
+		claimArgs.Resources = *request.Body.Resources
+	}
+	if request.Body.Queue != nil {
+		if err := internal.ValidateQueueName(*request.Body.Queue); err != nil {
+			return virest.ClaimWorkerJob400JSONResponse{
+				Code:    "INVALID_QUEUE",
+				Message: err.Error(),
+			}, nil
+		}
+		claimArgs.QueueName = *request.Body.Queue
+	}
+	queue := claimArgs.Queue()
+
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return virest.ClaimWorkerJob500JSONResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: fmt.Sprintf("failed to begin transaction: %v", err),
+		}, nil
+	}
+	defer tx.Rollback(ctx)
+
+	// Claim the next available job, or one whose remote lease has expired.
+	// SKIP LOCKED lets concurrent claims and River's own fetches proceed
+	// without blocking on each other.
+	var (
+		jobID       int64
+		attempt     int
+		encodedArgs []byte
+	)
+	err = tx.QueryRow(ctx, `
+		UPDATE river_job
+		SET state = 'running',
+			attempt = attempt + 1,
+			attempted_at = now(),
+			attempted_by = array_append(attempted_by, $2),
+			metadata = metadata || jsonb_build_object($3::text, $2::text)
+		WHERE id = (
+			SELECT id FROM river_job
+			WHERE kind = $1 AND queue = $6
+				AND ((state = 'available' AND scheduled_at <= now())
+					OR (state = 'running' AND metadata ? $3
+						AND attempted_at < now() - make_interval(secs => $4) AND attempt < max_attempts))
+				AND (cardinality($5::text[]) = 0
+					OR EXISTS (SELECT 1 FROM unnest($5::text[]) AS m WHERE starts_with(args->>'path', m)))
+			ORDER BY priority, scheduled_at, id
+			LIMIT 1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING id, attempt, args`,
+		internal.InfoJobArgs{}.Kind(), request.Body.WorkerId, remoteWorkerMetadataKey, remoteJobLease.Seconds(), mounts, queue).
+		Scan(&jobID, &attempt, &encodedArgs)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return virest.ClaimWorkerJob204Response{}, nil
+	} else if err != nil {
+		return virest.ClaimWorkerJob500JSONResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: fmt.Sprintf("failed to claim job: %v", err),
+		}, nil
+	}
+
+	var jobArgs internal.InfoJobArgs
+	if err := json.Unmarshal(encodedArgs, &jobArgs); err != nil {
+		return virest.ClaimWorkerJob500JSONResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: fmt.Sprintf("failed to unmarshal job args: %v", err),
+		}, nil
+	}
+
+	// Enqueue the webhooks of a job whose webhook events include its start.
+	// Retries and reclaims of expired leases start the job again, but its
+	// receivers only hear of the first start.
+	var started []internal.WebhookJobArgs
+	if attempt == 1 {
+		started = jobArgs.WebhookArgs(internal.WebhookStarted, nil)
+	}
+	for _, webhookArgs := range started {
+		if err := internal.EnqueueWebhook(ctx, tx, s.riverClient, webhookArgs, s.clock.Now()); err != nil {
+			return virest.ClaimWorkerJob500JSONResponse{
+				Code:    "INTERNAL_ERROR",
+				Message: err.Error(),
+			}, nil
+		}
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return virest.ClaimWorkerJob500JSONResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: fmt.Sprintf("failed to commit transaction: %v", err),
+		}, nil
+	}
+
+	job := virest.ClaimWorkerJob200JSONResponse{
+		JobId:           jobID,
+		Attempt:         attempt,
+		Uuid:            jobArgs.UUID,
+		VideoPath:       jobArgs.Path,
+		AnalyzeCrop:     &jobArgs.AnalyzeCrop,
+		AnalyzeLoudness: &jobArgs.AnalyzeLoudness,
+		Verify:          &jobArgs.Verify,
+		Analyzers:       jobArgs.Analyzers,
+		IncludeRaw:      &jobArgs.IncludeRaw,
+	}
+	if jobArgs.SampleWindows > 0 {
+		job.SampleWindows = &jobArgs.SampleWindows
+	}
+	if jobArgs.Checksum != "" {
+		job.Checksum = &jobArgs.Checksum
+	}
+	if jobArgs.TimeoutSeconds > 0 {
+		job.TimeoutSeconds = &jobArgs.TimeoutSeconds
+	}
+	s.publisher.Publish(internal.JobEventStarted, jobArgs, nil, s.clock.Now())
+	return job, nil
+}
+
+// CompleteWorkerJob handles POST /worker/jobs/{jobId}/complete requests.
+func (s *Server) CompleteWorkerJob(ctx context.Context, request virest.CompleteWorkerJobRequestObject) (virest.CompleteWorkerJobResponseObject, error) {
+	if request.Body == nil {
+		return virest.CompleteWorkerJob400JSONResponse{
+			Code:    "INVALID_REQUEST",
+			Message: "Request body is required",
+		}, nil
+	}
+	if (request.Body.Result == nil) == (request.Body.Error == nil) {
+		return virest.CompleteWorkerJob400JSONResponse{
+			Code:    "INVALID_REQUEST",
+			Message: "exactly one of result or error is required",
+		}, nil
+	}
+
+	// Use a transaction to complete the job, store its result and enqueue its
+	// webhook atomically
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return virest.CompleteWorkerJob500JSONResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: fmt.Sprintf("failed to begin transaction: %v", err),
+		}, nil
+	}
+	defer tx.Rollback(ctx)
+
+	// The attempt acts as a fencing token, so a worker whose lease expired
+	// can't overwrite the outcome of a later claim.
+	var (
+		encodedArgs []byte
+		priority    int
+		createdAt   time.Time
+	)
+	err = tx.QueryRow(ctx, `
+		SELECT args, priority, created_at FROM river_job
+		WHERE id = $1 AND kind = $2 AND state = 'running' AND attempt = $3 AND metadata ? $4
+		FOR UPDATE`,
+		request.JobId, internal.InfoJobArgs{}.Kind(), request.Body.Attempt, remoteWorkerMetadataKey).Scan(&encodedArgs, &priority, &createdAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return virest.CompleteWorkerJob409JSONResponse{
+			Code:    "JOB_NOT_CLAIMED",
+			Message: fmt.Sprintf("job %d is not claimed with attempt %d", request.JobId, request.Body.Attempt),
+		}, nil
+	} else if err != nil {
+		return virest.CompleteWorkerJob500JSONResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: fmt.Sprintf("failed to look up job: %v", err),
+		}, nil
+	}
+
+	var jobArgs internal.InfoJobArgs
+	if err := json.Unmarshal(encodedArgs, &jobArgs); err != nil {
+		return virest.CompleteWorkerJob500JSONResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: fmt.Sprintf("failed to unmarshal job args: %v", err),
+		}, nil
+	}
+
+	if request.Body.Error != nil {
+		errMsg := internal.Redact(*request.Body.Error)
+		request.Body.Error = &errMsg
+	}
+	status := internal.InfoJobStatus{
+		Error:     request.Body.Error,
+		ErrorCode: request.Body.ErrorCode,
+		Result:    internal.NewInfoJobResult(request.Body.Result),
+		Timings:   internal.NewPhaseTimings(request.Body.Timings),
+	}
+	// Workers of other versions may produce results this server can't serve
+	internal.EnforceOutputSchema(&status)
+	if err := s.signer.SignInfoJobStatus(jobArgs, &status); err != nil {
+		return virest.CompleteWorkerJob500JSONResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: fmt.Sprintf("failed to sign job output: %v", err),
+		}, nil
+	}
+	output, err := json.Marshal(status)
+	if err != nil {
+		return virest.CompleteWorkerJob500JSONResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: fmt.Sprintf("failed to marshal job output: %v", err),
+		}, nil
+	}
+
+	_, err = tx.Exec(ctx, `
+		UPDATE river_job
+		SET state = 'completed',
+			finalized_at = now(),
+			metadata = metadata || jsonb_build_object($2::text, $3::jsonb)
+		WHERE id = $1`,
+		request.JobId, rivertype.MetadataKeyOutput, string(output))
+	if err != nil {
+		return virest.CompleteWorkerJob500JSONResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: fmt.Sprintf("failed to complete job: %v", err),
+		}, nil
+	}
+
+	if err := internal.StoreInfoResult(ctx, tx, request.JobId, jobArgs, &status, priority, createdAt); err != nil {
+		return virest.CompleteWorkerJob500JSONResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: err.Error(),
+		}, nil
+	}
+
+	// Enqueue a webhook job for each configured webhook target
+	if webhooks := jobArgs.WebhookArgs("", &status); len(webhooks) > 0 {
+		var changes *internal.ResultChanges
+		if status.Result != nil {
+			changes, err = internal.CompareWithPreviousResult(ctx, tx, request.JobId, jobArgs, status.Result)
+			if err != nil {
+				return virest.CompleteWorkerJob500JSONResponse{
+					Code:    "INTERNAL_ERROR",
+					Message: err.Error(),
+				}, nil
+			}
+		}
+		for _, webhookArgs := range webhooks {
+			webhookArgs.Changes = changes
+			if err := internal.EnqueueWebhook(ctx, tx, s.riverClient, webhookArgs, s.clock.Now()); err != nil {
+				return virest.CompleteWorkerJob500JSONResponse{
+					Code:    "INTERNAL_ERROR",
+					Message: err.Error(),
+				}, nil
+			}
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return virest.CompleteWorkerJob500JSONResponse{
+			Code:    "INTERNAL_ERROR",
+			Message: fmt.Sprintf("failed to commit transaction: %v", err),
+		}, nil
+	}
+	s.publisher.PublishFinished(jobArgs, &status, s.clock.Now())
+
+	return virest.CompleteWorkerJob204Response{}, nil
+}
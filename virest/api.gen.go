@@ -25,788 +25,10823 @@ import (
 	openapi_types "github.com/oapi-codegen/runtime/types"
 )
 
+const (
+	WorkerTokenScopes = "workerToken.Scopes"
+)
+
+// Defines values for ChecksumAlgorithm.
+const (
+	Partial  ChecksumAlgorithm = "partial"
+	Sha256   ChecksumAlgorithm = "sha256"
+	Xxhash64 ChecksumAlgorithm = "xxhash64"
+)
+
 // Defines values for InfoStatus.
 const (
+	Cancelled InfoStatus = "cancelled"
 	Completed InfoStatus = "completed"
 	Failed    InfoStatus = "failed"
 	Pending   InfoStatus = "pending"
 	Running   InfoStatus = "running"
 )
 
-// Error defines model for Error.
-type Error struct {
-	// Code Error code
-	Code string `json:"code"`
+// Defines values for MediaKind.
+const (
+	Audio         MediaKind = "audio"
+	ImageSequence MediaKind = "image_sequence"
+	Video         MediaKind = "video"
+)
 
-	// Message Human-readable error message
-	Message string `json:"message"`
-}
+// Defines values for Resources.
+const (
+	Cpu Resources = "cpu"
+	Gpu Resources = "gpu"
+)
 
-// InfoJob defines model for InfoJob.
-type InfoJob struct {
-	// CreatedAt Timestamp when the job was created
-	CreatedAt time.Time `json:"createdAt"`
+// Defines values for WebhookBreakerState.
+const (
+	Closed   WebhookBreakerState = "closed"
+	HalfOpen WebhookBreakerState = "half_open"
+	Open     WebhookBreakerState = "open"
+)
 
-	// Error Error message if the info extraction failed
-	Error  *string    `json:"error,omitempty"`
-	Result *VideoInfo `json:"result,omitempty"`
+// Defines values for WebhookEvent.
+const (
+	WebhookEventCancelled WebhookEvent = "cancelled"
+	WebhookEventCompleted WebhookEvent = "completed"
+	WebhookEventExpired   WebhookEvent = "expired"
+	WebhookEventFailed    WebhookEvent = "failed"
+	WebhookEventStarted   WebhookEvent = "started"
+)
 
-	// Status Current status of the info extraction job
-	Status InfoStatus `json:"status"`
+// Defines values for ExportTimelineParamsFormat.
+const (
+	Csv  ExportTimelineParamsFormat = "csv"
+	Otlp ExportTimelineParamsFormat = "otlp"
+)
 
-	// UpdatedAt Timestamp when the job was last updated
-	UpdatedAt time.Time `json:"updatedAt"`
+// Defines values for ListInfoParamsSort.
+const (
+	CreatedAt ListInfoParamsSort = "created_at"
+	Duration  ListInfoParamsSort = "duration"
+	Path      ListInfoParamsSort = "path"
+	UpdatedAt ListInfoParamsSort = "updated_at"
+)
 
-	// Uuid Unique identifier for the info job
-	Uuid openapi_types.UUID `json:"uuid"`
+// Defines values for ListInfoParamsOrder.
+const (
+	Asc  ListInfoParamsOrder = "asc"
+	Desc ListInfoParamsOrder = "desc"
+)
 
-	// VideoPath Path to the video file being inspected
-	VideoPath string `json:"videoPath"`
-}
+// Agent defines model for Agent.
+type Agent struct {
+	// Capacity Number of jobs the worker runs concurrently
+	Capacity int `json:"capacity"`
 
-// InfoRequest defines model for InfoRequest.
-type InfoRequest struct {
-	// Uuid Client-provided UUID for the info job
-	Uuid openapi_types.UUID `json:"uuid"`
+	// FfprobeVersion Version of ffprobe used by the worker
+	FfprobeVersion string `json:"ffprobeVersion"`
 
-	// VideoPath Path to the video file to inspect
-	VideoPath string `json:"videoPath"`
+	// GpuCapacity Number of GPU jobs the worker runs concurrently
+	GpuCapacity int `json:"gpuCapacity"`
 
-	// WebhookToken Optional base64-encoded token to include in webhook POST body
-	WebhookToken []byte `json:"webhookToken,omitempty"`
+	// Hostname Host name of the machine running the worker
+	Hostname string `json:"hostname"`
 
-	// WebhookUri Optional URI to POST results to when job completes
-	WebhookUri *string `json:"webhookUri,omitempty"`
+	// LastSeenAt Timestamp of the worker's most recent registration or claim
+	LastSeenAt time.Time `json:"lastSeenAt"`
+
+	// Mounts Directories under which the worker can read video files
+	Mounts []string `json:"mounts"`
+
+	// RegisteredAt Timestamp when the worker first registered
+	RegisteredAt time.Time `json:"registeredAt"`
+
+	// RunningJobs Number of jobs currently claimed by the worker
+	RunningJobs int `json:"runningJobs"`
+
+	// WorkerId Identifier of the worker
+	WorkerId string `json:"workerId"`
 }
 
-// InfoStatus Current status of the info extraction job
-type InfoStatus string
+// AgentList defines model for AgentList.
+type AgentList struct {
+	Agents []Agent `json:"agents"`
+}
 
-// VideoInfo defines model for VideoInfo.
-type VideoInfo struct {
-	// ChapterDurationsSeconds Duration of each chapter in seconds
-	ChapterDurationsSeconds []float64 `json:"chapterDurationsSeconds"`
+// AgentRegistration defines model for AgentRegistration.
+type AgentRegistration struct {
+	// Capacity Number of jobs the worker runs concurrently
+	Capacity int `json:"capacity"`
 
-	// TotalDurationSeconds Total duration of the video in seconds
-	TotalDurationSeconds float64 `json:"totalDurationSeconds"`
+	// FfprobeVersion Version of ffprobe used by the worker
+	FfprobeVersion string `json:"ffprobeVersion"`
+
+	// GpuCapacity Number of GPU jobs the worker runs concurrently, in addition to capacity
+	GpuCapacity *int `json:"gpuCapacity,omitempty"`
+
+	// Hostname Host name of the machine running the worker
+	Hostname string `json:"hostname"`
+
+	// Mounts Directories under which the worker can read video files.  An empty list means the worker can read any path.
+	Mounts []string `json:"mounts"`
 }
 
-// CreateInfoJSONRequestBody defines body for CreateInfo for application/json ContentType.
-type CreateInfoJSONRequestBody = InfoRequest
+// Annotations defines model for Annotations.
+type Annotations struct {
+	// Acknowledged Whether an operator has acknowledged the job, typically a known failure
+	Acknowledged bool `json:"acknowledged"`
 
-// RequestEditorFn  is the function signature for the RequestEditor callback function
-type RequestEditorFn func(ctx context.Context, req *http.Request) error
+	// Note Free-form note left by an operator
+	Note *string `json:"note,omitempty"`
 
-// Doer performs HTTP requests.
-//
-// The standard http.Client implements this interface.
-type HttpRequestDoer interface {
-	Do(req *http.Request) (*http.Response, error)
+	// UpdatedAt When the annotations were last changed.  Absent if they never were.
+	UpdatedAt *time.Time `json:"updatedAt,omitempty"`
 }
 
-// Client which conforms to the OpenAPI3 specification for this service.
-type Client struct {
-	// The endpoint of the server conforming to this interface, with scheme,
-	// https://api.deepmap.com for example. This can contain a path relative
-	// to the server, such as https://api.deepmap.com/dev-test, and all the
-	// paths in the swagger spec will be appended to the server.
-	Server string
+// AnnotationsUpdate defines model for AnnotationsUpdate.
+type AnnotationsUpdate struct {
+	// Acknowledged New acknowledged flag
+	Acknowledged *bool `json:"acknowledged,omitempty"`
 
-	// Doer for performing requests, typically a *http.Client with any
-	// customized settings, such as certificate chains.
-	Client HttpRequestDoer
+	// Note New note.  An empty string clears the note.
+	Note *string `json:"note,omitempty"`
+}
 
-	// A list of callbacks for modifying requests which are generated before sending over
-	// the network.
-	RequestEditors []RequestEditorFn
+// AudioLoudness EBU R128 loudness of the whole track, measured when loudness analysis was requested.  Measurements are absent for silent tracks.
+type AudioLoudness struct {
+	// IntegratedLufs Integrated loudness in LUFS
+	IntegratedLufs *float64 `json:"integratedLufs,omitempty"`
+
+	// LoudnessRangeLu Loudness range (LRA) in LU
+	LoudnessRangeLu *float64 `json:"loudnessRangeLu,omitempty"`
+
+	// ThresholdLufs Gating threshold in LUFS used for the integrated loudness
+	ThresholdLufs *float64 `json:"thresholdLufs,omitempty"`
+
+	// TruePeakDbtp True peak in dBTP
+	TruePeakDbtp *float64 `json:"truePeakDbtp,omitempty"`
 }
 
-// ClientOption allows setting custom parameters during construction
-type ClientOption func(*Client) error
+// AudioTrack defines model for AudioTrack.
+type AudioTrack struct {
+	// BitRate Bit rate in bits per second, if known
+	BitRate *int64 `json:"bitRate,omitempty"`
 
-// Creates a new Client, with reasonable defaults
-func NewClient(server string, opts ...ClientOption) (*Client, error) {
-	// create a client with sane default values
-	client := Client{
-		Server: server,
-	}
-	// mutate client and add all optional params
-	for _, o := range opts {
-		if err := o(&client); err != nil {
-			return nil, err
-		}
-	}
-	// ensure the server URL always has a trailing slash
-	if !strings.HasSuffix(client.Server, "/") {
-		client.Server += "/"
-	}
-	// create httpClient, if not already present
-	if client.Client == nil {
-		client.Client = &http.Client{}
-	}
-	return &client, nil
+	// ChannelLayout Channel layout
+	ChannelLayout *string `json:"channelLayout,omitempty"`
+
+	// Channels Number of audio channels
+	Channels int `json:"channels"`
+
+	// CodecName Short name of the codec
+	CodecName string `json:"codecName"`
+
+	// Default Whether the track is flagged as a default track
+	Default bool `json:"default"`
+
+	// Forced Whether the track is flagged as forced
+	Forced bool `json:"forced"`
+
+	// Index Index of the stream within the file
+	Index int `json:"index"`
+
+	// Language Language tag of the track
+	Language *string `json:"language,omitempty"`
+
+	// Loudness EBU R128 loudness of the whole track, measured when loudness analysis was requested.  Measurements are absent for silent tracks.
+	Loudness *AudioLoudness `json:"loudness,omitempty"`
+
+	// SampleRate Sample rate in hertz
+	SampleRate *int `json:"sampleRate,omitempty"`
 }
 
-// WithHTTPClient allows overriding the default Doer, which is
-// automatically created using http.Client. This is useful for tests.
-func WithHTTPClient(doer HttpRequestDoer) ClientOption {
-	return func(c *Client) error {
-		c.Client = doer
-		return nil
-	}
+// BlackBars defines model for BlackBars.
+type BlackBars struct {
+	Bottom int `json:"bottom"`
+	Left   int `json:"left"`
+	Right  int `json:"right"`
+	Top    int `json:"top"`
 }
 
-// WithRequestEditorFn allows setting up a callback function, which will be
-// called right before sending the request. This can be used to mutate the request.
-func WithRequestEditorFn(fn RequestEditorFn) ClientOption {
-	return func(c *Client) error {
-		c.RequestEditors = append(c.RequestEditors, fn)
-		return nil
-	}
+// CanaryDifference defines model for CanaryDifference.
+type CanaryDifference struct {
+	// BaselineError Error of the baseline job, if it failed
+	BaselineError *string `json:"baselineError,omitempty"`
+
+	// BaselineUuid Job that produced the result it was compared with
+	BaselineUuid openapi_types.UUID `json:"baselineUuid"`
+
+	// CanaryError Error of the canary job, if it failed
+	CanaryError *string `json:"canaryError,omitempty"`
+
+	// CanaryUuid Canary job that produced the result
+	CanaryUuid openapi_types.UUID `json:"canaryUuid"`
+
+	// ChangedFields MediaInfo properties that differ, or error if only one of the jobs failed or they failed differently
+	ChangedFields []string `json:"changedFields"`
+	VideoPath     string   `json:"videoPath"`
 }
 
-// The interface specification for the client above.
-type ClientInterface interface {
-	// CreateInfoWithBody request with any body
-	CreateInfoWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+// CanaryReport defines model for CanaryReport.
+type CanaryReport struct {
+	// Compared Number of canary results compared with a baseline
+	Compared int `json:"compared"`
 
-	CreateInfo(ctx context.Context, body CreateInfoJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+	// Differences The most recent differing canary results
+	Differences []CanaryDifference `json:"differences"`
 
-	// GetInfoStatus request
-	GetInfoStatus(ctx context.Context, uuid openapi_types.UUID, reqEditors ...RequestEditorFn) (*http.Response, error)
+	// Differing Number of canary results that differed from their baseline
+	Differing int `json:"differing"`
+
+	// Identical Number of canary results that matched their baseline
+	Identical int `json:"identical"`
+
+	// NoBaseline Number of canary results for paths without a result from a job that wasn't a canary
+	NoBaseline int `json:"noBaseline"`
+
+	// Percent Percentage of eligible new jobs routed to the canary queue
+	Percent *int `json:"percent,omitempty"`
+
+	// Queue Queue canary jobs are routed to.  Absent if routing is disabled.
+	Queue *string `json:"queue,omitempty"`
+
+	// Truncated Whether there were more canary results than could be compared at once
+	Truncated bool `json:"truncated"`
 }
 
-func (c *Client) CreateInfoWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewCreateInfoRequestWithBody(c.Server, contentType, body)
-	if err != nil {
-		return nil, err
-	}
-	req = req.WithContext(ctx)
-	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
-		return nil, err
-	}
-	return c.Client.Do(req)
+// Chapter defines model for Chapter.
+type Chapter struct {
+	// EndSeconds End of the chapter in seconds
+	EndSeconds float64 `json:"endSeconds"`
+
+	// StartSeconds Start of the chapter in seconds
+	StartSeconds float64 `json:"startSeconds"`
+
+	// Title Chapter title
+	Title *string `json:"title,omitempty"`
 }
 
-func (c *Client) CreateInfo(ctx context.Context, body CreateInfoJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewCreateInfoRequest(c.Server, body)
-	if err != nil {
-		return nil, err
-	}
-	req = req.WithContext(ctx)
-	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
-		return nil, err
-	}
-	return c.Client.Do(req)
+// ChecksumAlgorithm Checksum to compute over the file's contents.  xxhash64 and sha256 read the whole file.  partial is an xxhash64 of the file's size and its first and last MiB, which is fast and good enough to spot likely duplicates but not to verify copies.
+type ChecksumAlgorithm string
+
+// Comparison defines model for Comparison.
+type Comparison struct {
+	Baseline  InfoJob `json:"baseline"`
+	Candidate InfoJob `json:"candidate"`
+
+	// Compared Whether both jobs have results and they were compared.  Check the jobs' errors when either has finished without one.
+	Compared  bool      `json:"compared"`
+	CreatedAt time.Time `json:"createdAt"`
+
+	// Differences Differences from the baseline's result to the candidate's, ordered by path.  Absent if the results are the same or weren't compared.
+	Differences []ResultDifference `json:"differences,omitempty"`
+	Id          openapi_types.UUID `json:"id"`
+	VideoPath   string             `json:"videoPath"`
 }
 
-func (c *Client) GetInfoStatus(ctx context.Context, uuid openapi_types.UUID, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewGetInfoStatusRequest(c.Server, uuid)
-	if err != nil {
-		return nil, err
-	}
-	req = req.WithContext(ctx)
-	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
-		return nil, err
-	}
-	return c.Client.Do(req)
+// ComparisonRequest defines model for ComparisonRequest.
+type ComparisonRequest struct {
+	// Baseline How one side of a comparison probes the file.  Properties mean what they do in InfoRequest.
+	Baseline ComparisonSide `json:"baseline"`
+
+	// Candidate How one side of a comparison probes the file.  Properties mean what they do in InfoRequest.
+	Candidate ComparisonSide `json:"candidate"`
+
+	// Labels Caller-defined key/value labels stored with the job and echoed in status responses and webhook payloads.  Keys must be 1-64 characters.
+	Labels *Labels `json:"labels,omitempty"`
+
+	// VideoPath Path or URL of the video to probe, as in InfoRequest
+	VideoPath string `json:"videoPath"`
 }
 
-// NewCreateInfoRequest calls the generic CreateInfo builder with application/json body
-func NewCreateInfoRequest(server string, body CreateInfoJSONRequestBody) (*http.Request, error) {
-	var bodyReader io.Reader
-	buf, err := json.Marshal(body)
-	if err != nil {
-		return nil, err
-	}
-	bodyReader = bytes.NewReader(buf)
-	return NewCreateInfoRequestWithBody(server, "application/json", bodyReader)
+// ComparisonSide How one side of a comparison probes the file.  Properties mean what they do in InfoRequest.
+type ComparisonSide struct {
+	AnalyzeCrop     *bool    `json:"analyzeCrop,omitempty"`
+	AnalyzeLoudness *bool    `json:"analyzeLoudness,omitempty"`
+	Analyzers       []string `json:"analyzers,omitempty"`
+
+	// Checksum Checksum to compute over the file's contents.  xxhash64 and sha256 read the whole file.  partial is an xxhash64 of the file's size and its first and last MiB, which is fast and good enough to spot likely duplicates but not to verify copies.
+	Checksum   *ChecksumAlgorithm `json:"checksum,omitempty"`
+	IncludeRaw *bool              `json:"includeRaw,omitempty"`
+
+	// Queue Queue an info job waits in.  Workers choose which queues they consume and how many jobs they run from each, so slow jobs can be kept from delaying quick ones.  Jobs that require a GPU always use the GPU queue.  The names gpu and maintenance are reserved.
+	Queue *Queue `json:"queue,omitempty"`
+
+	// Resources Resources an info job requires.  Jobs that require a GPU are only run by workers with GPU capacity.
+	Resources     *Resources `json:"resources,omitempty"`
+	SampleWindows *int       `json:"sampleWindows,omitempty"`
+	Verify        *bool      `json:"verify,omitempty"`
 }
 
-// NewCreateInfoRequestWithBody generates requests for CreateInfo with any type of body
-func NewCreateInfoRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
-	var err error
+// Container Container format of a file, absent for image sequences
+type Container struct {
+	// BitRate Overall bit rate in bits per second, if known
+	BitRate *int64 `json:"bitRate,omitempty"`
 
-	serverURL, err := url.Parse(server)
-	if err != nil {
-		return nil, err
-	}
+	// CreationTime Creation time tag of the file
+	CreationTime *time.Time `json:"creationTime,omitempty"`
 
-	operationPath := fmt.Sprintf("/info")
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
-	}
+	// Encoder Encoder tag of the file, usually naming the muxing application
+	Encoder *string `json:"encoder,omitempty"`
 
-	queryURL, err := serverURL.Parse(operationPath)
-	if err != nil {
-		return nil, err
-	}
+	// FormatLongName Descriptive name of the format
+	FormatLongName *string `json:"formatLongName,omitempty"`
 
-	req, err := http.NewRequest("POST", queryURL.String(), body)
-	if err != nil {
-		return nil, err
-	}
+	// FormatName Short names of the formats the container matches, comma separated
+	FormatName string `json:"formatName"`
 
-	req.Header.Add("Content-Type", contentType)
+	// SizeBytes Size of the file in bytes
+	SizeBytes int64 `json:"sizeBytes"`
 
-	return req, nil
+	// Title Title tag of the file
+	Title *string `json:"title,omitempty"`
 }
 
-// NewGetInfoStatusRequest generates requests for GetInfoStatus
-func NewGetInfoStatusRequest(server string, uuid openapi_types.UUID) (*http.Request, error) {
-	var err error
+// CropDetection Active picture area of the first video stream, in pixels of the decoded frame, and the black bars around it.  Found by sampling evenly spaced windows of the video rather than decoding all of it, unless the whole video was decoded for verification.
+type CropDetection struct {
+	BlackBars BlackBars `json:"blackBars"`
+	Height    int       `json:"height"`
 
-	var pathParam0 string
+	// SampledSeconds Combined length of the analyzed windows
+	SampledSeconds float64 `json:"sampledSeconds"`
 
-	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "uuid", runtime.ParamLocationPath, uuid)
-	if err != nil {
-		return nil, err
-	}
+	// Samples Number of windows of the video analyzed, counting the whole video as one window when it was decoded for verification
+	Samples int `json:"samples"`
+	Width   int `json:"width"`
 
-	serverURL, err := url.Parse(server)
-	if err != nil {
-		return nil, err
-	}
+	// X Offset of the active area from the left edge
+	X int `json:"x"`
 
-	operationPath := fmt.Sprintf("/info/%s", pathParam0)
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
-	}
+	// Y Offset of the active area from the top edge
+	Y int `json:"y"`
+}
 
-	queryURL, err := serverURL.Parse(operationPath)
-	if err != nil {
-		return nil, err
-	}
+// DecodeVerification Outcome of decoding every video and audio stream of the file in full, or in the windows given by the result's sampling, looking for corruption.
+type DecodeVerification struct {
+	// Complete False if the decoder gave up before the end of the file
+	Complete bool `json:"complete"`
 
-	req, err := http.NewRequest("GET", queryURL.String(), nil)
-	if err != nil {
-		return nil, err
-	}
+	// DecodedSeconds How far into the file decoding got, or when the file was sampled and decoding completed, the combined length of the windows
+	DecodedSeconds float64 `json:"decodedSeconds"`
 
-	return req, nil
+	// ErrorCount Number of errors logged by the decoder
+	ErrorCount int `json:"errorCount"`
+
+	// Excerpt The first errors logged by the decoder
+	Excerpt *string `json:"excerpt,omitempty"`
+
+	// FirstErrorSeconds Approximate position in the file of the first error.  Absent when the file was sampled.
+	FirstErrorSeconds *float64 `json:"firstErrorSeconds,omitempty"`
 }
 
-func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
-	for _, r := range c.RequestEditors {
-		if err := r(ctx, req); err != nil {
-			return err
-		}
-	}
-	for _, r := range additionalEditors {
-		if err := r(ctx, req); err != nil {
-			return err
-		}
-	}
-	return nil
-}
+// Edition defines model for Edition.
+type Edition struct {
+	// Chapters Top-level chapters of the edition, in order
+	Chapters []EditionChapter `json:"chapters"`
 
-// ClientWithResponses builds on ClientInterface to offer response payloads
-type ClientWithResponses struct {
-	ClientInterface
-}
+	// Default Whether this is the default edition
+	Default bool `json:"default"`
 
-// NewClientWithResponses creates a new ClientWithResponses, which wraps
-// Client with return type handling
-func NewClientWithResponses(server string, opts ...ClientOption) (*ClientWithResponses, error) {
-	client, err := NewClient(server, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return &ClientWithResponses{client}, nil
-}
+	// DurationSeconds Duration of the edition's virtual timeline if it is ordered, otherwise the duration of the file
+	DurationSeconds float64 `json:"durationSeconds"`
 
-// WithBaseURL overrides the baseURL.
-func WithBaseURL(baseURL string) ClientOption {
-	return func(c *Client) error {
-		newBaseURL, err := url.Parse(baseURL)
-		if err != nil {
-			return err
-		}
-		c.Server = newBaseURL.String()
-		return nil
-	}
+	// Hidden Whether players should hide the edition
+	Hidden bool `json:"hidden"`
+
+	// Ordered Whether the edition uses ordered chapters to define a virtual timeline
+	Ordered bool `json:"ordered"`
+
+	// Uid Edition UID, in hexadecimal
+	Uid string `json:"uid"`
 }
 
-// ClientWithResponsesInterface is the interface specification for the client with responses above.
-type ClientWithResponsesInterface interface {
-	// CreateInfoWithBodyWithResponse request with any body
-	CreateInfoWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateInfoResponse, error)
+// EditionChapter defines model for EditionChapter.
+type EditionChapter struct {
+	// Enabled Whether the chapter is played
+	Enabled bool `json:"enabled"`
 
-	CreateInfoWithResponse(ctx context.Context, body CreateInfoJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateInfoResponse, error)
+	// EndSeconds End of the chapter on its segment's timeline
+	EndSeconds float64 `json:"endSeconds"`
 
-	// GetInfoStatusWithResponse request
-	GetInfoStatusWithResponse(ctx context.Context, uuid openapi_types.UUID, reqEditors ...RequestEditorFn) (*GetInfoStatusResponse, error)
-}
+	// SegmentUid UID, in hexadecimal, of another segment the chapter is taken from. Absent for chapters of this file.
+	SegmentUid *string `json:"segmentUid,omitempty"`
 
-type CreateInfoResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSON201      *InfoJob
-	JSON400      *Error
-	JSON409      *Error
-	JSON500      *Error
+	// StartSeconds Start of the chapter on its segment's timeline
+	StartSeconds float64 `json:"startSeconds"`
+
+	// Title Chapter title
+	Title *string `json:"title,omitempty"`
 }
 
-// Status returns HTTPResponse.Status
-func (r CreateInfoResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
-	}
-	return http.StatusText(0)
+// Error defines model for Error.
+type Error struct {
+	// Code Error code
+	Code string `json:"code"`
+
+	// Message Human-readable error message
+	Message string `json:"message"`
 }
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r CreateInfoResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
-	}
-	return 0
+// FailureGroup defines model for FailureGroup.
+type FailureGroup struct {
+	// CommonPathPrefix Longest directory prefix shared by every video path in the group
+	CommonPathPrefix string `json:"commonPathPrefix"`
+
+	// Count Number of failed jobs in the group
+	Count int `json:"count"`
+
+	// ErrorCode Error code shared by the group, or OTHER for errors without one
+	ErrorCode string `json:"errorCode"`
+
+	// ExamplePaths Video paths of a few jobs in the group
+	ExamplePaths []string `json:"examplePaths"`
+
+	// Fingerprint Stable identifier of the group's error code and message
+	Fingerprint string `json:"fingerprint"`
+
+	// Message Error message of the group, with variable parts replaced by placeholders
+	Message string `json:"message"`
 }
 
-type GetInfoStatusResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSON200      *InfoJob
-	JSON404      *Error
-	JSON500      *Error
+// FailureSummary defines model for FailureSummary.
+type FailureSummary struct {
+	// Groups Groups of similar failures, largest first
+	Groups []FailureGroup `json:"groups"`
+
+	// TotalFailures Number of failed jobs considered
+	TotalFailures int `json:"totalFailures"`
 }
 
-// Status returns HTTPResponse.Status
-func (r GetInfoStatusResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
-	}
-	return http.StatusText(0)
+// FileChecksum defines model for FileChecksum.
+type FileChecksum struct {
+	// Algorithm Checksum to compute over the file's contents.  xxhash64 and sha256 read the whole file.  partial is an xxhash64 of the file's size and its first and last MiB, which is fast and good enough to spot likely duplicates but not to verify copies.
+	Algorithm ChecksumAlgorithm `json:"algorithm"`
+
+	// Value The checksum in lowercase hexadecimal
+	Value string `json:"value"`
 }
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r GetInfoStatusResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
-	}
-	return 0
+// HealthStatus defines model for HealthStatus.
+type HealthStatus struct {
+	// Status Always ok
+	Status string `json:"status"`
 }
 
-// CreateInfoWithBodyWithResponse request with arbitrary body returning *CreateInfoResponse
-func (c *ClientWithResponses) CreateInfoWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateInfoResponse, error) {
-	rsp, err := c.CreateInfoWithBody(ctx, contentType, body, reqEditors...)
-	if err != nil {
-		return nil, err
-	}
-	return ParseCreateInfoResponse(rsp)
+// InfoBatchItemResult defines model for InfoBatchItemResult.
+type InfoBatchItemResult struct {
+	Error *Error   `json:"error,omitempty"`
+	Job   *InfoJob `json:"job,omitempty"`
+
+	// Status HTTP status the item would have received from POST /info: 201 if the job was created, or 409 if its UUID or external ID is taken
+	Status int `json:"status"`
+
+	// Uuid UUID of the requested item
+	Uuid openapi_types.UUID `json:"uuid"`
 }
 
-func (c *ClientWithResponses) CreateInfoWithResponse(ctx context.Context, body CreateInfoJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateInfoResponse, error) {
-	rsp, err := c.CreateInfo(ctx, body, reqEditors...)
-	if err != nil {
-		return nil, err
-	}
-	return ParseCreateInfoResponse(rsp)
+// InfoBatchRequest defines model for InfoBatchRequest.
+type InfoBatchRequest struct {
+	// Items Info jobs to create
+	Items []InfoRequest `json:"items"`
 }
 
-// GetInfoStatusWithResponse request returning *GetInfoStatusResponse
-func (c *ClientWithResponses) GetInfoStatusWithResponse(ctx context.Context, uuid openapi_types.UUID, reqEditors ...RequestEditorFn) (*GetInfoStatusResponse, error) {
-	rsp, err := c.GetInfoStatus(ctx, uuid, reqEditors...)
-	if err != nil {
-		return nil, err
-	}
-	return ParseGetInfoStatusResponse(rsp)
+// InfoBatchResult defines model for InfoBatchResult.
+type InfoBatchResult struct {
+	// Items Outcome of each requested item, in request order
+	Items []InfoBatchItemResult `json:"items"`
 }
 
-// ParseCreateInfoResponse parses an HTTP response from a CreateInfoWithResponse call
-func ParseCreateInfoResponse(rsp *http.Response) (*CreateInfoResponse, error) {
-	bodyBytes, err := io.ReadAll(rsp.Body)
-	defer func() { _ = rsp.Body.Close() }()
-	if err != nil {
-		return nil, err
-	}
+// InfoJob defines model for InfoJob.
+type InfoJob struct {
+	Annotations *Annotations `json:"annotations,omitempty"`
 
-	response := &CreateInfoResponse{
-		Body:         bodyBytes,
-		HTTPResponse: rsp,
-	}
+	// CreatedAt Timestamp when the job was created
+	CreatedAt time.Time `json:"createdAt"`
 
-	switch {
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
-		var dest InfoJob
-		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
-			return nil, err
-		}
-		response.JSON201 = &dest
+	// Error Error message if the info extraction failed
+	Error *string `json:"error,omitempty"`
 
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
-		var dest Error
-		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
-			return nil, err
-		}
-		response.JSON400 = &dest
+	// ErrorCode Machine-readable code for the error, if it has one. UNSUPPORTED_FORMAT means the file is not a video (or audio, if the worker probes audio files) and was not probed.  FILE_TOO_LARGE means the file is over the worker's size limit.  NOT_FOUND and PERMISSION_DENIED mean the worker could not access the file. TIMEOUT means the job ran past its timeout.  PATH_NOT_ALLOWED means the path is outside the worker's allowed roots. INVALID_OUTPUT means the worker's result did not match the MediaInfo schema and was discarded.
+	ErrorCode *string `json:"errorCode,omitempty"`
 
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
-		var dest Error
-		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
-			return nil, err
-		}
-		response.JSON409 = &dest
+	// EstimatedCompletionAt When a pending job is expected to finish: its estimated start plus the average time recent jobs in its queue took to run.
+	EstimatedCompletionAt *time.Time `json:"estimatedCompletionAt,omitempty"`
 
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
-		var dest Error
-		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
-			return nil, err
-		}
-		response.JSON500 = &dest
+	// EstimatedStartAt When a pending job is expected to start, from its queue position and the rate at which the queue's jobs finished over the last 15 minutes.  Only set along with queuePosition, and omitted when no jobs finished recently.
+	EstimatedStartAt *time.Time `json:"estimatedStartAt,omitempty"`
 
-	}
+	// ExternalId Caller-supplied identifier for the info job, if one was provided
+	ExternalId *string `json:"externalId,omitempty"`
 
-	return response, nil
-}
+	// Labels Caller-defined key/value labels stored with the job and echoed in status responses and webhook payloads.  Keys must be 1-64 characters.
+	Labels *Labels `json:"labels,omitempty"`
 
-// ParseGetInfoStatusResponse parses an HTTP response from a GetInfoStatusWithResponse call
-func ParseGetInfoStatusResponse(rsp *http.Response) (*GetInfoStatusResponse, error) {
-	bodyBytes, err := io.ReadAll(rsp.Body)
-	defer func() { _ = rsp.Body.Close() }()
-	if err != nil {
-		return nil, err
-	}
+	// Priority Current priority of the job, from 1 (highest) to 4 (lowest), including any raise for having waited
+	Priority int `json:"priority"`
 
-	response := &GetInfoStatusResponse{
-		Body:         bodyBytes,
-		HTTPResponse: rsp,
-	}
+	// Queue Queue an info job waits in.  Workers choose which queues they consume and how many jobs they run from each, so slow jobs can be kept from delaying quick ones.  Jobs that require a GPU always use the GPU queue.  The names gpu and maintenance are reserved.
+	Queue Queue `json:"queue"`
 
-	switch {
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
-		var dest InfoJob
-		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
-			return nil, err
-		}
-		response.JSON200 = &dest
+	// QueuePosition Position of a pending job in its queue, where 1 is the next job to run.  Jobs run in order of priority, then of when they were scheduled, so the position grows when higher priority jobs arrive.  Only set on pending jobs fetched by UUID or external ID.
+	QueuePosition *int `json:"queuePosition,omitempty"`
 
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
-		var dest Error
-		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
-			return nil, err
-		}
-		response.JSON404 = &dest
+	// Resources Resources an info job requires.  Jobs that require a GPU are only run by workers with GPU capacity.
+	Resources Resources  `json:"resources"`
+	Result    *MediaInfo `json:"result,omitempty"`
 
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
-		var dest Error
-		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
-			return nil, err
-		}
-		response.JSON500 = &dest
+	// SignedResult A signed copy of the job outcome.  The payload is the JSON-encoded uuid, videoPath, result, error and signedAt of the job, and the signature is the Ed25519 signature of the payload bytes.
+	SignedResult *SignedPayload `json:"signedResult,omitempty"`
 
-	}
+	// Status Current status of the info extraction job
+	Status InfoStatus `json:"status"`
 
-	return response, nil
-}
+	// Supersedes UUID of the earlier job whose result this job's replaces, if it was created by a reprobe
+	Supersedes *openapi_types.UUID `json:"supersedes,omitempty"`
 
-// ServerInterface represents all server handlers.
-type ServerInterface interface {
-	// Start a new video info extraction job
-	// (POST /info)
-	CreateInfo(w http.ResponseWriter, r *http.Request)
-	// Get video info job status
-	// (GET /info/{uuid})
-	GetInfoStatus(w http.ResponseWriter, r *http.Request, uuid openapi_types.UUID)
+	// Timings How long each phase of the job took, in the order they ran
+	Timings []PhaseTiming `json:"timings,omitempty"`
+
+	// UpdatedAt Timestamp when the job was last updated
+	UpdatedAt time.Time `json:"updatedAt"`
+
+	// Uuid Unique identifier for the info job
+	Uuid openapi_types.UUID `json:"uuid"`
+
+	// VideoPath Path to the video file being inspected
+	VideoPath string `json:"videoPath"`
 }
 
-// ServerInterfaceWrapper converts contexts to parameters.
-type ServerInterfaceWrapper struct {
-	Handler            ServerInterface
-	HandlerMiddlewares []MiddlewareFunc
-	ErrorHandlerFunc   func(w http.ResponseWriter, r *http.Request, err error)
+// InfoJobHistory defines model for InfoJobHistory.
+type InfoJobHistory struct {
+	// Items The requested job, then each job it supersedes in turn.  At most 100 jobs are listed.
+	Items []InfoJob `json:"items"`
 }
 
-type MiddlewareFunc func(http.Handler) http.Handler
+// InfoJobList defines model for InfoJobList.
+type InfoJobList struct {
+	// Items Info jobs on this page, newest first
+	Items []InfoJob `json:"items"`
 
-// CreateInfo operation middleware
-func (siw *ServerInterfaceWrapper) CreateInfo(w http.ResponseWriter, r *http.Request) {
+	// NextPageToken Token to pass as pageToken to fetch the next page.  Absent on the last page.
+	NextPageToken *string `json:"nextPageToken,omitempty"`
+}
 
-	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.CreateInfo(w, r)
-	}))
+// InfoRequest defines model for InfoRequest.
+type InfoRequest struct {
+	// AnalyzeCrop Detect the active picture area and black bars by sampling the video with ffmpeg's cropdetect filter.  When verify is also requested, frames from across the whole video are used instead, since it is decoded in full anyway.  Defaults to false.
+	AnalyzeCrop *bool `json:"analyzeCrop,omitempty"`
 
-	for _, middleware := range siw.HandlerMiddlewares {
-		handler = middleware(handler)
-	}
+	// AnalyzeLoudness Measure the EBU R128 loudness of every audio track with ffmpeg's loudnorm filter.  This decodes all of the audio.  Defaults to false.
+	AnalyzeLoudness *bool `json:"analyzeLoudness,omitempty"`
 
-	handler.ServeHTTP(w, r)
-}
+	// Analyzers Names of analyzer plugins to run, as configured on the workers. Each contributes its output to the result's extensions under its name.  Plugins a worker doesn't have are skipped with a warning.
+	Analyzers []string `json:"analyzers,omitempty"`
 
-// GetInfoStatus operation middleware
-func (siw *ServerInterfaceWrapper) GetInfoStatus(w http.ResponseWriter, r *http.Request) {
+	// Checksum Checksum to compute over the file's contents.  xxhash64 and sha256 read the whole file.  partial is an xxhash64 of the file's size and its first and last MiB, which is fast and good enough to spot likely duplicates but not to verify copies.
+	Checksum *ChecksumAlgorithm `json:"checksum,omitempty"`
 
-	var err error
+	// ExternalId Optional caller-supplied identifier, unique across jobs, that can be used to look the job up
+	ExternalId *string `json:"externalId,omitempty"`
 
-	// ------------- Path parameter "uuid" -------------
-	var uuid openapi_types.UUID
+	// Force Probe the file even if a result is cached for it.  Workers with database access cache the results of files, keyed by path, size, modification time and requested analyses, and return the cached result without probing while the file is unchanged.  Results of URLs and image sequences, and results with warnings, are never cached.  Defaults to false.
+	Force *bool `json:"force,omitempty"`
 
-	err = runtime.BindStyledParameterWithOptions("simple", "uuid", r.PathValue("uuid"), &uuid, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
-	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "uuid", Err: err})
-		return
-	}
+	// IncludeRaw Include the complete ffprobe output the result was built from as the result's rawProbe, for fields not otherwise reported. Defaults to false.
+	IncludeRaw *bool `json:"includeRaw,omitempty"`
 
-	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetInfoStatus(w, r, uuid)
-	}))
+	// Labels Caller-defined key/value labels stored with the job and echoed in status responses and webhook payloads.  Keys must be 1-64 characters.
+	Labels *Labels `json:"labels,omitempty"`
 
-	for _, middleware := range siw.HandlerMiddlewares {
-		handler = middleware(handler)
-	}
+	// Priority Priority of the job, from 1 (highest, the default) to 4 (lowest). Workers may raise the priority of jobs that have waited long enough, so low priority jobs still run under constant load.
+	Priority *int `json:"priority,omitempty"`
 
-	handler.ServeHTTP(w, r)
-}
+	// Queue Queue an info job waits in.  Workers choose which queues they consume and how many jobs they run from each, so slow jobs can be kept from delaying quick ones.  Jobs that require a GPU always use the GPU queue.  The names gpu and maintenance are reserved.
+	Queue *Queue `json:"queue,omitempty"`
 
-type UnescapedCookieParamError struct {
-	ParamName string
-	Err       error
-}
+	// Resources Resources an info job requires.  Jobs that require a GPU are only run by workers with GPU capacity.
+	Resources *Resources `json:"resources,omitempty"`
 
-func (e *UnescapedCookieParamError) Error() string {
-	return fmt.Sprintf("error unescaping cookie parameter '%s'", e.ParamName)
-}
+	// SampleWindows Run loudness analysis and decode verification on this many evenly spaced 30 second windows of the file instead of all of it, for files too long to decode routinely.  The result's sampling reports how much of the file was covered.  Files no longer than the windows are analyzed in full.  Defaults to analyzing the whole file.
+	SampleWindows *int `json:"sampleWindows,omitempty"`
 
-func (e *UnescapedCookieParamError) Unwrap() error {
-	return e.Err
+	// TimeoutSeconds How long the job may run before it fails with the TIMEOUT error code.  Defaults to the server's default timeout, if it has one, and may not exceed the server's maximum.
+	TimeoutSeconds *int `json:"timeoutSeconds,omitempty"`
+
+	// Uuid Client-provided UUID for the info job
+	Uuid openapi_types.UUID `json:"uuid"`
+
+	// Verify Decode every video and audio stream in full and report decoder errors, to detect corrupted files.  This is as slow as decoding the whole file.  Defaults to false.
+	Verify *bool `json:"verify,omitempty"`
+
+	// VideoPath Path to the video file to inspect.  If the server restricts video
+	// paths to allowed roots, it must be an absolute path under one of
+	// them, or the request fails with INVALID_VIDEO_PATH.  An http,
+	// https, sftp or smb URL, such as smb://nas/share/movie.mkv, may be
+	// given instead if the server allows its scheme.  ffprobe reads only
+	// the byte ranges of the file it needs, but if analyses that read the
+	// media itself are requested, the file is first copied to the worker
+	// and probed like a local file.  Credentials are configured on the
+	// workers, and no URL may include a user or password.
+	// The hosts of URLs must pass the server's webhook URL policy.
+	VideoPath string `json:"videoPath"`
+
+	// WebhookBatch Delivers the webhook together with those of other jobs with the same webhookUri and webhookToken that also set webhookBatch.  Each POST carries a WebhookBatch of up to maxSize notifications, and a webhook waits up to maxWaitSeconds after its job finishes for others to join it.  Each of webhooks is batched with the webhooks of other jobs to the same target in the same way.  Requires webhookUri or webhooks.
+	WebhookBatch *WebhookBatchOptions `json:"webhookBatch,omitempty"`
+
+	// WebhookEvents Statuses to send the webhook for.  Defaults to every status a job may finish with.  Include started to be told when a worker first picks the job up; retries don't send it again.  Requires webhookUri or webhooks.
+	WebhookEvents []WebhookEvent `json:"webhookEvents,omitempty"`
+
+	// WebhookHeaders Optional HTTP headers to send with the webhook, such as an Authorization header for a receiver behind an auth gateway. Headers that describe the request itself, such as Content-Type, can't be set.  Like the token, they are never returned, and are encrypted at rest if the server has secrets keys.  Requires webhookUri.
+	WebhookHeaders map[string]string `json:"webhookHeaders,omitempty"`
+
+	// WebhookRetry Overrides how the webhook is retried.  Unset fields fall back to the worker's configuration.
+	WebhookRetry *WebhookRetryPolicy `json:"webhookRetry,omitempty"`
+
+	// WebhookToken Optional base64-encoded token to include in webhook POST body
+	WebhookToken []byte `json:"webhookToken,omitempty"`
+
+	// WebhookUri Optional URI to POST results to when the job finishes, including when it is cancelled or expires
+	WebhookUri *string `json:"webhookUri,omitempty"`
+
+	// Webhooks Further receivers of the webhook, for when several services need the callback.  Each target, including webhookUri if set, is delivered independently, so one failing receiver doesn't hold up the others.  webhookRetry and webhookBatch apply to every target.
+	Webhooks []WebhookReceiver `json:"webhooks,omitempty"`
 }
 
-type UnmarshalingParamError struct {
-	ParamName string
-	Err       error
+// InfoStatus Current status of the info extraction job
+type InfoStatus string
+
+// Labels Caller-defined key/value labels stored with the job and echoed in status responses and webhook payloads.  Keys must be 1-64 characters.
+type Labels map[string]string
+
+// LinkedSegment defines model for LinkedSegment.
+type LinkedSegment struct {
+	// File Name of the file holding the segment, if present
+	File *string `json:"file,omitempty"`
+
+	// Present Whether a file holding the segment is in the same directory as the probed file
+	Present bool `json:"present"`
+
+	// Uid Segment UID, in hexadecimal
+	Uid string `json:"uid"`
 }
 
-func (e *UnmarshalingParamError) Error() string {
-	return fmt.Sprintf("Error unmarshaling parameter %s as JSON: %s", e.ParamName, e.Err.Error())
+// MediaInfo defines model for MediaInfo.
+type MediaInfo struct {
+	// AudioTracks Audio streams in the file
+	AudioTracks []AudioTrack `json:"audioTracks,omitempty"`
+
+	// ChapterDurationsSeconds Duration of each chapter in seconds.  Kept for compatibility; chapters carries the same information and more.
+	ChapterDurationsSeconds []float64 `json:"chapterDurationsSeconds"`
+
+	// Chapters Chapters of the file, in order
+	Chapters []Chapter     `json:"chapters,omitempty"`
+	Checksum *FileChecksum `json:"checksum,omitempty"`
+
+	// Container Container format of a file, absent for image sequences
+	Container *Container `json:"container,omitempty"`
+
+	// Crop Active picture area of the first video stream, in pixels of the decoded frame, and the black bars around it.  Found by sampling evenly spaced windows of the video rather than decoding all of it, unless the whole video was decoded for verification.
+	Crop *CropDetection `json:"crop,omitempty"`
+
+	// Editions Matroska chapter editions, in file order
+	Editions []Edition `json:"editions,omitempty"`
+
+	// Extensions Output of the selected analyzer plugins, keyed by plugin name
+	Extensions map[string]interface{} `json:"extensions,omitempty"`
+
+	// FrameCount Number of images in an image sequence
+	FrameCount *int `json:"frameCount,omitempty"`
+
+	// LinkedSegments Other Matroska segments the file refers to, as parts of a split file or as sources of ordered chapters.  Players fail to play the file properly when any of them is missing.
+	LinkedSegments []LinkedSegment `json:"linkedSegments,omitempty"`
+
+	// MediaKind Kind of media that was probed.  Audio files have no video streams. Image sequences are directories of numbered images, described by the first image and frameCount.
+	MediaKind MediaKind `json:"mediaKind"`
+
+	// RawProbe Complete ffprobe JSON output the result was built from, with its format, chapters and streams.  Only present when includeRaw was requested.  For image sequences this describes the first frame.
+	RawProbe map[string]interface{} `json:"rawProbe,omitempty"`
+
+	// Sampling How much of the file loudness analysis and decode verification covered when they sampled windows of it.  Absent if they decoded the whole file.
+	Sampling *SamplingCoverage `json:"sampling,omitempty"`
+
+	// SubtitleTracks Subtitle streams in the file
+	SubtitleTracks []SubtitleTrack `json:"subtitleTracks,omitempty"`
+
+	// SuggestedPreset Encoding preset suggested for the file by the worker's preset rules.  Absent if the worker has no rules or none matched.
+	SuggestedPreset *string `json:"suggestedPreset,omitempty"`
+
+	// TimelineDurationSeconds Duration of the virtual timeline of the default edition, when it uses ordered chapters.  This is what a player shows, and may differ from totalDurationSeconds, the duration of the file itself.
+	TimelineDurationSeconds *float64 `json:"timelineDurationSeconds,omitempty"`
+
+	// TotalDurationSeconds Total duration of the media in seconds.  Zero for image sequences.
+	TotalDurationSeconds float64 `json:"totalDurationSeconds"`
+
+	// Verification Outcome of decoding every video and audio stream of the file in full, or in the windows given by the result's sampling, looking for corruption.
+	Verification *DecodeVerification `json:"verification,omitempty"`
+
+	// Versions Versions of the worker and the tools it ran when it probed the file. Results reused from a worker's cache keep the versions that first produced them.  Absent for results recorded before versions were.
+	Versions *ToolVersions `json:"versions,omitempty"`
+
+	// VideoStreams Video streams in the file, excluding attached pictures such as cover art
+	VideoStreams []VideoStream `json:"videoStreams,omitempty"`
+
+	// Warnings Requested analyses that were skipped or failed without failing the job
+	Warnings []string `json:"warnings,omitempty"`
 }
 
-func (e *UnmarshalingParamError) Unwrap() error {
-	return e.Err
+// MediaKind Kind of media that was probed.  Audio files have no video streams. Image sequences are directories of numbered images, described by the first image and frameCount.
+type MediaKind string
+
+// PhaseTiming defines model for PhaseTiming.
+type PhaseTiming struct {
+	// DurationSeconds How long the phase took, in seconds
+	DurationSeconds float64 `json:"durationSeconds"`
+
+	// Phase Name of the phase
+	Phase string `json:"phase"`
 }
 
-type RequiredParamError struct {
-	ParamName string
+// PurgeRequest defines model for PurgeRequest.
+type PurgeRequest struct {
+	// Prefix Treat videoPath as a prefix and purge every path that starts with it
+	Prefix *bool `json:"prefix,omitempty"`
+
+	// VideoPath Video path, or path prefix if prefix is true, whose data should be purged
+	VideoPath string `json:"videoPath"`
 }
 
-func (e *RequiredParamError) Error() string {
-	return fmt.Sprintf("Query argument %s is required, but not found", e.ParamName)
+// PurgeResult defines model for PurgeResult.
+type PurgeResult struct {
+	// PurgedJobs Number of info jobs deleted, including jobs of which only the stored result was left
+	PurgedJobs int `json:"purgedJobs"`
+
+	// PurgedWebhookJobs Number of webhook delivery jobs deleted
+	PurgedWebhookJobs int `json:"purgedWebhookJobs"`
+
+	// SkippedRunningJobs Number of matching info jobs left in place because they are currently running
+	SkippedRunningJobs int `json:"skippedRunningJobs"`
 }
 
-type RequiredHeaderError struct {
-	ParamName string
-	Err       error
+// Queue Queue an info job waits in.  Workers choose which queues they consume and how many jobs they run from each, so slow jobs can be kept from delaying quick ones.  Jobs that require a GPU always use the GPU queue.  The names gpu and maintenance are reserved.
+type Queue = string
+
+// ReprobeCampaign defines model for ReprobeCampaign.
+type ReprobeCampaign struct {
+	CreatedAt time.Time `json:"createdAt"`
+
+	// Enqueued Number of reprobes enqueued so far
+	Enqueued int `json:"enqueued"`
+
+	// FfprobeVersion Version of ffprobe whose results are left alone
+	FfprobeVersion string `json:"ffprobeVersion"`
+
+	// Fields MediaInfo properties a result must have one of to be reprobed
+	Fields []string `json:"fields,omitempty"`
+
+	// FinishedAt When every matching result had been reprobed.  Absent while the campaign runs.
+	FinishedAt *time.Time         `json:"finishedAt,omitempty"`
+	Id         openapi_types.UUID `json:"id"`
+
+	// PathPrefix Prefix of the video paths reprobed
+	PathPrefix *string `json:"pathPrefix,omitempty"`
+
+	// PerMinute Most reprobes enqueued per minute
+	PerMinute int `json:"perMinute"`
 }
 
-func (e *RequiredHeaderError) Error() string {
-	return fmt.Sprintf("Header parameter %s is required, but not found", e.ParamName)
+// ReprobeCampaignList defines model for ReprobeCampaignList.
+type ReprobeCampaignList struct {
+	Campaigns []ReprobeCampaign `json:"campaigns"`
 }
 
-func (e *RequiredHeaderError) Unwrap() error {
-	return e.Err
+// ReprobeCampaignRequest defines model for ReprobeCampaignRequest.
+type ReprobeCampaignRequest struct {
+	// FfprobeVersion Version of ffprobe that workers now run, as reported in result versions.  Results produced by any other version are reprobed.
+	FfprobeVersion string `json:"ffprobeVersion"`
+
+	// Fields MediaInfo properties the upgrade affects, such as subtitleTracks.  If set, only results that have at least one of them are reprobed.
+	Fields []string `json:"fields,omitempty"`
+
+	// PathPrefix Only reprobe results for video paths starting with this prefix
+	PathPrefix *string `json:"pathPrefix,omitempty"`
+
+	// PerMinute Most reprobes to enqueue per minute
+	PerMinute *int `json:"perMinute,omitempty"`
 }
 
-type InvalidParamFormatError struct {
-	ParamName string
-	Err       error
+// ReprobeRequest defines model for ReprobeRequest.
+type ReprobeRequest struct {
+	// Uuid Client-provided UUID for the new info job
+	Uuid openapi_types.UUID `json:"uuid"`
 }
 
-func (e *InvalidParamFormatError) Error() string {
-	return fmt.Sprintf("Invalid format for parameter %s: %s", e.ParamName, e.Err.Error())
+// Resources Resources an info job requires.  Jobs that require a GPU are only run by workers with GPU capacity.
+type Resources string
+
+// ResultDiff defines model for ResultDiff.
+type ResultDiff struct {
+	// Differences Differences between the results, ordered by path.  Empty if they are the same.
+	Differences []ResultDifference `json:"differences"`
+
+	// From UUID of the info job with the earlier result
+	From openapi_types.UUID `json:"from"`
+
+	// To UUID of the info job with the later result
+	To openapi_types.UUID `json:"to"`
 }
 
-func (e *InvalidParamFormatError) Unwrap() error {
-	return e.Err
+// ResultDifference defines model for ResultDifference.
+type ResultDifference struct {
+	// Change added if the value is only in the later result, removed if it is only in the earlier one, and changed if it differs
+	Change string `json:"change"`
+
+	// From The value in the earlier result, unless it was added
+	From interface{} `json:"from,omitempty"`
+
+	// Path Location of the difference in MediaInfo, with list positions counted from zero
+	Path string `json:"path"`
+
+	// To The value in the later result, unless it was removed
+	To interface{} `json:"to,omitempty"`
 }
 
-type TooManyValuesForParamError struct {
-	ParamName string
-	Count     int
+// RetryFailuresRequest defines model for RetryFailuresRequest.
+type RetryFailuresRequest struct {
+	// ErrorCode Only retry failures with this error code, or OTHER for failures without one
+	ErrorCode *string `json:"errorCode,omitempty"`
+
+	// Fingerprint Only retry failures in the group with this fingerprint, as reported by GET /admin/failures
+	Fingerprint *string `json:"fingerprint,omitempty"`
+
+	// PathPrefix Only retry failures whose video path starts with this prefix
+	PathPrefix *string `json:"pathPrefix,omitempty"`
 }
 
-func (e *TooManyValuesForParamError) Error() string {
-	return fmt.Sprintf("Expected one value for %s, got %d", e.ParamName, e.Count)
+// RetryFailuresResult defines model for RetryFailuresResult.
+type RetryFailuresResult struct {
+	// RetriedJobs Number of jobs requeued
+	RetriedJobs int `json:"retriedJobs"`
 }
 
-// Handler creates http.Handler with routing matching OpenAPI spec.
-func Handler(si ServerInterface) http.Handler {
-	return HandlerWithOptions(si, StdHTTPServerOptions{})
+// SamplingCoverage How much of the file loudness analysis and decode verification covered when they sampled windows of it.  Absent if they decoded the whole file.
+type SamplingCoverage struct {
+	// Fraction Fraction of the file's duration the windows cover
+	Fraction float64 `json:"fraction"`
+
+	// SampledSeconds Combined length of the windows
+	SampledSeconds float64 `json:"sampledSeconds"`
+
+	// Windows Number of windows decoded
+	Windows int `json:"windows"`
 }
 
-// ServeMux is an abstraction of http.ServeMux.
-type ServeMux interface {
-	HandleFunc(pattern string, handler func(http.ResponseWriter, *http.Request))
-	ServeHTTP(w http.ResponseWriter, r *http.Request)
+// ScanPreview defines model for ScanPreview.
+type ScanPreview struct {
+	// Directory Directory that is scanned
+	Directory string `json:"directory"`
+
+	// Error Why the dry run failed, if it did
+	Error *string `json:"error,omitempty"`
+
+	// Finished Whether the dry run has finished
+	Finished bool `json:"finished"`
+
+	// Found Number of video files found under the directory
+	Found *int `json:"found,omitempty"`
+
+	// Id Server-assigned ID of the preview
+	Id int64 `json:"id"`
+
+	// Sample Up to 100 of the files found: the first, in path order, that the scan would enqueue, followed by the first it would skip.  Each kind gets at least half of the sample if it has that many files.
+	Sample []ScanPreviewFile `json:"sample,omitempty"`
+
+	// Skipped Number of files the scan would skip, by reason: pending_job if an info job for the file is waiting or running, too_new if it was modified more recently than minAgeSeconds ago, cached if its cached result is current, and unchanged if an incremental scan found it as the schedule's catalog recorded it
+	Skipped map[string]int `json:"skipped,omitempty"`
+
+	// WouldEnqueue Number of files the scan would enqueue an info job for
+	WouldEnqueue *int `json:"wouldEnqueue,omitempty"`
 }
 
-type StdHTTPServerOptions struct {
-	BaseURL          string
-	BaseRouter       ServeMux
-	Middlewares      []MiddlewareFunc
-	ErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
+// ScanPreviewFile defines model for ScanPreviewFile.
+type ScanPreviewFile struct {
+	Path string `json:"path"`
+
+	// Size Size of the file in bytes
+	Size int64 `json:"size"`
+
+	// SkipReason Why the scan would skip the file, or absent if it would enqueue it
+	SkipReason *string `json:"skipReason,omitempty"`
 }
 
-// HandlerFromMux creates http.Handler with routing matching OpenAPI spec based on the provided mux.
-func HandlerFromMux(si ServerInterface, m ServeMux) http.Handler {
-	return HandlerWithOptions(si, StdHTTPServerOptions{
-		BaseRouter: m,
-	})
+// ScanProgress Progress of the last scan of a schedule, absent if it hasn't been scanned or the scan's job has been cleaned up.  Scans record their progress with each batch of info jobs they enqueue, and a scan retried after failing resumes where it left off.
+type ScanProgress struct {
+	// Enqueued Number of info jobs enqueued so far
+	Enqueued int `json:"enqueued"`
+
+	// Error Why the scan last failed, if it has failed and not since finished
+	Error *string `json:"error,omitempty"`
+
+	// Finished Whether the scan has finished
+	Finished bool `json:"finished"`
+
+	// FollowUpAt When the follow-up scan for the files skipped as too new is due, if the scan has scheduled one
+	FollowUpAt *time.Time `json:"followUpAt,omitempty"`
+
+	// Found Number of video files found so far
+	Found int `json:"found"`
+
+	// Skipped Number of files skipped so far, by reason, as in ScanPreview
+	Skipped map[string]int `json:"skipped,omitempty"`
+}
+
+// Schedule defines model for Schedule.
+type Schedule struct {
+	// CreatedAt Timestamp when the schedule was created
+	CreatedAt time.Time `json:"createdAt"`
+
+	// Cron When the directory is scanned
+	Cron string `json:"cron"`
+
+	// Directory Directory that is scanned
+	Directory string `json:"directory"`
+
+	// Exclude Glob patterns of files and directories that scans skip
+	Exclude []string `json:"exclude,omitempty"`
+
+	// Force Whether scans probe files even if a result is cached for them
+	Force bool `json:"force"`
+
+	// Id Server-assigned ID of the schedule
+	Id openapi_types.UUID `json:"id"`
+
+	// Incremental Whether scans skip files unchanged since the schedule's catalog recorded them
+	Incremental bool `json:"incremental"`
+
+	// Labels Caller-defined key/value labels stored with the job and echoed in status responses and webhook payloads.  Keys must be 1-64 characters.
+	Labels *Labels `json:"labels,omitempty"`
+
+	// LastRunAt When the last scan was started, if one has been
+	LastRunAt *time.Time `json:"lastRunAt,omitempty"`
+
+	// LastScan Progress of the last scan of a schedule, absent if it hasn't been scanned or the scan's job has been cleaned up.  Scans record their progress with each batch of info jobs they enqueue, and a scan retried after failing resumes where it left off.
+	LastScan *ScanProgress `json:"lastScan,omitempty"`
+
+	// MinAgeSeconds How long ago files must have last been modified for scans to enqueue them, if at all
+	MinAgeSeconds *int `json:"minAgeSeconds,omitempty"`
+
+	// NextRunAt When the next scan is due
+	NextRunAt time.Time `json:"nextRunAt"`
+
+	// Priority Priority of each info job
+	Priority int `json:"priority"`
+
+	// Queue Queue an info job waits in.  Workers choose which queues they consume and how many jobs they run from each, so slow jobs can be kept from delaying quick ones.  Jobs that require a GPU always use the GPU queue.  The names gpu and maintenance are reserved.
+	Queue *Queue `json:"queue,omitempty"`
+
+	// TimeoutSeconds How long each info job may run, if bounded
+	TimeoutSeconds *int `json:"timeoutSeconds,omitempty"`
+}
+
+// ScheduleList defines model for ScheduleList.
+type ScheduleList struct {
+	Schedules []Schedule `json:"schedules"`
+}
+
+// ScheduleRequest defines model for ScheduleRequest.
+type ScheduleRequest struct {
+	// Cron When to scan, as a standard five field cron expression or a descriptor such as @daily.  Times are in UTC unless the expression starts with CRON_TZ=<zone>.
+	Cron string `json:"cron"`
+
+	// Directory Absolute path of the directory to scan.  If the server restricts video paths to allowed roots, it must be under one of them. Hidden files and directories are skipped.
+	Directory string `json:"directory"`
+
+	// Exclude Glob patterns of files and directories for scans to skip, as understood by Go's path.Match.  A pattern without a slash matches names at any depth, and one with a slash matches paths relative to the directory.  A pattern ending in a slash matches only directories, whose contents are skipped with them.  A .viignore file in any scanned directory lists further patterns, one per line, that apply to that directory in the same way; blank lines and lines starting with # are ignored.
+	Exclude []string `json:"exclude,omitempty"`
+
+	// Force Enqueue every file found and probe it even if a result is cached for it.  Otherwise scans skip files whose cached result is current.  Defaults to false.
+	Force *bool `json:"force,omitempty"`
+
+	// Incremental Skip files whose path, size and modification time are as the schedule's catalog last recorded them, rather than rescanning every file.  Scans record the files they enqueue, or find a current cached result for, in the catalog; files whose info job failed or was cancelled are enqueued again by the next scan. Defaults to false.
+	Incremental *bool `json:"incremental,omitempty"`
+
+	// Labels Caller-defined key/value labels stored with the job and echoed in status responses and webhook payloads.  Keys must be 1-64 characters.
+	Labels *Labels `json:"labels,omitempty"`
+
+	// MinAgeSeconds How long ago files must have last been modified for scans to enqueue them, as newer files are likely still being written. Scans skip newer files and schedule a single follow-up scan for when the newest of them is old enough.  Defaults to 0, which enqueues files however new.
+	MinAgeSeconds *int `json:"minAgeSeconds,omitempty"`
+
+	// Priority Priority of each info job, from 1 (highest, the default) to 4 (lowest)
+	Priority *int `json:"priority,omitempty"`
+
+	// Queue Queue an info job waits in.  Workers choose which queues they consume and how many jobs they run from each, so slow jobs can be kept from delaying quick ones.  Jobs that require a GPU always use the GPU queue.  The names gpu and maintenance are reserved.
+	Queue *Queue `json:"queue,omitempty"`
+
+	// TimeoutSeconds How long each info job may run.  Defaults to the server's default timeout, if it has one, and may not exceed the server's maximum.
+	TimeoutSeconds *int `json:"timeoutSeconds,omitempty"`
+}
+
+// SignedPayload A signed copy of the job outcome.  The payload is the JSON-encoded uuid, videoPath, result, error and signedAt of the job, and the signature is the Ed25519 signature of the payload bytes.
+type SignedPayload struct {
+	// KeyId Identifier of the signing key
+	KeyId string `json:"keyId"`
+
+	// Payload Base64-encoded signed payload
+	Payload []byte `json:"payload"`
+
+	// Signature Base64-encoded Ed25519 signature of the payload
+	Signature []byte `json:"signature"`
+}
+
+// SigningKey defines model for SigningKey.
+type SigningKey struct {
+	// Algorithm Signature algorithm
+	Algorithm string `json:"algorithm"`
+
+	// KeyId Identifier of the signing key, as used in signatures
+	KeyId string `json:"keyId"`
+
+	// PublicKey Base64-encoded raw public key
+	PublicKey []byte `json:"publicKey"`
+}
+
+// SubtitleTrack defines model for SubtitleTrack.
+type SubtitleTrack struct {
+	// CodecName Short name of the codec
+	CodecName string `json:"codecName"`
+
+	// Default Whether the track is flagged as a default track
+	Default bool `json:"default"`
+
+	// Forced Whether the track is flagged as forced, meaning it should be shown even when subtitles are off, typically for foreign dialogue
+	Forced bool `json:"forced"`
+
+	// HearingImpaired Whether the track is flagged as intended for the hearing impaired
+	HearingImpaired bool `json:"hearingImpaired"`
+
+	// Index Index of the stream within the file
+	Index int `json:"index"`
+
+	// Language Language tag of the track
+	Language *string `json:"language,omitempty"`
+
+	// Title Title of the track
+	Title *string `json:"title,omitempty"`
+}
+
+// ToolVersions Versions of the worker and the tools it ran when it probed the file. Results reused from a worker's cache keep the versions that first produced them.  Absent for results recorded before versions were.
+type ToolVersions struct {
+	// Ffmpeg Version reported by ffmpeg, or unknown
+	Ffmpeg string `json:"ffmpeg"`
+
+	// Ffprobe Version reported by ffprobe, or unknown
+	Ffprobe string `json:"ffprobe"`
+
+	// Worker Build version of the worker, or unknown
+	Worker string `json:"worker"`
+}
+
+// UndeliveredWebhook defines model for UndeliveredWebhook.
+type UndeliveredWebhook struct {
+	// Attempts Number of delivery attempts made
+	Attempts int `json:"attempts"`
+
+	// Error Error from the final attempt
+	Error string `json:"error"`
+
+	// FailedAt When the delivery was given up on
+	FailedAt time.Time `json:"failedAt"`
+
+	// Target Scheme and host of the webhook URI, or empty if it could not be recovered
+	Target string `json:"target"`
+
+	// Uuid UUID of the info job the webhook reported on
+	Uuid openapi_types.UUID `json:"uuid"`
+}
+
+// VideoStream defines model for VideoStream.
+type VideoStream struct {
+	// BitRate Bit rate in bits per second, if known
+	BitRate *int64 `json:"bitRate,omitempty"`
+
+	// CodecName Short name of the codec
+	CodecName string `json:"codecName"`
+
+	// FrameRate Average frame rate in frames per second
+	FrameRate *float64 `json:"frameRate,omitempty"`
+
+	// Height Height in pixels
+	Height int `json:"height"`
+
+	// Index Index of the stream within the file
+	Index int `json:"index"`
+
+	// Level Codec level, as reported by ffprobe
+	Level *int `json:"level,omitempty"`
+
+	// PixelFormat Pixel format
+	PixelFormat *string `json:"pixelFormat,omitempty"`
+
+	// Profile Codec profile
+	Profile *string `json:"profile,omitempty"`
+
+	// Width Width in pixels
+	Width int `json:"width"`
+}
+
+// WebhookBatch Body of a webhook POST for requests that set webhookBatch.  A notification may be repeated in a later batch if its delivery is retried.
+type WebhookBatch struct {
+	Notifications []WebhookNotification `json:"notifications"`
+
+	// Token The webhookToken shared by the batched requests, if any
+	Token []byte `json:"token,omitempty"`
+}
+
+// WebhookBatchOptions Delivers the webhook together with those of other jobs with the same webhookUri and webhookToken that also set webhookBatch.  Each POST carries a WebhookBatch of up to maxSize notifications, and a webhook waits up to maxWaitSeconds after its job finishes for others to join it.  Each of webhooks is batched with the webhooks of other jobs to the same target in the same way.  Requires webhookUri or webhooks.
+type WebhookBatchOptions struct {
+	// MaxSize Most notifications in one POST.  Defaults to 100.
+	MaxSize *int `json:"maxSize,omitempty"`
+
+	// MaxWaitSeconds Longest a webhook waits for others to be delivered with. Defaults to 10.
+	MaxWaitSeconds *int `json:"maxWaitSeconds,omitempty"`
+}
+
+// WebhookBreaker defines model for WebhookBreaker.
+type WebhookBreaker struct {
+	// ConsecutiveFailures Failed deliveries since the last successful one
+	ConsecutiveFailures int `json:"consecutiveFailures"`
+
+	// OpenUntil When deliveries may resume or a probe may be sent.  Absent unless the breaker is open or half open.
+	OpenUntil *time.Time `json:"openUntil,omitempty"`
+
+	// OpenedAt When the breaker last opened.  Absent if it never has.
+	OpenedAt *time.Time `json:"openedAt,omitempty"`
+
+	// State Whether deliveries proceed (closed), wait (open), or wait for a probe because the cooldown has passed (half_open).
+	State WebhookBreakerState `json:"state"`
+
+	// Target Scheme and host of the webhook URIs
+	Target string `json:"target"`
+
+	// Trips Times the breaker opened since the last successful delivery
+	Trips int `json:"trips"`
+
+	// UpdatedAt When the last failure was recorded
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// WebhookBreakerState Whether deliveries proceed (closed), wait (open), or wait for a probe because the cooldown has passed (half_open).
+type WebhookBreakerState string
+
+// WebhookEvent A status of an info job reported by its webhook
+type WebhookEvent string
+
+// WebhookNotification Body of a webhook POST reporting one finished info job.  Batched webhooks carry these inside a WebhookBatch, without the token.
+type WebhookNotification struct {
+	// Changes Set when the video path was probed successfully before, naming the fields of the result that changed since.
+	Changes *struct {
+		ChangedFields []string           `json:"changedFields"`
+		PreviousUuid  openapi_types.UUID `json:"previousUuid"`
+	} `json:"changes,omitempty"`
+
+	// Error Error message if the info extraction failed or expired
+	Error *string `json:"error,omitempty"`
+
+	// ErrorCode Machine-readable code for the error, as in InfoJob
+	ErrorCode *string `json:"errorCode,omitempty"`
+
+	// ExternalId External ID of the info job, if it has one
+	ExternalId *string `json:"externalId,omitempty"`
+
+	// Labels Caller-defined key/value labels stored with the job and echoed in status responses and webhook payloads.  Keys must be 1-64 characters.
+	Labels *Labels    `json:"labels,omitempty"`
+	Result *MediaInfo `json:"result,omitempty"`
+
+	// SignedResult A signed copy of the job outcome.  The payload is the JSON-encoded uuid, videoPath, result, error and signedAt of the job, and the signature is the Ed25519 signature of the payload bytes.
+	SignedResult *SignedPayload `json:"signedResult,omitempty"`
+
+	// Status How the info job finished: completed, failed, cancelled or expired.  completed means it has a result, and failed that it has an error instead.  cancelled means it was cancelled before finishing, and expired that it ran out of time, or its worker stopped responding, on its last attempt. Cancelled and expired jobs are reported within a minute or so. For requests whose webhookEvents include it, started means a worker has just picked the job up.  Deliveries are independent, so a started notification whose delivery was retried may arrive after the job's outcome.
+	Status string `json:"status"`
+
+	// Token The webhookToken of the request, if any
+	Token []byte `json:"token,omitempty"`
+
+	// Uuid UUID of the info job
+	Uuid openapi_types.UUID `json:"uuid"`
+}
+
+// WebhookReceiver A receiver of an info job's webhook.  Its token and headers are treated like webhookToken and webhookHeaders.
+type WebhookReceiver struct {
+	// Headers Optional HTTP headers to send with the webhook
+	Headers map[string]string `json:"headers,omitempty"`
+
+	// Token Optional base64-encoded token to include in the POST body
+	Token []byte `json:"token,omitempty"`
+
+	// Uri URI to POST results to when the job finishes
+	Uri string `json:"uri"`
+}
+
+// WebhookRetryPolicy Overrides how the webhook is retried.  Unset fields fall back to the worker's configuration.
+type WebhookRetryPolicy struct {
+	// BackoffSeconds Delay before the first retry, doubling with each further retry
+	BackoffSeconds *int `json:"backoffSeconds,omitempty"`
+
+	// MaxAttempts Number of delivery attempts before giving up
+	MaxAttempts *int `json:"maxAttempts,omitempty"`
+
+	// TimeoutSeconds Time allowed for each delivery attempt
+	TimeoutSeconds *int `json:"timeoutSeconds,omitempty"`
+}
+
+// WebhookTargetStats defines model for WebhookTargetStats.
+type WebhookTargetStats struct {
+	// Delivered Number of webhooks delivered
+	Delivered int `json:"delivered"`
+
+	// LatencyMaxSeconds Highest delivery latency.  Absent if nothing was delivered.
+	LatencyMaxSeconds *float64 `json:"latencyMaxSeconds,omitempty"`
+
+	// LatencyP50Seconds Median delivery latency.  Absent if nothing was delivered.
+	LatencyP50Seconds *float64 `json:"latencyP50Seconds,omitempty"`
+
+	// LatencyP95Seconds 95th percentile delivery latency.  Absent if nothing was delivered.
+	LatencyP95Seconds *float64 `json:"latencyP95Seconds,omitempty"`
+
+	// SuccessRate Fraction of webhooks delivered
+	SuccessRate float64 `json:"successRate"`
+
+	// Target Scheme and host of the webhook URIs
+	Target string `json:"target"`
+
+	// Undelivered Number of webhooks given up on
+	Undelivered int `json:"undelivered"`
+}
+
+// WorkerClaimRequest defines model for WorkerClaimRequest.
+type WorkerClaimRequest struct {
+	// Queue Queue an info job waits in.  Workers choose which queues they consume and how many jobs they run from each, so slow jobs can be kept from delaying quick ones.  Jobs that require a GPU always use the GPU queue.  The names gpu and maintenance are reserved.
+	Queue *Queue `json:"queue,omitempty"`
+
+	// Resources Resources an info job requires.  Jobs that require a GPU are only run by workers with GPU capacity.
+	Resources *Resources `json:"resources,omitempty"`
+
+	// WorkerId Identifier of the claiming worker, recorded with the job
+	WorkerId string `json:"workerId"`
+}
+
+// WorkerJob defines model for WorkerJob.
+type WorkerJob struct {
+	// AnalyzeCrop Whether crop detection was requested
+	AnalyzeCrop *bool `json:"analyzeCrop,omitempty"`
+
+	// AnalyzeLoudness Whether loudness analysis was requested
+	AnalyzeLoudness *bool `json:"analyzeLoudness,omitempty"`
+
+	// Analyzers Names of analyzer plugins to run, as configured on the workers. Each contributes its output to the result's extensions under its name.  Plugins a worker doesn't have are skipped with a warning.
+	Analyzers []string `json:"analyzers,omitempty"`
+
+	// Attempt Attempt number of this claim, to be echoed on completion
+	Attempt int `json:"attempt"`
+
+	// Checksum Checksum to compute over the file's contents.  xxhash64 and sha256 read the whole file.  partial is an xxhash64 of the file's size and its first and last MiB, which is fast and good enough to spot likely duplicates but not to verify copies.
+	Checksum *ChecksumAlgorithm `json:"checksum,omitempty"`
+
+	// IncludeRaw Whether the raw ffprobe output was requested
+	IncludeRaw *bool `json:"includeRaw,omitempty"`
+
+	// JobId ID of the claimed job
+	JobId int64 `json:"jobId"`
+
+	// SampleWindows Number of windows to sample, if sampling was requested
+	SampleWindows *int `json:"sampleWindows,omitempty"`
+
+	// TimeoutSeconds How long the job may run, if it is bounded
+	TimeoutSeconds *int `json:"timeoutSeconds,omitempty"`
+
+	// Uuid UUID of the info job
+	Uuid openapi_types.UUID `json:"uuid"`
+
+	// Verify Whether decode verification was requested
+	Verify *bool `json:"verify,omitempty"`
+
+	// VideoPath Path to the video file to inspect
+	VideoPath string `json:"videoPath"`
+}
+
+// WorkerJobOutcome defines model for WorkerJobOutcome.
+type WorkerJobOutcome struct {
+	// Attempt Attempt number returned by claimWorkerJob
+	Attempt int `json:"attempt"`
+
+	// Error Error message if the info extraction failed
+	Error *string `json:"error,omitempty"`
+
+	// ErrorCode Machine-readable code for the error, if it has one. UNSUPPORTED_FORMAT means the file is not a video (or audio, if the worker probes audio files) and was not probed.  FILE_TOO_LARGE means the file is over the worker's size limit.  NOT_FOUND and PERMISSION_DENIED mean the worker could not access the file. TIMEOUT means the job ran past its timeout.  PATH_NOT_ALLOWED means the path is outside the worker's allowed roots. INVALID_OUTPUT means the worker's result did not match the MediaInfo schema and was discarded.
+	ErrorCode *string    `json:"errorCode,omitempty"`
+	Result    *MediaInfo `json:"result,omitempty"`
+
+	// Timings How long each phase of the job took, in the order they ran
+	Timings []PhaseTiming `json:"timings,omitempty"`
+}
+
+// QueueFull defines model for QueueFull.
+type QueueFull = Error
+
+// GetCanaryReportParams defines parameters for GetCanaryReport.
+type GetCanaryReportParams struct {
+	// Since Only compare canary results finalized at or after this time.  Defaults to 24 hours ago.
+	Since *time.Time `form:"since,omitempty" json:"since,omitempty"`
+}
+
+// ListFailuresParams defines parameters for ListFailures.
+type ListFailuresParams struct {
+	// PathPrefix Only consider jobs whose video path starts with this prefix
+	PathPrefix *string `form:"pathPrefix,omitempty" json:"pathPrefix,omitempty"`
+
+	// CreatedAfter Only consider jobs created at or after this time
+	CreatedAfter *time.Time `form:"createdAfter,omitempty" json:"createdAfter,omitempty"`
+}
+
+// ExportTimelineParams defines parameters for ExportTimeline.
+type ExportTimelineParams struct {
+	// After Start of the window.  Defaults to 24 hours before the end of the window.
+	After *time.Time `form:"after,omitempty" json:"after,omitempty"`
+
+	// Before End of the window.  Defaults to now.
+	Before *time.Time `form:"before,omitempty" json:"before,omitempty"`
+
+	// Format Format of the export
+	Format *ExportTimelineParamsFormat `form:"format,omitempty" json:"format,omitempty"`
+}
+
+// ExportTimelineParamsFormat defines parameters for ExportTimeline.
+type ExportTimelineParamsFormat string
+
+// ListWebhookTargetsParams defines parameters for ListWebhookTargets.
+type ListWebhookTargetsParams struct {
+	// Since Only consider deliveries finished at or after this time.  Defaults to one day ago.
+	Since *time.Time `form:"since,omitempty" json:"since,omitempty"`
+
+	// Limit Maximum number of targets to return.  Defaults to 100.
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// ListUndeliveredWebhooksParams defines parameters for ListUndeliveredWebhooks.
+type ListUndeliveredWebhooksParams struct {
+	// Uuid Only list deliveries for the info job with this UUID
+	Uuid *openapi_types.UUID `form:"uuid,omitempty" json:"uuid,omitempty"`
+
+	// Target Only list deliveries to this target, given as scheme and host
+	Target *string `form:"target,omitempty" json:"target,omitempty"`
+
+	// Limit Maximum number of records to return.  Defaults to 100.
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// ListInfoParams defines parameters for ListInfo.
+type ListInfoParams struct {
+	// Status Only return jobs with this status
+	Status *InfoStatus `form:"status,omitempty" json:"status,omitempty"`
+
+	// VideoPath Only return jobs for exactly this video path
+	VideoPath *string `form:"videoPath,omitempty" json:"videoPath,omitempty"`
+
+	// PathPrefix Only return jobs whose video path starts with this prefix
+	PathPrefix *string `form:"pathPrefix,omitempty" json:"pathPrefix,omitempty"`
+
+	// Q Only return jobs whose video path contains these words, in the order given but ignoring case, such as "breaking bad s02e05". At least 3 characters other than spaces are required.  Combine with latest to search every video path with a stored result.
+	Q *string `form:"q,omitempty" json:"q,omitempty"`
+
+	// Latest Only return the most recent matching job for each video path, ordered by video path rather than newest first.  This includes completed jobs whose results are stored after River has cleaned up the job, so it finds results older than the job list keeps. Defaults to false.
+	Latest *bool `form:"latest,omitempty" json:"latest,omitempty"`
+
+	// CreatedAfter Only return jobs created at or after this time
+	CreatedAfter *time.Time `form:"createdAfter,omitempty" json:"createdAfter,omitempty"`
+
+	// CreatedBefore Only return jobs created before this time
+	CreatedBefore *time.Time `form:"createdBefore,omitempty" json:"createdBefore,omitempty"`
+
+	// Label Only return jobs with this label, given as key:value.  May be repeated to require several labels.
+	Label []string `form:"label,omitempty" json:"label,omitempty"`
+
+	// Acknowledged Only return jobs whose acknowledged annotation has this value
+	Acknowledged *bool `form:"acknowledged,omitempty" json:"acknowledged,omitempty"`
+
+	// Sort Field to order jobs by: created_at, updated_at, duration or path. duration is how long the job's latest attempt ran, or has been running so far, and is zero for jobs waiting to run.  Jobs with equal values are ordered by when they were created.  Can't be combined with latest.
+	Sort *ListInfoParamsSort `form:"sort,omitempty" json:"sort,omitempty"`
+
+	// Order Direction to order jobs in.  Defaults to desc, such as newest or longest-running first, except when sorting by path.  Requires sort.
+	Order *ListInfoParamsOrder `form:"order,omitempty" json:"order,omitempty"`
+
+	// Limit Maximum number of jobs to return
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// PageToken Token from a previous response to continue listing from.  It must be used with the same sort and order.
+	PageToken *string `form:"pageToken,omitempty" json:"pageToken,omitempty"`
+}
+
+// ListInfoParamsSort defines parameters for ListInfo.
+type ListInfoParamsSort string
+
+// ListInfoParamsOrder defines parameters for ListInfo.
+type ListInfoParamsOrder string
+
+// DiffInfoParams defines parameters for DiffInfo.
+type DiffInfoParams struct {
+	// From UUID of the info job with the earlier result
+	From openapi_types.UUID `form:"from" json:"from"`
+
+	// To UUID of the info job with the later result
+	To openapi_types.UUID `form:"to" json:"to"`
+}
+
+// ListInfoHistoryParams defines parameters for ListInfoHistory.
+type ListInfoHistoryParams struct {
+	// VideoPath The video path whose results to list
+	VideoPath string `form:"videoPath" json:"videoPath"`
+
+	// Limit Maximum number of results to return
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// PageToken Token from a previous response to continue listing from
+	PageToken *string `form:"pageToken,omitempty" json:"pageToken,omitempty"`
+}
+
+// CreateScheduleParams defines parameters for CreateSchedule.
+type CreateScheduleParams struct {
+	// DryRun Preview what a scan would enqueue instead of creating the schedule
+	DryRun *bool `form:"dryRun,omitempty" json:"dryRun,omitempty"`
+}
+
+// CreateComparisonJSONRequestBody defines body for CreateComparison for application/json ContentType.
+type CreateComparisonJSONRequestBody = ComparisonRequest
+
+// RetryFailuresJSONRequestBody defines body for RetryFailures for application/json ContentType.
+type RetryFailuresJSONRequestBody = RetryFailuresRequest
+
+// PurgeInfoJSONRequestBody defines body for PurgeInfo for application/json ContentType.
+type PurgeInfoJSONRequestBody = PurgeRequest
+
+// CreateReprobeCampaignJSONRequestBody defines body for CreateReprobeCampaign for application/json ContentType.
+type CreateReprobeCampaignJSONRequestBody = ReprobeCampaignRequest
+
+// CreateInfoJSONRequestBody defines body for CreateInfo for application/json ContentType.
+type CreateInfoJSONRequestBody = InfoRequest
+
+// CreateInfoBatchJSONRequestBody defines body for CreateInfoBatch for application/json ContentType.
+type CreateInfoBatchJSONRequestBody = InfoBatchRequest
+
+// AnnotateInfoJSONRequestBody defines body for AnnotateInfo for application/json ContentType.
+type AnnotateInfoJSONRequestBody = AnnotationsUpdate
+
+// ReprobeInfoJSONRequestBody defines body for ReprobeInfo for application/json ContentType.
+type ReprobeInfoJSONRequestBody = ReprobeRequest
+
+// CreateScheduleJSONRequestBody defines body for CreateSchedule for application/json ContentType.
+type CreateScheduleJSONRequestBody = ScheduleRequest
+
+// RegisterAgentJSONRequestBody defines body for RegisterAgent for application/json ContentType.
+type RegisterAgentJSONRequestBody = AgentRegistration
+
+// ClaimWorkerJobJSONRequestBody defines body for ClaimWorkerJob for application/json ContentType.
+type ClaimWorkerJobJSONRequestBody = WorkerClaimRequest
+
+// CompleteWorkerJobJSONRequestBody defines body for CompleteWorkerJob for application/json ContentType.
+type CompleteWorkerJobJSONRequestBody = WorkerJobOutcome
+
+// RequestEditorFn  is the function signature for the RequestEditor callback function
+type RequestEditorFn func(ctx context.Context, req *http.Request) error
+
+// Doer performs HTTP requests.
+//
+// The standard http.Client implements this interface.
+type HttpRequestDoer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// Client which conforms to the OpenAPI3 specification for this service.
+type Client struct {
+	// The endpoint of the server conforming to this interface, with scheme,
+	// https://api.deepmap.com for example. This can contain a path relative
+	// to the server, such as https://api.deepmap.com/dev-test, and all the
+	// paths in the swagger spec will be appended to the server.
+	Server string
+
+	// Doer for performing requests, typically a *http.Client with any
+	// customized settings, such as certificate chains.
+	Client HttpRequestDoer
+
+	// A list of callbacks for modifying requests which are generated before sending over
+	// the network.
+	RequestEditors []RequestEditorFn
+}
+
+// ClientOption allows setting custom parameters during construction
+type ClientOption func(*Client) error
+
+// Creates a new Client, with reasonable defaults
+func NewClient(server string, opts ...ClientOption) (*Client, error) {
+	// create a client with sane default values
+	client := Client{
+		Server: server,
+	}
+	// mutate client and add all optional params
+	for _, o := range opts {
+		if err := o(&client); err != nil {
+			return nil, err
+		}
+	}
+	// ensure the server URL always has a trailing slash
+	if !strings.HasSuffix(client.Server, "/") {
+		client.Server += "/"
+	}
+	// create httpClient, if not already present
+	if client.Client == nil {
+		client.Client = &http.Client{}
+	}
+	return &client, nil
+}
+
+// WithHTTPClient allows overriding the default Doer, which is
+// automatically created using http.Client. This is useful for tests.
+func WithHTTPClient(doer HttpRequestDoer) ClientOption {
+	return func(c *Client) error {
+		c.Client = doer
+		return nil
+	}
+}
+
+// WithRequestEditorFn allows setting up a callback function, which will be
+// called right before sending the request. This can be used to mutate the request.
+func WithRequestEditorFn(fn RequestEditorFn) ClientOption {
+	return func(c *Client) error {
+		c.RequestEditors = append(c.RequestEditors, fn)
+		return nil
+	}
+}
+
+// The interface specification for the client above.
+type ClientInterface interface {
+	// GetSigningKey request
+	GetSigningKey(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ListAgents request
+	ListAgents(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetCanaryReport request
+	GetCanaryReport(ctx context.Context, params *GetCanaryReportParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// CreateComparisonWithBody request with any body
+	CreateComparisonWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	CreateComparison(ctx context.Context, body CreateComparisonJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetComparison request
+	GetComparison(ctx context.Context, id openapi_types.UUID, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ListFailures request
+	ListFailures(ctx context.Context, params *ListFailuresParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// RetryFailuresWithBody request with any body
+	RetryFailuresWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	RetryFailures(ctx context.Context, body RetryFailuresJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PurgeInfoWithBody request with any body
+	PurgeInfoWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PurgeInfo(ctx context.Context, body PurgeInfoJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ListReprobeCampaigns request
+	ListReprobeCampaigns(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// CreateReprobeCampaignWithBody request with any body
+	CreateReprobeCampaignWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	CreateReprobeCampaign(ctx context.Context, body CreateReprobeCampaignJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// DeleteReprobeCampaign request
+	DeleteReprobeCampaign(ctx context.Context, id openapi_types.UUID, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetSupportBundle request
+	GetSupportBundle(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ExportTimeline request
+	ExportTimeline(ctx context.Context, params *ExportTimelineParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ListWebhookBreakers request
+	ListWebhookBreakers(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ListWebhookTargets request
+	ListWebhookTargets(ctx context.Context, params *ListWebhookTargetsParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ListUndeliveredWebhooks request
+	ListUndeliveredWebhooks(ctx context.Context, params *ListUndeliveredWebhooksParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetHealth request
+	GetHealth(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ListInfo request
+	ListInfo(ctx context.Context, params *ListInfoParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// CreateInfoWithBody request with any body
+	CreateInfoWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	CreateInfo(ctx context.Context, body CreateInfoJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// CreateInfoBatchWithBody request with any body
+	CreateInfoBatchWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	CreateInfoBatch(ctx context.Context, body CreateInfoBatchJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetInfoStatusByExternalId request
+	GetInfoStatusByExternalId(ctx context.Context, externalId string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// DiffInfo request
+	DiffInfo(ctx context.Context, params *DiffInfoParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ListInfoHistory request
+	ListInfoHistory(ctx context.Context, params *ListInfoHistoryParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetInfoStatus request
+	GetInfoStatus(ctx context.Context, uuid openapi_types.UUID, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// AnnotateInfoWithBody request with any body
+	AnnotateInfoWithBody(ctx context.Context, uuid openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	AnnotateInfo(ctx context.Context, uuid openapi_types.UUID, body AnnotateInfoJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// CancelInfo request
+	CancelInfo(ctx context.Context, uuid openapi_types.UUID, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetInfoHistory request
+	GetInfoHistory(ctx context.Context, uuid openapi_types.UUID, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ReprobeInfoWithBody request with any body
+	ReprobeInfoWithBody(ctx context.Context, uuid openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	ReprobeInfo(ctx context.Context, uuid openapi_types.UUID, body ReprobeInfoJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// RetryInfo request
+	RetryInfo(ctx context.Context, uuid openapi_types.UUID, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetReadiness request
+	GetReadiness(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetScanPreview request
+	GetScanPreview(ctx context.Context, id int64, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ListSchedules request
+	ListSchedules(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// CreateScheduleWithBody request with any body
+	CreateScheduleWithBody(ctx context.Context, params *CreateScheduleParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	CreateSchedule(ctx context.Context, params *CreateScheduleParams, body CreateScheduleJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// DeleteSchedule request
+	DeleteSchedule(ctx context.Context, id openapi_types.UUID, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// RegisterAgentWithBody request with any body
+	RegisterAgentWithBody(ctx context.Context, workerId string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	RegisterAgent(ctx context.Context, workerId string, body RegisterAgentJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ClaimWorkerJobWithBody request with any body
+	ClaimWorkerJobWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	ClaimWorkerJob(ctx context.Context, body ClaimWorkerJobJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// CompleteWorkerJobWithBody request with any body
+	CompleteWorkerJobWithBody(ctx context.Context, jobId int64, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	CompleteWorkerJob(ctx context.Context, jobId int64, body CompleteWorkerJobJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+}
+
+func (c *Client) GetSigningKey(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetSigningKeyRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ListAgents(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewListAgentsRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetCanaryReport(ctx context.Context, params *GetCanaryReportParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetCanaryReportRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CreateComparisonWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreateComparisonRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CreateComparison(ctx context.Context, body CreateComparisonJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreateComparisonRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetComparison(ctx context.Context, id openapi_types.UUID, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetComparisonRequest(c.Server, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ListFailures(ctx context.Context, params *ListFailuresParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewListFailuresRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) RetryFailuresWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewRetryFailuresRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) RetryFailures(ctx context.Context, body RetryFailuresJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewRetryFailuresRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PurgeInfoWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPurgeInfoRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PurgeInfo(ctx context.Context, body PurgeInfoJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPurgeInfoRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ListReprobeCampaigns(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewListReprobeCampaignsRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CreateReprobeCampaignWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreateReprobeCampaignRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CreateReprobeCampaign(ctx context.Context, body CreateReprobeCampaignJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreateReprobeCampaignRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) DeleteReprobeCampaign(ctx context.Context, id openapi_types.UUID, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDeleteReprobeCampaignRequest(c.Server, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetSupportBundle(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetSupportBundleRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ExportTimeline(ctx context.Context, params *ExportTimelineParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewExportTimelineRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ListWebhookBreakers(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewListWebhookBreakersRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ListWebhookTargets(ctx context.Context, params *ListWebhookTargetsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewListWebhookTargetsRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ListUndeliveredWebhooks(ctx context.Context, params *ListUndeliveredWebhooksParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewListUndeliveredWebhooksRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetHealth(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetHealthRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ListInfo(ctx context.Context, params *ListInfoParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewListInfoRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CreateInfoWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreateInfoRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CreateInfo(ctx context.Context, body CreateInfoJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreateInfoRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CreateInfoBatchWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreateInfoBatchRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CreateInfoBatch(ctx context.Context, body CreateInfoBatchJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreateInfoBatchRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetInfoStatusByExternalId(ctx context.Context, externalId string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetInfoStatusByExternalIdRequest(c.Server, externalId)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) DiffInfo(ctx context.Context, params *DiffInfoParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDiffInfoRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ListInfoHistory(ctx context.Context, params *ListInfoHistoryParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewListInfoHistoryRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetInfoStatus(ctx context.Context, uuid openapi_types.UUID, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetInfoStatusRequest(c.Server, uuid)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) AnnotateInfoWithBody(ctx context.Context, uuid openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewAnnotateInfoRequestWithBody(c.Server, uuid, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) AnnotateInfo(ctx context.Context, uuid openapi_types.UUID, body AnnotateInfoJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewAnnotateInfoRequest(c.Server, uuid, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CancelInfo(ctx context.Context, uuid openapi_types.UUID, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCancelInfoRequest(c.Server, uuid)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetInfoHistory(ctx context.Context, uuid openapi_types.UUID, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetInfoHistoryRequest(c.Server, uuid)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ReprobeInfoWithBody(ctx context.Context, uuid openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewReprobeInfoRequestWithBody(c.Server, uuid, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ReprobeInfo(ctx context.Context, uuid openapi_types.UUID, body ReprobeInfoJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewReprobeInfoRequest(c.Server, uuid, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) RetryInfo(ctx context.Context, uuid openapi_types.UUID, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewRetryInfoRequest(c.Server, uuid)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetReadiness(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetReadinessRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetScanPreview(ctx context.Context, id int64, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetScanPreviewRequest(c.Server, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ListSchedules(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewListSchedulesRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CreateScheduleWithBody(ctx context.Context, params *CreateScheduleParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreateScheduleRequestWithBody(c.Server, params, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CreateSchedule(ctx context.Context, params *CreateScheduleParams, body CreateScheduleJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreateScheduleRequest(c.Server, params, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) DeleteSchedule(ctx context.Context, id openapi_types.UUID, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDeleteScheduleRequest(c.Server, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) RegisterAgentWithBody(ctx context.Context, workerId string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewRegisterAgentRequestWithBody(c.Server, workerId, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) RegisterAgent(ctx context.Context, workerId string, body RegisterAgentJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewRegisterAgentRequest(c.Server, workerId, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ClaimWorkerJobWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewClaimWorkerJobRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ClaimWorkerJob(ctx context.Context, body ClaimWorkerJobJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewClaimWorkerJobRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CompleteWorkerJobWithBody(ctx context.Context, jobId int64, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCompleteWorkerJobRequestWithBody(c.Server, jobId, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CompleteWorkerJob(ctx context.Context, jobId int64, body CompleteWorkerJobJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCompleteWorkerJobRequest(c.Server, jobId, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+// NewGetSigningKeyRequest generates requests for GetSigningKey
+func NewGetSigningKeyRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/.well-known/video-info-signing-key")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewListAgentsRequest generates requests for ListAgents
+func NewListAgentsRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/admin/agents")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetCanaryReportRequest generates requests for GetCanaryReport
+func NewGetCanaryReportRequest(server string, params *GetCanaryReportParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/admin/canary-report")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Since != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "since", runtime.ParamLocationQuery, *params.Since); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewCreateComparisonRequest calls the generic CreateComparison builder with application/json body
+func NewCreateComparisonRequest(server string, body CreateComparisonJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewCreateComparisonRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewCreateComparisonRequestWithBody generates requests for CreateComparison with any type of body
+func NewCreateComparisonRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/admin/comparisons")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewGetComparisonRequest generates requests for GetComparison
+func NewGetComparisonRequest(server string, id openapi_types.UUID) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/admin/comparisons/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewListFailuresRequest generates requests for ListFailures
+func NewListFailuresRequest(server string, params *ListFailuresParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/admin/failures")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.PathPrefix != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "pathPrefix", runtime.ParamLocationQuery, *params.PathPrefix); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.CreatedAfter != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "createdAfter", runtime.ParamLocationQuery, *params.CreatedAfter); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewRetryFailuresRequest calls the generic RetryFailures builder with application/json body
+func NewRetryFailuresRequest(server string, body RetryFailuresJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewRetryFailuresRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewRetryFailuresRequestWithBody generates requests for RetryFailures with any type of body
+func NewRetryFailuresRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/admin/failures/retry")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewPurgeInfoRequest calls the generic PurgeInfo builder with application/json body
+func NewPurgeInfoRequest(server string, body PurgeInfoJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPurgeInfoRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewPurgeInfoRequestWithBody generates requests for PurgeInfo with any type of body
+func NewPurgeInfoRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/admin/purge")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewListReprobeCampaignsRequest generates requests for ListReprobeCampaigns
+func NewListReprobeCampaignsRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/admin/reprobe-campaigns")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewCreateReprobeCampaignRequest calls the generic CreateReprobeCampaign builder with application/json body
+func NewCreateReprobeCampaignRequest(server string, body CreateReprobeCampaignJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewCreateReprobeCampaignRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewCreateReprobeCampaignRequestWithBody generates requests for CreateReprobeCampaign with any type of body
+func NewCreateReprobeCampaignRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/admin/reprobe-campaigns")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewDeleteReprobeCampaignRequest generates requests for DeleteReprobeCampaign
+func NewDeleteReprobeCampaignRequest(server string, id openapi_types.UUID) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/admin/reprobe-campaigns/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetSupportBundleRequest generates requests for GetSupportBundle
+func NewGetSupportBundleRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/admin/support-bundle")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewExportTimelineRequest generates requests for ExportTimeline
+func NewExportTimelineRequest(server string, params *ExportTimelineParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/admin/timeline")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.After != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "after", runtime.ParamLocationQuery, *params.After); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Before != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "before", runtime.ParamLocationQuery, *params.Before); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Format != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "format", runtime.ParamLocationQuery, *params.Format); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewListWebhookBreakersRequest generates requests for ListWebhookBreakers
+func NewListWebhookBreakersRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/admin/webhooks/breakers")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewListWebhookTargetsRequest generates requests for ListWebhookTargets
+func NewListWebhookTargetsRequest(server string, params *ListWebhookTargetsParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/admin/webhooks/targets")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Since != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "since", runtime.ParamLocationQuery, *params.Since); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Limit != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "limit", runtime.ParamLocationQuery, *params.Limit); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewListUndeliveredWebhooksRequest generates requests for ListUndeliveredWebhooks
+func NewListUndeliveredWebhooksRequest(server string, params *ListUndeliveredWebhooksParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/admin/webhooks/undelivered")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Uuid != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "uuid", runtime.ParamLocationQuery, *params.Uuid); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Target != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "target", runtime.ParamLocationQuery, *params.Target); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Limit != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "limit", runtime.ParamLocationQuery, *params.Limit); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetHealthRequest generates requests for GetHealth
+func NewGetHealthRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/healthz")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewListInfoRequest generates requests for ListInfo
+func NewListInfoRequest(server string, params *ListInfoParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/info")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Status != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "status", runtime.ParamLocationQuery, *params.Status); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.VideoPath != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "videoPath", runtime.ParamLocationQuery, *params.VideoPath); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.PathPrefix != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "pathPrefix", runtime.ParamLocationQuery, *params.PathPrefix); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Q != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "q", runtime.ParamLocationQuery, *params.Q); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Latest != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "latest", runtime.ParamLocationQuery, *params.Latest); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.CreatedAfter != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "createdAfter", runtime.ParamLocationQuery, *params.CreatedAfter); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.CreatedBefore != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "createdBefore", runtime.ParamLocationQuery, *params.CreatedBefore); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "label", runtime.ParamLocationQuery, params.Label); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+		if params.Acknowledged != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "acknowledged", runtime.ParamLocationQuery, *params.Acknowledged); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Sort != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "sort", runtime.ParamLocationQuery, *params.Sort); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Order != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "order", runtime.ParamLocationQuery, *params.Order); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Limit != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "limit", runtime.ParamLocationQuery, *params.Limit); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.PageToken != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "pageToken", runtime.ParamLocationQuery, *params.PageToken); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewCreateInfoRequest calls the generic CreateInfo builder with application/json body
+func NewCreateInfoRequest(server string, body CreateInfoJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewCreateInfoRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewCreateInfoRequestWithBody generates requests for CreateInfo with any type of body
+func NewCreateInfoRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/info")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewCreateInfoBatchRequest calls the generic CreateInfoBatch builder with application/json body
+func NewCreateInfoBatchRequest(server string, body CreateInfoBatchJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewCreateInfoBatchRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewCreateInfoBatchRequestWithBody generates requests for CreateInfoBatch with any type of body
+func NewCreateInfoBatchRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/info/batch")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewGetInfoStatusByExternalIdRequest generates requests for GetInfoStatusByExternalId
+func NewGetInfoStatusByExternalIdRequest(server string, externalId string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "externalId", runtime.ParamLocationPath, externalId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/info/by-external-id/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewDiffInfoRequest generates requests for DiffInfo
+func NewDiffInfoRequest(server string, params *DiffInfoParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/info/diff")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "from", runtime.ParamLocationQuery, params.From); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "to", runtime.ParamLocationQuery, params.To); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewListInfoHistoryRequest generates requests for ListInfoHistory
+func NewListInfoHistoryRequest(server string, params *ListInfoHistoryParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/info/history")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "videoPath", runtime.ParamLocationQuery, params.VideoPath); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+		if params.Limit != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "limit", runtime.ParamLocationQuery, *params.Limit); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.PageToken != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "pageToken", runtime.ParamLocationQuery, *params.PageToken); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetInfoStatusRequest generates requests for GetInfoStatus
+func NewGetInfoStatusRequest(server string, uuid openapi_types.UUID) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "uuid", runtime.ParamLocationPath, uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/info/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewAnnotateInfoRequest calls the generic AnnotateInfo builder with application/json body
+func NewAnnotateInfoRequest(server string, uuid openapi_types.UUID, body AnnotateInfoJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewAnnotateInfoRequestWithBody(server, uuid, "application/json", bodyReader)
+}
+
+// NewAnnotateInfoRequestWithBody generates requests for AnnotateInfo with any type of body
+func NewAnnotateInfoRequestWithBody(server string, uuid openapi_types.UUID, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "uuid", runtime.ParamLocationPath, uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/info/%s/annotations", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PATCH", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewCancelInfoRequest generates requests for CancelInfo
+func NewCancelInfoRequest(server string, uuid openapi_types.UUID) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "uuid", runtime.ParamLocationPath, uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/info/%s/cancel", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetInfoHistoryRequest generates requests for GetInfoHistory
+func NewGetInfoHistoryRequest(server string, uuid openapi_types.UUID) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "uuid", runtime.ParamLocationPath, uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/info/%s/history", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewReprobeInfoRequest calls the generic ReprobeInfo builder with application/json body
+func NewReprobeInfoRequest(server string, uuid openapi_types.UUID, body ReprobeInfoJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewReprobeInfoRequestWithBody(server, uuid, "application/json", bodyReader)
+}
+
+// NewReprobeInfoRequestWithBody generates requests for ReprobeInfo with any type of body
+func NewReprobeInfoRequestWithBody(server string, uuid openapi_types.UUID, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "uuid", runtime.ParamLocationPath, uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/info/%s/reprobe", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewRetryInfoRequest generates requests for RetryInfo
+func NewRetryInfoRequest(server string, uuid openapi_types.UUID) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "uuid", runtime.ParamLocationPath, uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/info/%s/retry", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetReadinessRequest generates requests for GetReadiness
+func NewGetReadinessRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/readyz")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetScanPreviewRequest generates requests for GetScanPreview
+func NewGetScanPreviewRequest(server string, id int64) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/scan-previews/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewListSchedulesRequest generates requests for ListSchedules
+func NewListSchedulesRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/schedules")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewCreateScheduleRequest calls the generic CreateSchedule builder with application/json body
+func NewCreateScheduleRequest(server string, params *CreateScheduleParams, body CreateScheduleJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewCreateScheduleRequestWithBody(server, params, "application/json", bodyReader)
+}
+
+// NewCreateScheduleRequestWithBody generates requests for CreateSchedule with any type of body
+func NewCreateScheduleRequestWithBody(server string, params *CreateScheduleParams, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/schedules")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.DryRun != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "dryRun", runtime.ParamLocationQuery, *params.DryRun); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewDeleteScheduleRequest generates requests for DeleteSchedule
+func NewDeleteScheduleRequest(server string, id openapi_types.UUID) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/schedules/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewRegisterAgentRequest calls the generic RegisterAgent builder with application/json body
+func NewRegisterAgentRequest(server string, workerId string, body RegisterAgentJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewRegisterAgentRequestWithBody(server, workerId, "application/json", bodyReader)
+}
+
+// NewRegisterAgentRequestWithBody generates requests for RegisterAgent with any type of body
+func NewRegisterAgentRequestWithBody(server string, workerId string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "workerId", runtime.ParamLocationPath, workerId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/worker/agents/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PUT", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewClaimWorkerJobRequest calls the generic ClaimWorkerJob builder with application/json body
+func NewClaimWorkerJobRequest(server string, body ClaimWorkerJobJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewClaimWorkerJobRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewClaimWorkerJobRequestWithBody generates requests for ClaimWorkerJob with any type of body
+func NewClaimWorkerJobRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/worker/claim")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewCompleteWorkerJobRequest calls the generic CompleteWorkerJob builder with application/json body
+func NewCompleteWorkerJobRequest(server string, jobId int64, body CompleteWorkerJobJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewCompleteWorkerJobRequestWithBody(server, jobId, "application/json", bodyReader)
+}
+
+// NewCompleteWorkerJobRequestWithBody generates requests for CompleteWorkerJob with any type of body
+func NewCompleteWorkerJobRequestWithBody(server string, jobId int64, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "jobId", runtime.ParamLocationPath, jobId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/worker/jobs/%s/complete", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
+	for _, r := range c.RequestEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	for _, r := range additionalEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ClientWithResponses builds on ClientInterface to offer response payloads
+type ClientWithResponses struct {
+	ClientInterface
+}
+
+// NewClientWithResponses creates a new ClientWithResponses, which wraps
+// Client with return type handling
+func NewClientWithResponses(server string, opts ...ClientOption) (*ClientWithResponses, error) {
+	client, err := NewClient(server, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &ClientWithResponses{client}, nil
+}
+
+// WithBaseURL overrides the baseURL.
+func WithBaseURL(baseURL string) ClientOption {
+	return func(c *Client) error {
+		newBaseURL, err := url.Parse(baseURL)
+		if err != nil {
+			return err
+		}
+		c.Server = newBaseURL.String()
+		return nil
+	}
+}
+
+// ClientWithResponsesInterface is the interface specification for the client with responses above.
+type ClientWithResponsesInterface interface {
+	// GetSigningKeyWithResponse request
+	GetSigningKeyWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetSigningKeyResponse, error)
+
+	// ListAgentsWithResponse request
+	ListAgentsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ListAgentsResponse, error)
+
+	// GetCanaryReportWithResponse request
+	GetCanaryReportWithResponse(ctx context.Context, params *GetCanaryReportParams, reqEditors ...RequestEditorFn) (*GetCanaryReportResponse, error)
+
+	// CreateComparisonWithBodyWithResponse request with any body
+	CreateComparisonWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateComparisonResponse, error)
+
+	CreateComparisonWithResponse(ctx context.Context, body CreateComparisonJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateComparisonResponse, error)
+
+	// GetComparisonWithResponse request
+	GetComparisonWithResponse(ctx context.Context, id openapi_types.UUID, reqEditors ...RequestEditorFn) (*GetComparisonResponse, error)
+
+	// ListFailuresWithResponse request
+	ListFailuresWithResponse(ctx context.Context, params *ListFailuresParams, reqEditors ...RequestEditorFn) (*ListFailuresResponse, error)
+
+	// RetryFailuresWithBodyWithResponse request with any body
+	RetryFailuresWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*RetryFailuresResponse, error)
+
+	RetryFailuresWithResponse(ctx context.Context, body RetryFailuresJSONRequestBody, reqEditors ...RequestEditorFn) (*RetryFailuresResponse, error)
+
+	// PurgeInfoWithBodyWithResponse request with any body
+	PurgeInfoWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PurgeInfoResponse, error)
+
+	PurgeInfoWithResponse(ctx context.Context, body PurgeInfoJSONRequestBody, reqEditors ...RequestEditorFn) (*PurgeInfoResponse, error)
+
+	// ListReprobeCampaignsWithResponse request
+	ListReprobeCampaignsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ListReprobeCampaignsResponse, error)
+
+	// CreateReprobeCampaignWithBodyWithResponse request with any body
+	CreateReprobeCampaignWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateReprobeCampaignResponse, error)
+
+	CreateReprobeCampaignWithResponse(ctx context.Context, body CreateReprobeCampaignJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateReprobeCampaignResponse, error)
+
+	// DeleteReprobeCampaignWithResponse request
+	DeleteReprobeCampaignWithResponse(ctx context.Context, id openapi_types.UUID, reqEditors ...RequestEditorFn) (*DeleteReprobeCampaignResponse, error)
+
+	// GetSupportBundleWithResponse request
+	GetSupportBundleWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetSupportBundleResponse, error)
+
+	// ExportTimelineWithResponse request
+	ExportTimelineWithResponse(ctx context.Context, params *ExportTimelineParams, reqEditors ...RequestEditorFn) (*ExportTimelineResponse, error)
+
+	// ListWebhookBreakersWithResponse request
+	ListWebhookBreakersWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ListWebhookBreakersResponse, error)
+
+	// ListWebhookTargetsWithResponse request
+	ListWebhookTargetsWithResponse(ctx context.Context, params *ListWebhookTargetsParams, reqEditors ...RequestEditorFn) (*ListWebhookTargetsResponse, error)
+
+	// ListUndeliveredWebhooksWithResponse request
+	ListUndeliveredWebhooksWithResponse(ctx context.Context, params *ListUndeliveredWebhooksParams, reqEditors ...RequestEditorFn) (*ListUndeliveredWebhooksResponse, error)
+
+	// GetHealthWithResponse request
+	GetHealthWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetHealthResponse, error)
+
+	// ListInfoWithResponse request
+	ListInfoWithResponse(ctx context.Context, params *ListInfoParams, reqEditors ...RequestEditorFn) (*ListInfoResponse, error)
+
+	// CreateInfoWithBodyWithResponse request with any body
+	CreateInfoWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateInfoResponse, error)
+
+	CreateInfoWithResponse(ctx context.Context, body CreateInfoJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateInfoResponse, error)
+
+	// CreateInfoBatchWithBodyWithResponse request with any body
+	CreateInfoBatchWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateInfoBatchResponse, error)
+
+	CreateInfoBatchWithResponse(ctx context.Context, body CreateInfoBatchJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateInfoBatchResponse, error)
+
+	// GetInfoStatusByExternalIdWithResponse request
+	GetInfoStatusByExternalIdWithResponse(ctx context.Context, externalId string, reqEditors ...RequestEditorFn) (*GetInfoStatusByExternalIdResponse, error)
+
+	// DiffInfoWithResponse request
+	DiffInfoWithResponse(ctx context.Context, params *DiffInfoParams, reqEditors ...RequestEditorFn) (*DiffInfoResponse, error)
+
+	// ListInfoHistoryWithResponse request
+	ListInfoHistoryWithResponse(ctx context.Context, params *ListInfoHistoryParams, reqEditors ...RequestEditorFn) (*ListInfoHistoryResponse, error)
+
+	// GetInfoStatusWithResponse request
+	GetInfoStatusWithResponse(ctx context.Context, uuid openapi_types.UUID, reqEditors ...RequestEditorFn) (*GetInfoStatusResponse, error)
+
+	// AnnotateInfoWithBodyWithResponse request with any body
+	AnnotateInfoWithBodyWithResponse(ctx context.Context, uuid openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*AnnotateInfoResponse, error)
+
+	AnnotateInfoWithResponse(ctx context.Context, uuid openapi_types.UUID, body AnnotateInfoJSONRequestBody, reqEditors ...RequestEditorFn) (*AnnotateInfoResponse, error)
+
+	// CancelInfoWithResponse request
+	CancelInfoWithResponse(ctx context.Context, uuid openapi_types.UUID, reqEditors ...RequestEditorFn) (*CancelInfoResponse, error)
+
+	// GetInfoHistoryWithResponse request
+	GetInfoHistoryWithResponse(ctx context.Context, uuid openapi_types.UUID, reqEditors ...RequestEditorFn) (*GetInfoHistoryResponse, error)
+
+	// ReprobeInfoWithBodyWithResponse request with any body
+	ReprobeInfoWithBodyWithResponse(ctx context.Context, uuid openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*ReprobeInfoResponse, error)
+
+	ReprobeInfoWithResponse(ctx context.Context, uuid openapi_types.UUID, body ReprobeInfoJSONRequestBody, reqEditors ...RequestEditorFn) (*ReprobeInfoResponse, error)
+
+	// RetryInfoWithResponse request
+	RetryInfoWithResponse(ctx context.Context, uuid openapi_types.UUID, reqEditors ...RequestEditorFn) (*RetryInfoResponse, error)
+
+	// GetReadinessWithResponse request
+	GetReadinessWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetReadinessResponse, error)
+
+	// GetScanPreviewWithResponse request
+	GetScanPreviewWithResponse(ctx context.Context, id int64, reqEditors ...RequestEditorFn) (*GetScanPreviewResponse, error)
+
+	// ListSchedulesWithResponse request
+	ListSchedulesWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ListSchedulesResponse, error)
+
+	// CreateScheduleWithBodyWithResponse request with any body
+	CreateScheduleWithBodyWithResponse(ctx context.Context, params *CreateScheduleParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateScheduleResponse, error)
+
+	CreateScheduleWithResponse(ctx context.Context, params *CreateScheduleParams, body CreateScheduleJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateScheduleResponse, error)
+
+	// DeleteScheduleWithResponse request
+	DeleteScheduleWithResponse(ctx context.Context, id openapi_types.UUID, reqEditors ...RequestEditorFn) (*DeleteScheduleResponse, error)
+
+	// RegisterAgentWithBodyWithResponse request with any body
+	RegisterAgentWithBodyWithResponse(ctx context.Context, workerId string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*RegisterAgentResponse, error)
+
+	RegisterAgentWithResponse(ctx context.Context, workerId string, body RegisterAgentJSONRequestBody, reqEditors ...RequestEditorFn) (*RegisterAgentResponse, error)
+
+	// ClaimWorkerJobWithBodyWithResponse request with any body
+	ClaimWorkerJobWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*ClaimWorkerJobResponse, error)
+
+	ClaimWorkerJobWithResponse(ctx context.Context, body ClaimWorkerJobJSONRequestBody, reqEditors ...RequestEditorFn) (*ClaimWorkerJobResponse, error)
+
+	// CompleteWorkerJobWithBodyWithResponse request with any body
+	CompleteWorkerJobWithBodyWithResponse(ctx context.Context, jobId int64, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CompleteWorkerJobResponse, error)
+
+	CompleteWorkerJobWithResponse(ctx context.Context, jobId int64, body CompleteWorkerJobJSONRequestBody, reqEditors ...RequestEditorFn) (*CompleteWorkerJobResponse, error)
+}
+
+type GetSigningKeyResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *SigningKey
+	JSON404      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetSigningKeyResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetSigningKeyResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ListAgentsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *AgentList
+	JSON500      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r ListAgentsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ListAgentsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetCanaryReportResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *CanaryReport
+	JSON500      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetCanaryReportResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetCanaryReportResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type CreateComparisonResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON201      *Comparison
+	JSON400      *Error
+	JSON429      *QueueFull
+	JSON500      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r CreateComparisonResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r CreateComparisonResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetComparisonResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Comparison
+	JSON404      *Error
+	JSON500      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetComparisonResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetComparisonResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ListFailuresResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *FailureSummary
+	JSON500      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r ListFailuresResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ListFailuresResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type RetryFailuresResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *RetryFailuresResult
+	JSON400      *Error
+	JSON500      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r RetryFailuresResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r RetryFailuresResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PurgeInfoResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *PurgeResult
+	JSON400      *Error
+	JSON500      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r PurgeInfoResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PurgeInfoResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ListReprobeCampaignsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *ReprobeCampaignList
+	JSON500      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r ListReprobeCampaignsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ListReprobeCampaignsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type CreateReprobeCampaignResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON201      *ReprobeCampaign
+	JSON400      *Error
+	JSON500      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r CreateReprobeCampaignResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r CreateReprobeCampaignResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type DeleteReprobeCampaignResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON404      *Error
+	JSON500      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r DeleteReprobeCampaignResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DeleteReprobeCampaignResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetSupportBundleResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON500      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetSupportBundleResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetSupportBundleResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ExportTimelineResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *map[string]interface{}
+	JSON400      *Error
+	JSON500      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r ExportTimelineResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ExportTimelineResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ListWebhookBreakersResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]WebhookBreaker
+	JSON500      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r ListWebhookBreakersResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ListWebhookBreakersResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ListWebhookTargetsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]WebhookTargetStats
+	JSON400      *Error
+	JSON500      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r ListWebhookTargetsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ListWebhookTargetsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ListUndeliveredWebhooksResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]UndeliveredWebhook
+	JSON400      *Error
+	JSON500      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r ListUndeliveredWebhooksResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ListUndeliveredWebhooksResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetHealthResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *HealthStatus
+}
+
+// Status returns HTTPResponse.Status
+func (r GetHealthResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetHealthResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ListInfoResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *InfoJobList
+	JSON400      *Error
+	JSON500      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r ListInfoResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ListInfoResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type CreateInfoResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON201      *InfoJob
+	JSON400      *Error
+	JSON409      *Error
+	JSON429      *QueueFull
+	JSON500      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r CreateInfoResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r CreateInfoResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type CreateInfoBatchResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *InfoBatchResult
+	JSON400      *Error
+	JSON429      *QueueFull
+	JSON500      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r CreateInfoBatchResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r CreateInfoBatchResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetInfoStatusByExternalIdResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *InfoJob
+	JSON404      *Error
+	JSON500      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetInfoStatusByExternalIdResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetInfoStatusByExternalIdResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type DiffInfoResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *ResultDiff
+	JSON404      *Error
+	JSON409      *Error
+	JSON500      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r DiffInfoResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DiffInfoResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ListInfoHistoryResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *InfoJobList
+	JSON400      *Error
+	JSON500      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r ListInfoHistoryResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ListInfoHistoryResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetInfoStatusResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *InfoJob
+	JSON404      *Error
+	JSON500      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetInfoStatusResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetInfoStatusResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type AnnotateInfoResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Annotations
+	JSON400      *Error
+	JSON404      *Error
+	JSON500      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r AnnotateInfoResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r AnnotateInfoResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type CancelInfoResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *InfoJob
+	JSON404      *Error
+	JSON409      *Error
+	JSON500      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r CancelInfoResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r CancelInfoResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetInfoHistoryResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *InfoJobHistory
+	JSON404      *Error
+	JSON500      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetInfoHistoryResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetInfoHistoryResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ReprobeInfoResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON201      *InfoJob
+	JSON400      *Error
+	JSON404      *Error
+	JSON409      *Error
+	JSON429      *QueueFull
+	JSON500      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r ReprobeInfoResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ReprobeInfoResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type RetryInfoResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *InfoJob
+	JSON404      *Error
+	JSON409      *Error
+	JSON500      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r RetryInfoResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r RetryInfoResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetReadinessResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *HealthStatus
+	JSON503      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetReadinessResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetReadinessResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetScanPreviewResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *ScanPreview
+	JSON404      *Error
+	JSON500      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetScanPreviewResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetScanPreviewResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ListSchedulesResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *ScheduleList
+	JSON500      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r ListSchedulesResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ListSchedulesResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type CreateScheduleResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON201      *Schedule
+	JSON202      *ScanPreview
+	JSON400      *Error
+	JSON500      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r CreateScheduleResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r CreateScheduleResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type DeleteScheduleResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON404      *Error
+	JSON500      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r DeleteScheduleResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DeleteScheduleResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type RegisterAgentResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Agent
+	JSON400      *Error
+	JSON401      *Error
+	JSON500      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r RegisterAgentResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r RegisterAgentResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ClaimWorkerJobResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *WorkerJob
+	JSON400      *Error
+	JSON401      *Error
+	JSON500      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r ClaimWorkerJobResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ClaimWorkerJobResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type CompleteWorkerJobResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON400      *Error
+	JSON401      *Error
+	JSON409      *Error
+	JSON500      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r CompleteWorkerJobResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r CompleteWorkerJobResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+// GetSigningKeyWithResponse request returning *GetSigningKeyResponse
+func (c *ClientWithResponses) GetSigningKeyWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetSigningKeyResponse, error) {
+	rsp, err := c.GetSigningKey(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetSigningKeyResponse(rsp)
+}
+
+// ListAgentsWithResponse request returning *ListAgentsResponse
+func (c *ClientWithResponses) ListAgentsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ListAgentsResponse, error) {
+	rsp, err := c.ListAgents(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseListAgentsResponse(rsp)
+}
+
+// GetCanaryReportWithResponse request returning *GetCanaryReportResponse
+func (c *ClientWithResponses) GetCanaryReportWithResponse(ctx context.Context, params *GetCanaryReportParams, reqEditors ...RequestEditorFn) (*GetCanaryReportResponse, error) {
+	rsp, err := c.GetCanaryReport(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetCanaryReportResponse(rsp)
+}
+
+// CreateComparisonWithBodyWithResponse request with arbitrary body returning *CreateComparisonResponse
+func (c *ClientWithResponses) CreateComparisonWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateComparisonResponse, error) {
+	rsp, err := c.CreateComparisonWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateComparisonResponse(rsp)
+}
+
+func (c *ClientWithResponses) CreateComparisonWithResponse(ctx context.Context, body CreateComparisonJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateComparisonResponse, error) {
+	rsp, err := c.CreateComparison(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateComparisonResponse(rsp)
+}
+
+// GetComparisonWithResponse request returning *GetComparisonResponse
+func (c *ClientWithResponses) GetComparisonWithResponse(ctx context.Context, id openapi_types.UUID, reqEditors ...RequestEditorFn) (*GetComparisonResponse, error) {
+	rsp, err := c.GetComparison(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetComparisonResponse(rsp)
+}
+
+// ListFailuresWithResponse request returning *ListFailuresResponse
+func (c *ClientWithResponses) ListFailuresWithResponse(ctx context.Context, params *ListFailuresParams, reqEditors ...RequestEditorFn) (*ListFailuresResponse, error) {
+	rsp, err := c.ListFailures(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseListFailuresResponse(rsp)
+}
+
+// RetryFailuresWithBodyWithResponse request with arbitrary body returning *RetryFailuresResponse
+func (c *ClientWithResponses) RetryFailuresWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*RetryFailuresResponse, error) {
+	rsp, err := c.RetryFailuresWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseRetryFailuresResponse(rsp)
+}
+
+func (c *ClientWithResponses) RetryFailuresWithResponse(ctx context.Context, body RetryFailuresJSONRequestBody, reqEditors ...RequestEditorFn) (*RetryFailuresResponse, error) {
+	rsp, err := c.RetryFailures(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseRetryFailuresResponse(rsp)
+}
+
+// PurgeInfoWithBodyWithResponse request with arbitrary body returning *PurgeInfoResponse
+func (c *ClientWithResponses) PurgeInfoWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PurgeInfoResponse, error) {
+	rsp, err := c.PurgeInfoWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePurgeInfoResponse(rsp)
+}
+
+func (c *ClientWithResponses) PurgeInfoWithResponse(ctx context.Context, body PurgeInfoJSONRequestBody, reqEditors ...RequestEditorFn) (*PurgeInfoResponse, error) {
+	rsp, err := c.PurgeInfo(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePurgeInfoResponse(rsp)
+}
+
+// ListReprobeCampaignsWithResponse request returning *ListReprobeCampaignsResponse
+func (c *ClientWithResponses) ListReprobeCampaignsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ListReprobeCampaignsResponse, error) {
+	rsp, err := c.ListReprobeCampaigns(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseListReprobeCampaignsResponse(rsp)
+}
+
+// CreateReprobeCampaignWithBodyWithResponse request with arbitrary body returning *CreateReprobeCampaignResponse
+func (c *ClientWithResponses) CreateReprobeCampaignWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateReprobeCampaignResponse, error) {
+	rsp, err := c.CreateReprobeCampaignWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateReprobeCampaignResponse(rsp)
+}
+
+func (c *ClientWithResponses) CreateReprobeCampaignWithResponse(ctx context.Context, body CreateReprobeCampaignJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateReprobeCampaignResponse, error) {
+	rsp, err := c.CreateReprobeCampaign(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateReprobeCampaignResponse(rsp)
+}
+
+// DeleteReprobeCampaignWithResponse request returning *DeleteReprobeCampaignResponse
+func (c *ClientWithResponses) DeleteReprobeCampaignWithResponse(ctx context.Context, id openapi_types.UUID, reqEditors ...RequestEditorFn) (*DeleteReprobeCampaignResponse, error) {
+	rsp, err := c.DeleteReprobeCampaign(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDeleteReprobeCampaignResponse(rsp)
+}
+
+// GetSupportBundleWithResponse request returning *GetSupportBundleResponse
+func (c *ClientWithResponses) GetSupportBundleWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetSupportBundleResponse, error) {
+	rsp, err := c.GetSupportBundle(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetSupportBundleResponse(rsp)
+}
+
+// ExportTimelineWithResponse request returning *ExportTimelineResponse
+func (c *ClientWithResponses) ExportTimelineWithResponse(ctx context.Context, params *ExportTimelineParams, reqEditors ...RequestEditorFn) (*ExportTimelineResponse, error) {
+	rsp, err := c.ExportTimeline(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseExportTimelineResponse(rsp)
+}
+
+// ListWebhookBreakersWithResponse request returning *ListWebhookBreakersResponse
+func (c *ClientWithResponses) ListWebhookBreakersWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ListWebhookBreakersResponse, error) {
+	rsp, err := c.ListWebhookBreakers(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseListWebhookBreakersResponse(rsp)
+}
+
+// ListWebhookTargetsWithResponse request returning *ListWebhookTargetsResponse
+func (c *ClientWithResponses) ListWebhookTargetsWithResponse(ctx context.Context, params *ListWebhookTargetsParams, reqEditors ...RequestEditorFn) (*ListWebhookTargetsResponse, error) {
+	rsp, err := c.ListWebhookTargets(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseListWebhookTargetsResponse(rsp)
+}
+
+// ListUndeliveredWebhooksWithResponse request returning *ListUndeliveredWebhooksResponse
+func (c *ClientWithResponses) ListUndeliveredWebhooksWithResponse(ctx context.Context, params *ListUndeliveredWebhooksParams, reqEditors ...RequestEditorFn) (*ListUndeliveredWebhooksResponse, error) {
+	rsp, err := c.ListUndeliveredWebhooks(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseListUndeliveredWebhooksResponse(rsp)
+}
+
+// GetHealthWithResponse request returning *GetHealthResponse
+func (c *ClientWithResponses) GetHealthWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetHealthResponse, error) {
+	rsp, err := c.GetHealth(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetHealthResponse(rsp)
+}
+
+// ListInfoWithResponse request returning *ListInfoResponse
+func (c *ClientWithResponses) ListInfoWithResponse(ctx context.Context, params *ListInfoParams, reqEditors ...RequestEditorFn) (*ListInfoResponse, error) {
+	rsp, err := c.ListInfo(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseListInfoResponse(rsp)
+}
+
+// CreateInfoWithBodyWithResponse request with arbitrary body returning *CreateInfoResponse
+func (c *ClientWithResponses) CreateInfoWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateInfoResponse, error) {
+	rsp, err := c.CreateInfoWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateInfoResponse(rsp)
+}
+
+func (c *ClientWithResponses) CreateInfoWithResponse(ctx context.Context, body CreateInfoJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateInfoResponse, error) {
+	rsp, err := c.CreateInfo(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateInfoResponse(rsp)
+}
+
+// CreateInfoBatchWithBodyWithResponse request with arbitrary body returning *CreateInfoBatchResponse
+func (c *ClientWithResponses) CreateInfoBatchWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateInfoBatchResponse, error) {
+	rsp, err := c.CreateInfoBatchWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateInfoBatchResponse(rsp)
+}
+
+func (c *ClientWithResponses) CreateInfoBatchWithResponse(ctx context.Context, body CreateInfoBatchJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateInfoBatchResponse, error) {
+	rsp, err := c.CreateInfoBatch(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateInfoBatchResponse(rsp)
+}
+
+// GetInfoStatusByExternalIdWithResponse request returning *GetInfoStatusByExternalIdResponse
+func (c *ClientWithResponses) GetInfoStatusByExternalIdWithResponse(ctx context.Context, externalId string, reqEditors ...RequestEditorFn) (*GetInfoStatusByExternalIdResponse, error) {
+	rsp, err := c.GetInfoStatusByExternalId(ctx, externalId, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetInfoStatusByExternalIdResponse(rsp)
+}
+
+// DiffInfoWithResponse request returning *DiffInfoResponse
+func (c *ClientWithResponses) DiffInfoWithResponse(ctx context.Context, params *DiffInfoParams, reqEditors ...RequestEditorFn) (*DiffInfoResponse, error) {
+	rsp, err := c.DiffInfo(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDiffInfoResponse(rsp)
+}
+
+// ListInfoHistoryWithResponse request returning *ListInfoHistoryResponse
+func (c *ClientWithResponses) ListInfoHistoryWithResponse(ctx context.Context, params *ListInfoHistoryParams, reqEditors ...RequestEditorFn) (*ListInfoHistoryResponse, error) {
+	rsp, err := c.ListInfoHistory(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseListInfoHistoryResponse(rsp)
+}
+
+// GetInfoStatusWithResponse request returning *GetInfoStatusResponse
+func (c *ClientWithResponses) GetInfoStatusWithResponse(ctx context.Context, uuid openapi_types.UUID, reqEditors ...RequestEditorFn) (*GetInfoStatusResponse, error) {
+	rsp, err := c.GetInfoStatus(ctx, uuid, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetInfoStatusResponse(rsp)
+}
+
+// AnnotateInfoWithBodyWithResponse request with arbitrary body returning *AnnotateInfoResponse
+func (c *ClientWithResponses) AnnotateInfoWithBodyWithResponse(ctx context.Context, uuid openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*AnnotateInfoResponse, error) {
+	rsp, err := c.AnnotateInfoWithBody(ctx, uuid, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseAnnotateInfoResponse(rsp)
+}
+
+func (c *ClientWithResponses) AnnotateInfoWithResponse(ctx context.Context, uuid openapi_types.UUID, body AnnotateInfoJSONRequestBody, reqEditors ...RequestEditorFn) (*AnnotateInfoResponse, error) {
+	rsp, err := c.AnnotateInfo(ctx, uuid, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseAnnotateInfoResponse(rsp)
+}
+
+// CancelInfoWithResponse request returning *CancelInfoResponse
+func (c *ClientWithResponses) CancelInfoWithResponse(ctx context.Context, uuid openapi_types.UUID, reqEditors ...RequestEditorFn) (*CancelInfoResponse, error) {
+	rsp, err := c.CancelInfo(ctx, uuid, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCancelInfoResponse(rsp)
+}
+
+// GetInfoHistoryWithResponse request returning *GetInfoHistoryResponse
+func (c *ClientWithResponses) GetInfoHistoryWithResponse(ctx context.Context, uuid openapi_types.UUID, reqEditors ...RequestEditorFn) (*GetInfoHistoryResponse, error) {
+	rsp, err := c.GetInfoHistory(ctx, uuid, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetInfoHistoryResponse(rsp)
+}
+
+// ReprobeInfoWithBodyWithResponse request with arbitrary body returning *ReprobeInfoResponse
+func (c *ClientWithResponses) ReprobeInfoWithBodyWithResponse(ctx context.Context, uuid openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*ReprobeInfoResponse, error) {
+	rsp, err := c.ReprobeInfoWithBody(ctx, uuid, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseReprobeInfoResponse(rsp)
+}
+
+func (c *ClientWithResponses) ReprobeInfoWithResponse(ctx context.Context, uuid openapi_types.UUID, body ReprobeInfoJSONRequestBody, reqEditors ...RequestEditorFn) (*ReprobeInfoResponse, error) {
+	rsp, err := c.ReprobeInfo(ctx, uuid, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseReprobeInfoResponse(rsp)
+}
+
+// RetryInfoWithResponse request returning *RetryInfoResponse
+func (c *ClientWithResponses) RetryInfoWithResponse(ctx context.Context, uuid openapi_types.UUID, reqEditors ...RequestEditorFn) (*RetryInfoResponse, error) {
+	rsp, err := c.RetryInfo(ctx, uuid, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseRetryInfoResponse(rsp)
+}
+
+// GetReadinessWithResponse request returning *GetReadinessResponse
+func (c *ClientWithResponses) GetReadinessWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetReadinessResponse, error) {
+	rsp, err := c.GetReadiness(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetReadinessResponse(rsp)
+}
+
+// GetScanPreviewWithResponse request returning *GetScanPreviewResponse
+func (c *ClientWithResponses) GetScanPreviewWithResponse(ctx context.Context, id int64, reqEditors ...RequestEditorFn) (*GetScanPreviewResponse, error) {
+	rsp, err := c.GetScanPreview(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetScanPreviewResponse(rsp)
+}
+
+// ListSchedulesWithResponse request returning *ListSchedulesResponse
+func (c *ClientWithResponses) ListSchedulesWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ListSchedulesResponse, error) {
+	rsp, err := c.ListSchedules(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseListSchedulesResponse(rsp)
+}
+
+// CreateScheduleWithBodyWithResponse request with arbitrary body returning *CreateScheduleResponse
+func (c *ClientWithResponses) CreateScheduleWithBodyWithResponse(ctx context.Context, params *CreateScheduleParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateScheduleResponse, error) {
+	rsp, err := c.CreateScheduleWithBody(ctx, params, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateScheduleResponse(rsp)
+}
+
+func (c *ClientWithResponses) CreateScheduleWithResponse(ctx context.Context, params *CreateScheduleParams, body CreateScheduleJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateScheduleResponse, error) {
+	rsp, err := c.CreateSchedule(ctx, params, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateScheduleResponse(rsp)
+}
+
+// DeleteScheduleWithResponse request returning *DeleteScheduleResponse
+func (c *ClientWithResponses) DeleteScheduleWithResponse(ctx context.Context, id openapi_types.UUID, reqEditors ...RequestEditorFn) (*DeleteScheduleResponse, error) {
+	rsp, err := c.DeleteSchedule(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDeleteScheduleResponse(rsp)
+}
+
+// RegisterAgentWithBodyWithResponse request with arbitrary body returning *RegisterAgentResponse
+func (c *ClientWithResponses) RegisterAgentWithBodyWithResponse(ctx context.Context, workerId string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*RegisterAgentResponse, error) {
+	rsp, err := c.RegisterAgentWithBody(ctx, workerId, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseRegisterAgentResponse(rsp)
+}
+
+func (c *ClientWithResponses) RegisterAgentWithResponse(ctx context.Context, workerId string, body RegisterAgentJSONRequestBody, reqEditors ...RequestEditorFn) (*RegisterAgentResponse, error) {
+	rsp, err := c.RegisterAgent(ctx, workerId, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseRegisterAgentResponse(rsp)
+}
+
+// ClaimWorkerJobWithBodyWithResponse request with arbitrary body returning *ClaimWorkerJobResponse
+func (c *ClientWithResponses) ClaimWorkerJobWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*ClaimWorkerJobResponse, error) {
+	rsp, err := c.ClaimWorkerJobWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseClaimWorkerJobResponse(rsp)
+}
+
+func (c *ClientWithResponses) ClaimWorkerJobWithResponse(ctx context.Context, body ClaimWorkerJobJSONRequestBody, reqEditors ...RequestEditorFn) (*ClaimWorkerJobResponse, error) {
+	rsp, err := c.ClaimWorkerJob(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseClaimWorkerJobResponse(rsp)
+}
+
+// CompleteWorkerJobWithBodyWithResponse request with arbitrary body returning *CompleteWorkerJobResponse
+func (c *ClientWithResponses) CompleteWorkerJobWithBodyWithResponse(ctx context.Context, jobId int64, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CompleteWorkerJobResponse, error) {
+	rsp, err := c.CompleteWorkerJobWithBody(ctx, jobId, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCompleteWorkerJobResponse(rsp)
+}
+
+func (c *ClientWithResponses) CompleteWorkerJobWithResponse(ctx context.Context, jobId int64, body CompleteWorkerJobJSONRequestBody, reqEditors ...RequestEditorFn) (*CompleteWorkerJobResponse, error) {
+	rsp, err := c.CompleteWorkerJob(ctx, jobId, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCompleteWorkerJobResponse(rsp)
+}
+
+// ParseGetSigningKeyResponse parses an HTTP response from a GetSigningKeyWithResponse call
+func ParseGetSigningKeyResponse(rsp *http.Response) (*GetSigningKeyResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetSigningKeyResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest SigningKey
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseListAgentsResponse parses an HTTP response from a ListAgentsWithResponse call
+func ParseListAgentsResponse(rsp *http.Response) (*ListAgentsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ListAgentsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest AgentList
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetCanaryReportResponse parses an HTTP response from a GetCanaryReportWithResponse call
+func ParseGetCanaryReportResponse(rsp *http.Response) (*GetCanaryReportResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetCanaryReportResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest CanaryReport
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseCreateComparisonResponse parses an HTTP response from a CreateComparisonWithResponse call
+func ParseCreateComparisonResponse(rsp *http.Response) (*CreateComparisonResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &CreateComparisonResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
+		var dest Comparison
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON201 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 429:
+		var dest QueueFull
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON429 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetComparisonResponse parses an HTTP response from a GetComparisonWithResponse call
+func ParseGetComparisonResponse(rsp *http.Response) (*GetComparisonResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetComparisonResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Comparison
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseListFailuresResponse parses an HTTP response from a ListFailuresWithResponse call
+func ParseListFailuresResponse(rsp *http.Response) (*ListFailuresResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ListFailuresResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest FailureSummary
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseRetryFailuresResponse parses an HTTP response from a RetryFailuresWithResponse call
+func ParseRetryFailuresResponse(rsp *http.Response) (*RetryFailuresResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &RetryFailuresResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest RetryFailuresResult
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePurgeInfoResponse parses an HTTP response from a PurgeInfoWithResponse call
+func ParsePurgeInfoResponse(rsp *http.Response) (*PurgeInfoResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PurgeInfoResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest PurgeResult
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseListReprobeCampaignsResponse parses an HTTP response from a ListReprobeCampaignsWithResponse call
+func ParseListReprobeCampaignsResponse(rsp *http.Response) (*ListReprobeCampaignsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ListReprobeCampaignsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest ReprobeCampaignList
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseCreateReprobeCampaignResponse parses an HTTP response from a CreateReprobeCampaignWithResponse call
+func ParseCreateReprobeCampaignResponse(rsp *http.Response) (*CreateReprobeCampaignResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &CreateReprobeCampaignResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
+		var dest ReprobeCampaign
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON201 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseDeleteReprobeCampaignResponse parses an HTTP response from a DeleteReprobeCampaignWithResponse call
+func ParseDeleteReprobeCampaignResponse(rsp *http.Response) (*DeleteReprobeCampaignResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &DeleteReprobeCampaignResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetSupportBundleResponse parses an HTTP response from a GetSupportBundleWithResponse call
+func ParseGetSupportBundleResponse(rsp *http.Response) (*GetSupportBundleResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetSupportBundleResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseExportTimelineResponse parses an HTTP response from a ExportTimelineWithResponse call
+func ParseExportTimelineResponse(rsp *http.Response) (*ExportTimelineResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ExportTimelineResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest map[string]interface{}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	case rsp.StatusCode == 200:
+		// Content-type (text/csv) unsupported
+
+	}
+
+	return response, nil
+}
+
+// ParseListWebhookBreakersResponse parses an HTTP response from a ListWebhookBreakersWithResponse call
+func ParseListWebhookBreakersResponse(rsp *http.Response) (*ListWebhookBreakersResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ListWebhookBreakersResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []WebhookBreaker
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseListWebhookTargetsResponse parses an HTTP response from a ListWebhookTargetsWithResponse call
+func ParseListWebhookTargetsResponse(rsp *http.Response) (*ListWebhookTargetsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ListWebhookTargetsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []WebhookTargetStats
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseListUndeliveredWebhooksResponse parses an HTTP response from a ListUndeliveredWebhooksWithResponse call
+func ParseListUndeliveredWebhooksResponse(rsp *http.Response) (*ListUndeliveredWebhooksResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ListUndeliveredWebhooksResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []UndeliveredWebhook
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetHealthResponse parses an HTTP response from a GetHealthWithResponse call
+func ParseGetHealthResponse(rsp *http.Response) (*GetHealthResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetHealthResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest HealthStatus
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseListInfoResponse parses an HTTP response from a ListInfoWithResponse call
+func ParseListInfoResponse(rsp *http.Response) (*ListInfoResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ListInfoResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest InfoJobList
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseCreateInfoResponse parses an HTTP response from a CreateInfoWithResponse call
+func ParseCreateInfoResponse(rsp *http.Response) (*CreateInfoResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &CreateInfoResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
+		var dest InfoJob
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON201 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON409 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 429:
+		var dest QueueFull
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON429 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseCreateInfoBatchResponse parses an HTTP response from a CreateInfoBatchWithResponse call
+func ParseCreateInfoBatchResponse(rsp *http.Response) (*CreateInfoBatchResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &CreateInfoBatchResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest InfoBatchResult
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 429:
+		var dest QueueFull
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON429 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetInfoStatusByExternalIdResponse parses an HTTP response from a GetInfoStatusByExternalIdWithResponse call
+func ParseGetInfoStatusByExternalIdResponse(rsp *http.Response) (*GetInfoStatusByExternalIdResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetInfoStatusByExternalIdResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest InfoJob
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseDiffInfoResponse parses an HTTP response from a DiffInfoWithResponse call
+func ParseDiffInfoResponse(rsp *http.Response) (*DiffInfoResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &DiffInfoResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest ResultDiff
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON409 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseListInfoHistoryResponse parses an HTTP response from a ListInfoHistoryWithResponse call
+func ParseListInfoHistoryResponse(rsp *http.Response) (*ListInfoHistoryResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ListInfoHistoryResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest InfoJobList
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetInfoStatusResponse parses an HTTP response from a GetInfoStatusWithResponse call
+func ParseGetInfoStatusResponse(rsp *http.Response) (*GetInfoStatusResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetInfoStatusResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest InfoJob
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseAnnotateInfoResponse parses an HTTP response from a AnnotateInfoWithResponse call
+func ParseAnnotateInfoResponse(rsp *http.Response) (*AnnotateInfoResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &AnnotateInfoResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Annotations
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseCancelInfoResponse parses an HTTP response from a CancelInfoWithResponse call
+func ParseCancelInfoResponse(rsp *http.Response) (*CancelInfoResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &CancelInfoResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest InfoJob
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON409 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetInfoHistoryResponse parses an HTTP response from a GetInfoHistoryWithResponse call
+func ParseGetInfoHistoryResponse(rsp *http.Response) (*GetInfoHistoryResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetInfoHistoryResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest InfoJobHistory
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseReprobeInfoResponse parses an HTTP response from a ReprobeInfoWithResponse call
+func ParseReprobeInfoResponse(rsp *http.Response) (*ReprobeInfoResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ReprobeInfoResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
+		var dest InfoJob
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON201 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON409 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 429:
+		var dest QueueFull
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON429 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseRetryInfoResponse parses an HTTP response from a RetryInfoWithResponse call
+func ParseRetryInfoResponse(rsp *http.Response) (*RetryInfoResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &RetryInfoResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest InfoJob
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON409 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetReadinessResponse parses an HTTP response from a GetReadinessWithResponse call
+func ParseGetReadinessResponse(rsp *http.Response) (*GetReadinessResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetReadinessResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest HealthStatus
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 503:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON503 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetScanPreviewResponse parses an HTTP response from a GetScanPreviewWithResponse call
+func ParseGetScanPreviewResponse(rsp *http.Response) (*GetScanPreviewResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetScanPreviewResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest ScanPreview
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseListSchedulesResponse parses an HTTP response from a ListSchedulesWithResponse call
+func ParseListSchedulesResponse(rsp *http.Response) (*ListSchedulesResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ListSchedulesResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest ScheduleList
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseCreateScheduleResponse parses an HTTP response from a CreateScheduleWithResponse call
+func ParseCreateScheduleResponse(rsp *http.Response) (*CreateScheduleResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &CreateScheduleResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
+		var dest Schedule
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON201 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 202:
+		var dest ScanPreview
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON202 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseDeleteScheduleResponse parses an HTTP response from a DeleteScheduleWithResponse call
+func ParseDeleteScheduleResponse(rsp *http.Response) (*DeleteScheduleResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &DeleteScheduleResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseRegisterAgentResponse parses an HTTP response from a RegisterAgentWithResponse call
+func ParseRegisterAgentResponse(rsp *http.Response) (*RegisterAgentResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &RegisterAgentResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Agent
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseClaimWorkerJobResponse parses an HTTP response from a ClaimWorkerJobWithResponse call
+func ParseClaimWorkerJobResponse(rsp *http.Response) (*ClaimWorkerJobResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ClaimWorkerJobResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest WorkerJob
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseCompleteWorkerJobResponse parses an HTTP response from a CompleteWorkerJobWithResponse call
+func ParseCompleteWorkerJobResponse(rsp *http.Response) (*CompleteWorkerJobResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &CompleteWorkerJobResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON409 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ServerInterface represents all server handlers.
+type ServerInterface interface {
+	// Get the result signing key
+	// (GET /.well-known/video-info-signing-key)
+	GetSigningKey(w http.ResponseWriter, r *http.Request)
+	// List registered workers
+	// (GET /admin/agents)
+	ListAgents(w http.ResponseWriter, r *http.Request)
+	// Compare canary results with the results they would replace
+	// (GET /admin/canary-report)
+	GetCanaryReport(w http.ResponseWriter, r *http.Request, params GetCanaryReportParams)
+	// Compare two ways of probing a file
+	// (POST /admin/comparisons)
+	CreateComparison(w http.ResponseWriter, r *http.Request)
+	// Get a comparison
+	// (GET /admin/comparisons/{id})
+	GetComparison(w http.ResponseWriter, r *http.Request, id openapi_types.UUID)
+	// Summarize failed info jobs
+	// (GET /admin/failures)
+	ListFailures(w http.ResponseWriter, r *http.Request, params ListFailuresParams)
+	// Retry failed info jobs in bulk
+	// (POST /admin/failures/retry)
+	RetryFailures(w http.ResponseWriter, r *http.Request)
+	// Purge stored data for a video path
+	// (POST /admin/purge)
+	PurgeInfo(w http.ResponseWriter, r *http.Request)
+	// List reprobe campaigns
+	// (GET /admin/reprobe-campaigns)
+	ListReprobeCampaigns(w http.ResponseWriter, r *http.Request)
+	// Start a reprobe campaign
+	// (POST /admin/reprobe-campaigns)
+	CreateReprobeCampaign(w http.ResponseWriter, r *http.Request)
+	// Delete a reprobe campaign
+	// (DELETE /admin/reprobe-campaigns/{id})
+	DeleteReprobeCampaign(w http.ResponseWriter, r *http.Request, id openapi_types.UUID)
+	// Download a support bundle
+	// (GET /admin/support-bundle)
+	GetSupportBundle(w http.ResponseWriter, r *http.Request)
+	// Export a job timeline
+	// (GET /admin/timeline)
+	ExportTimeline(w http.ResponseWriter, r *http.Request, params ExportTimelineParams)
+	// Report the circuit breakers of webhook targets
+	// (GET /admin/webhooks/breakers)
+	ListWebhookBreakers(w http.ResponseWriter, r *http.Request)
+	// Report webhook delivery statistics per target
+	// (GET /admin/webhooks/targets)
+	ListWebhookTargets(w http.ResponseWriter, r *http.Request, params ListWebhookTargetsParams)
+	// List undelivered webhooks
+	// (GET /admin/webhooks/undelivered)
+	ListUndeliveredWebhooks(w http.ResponseWriter, r *http.Request, params ListUndeliveredWebhooksParams)
+	// Liveness probe
+	// (GET /healthz)
+	GetHealth(w http.ResponseWriter, r *http.Request)
+	// List video info jobs
+	// (GET /info)
+	ListInfo(w http.ResponseWriter, r *http.Request, params ListInfoParams)
+	// Start a new video info extraction job
+	// (POST /info)
+	CreateInfo(w http.ResponseWriter, r *http.Request)
+	// Start many video info extraction jobs at once
+	// (POST /info/batch)
+	CreateInfoBatch(w http.ResponseWriter, r *http.Request)
+	// Get video info job status by external ID
+	// (GET /info/by-external-id/{externalId})
+	GetInfoStatusByExternalId(w http.ResponseWriter, r *http.Request, externalId string)
+	// Compare the results of two info jobs
+	// (GET /info/diff)
+	DiffInfo(w http.ResponseWriter, r *http.Request, params DiffInfoParams)
+	// List the stored results for a video path
+	// (GET /info/history)
+	ListInfoHistory(w http.ResponseWriter, r *http.Request, params ListInfoHistoryParams)
+	// Get video info job status
+	// (GET /info/{uuid})
+	GetInfoStatus(w http.ResponseWriter, r *http.Request, uuid openapi_types.UUID)
+	// Annotate a video info job
+	// (PATCH /info/{uuid}/annotations)
+	AnnotateInfo(w http.ResponseWriter, r *http.Request, uuid openapi_types.UUID)
+	// Cancel a video info job
+	// (POST /info/{uuid}/cancel)
+	CancelInfo(w http.ResponseWriter, r *http.Request, uuid openapi_types.UUID)
+	// Get the result history of a video info job
+	// (GET /info/{uuid}/history)
+	GetInfoHistory(w http.ResponseWriter, r *http.Request, uuid openapi_types.UUID)
+	// Probe a video again, superseding an earlier result
+	// (POST /info/{uuid}/reprobe)
+	ReprobeInfo(w http.ResponseWriter, r *http.Request, uuid openapi_types.UUID)
+	// Retry a failed video info job
+	// (POST /info/{uuid}/retry)
+	RetryInfo(w http.ResponseWriter, r *http.Request, uuid openapi_types.UUID)
+	// Readiness probe
+	// (GET /readyz)
+	GetReadiness(w http.ResponseWriter, r *http.Request)
+	// Get a scan preview
+	// (GET /scan-previews/{id})
+	GetScanPreview(w http.ResponseWriter, r *http.Request, id int64)
+	// List schedules
+	// (GET /schedules)
+	ListSchedules(w http.ResponseWriter, r *http.Request)
+	// Create a schedule
+	// (POST /schedules)
+	CreateSchedule(w http.ResponseWriter, r *http.Request, params CreateScheduleParams)
+	// Delete a schedule
+	// (DELETE /schedules/{id})
+	DeleteSchedule(w http.ResponseWriter, r *http.Request, id openapi_types.UUID)
+	// Register or refresh a pull-mode worker
+	// (PUT /worker/agents/{workerId})
+	RegisterAgent(w http.ResponseWriter, r *http.Request, workerId string)
+	// Claim the next pending info job
+	// (POST /worker/claim)
+	ClaimWorkerJob(w http.ResponseWriter, r *http.Request)
+	// Report the outcome of a claimed info job
+	// (POST /worker/jobs/{jobId}/complete)
+	CompleteWorkerJob(w http.ResponseWriter, r *http.Request, jobId int64)
+}
+
+// ServerInterfaceWrapper converts contexts to parameters.
+type ServerInterfaceWrapper struct {
+	Handler            ServerInterface
+	HandlerMiddlewares []MiddlewareFunc
+	ErrorHandlerFunc   func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+type MiddlewareFunc func(http.Handler) http.Handler
+
+// GetSigningKey operation middleware
+func (siw *ServerInterfaceWrapper) GetSigningKey(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetSigningKey(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListAgents operation middleware
+func (siw *ServerInterfaceWrapper) ListAgents(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListAgents(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetCanaryReport operation middleware
+func (siw *ServerInterfaceWrapper) GetCanaryReport(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetCanaryReportParams
+
+	// ------------- Optional query parameter "since" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "since", r.URL.Query(), &params.Since)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "since", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetCanaryReport(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// CreateComparison operation middleware
+func (siw *ServerInterfaceWrapper) CreateComparison(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateComparison(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetComparison operation middleware
+func (siw *ServerInterfaceWrapper) GetComparison(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetComparison(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListFailures operation middleware
+func (siw *ServerInterfaceWrapper) ListFailures(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListFailuresParams
+
+	// ------------- Optional query parameter "pathPrefix" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "pathPrefix", r.URL.Query(), &params.PathPrefix)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "pathPrefix", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "createdAfter" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "createdAfter", r.URL.Query(), &params.CreatedAfter)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "createdAfter", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListFailures(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// RetryFailures operation middleware
+func (siw *ServerInterfaceWrapper) RetryFailures(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.RetryFailures(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PurgeInfo operation middleware
+func (siw *ServerInterfaceWrapper) PurgeInfo(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PurgeInfo(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListReprobeCampaigns operation middleware
+func (siw *ServerInterfaceWrapper) ListReprobeCampaigns(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListReprobeCampaigns(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// CreateReprobeCampaign operation middleware
+func (siw *ServerInterfaceWrapper) CreateReprobeCampaign(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateReprobeCampaign(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DeleteReprobeCampaign operation middleware
+func (siw *ServerInterfaceWrapper) DeleteReprobeCampaign(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteReprobeCampaign(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetSupportBundle operation middleware
+func (siw *ServerInterfaceWrapper) GetSupportBundle(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetSupportBundle(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ExportTimeline operation middleware
+func (siw *ServerInterfaceWrapper) ExportTimeline(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ExportTimelineParams
+
+	// ------------- Optional query parameter "after" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "after", r.URL.Query(), &params.After)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "after", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "before" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "before", r.URL.Query(), &params.Before)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "before", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "format" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "format", r.URL.Query(), &params.Format)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "format", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ExportTimeline(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListWebhookBreakers operation middleware
+func (siw *ServerInterfaceWrapper) ListWebhookBreakers(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListWebhookBreakers(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListWebhookTargets operation middleware
+func (siw *ServerInterfaceWrapper) ListWebhookTargets(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListWebhookTargetsParams
+
+	// ------------- Optional query parameter "since" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "since", r.URL.Query(), &params.Since)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "since", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListWebhookTargets(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListUndeliveredWebhooks operation middleware
+func (siw *ServerInterfaceWrapper) ListUndeliveredWebhooks(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListUndeliveredWebhooksParams
+
+	// ------------- Optional query parameter "uuid" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "uuid", r.URL.Query(), &params.Uuid)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "uuid", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "target" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "target", r.URL.Query(), &params.Target)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "target", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListUndeliveredWebhooks(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetHealth operation middleware
+func (siw *ServerInterfaceWrapper) GetHealth(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetHealth(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListInfo operation middleware
+func (siw *ServerInterfaceWrapper) ListInfo(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListInfoParams
+
+	// ------------- Optional query parameter "status" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "status", r.URL.Query(), &params.Status)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "status", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "videoPath" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "videoPath", r.URL.Query(), &params.VideoPath)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "videoPath", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "pathPrefix" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "pathPrefix", r.URL.Query(), &params.PathPrefix)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "pathPrefix", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "q" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "q", r.URL.Query(), &params.Q)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "q", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "latest" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "latest", r.URL.Query(), &params.Latest)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "latest", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "createdAfter" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "createdAfter", r.URL.Query(), &params.CreatedAfter)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "createdAfter", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "createdBefore" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "createdBefore", r.URL.Query(), &params.CreatedBefore)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "createdBefore", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "label" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "label", r.URL.Query(), &params.Label)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "label", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "acknowledged" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "acknowledged", r.URL.Query(), &params.Acknowledged)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "acknowledged", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "sort" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "sort", r.URL.Query(), &params.Sort)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "sort", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "order" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "order", r.URL.Query(), &params.Order)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "order", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "pageToken" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "pageToken", r.URL.Query(), &params.PageToken)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "pageToken", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListInfo(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// CreateInfo operation middleware
+func (siw *ServerInterfaceWrapper) CreateInfo(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateInfo(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// CreateInfoBatch operation middleware
+func (siw *ServerInterfaceWrapper) CreateInfoBatch(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateInfoBatch(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetInfoStatusByExternalId operation middleware
+func (siw *ServerInterfaceWrapper) GetInfoStatusByExternalId(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "externalId" -------------
+	var externalId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "externalId", r.PathValue("externalId"), &externalId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "externalId", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetInfoStatusByExternalId(w, r, externalId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DiffInfo operation middleware
+func (siw *ServerInterfaceWrapper) DiffInfo(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params DiffInfoParams
+
+	// ------------- Required query parameter "from" -------------
+
+	if paramValue := r.URL.Query().Get("from"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "from"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "from", r.URL.Query(), &params.From)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "from", Err: err})
+		return
+	}
+
+	// ------------- Required query parameter "to" -------------
+
+	if paramValue := r.URL.Query().Get("to"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "to"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "to", r.URL.Query(), &params.To)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "to", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DiffInfo(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListInfoHistory operation middleware
+func (siw *ServerInterfaceWrapper) ListInfoHistory(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListInfoHistoryParams
+
+	// ------------- Required query parameter "videoPath" -------------
+
+	if paramValue := r.URL.Query().Get("videoPath"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "videoPath"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "videoPath", r.URL.Query(), &params.VideoPath)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "videoPath", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "pageToken" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "pageToken", r.URL.Query(), &params.PageToken)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "pageToken", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListInfoHistory(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetInfoStatus operation middleware
+func (siw *ServerInterfaceWrapper) GetInfoStatus(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "uuid" -------------
+	var uuid openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "uuid", r.PathValue("uuid"), &uuid, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "uuid", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetInfoStatus(w, r, uuid)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// AnnotateInfo operation middleware
+func (siw *ServerInterfaceWrapper) AnnotateInfo(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "uuid" -------------
+	var uuid openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "uuid", r.PathValue("uuid"), &uuid, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "uuid", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.AnnotateInfo(w, r, uuid)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// CancelInfo operation middleware
+func (siw *ServerInterfaceWrapper) CancelInfo(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "uuid" -------------
+	var uuid openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "uuid", r.PathValue("uuid"), &uuid, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "uuid", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CancelInfo(w, r, uuid)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetInfoHistory operation middleware
+func (siw *ServerInterfaceWrapper) GetInfoHistory(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "uuid" -------------
+	var uuid openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "uuid", r.PathValue("uuid"), &uuid, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "uuid", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetInfoHistory(w, r, uuid)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ReprobeInfo operation middleware
+func (siw *ServerInterfaceWrapper) ReprobeInfo(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "uuid" -------------
+	var uuid openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "uuid", r.PathValue("uuid"), &uuid, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "uuid", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ReprobeInfo(w, r, uuid)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// RetryInfo operation middleware
+func (siw *ServerInterfaceWrapper) RetryInfo(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "uuid" -------------
+	var uuid openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "uuid", r.PathValue("uuid"), &uuid, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "uuid", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.RetryInfo(w, r, uuid)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetReadiness operation middleware
+func (siw *ServerInterfaceWrapper) GetReadiness(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetReadiness(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetScanPreview operation middleware
+func (siw *ServerInterfaceWrapper) GetScanPreview(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetScanPreview(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListSchedules operation middleware
+func (siw *ServerInterfaceWrapper) ListSchedules(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListSchedules(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// CreateSchedule operation middleware
+func (siw *ServerInterfaceWrapper) CreateSchedule(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params CreateScheduleParams
+
+	// ------------- Optional query parameter "dryRun" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "dryRun", r.URL.Query(), &params.DryRun)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "dryRun", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateSchedule(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DeleteSchedule operation middleware
+func (siw *ServerInterfaceWrapper) DeleteSchedule(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteSchedule(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// RegisterAgent operation middleware
+func (siw *ServerInterfaceWrapper) RegisterAgent(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "workerId" -------------
+	var workerId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "workerId", r.PathValue("workerId"), &workerId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "workerId", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, WorkerTokenScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.RegisterAgent(w, r, workerId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ClaimWorkerJob operation middleware
+func (siw *ServerInterfaceWrapper) ClaimWorkerJob(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, WorkerTokenScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ClaimWorkerJob(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// CompleteWorkerJob operation middleware
+func (siw *ServerInterfaceWrapper) CompleteWorkerJob(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "jobId" -------------
+	var jobId int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "jobId", r.PathValue("jobId"), &jobId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "jobId", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, WorkerTokenScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CompleteWorkerJob(w, r, jobId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+type UnescapedCookieParamError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *UnescapedCookieParamError) Error() string {
+	return fmt.Sprintf("error unescaping cookie parameter '%s'", e.ParamName)
+}
+
+func (e *UnescapedCookieParamError) Unwrap() error {
+	return e.Err
+}
+
+type UnmarshalingParamError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *UnmarshalingParamError) Error() string {
+	return fmt.Sprintf("Error unmarshaling parameter %s as JSON: %s", e.ParamName, e.Err.Error())
+}
+
+func (e *UnmarshalingParamError) Unwrap() error {
+	return e.Err
+}
+
+type RequiredParamError struct {
+	ParamName string
+}
+
+func (e *RequiredParamError) Error() string {
+	return fmt.Sprintf("Query argument %s is required, but not found", e.ParamName)
+}
+
+type RequiredHeaderError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *RequiredHeaderError) Error() string {
+	return fmt.Sprintf("Header parameter %s is required, but not found", e.ParamName)
+}
+
+func (e *RequiredHeaderError) Unwrap() error {
+	return e.Err
+}
+
+type InvalidParamFormatError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *InvalidParamFormatError) Error() string {
+	return fmt.Sprintf("Invalid format for parameter %s: %s", e.ParamName, e.Err.Error())
+}
+
+func (e *InvalidParamFormatError) Unwrap() error {
+	return e.Err
+}
+
+type TooManyValuesForParamError struct {
+	ParamName string
+	Count     int
+}
+
+func (e *TooManyValuesForParamError) Error() string {
+	return fmt.Sprintf("Expected one value for %s, got %d", e.ParamName, e.Count)
+}
+
+// Handler creates http.Handler with routing matching OpenAPI spec.
+func Handler(si ServerInterface) http.Handler {
+	return HandlerWithOptions(si, StdHTTPServerOptions{})
+}
+
+// ServeMux is an abstraction of http.ServeMux.
+type ServeMux interface {
+	HandleFunc(pattern string, handler func(http.ResponseWriter, *http.Request))
+	ServeHTTP(w http.ResponseWriter, r *http.Request)
+}
+
+type StdHTTPServerOptions struct {
+	BaseURL          string
+	BaseRouter       ServeMux
+	Middlewares      []MiddlewareFunc
+	ErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+// HandlerFromMux creates http.Handler with routing matching OpenAPI spec based on the provided mux.
+func HandlerFromMux(si ServerInterface, m ServeMux) http.Handler {
+	return HandlerWithOptions(si, StdHTTPServerOptions{
+		BaseRouter: m,
+	})
+}
+
+func HandlerFromMuxWithBaseURL(si ServerInterface, m ServeMux, baseURL string) http.Handler {
+	return HandlerWithOptions(si, StdHTTPServerOptions{
+		BaseURL:    baseURL,
+		BaseRouter: m,
+	})
+}
+
+// HandlerWithOptions creates http.Handler with additional options
+func HandlerWithOptions(si ServerInterface, options StdHTTPServerOptions) http.Handler {
+	m := options.BaseRouter
+
+	if m == nil {
+		m = http.NewServeMux()
+	}
+	if options.ErrorHandlerFunc == nil {
+		options.ErrorHandlerFunc = func(w http.ResponseWriter, r *http.Request, err error) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		}
+	}
+
+	wrapper := ServerInterfaceWrapper{
+		Handler:            si,
+		HandlerMiddlewares: options.Middlewares,
+		ErrorHandlerFunc:   options.ErrorHandlerFunc,
+	}
+
+	m.HandleFunc("GET "+options.BaseURL+"/.well-known/video-info-signing-key", wrapper.GetSigningKey)
+	m.HandleFunc("GET "+options.BaseURL+"/admin/agents", wrapper.ListAgents)
+	m.HandleFunc("GET "+options.BaseURL+"/admin/canary-report", wrapper.GetCanaryReport)
+	m.HandleFunc("POST "+options.BaseURL+"/admin/comparisons", wrapper.CreateComparison)
+	m.HandleFunc("GET "+options.BaseURL+"/admin/comparisons/{id}", wrapper.GetComparison)
+	m.HandleFunc("GET "+options.BaseURL+"/admin/failures", wrapper.ListFailures)
+	m.HandleFunc("POST "+options.BaseURL+"/admin/failures/retry", wrapper.RetryFailures)
+	m.HandleFunc("POST "+options.BaseURL+"/admin/purge", wrapper.PurgeInfo)
+	m.HandleFunc("GET "+options.BaseURL+"/admin/reprobe-campaigns", wrapper.ListReprobeCampaigns)
+	m.HandleFunc("POST "+options.BaseURL+"/admin/reprobe-campaigns", wrapper.CreateReprobeCampaign)
+	m.HandleFunc("DELETE "+options.BaseURL+"/admin/reprobe-campaigns/{id}", wrapper.DeleteReprobeCampaign)
+	m.HandleFunc("GET "+options.BaseURL+"/admin/support-bundle", wrapper.GetSupportBundle)
+	m.HandleFunc("GET "+options.BaseURL+"/admin/timeline", wrapper.ExportTimeline)
+	m.HandleFunc("GET "+options.BaseURL+"/admin/webhooks/breakers", wrapper.ListWebhookBreakers)
+	m.HandleFunc("GET "+options.BaseURL+"/admin/webhooks/targets", wrapper.ListWebhookTargets)
+	m.HandleFunc("GET "+options.BaseURL+"/admin/webhooks/undelivered", wrapper.ListUndeliveredWebhooks)
+	m.HandleFunc("GET "+options.BaseURL+"/healthz", wrapper.GetHealth)
+	m.HandleFunc("GET "+options.BaseURL+"/info", wrapper.ListInfo)
+	m.HandleFunc("POST "+options.BaseURL+"/info", wrapper.CreateInfo)
+	m.HandleFunc("POST "+options.BaseURL+"/info/batch", wrapper.CreateInfoBatch)
+	m.HandleFunc("GET "+options.BaseURL+"/info/by-external-id/{externalId}", wrapper.GetInfoStatusByExternalId)
+	m.HandleFunc("GET "+options.BaseURL+"/info/diff", wrapper.DiffInfo)
+	m.HandleFunc("GET "+options.BaseURL+"/info/history", wrapper.ListInfoHistory)
+	m.HandleFunc("GET "+options.BaseURL+"/info/{uuid}", wrapper.GetInfoStatus)
+	m.HandleFunc("PATCH "+options.BaseURL+"/info/{uuid}/annotations", wrapper.AnnotateInfo)
+	m.HandleFunc("POST "+options.BaseURL+"/info/{uuid}/cancel", wrapper.CancelInfo)
+	m.HandleFunc("GET "+options.BaseURL+"/info/{uuid}/history", wrapper.GetInfoHistory)
+	m.HandleFunc("POST "+options.BaseURL+"/info/{uuid}/reprobe", wrapper.ReprobeInfo)
+	m.HandleFunc("POST "+options.BaseURL+"/info/{uuid}/retry", wrapper.RetryInfo)
+	m.HandleFunc("GET "+options.BaseURL+"/readyz", wrapper.GetReadiness)
+	m.HandleFunc("GET "+options.BaseURL+"/scan-previews/{id}", wrapper.GetScanPreview)
+	m.HandleFunc("GET "+options.BaseURL+"/schedules", wrapper.ListSchedules)
+	m.HandleFunc("POST "+options.BaseURL+"/schedules", wrapper.CreateSchedule)
+	m.HandleFunc("DELETE "+options.BaseURL+"/schedules/{id}", wrapper.DeleteSchedule)
+	m.HandleFunc("PUT "+options.BaseURL+"/worker/agents/{workerId}", wrapper.RegisterAgent)
+	m.HandleFunc("POST "+options.BaseURL+"/worker/claim", wrapper.ClaimWorkerJob)
+	m.HandleFunc("POST "+options.BaseURL+"/worker/jobs/{jobId}/complete", wrapper.CompleteWorkerJob)
+
+	return m
+}
+
+type QueueFullResponseHeaders struct {
+	RetryAfter  int
+	XQueueDepth int
+}
+type QueueFullJSONResponse struct {
+	Body Error
+
+	Headers QueueFullResponseHeaders
+}
+
+type GetSigningKeyRequestObject struct {
+}
+
+type GetSigningKeyResponseObject interface {
+	VisitGetSigningKeyResponse(w http.ResponseWriter) error
+}
+
+type GetSigningKey200JSONResponse SigningKey
+
+func (response GetSigningKey200JSONResponse) VisitGetSigningKeyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetSigningKey404JSONResponse Error
+
+func (response GetSigningKey404JSONResponse) VisitGetSigningKeyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListAgentsRequestObject struct {
+}
+
+type ListAgentsResponseObject interface {
+	VisitListAgentsResponse(w http.ResponseWriter) error
+}
+
+type ListAgents200JSONResponse AgentList
+
+func (response ListAgents200JSONResponse) VisitListAgentsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListAgents500JSONResponse Error
+
+func (response ListAgents500JSONResponse) VisitListAgentsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetCanaryReportRequestObject struct {
+	Params GetCanaryReportParams
+}
+
+type GetCanaryReportResponseObject interface {
+	VisitGetCanaryReportResponse(w http.ResponseWriter) error
+}
+
+type GetCanaryReport200JSONResponse CanaryReport
+
+func (response GetCanaryReport200JSONResponse) VisitGetCanaryReportResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetCanaryReport500JSONResponse Error
+
+func (response GetCanaryReport500JSONResponse) VisitGetCanaryReportResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateComparisonRequestObject struct {
+	Body *CreateComparisonJSONRequestBody
+}
+
+type CreateComparisonResponseObject interface {
+	VisitCreateComparisonResponse(w http.ResponseWriter) error
+}
+
+type CreateComparison201JSONResponse Comparison
+
+func (response CreateComparison201JSONResponse) VisitCreateComparisonResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateComparison400JSONResponse Error
+
+func (response CreateComparison400JSONResponse) VisitCreateComparisonResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateComparison429JSONResponse struct{ QueueFullJSONResponse }
+
+func (response CreateComparison429JSONResponse) VisitCreateComparisonResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.Header().Set("X-Queue-Depth", fmt.Sprint(response.Headers.XQueueDepth))
+	w.WriteHeader(429)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type CreateComparison500JSONResponse Error
+
+func (response CreateComparison500JSONResponse) VisitCreateComparisonResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetComparisonRequestObject struct {
+	Id openapi_types.UUID `json:"id"`
+}
+
+type GetComparisonResponseObject interface {
+	VisitGetComparisonResponse(w http.ResponseWriter) error
+}
+
+type GetComparison200JSONResponse Comparison
+
+func (response GetComparison200JSONResponse) VisitGetComparisonResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetComparison404JSONResponse Error
+
+func (response GetComparison404JSONResponse) VisitGetComparisonResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetComparison500JSONResponse Error
+
+func (response GetComparison500JSONResponse) VisitGetComparisonResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListFailuresRequestObject struct {
+	Params ListFailuresParams
+}
+
+type ListFailuresResponseObject interface {
+	VisitListFailuresResponse(w http.ResponseWriter) error
+}
+
+type ListFailures200JSONResponse FailureSummary
+
+func (response ListFailures200JSONResponse) VisitListFailuresResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListFailures500JSONResponse Error
+
+func (response ListFailures500JSONResponse) VisitListFailuresResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RetryFailuresRequestObject struct {
+	Body *RetryFailuresJSONRequestBody
+}
+
+type RetryFailuresResponseObject interface {
+	VisitRetryFailuresResponse(w http.ResponseWriter) error
+}
+
+type RetryFailures200JSONResponse RetryFailuresResult
+
+func (response RetryFailures200JSONResponse) VisitRetryFailuresResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RetryFailures400JSONResponse Error
+
+func (response RetryFailures400JSONResponse) VisitRetryFailuresResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RetryFailures500JSONResponse Error
+
+func (response RetryFailures500JSONResponse) VisitRetryFailuresResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PurgeInfoRequestObject struct {
+	Body *PurgeInfoJSONRequestBody
+}
+
+type PurgeInfoResponseObject interface {
+	VisitPurgeInfoResponse(w http.ResponseWriter) error
+}
+
+type PurgeInfo200JSONResponse PurgeResult
+
+func (response PurgeInfo200JSONResponse) VisitPurgeInfoResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PurgeInfo400JSONResponse Error
+
+func (response PurgeInfo400JSONResponse) VisitPurgeInfoResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PurgeInfo500JSONResponse Error
+
+func (response PurgeInfo500JSONResponse) VisitPurgeInfoResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListReprobeCampaignsRequestObject struct {
+}
+
+type ListReprobeCampaignsResponseObject interface {
+	VisitListReprobeCampaignsResponse(w http.ResponseWriter) error
+}
+
+type ListReprobeCampaigns200JSONResponse ReprobeCampaignList
+
+func (response ListReprobeCampaigns200JSONResponse) VisitListReprobeCampaignsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListReprobeCampaigns500JSONResponse Error
+
+func (response ListReprobeCampaigns500JSONResponse) VisitListReprobeCampaignsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateReprobeCampaignRequestObject struct {
+	Body *CreateReprobeCampaignJSONRequestBody
+}
+
+type CreateReprobeCampaignResponseObject interface {
+	VisitCreateReprobeCampaignResponse(w http.ResponseWriter) error
+}
+
+type CreateReprobeCampaign201JSONResponse ReprobeCampaign
+
+func (response CreateReprobeCampaign201JSONResponse) VisitCreateReprobeCampaignResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateReprobeCampaign400JSONResponse Error
+
+func (response CreateReprobeCampaign400JSONResponse) VisitCreateReprobeCampaignResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateReprobeCampaign500JSONResponse Error
+
+func (response CreateReprobeCampaign500JSONResponse) VisitCreateReprobeCampaignResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteReprobeCampaignRequestObject struct {
+	Id openapi_types.UUID `json:"id"`
+}
+
+type DeleteReprobeCampaignResponseObject interface {
+	VisitDeleteReprobeCampaignResponse(w http.ResponseWriter) error
+}
+
+type DeleteReprobeCampaign204Response struct {
+}
+
+func (response DeleteReprobeCampaign204Response) VisitDeleteReprobeCampaignResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type DeleteReprobeCampaign404JSONResponse Error
+
+func (response DeleteReprobeCampaign404JSONResponse) VisitDeleteReprobeCampaignResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteReprobeCampaign500JSONResponse Error
+
+func (response DeleteReprobeCampaign500JSONResponse) VisitDeleteReprobeCampaignResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetSupportBundleRequestObject struct {
+}
+
+type GetSupportBundleResponseObject interface {
+	VisitGetSupportBundleResponse(w http.ResponseWriter) error
+}
+
+type GetSupportBundle200ApplicationgzipResponse struct {
+	Body          io.Reader
+	ContentLength int64
+}
+
+func (response GetSupportBundle200ApplicationgzipResponse) VisitGetSupportBundleResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/gzip")
+	if response.ContentLength != 0 {
+		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
+	}
+	w.WriteHeader(200)
+
+	if closer, ok := response.Body.(io.ReadCloser); ok {
+		defer closer.Close()
+	}
+	_, err := io.Copy(w, response.Body)
+	return err
+}
+
+type GetSupportBundle500JSONResponse Error
+
+func (response GetSupportBundle500JSONResponse) VisitGetSupportBundleResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ExportTimelineRequestObject struct {
+	Params ExportTimelineParams
+}
+
+type ExportTimelineResponseObject interface {
+	VisitExportTimelineResponse(w http.ResponseWriter) error
+}
+
+type ExportTimeline200JSONResponse map[string]interface{}
+
+func (response ExportTimeline200JSONResponse) VisitExportTimelineResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ExportTimeline200TextcsvResponse struct {
+	Body          io.Reader
+	ContentLength int64
+}
+
+func (response ExportTimeline200TextcsvResponse) VisitExportTimelineResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "text/csv")
+	if response.ContentLength != 0 {
+		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
+	}
+	w.WriteHeader(200)
+
+	if closer, ok := response.Body.(io.ReadCloser); ok {
+		defer closer.Close()
+	}
+	_, err := io.Copy(w, response.Body)
+	return err
+}
+
+type ExportTimeline400JSONResponse Error
+
+func (response ExportTimeline400JSONResponse) VisitExportTimelineResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ExportTimeline500JSONResponse Error
+
+func (response ExportTimeline500JSONResponse) VisitExportTimelineResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListWebhookBreakersRequestObject struct {
+}
+
+type ListWebhookBreakersResponseObject interface {
+	VisitListWebhookBreakersResponse(w http.ResponseWriter) error
+}
+
+type ListWebhookBreakers200JSONResponse []WebhookBreaker
+
+func (response ListWebhookBreakers200JSONResponse) VisitListWebhookBreakersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListWebhookBreakers500JSONResponse Error
+
+func (response ListWebhookBreakers500JSONResponse) VisitListWebhookBreakersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListWebhookTargetsRequestObject struct {
+	Params ListWebhookTargetsParams
+}
+
+type ListWebhookTargetsResponseObject interface {
+	VisitListWebhookTargetsResponse(w http.ResponseWriter) error
+}
+
+type ListWebhookTargets200JSONResponse []WebhookTargetStats
+
+func (response ListWebhookTargets200JSONResponse) VisitListWebhookTargetsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListWebhookTargets400JSONResponse Error
+
+func (response ListWebhookTargets400JSONResponse) VisitListWebhookTargetsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListWebhookTargets500JSONResponse Error
+
+func (response ListWebhookTargets500JSONResponse) VisitListWebhookTargetsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListUndeliveredWebhooksRequestObject struct {
+	Params ListUndeliveredWebhooksParams
+}
+
+type ListUndeliveredWebhooksResponseObject interface {
+	VisitListUndeliveredWebhooksResponse(w http.ResponseWriter) error
+}
+
+type ListUndeliveredWebhooks200JSONResponse []UndeliveredWebhook
+
+func (response ListUndeliveredWebhooks200JSONResponse) VisitListUndeliveredWebhooksResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListUndeliveredWebhooks400JSONResponse Error
+
+func (response ListUndeliveredWebhooks400JSONResponse) VisitListUndeliveredWebhooksResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListUndeliveredWebhooks500JSONResponse Error
+
+func (response ListUndeliveredWebhooks500JSONResponse) VisitListUndeliveredWebhooksResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetHealthRequestObject struct {
+}
+
+type GetHealthResponseObject interface {
+	VisitGetHealthResponse(w http.ResponseWriter) error
+}
+
+type GetHealth200JSONResponse HealthStatus
+
+func (response GetHealth200JSONResponse) VisitGetHealthResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListInfoRequestObject struct {
+	Params ListInfoParams
+}
+
+type ListInfoResponseObject interface {
+	VisitListInfoResponse(w http.ResponseWriter) error
+}
+
+type ListInfo200JSONResponse InfoJobList
+
+func (response ListInfo200JSONResponse) VisitListInfoResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListInfo400JSONResponse Error
+
+func (response ListInfo400JSONResponse) VisitListInfoResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListInfo500JSONResponse Error
+
+func (response ListInfo500JSONResponse) VisitListInfoResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateInfoRequestObject struct {
+	Body *CreateInfoJSONRequestBody
+}
+
+type CreateInfoResponseObject interface {
+	VisitCreateInfoResponse(w http.ResponseWriter) error
+}
+
+type CreateInfo201ResponseHeaders struct {
+	XQueueDepth int
+}
+
+type CreateInfo201JSONResponse struct {
+	Body    InfoJob
+	Headers CreateInfo201ResponseHeaders
+}
+
+func (response CreateInfo201JSONResponse) VisitCreateInfoResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("X-Queue-Depth", fmt.Sprint(response.Headers.XQueueDepth))
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type CreateInfo400JSONResponse Error
+
+func (response CreateInfo400JSONResponse) VisitCreateInfoResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateInfo409JSONResponse Error
+
+func (response CreateInfo409JSONResponse) VisitCreateInfoResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateInfo429JSONResponse struct{ QueueFullJSONResponse }
+
+func (response CreateInfo429JSONResponse) VisitCreateInfoResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.Header().Set("X-Queue-Depth", fmt.Sprint(response.Headers.XQueueDepth))
+	w.WriteHeader(429)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type CreateInfo500JSONResponse Error
+
+func (response CreateInfo500JSONResponse) VisitCreateInfoResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateInfoBatchRequestObject struct {
+	Body *CreateInfoBatchJSONRequestBody
+}
+
+type CreateInfoBatchResponseObject interface {
+	VisitCreateInfoBatchResponse(w http.ResponseWriter) error
+}
+
+type CreateInfoBatch200ResponseHeaders struct {
+	XQueueDepth int
+}
+
+type CreateInfoBatch200JSONResponse struct {
+	Body    InfoBatchResult
+	Headers CreateInfoBatch200ResponseHeaders
+}
+
+func (response CreateInfoBatch200JSONResponse) VisitCreateInfoBatchResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("X-Queue-Depth", fmt.Sprint(response.Headers.XQueueDepth))
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type CreateInfoBatch400JSONResponse Error
+
+func (response CreateInfoBatch400JSONResponse) VisitCreateInfoBatchResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateInfoBatch429JSONResponse struct{ QueueFullJSONResponse }
+
+func (response CreateInfoBatch429JSONResponse) VisitCreateInfoBatchResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.Header().Set("X-Queue-Depth", fmt.Sprint(response.Headers.XQueueDepth))
+	w.WriteHeader(429)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type CreateInfoBatch500JSONResponse Error
+
+func (response CreateInfoBatch500JSONResponse) VisitCreateInfoBatchResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetInfoStatusByExternalIdRequestObject struct {
+	ExternalId string `json:"externalId"`
+}
+
+type GetInfoStatusByExternalIdResponseObject interface {
+	VisitGetInfoStatusByExternalIdResponse(w http.ResponseWriter) error
+}
+
+type GetInfoStatusByExternalId200JSONResponse InfoJob
+
+func (response GetInfoStatusByExternalId200JSONResponse) VisitGetInfoStatusByExternalIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetInfoStatusByExternalId404JSONResponse Error
+
+func (response GetInfoStatusByExternalId404JSONResponse) VisitGetInfoStatusByExternalIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetInfoStatusByExternalId500JSONResponse Error
+
+func (response GetInfoStatusByExternalId500JSONResponse) VisitGetInfoStatusByExternalIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DiffInfoRequestObject struct {
+	Params DiffInfoParams
+}
+
+type DiffInfoResponseObject interface {
+	VisitDiffInfoResponse(w http.ResponseWriter) error
+}
+
+type DiffInfo200JSONResponse ResultDiff
+
+func (response DiffInfo200JSONResponse) VisitDiffInfoResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DiffInfo404JSONResponse Error
+
+func (response DiffInfo404JSONResponse) VisitDiffInfoResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DiffInfo409JSONResponse Error
+
+func (response DiffInfo409JSONResponse) VisitDiffInfoResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DiffInfo500JSONResponse Error
+
+func (response DiffInfo500JSONResponse) VisitDiffInfoResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListInfoHistoryRequestObject struct {
+	Params ListInfoHistoryParams
+}
+
+type ListInfoHistoryResponseObject interface {
+	VisitListInfoHistoryResponse(w http.ResponseWriter) error
+}
+
+type ListInfoHistory200JSONResponse InfoJobList
+
+func (response ListInfoHistory200JSONResponse) VisitListInfoHistoryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListInfoHistory400JSONResponse Error
+
+func (response ListInfoHistory400JSONResponse) VisitListInfoHistoryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListInfoHistory500JSONResponse Error
+
+func (response ListInfoHistory500JSONResponse) VisitListInfoHistoryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetInfoStatusRequestObject struct {
+	Uuid openapi_types.UUID `json:"uuid"`
+}
+
+type GetInfoStatusResponseObject interface {
+	VisitGetInfoStatusResponse(w http.ResponseWriter) error
+}
+
+type GetInfoStatus200JSONResponse InfoJob
+
+func (response GetInfoStatus200JSONResponse) VisitGetInfoStatusResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetInfoStatus404JSONResponse Error
+
+func (response GetInfoStatus404JSONResponse) VisitGetInfoStatusResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetInfoStatus500JSONResponse Error
+
+func (response GetInfoStatus500JSONResponse) VisitGetInfoStatusResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AnnotateInfoRequestObject struct {
+	Uuid openapi_types.UUID `json:"uuid"`
+	Body *AnnotateInfoJSONRequestBody
+}
+
+type AnnotateInfoResponseObject interface {
+	VisitAnnotateInfoResponse(w http.ResponseWriter) error
+}
+
+type AnnotateInfo200JSONResponse Annotations
+
+func (response AnnotateInfo200JSONResponse) VisitAnnotateInfoResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AnnotateInfo400JSONResponse Error
+
+func (response AnnotateInfo400JSONResponse) VisitAnnotateInfoResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AnnotateInfo404JSONResponse Error
+
+func (response AnnotateInfo404JSONResponse) VisitAnnotateInfoResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AnnotateInfo500JSONResponse Error
+
+func (response AnnotateInfo500JSONResponse) VisitAnnotateInfoResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CancelInfoRequestObject struct {
+	Uuid openapi_types.UUID `json:"uuid"`
+}
+
+type CancelInfoResponseObject interface {
+	VisitCancelInfoResponse(w http.ResponseWriter) error
+}
+
+type CancelInfo200JSONResponse InfoJob
+
+func (response CancelInfo200JSONResponse) VisitCancelInfoResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CancelInfo404JSONResponse Error
+
+func (response CancelInfo404JSONResponse) VisitCancelInfoResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CancelInfo409JSONResponse Error
+
+func (response CancelInfo409JSONResponse) VisitCancelInfoResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CancelInfo500JSONResponse Error
+
+func (response CancelInfo500JSONResponse) VisitCancelInfoResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetInfoHistoryRequestObject struct {
+	Uuid openapi_types.UUID `json:"uuid"`
+}
+
+type GetInfoHistoryResponseObject interface {
+	VisitGetInfoHistoryResponse(w http.ResponseWriter) error
+}
+
+type GetInfoHistory200JSONResponse InfoJobHistory
+
+func (response GetInfoHistory200JSONResponse) VisitGetInfoHistoryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetInfoHistory404JSONResponse Error
+
+func (response GetInfoHistory404JSONResponse) VisitGetInfoHistoryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetInfoHistory500JSONResponse Error
+
+func (response GetInfoHistory500JSONResponse) VisitGetInfoHistoryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReprobeInfoRequestObject struct {
+	Uuid openapi_types.UUID `json:"uuid"`
+	Body *ReprobeInfoJSONRequestBody
+}
+
+type ReprobeInfoResponseObject interface {
+	VisitReprobeInfoResponse(w http.ResponseWriter) error
+}
+
+type ReprobeInfo201ResponseHeaders struct {
+	XQueueDepth int
+}
+
+type ReprobeInfo201JSONResponse struct {
+	Body    InfoJob
+	Headers ReprobeInfo201ResponseHeaders
+}
+
+func (response ReprobeInfo201JSONResponse) VisitReprobeInfoResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("X-Queue-Depth", fmt.Sprint(response.Headers.XQueueDepth))
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type ReprobeInfo400JSONResponse Error
+
+func (response ReprobeInfo400JSONResponse) VisitReprobeInfoResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReprobeInfo404JSONResponse Error
+
+func (response ReprobeInfo404JSONResponse) VisitReprobeInfoResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReprobeInfo409JSONResponse Error
+
+func (response ReprobeInfo409JSONResponse) VisitReprobeInfoResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReprobeInfo429JSONResponse struct{ QueueFullJSONResponse }
+
+func (response ReprobeInfo429JSONResponse) VisitReprobeInfoResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.Header().Set("X-Queue-Depth", fmt.Sprint(response.Headers.XQueueDepth))
+	w.WriteHeader(429)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type ReprobeInfo500JSONResponse Error
+
+func (response ReprobeInfo500JSONResponse) VisitReprobeInfoResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RetryInfoRequestObject struct {
+	Uuid openapi_types.UUID `json:"uuid"`
+}
+
+type RetryInfoResponseObject interface {
+	VisitRetryInfoResponse(w http.ResponseWriter) error
+}
+
+type RetryInfo200JSONResponse InfoJob
+
+func (response RetryInfo200JSONResponse) VisitRetryInfoResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RetryInfo404JSONResponse Error
+
+func (response RetryInfo404JSONResponse) VisitRetryInfoResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RetryInfo409JSONResponse Error
+
+func (response RetryInfo409JSONResponse) VisitRetryInfoResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RetryInfo500JSONResponse Error
+
+func (response RetryInfo500JSONResponse) VisitRetryInfoResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetReadinessRequestObject struct {
+}
+
+type GetReadinessResponseObject interface {
+	VisitGetReadinessResponse(w http.ResponseWriter) error
+}
+
+type GetReadiness200JSONResponse HealthStatus
+
+func (response GetReadiness200JSONResponse) VisitGetReadinessResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetReadiness503JSONResponse Error
+
+func (response GetReadiness503JSONResponse) VisitGetReadinessResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(503)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetScanPreviewRequestObject struct {
+	Id int64 `json:"id"`
+}
+
+type GetScanPreviewResponseObject interface {
+	VisitGetScanPreviewResponse(w http.ResponseWriter) error
+}
+
+type GetScanPreview200JSONResponse ScanPreview
+
+func (response GetScanPreview200JSONResponse) VisitGetScanPreviewResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetScanPreview404JSONResponse Error
+
+func (response GetScanPreview404JSONResponse) VisitGetScanPreviewResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetScanPreview500JSONResponse Error
+
+func (response GetScanPreview500JSONResponse) VisitGetScanPreviewResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListSchedulesRequestObject struct {
+}
+
+type ListSchedulesResponseObject interface {
+	VisitListSchedulesResponse(w http.ResponseWriter) error
+}
+
+type ListSchedules200JSONResponse ScheduleList
+
+func (response ListSchedules200JSONResponse) VisitListSchedulesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListSchedules500JSONResponse Error
+
+func (response ListSchedules500JSONResponse) VisitListSchedulesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateScheduleRequestObject struct {
+	Params CreateScheduleParams
+	Body   *CreateScheduleJSONRequestBody
+}
+
+type CreateScheduleResponseObject interface {
+	VisitCreateScheduleResponse(w http.ResponseWriter) error
+}
+
+type CreateSchedule201JSONResponse Schedule
+
+func (response CreateSchedule201JSONResponse) VisitCreateScheduleResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateSchedule202JSONResponse ScanPreview
+
+func (response CreateSchedule202JSONResponse) VisitCreateScheduleResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(202)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateSchedule400JSONResponse Error
+
+func (response CreateSchedule400JSONResponse) VisitCreateScheduleResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateSchedule500JSONResponse Error
+
+func (response CreateSchedule500JSONResponse) VisitCreateScheduleResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteScheduleRequestObject struct {
+	Id openapi_types.UUID `json:"id"`
+}
+
+type DeleteScheduleResponseObject interface {
+	VisitDeleteScheduleResponse(w http.ResponseWriter) error
+}
+
+type DeleteSchedule204Response struct {
+}
+
+func (response DeleteSchedule204Response) VisitDeleteScheduleResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type DeleteSchedule404JSONResponse Error
+
+func (response DeleteSchedule404JSONResponse) VisitDeleteScheduleResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteSchedule500JSONResponse Error
+
+func (response DeleteSchedule500JSONResponse) VisitDeleteScheduleResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RegisterAgentRequestObject struct {
+	WorkerId string `json:"workerId"`
+	Body     *RegisterAgentJSONRequestBody
+}
+
+type RegisterAgentResponseObject interface {
+	VisitRegisterAgentResponse(w http.ResponseWriter) error
+}
+
+type RegisterAgent200JSONResponse Agent
+
+func (response RegisterAgent200JSONResponse) VisitRegisterAgentResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RegisterAgent400JSONResponse Error
+
+func (response RegisterAgent400JSONResponse) VisitRegisterAgentResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RegisterAgent401JSONResponse Error
+
+func (response RegisterAgent401JSONResponse) VisitRegisterAgentResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RegisterAgent500JSONResponse Error
+
+func (response RegisterAgent500JSONResponse) VisitRegisterAgentResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ClaimWorkerJobRequestObject struct {
+	Body *ClaimWorkerJobJSONRequestBody
+}
+
+type ClaimWorkerJobResponseObject interface {
+	VisitClaimWorkerJobResponse(w http.ResponseWriter) error
+}
+
+type ClaimWorkerJob200JSONResponse WorkerJob
+
+func (response ClaimWorkerJob200JSONResponse) VisitClaimWorkerJobResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ClaimWorkerJob204Response struct {
+}
+
+func (response ClaimWorkerJob204Response) VisitClaimWorkerJobResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type ClaimWorkerJob400JSONResponse Error
+
+func (response ClaimWorkerJob400JSONResponse) VisitClaimWorkerJobResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ClaimWorkerJob401JSONResponse Error
+
+func (response ClaimWorkerJob401JSONResponse) VisitClaimWorkerJobResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ClaimWorkerJob500JSONResponse Error
+
+func (response ClaimWorkerJob500JSONResponse) VisitClaimWorkerJobResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CompleteWorkerJobRequestObject struct {
+	JobId int64 `json:"jobId"`
+	Body  *CompleteWorkerJobJSONRequestBody
+}
+
+type CompleteWorkerJobResponseObject interface {
+	VisitCompleteWorkerJobResponse(w http.ResponseWriter) error
+}
+
+type CompleteWorkerJob204Response struct {
+}
+
+func (response CompleteWorkerJob204Response) VisitCompleteWorkerJobResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type CompleteWorkerJob400JSONResponse Error
+
+func (response CompleteWorkerJob400JSONResponse) VisitCompleteWorkerJobResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CompleteWorkerJob401JSONResponse Error
+
+func (response CompleteWorkerJob401JSONResponse) VisitCompleteWorkerJobResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CompleteWorkerJob409JSONResponse Error
+
+func (response CompleteWorkerJob409JSONResponse) VisitCompleteWorkerJobResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CompleteWorkerJob500JSONResponse Error
+
+func (response CompleteWorkerJob500JSONResponse) VisitCompleteWorkerJobResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+// StrictServerInterface represents all server handlers.
+type StrictServerInterface interface {
+	// Get the result signing key
+	// (GET /.well-known/video-info-signing-key)
+	GetSigningKey(ctx context.Context, request GetSigningKeyRequestObject) (GetSigningKeyResponseObject, error)
+	// List registered workers
+	// (GET /admin/agents)
+	ListAgents(ctx context.Context, request ListAgentsRequestObject) (ListAgentsResponseObject, error)
+	// Compare canary results with the results they would replace
+	// (GET /admin/canary-report)
+	GetCanaryReport(ctx context.Context, request GetCanaryReportRequestObject) (GetCanaryReportResponseObject, error)
+	// Compare two ways of probing a file
+	// (POST /admin/comparisons)
+	CreateComparison(ctx context.Context, request CreateComparisonRequestObject) (CreateComparisonResponseObject, error)
+	// Get a comparison
+	// (GET /admin/comparisons/{id})
+	GetComparison(ctx context.Context, request GetComparisonRequestObject) (GetComparisonResponseObject, error)
+	// Summarize failed info jobs
+	// (GET /admin/failures)
+	ListFailures(ctx context.Context, request ListFailuresRequestObject) (ListFailuresResponseObject, error)
+	// Retry failed info jobs in bulk
+	// (POST /admin/failures/retry)
+	RetryFailures(ctx context.Context, request RetryFailuresRequestObject) (RetryFailuresResponseObject, error)
+	// Purge stored data for a video path
+	// (POST /admin/purge)
+	PurgeInfo(ctx context.Context, request PurgeInfoRequestObject) (PurgeInfoResponseObject, error)
+	// List reprobe campaigns
+	// (GET /admin/reprobe-campaigns)
+	ListReprobeCampaigns(ctx context.Context, request ListReprobeCampaignsRequestObject) (ListReprobeCampaignsResponseObject, error)
+	// Start a reprobe campaign
+	// (POST /admin/reprobe-campaigns)
+	CreateReprobeCampaign(ctx context.Context, request CreateReprobeCampaignRequestObject) (CreateReprobeCampaignResponseObject, error)
+	// Delete a reprobe campaign
+	// (DELETE /admin/reprobe-campaigns/{id})
+	DeleteReprobeCampaign(ctx context.Context, request DeleteReprobeCampaignRequestObject) (DeleteReprobeCampaignResponseObject, error)
+	// Download a support bundle
+	// (GET /admin/support-bundle)
+	GetSupportBundle(ctx context.Context, request GetSupportBundleRequestObject) (GetSupportBundleResponseObject, error)
+	// Export a job timeline
+	// (GET /admin/timeline)
+	ExportTimeline(ctx context.Context, request ExportTimelineRequestObject) (ExportTimelineResponseObject, error)
+	// Report the circuit breakers of webhook targets
+	// (GET /admin/webhooks/breakers)
+	ListWebhookBreakers(ctx context.Context, request ListWebhookBreakersRequestObject) (ListWebhookBreakersResponseObject, error)
+	// Report webhook delivery statistics per target
+	// (GET /admin/webhooks/targets)
+	ListWebhookTargets(ctx context.Context, request ListWebhookTargetsRequestObject) (ListWebhookTargetsResponseObject, error)
+	// List undelivered webhooks
+	// (GET /admin/webhooks/undelivered)
+	ListUndeliveredWebhooks(ctx context.Context, request ListUndeliveredWebhooksRequestObject) (ListUndeliveredWebhooksResponseObject, error)
+	// Liveness probe
+	// (GET /healthz)
+	GetHealth(ctx context.Context, request GetHealthRequestObject) (GetHealthResponseObject, error)
+	// List video info jobs
+	// (GET /info)
+	ListInfo(ctx context.Context, request ListInfoRequestObject) (ListInfoResponseObject, error)
+	// Start a new video info extraction job
+	// (POST /info)
+	CreateInfo(ctx context.Context, request CreateInfoRequestObject) (CreateInfoResponseObject, error)
+	// Start many video info extraction jobs at once
+	// (POST /info/batch)
+	CreateInfoBatch(ctx context.Context, request CreateInfoBatchRequestObject) (CreateInfoBatchResponseObject, error)
+	// Get video info job status by external ID
+	// (GET /info/by-external-id/{externalId})
+	GetInfoStatusByExternalId(ctx context.Context, request GetInfoStatusByExternalIdRequestObject) (GetInfoStatusByExternalIdResponseObject, error)
+	// Compare the results of two info jobs
+	// (GET /info/diff)
+	DiffInfo(ctx context.Context, request DiffInfoRequestObject) (DiffInfoResponseObject, error)
+	// List the stored results for a video path
+	// (GET /info/history)
+	ListInfoHistory(ctx context.Context, request ListInfoHistoryRequestObject) (ListInfoHistoryResponseObject, error)
+	// Get video info job status
+	// (GET /info/{uuid})
+	GetInfoStatus(ctx context.Context, request GetInfoStatusRequestObject) (GetInfoStatusResponseObject, error)
+	// Annotate a video info job
+	// (PATCH /info/{uuid}/annotations)
+	AnnotateInfo(ctx context.Context, request AnnotateInfoRequestObject) (AnnotateInfoResponseObject, error)
+	// Cancel a video info job
+	// (POST /info/{uuid}/cancel)
+	CancelInfo(ctx context.Context, request CancelInfoRequestObject) (CancelInfoResponseObject, error)
+	// Get the result history of a video info job
+	// (GET /info/{uuid}/history)
+	GetInfoHistory(ctx context.Context, request GetInfoHistoryRequestObject) (GetInfoHistoryResponseObject, error)
+	// Probe a video again, superseding an earlier result
+	// (POST /info/{uuid}/reprobe)
+	ReprobeInfo(ctx context.Context, request ReprobeInfoRequestObject) (ReprobeInfoResponseObject, error)
+	// Retry a failed video info job
+	// (POST /info/{uuid}/retry)
+	RetryInfo(ctx context.Context, request RetryInfoRequestObject) (RetryInfoResponseObject, error)
+	// Readiness probe
+	// (GET /readyz)
+	GetReadiness(ctx context.Context, request GetReadinessRequestObject) (GetReadinessResponseObject, error)
+	// Get a scan preview
+	// (GET /scan-previews/{id})
+	GetScanPreview(ctx context.Context, request GetScanPreviewRequestObject) (GetScanPreviewResponseObject, error)
+	// List schedules
+	// (GET /schedules)
+	ListSchedules(ctx context.Context, request ListSchedulesRequestObject) (ListSchedulesResponseObject, error)
+	// Create a schedule
+	// (POST /schedules)
+	CreateSchedule(ctx context.Context, request CreateScheduleRequestObject) (CreateScheduleResponseObject, error)
+	// Delete a schedule
+	// (DELETE /schedules/{id})
+	DeleteSchedule(ctx context.Context, request DeleteScheduleRequestObject) (DeleteScheduleResponseObject, error)
+	// Register or refresh a pull-mode worker
+	// (PUT /worker/agents/{workerId})
+	RegisterAgent(ctx context.Context, request RegisterAgentRequestObject) (RegisterAgentResponseObject, error)
+	// Claim the next pending info job
+	// (POST /worker/claim)
+	ClaimWorkerJob(ctx context.Context, request ClaimWorkerJobRequestObject) (ClaimWorkerJobResponseObject, error)
+	// Report the outcome of a claimed info job
+	// (POST /worker/jobs/{jobId}/complete)
+	CompleteWorkerJob(ctx context.Context, request CompleteWorkerJobRequestObject) (CompleteWorkerJobResponseObject, error)
+}
+
+type StrictHandlerFunc = strictnethttp.StrictHTTPHandlerFunc
+type StrictMiddlewareFunc = strictnethttp.StrictHTTPMiddlewareFunc
+
+type StrictHTTPServerOptions struct {
+	RequestErrorHandlerFunc  func(w http.ResponseWriter, r *http.Request, err error)
+	ResponseErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+func NewStrictHandler(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc) ServerInterface {
+	return &strictHandler{ssi: ssi, middlewares: middlewares, options: StrictHTTPServerOptions{
+		RequestErrorHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		},
+		ResponseErrorHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		},
+	}}
+}
+
+func NewStrictHandlerWithOptions(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc, options StrictHTTPServerOptions) ServerInterface {
+	return &strictHandler{ssi: ssi, middlewares: middlewares, options: options}
+}
+
+type strictHandler struct {
+	ssi         StrictServerInterface
+	middlewares []StrictMiddlewareFunc
+	options     StrictHTTPServerOptions
+}
+
+// GetSigningKey operation middleware
+func (sh *strictHandler) GetSigningKey(w http.ResponseWriter, r *http.Request) {
+	var request GetSigningKeyRequestObject
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetSigningKey(ctx, request.(GetSigningKeyRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetSigningKey")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetSigningKeyResponseObject); ok {
+		if err := validResponse.VisitGetSigningKeyResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ListAgents operation middleware
+func (sh *strictHandler) ListAgents(w http.ResponseWriter, r *http.Request) {
+	var request ListAgentsRequestObject
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ListAgents(ctx, request.(ListAgentsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListAgents")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ListAgentsResponseObject); ok {
+		if err := validResponse.VisitListAgentsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetCanaryReport operation middleware
+func (sh *strictHandler) GetCanaryReport(w http.ResponseWriter, r *http.Request, params GetCanaryReportParams) {
+	var request GetCanaryReportRequestObject
+
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetCanaryReport(ctx, request.(GetCanaryReportRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetCanaryReport")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetCanaryReportResponseObject); ok {
+		if err := validResponse.VisitGetCanaryReportResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// CreateComparison operation middleware
+func (sh *strictHandler) CreateComparison(w http.ResponseWriter, r *http.Request) {
+	var request CreateComparisonRequestObject
+
+	var body CreateComparisonJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.CreateComparison(ctx, request.(CreateComparisonRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "CreateComparison")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(CreateComparisonResponseObject); ok {
+		if err := validResponse.VisitCreateComparisonResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetComparison operation middleware
+func (sh *strictHandler) GetComparison(w http.ResponseWriter, r *http.Request, id openapi_types.UUID) {
+	var request GetComparisonRequestObject
+
+	request.Id = id
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetComparison(ctx, request.(GetComparisonRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetComparison")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetComparisonResponseObject); ok {
+		if err := validResponse.VisitGetComparisonResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ListFailures operation middleware
+func (sh *strictHandler) ListFailures(w http.ResponseWriter, r *http.Request, params ListFailuresParams) {
+	var request ListFailuresRequestObject
+
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ListFailures(ctx, request.(ListFailuresRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListFailures")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ListFailuresResponseObject); ok {
+		if err := validResponse.VisitListFailuresResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// RetryFailures operation middleware
+func (sh *strictHandler) RetryFailures(w http.ResponseWriter, r *http.Request) {
+	var request RetryFailuresRequestObject
+
+	var body RetryFailuresJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.RetryFailures(ctx, request.(RetryFailuresRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "RetryFailures")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(RetryFailuresResponseObject); ok {
+		if err := validResponse.VisitRetryFailuresResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// PurgeInfo operation middleware
+func (sh *strictHandler) PurgeInfo(w http.ResponseWriter, r *http.Request) {
+	var request PurgeInfoRequestObject
+
+	var body PurgeInfoJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.PurgeInfo(ctx, request.(PurgeInfoRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PurgeInfo")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(PurgeInfoResponseObject); ok {
+		if err := validResponse.VisitPurgeInfoResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ListReprobeCampaigns operation middleware
+func (sh *strictHandler) ListReprobeCampaigns(w http.ResponseWriter, r *http.Request) {
+	var request ListReprobeCampaignsRequestObject
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ListReprobeCampaigns(ctx, request.(ListReprobeCampaignsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListReprobeCampaigns")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ListReprobeCampaignsResponseObject); ok {
+		if err := validResponse.VisitListReprobeCampaignsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// CreateReprobeCampaign operation middleware
+func (sh *strictHandler) CreateReprobeCampaign(w http.ResponseWriter, r *http.Request) {
+	var request CreateReprobeCampaignRequestObject
+
+	var body CreateReprobeCampaignJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.CreateReprobeCampaign(ctx, request.(CreateReprobeCampaignRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "CreateReprobeCampaign")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(CreateReprobeCampaignResponseObject); ok {
+		if err := validResponse.VisitCreateReprobeCampaignResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// DeleteReprobeCampaign operation middleware
+func (sh *strictHandler) DeleteReprobeCampaign(w http.ResponseWriter, r *http.Request, id openapi_types.UUID) {
+	var request DeleteReprobeCampaignRequestObject
+
+	request.Id = id
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.DeleteReprobeCampaign(ctx, request.(DeleteReprobeCampaignRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DeleteReprobeCampaign")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(DeleteReprobeCampaignResponseObject); ok {
+		if err := validResponse.VisitDeleteReprobeCampaignResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetSupportBundle operation middleware
+func (sh *strictHandler) GetSupportBundle(w http.ResponseWriter, r *http.Request) {
+	var request GetSupportBundleRequestObject
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetSupportBundle(ctx, request.(GetSupportBundleRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetSupportBundle")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetSupportBundleResponseObject); ok {
+		if err := validResponse.VisitGetSupportBundleResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ExportTimeline operation middleware
+func (sh *strictHandler) ExportTimeline(w http.ResponseWriter, r *http.Request, params ExportTimelineParams) {
+	var request ExportTimelineRequestObject
+
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ExportTimeline(ctx, request.(ExportTimelineRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ExportTimeline")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ExportTimelineResponseObject); ok {
+		if err := validResponse.VisitExportTimelineResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ListWebhookBreakers operation middleware
+func (sh *strictHandler) ListWebhookBreakers(w http.ResponseWriter, r *http.Request) {
+	var request ListWebhookBreakersRequestObject
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ListWebhookBreakers(ctx, request.(ListWebhookBreakersRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListWebhookBreakers")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ListWebhookBreakersResponseObject); ok {
+		if err := validResponse.VisitListWebhookBreakersResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ListWebhookTargets operation middleware
+func (sh *strictHandler) ListWebhookTargets(w http.ResponseWriter, r *http.Request, params ListWebhookTargetsParams) {
+	var request ListWebhookTargetsRequestObject
+
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ListWebhookTargets(ctx, request.(ListWebhookTargetsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListWebhookTargets")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ListWebhookTargetsResponseObject); ok {
+		if err := validResponse.VisitListWebhookTargetsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ListUndeliveredWebhooks operation middleware
+func (sh *strictHandler) ListUndeliveredWebhooks(w http.ResponseWriter, r *http.Request, params ListUndeliveredWebhooksParams) {
+	var request ListUndeliveredWebhooksRequestObject
+
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ListUndeliveredWebhooks(ctx, request.(ListUndeliveredWebhooksRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListUndeliveredWebhooks")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ListUndeliveredWebhooksResponseObject); ok {
+		if err := validResponse.VisitListUndeliveredWebhooksResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetHealth operation middleware
+func (sh *strictHandler) GetHealth(w http.ResponseWriter, r *http.Request) {
+	var request GetHealthRequestObject
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetHealth(ctx, request.(GetHealthRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetHealth")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetHealthResponseObject); ok {
+		if err := validResponse.VisitGetHealthResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ListInfo operation middleware
+func (sh *strictHandler) ListInfo(w http.ResponseWriter, r *http.Request, params ListInfoParams) {
+	var request ListInfoRequestObject
+
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ListInfo(ctx, request.(ListInfoRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListInfo")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ListInfoResponseObject); ok {
+		if err := validResponse.VisitListInfoResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// CreateInfo operation middleware
+func (sh *strictHandler) CreateInfo(w http.ResponseWriter, r *http.Request) {
+	var request CreateInfoRequestObject
+
+	var body CreateInfoJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.CreateInfo(ctx, request.(CreateInfoRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "CreateInfo")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(CreateInfoResponseObject); ok {
+		if err := validResponse.VisitCreateInfoResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// CreateInfoBatch operation middleware
+func (sh *strictHandler) CreateInfoBatch(w http.ResponseWriter, r *http.Request) {
+	var request CreateInfoBatchRequestObject
+
+	var body CreateInfoBatchJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.CreateInfoBatch(ctx, request.(CreateInfoBatchRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "CreateInfoBatch")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(CreateInfoBatchResponseObject); ok {
+		if err := validResponse.VisitCreateInfoBatchResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetInfoStatusByExternalId operation middleware
+func (sh *strictHandler) GetInfoStatusByExternalId(w http.ResponseWriter, r *http.Request, externalId string) {
+	var request GetInfoStatusByExternalIdRequestObject
+
+	request.ExternalId = externalId
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetInfoStatusByExternalId(ctx, request.(GetInfoStatusByExternalIdRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetInfoStatusByExternalId")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetInfoStatusByExternalIdResponseObject); ok {
+		if err := validResponse.VisitGetInfoStatusByExternalIdResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// DiffInfo operation middleware
+func (sh *strictHandler) DiffInfo(w http.ResponseWriter, r *http.Request, params DiffInfoParams) {
+	var request DiffInfoRequestObject
+
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.DiffInfo(ctx, request.(DiffInfoRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DiffInfo")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(DiffInfoResponseObject); ok {
+		if err := validResponse.VisitDiffInfoResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ListInfoHistory operation middleware
+func (sh *strictHandler) ListInfoHistory(w http.ResponseWriter, r *http.Request, params ListInfoHistoryParams) {
+	var request ListInfoHistoryRequestObject
+
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ListInfoHistory(ctx, request.(ListInfoHistoryRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListInfoHistory")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ListInfoHistoryResponseObject); ok {
+		if err := validResponse.VisitListInfoHistoryResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetInfoStatus operation middleware
+func (sh *strictHandler) GetInfoStatus(w http.ResponseWriter, r *http.Request, uuid openapi_types.UUID) {
+	var request GetInfoStatusRequestObject
+
+	request.Uuid = uuid
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetInfoStatus(ctx, request.(GetInfoStatusRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetInfoStatus")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetInfoStatusResponseObject); ok {
+		if err := validResponse.VisitGetInfoStatusResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// AnnotateInfo operation middleware
+func (sh *strictHandler) AnnotateInfo(w http.ResponseWriter, r *http.Request, uuid openapi_types.UUID) {
+	var request AnnotateInfoRequestObject
+
+	request.Uuid = uuid
+
+	var body AnnotateInfoJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.AnnotateInfo(ctx, request.(AnnotateInfoRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "AnnotateInfo")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(AnnotateInfoResponseObject); ok {
+		if err := validResponse.VisitAnnotateInfoResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// CancelInfo operation middleware
+func (sh *strictHandler) CancelInfo(w http.ResponseWriter, r *http.Request, uuid openapi_types.UUID) {
+	var request CancelInfoRequestObject
+
+	request.Uuid = uuid
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.CancelInfo(ctx, request.(CancelInfoRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "CancelInfo")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(CancelInfoResponseObject); ok {
+		if err := validResponse.VisitCancelInfoResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-func HandlerFromMuxWithBaseURL(si ServerInterface, m ServeMux, baseURL string) http.Handler {
-	return HandlerWithOptions(si, StdHTTPServerOptions{
-		BaseURL:    baseURL,
-		BaseRouter: m,
-	})
-}
+// GetInfoHistory operation middleware
+func (sh *strictHandler) GetInfoHistory(w http.ResponseWriter, r *http.Request, uuid openapi_types.UUID) {
+	var request GetInfoHistoryRequestObject
 
-// HandlerWithOptions creates http.Handler with additional options
-func HandlerWithOptions(si ServerInterface, options StdHTTPServerOptions) http.Handler {
-	m := options.BaseRouter
+	request.Uuid = uuid
 
-	if m == nil {
-		m = http.NewServeMux()
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetInfoHistory(ctx, request.(GetInfoHistoryRequestObject))
 	}
-	if options.ErrorHandlerFunc == nil {
-		options.ErrorHandlerFunc = func(w http.ResponseWriter, r *http.Request, err error) {
-			http.Error(w, err.Error(), http.StatusBadRequest)
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetInfoHistory")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetInfoHistoryResponseObject); ok {
+		if err := validResponse.VisitGetInfoHistoryResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
 	}
+}
 
-	wrapper := ServerInterfaceWrapper{
-		Handler:            si,
-		HandlerMiddlewares: options.Middlewares,
-		ErrorHandlerFunc:   options.ErrorHandlerFunc,
+// ReprobeInfo operation middleware
+func (sh *strictHandler) ReprobeInfo(w http.ResponseWriter, r *http.Request, uuid openapi_types.UUID) {
+	var request ReprobeInfoRequestObject
+
+	request.Uuid = uuid
+
+	var body ReprobeInfoJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
 	}
+	request.Body = &body
 
-	m.HandleFunc("POST "+options.BaseURL+"/info", wrapper.CreateInfo)
-	m.HandleFunc("GET "+options.BaseURL+"/info/{uuid}", wrapper.GetInfoStatus)
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ReprobeInfo(ctx, request.(ReprobeInfoRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ReprobeInfo")
+	}
 
-	return m
-}
+	response, err := handler(r.Context(), w, r, request)
 
-type CreateInfoRequestObject struct {
-	Body *CreateInfoJSONRequestBody
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ReprobeInfoResponseObject); ok {
+		if err := validResponse.VisitReprobeInfoResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type CreateInfoResponseObject interface {
-	VisitCreateInfoResponse(w http.ResponseWriter) error
-}
+// RetryInfo operation middleware
+func (sh *strictHandler) RetryInfo(w http.ResponseWriter, r *http.Request, uuid openapi_types.UUID) {
+	var request RetryInfoRequestObject
 
-type CreateInfo201JSONResponse InfoJob
+	request.Uuid = uuid
 
-func (response CreateInfo201JSONResponse) VisitCreateInfoResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(201)
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.RetryInfo(ctx, request.(RetryInfoRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "RetryInfo")
+	}
 
-	return json.NewEncoder(w).Encode(response)
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(RetryInfoResponseObject); ok {
+		if err := validResponse.VisitRetryInfoResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type CreateInfo400JSONResponse Error
+// GetReadiness operation middleware
+func (sh *strictHandler) GetReadiness(w http.ResponseWriter, r *http.Request) {
+	var request GetReadinessRequestObject
 
-func (response CreateInfo400JSONResponse) VisitCreateInfoResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetReadiness(ctx, request.(GetReadinessRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetReadiness")
+	}
 
-	return json.NewEncoder(w).Encode(response)
-}
+	response, err := handler(r.Context(), w, r, request)
 
-type CreateInfo409JSONResponse Error
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetReadinessResponseObject); ok {
+		if err := validResponse.VisitGetReadinessResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
 
-func (response CreateInfo409JSONResponse) VisitCreateInfoResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(409)
+// GetScanPreview operation middleware
+func (sh *strictHandler) GetScanPreview(w http.ResponseWriter, r *http.Request, id int64) {
+	var request GetScanPreviewRequestObject
 
-	return json.NewEncoder(w).Encode(response)
-}
+	request.Id = id
 
-type CreateInfo500JSONResponse Error
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetScanPreview(ctx, request.(GetScanPreviewRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetScanPreview")
+	}
 
-func (response CreateInfo500JSONResponse) VisitCreateInfoResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(500)
+	response, err := handler(r.Context(), w, r, request)
 
-	return json.NewEncoder(w).Encode(response)
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetScanPreviewResponseObject); ok {
+		if err := validResponse.VisitGetScanPreviewResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type GetInfoStatusRequestObject struct {
-	Uuid openapi_types.UUID `json:"uuid"`
-}
+// ListSchedules operation middleware
+func (sh *strictHandler) ListSchedules(w http.ResponseWriter, r *http.Request) {
+	var request ListSchedulesRequestObject
 
-type GetInfoStatusResponseObject interface {
-	VisitGetInfoStatusResponse(w http.ResponseWriter) error
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ListSchedules(ctx, request.(ListSchedulesRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListSchedules")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ListSchedulesResponseObject); ok {
+		if err := validResponse.VisitListSchedulesResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type GetInfoStatus200JSONResponse InfoJob
+// CreateSchedule operation middleware
+func (sh *strictHandler) CreateSchedule(w http.ResponseWriter, r *http.Request, params CreateScheduleParams) {
+	var request CreateScheduleRequestObject
 
-func (response GetInfoStatus200JSONResponse) VisitGetInfoStatusResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	request.Params = params
 
-	return json.NewEncoder(w).Encode(response)
-}
+	var body CreateScheduleJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
 
-type GetInfoStatus404JSONResponse Error
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.CreateSchedule(ctx, request.(CreateScheduleRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "CreateSchedule")
+	}
 
-func (response GetInfoStatus404JSONResponse) VisitGetInfoStatusResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(404)
+	response, err := handler(r.Context(), w, r, request)
 
-	return json.NewEncoder(w).Encode(response)
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(CreateScheduleResponseObject); ok {
+		if err := validResponse.VisitCreateScheduleResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type GetInfoStatus500JSONResponse Error
+// DeleteSchedule operation middleware
+func (sh *strictHandler) DeleteSchedule(w http.ResponseWriter, r *http.Request, id openapi_types.UUID) {
+	var request DeleteScheduleRequestObject
 
-func (response GetInfoStatus500JSONResponse) VisitGetInfoStatusResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(500)
+	request.Id = id
 
-	return json.NewEncoder(w).Encode(response)
-}
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.DeleteSchedule(ctx, request.(DeleteScheduleRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DeleteSchedule")
+	}
 
-// StrictServerInterface represents all server handlers.
-type StrictServerInterface interface {
-	// Start a new video info extraction job
-	// (POST /info)
-	CreateInfo(ctx context.Context, request CreateInfoRequestObject) (CreateInfoResponseObject, error)
-	// Get video info job status
-	// (GET /info/{uuid})
-	GetInfoStatus(ctx context.Context, request GetInfoStatusRequestObject) (GetInfoStatusResponseObject, error)
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(DeleteScheduleResponseObject); ok {
+		if err := validResponse.VisitDeleteScheduleResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type StrictHandlerFunc = strictnethttp.StrictHTTPHandlerFunc
-type StrictMiddlewareFunc = strictnethttp.StrictHTTPMiddlewareFunc
+// RegisterAgent operation middleware
+func (sh *strictHandler) RegisterAgent(w http.ResponseWriter, r *http.Request, workerId string) {
+	var request RegisterAgentRequestObject
 
-type StrictHTTPServerOptions struct {
-	RequestErrorHandlerFunc  func(w http.ResponseWriter, r *http.Request, err error)
-	ResponseErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
-}
+	request.WorkerId = workerId
 
-func NewStrictHandler(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc) ServerInterface {
-	return &strictHandler{ssi: ssi, middlewares: middlewares, options: StrictHTTPServerOptions{
-		RequestErrorHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
-			http.Error(w, err.Error(), http.StatusBadRequest)
-		},
-		ResponseErrorHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
-			http.Error(w, err.Error(), http.StatusInternalServerError)
-		},
-	}}
-}
+	var body RegisterAgentJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
 
-func NewStrictHandlerWithOptions(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc, options StrictHTTPServerOptions) ServerInterface {
-	return &strictHandler{ssi: ssi, middlewares: middlewares, options: options}
-}
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.RegisterAgent(ctx, request.(RegisterAgentRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "RegisterAgent")
+	}
 
-type strictHandler struct {
-	ssi         StrictServerInterface
-	middlewares []StrictMiddlewareFunc
-	options     StrictHTTPServerOptions
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(RegisterAgentResponseObject); ok {
+		if err := validResponse.VisitRegisterAgentResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-// CreateInfo operation middleware
-func (sh *strictHandler) CreateInfo(w http.ResponseWriter, r *http.Request) {
-	var request CreateInfoRequestObject
+// ClaimWorkerJob operation middleware
+func (sh *strictHandler) ClaimWorkerJob(w http.ResponseWriter, r *http.Request) {
+	var request ClaimWorkerJobRequestObject
 
-	var body CreateInfoJSONRequestBody
+	var body ClaimWorkerJobJSONRequestBody
 	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
 		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
 		return
@@ -814,18 +10849,18 @@ func (sh *strictHandler) CreateInfo(w http.ResponseWriter, r *http.Request) {
 	request.Body = &body
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.CreateInfo(ctx, request.(CreateInfoRequestObject))
+		return sh.ssi.ClaimWorkerJob(ctx, request.(ClaimWorkerJobRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "CreateInfo")
+		handler = middleware(handler, "ClaimWorkerJob")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(CreateInfoResponseObject); ok {
-		if err := validResponse.VisitCreateInfoResponse(w); err != nil {
+	} else if validResponse, ok := response.(ClaimWorkerJobResponseObject); ok {
+		if err := validResponse.VisitClaimWorkerJobResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -833,25 +10868,32 @@ func (sh *strictHandler) CreateInfo(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-// GetInfoStatus operation middleware
-func (sh *strictHandler) GetInfoStatus(w http.ResponseWriter, r *http.Request, uuid openapi_types.UUID) {
-	var request GetInfoStatusRequestObject
+// CompleteWorkerJob operation middleware
+func (sh *strictHandler) CompleteWorkerJob(w http.ResponseWriter, r *http.Request, jobId int64) {
+	var request CompleteWorkerJobRequestObject
 
-	request.Uuid = uuid
+	request.JobId = jobId
+
+	var body CompleteWorkerJobJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.GetInfoStatus(ctx, request.(GetInfoStatusRequestObject))
+		return sh.ssi.CompleteWorkerJob(ctx, request.(CompleteWorkerJobRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "GetInfoStatus")
+		handler = middleware(handler, "CompleteWorkerJob")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(GetInfoStatusResponseObject); ok {
-		if err := validResponse.VisitGetInfoStatusResponse(w); err != nil {
+	} else if validResponse, ok := response.(CompleteWorkerJobResponseObject); ok {
+		if err := validResponse.VisitCompleteWorkerJobResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -862,26 +10904,336 @@ func (sh *strictHandler) GetInfoStatus(w http.ResponseWriter, r *http.Request, u
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/8xWbW/bNhf9KwSf56McyZ6dpgL6IWuLzkOxprHdYS2CgRKvIqYSyZBXTozA/30gJdly",
-	"pLxgaIN9SWT58p7jw3Nf7miqSq0kSLQ0vqM2zaFk/vG9Mcq4B22UBoMC/OtUcXD/OdjUCI1CSRrXwcR/",
-	"F1C4ZaUugMZ0/seX04/zd3+fv/+8er9Y0oDiRrsvLBohL+k2oCVYyy4HUv5WlUyODDDOkgIIeIQ2uguy",
-	"zIGsBQdFNMOcCEuEXLNC8D7cNqAGrithgNP4G234tkkvdvEquYIUHb25zNTvKhnQwQBD4KfYZ74UJVhk",
-	"pSY3OUiCOZArlZAbZklzigY0U6ZkSGPKGcIIRQlD6kB7CUNyN7yJyDyGkJkicIuGpS6MZEwUwIeyGrBV",
-	"4Yn/30BGY/q/cG+DsPFA+MVp6n6/O2KRYWWfOuKiF3XkNqCV5v9CooJZJM3RZ+tUVYL3UVZSXFdABAeJ",
-	"IhNgSKbMXqsrlXQBfI6B3N5bZwzzPoB7S1D5lLUFM1EASUDISyKk1ZAiPO3DBrnRuIsYdHzWFfQhq57D",
-	"dQUW+3YdFuhtIUDiSBvlIDlZrebvhjTaF9tsFsHJNIpGMHmdjKZjPh2xV+Pj0XR6fDybTadRFEU/R1RU",
-	"raIHhEIfYsNSrQUcld/XQ2g3kORKfV+q7yD7gJ/8AytIwiwcT0cgXWPgBF14jZsWFXeKkCYTOfu0WJJE",
-	"8c0BmXTyGr8uxlEywSIR48lff96Ov35+86arSLJBeITjyohHGK7O546QR6+r2LrPvohcAbmaLADBHtDK",
-	"EbWNw7B5c5SqMmzgDu7KiOdadX99DzlxsWsY9yxXGQMSSW12ooZ7V2M7WZUOVIPkjkxATSVl/dT+Ut8j",
-	"6k53MaDqvon1O3jONIJ5VxnmMO0CUiX5AOU2wpEFluakOen8YJtDHbm/jU+iKDj8czS7CKhAKH36fU9T",
-	"VVJ03CCrMgHjeDcvmDFs4z8rZEVL5EGmSxdFeIfvvoiGyb6aOHLB05Tu+WCQT/Cgpn2XuISiuZfDH3F6",
-	"Nvc9qLWDvCQlIOMMGcmMKjtNwUGiQG9yf9PEXTU5PZs7j4KxdcbxUXQUORWVBsm0oDH9xb8KqFsZvJBh",
-	"S0YrOzCw3vo2bAkjEm52ivZMS24E5oSR9KHGKjiUWiHI1HUOZ0cv1JzvMLxZa7HB4q+uxfi1SyJIT4xp",
-	"XYjUHwuvrJL7ve0547mdENvDG0VTQb0ZaCVtXSCTaPxDod0e5WEPpZ03c6Zdjoit0hSszaqi8N6fRtEP",
-	"41FvtYMs/M5ITKuPw33983FP5W7Q1ubBXNjaL6xw2++GwK2w6Deq2csogWDcsLFg1mDqzds3AFuVJTMb",
-	"GtMFMoNPFYM/4+sqvHNzY+soXcJAdZ0DVkZa363S3oBgj0Dcr6EPgJ3p4+rbsBIQjKXxt167zKFWujuF",
-	"6qzCfa/rFUyyEvarzGHNBB21n1h6the9+opetL7sbjOfRtOX8FGDKxWSTFWS/6cs/AGw66uuQD7Qnxxy",
-	"zUeVuiELayiULr1Vfaxb0E3RrFtxGBYuLlcW45PoJKLbi+0/AQAA//+meKTxbg8AAA==",
+	"H4sIAAAAAAAC/+y9C3PbuJIw+ldQ+r6qnLNLy7LjeJJsbd3Pec1kNg8f2zmz98zkpiASkjCmCB0AtKOZ",
+	"yn+/1d0ACFKgROU1OfvNbtUZRyTxaHQ3+t2/j3K1XKlKVNaMHv4+WgheCI1//vfB32pRi4MnYmUX8EMh",
+	"TK7lykpVjR6OXtXLqdBMzZisZor9qqaG3XJpZTVnVjFdVxnLVV1ZUTBu2VIZyzgzIldVwQTXpRR6zM7K",
+	"W7427DehFaurUhjD7EIwI/SN0KyUS2npl3/CWlgBaxmPspHJF2LJYVV2vRKjhyNZWTEXevThw4dspIVZ",
+	"qcoI3Afu4lldlvCPXFVWVBb+5KtVKXMO2zn81cCefo+G/d9azEYPR//rsIHPIT01h0+1Vm6mNkyulGJL",
+	"Xq0jkHAtOmAZM3YhrF4zPrNC4+aqAEuCj2FzeSMqNl3Tqwdn8CrsOzqf6Mnm6Vy6cazC2dlUzJQWTMM3",
+	"spqPAEb/rKUWxeih1bXYCtFsExdS4HFrO2y//AHh5EAHn57N3QGstFoJbSUdU85XPJd2vQ3TEKIAsFul",
+	"r4UGaBqWqyqvtRaVLdejbGP12Wg2W2k1FX8X2khVbY7vHsAE7lVWG1EA9Ju5mpGN1QDBD9lovqofD1j1",
+	"9+dvPnLlC2VsxZdic/QfgJzgEUwA4y55vpCVgIErxLWtKy+5sZdCVGd2c+gruRTG8uXKD03D3DFEw1rk",
+	"ooL/zKWxGsmHKc3yksvlKBvNlF5yO3o4KrgVB1YuRWr+JTAGszn3E6lFbpWWwrC6KoRmtwuZL2LI5bxi",
+	"WvCC3chCKDaTpTCjbCStWJoIe5u53A9ca74eIXOAlQstiu27v12IKp54JrWxrPl68GbdkfyopmYncgd8",
+	"IID2Y2GEJfToebE5+PNCVFbOJE2wDSU+xAzh52bICAfDqW1QVNYQb5so2nvvgL6FhW/DitT0V5Fb2Bcy",
+	"ihfSJJgFn/sLK5z7NoaNI23iQmfTbtDepVxEKP/l+Jd4z5erUoweHmejpazksl6OHh59Sb4WZhzdGx+N",
+	"Tw8m/16I6dFxfTSI5814XdrRw0n2afwvY7JivCgkfM6sYhFKhQUeRSCZfEmG2YCk4ubASCsOjr8CHxsz",
+	"dlYxsVzZNSulsWwpeGWSX4GUseIkDIXV/jw6xNHMoVvy2+GMsUMM+5F9kmaqSlkkFpMg4Py6UrelKOYi",
+	"wbd+Wgi7EJrxisFH3CrNFtyw+CuEyq9qmjG7Xsmcl+WacQbPKzbjsqx1xIynSpWCV7CsStkEejzTQhwA",
+	"O2fwnJViZoFOogW0sOK/YJqDKS+YUbXORcbkvFI6yf7rFdwOycvmJ3/F8AZW7FZowYA1snzBq7koACum",
+	"RlSWSUTdNasESMfw4njgLdRldTH4dxzeG1z/vkf4Sty2j2tW8vkeBwLfw5OYJGgzLC8F10QV+AagKH//",
+	"QlRzkE1PJg9OU9vf3GJdSPVC1UUlTIKEnz56wy6Oju+z0r0SrtCFKgWzmufXGRCoqbUoSFoIr/KKl2sj",
+	"QSMyDAAvjMWDfEnvL+GmQd2A08nOlGZGlvAnjmxgV214I5vTgEov6lliwc/D82YdsmIv3jy7jHH34Pju",
+	"+CRGGlVPywhjSBdBIdGNcgFY+KLenNEDj2l4g/3lxcXZX2nKFtM+Gd8fNJ9daGEWquzZ3/eclCj/lt8c",
+	"XWoAQDgduQmF1u7v3h0/GLYaXYtzwa+fTO0qISbqWrCV4NewiuLR1XlrkqPx8YA5epHyCjBgk+Cm0l7w",
+	"FK08kpbBlmEtU2kNWwntNMkMeAYyxXiBpyeTyWQSLVFW9vQkKVwCD6pE+YKvVZ3gYI/pMSvpeUeY+IuR",
+	"hfhriim6YbcKxBxgwcKb8fqTK1WFyF8lL//LhdLt2x9fbi1X8PxuaqVBwum7pGA4pFkmDXI54HZwWTH3",
+	"KT1N8r6Z0rko9h/bfZcaUlaFeJ/iDoV473dvrBZ8yW6lXUi6gED86EhamxAueTWv+TwB4BfuCbN87ifx",
+	"u45AXM2TymjEg7dK8S2GDUYFHDhNE5f4LJDFQmj7W7yYk/tIAQlbR3xPEjBj3IpQt8GNcJCpq/RRyfPr",
+	"R1wnpKCpslYt4a+IWyYFWxBIWu8l39JyvhjwmlWr3XN2IAHfZH7Bbj1+wtSuH/OK6/UTOZsJLao8IT9M",
+	"uRGlrARZ0zbvX/jZ45J/lwQ+OWPSopAnihRC+bff1DJBWz+qKbMLbtlKq6LOnSSphQFSlRavbMA+jte6",
+	"tItYxKprmZwyx+0O2Qq9OWwj9G56G4/DOL27GbRwkjGfSVEWCW78UhSSPwdrZnN4NF+BR5sxpZnA/ckZ",
+	"U1W5ZqoKXBa1Ptoeo/t57f9ZOMzoKL0/j3i4AYHCUJ25RHZl9tFm3JfnnMyV26Xh5tUWyDuI1IVVP9Zf",
+	"iJXSKROnw6ptl55DDzrADiIyHggheVcXgdoSJ3kFOm9kwKOXUZ5uTRnb0rZx4w0CT5xBmGOPHUfIBWKd",
+	"VkvAG6m3b12ipSvn5b4zLbnNF6IYMkelHvnHwycBuRT0dIMnqGrLuHtGe+MNBd9yU92B5zREcg0roXNn",
+	"Pm8v4JwewB2sZkyUci6npWCVuCUq1Kq2sE0VMyF0qsTkdy81J721MSMa+SN2RvpMmKeltcKvgGrSsEIa",
+	"Pi1F0TJcjLpbjuha11XO7Q4pSQvSm5dKi8Q5V+CKKgs2FQ1FccsU4O2mENVhD/6LUYxmMXK3UCNecZsk",
+	"kwxjwVfOgdPmFaIqnA8ncZ9URbhN6HsmK+86aklx9yeTAYpINjKWa9s73yU8HTbjsOmstKVIKhM4ND2O",
+	"Rg1PjnZaNlo7yWIwpsEv8mtTL8/KudLSLpapRdEraJNUy1VtBVM3Qgex+Y5hzqsI9rv37xfcLE5PGK8K",
+	"Zhb8+N4pGewa0wF8NGZsxbWVvASa4FXznQOzG9nI3wQOJa1xHgj4F5qHXspHmbMrgnbA3bO5UgUTlarn",
+	"C1izWSnLSnktyjUranJ5CsOmtWWVsvDGjdBytma5WkmBdgdRgYH155Ff0ygb0U5G2citevR24yCy0WMk",
+	"FGlS5vFpxDq3XSsga/yopk76KaS3PQ39pveK9cxiquyC2NWC34jAJAByKJ8gG/HDjBnD8w/SzB0SdgzZ",
+	"e4TEIcE0OZOVNAtRBDavKrRMbSpouRaNPXCYB2nrxd5cwSZcl+Eiu2P8bdNwfgLqHZMxpQu8ZafOltwx",
+	"NDbA0QL/bVCDJssjXFUBTEOFhgsccLvQIIsWYPoE1z3kOxygJeSFI2hkvFGMcBEiJdlGQPQLsu19PL43",
+	"Q13KQuyF9puflnzqbCrbvntBb3Vh2JEnuF3AUb+5eOFZEr4NeIRegIxxtC8C8XkoxCzbeyKW6kaK8fL6",
+	"Zifrjk8oeSrbjwKBkPD73KJGYmSBkhFnefiCNmICvx0zdt5oOeB7YbcgmCFfKFRnt5sWWrT6/iYea9Kv",
+	"N0nfvRAbnXtf0m335opbKzTs6P/7mR/89hb+Z3Lw4N3B298n2enxh/+ddE/x989pgKPTTULL3c22E882",
+	"Lkk0M+VlXYgLfpveRRAat42MIuSIAnbQjzKEgbgXg/3nJ1kV6ha/XPL35Bw8nexyntKll1r7hySWVZbL",
+	"KhVpEx4xYlmEZIBOWWzcl0sQzQ2gDrLxbKhx9/WN0LwswbC7n5H3/r3JcCuvFujsgeiHxBbdUwb3U2zi",
+	"c1bDYZeYqHJVpCD4lB50B85YbWp061V86T20y/o9/BnFbsVbHpVyKqbLkt0cjU/Gx+zfWSmnS261Mtcc",
+	"fjwdn6SWRht4oap52n78xP/rRrSsyG7j8Qpe+tkO2U9i+rJ/tl2WatOexDiztUc20ltNBuxsyZkRK66d",
+	"ztEsxm89uxXTZWopIF4+WtuUWHEJkmd0HIh3+Go0w3enhGQD0axH7r+CnxN41WzkkZyzR3V+zR7VVbXe",
+	"eZNEEI73mLw/tFo9EVbkNhlDcZbjoa9kbmstGNeCN4vUxrpLkQzqGMOwku9FGQ6vEIDbYMfgS5F5KZNN",
+	"wSbMplyDbKVqlO/HjD3DP6drhpwNEF3cCLCpmRXPUbZEVte+kDV3SjCvaDokkLKEt6TN4rBK0j/oMzBz",
+	"htUpTVqAo6nNq20aG7G3MejG2g3hGGLDIH1/kjRJ44ZFv8r7WC2nshIFK9HN6yHg7soAmVb8zEDVF1/f",
+	"6ohKgt1P7aJcQwhJBGJuUPSgz0ljkHYr4FvKexJQt7Igca1578Fx8s2EE+j1bGZE0OQ54TbidFAcMPJB",
+	"FHPR0eo3x19/1PhWrTaGH+J/oH0HlILtwRKyCDObs9zApxTlP8Ez+HsM/c0N1TZXxO8DaYkbodcBCQrn",
+	"pnQutQ7DnNVliSZy52bzmBTCexstC5R9R/UZK5W6hrkAP3Kldb1KkyUQYCmSAS28NMLrcYRums1B461X",
+	"PhoYHomqiBcdHwvFBW+Kdg55e2kVRO4Zhz1bFQZu4DdXFkES4ivx8S13+xcFQjW87rdYZO4GTDKCBP3f",
+	"Ozk+Gt8dxANQp38MZLyNDTjNv1TohXWH50AbT3w3RS3ifS70yqbt8nSVDB1/9PPi+PSE/R82eX/vXjF5",
+	"Sx+CJSgG88tH7N5ddjzJ6NYm9Dz4LimQwPTopOo907PVSqv3csmtYCtlKEov8h23L0VcUGNN6D3pcdtY",
+	"efdkfG9Y3ETMGaLD28DNrKGQFAt4SuGGCU8N2RtTbhS1OijFjSi9ITRcC4IGQyEADStDjSJuFd4UnPKj",
+	"7I5BkIZJ4zAGX/brSdqgiprCWHvP+4l7obO5O4bdSG1rXqIugC5ZcmJK461JGVOwpltpiMMUnaE2Qg1O",
+	"jieDzj0bLWRRiKofDKuSr+FEzAKt/QtZiHj1SVC4VW+PwXADsNqIsM8GAawCoAMs+AZ4knMmHboODdib",
+	"508yCll4zwuRyyUvY3CN7s6O+YP8/lExORHfTU/v7ZSGyYDWxCr4HQd4buJD1lDAFrrZ4rxAF892mAZH",
+	"gqFzS4ez7OkHAa5kDTNivhQV3KnROQQQPhgoGdIgb1KHlTikDDX/CpHfL6C7UcuvRYXC0NgzR7ziW6xE",
+	"GrJGtU79QXEkJrOT/Hj6Hb8v7p7em/Cj/KT4ThzPHkzv82Ss9kd4dQbB7ws5eV6vRCWr+U587nfxZAHz",
+	"kljrozO6MlTKbogvY5hYa43PX/397MXzJ+8unv7tzdPLq6TRTRiTDJL6oV7y6kALXsAa3a3t344nuQpK",
+	"BljlAW9kdcNLWewEjVuvHzQFhWcUG/29VvUqGaKwVBUYYc+1mMn3qaDPai6MZYULcV+zFb4Jzi7nS4gl",
+	"ZNoBXf9znLNlJK64OeQ6X8gbcZgMT9kllLlwEnTo9E1z/CCpwDjBYfvpR9sKY6P4+vrqh6cXSL3eI9Q4",
+	"flp7PH968fL55eXz16/ePXn66vnTJ6l9utcB8AlS/XsApXHmRXGb3PLw8JiZrOZCr7RMgffSIobKjfQh",
+	"nOeOYaKBDwjrKSR+kB/NJuJkesy/K4Bh7UUqT2PaaE2eIZzZDdcS17ji2hqmxarkufNkwV8QICx0Sx8Y",
+	"OVSxihnLbZR38ZB++KWeTO7mAGX8SzxkK6GX0mASTSEqKXYTYINTbRA3e/U43TnzbJP0tlDvZb1ccr3e",
+	"pF+EUSp4Gn8HSBq5lCXXPkfCZKzkGikaZfehQmuLjSTwyyrLS/eSGUrAuaqM9MJJo08dHQ8IT4ynyzwc",
+	"kiCUpXgcuT46Ppw4EmBvr8gNL1OhMld4w9L7QLKluhU650b0CnkP8mNxUtyfHfG703v5dwMyOsIy/CpS",
+	"e/9B8NIuLi23dSIc1YTfO8ofJWmrdjSvuh5yU8OAqZWAI+0R2LDBQUVu4c0FCX9jD8jGzka/qukekQJ9",
+	"m/3h6uqc0UNKKLBiyW5Jn6CAgVzIGx+adv768oodymqmHrLjyZE3uEBMF8aRkqMZL4yTyQPSlQx78+b5",
+	"E/hJvLdCV7xkz58E6bBtwkz6ruqk/kCDese9SznB5e+OA+1qDPTSkOPrdYAHLtKNQ/eZ8VY58AxlObGn",
+	"ue3cRP/DUlb+3zty22i2HdtKY2TPriI7oeD5ogN/1BTcT/uZBlJU8uGTdvejmm7uirdT9bYG4UevdoNZ",
+	"dmZSd8hiuOtwW1y1lxIc6QEtAmFpji6dLdHVW+S/l5Qc2gjrKOr4HCP80EdvL8jKP2ZvXl2+OT9/fXH1",
+	"9Mm7Z68vXp5dRembZBA2GHLFnejxF6XJeJz5tbskTxeWgM/wS/NXFLNuOQ2AzyE26dnzF0/fXb1+/e7F",
+	"2cX3TxPThUC1kMOPAWVYV2PM2KvXV++evX7z6gkOvyGo4oDxwiiIEveQ58I0c43Z1fOXT1+/ibcMh615",
+	"xVbcWOR6cLqqhnnPz65+eAeTn7148fqnp0+ir7zGo2prvPkmLJ6XcHMWTCsFwXZeGXv95uq8NXX4wMU+",
+	"FZJWjU5TfKOJayfEDvAtpMm5LrrxqZuHm8QoY9FEWjwmq6NUVW/aJ2crUaGlFgAlDRPvVyJ3MboUTfYQ",
+	"wRYGZaj4slXpbiYOkQFzgXD1Ud1eKYAPqWSKVeraVx4ZTnB+TjQSfNwecLUZXZPNcoLx2LtCMaaBWxfI",
+	"GEq93DG0lxBXF1AZYx+P7rGlrGqLOdOv0UUqLOOlquakIOAg524ycryqpbTWp2pWqjM+AbBc7wEkd3s/",
+	"T2ZnlKXQB6aGQAVRxKpUk6lIN2FGmRNkGF9pBcyhSBft2C+6a6Wl0smKBI8p6575N6J8DXdgR+wvCzlf",
+	"CGP/Cmd5wv4ClGfsXzNGkT/oZK7WTHNpiDMu+A38eMtlJwzh7tag8kHxQa3TTASruSekHLdwMiIFCJYV",
+	"WrAjbymvxHukmKYwD9SpgD+DDR9G9FBCBxTO4S80Fy0Kyy3qUhQZM+TxClg+1+Duw/cRnrqBuQuZ1/JG",
+	"xDisqngDhs0EJShM1ymxse1B2Vky4uPirHQQhrZ9ElgqBZbMK1FcDPrwEt895+tS8aItmu+SjZwmA9/U",
+	"K6GNKFLKZiwZu8pTJIoslAmZX2h3/VVN7wRrgvFXfCSywDFweAGu4CH5VVZC7FKPlxTZFQqMqwU3cdoU",
+	"su3Mm3cIFRHhNK+GCo/nMOaVXPqVdDT0LXUJtohuyH3dp4M5ZY/CUsl/1mIbaxwC4F0xrM4H3Vh72FQA",
+	"ccnK0F21pzbUjiRuCCrit567tQONG3hvkc1/kMaqlGGnR/G4ail7yL+RSSFSIQO0rCENxKdaA6c7cyXZ",
+	"jiaTJnmnlDDMeB/txGnTn6aRpIv87NQgVUVUu+JzkbFK3O5txerdQTaCy+Gcz8WVuk65HPFnDIbmxjBO",
+	"iwg/ItNu7hh41rjDVdXIMfhkJwZuB2CvCt6JR+7GNFqR2zhQpxXkBgJTFKUWx6Q15ISS1my2XIk5ZMFo",
+	"tSpo1JksrYAIABQTXYKJBAHeqAZfMwqLc2kLPNcqEacGeIkVHmRlrOBwx8oqF87n7AOpXKwNiCS3fD1m",
+	"7Am5O9HMMOOl6UnGSERkdxNgsWAHLitZEoScHaSrUap+Gyr4rtLLBiZXi7Bw4wP18BhgjL2XngpSeOXj",
+	"R/07oDTMZWVCZUbMca5mco7FS1Ss5ZkxewrcI1eV1XIKMjbKUKq2qzrkkYSgJRBGKoOVa6jSEbxb8SVG",
+	"07tZuRuaFUpgjiNa0uBkzbVcrZr01luuwQXYKWqUl9wYvCFaKcDfWkj8Nn3gNf7BS5b3KgYQqYkXoiME",
+	"YHEZZYbmvGK+epdVGB0WLuWOR62UU831+gCgdHBy3K6Nc3zvNB2PnCdsIOdYMSxYEwRErMlZk74qDcs5",
+	"iqYY3w56/U+EQHSaBbcc0ji8rQBfbqUVqRlZNzJ2LdZNGlKGRoqMLVURQvNIyQWm1Nx2iNwGPqff4WZz",
+	"OU45aXS4Tu+XA5ENFRSMk4qtJHXVVFq6aNb25uIF5YV1Yvf9fPQi7tXhLTzSwpVnolUMp+d2RkX3zsNn",
+	"PgIOI5pCUTdPmAGyKKhNaxlyjE2bZDW/PacUHji5Gaazo32kCdzRmMIOyx+4+s+nnJ4PUEqzONKpo6GO",
+	"AxouuddP4e1Y2XXl6LjjRKSzOnEcMydRkyvVbUddM1aWJSqIxOvAYWV5ZRmoLi2udZI12Sgnu9SyPyRZ",
+	"pg31izpVvipEYopWmHKQvLDYbTtG/e7EF/nthE27mFi8xOG3KESd0LAUgGSKjsEqPzHljotyjVdnIlzW",
+	"IathC3XLlnW+aM1I5TxuBGVzPsNZKprER83HkblAvyGq3EkVHRqmx+2A742AnaPjCAF2pyM52+jWmFqC",
+	"i2P7iNt15UN5XRkRx47gJW+QbVz1nW00dZbvmKZUEa2jY9wmjgdTApeAUFZRtL93W22B4HSyc9tpxfBx",
+	"KUVlD7wtjOweCd0wqjV1byLun0wmB+L4wfTg5Kg4OeDfHZ0enJycnt67d4LlrgYpkyErrCssIypuDf1u",
+	"RNDCYWSIu8YzMBkhNQrILqgbLk9X+PHKhXBywwwwHp8lkMCzwVfK/rqxVV4xHjP2fBYdMtCd1TK3ht7/",
+	"paJ4FKvaZvkM8GZZGwsSC68gB06VtXWWfWKbVCLml8ouxDJzlWGCgyzCY2/g//vzJ09fvwOvAZUDXFi7",
+	"yn6p4D8mY2ZmVzCIWU7hzs6YAR4AcFxOHx5iiBEG8TSpqBni8lT8UlEgvudKsrVh3BbJvshRAfD+ztWC",
+	"Fwbr3eAuMMaayuB1+J1llRCFyTDhXs6C0EK3j68M8Eu1FIXkMJcoZ8iEIi0pllUowBpz9UM1DxKuf6kA",
+	"83B5BSb8M85KlfPSI81jLVDY5CWxuQ0F4JeKRnISTqUAnAgqJ5swDjKoZljTxJhbpYvxLxUw5YUykdCE",
+	"xw9vtJnErZguQHSFUVeqlPl6/Eu1b9JwNnLDoF901xX4U/QuyeAmGuLpjUiWbyWzIl5HzAjnqPCrnynd",
+	"oUBiCy5mgAf2TK4FxGQgJgdCdIvQ2YF4rUrnkODtOtMrmV+bSMj/D6aF1VIYVijQoHBZ0jI+55JKyqO5",
+	"IAD5jZaUqY//Mh2Nyi0iio+Hv52r9O1A48lPERBHH7Y64APEf2jq1/tiv7w8b1ktEhX4kpoUBmq4mvPh",
+	"oML15+ZrmAGv2FltF0rL30iGoU/xXuE+qkOzqVhI4OwV47VdsDm3Ag0KP/iJgGxpRVPR4ltEu82Ej6ko",
+	"yMHVeiUyUOHuIEs0AljrC3lNX1swGWVk2210B1JmREGECL+LKtfrlWumoHG+FrOCm9qIXAtrQJsyaZRo",
+	"ocHvoxZAIM1ScC00y48f2H9cHv32j59erf/x339zanN8SkenCVuUmwb7EgzEHXz3HFlBNEKPyS2cPGiV",
+	"pycHlFBcEAjp5iISk1UgVozPmapi3a42RBucTI9tOZVHx//vT++P/vG3//zPWEQAjr6F+7zRcssK31w8",
+	"hwXh7KEMkWpb053n0cQeNZ8jiLp1lYvSVU4T71dSt1NvR3j7PTw8dL+Mc7U8dItriTpabtlGgvk9qzWG",
+	"sHuSaArfepKa+ewtIzA5HXFQ5sLgXedU8LKc8vx6zMiYZLmeC9vaasOn5AyoIiN7XilRVmeyKsRKVIWr",
+	"Dm4USg3Aokjkd/QaLErASOsVTo5qLFBAjJHk4Y+uA0wiXzf8m5Y42PgdMJgW0rYt3d9hEXfyZyOf9dl2",
+	"L3ui1LwH1106Kh374sRkV87HuRWbqvxp9p+NAuolq/y8CJp+HwffaXFK+sophaYA7nWIEYyMbArMWBUq",
+	"4HnigcMU+UKRkuagENq+xGfNVuRYBHz4L7E2QTY9Ojg9gbwHABagS5s1Ojsa2PJGKI0cHB3fPUmwwrvH",
+	"iaN7IatryELDLIpNwzymQCWtti3hEXDai/8uIwMVs5UWRlQ2lTzRJzP5T/pLrfdOSfkHTf2fJvDf2ZWc",
+	"yOnyugZmOznY7Mx22j/vJZn95PeforPGcb3pQYmKUW6GxUban2HtQrrD2lKE0VMOKJeS4zPxzKBcPfT7",
+	"bZZoQ9xfuWQjtVxxK6eylHb9H03uUc61lsI0By0rukR8tM5S6baN42esL9f+n/G9WHwckiaU3rbpzRwy",
+	"7SIh+2ZbbkmzHGr9b0WQw3dxdZitc4cX4Svnltv6Qas6xYds5BIRU44qX3jEn75/FUGExP0xWakpODUu",
+	"n/5bgJLHN6JkwVCtvOBaUqxY10kVuwPwF+YaUUTsOXIJPcSuXjNZUJnhyfjB3Ww0F5XGNVeSMDhd1wf9",
+	"jzsTv9H+jxTOq44zoJ3I2lO7Ob4MUsHDyIDD+Tm2GwVyajEjHScjR7O2Lg3IrEpp/dnCM2fvhafdLFV0",
+	"x1F6LFz06Lou+bqZhThfuXbqaOUN8Eu4ADANhhxzg7CnfQEmcAjtHf8lq2JQWBG+CIzdeS72w7vHXY/J",
+	"j5evX+10m7hMI0lFXJfcZg2vxOKOxPh97Ja7YZwIH3w5Gx0gnm0WgyJbulcqTZRIj/g5HiUw1xu/dwZX",
+	"ufceK4oWxW/rKWZf9l1sl+75p9xtl/EcKQQw9XyOMDkHwNmeslAgiyBkLQsfBFMwRfOs2yG/7m1du346",
+	"rYKG9BYqy5Wid4ByKlUJXwm4dcONjib3Jyv2w98yNMYup8CbxIpB+4cfnvTEe2G+7JN9M+w38urd7510",
+	"/izoiMlc9MiQjKXruMuJZ2ahbk1jx6eKkq4si7K87Kw3603dd3aO8Uem8KcmS0XXWF5uTO9spLFU8w+h",
+	"Vaq+Wmt53x1Phi7vplMOZhuKJwrI0AhmSCLFlVLl3/27HzqVznsyQBP0mIE7xsfjWkuebxfJY4ItCh1g",
+	"jOvBgVF/b1aTIl7v7074ETfc8664tYiiPZT2aX/eOe/1eqffdeI/tFo5xwlggxvl4WaWw9Hku7vfnRzd",
+	"Pz6ZTMgkXwixatyZmPfwCU2xmkurB5H7pfZexcPfgW0wwq+A9oTyvjx4k/lx1uSGkAu7Uq1SZJAd0blk",
+	"uG50N0kyAuG9cBEOJgt3UMh3pmsIHyPvaASmuFAwzjzKSF0aZSN8/52fOmlFiINTN9SuneVJWu5Qip0N",
+	"AbOpqtTjk+NB5I9DbdfM6ZVWXvHMRwNv10T9l93dpVDjvNZz0RvYt4py811lGPQCbvSj1cInOqO/D7vS",
+	"0Md4miuYxZm/0D2HiIbOAeOln30di02uesZc7Xs/pZyFvwxDQc2FYBfccl+0ZSpoWUXSMaTK4gDNMeZw",
+	"j3qyWyCcTvOjFezq2Nl0+S2EK1HV2DfxdzVzeS3YGYNa76AxK5I4XSuVRK1/XISzM+5ai7d2OQPqurWu",
+	"5PCOjV4M606K0hHFTftNw8KB4DBYnk1FzmsjGk9G08q0MTfuyOKOwJ7afnLNqbP9W9OxwJFH+CtLdjHg",
+	"VdgXBgPBFRvFs+ULBWhKR4nBOob2CfE/tQtMw+gTUJt8r0vcOIlYYJZBGza69vEFF9N3jWYZeKcQJYfe",
+	"zOyftcyvmapQfv0xRCo5QDGODTU55WQ7iONPuDAXJEMVROer2kl8APEKzLrOt4xeo46sWwixOvB3ZTtw",
+	"8PQk64ZbTg4evP33v/z87uBt+Ndf/y0ZcnlB2RKP+XLF5TxVbWv/iuiiwt1ubaXisjQM8y/DAcy4/lxN",
+	"ouP0ERc/L2aUhCbSZdb26LETwizRXI1XvG+sg45jt7li36IfmOzWm9BHV0GgdbeEBQe2LKowaVzazQdT",
+	"5u54AenN8CS6gdXeV1sq0tDv7eqcFJsSAWlzRKFfYgZh4kCoTU4Xe1ZCu6TD3ZwMd7HRL7WZM8LgOEsk",
+	"xcw65JPOlfDQH94TuTPsTtm3mWHAInsll48gMxJ+HSeu1G0IYPcxqpRdj5jqla8okDf0xcJ2rmtyDvoX",
+	"HTd0WN3iht+Nj8ZHB0efTMiAk/VqrnkhGJ/NRG5NFKHUssRQyBW6QlFeaDUqopB5y0rBjY2abC379/Bz",
+	"19KzVwOtbST3mpbnY6GaVkcx9aEcKX1CLmXq0HgbJaBQzTnsFew2qNXd6qeTbCvpWuWpt028ITDzaDLZ",
+	"FaTYLS3dRuAttNBLAx8R9FiJ23Tg4+mMn9x7IPiBEPf5wd2cnxzcf3AiDorpd9+Jo9PJ3Xv3xMeV/0jv",
+	"LIo8Dqcwylf1hlwVXm3JVm6WrYKNFg77a6yQexvnFMALvul0rIDSCuarOqlrNl1HEqrm0NYqU2FvhQvf",
+	"cBifaqDyFDsV+0bNceOUz9okBSTG7YmujTzr/eY+9XV4c0Cr9p2j5HaPGbq0pbG3pFUDGmdtAClVw7VK",
+	"1RbjBZCXdNICBhpICuX0drV4ExnTYqlu6Atpu696mIYgaZrVv027aMftuDeSF0vyVK+adVaJgwwV3l2m",
+	"Mu7PM/BU/b68ZVRtAA3DhyvMeT+wC73PZjdU6tzXPvpNaNXaWeQt//no7dj32B2EVhubbB9Ce4vuSDb1",
+	"R9feh04+jTZWr32dsF4OvaUyjLv5rF6HCmrR/dYE23dqBLbe/egqgVuL9qVWFpcHjJYZjdOWpKZr9v3T",
+	"K3bIi6WsDmdNPbX96voNEB3aAERNqpEdWiaorYJDf+3ID7tPP237oTjbncYf6qooghC/vdpkB1HjOVJo",
+	"uuG1S1pAN3JdhifwuIyYqJKFL74eZe1gTl/LgbYOCbebqS8dQd/FoCXiC92TTqe74OyJs3FwmW1L7uT0",
+	"dI++Dnt3lEgUkr97Oqzg7W1fbtVmJwkHxW7C0M4eCH5tnc1lDbiT2JTz6lyLGyluUwKQC+dKiT/uEclp",
+	"EvIgeFVtqaaVMiu4OvbamcPQ75OF+7Ho4XNop9heQNoPGbff6+myXldbLUVNAgxoMXVVuDwVnCXAJ913",
+	"NhXapm+EPuCGiqCwRl5auUMY1CzHhGCXjgC2YlZh3YaIgNy6HzZOG2pGQ13cCqFdJjE8hkN0FQ2ddpSx",
+	"mXI5PG2/j3Xvgd3Vh/JeS2gyKaxptNEFL2d+NbTsKH/Mtdmt1i7VaXAEQYO1EHCVjCEgc/CAbIIIrr2F",
+	"SBGMHQDBDNBBgWnBjaoe+to877C2xqyl3LTCEsABzyWqv0p7C3jGrFLvUJkLhWUoy1lQeF2oQkWJiUtZ",
+	"nc2Fo3LG5yrzuc2ukGQ701kab3QnWTTkNYeV5losRQW+ddzjzLUe8pGcvpARFHPglpcKA60BfwryBMUR",
+	"WDg18K3JJHXXIvyeVj3Ng3eA3WvtHfAOtLvFJBsYww7G+MyF5Hb8QOn+ktRUar+eWUMo/lquLhDP+vlo",
+	"BzejOAClfb85h1wtSLaPz5/eTpclCdS4234AqrlO1s7wTzxIsOIJboCi1xy2Ze11L6gNNhqc3X3jUwTh",
+	"n1QYDt6iV3Lg8gJi/8eMwXqMw1nX0nvlF4HCJMbHTjH8v+XAQ9kmcENMuqGVOkmN8ZkVOko/ALePcQXF",
+	"pCXLv5rNNgWhIZ6KZh1DXBU7blpcNoK6fdfiNYm/uOw+6yqpbN6d+97EOOWAaxjumDerXu8D4jK+dFCv",
+	"PIfS0R3n40c4JYojIwXJUYQKmmElHrkKp2kN80Z8hKSw5aC+wPXkIUCzRjeTb8cay3p78OuuJaY5RwJJ",
+	"5K9I8wGC9g7f3s4qY/7UPqpKbK7TjNON3eQtbBdkP5tAjHFZiVvi+1JNmfOmNkVQSFWL4nNwCoMMDU59",
+	"P0dfuqSLp1kalaz3NPmO6i7gZ0jS9H7yrz/eIbbHSFjZtRG8CGkfjchDvG2XVNO7sX3rmQDDvairrbyt",
+	"uf8AvV0Obij/6W+0wfgOwwG5DxOk3SVNebqNVLkltorPPadrfNC4Bbx3g9wK+EHnELlbKLMfEAoc4mWS",
+	"PVbi/U6QwTsEMmL0g4EzrL4MigORY+VTS7MMLuPRmtgX80CATYHfJkN2UqIu8ry2xLtqyhASG2jTUgz3",
+	"Xa5nz9bTPmdPV8N9zn68nc7mZuhty+q13267CRSiU0axcMbyquC6YDN5I6gSEoOPIeEWyAXNVJpx5kcC",
+	"XHe+2/9TcElFaeAeQ1ePrNibq8dxx9VonNim+fji9at3V//4T2o58puqBP7V6fk0YXfZv8H/73lNnbVK",
+	"bgQ7f7i7CAS7CnywAfU94nIeSPNj9gP2FOu506KSb+PtXuAvcaO2OBVp9txVrTNWKTJ/qzuGfHkvQUsA",
+	"A6gfOdjuOTMlNwvfg9kFWwGnq9asECu7cAWnsfmrXWx84KNTSo6FF10Zj3BCrUldLWBZbYyCfqhofz6a",
+	"MqfCA2azwB6dEDtj4xsp55XSjZoKS/faVoMq4P4xbOYywj2EM9zZSmhWyko4y1LIpsZ/NUPEqaO3fP0f",
+	"UFWyusYv6YTor3awwP8icsIldmMa/m28okD2p5DojOEClOUizOF+EQ49UpIzWrhgKAQQifqhvgqQwJB6",
+	"eK9DPbUNQcUdVY/9Zq+qcf1i0mV3vqa8nsvo3Kiwp8UWYxDe/i3ZKWu1n9YCUajpkusqz3TV8sboEynd",
+	"WAMMFW8HhA50ZthzwVf4wwX9R2tzjaWItFylSY0IhRSojoZTsLFwijd3BjHji9W7+3pCFzegGAtfUY3q",
+	"+l6Lcu2q11H541strRXVmF02mNn6DFRbr41xkKbnZVI7v21ENay/GyUNqrJw5fQ6+DzJXCytW7lxky7U",
+	"LeANDDVuN2yMg3SSSZYfIe/tVVXwK9X2+zQB8pur8NaNIexKq0nprlUOflO4YU6tzNUqrhHJFPXgcQHQ",
+	"ruqDL/UPGZ+hYAyonFmTFpGFKANy3yPq4xxntlWE0resgIccSyW70Z8Wx/fuHT2IHrjP/CrQCLxpG7wW",
+	"61Sx2OcbffdgYKDaa7FO+9t7gPWoXSnHQc6/PqDaTdjRzrF3wWD3bB1kIeA0m4sX04c3spr/l1jv6OrW",
+	"tdn79TYvxZKp21cKOB9zfCRyGle3xM+dFHdX9bSUudvPVthrfsvobYch+0E63niAepg8CetWdm+ypWoO",
+	"SVMJcC+Utigxe+D4MKEG5KaeajR4bSo9uxthC1eFWxo2Kzm2UkdVL/A/eNpjn9b5Lkt3amz6LsP+Qyiq",
+	"2yiXCfJuKxIWqWSSgxxdzGo2y5hdryTUS1pTpJDSAsLoCwkSTp0uqbIQHEDyHKKt9cesGfNBiiiX2o3I",
+	"pB8yLWwWIhHS8xx+DrhO1TBBjG+njW/vB1Pyal4nI11euCfM8rmfxB9iGHMkUo2De5sQX8HP/YMBTzDY",
+	"D/T4lF0qSHTdRUMEmixC/bjltkOtzYNLEVcrM7gvQr6pykUp7f5uskqVGCeneRVSxV2FnqZblw+O1wJZ",
+	"EVWJbnLoqVQ3ZrrDNz6qnlQ7V5/Qh9V7nbLpZq3D4E5RcDVqwzC3QqcihrBifu+GW0Fq9C7qDHV1Xanb",
+	"dhnJe+Oj8enB5N8LMT06rtMx/C5pc+B0+PKnzEewTXDyWpaFB037THunuzkan4wnO1HSTZlFCaoOxims",
+	"e1OF2msu5y5xjVorliu7NcQpJCD6l9mSt5to39vDqUkt/hA/CXsrXvqRWzDx+Y9xFVlv9nDlwe5N7iYx",
+	"Ad/caoUOewJ9kkrG1iumhpvqqbZc4jYE+d+nDwblKSqU+hyRQPigcmmjHnyYeuEC+ZKVAanyZ1QfcI/e",
+	"PKko73hpgTzaUNinvagDStaglUeD6FBSqBoXJ9jA0am0FzyV1fUImaKloAxpDRqwKFkclaENOrs/wf8b",
+	"FLrxiRLP4vj0ZBNsrj5RejtnrvsfvhI2hv+Kt9aKTL07fvDdsOjJhZDzRQJhf8DfYaaVfC/KVpwk1EpJ",
+	"webzSA3JoUtxI8pUbGchcoYPN+KbG2bY6PNJxR03+MyBasOoAA9dXaDWSa7rm5PjySrtklLpwn+0XP84",
+	"Hu0HOV8kbxNZpGL7f4Kfew7nwfHusOSUEENTBYxI0eNPnYLMHbpTxZoCflpFWUlSQGbtfd3Ctgp0oqW6",
+	"UrYxUVLVbjhOasaGRnFKFKCYHhcWF/i1ND6AZ1PeiEce7sJye30VfZxuf57uGtVwUOoVhYXJQ9TllEoQ",
+	"BbiQB7XaX6Nr723XmfnC2Imy9yVVgI05v1Vz0i2cS0FR4zrKq8QgJvfAG/2bQq9RWU7aPnkOSqNSh4/R",
+	"pogrvjQhZ/GyYdYag2GX/D2G3rW27cO4/Loprz988BOXNkRWYnAXPI4L8yKK4rbQmvarwmaSfmFNzQWs",
+	"tuSPrluA2nRgY1UDGboAux6SYcW8V92Kq5fJiETMh2yBBaZTlUDAduyFR5NJu6wTmvfiXMldxs42XFPJ",
+	"R9VcGLtxKm1IT0VUB5jKp7eX2VlltMi7AyySvbSgBXcyetecURmR1+Cr88kjibQGEjjdwgFbmwgUCvyo",
+	"sQ/SrC67yT+p0P9spFaielNZWfYIptFMaP7FkETyVpOTynFLQ/4kp6BFvukp7Rct9SuBjm4K5F6JPVoF",
+	"w9s7xGc/EYKB3m8llkjryp4v+B6VA0Cq3xLjFIFnpRXas/+Sl8qI4q8ZYh37Cyzlrxn5iaRlswh2UTUT",
+	"litVFmDFWWD1RWNgKIDUOxyglYeKE4wIKnBp+rdGb6Pz9k8/o5pgPl4BsFquTE90Xuv46OS2oLW/dlsy",
+	"Z7LtS3/r0XawlMsQc0l/ZE4YiCCdGzEoG4Q2WZKoPTB2NetsdT5IeShCVe528nMjhQLTa4q1e/TZ2pYh",
+	"rsudjagifKJCdzZ6fwDjHdxwjYEJMHC84MswSfzr42jC+PdnfvLWy9FC4t+f+kU1MGoJSkNlQ4IU5k5U",
+	"TXxygOSYsUf+uvW3LIgIKEShIxhbx7elhSyEb4S2C5v3KAUPpkpiCtt4OqNExaYoW0QI5dqZvDJQ+3xZ",
+	"O9dnzdFu6DrMLWuFLPYtqngWqk7sUcNBixupavOmHlRnpRv9H3+dddaRooutRpylMAYUVpmuVd847T1q",
+	"9yWZpRNzX3KoWSMOtOAFn5akZQfzNn7oY6R919c9e7o/bdpud00jHW/q5+je/sc13d70OMdbDeT4MHQi",
+	"LLKQapDoWgF3fXgTnSTGg4oHzyvWFiQMQJLwL1R0cr5D05hFM4Sh2lEeztpMy8SkKxjcrSWMrnnFlCtG",
+	"LZdk2kWmTOZ0YxWGTVFLgQKHUdRLHi8mZ7Ias8AKW7OEjsqB5zvLBndFSGA6o8ZYDThowRTG0upN1PQ0",
+	"sVloHUQb53E1219r1zPImfmpaRDK90EMogjF0FUD65HxMGhL0aaVtMyePhEGpErqWu/UJjfdHRM88Omc",
+	"/2EKcWCPCJPhCvA+hsyPNVg6EtkiEYRuIAmhILQsaYsFTV8sCMhEDedaUMF939wIDs66/vPY2KsFsUip",
+	"dk2KNq+QxVduu/Ql2wzZT+8OBCv9/J2B6lRLoH06ASVleBfqNv6YNj9dNNZyO/I2zZg2wXsjtJYFBWm1",
+	"tI/IxsbYm8oI60WdGbT4hC5A7UZ1d5re04gRm+gK36jZrL92tii5l7GidGVYxjpjaNUOoaQYKDULPY1s",
+	"Wz25GxsP7p+eDDJxnH2UG8wtdy5vYG3tts33olUc3/vUZqFXGMnpgrZBAkIYdFfUa0M5/QQbyhWqWdC1",
+	"yCRKDXijzoBCplErqNZCj9I+hpJbUeXrl/x9f+gcBfk1cHDftEwRlbJY+fCWRwtoxyIeHY/vDvKhuPHP",
+	"701614TiW/WpSxpc3tiv6MG93hU9uGcXbCV0LsD0JD51aXePBhZed9pT2tcVlwzZjh+T8YMH3w2b8Q8x",
+	"ttTVfkTQdjZvC6Hps3fEYIpnb4M8eS0gt35ccrnszbT5Gp2r6dYYFmmXw2oRJV0IRQhCiduHtc5Pi6Wy",
+	"4sBIKw6OBgZVPC+2QAxUyw1AuSY3j13Tn7TNslPovtU6JBmQ5UZ94er+9I+8WRlo8OCpVkyv0MusZhu9",
+	"e5hVoT7nRn9ZdyhmTB6UXFVWy2ltBTXZ9Q1ZVLtbfdNtyKUbwbtg2cKONjRr0IVCO0CsltnNf+G+UX8n",
+	"pSTqJhQnkHQqHvtCx+8O3v4+yU6PPySrHTdNAI9ONw0y/u7d1A/ogSvJT+gsDeFz5pwiruEdlm+CpeOn",
+	"yWiEgY2sfBOrsxAAShklrnfN9rBCCDr1NVrd0e3GqV/VNEnIT1oETBr0PmVyfhpee8kqV6MGlcvQRL5n",
+	"7Z+hR3vWlA7sT+78vPprfyfzxj2yWRRs9/F9SkPxnayVcKOhkWxYm8zAdF+T+aE3bG0n0fmuu2CgRzwM",
+	"Q4/2DlkbZO38EibOliFyzN68unxzfv764urpk3fPXl+8PLty5qO4UlGlLOPu1P6iNPW1zzpNk1xNXd50",
+	"Hvkr2R44DRBKgz97/uLpu6vXr9+9OLv4/mliutCvJeiDmJGG3VnGjL16ffXu2es3r57g8BuFEXHAeGFN",
+	"RBxHgSaKc716/vLp6zfxltERwyvw42HLZp8PA5fJ2dUP72DysxcvXv/09En0Fdr6JV5R6FpoLb6VHjsO",
+	"Tetfv7k6b00dPnC2/0LSqjGfE99oCkgThw7wLaTJuS66WbObh5vCqI+wJFsUonbmH1HnlSgHJzRhgR+U",
+	"djXU1gDxoWW/4sYwu9LEPWG/TdUzAe+elnZN8jzOSyfQ0176kkJxqIl2QnhxWdKU2MtWdVkeLIH+aFCs",
+	"yoczAcdEA1dzFqAijD58wMt1lih+enb+nPR0xyCqOVsKy7EtCsa+NgzVjEJQu2u1gvhydv58FHpPjR6O",
+	"jsaT8cS75/lKjh6O7uJPVJwToXE4vhVleYBxj9Rf5QCWd+CyVQ6uKfMkqSRdIKtsZz81GSiU4AIXLWQy",
+	"tFqepHvzoi0afOwuDtCsDeAKNsmgm4zizMmzTmYjkCNG3wsb5f1ko9ACGJZ8PJm48A3rHLWQpOxuu8Nf",
+	"XZ0tQrwh/hI3Cx7kphk7ztH6kI1OJiefbXK8UlLzkq8nTO1YuajgdqDyuKZeLrleE6hiz2NruR+ykav5",
+	"yue+SeXOc2+83JTsq8VcGivgrLvUsXFuUFHijKb6goeGM8BUadiF5XoS/pCN7k0mX/7YnlfOk+h4inAv",
+	"xscFy2Y6scbmrHJecb0+IB9T75GFiAo3mWxpZlYxrWqLOb4LSklqVZunAhCMpmIuVRv2yrUwET4hHri3",
+	"2kGAJbfCWPdakFYw2i1ypLsUFAozp25noMf5mccsrsXuLpiQUOLEvXVcNSBzgSpuTQ58WDCUh6LXFvt9",
+	"osBXCKAIlz26pDYCOWWh4wBxnxe3/4Lszkmu9Bi/uqCjAaar+VJQX+OfkyWR3Zjd6TDjQf4mCsYtBnc5",
+	"n5skyaUTwXd8whaq1lgrEpYlYfh/1hSTU2F4+gjBMsoiHB4UTvP2C9JpC1gJennsYUIvfENU+jh9agH5",
+	"/Q8oBVFFRi2wYVaLkHEUaVwQ7koZm6ymCPJ3083N3spcZEwBlkPtKG6ocWjTSJZkFXqc86qQcMBoIyGn",
+	"OBnHiIztrYqIHp3zzbJ80CsKv6DWgquZjDa3lecKVgUkdpI5Ehd2WG05fNzs/nPfKBLCepRduAVQfyvf",
+	"AsYXuYTvKiGdM6ddd2OTDh+j0/Rx2MaIxEdhLAQefT70DRN4O+mHtqRqdS0+bNDP0RdYQJJ6wlPv7Cf5",
+	"5KuQ0A0vZQhox3mPH/QNF+BDFuNndVl+k8QOpILYqWaIoNh7FRG0h6gPf5fFh0EyVZsKMTKuGQew39H7",
+	"FAgFjZyhF0m7mYNpkuY8Y2g0T2emCSzhjkGDLZb+h9kPYRxiV+nLLaaorVdbZNiLv8F7yRWXddcSWnra",
+	"JJO8o3oCNr7o9bSVvK7am/taon9E1ZWyVKHom6IW0Dd4CzINbcyiMPokTXyvVb0K9Wobipiuox4beBtA",
+	"W6+4oYXDN+kW5Q1xrp/JDdcSTWfURd/XcqOCXHi5oCnQhNpTjLmlcO0sGqJgJTjXjO0T/0B0j2KKh8h+",
+	"GLHq02eGd8NIyXdR842YgDZsrwNW4qoDpkXPnundN2fw8rclZbojuXQYmkBr9wbzSPwtkRMtG+ykXaJI",
+	"EdYhRbr0CpMQ6yOF8WW62iM2DSAhgMfxb/JEz2QJiIzXBcY00X3xO3DlDzSpuzcYO4ub1dGHFCxEPH6T",
+	"blrtWb6QrJZsADRIXJt8qTWgdTaBFdghDfumhzYzf6Dc9s0QwoWwmyiLNoFpXV7HxIAdhLcoVEIveUXt",
+	"FqhRMtryvb0yDJ214j+ivCJU6qyW87nQFPwc1auj6yQSAoPpgygp4u/tNtleNYMWV5H3IYhzvqB9xgow",
+	"/tSVc2e6CSlcMK6+QYUd2z33kg2amx62vquzW4t7HKmHcWgzN3FNzzZJY5dt9Cp8GXJu9Un/ymQcdxBP",
+	"4DI+biLf/6TdABNHYOTewKS7hhhi+nV9PA9azW23qlDeEI3fhZbEGVNlEeS1pLjW6V/7Ra3SqYa+Sft0",
+	"exffpHk6scQ0s70kGZa+8ClRbdNwcCRErNHbgjttfJvAk6b4aLsza0Y9Qzi5wivV8ENvNe6VYWhkL8W4",
+	"Y3CWY3KhUE1I5mtOUqJ5XFy027cUUB3UcOehzmAgtDGHvrYhMwTdp+Q6VbMIFOCgRi0FB+w2rG/3GYoj",
+	"n7wdXFp2K8vSA5JxNPM70PNqDZnnPQa0DsJ+Mcks2UL6K5vSuntNWqPp2bdgTft21BOABSZ0tVnCVn4e",
+	"7GIkfiUD/UksS/B0TNFy9fZanYN8gprz5WhPwNIyXmrBi3VDqBsSzyYN0Bo2aWCo3av54mtYvU4S5WU8",
+	"vhKUi69novITf5sGKjrYHThr6tVKaXswrauiFDsFEM7mv1HAqeWaue6kmA8i+bxSxsqcFIFpPXcStHno",
+	"ryRk6XDgeH+1qu+2/Cd0BVCgimFaFDzHJEzEaXTLS5woY9ybMmAJzp/pTQRZ/EPIWayKhNPZURL6bP2e",
+	"pPE1pH31mrDYNsxABF7yKm1JvqRXH+Gb+wldAOg2pjQZWhK8cQl62cATNz9zx/tNoae6rbB4MGems8oG",
+	"Pa1com2/FzGfvocvDSWctYtG8xy7DxS1JgcGjOWiZFHk8kwyc4UeXKIopuo6Fpu5pv+mBic1DIOfsKlY",
+	"8BupNFiBDHt8+feM5oZpJUYmMq1u6enrqxfnWJ+5/Q7HupzCWUO1UpaZFXedaylgnPrPlnLmrbucObae",
+	"L8Crj+9TwZ+QuGvonsLKQF5ait72lYAwlc3v0rdmK7z8h5By/F0ggMnchSLdEVaNa/RkeiGlHNPZXPkj",
+	"3HGl0A3balPb5/2PMuNg1e1veuy2/OMMthtm5KdVsXWRVf8SaNmfYQ1Uta19Qj1zhhJuzZyhyPAoNzdx",
+	"cRf8l7LlavR2yFW8Hx9J5+WSQNCJFqwammEOh4BUoO+WzIM1JBUTacV7ewj7+FS++aOassB7/pR/PS37",
+	"CKYAmYZT+5SuQ1dOZ5sxg1g2So9S57W0TQ0e18PAjeZLiAVF193lXuUzvTV6Mqr55BfjXFmMYbSYCyDs",
+	"pO2AOBHXAvJ103iUtBJV1fFriQ2mxIhp0ZlrTh4/hdnclrAk0+0CQ8ZtqFEVdxupDSXSOncfJSGbMXvt",
+	"9x4KN2HRJmfHjapehWpXuJJSWLqZPIiYXWhoXkHBMCgiYoo+wypPxofH4wMCsShcaIsKjV8coLDZCBqA",
+	"IWgHRoQo6jFjj90a6a74VVoSvIwKan0EoUJhTFxZ+mpfwOSqlNIC5pl2WbNPNmntUx7RzZkI4N5UEtoY",
+	"br4xXwNSdYISTZSm6VDWJMndP9tF7QHrclVXznvgcm6bHO72hBkzzhKF1AtyeDd9t8nafeHG0nUVxYZ0",
+	"qrqgYa5FhjyH+PBSFHNvtGuqVfghpetFBW8Slkc4eyFdZTfUAtfCRh17fRsYDL/ESxebwivU+Au+dgwG",
+	"/Sy0wK2YfuVAvZfLPVpqkPeGhFoip+DrLxBpmW3m/WBmfpwbSDuFdVDSUrqSY2pRmGXTWtQeFR4/vP2K",
+	"TCSuJTCAkZwLfeCQtlGB/5RNGi7W8WCuIzABy3BIlWRinbT1JCMDQjRpNym35MeOstmzVow18q+MucDO",
+	"qPjiOi7UFEppqNY7UkNSvsteBFFXkN2eYlvhWr0WK8tq4IhtpicNQx+xbzC+hplKbMnXiD13vJFcCzhH",
+	"qSqAllRFmhdt1tEfxpBKaWwMNe8tbsz8IfwHckJ7qNtXKhpsQcwGLcYqxwfdvUMH6dpsR8USelbVFF8M",
+	"6/qo+glDWGOIaf4fyRo3sWsIa4y+8gT6J2cMXsw6CR3gggvBS7v4rZflXTqhH61rwme1Nnk2GGloFVtw",
+	"NIQ6CJikJfQHnOtL+p1phksqYtaXxNasHUCyAa4bUQnjwvIJRj6jcatZvIkcCaFl5OqNo8oy30KQpFlX",
+	"qdgobZnBaGuvVLn+o5Beza0zXKJ71Bjs5HjO54Jqo7msIg9SCvX0D61iM+FTb6mzISwMXoCelcJ6/zS8",
+	"KF1/nfjWQrnZi+aR09oXK0zeDy4YZveFQNyrlUwljUu66xM4/cNhCAFLCeiQ7VwEZajyHCKGcC3Nlrf2",
+	"8h0vr296Ftxk9u8fqtqCzx8YMrt1HUC4XFJwv0GRAkwErQRpIoFpbSnmGA3SHJDcByf/MkKdEx5MecHM",
+	"5FhM7v0yGjcBlndZvuCa51ZoRyZkITErngsfreWiLsHksJxK35q4QXEjwLWzGYLhOxjHWbztNPTE8noA",
+	"/c/u7fpCVHO7gKJnp9l+8O4SY2AsPVSZhejtaWt7sUUp5j/o85KhAKeJaphGRx1nBToIkdrYqL2Nutt0",
+	"kjSKSYtMxYQhIEbJrcO9R2IYtOEyPX1pk1IMnmgKkUMhjwGY/PVDv4evKbg3Bi3n0efyKmzhyljcNxKL",
+	"r8X64Q0va7hIXnaalljliZEZIDZe0ueGiGpVYu0PMvynz3cqylGWkhx31oM2dl16r8doMD9rbEDo/quU",
+	"JTf0ght3EcBW+/xK0cd7IiVWmgZ4EaN0CSAPPR684zZjrlA7/l14/7gLqB03v0iqWxmX57ljPPNzeiXT",
+	"nMLGYF/Ybtk7CA2QnHZZi4b9JrRCFkMwcnZaqnzlo2wRN8Q/a14SdNoJJBAf5tKy16Qauz0Be+Zg5J2i",
+	"3xz4dBEz6l5TE3m4GugGp1WAVVPVnv7hYeNKQqR8Whsn8gQ79wJA26ciu1oWfNbcYI6vKo0HIIw98JD1",
+	"iv/7XKxchXXjSr9P1+4Qm3Yo8KgPAriaJAi4yUck647efpRK6dPgiTIGK4/Bj7hvH5XNNbWkWV+WvRFr",
+	"MfO2srKqBd4ZCFmtluSuwEbiU0FFOdq9eVC4RocFQG/cKx45kXmrdPQlU3dcxfa+UNkzUgbULE6K+VO9",
+	"JfWWZJ0WYNIhuhTsaVxkaPRZVLcqGKI4y0spKnuw0gpeLdAqhVxRFmK5Uuga6Ako/YIJATD0HxQ66pA0",
+	"fVgE/SDCxM0iqC2sr8/93weY/HzwRKzsom9K9/5h++UPH/5ApD+ZPPjy855VffbQJrTzvTTW/Itnmvto",
+	"2q2E2NhfDqe+9d4Oyo7AF1QkkB6pkx7400vsh1wZmgYvELH0giACmupUhU4Y0gTQW34tqsxpTO4K5xUT",
+	"XJdS6DBRuH2m1Jelm0yUq2pWytz5QF0VDZeyFKSk59gXwI1pmCSEzFgVZSj5t7dwIepZ+OVYEY7/B+Un",
+	"RfP35Si5kokhrATACQfoCZt5qep/AoP6V2cHS0D4Xm5gfDBIzBbWB55UD2Rx+HvT3WZYNQqXIRhV+mqq",
+	"eyaWEG63IGSSQgzN7YU+gCjWUooiZh8pS3hjlny0fhpWvMtmimUY+idK1FBNhOOLeLr+sPw/QPTdKlUY",
+	"Z8T9SnH9Yd5vt/BEW+j1CAzVIyLcawgF6p0Mooi4wMpU2FvhaqtFRdDsrYpshVEWsdeG4/cp/RELozqr",
+	"FoYmuyhk59Ne1u8pm0Xz/No0AWao63Uqkq2UwTBSVJ/d34mcFjmbDXFEJNuAB+r2Fzttpi/AVqvlVmLa",
+	"20O9fVHUDnjrkqz6ZkvN0FUNx9PnoduBgX8wE/jqWkDI7iQR51usFrXJHjqlO+CfhwtprNLrgZU3I19M",
+	"kze7yXU20qtbYTflugl5c4a4pGP1InJytENp9guYyWCd1Q4HiSSTYr/z9AcHqAHyQOzFarlrrELe+Tnc",
+	"lsOlhEHBK2F9/3LGxn9942Hi1v7TjOjNiBt8x/TUboiS6T9SyeD9KkbEjVoHhgqQsRCPe8NliYE3rfaE",
+	"lBmGJfA8U4pLrPxKlVHE0ojyhlIFMPSfGFLj8zKsUKxSNvtYHjjervEM4Wo9LSES6owTYL5NUedPxeaz",
+	"KTYbhHcYYazrZJMvkl2NMa9Gc6vaWI7FJqP2tl534cxqCeyyUtTElFexe3gJhEwFhkLvbmkoMWDMGPqX",
+	"42Ji2CYQ9RfqLbxJHWe0KDFEW/kjiOPzmw3PmmN4g/7ar203jBbQp4SQ+zxGmIbZ1m7Rf6Qr4k9e4emm",
+	"fZm2vQaOV1Dr5C3eA3yOOXACWyHHlWainuxn4UcXW++bKHPDjFIYGBO1WK6UlbmIakE39ZxDuxNX2MPf",
+	"86a/UA2u8VtlEl//BnVE6siSTrjEOf5vMBD43S9445zymu63ZSPAgxlAo0OtA20Pn2tMNF2npPembQ+Q",
+	"JTP1SmgjCmFaNoIoGFIwtwomqsL4Wgj4vOnb0AzDKlVRX/VY98YzcQJz8WkS8x6GgP9ZBO83vo3uG88p",
+	"HnbrgP+8I5MdcTx2dzXQJEG6mkEDfO6tdiohrYvGx6mCDa4Rt9tRWmrluib45DrKDYaY0YyozlW4Ck3W",
+	"0M4mZ4y3a5JSkLQwVOAEcAXWBsuKO0HExALPOsyhRc2gcptY5zZYWdt/7oa/YzxwQUyoWn45p4Or+H0M",
+	"kC6Vuq5XXadNHP7tLQdNTHq3njDC5VOkAlhY2Py/lh7hdv9nMNT/XRrIVxewcPZQD8pT8Zs37dggWbHa",
+	"CFeV0yuQMV3DUMALMOCbV+TgRNb7Lx26gV17wn2CdUCywFGw7lbV9aMm7prttdzryjh7C2UyO3E/9gPh",
+	"xK5/crhX4Iy6d83mFeNuiuAIwF0ySX6b/jruf+piaV1MRm2S669YjPFb4haIqd9gUflARCnpD/nYwFzg",
+	"OA845xXTGF0HP/pKwFkkonNqCiZzDjKbqkS7Rx+9eiH9YOhgc4WUpqVIpxNfCF7IShjz7WQUuyBRRT+F",
+	"bGjCg7tfBw2b1QAi4oo2MMEBLk5yNjmvDpADituB3ZxuF05BLjQW3SFZHwYKlRSna1d/GpZe1KUw/0+h",
+	"1xd19Z/A2YhIMV8zFAQKlf61YHxqRBWXtfATYR0wXwM3WXQz59U5bWZ4HVtc+Sp89Qm1bPt6uX9R/hzv",
+	"uQ834h1+LbZ8GU36TTdyakOHiMJh7cCy/P79AeX4L8PQXxQlaJK+wIBmEd+cM97ES9tlf/AvO1MBGtUk",
+	"hMpiv8qco/RYYGafgsKAru6rE07bCQNRcjgaGhBbnVyJRoVnEvgT2QiCyBj8AbIsd5Srh8oLtCQtYka5",
+	"vZw+lRHziW6+7AberBS16TbnvRRsKQ1mw2F9wUq54Ukyc4uShi15IahcEbZCadwY3H+AKwQR+SdcF3Jv",
+	"TEIIQJchb/k/os9yXpn20twksD9V2/YhrC1ktpMcgE36Nu8jX0y6uXfgHddyFBsFuhELqrrLW9dFpXzj",
+	"GGdQUjM6+1yjT5eXzYZaE0nrSkfG07mZ+vIuPF3tunscu6aJeGICX2gDVosw9iXqTDNDKiSJjikdPoWp",
+	"9dlmcvIXMtJ4WPxBVppwFFvYn0dfYDXHk+OvdSM/cej5Z5OFyF2EJxGx9M5FvEc/Bf9N4LdRdwQH8kZF",
+	"iZqBQ7xTUxh8cPuEoTQfy5ttGv76fRMCAXztvglh4m+8b0IbC+lqO+TY1f/wd/qnyzRa1UnpkPoMYGRB",
+	"XZYHS1V4dyAaCrWYaWEWlLiIeZ3A4Kk9gXZdEoL1aklakrRe4S5CYEHOVzyXFm7/n5wYAeKPKwwUy0N4",
+	"tS8E13YquMWIpVxEDRGy5gr3RU8plCld/aeUwjjhSFWUn26NW2nKbEbTnAEEdxJKISorZ9LVA100gOPG",
+	"ZfovRMXyksulC8kwaVLyB7V/xtMXiH2CrV9EB/zVY58Q9gnCIMSJUOGPDXA6+vLzvpTGODndZdd61LcY",
+	"zv0NsCSR11raNZIHrY0izR/+/PbD25hledKKuEqC6bT4GFJOv9H9jWnrJU08FA7LYFivl6D4Xamu2gIF",
+	"vNTScR6cjlQCpx03TtUN5zF8ApPR5KjU4PcUCE0SvDNxNSHSwCld9nUpYBXE+dhU5GopDI2A86GzICG9",
+	"wwtEBz+ijf5LcAAaH6f6g9Kmmx0mkwWo45rxACepOCE9vAolZsNB/sky/oVYBqKgc2e+tyH0se0TcLwC",
+	"LtfD339V0+cQTuko7tN4B7OKrWqzYFOeX8dRKmhGppANW+uKRspbpOk8dr6slUsvQusIBoAQD4FPEkTu",
+	"Vh/T+U4fXtTtrGFDaWkDgfQ57MRfivX8qKauKsIwxpMgffd9aDn5J91/ZUejv/tCg2OPlp3CBI5C/sVE",
+	"mdCSQjXVO3jYYsSgcFx9k6bbFyrnJSvEjSjVCvM26N1RNqp16epyPzw8LOG9hTL24f3J/cnow9sP//8A",
+	"/7RK6TRuAQA=",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/krelinga/video-info/internal"
+	"github.com/riverqueue/river"
+)
+
+// priorityAgingInterval is how often the periodic priority aging job runs.
+const priorityAgingInterval = time.Minute
+
+// PriorityAgingWorker raises the priority of info jobs that have waited long
+// enough.  It does nothing if Step is not positive, which lets workers
+// without priority aging configured still work jobs enqueued by a leader
+// that has it.
+type PriorityAgingWorker struct {
+	river.WorkerDefaults[internal.PriorityAgingArgs]
+	DBPool *pgxpool.Pool
+	Step   time.Duration
+}
+
+// Work ages the priorities of waiting info jobs.
+func (w *PriorityAgingWorker) Work(ctx context.Context, job *river.Job[internal.PriorityAgingArgs]) error {
+	promoted, err := internal.AgeInfoJobPriorities(ctx, w.DBPool, w.Step)
+	if err != nil {
+		return err
+	}
+	if promoted > 0 {
+		log.Printf("Raised the priority of %d waiting info jobs", promoted)
+	}
+	return nil
+}
+
+// priorityAgingPeriodicJob enqueues the priority aging job every
+// priorityAgingInterval while this worker is River's leader.
+func priorityAgingPeriodicJob() *river.PeriodicJob {
+	return river.NewPeriodicJob(
+		river.PeriodicInterval(priorityAgingInterval),
+		func() (river.JobArgs, *river.InsertOpts) {
+			return internal.PriorityAgingArgs{}, nil
+		},
+		&river.PeriodicJobOpts{RunOnStart: true},
+	)
+}
@@ -0,0 +1,109 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/krelinga/video-info/internal"
+)
+
+// runAnalyses runs the optional analyses requested for videoPath, adding to
+// result.  Analyses that are skipped or fail are reported as warnings rather
+// than failing the job.  The analyses that read the file are independent, so
+// they run side by side, as do the analyzer plugins after them, which are
+// given what the others found.
+func (p *Prober) runAnalyses(ctx context.Context, videoPath string, info os.FileInfo, result *internal.InfoJobResult, analyses internal.Analyses, timer *phaseTimer) {
+	var tasks []analysisTask
+	// Checksums only read the file, so the deep analysis limit doesn't apply
+	if analyses.Checksum != "" {
+		if info.IsDir() {
+			result.Warnings = append(result.Warnings, "checksum skipped: not supported for image sequences")
+		} else {
+			tasks = append(tasks, func(warnings *[]string) {
+				err := timer.time(internal.PhaseChecksum, func() error {
+					var err error
+					result.Checksum, err = internal.ComputeChecksum(ctx, videoPath, analyses.Checksum)
+					return err
+				})
+				if err != nil {
+					*warnings = append(*warnings, internal.Redact(fmt.Sprintf("checksum failed: %v", err)))
+				}
+			})
+		}
+	}
+	tasks = append(tasks, p.deepAnalysisTasks(ctx, videoPath, info, result, analyses, timer)...)
+	p.runTasks(result, tasks)
+
+	tasks = nil
+	outputs := make([]any, len(analyses.Analyzers))
+	succeeded := make([]bool, len(analyses.Analyzers))
+	for i, name := range analyses.Analyzers {
+		plugin, ok := p.Analyzers.Get(name)
+		if !ok {
+			result.Warnings = append(result.Warnings, fmt.Sprintf("analyzer %s skipped: not configured on this worker", name))
+			continue
+		}
+		tasks = append(tasks, func(warnings *[]string) {
+			err := timer.time(internal.PhaseAnalyzer+":"+name, func() error {
+				var err error
+				outputs[i], err = p.runAnalyzer(ctx, plugin, videoPath, result)
+				return err
+			})
+			if err != nil {
+				*warnings = append(*warnings, internal.Redact(fmt.Sprintf("analyzer %s failed: %v", name, err)))
+				return
+			}
+			succeeded[i] = true
+		})
+	}
+	p.runTasks(result, tasks)
+	for i, name := range analyses.Analyzers {
+		if !succeeded[i] {
+			continue
+		}
+		if result.Extensions == nil {
+			result.Extensions = make(map[string]any)
+		}
+		result.Extensions[name] = outputs[i]
+	}
+}
+
+// analysisTask runs one or more analyses, adding what they find to the
+// result.  Tasks run concurrently, so each only sets the fields of the
+// result belonging to its analyses, and adds its warnings to warnings.
+type analysisTask func(warnings *[]string)
+
+// runTasks runs tasks, up to AnalysisParallelism at a time, then adds their
+// warnings to result in the order of tasks.
+func (p *Prober) runTasks(result *internal.InfoJobResult, tasks []analysisTask) {
+	warnings := make([][]string, len(tasks))
+	limit := make(chan struct{}, max(p.AnalysisParallelism, 1))
+	var wg sync.WaitGroup
+	for i, task := range tasks {
+		limit <- struct{}{}
+		wg.Go(func() {
+			defer func() { <-limit }()
+			task(&warnings[i])
+		})
+	}
+	wg.Wait()
+	for _, w := range warnings {
+		result.Warnings = append(result.Warnings, w...)
+	}
+}
+
+// deepAnalysisSkipReason explains why analyses that decode the file's
+// streams can't run on it, or returns "" if they can.
+func (p *Prober) deepAnalysisSkipReason(info os.FileInfo, hasStreams bool) string {
+	switch {
+	case info.IsDir():
+		return "not supported for image sequences"
+	case !hasStreams:
+		return "file has no streams to analyze"
+	case p.DeepAnalysisMaxSize > 0 && info.Size() > p.DeepAnalysisMaxSize:
+		return fmt.Sprintf("file is over the %d byte deep analysis limit", p.DeepAnalysisMaxSize)
+	}
+	return ""
+}
@@ -0,0 +1,144 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/krelinga/video-info/internal"
+	"github.com/krelinga/video-info/virest"
+)
+
+// maxAnalyzerOutput bounds the JSON an analyzer plugin may contribute to a
+// result.
+const maxAnalyzerOutput = 1 << 20
+
+// maxMakeMKVOutput bounds the robot output read from makemkvcon, which
+// includes its progress messages.
+const maxMakeMKVOutput = 16 << 20
+
+// analyzerFrameWidth bounds the width of frames sampled for analyzers.
+const analyzerFrameWidth = 640
+
+// AnalyzerRequest is the JSON written to an analyzer plugin's standard
+// input.  Result holds what is known of the file so far, including the
+// output of analyses that ran before the plugin.
+type AnalyzerRequest struct {
+	VideoPath string            `json:"videoPath"`
+	Result    *virest.MediaInfo `json:"result"`
+
+	// Frames are the video frames sampled for the plugin, if it asked for
+	// any and the file has video.
+	Frames []AnalyzerFrame `json:"frames,omitempty"`
+}
+
+// AnalyzerFrame is a PNG image of one video frame.
+type AnalyzerFrame struct {
+	// Path is where the plugin can read the frame: a host path for
+	// executables, or a path under wasmFramesDir for WASI modules.
+	Path string `json:"path"`
+
+	// Seconds is the position of the frame in the video.
+	Seconds float64 `json:"seconds"`
+}
+
+// runAnalyzer runs plugin against videoPath and returns the JSON value it
+// wrote.
+func (p *Prober) runAnalyzer(ctx context.Context, plugin internal.AnalyzerPlugin, videoPath string, result *internal.InfoJobResult) (any, error) {
+	if plugin.MakeMKV != "" {
+		return runMakeMKV(ctx, plugin, videoPath, result)
+	}
+	request := AnalyzerRequest{VideoPath: videoPath, Result: result.RESTMediaInfo()}
+
+	var framesDir string
+	if plugin.Frames > 0 && len(result.VideoStreams) > 0 {
+		var err error
+		framesDir, err = os.MkdirTemp("", "video-info-frames-")
+		if err != nil {
+			return nil, fmt.Errorf("failed to create frames directory: %w", err)
+		}
+		defer os.RemoveAll(framesDir)
+		if request.Frames, err = p.sampleFrames(ctx, videoPath, result, plugin.Frames, framesDir); err != nil {
+			return nil, err
+		}
+		if plugin.Wasm != "" {
+			for i := range request.Frames {
+				request.Frames[i].Path = wasmFramesDir + "/" + filepath.Base(request.Frames[i].Path)
+			}
+		}
+	}
+
+	input, err := json.Marshal(request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+	var stdout []byte
+	if plugin.Wasm != "" {
+		if p.wasm == nil {
+			return nil, fmt.Errorf("analyzer %s is not loaded", plugin.Name)
+		}
+		stdout, err = p.wasm.run(ctx, plugin, input, framesDir, maxAnalyzerOutput)
+	} else {
+		stdout, err = runExternal(ctx, plugin.Path, plugin.Timeout(), input, maxAnalyzerOutput)
+	}
+	if err != nil {
+		return nil, err
+	}
+	var output any
+	if err := json.Unmarshal(stdout, &output); err != nil {
+		return nil, fmt.Errorf("output is not JSON: %w", err)
+	}
+	return output, nil
+}
+
+// runMakeMKV runs `makemkvcon info` as plugin against videoPath and
+// returns what it found, noting which of its titles is the probed file.
+func runMakeMKV(ctx context.Context, plugin internal.AnalyzerPlugin, videoPath string, result *internal.InfoJobResult) (*internal.MakeMKVInfo, error) {
+	source := "file:" + videoPath
+	if strings.EqualFold(filepath.Ext(videoPath), ".iso") {
+		source = "iso:" + videoPath
+	}
+	// Short files are titles too, so don't apply MakeMKV's minimum length
+	stdout, err := runExternal(ctx, plugin.MakeMKV, plugin.Timeout(), nil, maxMakeMKVOutput,
+		"-r", "--noscan", "--minlength=0", "info", source)
+	if err != nil {
+		return nil, err
+	}
+	info, err := internal.ParseMakeMKVInfo(string(stdout))
+	if err != nil {
+		return nil, err
+	}
+	info.MatchTitle(result.DurationSeconds)
+	return info, nil
+}
+
+// sampleFrames writes count evenly spaced frames of the first video stream of
+// videoPath to dir as PNG images, scaled down to analyzerFrameWidth.
+func (p *Prober) sampleFrames(ctx context.Context, videoPath string, result *internal.InfoJobResult, count int, dir string) ([]AnalyzerFrame, error) {
+	var frames []AnalyzerFrame
+	for i := range count {
+		seconds := result.DurationSeconds * (float64(i) + 0.5) / float64(count)
+		path := filepath.Join(dir, fmt.Sprintf("frame%03d.png", i))
+		cmd := exec.CommandContext(ctx, p.FFmpegPath, p.ffmpegArgs(
+			[]string{"-ss", formatSeconds(seconds)}, videoPath,
+			"-map", "0:V:0",
+			"-frames:v", "1",
+			"-vf", fmt.Sprintf("scale='min(%d,iw)':-2", analyzerFrameWidth),
+			"-y", path)...)
+		var stderr bytes.Buffer
+		cmd.Stderr = &stderr
+		if err := cmd.Run(); err != nil {
+			return nil, fmt.Errorf("failed to sample frame at %ss: %w: %s", formatSeconds(seconds), err, lastLine(stderr.String()))
+		}
+		// Seeking past the last frame writes nothing
+		if _, err := os.Stat(path); err == nil {
+			frames = append(frames, AnalyzerFrame{Path: path, Seconds: seconds})
+		}
+	}
+	return frames, nil
+}
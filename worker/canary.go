@@ -0,0 +1,224 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/krelinga/video-info/internal"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// canaryAlertTimeout bounds the delivery of each canary alert.
+const canaryAlertTimeout = 30 * time.Second
+
+// CanaryAlert is the JSON body posted to the canary webhook URI when the
+// canary starts failing and when it recovers.
+type CanaryAlert struct {
+	Worker         string    `json:"worker"`
+	Status         string    `json:"status"`
+	Error          *string   `json:"error,omitempty"`
+	LatencySeconds float64   `json:"latencySeconds"`
+	CheckedAt      time.Time `json:"checkedAt"`
+}
+
+// Canary alert statuses.
+const (
+	canaryFailing   = "failing"
+	canaryRecovered = "recovered"
+)
+
+// Canary periodically probes the bundled self-test sample and checks that
+// the worker's directories can be read, so a broken ffprobe or a dead mount
+// is noticed before jobs fail.
+type Canary struct {
+	Prober *Prober
+	Dirs   []string
+
+	// Interval is how often the canary runs, and MaxLatency, if positive,
+	// is how long its probe may take before the canary fails.
+	Interval   time.Duration
+	MaxLatency time.Duration
+
+	// WebhookURI, if set, is posted a CanaryAlert whenever the canary
+	// starts failing or recovers.
+	WebhookURI string
+	HTTPClient *http.Client
+	Signer     *internal.Signer
+
+	Worker  string
+	Metrics *CanaryMetrics
+	Clock   internal.Clock
+
+	failing bool
+}
+
+// startCanary starts the canary configured by cfg in the background until
+// ctx is done.  It does nothing if no canary interval is configured.
+func startCanary(ctx context.Context, cfg *internal.WorkerConfig, prober *Prober) error {
+	if cfg.CanaryInterval <= 0 {
+		return nil
+	}
+	worker, err := os.Hostname()
+	if err != nil {
+		return fmt.Errorf("failed to determine worker ID: %w", err)
+	}
+	metrics, err := NewCanaryMetrics(otel.Meter("github.com/krelinga/video-info/worker"))
+	if err != nil {
+		return err
+	}
+	canary := &Canary{
+		Prober:     prober,
+		Dirs:       selfTestDirs(cfg),
+		Interval:   cfg.CanaryInterval,
+		MaxLatency: cfg.CanaryMaxLatency,
+		HTTPClient: internal.NewHTTPClient(cfg.OutboundProxy, cfg.WebhookPolicy, canaryAlertTimeout),
+		Signer:     internal.NewSigner(cfg.SigningKey),
+		Worker:     worker,
+		Metrics:    metrics,
+	}
+	if cfg.CanaryWebhookURI != nil {
+		canary.WebhookURI = cfg.CanaryWebhookURI.String()
+		if err := cfg.WebhookPolicy.CheckString(ctx, canary.WebhookURI); err != nil {
+			return fmt.Errorf("canary webhook URI rejected: %w", err)
+		}
+	}
+	go canary.Run(ctx)
+	return nil
+}
+
+// Run runs the canary every Interval, starting immediately, until ctx is
+// done.
+func (c *Canary) Run(ctx context.Context) {
+	clock := internal.OrSystemClock(c.Clock)
+	for {
+		c.runOnce(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-clock.After(c.Interval):
+		}
+	}
+}
+
+// runOnce runs the canary's checks, records their outcome and alerts if the
+// canary started failing or recovered.
+func (c *Canary) runOnce(ctx context.Context) {
+	clock := internal.OrSystemClock(c.Clock)
+	start := clock.Now()
+	err := c.check(ctx)
+	latency := clock.Now().Sub(start)
+	if ctx.Err() != nil {
+		// Shutting down, so the checks were cut short
+		return
+	}
+	c.Metrics.record(ctx, err, latency)
+
+	alert := CanaryAlert{Worker: c.Worker, LatencySeconds: latency.Seconds(), CheckedAt: start.UTC()}
+	switch {
+	case err != nil && !c.failing:
+		msg := internal.Redact(err.Error())
+		log.Printf("Canary failed: %s", msg)
+		alert.Status, alert.Error = canaryFailing, &msg
+	case err == nil && c.failing:
+		log.Printf("Canary recovered")
+		alert.Status = canaryRecovered
+	default:
+		return
+	}
+	c.failing = err != nil
+	if err := c.alert(ctx, alert); err != nil {
+		log.Printf("Failed to send canary alert: %v", internal.RedactError(err))
+	}
+}
+
+// check probes the sample, then checks each of Dirs.
+func (c *Canary) check(ctx context.Context) error {
+	clock := internal.OrSystemClock(c.Clock)
+	probeCtx, cancel := context.WithTimeout(ctx, selfTestTimeout)
+	defer cancel()
+	start := clock.Now()
+	if err := selfTestProbe(probeCtx, c.Prober); err != nil {
+		return fmt.Errorf("failed to probe sample: %w", err)
+	}
+	if latency := clock.Now().Sub(start); c.MaxLatency > 0 && latency > c.MaxLatency {
+		return fmt.Errorf("probing sample took %s, over the %s threshold", latency.Round(time.Millisecond), c.MaxLatency)
+	}
+	for _, dir := range c.Dirs {
+		if err := selfTestDir(dir); err != nil {
+			return fmt.Errorf("failed to read %s: %w", dir, err)
+		}
+	}
+	return nil
+}
+
+// alert posts alert to WebhookURI, if set.
+func (c *Canary) alert(ctx context.Context, alert CanaryAlert) error {
+	if c.WebhookURI == "" {
+		return nil
+	}
+	body, err := json.Marshal(alert)
+	if err != nil {
+		return fmt.Errorf("failed to marshal canary alert: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.WebhookURI, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create canary alert request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if signature := c.Signer.SignatureHeaderValue(body); signature != "" {
+		req.Header.Set(internal.SignatureHeader, signature)
+	}
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send canary alert: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("canary alert failed with status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// CanaryMetrics records the outcome of canary runs.  A nil CanaryMetrics
+// records nothing.
+type CanaryMetrics struct {
+	runs    metric.Int64Counter
+	latency metric.Float64Histogram
+}
+
+// NewCanaryMetrics creates the canary instruments from meter.
+func NewCanaryMetrics(meter metric.Meter) (*CanaryMetrics, error) {
+	runs, err := meter.Int64Counter("video_info.canary.runs",
+		metric.WithDescription("Canary runs by outcome, ok or failed"),
+		metric.WithUnit("{run}"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create canary run counter: %w", err)
+	}
+	latency, err := meter.Float64Histogram("video_info.canary.latency",
+		metric.WithDescription("Time taken by canary runs"),
+		metric.WithUnit("s"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create canary latency histogram: %w", err)
+	}
+	return &CanaryMetrics{runs: runs, latency: latency}, nil
+}
+
+func (m *CanaryMetrics) record(ctx context.Context, err error, latency time.Duration) {
+	if m == nil {
+		return
+	}
+	outcome := "ok"
+	if err != nil {
+		outcome = "failed"
+	}
+	m.runs.Add(ctx, 1, metric.WithAttributes(attribute.String("outcome", outcome)))
+	m.latency.Record(ctx, latency.Seconds())
+}
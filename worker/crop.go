@@ -0,0 +1,89 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+
+	"github.com/krelinga/video-info/internal"
+)
+
+// Crop detection on its own samples cropSamples evenly spaced windows of the
+// video, each cropSampleSeconds long, rather than decoding all of it.
+const (
+	cropSamples       = 10
+	cropSampleSeconds = 2.0
+)
+
+// cropDetectFilter finds the active picture area.  The limit is a fraction of
+// the pixel format's range so it suits any bit depth, and the area is not
+// reset so each window reports the union over all of its frames.
+const cropDetectFilter = "cropdetect=limit=0.094:round=2:reset=0"
+
+// cropFrameStep thins out the frames cropdetect sees in a pass over the whole
+// video.  The filter logs every frame it sees, which over a feature film
+// would be hundreds of thousands of lines.
+const cropFrameStep = "framestep=step=24"
+
+// cropWindows returns the windows sampled by crop detection in a video
+// lasting duration seconds.  Short videos are analyzed in one window covering
+// all of them.
+func cropWindows(duration float64) []passWindow {
+	if duration <= cropSamples*cropSampleSeconds {
+		window := cropSampleSeconds
+		if duration > 0 {
+			window = duration
+		}
+		return []passWindow{{length: window}}
+	}
+	return evenWindows(duration, cropSamples, cropSampleSeconds)
+}
+
+// addCropDetection adds cropdetect on the first video stream of each window
+// of d.
+func (p *Prober) addCropDetection(d *deepPass, result *internal.InfoJobResult) deepAnalysis {
+	filters := make([]int, len(d.windows))
+	for i, w := range d.windows {
+		chain := []string{cropDetectFilter}
+		if w.length == 0 {
+			chain = []string{cropFrameStep, cropDetectFilter}
+		}
+		filters[i] = d.filter(fmt.Sprintf("[%d:V:0]", i), chain...)
+	}
+
+	return deepAnalysis{name: "crop detection", finish: func(output string, exitErr *exec.ExitError) error {
+		if exitErr != nil {
+			return ffmpegFailed(output, exitErr)
+		}
+		var (
+			rects   []internal.CropRect
+			sampled float64
+		)
+		for i, filter := range filters {
+			if rect, ok := internal.ParseCropDetect(output, filter); ok {
+				rects = append(rects, rect)
+				sampled += d.windows[i].seconds(result.DurationSeconds)
+			}
+		}
+		stream := result.VideoStreams[0]
+		result.Crop = internal.NewCropDetection(stream.Width, stream.Height, rects, sampled)
+		if result.Crop == nil {
+			return errors.New("no picture found in any sample")
+		}
+		return nil
+	}}
+}
+
+// formatSeconds formats a time offset for ffmpeg.
+func formatSeconds(seconds float64) string {
+	return strconv.FormatFloat(seconds, 'f', 3, 64)
+}
+
+// lastLine returns the last non-empty line of s, where ffmpeg reports why it
+// failed.
+func lastLine(s string) string {
+	s = strings.TrimSpace(s)
+	return s[strings.LastIndex(s, "\n")+1:]
+}
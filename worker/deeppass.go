@@ -0,0 +1,226 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/krelinga/video-info/internal"
+)
+
+// deepPass is a single ffmpeg run that decodes a file for several analyses
+// at once, so the file is read once rather than once per analysis.  Each
+// analysis adds its filters and outputs to the pass, then reads its
+// measurements back from the log of the run.
+type deepPass struct {
+	// windows are the parts of the file the pass decodes, each opened as an
+	// input of its own.
+	windows []passWindow
+
+	graph []string
+	maps  []string
+	// filters counts the filters in graph.  ffmpeg logs each filter as
+	// Parsed_<name>_<position>, where position is its index in the graph.
+	filters int
+	// progress asks ffmpeg to report how far it has got among its log
+	// messages.
+	progress bool
+}
+
+// passWindow is a part of a file decoded by a deepPass.  A window without a
+// length covers the whole file.
+type passWindow struct {
+	start, length float64
+}
+
+// seconds returns the length of the window in a file of duration seconds.
+func (w passWindow) seconds(duration float64) float64 {
+	if w.length == 0 {
+		return duration
+	}
+	return w.length
+}
+
+// evenWindows returns count evenly spaced windows, each length seconds long,
+// of a file lasting duration seconds.
+func evenWindows(duration float64, count int, length float64) []passWindow {
+	windows := make([]passWindow, count)
+	for i := range windows {
+		start := max(duration*(float64(i)+0.5)/float64(count)-length/2, 0)
+		windows[i] = passWindow{start: start, length: length}
+	}
+	return windows
+}
+
+// sampleWindows returns the windows sampled by loudness analysis and decode
+// verification when count were requested in a file lasting duration seconds,
+// or nil if they should decode all of it.  Files no longer than the windows
+// would be, or of unknown duration, are decoded in full.
+func sampleWindows(duration float64, count int) []passWindow {
+	if count <= 0 || duration <= float64(count)*internal.SampleWindowSeconds {
+		return nil
+	}
+	return evenWindows(duration, count, internal.SampleWindowSeconds)
+}
+
+// sampled reports whether the pass decodes windows of the file rather than
+// all of it.
+func (d *deepPass) sampled() bool {
+	return d.windows[0].length > 0
+}
+
+// sampledSeconds returns the combined length of the windows of the pass over
+// a file lasting duration seconds.
+func (d *deepPass) sampledSeconds(duration float64) float64 {
+	var seconds float64
+	for _, w := range d.windows {
+		seconds += w.seconds(duration)
+	}
+	return seconds
+}
+
+// filter adds a chain applying filters to inputs, a list of stream
+// specifiers in brackets, and discards its output.  It returns the position
+// of the chain's last filter.
+func (d *deepPass) filter(inputs string, filters ...string) int {
+	label := fmt.Sprintf("[out%d]", len(d.graph))
+	d.graph = append(d.graph, inputs+strings.Join(filters, ",")+label)
+	d.maps = append(d.maps, "-map", label)
+	d.filters += len(filters)
+	return d.filters - 1
+}
+
+// decode adds the streams of every window matching spec to the pass's
+// output, so they are decoded even if no filter reads them.
+func (d *deepPass) decode(spec string) {
+	for i := range d.windows {
+		d.maps = append(d.maps, "-map", fmt.Sprintf("%d:%s", i, spec))
+	}
+}
+
+// deepAnalysis is an analysis's share of a deepPass.
+type deepAnalysis struct {
+	name string
+	// finish adds the analysis's measurements to the result from output,
+	// the log of the pass.  exitErr is the error ffmpeg exited with, if
+	// any.
+	finish func(output string, exitErr *exec.ExitError) error
+}
+
+// ffmpegFailed describes why a pass that exited with exitErr failed.
+func ffmpegFailed(output string, exitErr *exec.ExitError) error {
+	return fmt.Errorf("ffmpeg failed: %w: %s", exitErr, internal.LastFFmpegError(output))
+}
+
+// deepPassArgs returns the ffmpeg arguments for the pass d over videoPath.
+// Messages are logged with their levels so the errors among them can be told
+// apart from the output of the filters.
+func (p *Prober) deepPassArgs(d *deepPass, videoPath string) []string {
+	args := []string{"-hide_banner", "-nostats", "-loglevel", "repeat+level+info"}
+	if d.progress {
+		args = append(args, "-progress", "pipe:2", "-stats_period", "1")
+	}
+	for _, w := range d.windows {
+		args = append(args, p.HWAccel.InputArgs(p.HWAccelDevice)...)
+		// Seeking before each input skips straight to its window
+		if w.length > 0 {
+			args = append(args, "-ss", formatSeconds(w.start), "-t", formatSeconds(w.length))
+		}
+		args = append(args, "-i", videoPath)
+	}
+	if len(d.graph) > 0 {
+		args = append(args, "-filter_complex", strings.Join(d.graph, ";"))
+	}
+	args = append(args, d.maps...)
+	return append(args, "-f", "null", "-")
+}
+
+// runDeepPass runs the pass over videoPath for analyses, adding a warning to
+// warnings for each that fails.
+func (p *Prober) runDeepPass(ctx context.Context, videoPath string, d *deepPass, analyses []deepAnalysis, warnings *[]string) {
+	cmd := exec.CommandContext(ctx, p.FFmpegPath, p.deepPassArgs(d, videoPath)...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	err := cmd.Run()
+	var exitErr *exec.ExitError
+	if ctx.Err() != nil {
+		err = ctx.Err()
+	} else if err == nil || errors.As(err, &exitErr) {
+		err = nil
+	} else {
+		err = fmt.Errorf("failed to run ffmpeg: %w", err)
+	}
+	for _, analysis := range analyses {
+		failure := err
+		if failure == nil {
+			failure = analysis.finish(stderr.String(), exitErr)
+		}
+		if failure != nil {
+			*warnings = append(*warnings, internal.Redact(fmt.Sprintf("%s failed: %v", analysis.name, failure)))
+		}
+	}
+}
+
+// deepAnalysisTasks returns the tasks running the requested analyses that
+// decode the file's streams.  Loudness analysis and decode verification
+// share a pass over the whole file, or over the windows of it requested by
+// analyses.SampleWindows.  The pass also detects the crop if it is decoding
+// the video anyway.  Otherwise crop detection samples windows of the video
+// in a pass of its own, which runs alongside.
+func (p *Prober) deepAnalysisTasks(ctx context.Context, videoPath string, info os.FileInfo, result *internal.InfoJobResult, analyses internal.Analyses, timer *phaseTimer) []analysisTask {
+	crop := analyses.Crop && p.canAnalyze(info, result, "crop detection", len(result.VideoStreams) > 0)
+	loudness := analyses.Loudness && p.canAnalyze(info, result, "loudness analysis", len(result.AudioTracks) > 0)
+	verify := analyses.Verify && p.canAnalyze(info, result, "decode verification", len(result.VideoStreams)+len(result.AudioTracks) > 0)
+
+	var tasks []analysisTask
+	if loudness || verify {
+		decode := &deepPass{windows: []passWindow{{}}}
+		if windows := sampleWindows(result.DurationSeconds, analyses.SampleWindows); windows != nil {
+			decode.windows = windows
+			result.Sampling = internal.NewSamplingCoverage(len(windows), decode.sampledSeconds(result.DurationSeconds), result.DurationSeconds)
+		}
+		var parts []deepAnalysis
+		if verify {
+			parts = append(parts, p.addVerification(decode, result))
+			if crop {
+				parts = append(parts, p.addCropDetection(decode, result))
+				crop = false
+			}
+		}
+		if loudness {
+			parts = append(parts, p.addLoudness(decode, result))
+		}
+		tasks = append(tasks, func(warnings *[]string) {
+			timer.time(internal.PhaseDecode, func() error {
+				p.runDeepPass(ctx, videoPath, decode, parts, warnings)
+				return nil
+			})
+		})
+	}
+	if crop {
+		sampled := &deepPass{windows: cropWindows(result.DurationSeconds)}
+		parts := []deepAnalysis{p.addCropDetection(sampled, result)}
+		tasks = append(tasks, func(warnings *[]string) {
+			timer.time(internal.PhaseCrop, func() error {
+				p.runDeepPass(ctx, videoPath, sampled, parts, warnings)
+				return nil
+			})
+		})
+	}
+	return tasks
+}
+
+// canAnalyze reports whether the analysis name, which decodes the file's
+// streams, can run on it, warning that it was skipped if not.  hasStreams
+// reports whether the file has the streams the analysis decodes.
+func (p *Prober) canAnalyze(info os.FileInfo, result *internal.InfoJobResult, name string, hasStreams bool) bool {
+	if reason := p.deepAnalysisSkipReason(info, hasStreams); reason != "" {
+		result.Warnings = append(result.Warnings, name+" skipped: "+reason)
+		return false
+	}
+	return true
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"time"
+)
+
+// maxExternalStderr bounds how much of an external program's standard error
+// is kept for reporting a failure.
+const maxExternalStderr = 4096
+
+// errExternalOutputTooLarge reports an external program that wrote more than
+// it was allowed to.
+var errExternalOutputTooLarge = errors.New("output too large")
+
+// runExternal runs an operator-provided executable with args and with input
+// on its standard input, and returns its standard output, of which at most
+// maxOutput bytes are accepted.  Output is discarded if maxOutput is zero.
+// The program is run directly, not by a shell, in an empty temporary
+// directory with only PATH in its environment, and it and any processes it
+// starts are killed after timeout.
+//
+// This keeps the worker's credentials out of the program's environment, but
+// it is not a security boundary.  The program runs as the worker's user, so
+// it can read whatever the worker can, including the worker's own
+// environment under /proc and any key files it was configured with.  Only
+// run programs trusted as much as the worker itself, or drop their
+// privileges with a wrapper.
+func runExternal(ctx context.Context, path string, timeout time.Duration, input []byte, maxOutput int, args ...string) ([]byte, error) {
+	dir, err := os.MkdirTemp("", "video-info-exec-")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create working directory: %w", err)
+	}
+	defer os.RemoveAll(dir)
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, path, args...)
+	cmd.Dir = dir
+	cmd.Env = []string{"PATH=" + os.Getenv("PATH"), "HOME=" + dir, "TMPDIR=" + dir}
+	cmd.Stdin = bytes.NewReader(input)
+	stdout := &limitedBuffer{max: maxOutput}
+	stderr := &limitedBuffer{max: maxExternalStderr}
+	if maxOutput > 0 {
+		cmd.Stdout = stdout
+	}
+	cmd.Stderr = stderr
+	// Don't wait forever on output held open by a process the program left
+	// behind.  Run reports that with ErrWaitDelay only if the program itself
+	// succeeded.
+	cmd.WaitDelay = time.Second
+	confineCommand(cmd)
+
+	if err := cmd.Run(); err != nil && !errors.Is(err, exec.ErrWaitDelay) {
+		if ctx.Err() == context.DeadlineExceeded {
+			return nil, fmt.Errorf("timed out after %s", timeout)
+		}
+		return nil, fmt.Errorf("%w: %s", err, lastLine(stderr.String()))
+	}
+	if stdout.truncated {
+		return nil, fmt.Errorf("%w: over %d bytes", errExternalOutputTooLarge, maxOutput)
+	}
+	return stdout.Bytes(), nil
+}
+
+// limitedBuffer keeps the first max bytes written to it and discards the
+// rest.
+type limitedBuffer struct {
+	bytes.Buffer
+	max       int
+	truncated bool
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	room := b.max - b.Len()
+	if len(p) > room {
+		b.truncated = true
+	}
+	if room > 0 {
+		b.Buffer.Write(p[:min(len(p), room)])
+	}
+	return len(p), nil
+}
@@ -0,0 +1,311 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/hirochachacha/go-smb2"
+	"github.com/krelinga/video-info/internal"
+	"github.com/pkg/sftp"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
+)
+
+// Default ports of fetched URL schemes.
+const (
+	sftpPort = "22"
+	smbPort  = "445"
+)
+
+// extractFetchedInfo copies the file named by videoURL to local disk and
+// probes the copy like any other file.  Results are not cached, since the
+// copy is new every time.
+func (p *Prober) extractFetchedInfo(ctx context.Context, videoURL string, analyses internal.Analyses, timer *phaseTimer) (*internal.InfoJobResult, error) {
+	if err := internal.CheckInputURL(ctx, videoURL, p.URLInputSchemes, p.URLPolicy); err != nil {
+		return nil, err
+	}
+	var localPath string
+	err := timer.time(internal.PhaseFetch, func() error {
+		var err error
+		localPath, err = p.fetch(ctx, videoURL)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(localPath)
+
+	local := *p
+	local.AllowedRoots = nil
+	local.Cache = nil
+	return local.extractVideoInfo(ctx, localPath, analyses, timer)
+}
+
+// fetch copies the file named by videoURL into a new file in FetchDir and
+// returns its path.  The caller removes the file.
+func (p *Prober) fetch(ctx context.Context, videoURL string) (string, error) {
+	u, err := url.Parse(videoURL)
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", internal.ErrPathNotAllowed, err)
+	}
+
+	// Keep the extension, which ffprobe uses to pick among similar formats
+	f, err := os.CreateTemp(p.FetchDir, "video-info-fetch-*"+path.Ext(u.Path))
+	if err != nil {
+		return "", fmt.Errorf("failed to create local copy: %w", err)
+	}
+	if internal.IsFetchedURLScheme(u.Scheme) {
+		var file *remoteFile
+		file, err = p.openRemote(ctx, u)
+		if err == nil {
+			err = p.copyFetched(f, io.NewSectionReader(file, 0, file.size), file.size)
+			file.close()
+		}
+	} else {
+		err = p.fetchHTTP(ctx, u, f)
+	}
+	if closeErr := f.Close(); err == nil && closeErr != nil {
+		err = fmt.Errorf("failed to write local copy: %w", closeErr)
+	}
+	if err != nil {
+		os.Remove(f.Name())
+		// Connections are closed to interrupt fetches, which hides the reason
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return "", ctxErr
+		}
+		return "", fmt.Errorf("failed to fetch %s: %w", u.Redacted(), err)
+	}
+	return f.Name(), nil
+}
+
+// fetchHTTP copies the file at the http or https URL u to dst, through the
+// outbound proxy if one is configured.  Redirects are held to the URL
+// policy.
+func (p *Prober) fetchHTTP(ctx context.Context, u *url.URL, dst io.Writer) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return err
+	}
+	resp, err := internal.NewHTTPClient(p.OutboundProxy, p.URLPolicy, 0).Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("server responded %s", resp.Status)
+	}
+	return p.copyFetched(dst, resp.Body, resp.ContentLength)
+}
+
+// remoteFile is a file open on an SFTP server or SMB share.  Its close
+// function closes the file and the connection it was opened over.
+type remoteFile struct {
+	io.ReaderAt
+	size  int64
+	close func()
+}
+
+// openRemote opens the file named by the sftp or smb URL u, unless it is
+// over MaxFileSize.
+func (p *Prober) openRemote(ctx context.Context, u *url.URL) (*remoteFile, error) {
+	var (
+		file *remoteFile
+		err  error
+	)
+	switch strings.ToLower(u.Scheme) {
+	case "sftp":
+		file, err = p.openSFTP(ctx, u)
+	case "smb":
+		file, err = p.openSMB(ctx, u)
+	default:
+		err = fmt.Errorf("%w: %s URLs can't be fetched", internal.ErrPathNotAllowed, u.Scheme)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if p.MaxFileSize > 0 && file.size > p.MaxFileSize {
+		file.close()
+		return nil, fmt.Errorf("%w: video file is %d bytes, over the %d byte limit", internal.ErrFileTooLarge, file.size, p.MaxFileSize)
+	}
+	return file, nil
+}
+
+// dialFetch connects to the host of u, on defaultPort if it names none,
+// refusing addresses the URL policy denies.  The connection is closed if ctx
+// is done before stop is called.
+func (p *Prober) dialFetch(ctx context.Context, u *url.URL, defaultPort string) (conn net.Conn, stop func() bool, err error) {
+	port := u.Port()
+	if port == "" {
+		port = defaultPort
+	}
+	conn, err = p.URLPolicy.DialContext(ctx, p.URLInputTimeout, "tcp", net.JoinHostPort(u.Hostname(), port))
+	if err != nil {
+		return nil, nil, err
+	}
+	stop = context.AfterFunc(ctx, func() { conn.Close() })
+	return conn, stop, nil
+}
+
+// copyFetched copies the size bytes of src to dst, unless they are over
+// MaxFileSize.  The copy stops once it passes MaxFileSize, in case the file
+// grew or the server reported the wrong size.
+func (p *Prober) copyFetched(dst io.Writer, src io.Reader, size int64) error {
+	if p.MaxFileSize <= 0 {
+		_, err := io.Copy(dst, src)
+		return err
+	}
+	if size > p.MaxFileSize {
+		return fmt.Errorf("%w: video file is %d bytes, over the %d byte limit", internal.ErrFileTooLarge, size, p.MaxFileSize)
+	}
+	n, err := io.Copy(dst, io.LimitReader(src, p.MaxFileSize+1))
+	if err != nil {
+		return err
+	}
+	if n > p.MaxFileSize {
+		return fmt.Errorf("%w: video file is over the %d byte limit", internal.ErrFileTooLarge, p.MaxFileSize)
+	}
+	return nil
+}
+
+// openSFTP opens the file at the path of u on its SFTP server.  The
+// server's host key must be in the configured known hosts file.
+func (p *Prober) openSFTP(ctx context.Context, u *url.URL) (*remoteFile, error) {
+	if p.SFTP.KnownHosts == "" {
+		return nil, errors.New("no known hosts file is configured for sftp")
+	}
+	hostKeys, err := knownhosts.New(p.SFTP.KnownHosts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read known hosts: %w", err)
+	}
+	config := &ssh.ClientConfig{
+		User:            p.SFTP.User,
+		HostKeyCallback: hostKeys,
+		Timeout:         p.URLInputTimeout,
+	}
+	if p.SFTP.KeyFile != "" {
+		key, err := os.ReadFile(p.SFTP.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read sftp key: %w", err)
+		}
+		signer, err := ssh.ParsePrivateKey(key)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse sftp key: %w", err)
+		}
+		config.Auth = append(config.Auth, ssh.PublicKeys(signer))
+	}
+	if p.SFTP.Password != "" {
+		config.Auth = append(config.Auth, ssh.Password(p.SFTP.Password))
+	}
+
+	conn, stop, err := p.dialFetch(ctx, u, sftpPort)
+	if err != nil {
+		return nil, err
+	}
+	sshConn, chans, reqs, err := ssh.NewClientConn(conn, conn.RemoteAddr().String(), config)
+	if err != nil {
+		stop()
+		conn.Close()
+		return nil, err
+	}
+	sshClient := ssh.NewClient(sshConn, chans, reqs)
+	closeClient := func() {
+		stop()
+		sshClient.Close()
+	}
+	client, err := sftp.NewClient(sshClient)
+	if err != nil {
+		closeClient()
+		return nil, err
+	}
+
+	f, err := client.Open(u.Path)
+	if err != nil {
+		client.Close()
+		closeClient()
+		return nil, err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		client.Close()
+		closeClient()
+		return nil, err
+	}
+	return &remoteFile{
+		ReaderAt: f,
+		size:     info.Size(),
+		close: func() {
+			f.Close()
+			client.Close()
+			closeClient()
+		},
+	}, nil
+}
+
+// openSMB opens a file on an SMB share.  The first element of the path of u
+// names the share, and the rest the file within it.
+func (p *Prober) openSMB(ctx context.Context, u *url.URL) (*remoteFile, error) {
+	shareName, filePath, _ := strings.Cut(strings.TrimPrefix(u.Path, "/"), "/")
+	if shareName == "" || filePath == "" {
+		return nil, fmt.Errorf("%w: smb URLs must name a share and a file in it", internal.ErrPathNotAllowed)
+	}
+	initiator := &smb2.NTLMInitiator{
+		User:     p.SMB.User,
+		Password: p.SMB.Password,
+		Domain:   p.SMB.Domain,
+	}
+
+	conn, stop, err := p.dialFetch(ctx, u, smbPort)
+	if err != nil {
+		return nil, err
+	}
+	closeConn := func() {
+		stop()
+		conn.Close()
+	}
+	dialer := &smb2.Dialer{Initiator: initiator}
+	session, err := dialer.DialContext(ctx, conn)
+	if err != nil {
+		closeConn()
+		return nil, err
+	}
+	share, err := session.Mount(shareName)
+	if err != nil {
+		session.Logoff()
+		closeConn()
+		return nil, err
+	}
+	closeShare := func() {
+		share.Umount()
+		session.Logoff()
+		closeConn()
+	}
+
+	f, err := share.WithContext(ctx).Open(filePath)
+	if err != nil {
+		closeShare()
+		return nil, err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		closeShare()
+		return nil, err
+	}
+	return &remoteFile{
+		ReaderAt: f,
+		size:     info.Size(),
+		close: func() {
+			f.Close()
+			closeShare()
+		},
+	}, nil
+}
@@ -0,0 +1,119 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"github.com/krelinga/video-info/internal"
+	"github.com/krelinga/video-info/virest"
+)
+
+// healthCheckTimeout bounds each health check, so a hung dependency fails the
+// probe rather than hanging it.
+const healthCheckTimeout = 5 * time.Second
+
+// healthChecks are the checks behind the worker's health endpoints.
+type healthChecks struct {
+	// live fails when the worker has stopped working and should be
+	// restarted.
+	live func(context.Context) error
+
+	// ready fails when the worker can't currently take jobs.
+	ready func(context.Context) error
+}
+
+// serveHealth serves liveness and readiness probes at /healthz and /readyz
+// on port until ctx is done.  It does nothing if port is not positive.
+func serveHealth(ctx context.Context, port int, checks healthChecks) {
+	if port <= 0 {
+		return
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("GET /healthz", healthHandler(checks.live))
+	mux.Handle("GET /readyz", healthHandler(checks.ready))
+	server := &http.Server{
+		Addr:              fmt.Sprintf(":%d", port),
+		Handler:           mux,
+		ReadHeaderTimeout: healthCheckTimeout,
+	}
+
+	go func() {
+		log.Printf("Serving health checks on port %d", port)
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Printf("Health check server error: %v", err)
+		}
+	}()
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), healthCheckTimeout)
+		defer cancel()
+		server.Shutdown(shutdownCtx)
+	}()
+}
+
+// healthHandler responds like the server's health endpoints, with the
+// outcome of check.
+func healthHandler(check func(context.Context) error) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx, cancel := context.WithTimeout(r.Context(), healthCheckTimeout)
+		defer cancel()
+
+		status := http.StatusOK
+		var body any = virest.HealthStatus{Status: "ok"}
+		if err := check(ctx); err != nil {
+			status = http.StatusServiceUnavailable
+			body = virest.Error{Code: "NOT_READY", Message: err.Error()}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		json.NewEncoder(w).Encode(body)
+	})
+}
+
+// heartbeatMonitor tracks a pull-mode worker's heartbeats to the server.
+type heartbeatMonitor struct {
+	// clock times the heartbeats.  Nil means the system clock.
+	clock internal.Clock
+
+	lastAttempt atomic.Int64
+	lastSuccess atomic.Int64
+}
+
+// record notes a heartbeat attempt and whether it succeeded.
+func (m *heartbeatMonitor) record(err error) {
+	now := internal.OrSystemClock(m.clock).Now().UnixNano()
+	m.lastAttempt.Store(now)
+	if err == nil {
+		m.lastSuccess.Store(now)
+	}
+}
+
+// live fails if heartbeats have stopped being attempted, meaning the worker
+// is wedged.
+func (m *heartbeatMonitor) live(context.Context) error {
+	return m.checkRecent("heartbeat attempt", m.lastAttempt.Load())
+}
+
+// ready fails if the server hasn't accepted a heartbeat recently.
+func (m *heartbeatMonitor) ready(context.Context) error {
+	return m.checkRecent("successful heartbeat", m.lastSuccess.Load())
+}
+
+// checkRecent fails if the event that last happened at unixNano is overdue,
+// allowing for a couple of missed heartbeats.
+func (m *heartbeatMonitor) checkRecent(event string, unixNano int64) error {
+	if unixNano == 0 {
+		return fmt.Errorf("no %s yet", event)
+	}
+	if since := internal.OrSystemClock(m.clock).Now().Sub(time.Unix(0, unixNano)); since > 3*agentHeartbeatInterval {
+		return fmt.Errorf("last %s was %s ago", event, since.Round(time.Second))
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/krelinga/video-info/internal"
+	"github.com/krelinga/video-info/virest"
+)
+
+// defaultHookTimeout bounds the post-probe hook when no timeout is
+// configured.
+const defaultHookTimeout = 30 * time.Second
+
+// HookPayload is the JSON written to the post-probe hook's standard input.
+type HookPayload struct {
+	Uuid      uuid.UUID         `json:"uuid"`
+	VideoPath string            `json:"videoPath"`
+	Result    *virest.MediaInfo `json:"result,omitempty"`
+	Error     *string           `json:"error,omitempty"`
+	ErrorCode *string           `json:"errorCode,omitempty"`
+}
+
+// PostProbeHook runs an operator-provided executable after every probe.
+type PostProbeHook struct {
+	// Path is the executable to run, as described by runExternal.
+	Path string
+
+	// Timeout bounds each run, or defaultHookTimeout if zero.
+	Timeout time.Duration
+}
+
+// Run runs the hook with the outcome of probing videoPath for the job
+// jobUUID on its standard input.  Its output is discarded.
+func (h *PostProbeHook) Run(ctx context.Context, jobUUID uuid.UUID, videoPath string, status *internal.InfoJobStatus) error {
+	payload, err := json.Marshal(HookPayload{
+		Uuid:      jobUUID,
+		VideoPath: videoPath,
+		Result:    status.Result.RESTMediaInfo(),
+		Error:     status.Error,
+		ErrorCode: status.ErrorCode,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal hook payload: %w", err)
+	}
+
+	timeout := h.Timeout
+	if timeout <= 0 {
+		timeout = defaultHookTimeout
+	}
+	if _, err := runExternal(ctx, h.Path, timeout, payload, 0); err != nil {
+		return fmt.Errorf("hook failed: %w", err)
+	}
+	return nil
+}
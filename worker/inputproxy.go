@@ -0,0 +1,197 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"time"
+
+	"github.com/krelinga/video-info/internal"
+)
+
+// inputProxy is an HTTP proxy on the loopback interface that ffprobe reads
+// URLs through, so that every request it makes, including those following
+// redirects, is held to the URL policy rather than only the URL of the job.
+type inputProxy struct {
+	policy   *internal.URLPolicy
+	upstream *url.URL
+	timeout  time.Duration
+	url      *url.URL
+	reverse  *httputil.ReverseProxy
+}
+
+// startInputProxy starts an inputProxy that enforces policy, bounding each
+// connection it makes by timeout if positive.  Requests are sent on through
+// upstream if it is non-nil, which like the outbound proxy of any other
+// client is trusted to be reachable whatever its address.  The inputProxy
+// serves until the process exits.
+func startInputProxy(policy *internal.URLPolicy, upstream *url.URL, timeout time.Duration) (*inputProxy, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, fmt.Errorf("failed to start URL input proxy: %w", err)
+	}
+	p := &inputProxy{
+		policy:   policy,
+		upstream: upstream,
+		timeout:  timeout,
+		url:      &url.URL{Scheme: "http", Host: listener.Addr().String()},
+	}
+	transport := &http.Transport{
+		DialContext: func(ctx context.Context, network, address string) (net.Conn, error) {
+			return p.policy.DialContext(ctx, p.timeout, network, address)
+		},
+		TLSHandshakeTimeout:   10 * time.Second,
+		ResponseHeaderTimeout: timeout,
+	}
+	if upstream != nil {
+		transport.Proxy = http.ProxyURL(upstream)
+		transport.DialContext = (&net.Dialer{Timeout: timeout, KeepAlive: 30 * time.Second}).DialContext
+	}
+	p.reverse = &httputil.ReverseProxy{
+		// Requests to a proxy carry absolute URLs, so there is nothing to
+		// rewrite
+		Rewrite:   func(*httputil.ProxyRequest) {},
+		Transport: transport,
+		ErrorHandler: func(w http.ResponseWriter, r *http.Request, err error) {
+			log.Printf("URL input proxy request to %s failed: %v", r.URL.Redacted(), err)
+			http.Error(w, err.Error(), http.StatusBadGateway)
+		},
+	}
+	go http.Serve(listener, p)
+	return p, nil
+}
+
+// URL returns the address ffprobe should use as its HTTP proxy.
+func (p *inputProxy) URL() *url.URL {
+	return p.url
+}
+
+// ServeHTTP forwards plain http requests and tunnels https connections for
+// hosts the policy allows.  Redirects are passed back to ffprobe, whose
+// request for the new location comes through here in turn.
+func (p *inputProxy) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method == http.MethodConnect {
+		p.tunnel(w, r)
+		return
+	}
+	if err := p.policy.Check(r.Context(), r.URL); err != nil {
+		http.Error(w, err.Error(), http.StatusForbidden)
+		return
+	}
+	p.reverse.ServeHTTP(w, r)
+}
+
+// tunnel connects ffprobe to the host named by a CONNECT request.
+func (p *inputProxy) tunnel(w http.ResponseWriter, r *http.Request) {
+	host, _, err := net.SplitHostPort(r.Host)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := p.policy.CheckHost(r.Context(), host); err != nil {
+		http.Error(w, err.Error(), http.StatusForbidden)
+		return
+	}
+	upstream, err := p.dialTunnel(r.Context(), r.Host)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+	defer upstream.Close()
+
+	downstream, buffered, err := http.NewResponseController(w).Hijack()
+	if err != nil {
+		log.Printf("URL input proxy failed to tunnel to %s: %v", r.Host, err)
+		return
+	}
+	defer downstream.Close()
+	if _, err := io.WriteString(downstream, "HTTP/1.1 200 Connection Established\r\n\r\n"); err != nil {
+		return
+	}
+
+	done := make(chan struct{})
+	go func() {
+		io.Copy(upstream, buffered)
+		if closer, ok := upstream.(interface{ CloseWrite() error }); ok {
+			closer.CloseWrite()
+		}
+		close(done)
+	}()
+	io.Copy(downstream, upstream)
+	downstream.Close()
+	<-done
+}
+
+// dialTunnel connects to address, through a tunnel opened by the upstream
+// proxy if there is one.
+func (p *inputProxy) dialTunnel(ctx context.Context, address string) (net.Conn, error) {
+	if p.upstream == nil {
+		return p.policy.DialContext(ctx, p.timeout, "tcp", address)
+	}
+
+	upstreamAddr := p.upstream.Host
+	if p.upstream.Port() == "" {
+		port := "80"
+		if p.upstream.Scheme == "https" {
+			port = "443"
+		}
+		upstreamAddr = net.JoinHostPort(p.upstream.Hostname(), port)
+	}
+	dialer := &net.Dialer{Timeout: p.timeout, KeepAlive: 30 * time.Second}
+	conn, err := dialer.DialContext(ctx, "tcp", upstreamAddr)
+	if err != nil {
+		return nil, err
+	}
+	if p.upstream.Scheme == "https" {
+		conn = tls.Client(conn, &tls.Config{ServerName: p.upstream.Hostname()})
+	}
+	stop := context.AfterFunc(ctx, func() { conn.Close() })
+	defer stop()
+
+	req := &http.Request{
+		Method: http.MethodConnect,
+		URL:    &url.URL{Opaque: address},
+		Host:   address,
+		Header: make(http.Header),
+	}
+	if user := p.upstream.User; user != nil {
+		password, _ := user.Password()
+		credentials := base64.StdEncoding.EncodeToString([]byte(user.Username() + ":" + password))
+		req.Header.Set("Proxy-Authorization", "Basic "+credentials)
+	}
+	if err := req.Write(conn); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	reader := bufio.NewReader(conn)
+	resp, err := http.ReadResponse(reader, req)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		conn.Close()
+		return nil, fmt.Errorf("outbound proxy refused tunnel to %s: %s", address, resp.Status)
+	}
+	return &bufferedConn{Conn: conn, reader: reader}, nil
+}
+
+// bufferedConn is a net.Conn whose reads start with what was buffered while
+// reading the reply to CONNECT.
+type bufferedConn struct {
+	net.Conn
+	reader *bufio.Reader
+}
+
+func (c *bufferedConn) Read(b []byte) (int, error) {
+	return c.reader.Read(b)
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/krelinga/video-info/internal"
+	"github.com/riverqueue/river"
+)
+
+// Limits on the periodic job that publishes results to Kafka.
+const (
+	kafkaSinkInterval = time.Minute
+
+	// kafkaSinkMaxBatches bounds the batches published per run, so a topic
+	// catching up on a backlog doesn't hold the maintenance queue.
+	kafkaSinkMaxBatches = 20
+
+	kafkaSinkTimeout = 30 * time.Second
+)
+
+// KafkaSinkTickWorker publishes results stored since its last run to Kafka.
+type KafkaSinkTickWorker struct {
+	river.WorkerDefaults[internal.KafkaSinkTickArgs]
+	DBPool *pgxpool.Pool
+	Sink   *internal.KafkaSink
+	Clock  internal.Clock
+}
+
+// Work publishes new results in batches, committing the cursor after each.
+// A batch that fails to publish is retried from the same cursor, so each
+// result is published at least once.
+func (w *KafkaSinkTickWorker) Work(ctx context.Context, job *river.Job[internal.KafkaSinkTickArgs]) error {
+	var total int
+	for range kafkaSinkMaxBatches {
+		published, err := w.publishBatch(ctx)
+		if err != nil {
+			return err
+		}
+		total += published
+		if published == 0 {
+			break
+		}
+	}
+	if total > 0 {
+		log.Printf("Published %d results to Kafka topic %s", total, w.Sink.Topic)
+	}
+	return nil
+}
+
+// publishBatch publishes the next batch of results in its own transaction.
+func (w *KafkaSinkTickWorker) publishBatch(ctx context.Context) (int, error) {
+	tx, err := w.DBPool.Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	published, err := internal.PublishResultsToKafka(ctx, tx, w.Sink, internal.OrSystemClock(w.Clock).Now())
+	if err != nil {
+		return 0, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return 0, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return published, nil
+}
+
+// newKafkaSink returns the sink configured in cfg, or nil if none is.
+func newKafkaSink(cfg *internal.WorkerConfig) (*internal.KafkaSink, error) {
+	if cfg.KafkaRESTURL == nil {
+		return nil, nil
+	}
+	if cfg.KafkaTopic == "" {
+		return nil, fmt.Errorf("%s must be set with %s", internal.EnvKafkaTopic, internal.EnvKafkaRESTURL)
+	}
+	return &internal.KafkaSink{
+		URL:    cfg.KafkaRESTURL,
+		Topic:  cfg.KafkaTopic,
+		Client: internal.NewHTTPClient(cfg.OutboundProxy, nil, kafkaSinkTimeout),
+	}, nil
+}
+
+// kafkaSinkPeriodicJob enqueues the Kafka sink tick job every
+// kafkaSinkInterval while this worker is River's leader.
+func kafkaSinkPeriodicJob() *river.PeriodicJob {
+	return river.NewPeriodicJob(
+		river.PeriodicInterval(kafkaSinkInterval),
+		func() (river.JobArgs, *river.InsertOpts) {
+			return internal.KafkaSinkTickArgs{}, nil
+		},
+		&river.PeriodicJobOpts{RunOnStart: true},
+	)
+}
@@ -0,0 +1,41 @@
+package main
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/krelinga/video-info/internal"
+)
+
+// addLoudness adds a loudnorm filter to d for every audio track, measuring
+// its EBU R128 loudness.  The windows of a sampled pass are joined and
+// measured as one.
+func (p *Prober) addLoudness(d *deepPass, result *internal.InfoJobResult) deepAnalysis {
+	filters := make([]int, len(result.AudioTracks))
+	for i, track := range result.AudioTracks {
+		var inputs strings.Builder
+		for w := range d.windows {
+			fmt.Fprintf(&inputs, "[%d:%d]", w, track.Index)
+		}
+		chain := []string{"loudnorm=print_format=json"}
+		if len(d.windows) > 1 {
+			chain = append([]string{fmt.Sprintf("concat=n=%d:v=0:a=1", len(d.windows))}, chain...)
+		}
+		filters[i] = d.filter(inputs.String(), chain...)
+	}
+
+	return deepAnalysis{name: "loudness analysis", finish: func(output string, exitErr *exec.ExitError) error {
+		if exitErr != nil {
+			return ffmpegFailed(output, exitErr)
+		}
+		loudness, err := internal.ParseLoudnorm(output, filters)
+		if err != nil {
+			return err
+		}
+		for i := range result.AudioTracks {
+			result.AudioTracks[i].Loudness = &loudness[i]
+		}
+		return nil
+	}}
+}
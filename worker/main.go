@@ -2,8 +2,12 @@ package main
 
 import (
 	"context"
+	"errors"
+	"flag"
 	"fmt"
 	"log"
+	"log/slog"
+	"os"
 	"os/signal"
 	"syscall"
 	"time"
@@ -11,9 +15,13 @@ import (
 	"github.com/krelinga/video-info/internal"
 	"github.com/riverqueue/river"
 	"github.com/riverqueue/river/riverdriver/riverpgxv5"
+	"go.opentelemetry.io/otel"
 )
 
 func main() {
+	// Keep credentials out of everything the process logs
+	log.SetOutput(internal.NewRedactingWriter(os.Stderr))
+
 	if err := run(); err != nil {
 		log.Fatalf("worker error: %v", err)
 	}
@@ -24,9 +32,31 @@ func run() error {
 	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
 	defer stop()
 
+	selfTest := flag.Bool("self-test", false, "check that the worker can probe video and reach its dependencies, then exit")
+	flag.Parse()
+
 	// Load configuration
 	cfg := internal.NewWorkerConfigFromEnv()
 
+	if *selfTest {
+		return runSelfTest(ctx, cfg, os.Stdout)
+	}
+
+	shutdownMetrics, err := internal.StartMetrics(ctx, cfg.OutboundProxy)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := shutdownMetrics(context.Background()); err != nil {
+			log.Printf("Failed to flush metrics: %v", err)
+		}
+	}()
+
+	// Workers without database access pull jobs from the server instead
+	if cfg.ServerURL != nil {
+		return runPull(ctx, cfg)
+	}
+
 	// Create database pool
 	pool, err := internal.NewDBPool(ctx, cfg.Database)
 	if err != nil {
@@ -41,22 +71,107 @@ func run() error {
 	}
 	log.Println("Migrations complete")
 
+	if err := cfg.WebhookRetry.Validate(); err != nil {
+		return err
+	}
+
 	// Create River workers and register info worker
+	signer := internal.NewSigner(cfg.SigningKey)
+	keyring, err := internal.NewKeyring(cfg.SecretsKeys)
+	if err != nil {
+		return fmt.Errorf("failed to create secrets keyring: %w", err)
+	}
+	prober, err := NewProber(cfg)
+	if err != nil {
+		return err
+	}
+	if err := startCanary(ctx, cfg, prober); err != nil {
+		return err
+	}
+	webhookMetrics, err := NewWebhookMetrics(otel.Meter("github.com/krelinga/video-info/worker"))
+	if err != nil {
+		return err
+	}
+	events, closeEvents, err := internal.ConnectEventPublisher(cfg.NATSURL)
+	if err != nil {
+		return err
+	}
+	defer closeEvents()
 	workers := river.NewWorkers()
-	river.AddWorker(workers, &InfoWorker{DBPool: pool})
-	river.AddWorker(workers, &WebhookWorker{})
+	river.AddWorker(workers, &InfoWorker{DBPool: pool, Signer: signer, Prober: prober, Events: events})
+	// Delivery attempts are bounded by the job timeout rather than the client
+	river.AddWorker(workers, &WebhookWorker{
+		DBPool:      pool,
+		HTTPClient:  internal.NewHTTPClient(cfg.OutboundProxy, cfg.WebhookPolicy, 0),
+		URLPolicy:   cfg.WebhookPolicy,
+		Signer:      signer,
+		Keyring:     keyring,
+		RetryPolicy: cfg.WebhookRetry,
+		Breaker:     cfg.WebhookBreaker,
+		RateLimits:  cfg.WebhookRateLimits,
+		Metrics:     webhookMetrics,
+	})
+	river.AddWorker(workers, &PriorityAgingWorker{DBPool: pool, Step: cfg.PriorityAging})
+	river.AddWorker(workers, &ResultRetentionWorker{DBPool: pool, TTL: cfg.ResultTTL})
+	river.AddWorker(workers, &ScheduleTickWorker{DBPool: pool})
+	river.AddWorker(workers, &ScanWorker{DBPool: pool, CacheSettings: prober.CacheSettings(), Events: events})
+	river.AddWorker(workers, &TerminalWebhooksWorker{DBPool: pool})
+	river.AddWorker(workers, &ReprobeCampaignTickWorker{DBPool: pool, Events: events})
+	periodicJobs := []*river.PeriodicJob{scheduleTickPeriodicJob(), terminalWebhooksPeriodicJob(), reprobeCampaignPeriodicJob()}
+	if cfg.PriorityAging > 0 {
+		periodicJobs = append(periodicJobs, priorityAgingPeriodicJob())
+	}
+	if cfg.ResultTTL > 0 {
+		periodicJobs = append(periodicJobs, resultRetentionPeriodicJob())
+	}
+	kafkaSink, err := newKafkaSink(cfg)
+	if err != nil {
+		return err
+	}
+	if kafkaSink != nil {
+		river.AddWorker(workers, &KafkaSinkTickWorker{DBPool: pool, Sink: kafkaSink})
+		periodicJobs = append(periodicJobs, kafkaSinkPeriodicJob())
+	}
 
-	// Create River client with workers
+	// Create River client with workers.  Only workers with GPU capacity
+	// work the GPU queue.
+	queues := map[string]river.QueueConfig{
+		internal.QueueMaintenance: {MaxWorkers: 1},
+	}
+	for queue, concurrency := range cfg.QueueConcurrency() {
+		queues[queue] = river.QueueConfig{MaxWorkers: concurrency}
+	}
+	if cfg.GPUCapacity > 0 {
+		queues[internal.QueueGPU] = river.QueueConfig{MaxWorkers: cfg.GPUCapacity}
+	}
 	riverClient, err := river.NewClient(riverpgxv5.New(pool), &river.Config{
-		Queues: map[string]river.QueueConfig{
-			river.QueueDefault: {MaxWorkers: 1},
-		},
-		Workers: workers,
+		Queues:       queues,
+		Workers:      workers,
+		PeriodicJobs: periodicJobs,
+		Middleware:   events.RiverMiddleware(),
+		Logger:       slog.New(slog.NewTextHandler(internal.NewRedactingWriter(os.Stderr), nil)),
 	})
 	if err != nil {
 		return fmt.Errorf("failed to create river client: %w", err)
 	}
 
+	serveHealth(ctx, cfg.HealthPort, healthChecks{
+		live: func(context.Context) error {
+			select {
+			case <-riverClient.Stopped():
+				return errors.New("river client stopped")
+			default:
+				return nil
+			}
+		},
+		ready: func(ctx context.Context) error {
+			if err := pool.Ping(ctx); err != nil {
+				return fmt.Errorf("database unreachable: %w", err)
+			}
+			return nil
+		},
+	})
+
 	// Start River client to begin processing jobs
 	if err := riverClient.Start(ctx); err != nil {
 		return fmt.Errorf("failed to start river client: %w", err)
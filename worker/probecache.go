@@ -0,0 +1,62 @@
+package main
+
+import (
+	"context"
+	"log"
+	"os"
+
+	"github.com/krelinga/video-info/internal"
+)
+
+// lookupProbeCache returns the status of the job from the result cached in
+// the database for the file's current state and the worker's settings, or
+// nil if there is none or the job forces probing.  It also returns the
+// file's state, under which a new result may be cached, or nil if the
+// file's results can't be cached: URLs have no cheap way to tell whether
+// they changed, and image sequences are directories whose modification time
+// doesn't follow their frames.
+//
+// Files the probe would reject are left for it to report.  A cached result
+// gets what doesn't depend on the file alone done anew: its linked segments
+// are looked for, its preset suggested, and the post-probe hook run with
+// it.  Analyses aren't run again.
+func (w *InfoWorker) lookupProbeCache(ctx context.Context, jobArgs internal.InfoJobArgs) (*internal.InfoJobStatus, os.FileInfo) {
+	if internal.IsInputURL(jobArgs.Path) {
+		return nil, nil
+	}
+	if err := w.Prober.AllowedRoots.CheckResolved(jobArgs.Path); err != nil {
+		return nil, nil
+	}
+	info, err := os.Stat(jobArgs.Path)
+	if err != nil || info.IsDir() {
+		return nil, nil
+	}
+	if w.Prober.MaxFileSize > 0 && info.Size() > w.Prober.MaxFileSize {
+		return nil, nil
+	}
+	if jobArgs.Force {
+		return nil, info
+	}
+
+	timer := &phaseTimer{}
+	var result *internal.InfoJobResult
+	// A cache problem shouldn't fail the job, so fall back to probing
+	timer.time(internal.PhaseCache, func() error {
+		var err error
+		result, err = internal.LookupProbeCache(ctx, w.DBPool, jobArgs.Path, info, jobArgs.Analyses(), w.Prober.CacheSettings())
+		if err != nil {
+			log.Printf("Cached result lookup for %q failed: %v", jobArgs.Path, err)
+		}
+		return nil
+	})
+	if result == nil {
+		return nil, info
+	}
+
+	w.Prober.findLinkedSegments(jobArgs.Path, result, timer)
+	result.SuggestedPreset = w.Prober.Presets.Suggest(result)
+	status := &internal.InfoJobStatus{Result: result}
+	w.Prober.runHook(ctx, jobArgs.UUID, jobArgs.Path, status, timer)
+	status.Timings = timer.timings
+	return status, info
+}
@@ -0,0 +1,195 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/krelinga/video-info/internal"
+	"github.com/krelinga/video-info/virest"
+)
+
+// pullPollInterval is how long a pull-mode worker waits before asking the
+// server for work again after finding none or failing to reach it.
+const pullPollInterval = 5 * time.Second
+
+// agentHeartbeatInterval is how often a pull-mode worker refreshes its
+// registration with the server.
+const agentHeartbeatInterval = time.Minute
+
+// runPull runs the worker in pull mode, claiming jobs from the server over
+// HTTP and pushing their outcome back until ctx is cancelled.
+func runPull(ctx context.Context, cfg *internal.WorkerConfig) error {
+	workerID, err := os.Hostname()
+	if err != nil {
+		return fmt.Errorf("failed to determine worker ID: %w", err)
+	}
+
+	client, err := virest.NewClientWithResponses(cfg.ServerURL.String(),
+		virest.WithHTTPClient(internal.NewHTTPClient(cfg.OutboundProxy, nil, 30*time.Second)),
+		virest.WithRequestEditorFn(func(ctx context.Context, req *http.Request) error {
+			req.Header.Set("Authorization", "Bearer "+cfg.WorkerToken)
+			return nil
+		}))
+	if err != nil {
+		return fmt.Errorf("failed to create server client: %w", err)
+	}
+
+	prober, err := NewProber(cfg)
+	if err != nil {
+		return err
+	}
+	if err := startCanary(ctx, cfg, prober); err != nil {
+		return err
+	}
+	var capacity int
+	for _, concurrency := range cfg.QueueConcurrency() {
+		capacity += concurrency
+	}
+	registration := virest.AgentRegistration{
+		Hostname:       workerID,
+		Mounts:         cfg.Mounts,
+		FfprobeVersion: prober.Versions.FFprobe,
+		Capacity:       capacity,
+		GpuCapacity:    &cfg.GPUCapacity,
+	}
+	if registration.Mounts == nil {
+		registration.Mounts = []string{}
+	}
+	if err := register(ctx, client, workerID, registration); err != nil {
+		return err
+	}
+	var heartbeats heartbeatMonitor
+	heartbeats.record(nil)
+	serveHealth(ctx, cfg.HealthPort, healthChecks{live: heartbeats.live, ready: heartbeats.ready})
+
+	log.Printf("Worker %s started in pull mode against %s, waiting for jobs...", workerID, cfg.ServerURL)
+
+	var wg sync.WaitGroup
+	wg.Go(func() {
+		// Refresh the registration so the server knows the worker is alive
+		ticker := time.NewTicker(agentHeartbeatInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				err := register(ctx, client, workerID, registration)
+				if err != nil {
+					log.Printf("Heartbeat failed: %v", err)
+				}
+				heartbeats.record(err)
+			}
+		}
+	})
+	cpu, gpu := virest.Cpu, virest.Gpu
+	for queue, concurrency := range cfg.QueueConcurrency() {
+		request := virest.WorkerClaimRequest{WorkerId: workerID, Resources: &cpu, Queue: &queue}
+		for range concurrency {
+			wg.Go(func() {
+				pullLoop(ctx, client, request, prober)
+			})
+		}
+	}
+	for range cfg.GPUCapacity {
+		request := virest.WorkerClaimRequest{WorkerId: workerID, Resources: &gpu}
+		wg.Go(func() {
+			pullLoop(ctx, client, request, prober)
+		})
+	}
+	wg.Wait()
+
+	log.Println("Worker shutdown complete")
+	return nil
+}
+
+// register registers the worker with the server, or refreshes its registration.
+func register(ctx context.Context, client *virest.ClientWithResponses, workerID string, registration virest.AgentRegistration) error {
+	resp, err := client.RegisterAgentWithResponse(ctx, workerID, registration)
+	if err != nil {
+		return fmt.Errorf("failed to register with server: %w", err)
+	}
+	if resp.JSON200 == nil {
+		return fmt.Errorf("failed to register with server: unexpected status %s", resp.Status())
+	}
+	return nil
+}
+
+// pullLoop claims and runs jobs matching request one at a time until ctx is
+// cancelled.
+func pullLoop(ctx context.Context, client *virest.ClientWithResponses, request virest.WorkerClaimRequest, prober *Prober) {
+	for {
+		worked, err := pullOne(ctx, client, request, prober)
+		if err != nil {
+			log.Printf("Pull failed: %v", err)
+		}
+		if worked && err == nil {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(pullPollInterval):
+		}
+	}
+}
+
+// pullOne claims and runs a single job.  It reports whether a job was claimed.
+func pullOne(ctx context.Context, client *virest.ClientWithResponses, request virest.WorkerClaimRequest, prober *Prober) (bool, error) {
+	claim, err := client.ClaimWorkerJobWithResponse(ctx, request)
+	if err != nil {
+		return false, fmt.Errorf("failed to claim job: %w", err)
+	}
+	if claim.StatusCode() == http.StatusNoContent {
+		return false, nil
+	}
+	if claim.JSON200 == nil {
+		return false, fmt.Errorf("failed to claim job: unexpected status %s", claim.Status())
+	}
+	job := claim.JSON200
+
+	analyses := internal.Analyses{
+		Crop:      job.AnalyzeCrop != nil && *job.AnalyzeCrop,
+		Loudness:  job.AnalyzeLoudness != nil && *job.AnalyzeLoudness,
+		Verify:    job.Verify != nil && *job.Verify,
+		Analyzers: job.Analyzers,
+		Raw:       job.IncludeRaw != nil && *job.IncludeRaw,
+	}
+	if job.SampleWindows != nil {
+		analyses.SampleWindows = *job.SampleWindows
+	}
+	if job.Checksum != nil {
+		analyses.Checksum = *job.Checksum
+	}
+	var timeout time.Duration
+	if job.TimeoutSeconds != nil {
+		timeout = time.Duration(*job.TimeoutSeconds) * time.Second
+	}
+	status := prober.Probe(ctx, job.Uuid, job.VideoPath, analyses, timeout)
+	if ctx.Err() != nil {
+		// Shutting down; the job will be reclaimed once its lease expires
+		return true, nil
+	}
+	outcome := virest.WorkerJobOutcome{
+		Attempt:   job.Attempt,
+		Result:    status.Result.RESTMediaInfo(),
+		Error:     status.Error,
+		ErrorCode: status.ErrorCode,
+		Timings:   internal.RESTPhaseTimings(status.Timings),
+	}
+
+	complete, err := client.CompleteWorkerJobWithResponse(ctx, job.JobId, outcome)
+	if err != nil {
+		return true, fmt.Errorf("failed to report job %d: %w", job.JobId, err)
+	}
+	if complete.StatusCode() != http.StatusNoContent {
+		return true, fmt.Errorf("failed to report job %d: unexpected status %s", job.JobId, complete.Status())
+	}
+	return true, nil
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"crypto/rand"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"path"
+	"time"
+)
+
+// rangeServer serves a remoteFile over HTTP on the loopback interface, so
+// that ffprobe reads only the byte ranges it needs for container metadata,
+// usually near the head and tail of the file, rather than the whole file
+// being copied first.
+type rangeServer struct {
+	file   *remoteFile
+	name   string
+	path   string
+	url    string
+	server *http.Server
+}
+
+// serveRanges starts a rangeServer for file, whose base name is name.  The
+// file is served at a random path, so that other local processes can't
+// guess it.
+func serveRanges(file *remoteFile, name string) (*rangeServer, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, fmt.Errorf("failed to start range server: %w", err)
+	}
+	// Keep the extension, which ffprobe uses to pick among similar formats
+	servedPath := "/" + rand.Text() + path.Ext(name)
+	s := &rangeServer{
+		file: file,
+		name: name,
+		path: servedPath,
+		url:  (&url.URL{Scheme: "http", Host: listener.Addr().String(), Path: servedPath}).String(),
+	}
+	s.server = &http.Server{Handler: s, ReadHeaderTimeout: 10 * time.Second}
+	go s.server.Serve(listener)
+	return s, nil
+}
+
+// ServeHTTP serves the file, honoring Range headers.  Each request reads
+// through its own section of the file, since ffprobe may read ranges over
+// several connections.
+func (s *rangeServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != s.path {
+		http.NotFound(w, r)
+		return
+	}
+	http.ServeContent(w, r, s.name, time.Time{}, io.NewSectionReader(s.file, 0, s.file.size))
+}
+
+// inputArgs returns the ffprobe arguments for reading the served file,
+// which only need plain HTTP.
+func (s *rangeServer) inputArgs() []string {
+	return []string{"-protocol_whitelist", "http,tcp"}
+}
+
+// Close stops serving the file.  It doesn't close the file.
+func (s *rangeServer) Close() error {
+	return s.server.Close()
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/krelinga/video-info/internal"
+	"github.com/riverqueue/river"
+)
+
+// reprobeCampaignInterval is how often the periodic job that advances
+// reprobe campaigns runs, matching the per-minute rate of campaigns.
+const reprobeCampaignInterval = time.Minute
+
+// ReprobeCampaignTickWorker enqueues the next reprobes of every running
+// reprobe campaign.
+type ReprobeCampaignTickWorker struct {
+	river.WorkerDefaults[internal.ReprobeCampaignTickArgs]
+	DBPool *pgxpool.Pool
+	Clock  internal.Clock
+
+	// Events, if set, publishes the creation of the reprobes.
+	Events *internal.EventPublisher
+}
+
+// Work advances the running reprobe campaigns.
+func (w *ReprobeCampaignTickWorker) Work(ctx context.Context, job *river.Job[internal.ReprobeCampaignTickArgs]) error {
+	client := river.ClientFromContext[pgx.Tx](ctx)
+	tx, err := w.DBPool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	enqueued, err := internal.AdvanceReprobeCampaigns(ctx, tx, client, internal.OrSystemClock(w.Clock).Now())
+	if err != nil {
+		return err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	w.Events.PublishCreated(enqueued...)
+	if len(enqueued) > 0 {
+		log.Printf("Enqueued %d reprobes for reprobe campaigns", len(enqueued))
+	}
+	return nil
+}
+
+// reprobeCampaignPeriodicJob enqueues the reprobe campaign tick job every
+// reprobeCampaignInterval while this worker is River's leader.
+func reprobeCampaignPeriodicJob() *river.PeriodicJob {
+	return river.NewPeriodicJob(
+		river.PeriodicInterval(reprobeCampaignInterval),
+		func() (river.JobArgs, *river.InsertOpts) {
+			return internal.ReprobeCampaignTickArgs{}, nil
+		},
+		&river.PeriodicJobOpts{RunOnStart: true},
+	)
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/krelinga/video-info/internal"
+	"github.com/riverqueue/river"
+)
+
+// resultRetentionInterval is how often the periodic result retention job
+// runs.
+const resultRetentionInterval = time.Hour
+
+// ResultRetentionWorker prunes results older than TTL.  Like
+// PriorityAgingWorker, it does nothing if TTL is not positive.
+type ResultRetentionWorker struct {
+	river.WorkerDefaults[internal.ResultRetentionArgs]
+	DBPool *pgxpool.Pool
+	TTL    time.Duration
+	Clock  internal.Clock
+}
+
+// Work prunes old results.
+func (w *ResultRetentionWorker) Work(ctx context.Context, job *river.Job[internal.ResultRetentionArgs]) error {
+	pruned, err := internal.PruneResults(ctx, w.DBPool, internal.OrSystemClock(w.Clock).Now(), w.TTL)
+	if err != nil {
+		return err
+	}
+	if pruned != (internal.PrunedResults{}) {
+		log.Printf("Pruned %d info jobs, %d stored results, %d undelivered webhook records, %d cached results, %d comparisons and %d webhook batch items older than %s",
+			pruned.InfoJobs, pruned.StoredResults, pruned.DeadLetters, pruned.CachedResults, pruned.Comparisons, pruned.WebhookBatchItems, w.TTL)
+	}
+	return nil
+}
+
+// resultRetentionPeriodicJob enqueues the result retention job every
+// resultRetentionInterval while this worker is River's leader.
+func resultRetentionPeriodicJob() *river.PeriodicJob {
+	return river.NewPeriodicJob(
+		river.PeriodicInterval(resultRetentionInterval),
+		func() (river.JobArgs, *river.InsertOpts) {
+			return internal.ResultRetentionArgs{}, nil
+		},
+		&river.PeriodicJobOpts{RunOnStart: true},
+	)
+}
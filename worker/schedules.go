@@ -0,0 +1,211 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/krelinga/video-info/internal"
+	"github.com/riverqueue/river"
+)
+
+// scheduleTickInterval is how often the periodic job that starts due
+// schedules runs, matching the resolution of cron expressions.
+const scheduleTickInterval = time.Minute
+
+// scanBatchSize bounds the info jobs a scan enqueues per transaction.
+const scanBatchSize = 500
+
+// scanTimeout bounds how long a scan may run.  Walking a large network share
+// takes far longer than River's default timeout.
+const scanTimeout = time.Hour
+
+// ScheduleTickWorker enqueues a scan job for each schedule that is due.
+type ScheduleTickWorker struct {
+	river.WorkerDefaults[internal.ScheduleTickArgs]
+	DBPool *pgxpool.Pool
+	Clock  internal.Clock
+}
+
+// Work starts the scans of due schedules.
+func (w *ScheduleTickWorker) Work(ctx context.Context, job *river.Job[internal.ScheduleTickArgs]) error {
+	client := river.ClientFromContext[pgx.Tx](ctx)
+	tx, err := w.DBPool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	started, err := internal.StartDueSchedules(ctx, tx, client, internal.OrSystemClock(w.Clock).Now())
+	if err != nil {
+		return err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	if started > 0 {
+		log.Printf("Started %d scheduled scans", started)
+	}
+	return nil
+}
+
+// scheduleTickPeriodicJob enqueues the schedule tick job every
+// scheduleTickInterval while this worker is River's leader.
+func scheduleTickPeriodicJob() *river.PeriodicJob {
+	return river.NewPeriodicJob(
+		river.PeriodicInterval(scheduleTickInterval),
+		func() (river.JobArgs, *river.InsertOpts) {
+			return internal.ScheduleTickArgs{}, nil
+		},
+		&river.PeriodicJobOpts{RunOnStart: true},
+	)
+}
+
+// ScanWorker enqueues an info job for every video file under a scheduled
+// directory, skipping files whose previous job hasn't finished, files newer
+// than the schedule's minimum age, unless the schedule forces probing,
+// files whose result is cached and, for incremental schedules, files
+// unchanged since the schedule's catalog recorded them.
+type ScanWorker struct {
+	river.WorkerDefaults[internal.ScanArgs]
+	DBPool *pgxpool.Pool
+	Clock  internal.Clock
+
+	// CacheSettings are those of the workers that probe the files, under
+	// which their cached results are looked up.
+	CacheSettings internal.ProbeCacheSettings
+
+	// Events, if set, publishes the creation of the info jobs.
+	Events *internal.EventPublisher
+}
+
+// Timeout allows scans of large directories to finish.
+func (w *ScanWorker) Timeout(*river.Job[internal.ScanArgs]) time.Duration {
+	return scanTimeout
+}
+
+// Work scans the directory and enqueues its video files, or for a dry run,
+// records what it would have enqueued.  A retried scan resumes after the
+// last batch its previous attempts enqueued.  If the scan skips files as
+// too new, it schedules a follow-up scan for when they are old enough.
+func (w *ScanWorker) Work(ctx context.Context, job *river.Job[internal.ScanArgs]) error {
+	now := internal.OrSystemClock(w.Clock).Now()
+	if job.Args.DryRun {
+		files, err := internal.ScanDirectory(job.Args.Directory, "", job.Args.Exclude)
+		if err != nil {
+			return err
+		}
+		return w.preview(ctx, job.Args, files, now)
+	}
+
+	progress, err := internal.ScanCheckpoint(job.Metadata)
+	if err != nil {
+		return err
+	}
+	files, err := internal.ScanDirectory(job.Args.Directory, progress.AfterPath, job.Args.Exclude)
+	if err != nil {
+		return err
+	}
+
+	client := river.ClientFromContext[pgx.Tx](ctx)
+	for start := 0; start < len(files); start += scanBatchSize {
+		batch := files[start:min(start+scanBatchSize, len(files))]
+		if err := w.enqueue(ctx, client, job, batch, now, &progress); err != nil {
+			return err
+		}
+	}
+	if err := w.scheduleFollowUp(ctx, client, job, &progress); err != nil {
+		return err
+	}
+	if err := river.RecordOutput(ctx, progress); err != nil {
+		return fmt.Errorf("failed to record scan progress: %w", err)
+	}
+	log.Printf("Scan of %s for schedule %s found %d video files, enqueued %d info jobs and skipped %d files",
+		job.Args.Directory, job.Args.ScheduleID, progress.Found, progress.Enqueued, progress.Found-progress.Enqueued)
+	return nil
+}
+
+// enqueue enqueues info jobs for one batch of scanned files, and checkpoints
+// progress, updated with the batch, in the metadata of the scan job.
+func (w *ScanWorker) enqueue(ctx context.Context, client *river.Client[pgx.Tx], job *river.Job[internal.ScanArgs], files []internal.ScannedFile, now time.Time, progress *internal.ScanProgress) error {
+	tx, err := w.DBPool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	inserted, err := internal.EnqueueScannedFiles(ctx, tx, client, job.Args, files, now, w.CacheSettings, progress)
+	if err != nil {
+		return err
+	}
+	if err := internal.CheckpointScan(ctx, tx, job.ID, *progress); err != nil {
+		return err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	w.Events.PublishCreated(inserted...)
+	return nil
+}
+
+// scheduleFollowUp schedules the follow-up scan that progress calls for, if
+// any, and checkpoints that it has been scheduled so that a retry doesn't
+// schedule another.
+func (w *ScanWorker) scheduleFollowUp(ctx context.Context, client *river.Client[pgx.Tx], job *river.Job[internal.ScanArgs], progress *internal.ScanProgress) error {
+	args, opts, ok := progress.FollowUp(job.Args)
+	if !ok {
+		return nil
+	}
+	tx, err := w.DBPool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := client.InsertTx(ctx, tx, args, opts); err != nil {
+		return fmt.Errorf("failed to insert follow-up scan: %w", err)
+	}
+	followUpAt := opts.ScheduledAt
+	progress.FollowUpAt = &followUpAt
+	if err := internal.CheckpointScan(ctx, tx, job.ID, *progress); err != nil {
+		return err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	log.Printf("Scan of %s for schedule %s skipped %d files as too new, and will scan again at %s",
+		job.Args.Directory, job.Args.ScheduleID, progress.Skipped[internal.ScanSkipTooNew], followUpAt.Format(time.RFC3339))
+	return nil
+}
+
+// preview records a ScanPreview of the scanned files, as the scan with the
+// given arguments would find them at now, as the job's output.
+func (w *ScanWorker) preview(ctx context.Context, args internal.ScanArgs, files []internal.ScannedFile, now time.Time) error {
+	var preview internal.ScanPreview
+	for start := 0; start < len(files); start += scanBatchSize {
+		batch := files[start:min(start+scanBatchSize, len(files))]
+		skip, err := w.skipReasons(ctx, args, batch, now)
+		if err != nil {
+			return err
+		}
+		preview.Add(batch, skip)
+	}
+	if err := river.RecordOutput(ctx, preview); err != nil {
+		return fmt.Errorf("failed to record scan preview: %w", err)
+	}
+	log.Printf("Dry run of scan found %d video files and would enqueue %d info jobs", preview.Found, preview.WouldEnqueue)
+	return nil
+}
+
+// skipReasons finds why a scan would skip the files of one batch.
+func (w *ScanWorker) skipReasons(ctx context.Context, args internal.ScanArgs, files []internal.ScannedFile, now time.Time) (map[string]string, error) {
+	tx, err := w.DBPool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+	return internal.ScanSkipReasons(ctx, tx, args, files, now, w.CacheSettings)
+}
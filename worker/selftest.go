@@ -0,0 +1,169 @@
+package main
+
+import (
+	"context"
+	_ "embed"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/krelinga/video-info/internal"
+	"github.com/krelinga/video-info/virest"
+)
+
+// selfTestSample is a tiny video the self-test probes to check that ffprobe
+// works.  It is the start of the sample in testdata.
+//
+//go:embed selftest.mkv
+var selfTestSample []byte
+
+// selfTestTimeout bounds each check made by the self-test.
+const selfTestTimeout = 30 * time.Second
+
+// selfTestCheck is one check made by the self-test.
+type selfTestCheck struct {
+	name string
+	run  func(ctx context.Context) error
+}
+
+// runSelfTest checks that the worker can probe video, reach its database or,
+// in pull mode, the server, and read the directories it is configured with.
+// It reports the outcome of each check on w and fails if any did.
+func runSelfTest(ctx context.Context, cfg *internal.WorkerConfig, w io.Writer) error {
+	prober, err := NewProber(cfg)
+	if err != nil {
+		return err
+	}
+
+	checks := []selfTestCheck{{
+		name: "probe bundled sample",
+		run: func(ctx context.Context) error {
+			return selfTestProbe(ctx, prober)
+		},
+	}}
+	if cfg.ServerURL != nil {
+		checks = append(checks, selfTestCheck{
+			name: "reach server at " + cfg.ServerURL.Redacted(),
+			run: func(ctx context.Context) error {
+				return selfTestServer(ctx, cfg)
+			},
+		})
+	} else {
+		checks = append(checks, selfTestCheck{
+			name: "reach database",
+			run: func(ctx context.Context) error {
+				return selfTestDatabase(ctx, cfg.Database)
+			},
+		})
+	}
+	for _, dir := range selfTestDirs(cfg) {
+		checks = append(checks, selfTestCheck{
+			name: "read " + dir,
+			run: func(context.Context) error {
+				return selfTestDir(dir)
+			},
+		})
+	}
+
+	var failed int
+	for _, check := range checks {
+		checkCtx, cancel := context.WithTimeout(ctx, selfTestTimeout)
+		err := check.run(checkCtx)
+		cancel()
+		if err != nil {
+			failed++
+			fmt.Fprintf(w, "FAIL  %s: %s\n", check.name, internal.Redact(err.Error()))
+		} else {
+			fmt.Fprintf(w, "ok    %s\n", check.name)
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("self-test failed %d of %d checks", failed, len(checks))
+	}
+	fmt.Fprintf(w, "Self-test passed %d checks\n", len(checks))
+	return nil
+}
+
+// selfTestProbe probes the bundled sample with prober, bypassing its cache,
+// hook and allowed roots, and checks that a video stream was found.
+func selfTestProbe(ctx context.Context, prober *Prober) error {
+	f, err := os.CreateTemp("", "video-info-self-test-*.mkv")
+	if err != nil {
+		return fmt.Errorf("failed to create sample file: %w", err)
+	}
+	defer os.Remove(f.Name())
+	_, err = f.Write(selfTestSample)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write sample file: %w", err)
+	}
+
+	sample := *prober
+	sample.AllowedRoots = nil
+	sample.Cache = nil
+	sample.Hook = nil
+	status := sample.Probe(ctx, uuid.New(), f.Name(), internal.Analyses{}, selfTestTimeout)
+	if status.Error != nil {
+		return errors.New(*status.Error)
+	}
+	if len(status.Result.VideoStreams) == 0 {
+		return errors.New("no video stream found in sample")
+	}
+	return nil
+}
+
+// selfTestDatabase connects to the database and pings it.
+func selfTestDatabase(ctx context.Context, cfg *internal.DatabaseConfig) error {
+	pool, err := internal.NewDBPool(ctx, cfg)
+	if err != nil {
+		return fmt.Errorf("failed to create database pool: %w", err)
+	}
+	defer pool.Close()
+	return pool.Ping(ctx)
+}
+
+// selfTestServer checks that the server is reachable and ready.
+func selfTestServer(ctx context.Context, cfg *internal.WorkerConfig) error {
+	client, err := virest.NewClientWithResponses(cfg.ServerURL.String(),
+		virest.WithHTTPClient(internal.NewHTTPClient(cfg.OutboundProxy, nil, selfTestTimeout)))
+	if err != nil {
+		return fmt.Errorf("failed to create server client: %w", err)
+	}
+	resp, err := client.GetReadinessWithResponse(ctx)
+	if err != nil {
+		return err
+	}
+	if resp.JSON503 != nil {
+		return fmt.Errorf("server not ready: %s", resp.JSON503.Message)
+	}
+	if resp.JSON200 == nil {
+		return fmt.Errorf("unexpected status %s", resp.Status())
+	}
+	return nil
+}
+
+// selfTestDirs returns the directories the worker is configured to read.
+func selfTestDirs(cfg *internal.WorkerConfig) []string {
+	dirs := slices.Concat(cfg.Mounts, cfg.AllowedRoots)
+	slices.Sort(dirs)
+	return slices.Compact(dirs)
+}
+
+// selfTestDir checks that dir is a directory whose entries can be listed.
+func selfTestDir(dir string) error {
+	f, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	if _, err := f.ReadDir(1); err != nil && !errors.Is(err, io.EOF) {
+		return err
+	}
+	return nil
+}
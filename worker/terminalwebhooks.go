@@ -0,0 +1,59 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/krelinga/video-info/internal"
+	"github.com/riverqueue/river"
+)
+
+// terminalWebhooksInterval is how often the periodic job that notifies
+// cancelled and expired info jobs runs.
+const terminalWebhooksInterval = time.Minute
+
+// TerminalWebhooksWorker enqueues the webhooks of info jobs that were
+// cancelled or that River gave up on, which don't enqueue their own.
+type TerminalWebhooksWorker struct {
+	river.WorkerDefaults[internal.TerminalWebhooksArgs]
+	DBPool *pgxpool.Pool
+	Clock  internal.Clock
+}
+
+// Work enqueues the webhooks of a batch of finished info jobs.
+func (w *TerminalWebhooksWorker) Work(ctx context.Context, job *river.Job[internal.TerminalWebhooksArgs]) error {
+	client := river.ClientFromContext[pgx.Tx](ctx)
+	tx, err := w.DBPool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	enqueued, err := internal.EnqueueTerminalWebhooks(ctx, tx, client, internal.OrSystemClock(w.Clock).Now())
+	if err != nil {
+		return err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	if enqueued > 0 {
+		log.Printf("Enqueued %d webhooks for cancelled or failed info jobs", enqueued)
+	}
+	return nil
+}
+
+// terminalWebhooksPeriodicJob enqueues the terminal webhooks job every
+// terminalWebhooksInterval while this worker is River's leader.
+func terminalWebhooksPeriodicJob() *river.PeriodicJob {
+	return river.NewPeriodicJob(
+		river.PeriodicInterval(terminalWebhooksInterval),
+		func() (river.JobArgs, *river.InsertOpts) {
+			return internal.TerminalWebhooksArgs{}, nil
+		},
+		&river.PeriodicJobOpts{RunOnStart: true},
+	)
+}
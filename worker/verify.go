@@ -0,0 +1,31 @@
+package main
+
+import (
+	"fmt"
+	"os/exec"
+
+	"github.com/krelinga/video-info/internal"
+)
+
+// addVerification adds every video and audio stream to d, collecting the
+// errors ffmpeg logs while decoding them.  Progress is written among the
+// errors so each can be placed in the file.  The windows of a sampled pass
+// are decoded side by side, so its errors can't be placed.
+func (p *Prober) addVerification(d *deepPass, result *internal.InfoJobResult) deepAnalysis {
+	sampled := d.sampled()
+	d.progress = !sampled
+	d.decode("V?")
+	d.decode("a?")
+
+	return deepAnalysis{name: "decode verification", finish: func(output string, exitErr *exec.ExitError) error {
+		verification := internal.ParseDecodeErrors(output, exitErr == nil)
+		if exitErr != nil && verification.ErrorCount == 0 {
+			return fmt.Errorf("ffmpeg failed without logging an error: %w", exitErr)
+		}
+		if sampled && verification.Complete {
+			verification.DecodedSeconds = d.sampledSeconds(result.DurationSeconds)
+		}
+		result.Verification = verification
+		return nil
+	}}
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/krelinga/video-info/internal"
+	"github.com/tetratelabs/wazero"
+	"github.com/tetratelabs/wazero/imports/wasi_snapshot_preview1"
+)
+
+// wasmMemoryLimitPages caps the memory of each WASI analyzer at 256 MiB.
+const wasmMemoryLimitPages = 4096
+
+// wasmFramesDir is where sampled frames are mounted for WASI analyzers.
+const wasmFramesDir = "/frames"
+
+// wasmAnalyzers runs analyzer plugins that are WASI modules.  Modules get no
+// environment, clock, network or filesystem beyond their sampled frames, and
+// are stopped when their context is done.
+type wasmAnalyzers struct {
+	runtime wazero.Runtime
+	modules map[string]wazero.CompiledModule
+}
+
+// newWasmAnalyzers compiles the WASI modules among plugins, or returns nil if
+// there are none.
+func newWasmAnalyzers(ctx context.Context, plugins *internal.AnalyzerPlugins) (*wasmAnalyzers, error) {
+	wasm := plugins.Wasm()
+	if len(wasm) == 0 {
+		return nil, nil
+	}
+	runtime := wazero.NewRuntimeWithConfig(ctx, wazero.NewRuntimeConfig().
+		WithCloseOnContextDone(true).
+		WithMemoryLimitPages(wasmMemoryLimitPages))
+	if _, err := wasi_snapshot_preview1.Instantiate(ctx, runtime); err != nil {
+		runtime.Close(ctx)
+		return nil, fmt.Errorf("failed to instantiate WASI: %w", err)
+	}
+	w := &wasmAnalyzers{runtime: runtime, modules: make(map[string]wazero.CompiledModule, len(wasm))}
+	for _, plugin := range wasm {
+		code, err := os.ReadFile(plugin.Wasm)
+		if err != nil {
+			runtime.Close(ctx)
+			return nil, fmt.Errorf("failed to read analyzer %s: %w", plugin.Name, err)
+		}
+		w.modules[plugin.Name], err = runtime.CompileModule(ctx, code)
+		if err != nil {
+			runtime.Close(ctx)
+			return nil, fmt.Errorf("failed to compile analyzer %s: %w", plugin.Name, err)
+		}
+	}
+	return w, nil
+}
+
+// run runs the module of plugin with input on its standard input, and
+// framesDir, if set, mounted read-only at wasmFramesDir.  It returns the
+// module's standard output, of which at most maxOutput bytes are accepted.
+func (w *wasmAnalyzers) run(ctx context.Context, plugin internal.AnalyzerPlugin, input []byte, framesDir string, maxOutput int) ([]byte, error) {
+	compiled, ok := w.modules[plugin.Name]
+	if !ok {
+		return nil, fmt.Errorf("analyzer %s is not a WASI module", plugin.Name)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, plugin.Timeout())
+	defer cancel()
+
+	stdout := &limitedBuffer{max: maxOutput}
+	stderr := &limitedBuffer{max: maxExternalStderr}
+	// Anonymous modules can be instantiated concurrently
+	config := wazero.NewModuleConfig().
+		WithName("").
+		WithArgs(plugin.Name).
+		WithStdin(bytes.NewReader(input)).
+		WithStdout(stdout).
+		WithStderr(stderr)
+	if framesDir != "" {
+		config = config.WithFSConfig(wazero.NewFSConfig().WithReadOnlyDirMount(framesDir, wasmFramesDir))
+	}
+
+	module, err := w.runtime.InstantiateModule(ctx, compiled, config)
+	if err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			return nil, fmt.Errorf("timed out after %s", plugin.Timeout())
+		}
+		return nil, fmt.Errorf("%w: %s", err, lastLine(stderr.String()))
+	}
+	module.Close(ctx)
+	if stdout.truncated {
+		return nil, fmt.Errorf("%w: over %d bytes", errExternalOutputTooLarge, maxOutput)
+	}
+	return stdout.Bytes(), nil
+}
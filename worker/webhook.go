@@ -4,59 +4,389 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"net/http"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/krelinga/video-info/internal"
 	"github.com/krelinga/video-info/virest"
 	"github.com/riverqueue/river"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
 )
 
 // WebhookPayload is the JSON body sent to the webhook URI.
 type WebhookPayload struct {
-	Token  []byte            `json:"token,omitempty"`
-	Uuid   uuid.UUID         `json:"uuid"`
-	Result *virest.VideoInfo `json:"result,omitempty"`
-	Error  *string           `json:"error,omitempty"`
+	Token        []byte                `json:"token,omitempty"`
+	Uuid         uuid.UUID             `json:"uuid"`
+	Status       string                `json:"status"`
+	ExternalID   *string               `json:"externalId,omitempty"`
+	Labels       map[string]string     `json:"labels,omitempty"`
+	Result       *virest.MediaInfo     `json:"result,omitempty"`
+	Error        *string               `json:"error,omitempty"`
+	ErrorCode    *string               `json:"errorCode,omitempty"`
+	SignedResult *virest.SignedPayload `json:"signedResult,omitempty"`
+
+	// Changes is set when the video path was probed successfully before,
+	// so consumers can skip files whose result didn't change.
+	Changes *WebhookChanges `json:"changes,omitempty"`
+}
+
+// WebhookBatchPayload is the JSON body sent to the webhook URI for batched
+// webhooks.  The token is shared by every notification, so it is sent once.
+type WebhookBatchPayload struct {
+	Token         []byte           `json:"token,omitempty"`
+	Notifications []WebhookPayload `json:"notifications"`
 }
 
+// WebhookChanges compares a result with the previous result for the same
+// video path.
+type WebhookChanges struct {
+	PreviousUuid  uuid.UUID `json:"previousUuid"`
+	ChangedFields []string  `json:"changedFields"`
+}
+
+// defaultWebhookTimeout bounds delivery attempts when no retry policy sets a
+// timeout.
+const defaultWebhookTimeout = 30 * time.Second
+
 // WebhookWorker handles webhook notification jobs.
 type WebhookWorker struct {
 	river.WorkerDefaults[internal.WebhookJobArgs]
+	DBPool     *pgxpool.Pool
 	HTTPClient *http.Client
+	URLPolicy  *internal.URLPolicy
+	Signer     *internal.Signer
+	Keyring    *internal.Keyring
+
+	// RetryPolicy applies to jobs that don't set their own.
+	RetryPolicy internal.WebhookRetryPolicy
+
+	// RateLimits caps the rate of deliveries to each target.  Like
+	// breakers, they are disabled if DBPool is nil.
+	RateLimits internal.WebhookRateLimits
+
+	// Breaker pauses deliveries to failing targets.  Breakers are kept in
+	// the database, so they are disabled if DBPool is nil.
+	Breaker internal.WebhookBreakerPolicy
+
+	Metrics *WebhookMetrics
+
+	// Clock times retries and delivery latency.  Nil means the system
+	// clock.
+	Clock internal.Clock
+}
+
+// WebhookMetrics records the final outcome of webhook deliveries per target.
+// A nil WebhookMetrics records nothing.
+type WebhookMetrics struct {
+	deliveries metric.Int64Counter
+	latency    metric.Float64Histogram
+}
+
+// NewWebhookMetrics creates the webhook delivery instruments from meter.
+func NewWebhookMetrics(meter metric.Meter) (*WebhookMetrics, error) {
+	deliveries, err := meter.Int64Counter("video_info.webhook.deliveries",
+		metric.WithDescription("Webhook deliveries by final outcome, delivered or undelivered"),
+		metric.WithUnit("{delivery}"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create webhook delivery counter: %w", err)
+	}
+	latency, err := meter.Float64Histogram("video_info.webhook.latency",
+		metric.WithDescription("Time from info job completion to webhook delivery acknowledgement"),
+		metric.WithUnit("s"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create webhook latency histogram: %w", err)
+	}
+	return &WebhookMetrics{deliveries: deliveries, latency: latency}, nil
+}
+
+func (m *WebhookMetrics) recordDelivered(ctx context.Context, target string, latency time.Duration) {
+	if m == nil {
+		return
+	}
+	attrs := metric.WithAttributes(attribute.String("target", target))
+	m.deliveries.Add(ctx, 1, attrs, metric.WithAttributes(attribute.String("outcome", "delivered")))
+	m.latency.Record(ctx, latency.Seconds(), attrs)
+}
+
+func (m *WebhookMetrics) recordUndelivered(ctx context.Context, target string) {
+	if m == nil {
+		return
+	}
+	m.deliveries.Add(ctx, 1, metric.WithAttributes(
+		attribute.String("target", target),
+		attribute.String("outcome", "undelivered")))
+}
+
+// NextRetry backs off exponentially as configured by the job's retry policy,
+// deferring to River's default when it sets no backoff.
+func (w *WebhookWorker) NextRetry(job *river.Job[internal.WebhookJobArgs]) time.Time {
+	backoff := job.Args.RetryPolicy(w.RetryPolicy).Backoff(job.Attempt)
+	if backoff == 0 {
+		return time.Time{}
+	}
+	return internal.OrSystemClock(w.Clock).Now().Add(backoff)
+}
+
+// Timeout bounds each delivery attempt as configured by the job's retry
+// policy.
+func (w *WebhookWorker) Timeout(job *river.Job[internal.WebhookJobArgs]) time.Duration {
+	if timeout := job.Args.RetryPolicy(w.RetryPolicy).Timeout(); timeout > 0 {
+		return timeout
+	}
+	return defaultWebhookTimeout
 }
 
 // Work executes the webhook notification job by POSTing to the configured URI.
+// Errors are redacted since they are stored with the job.  Deliveries that
+// fail for the last time are recorded in the webhook_dead_letter table.
 func (w *WebhookWorker) Work(ctx context.Context, job *river.Job[internal.WebhookJobArgs]) error {
+	var (
+		target      string
+		deliveredAt time.Time
+		err         error
+	)
+	if job.Args.Batch != nil && w.DBPool != nil {
+		target, deliveredAt, err = w.deliverBatch(ctx, job)
+	} else {
+		target, err = w.deliver(ctx, job)
+		deliveredAt = internal.OrSystemClock(w.Clock).Now()
+	}
+	var snoozeErr *river.JobSnoozeError
+	if errors.As(err, &snoozeErr) {
+		return err
+	}
+	if err == nil {
+		// Webhook jobs are enqueued as the info job finishes
+		latency := deliveredAt.Sub(job.CreatedAt)
+		w.Metrics.recordDelivered(ctx, target, latency)
+		if err := river.RecordOutput(ctx, internal.WebhookOutput{Target: target, LatencySeconds: latency.Seconds()}); err != nil {
+			log.Printf("Failed to record output for webhook job %d: %v", job.ID, err)
+		}
+		return nil
+	}
+	err = internal.RedactError(err)
+
+	// River gives up on cancelled jobs and on its own attempt limit, but a
+	// lower limit set by the worker's policy must be enforced here
+	var cancelErr *river.JobCancelError
+	cancelled := errors.As(err, &cancelErr)
+	policy := job.Args.RetryPolicy(w.RetryPolicy)
+	exhausted := policy.MaxAttempts > 0 && job.Attempt >= policy.MaxAttempts
+	if !cancelled && !exhausted && job.Attempt < job.MaxAttempts {
+		return err
+	}
+
+	w.Metrics.recordUndelivered(ctx, target)
+	if recordErr := w.recordDeadLetter(ctx, job, target, err); recordErr != nil {
+		log.Printf("Failed to record undelivered webhook for job %d: %v", job.ID, recordErr)
+	}
+	if !cancelled && job.Attempt < job.MaxAttempts {
+		return river.JobCancel(err)
+	}
+	return err
+}
+
+// recordDeadLetter records that the webhook job will not be delivered.  It
+// runs even if ctx expired, as happens when the final attempt timed out.
+func (w *WebhookWorker) recordDeadLetter(ctx context.Context, job *river.Job[internal.WebhookJobArgs], target string, deliveryErr error) error {
+	if w.DBPool == nil {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 10*time.Second)
+	defer cancel()
+	_, err := w.DBPool.Exec(ctx, `
+		INSERT INTO webhook_dead_letter (river_job_id, info_uuid, target, attempts, error)
+		VALUES ($1, $2, $3, $4, $5)`,
+		job.ID, job.Args.Uuid, target, job.Attempt, deliveryErr.Error())
+	return err
+}
+
+// deliver sends the webhook, returning its target as reported by
+// internal.WebhookTarget, or "" if the URI couldn't be recovered.
+func (w *WebhookWorker) deliver(ctx context.Context, job *river.Job[internal.WebhookJobArgs]) (string, error) {
+	secrets, target, err := w.openSecrets(ctx, job.Args)
+	if err != nil {
+		return target, err
+	}
+	return target, w.send(ctx, job, target, secrets, webhookPayload(job.Args, secrets.Token))
+}
+
+// deliverBatch sends the webhook together with undelivered webhooks in the
+// same batch, returning its target and when it was delivered.  If another
+// job already delivered the webhook in its batch, nothing is sent.
+func (w *WebhookWorker) deliverBatch(ctx context.Context, job *river.Job[internal.WebhookJobArgs]) (string, time.Time, error) {
+	tx, err := w.DBPool.Begin(ctx)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	deliveredAt, deliveredTarget, err := internal.LockWebhookBatchItem(ctx, tx, job.ID)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	if deliveredAt != nil {
+		if err := internal.DeleteWebhookBatchItem(ctx, tx, job.ID); err != nil {
+			return deliveredTarget, time.Time{}, err
+		}
+		if err := tx.Commit(ctx); err != nil {
+			return deliveredTarget, time.Time{}, fmt.Errorf("failed to commit transaction: %w", err)
+		}
+		return deliveredTarget, *deliveredAt, nil
+	}
+
+	secrets, target, err := w.openSecrets(ctx, job.Args)
+	if err != nil {
+		return target, time.Time{}, err
+	}
+	// Webhooks in the batch share a URI, token and headers, so this job's
+	// are used
+	items, err := internal.ClaimWebhookBatch(ctx, tx, job.ID, job.Args.Batch.Key, job.Args.Batch.MaxSize-1)
+	if err != nil {
+		return target, time.Time{}, err
+	}
+	payload := WebhookBatchPayload{
+		Token:         secrets.Token,
+		Notifications: []WebhookPayload{webhookPayload(job.Args, nil)},
+	}
+	jobIDs := make([]int64, len(items))
+	for i, item := range items {
+		payload.Notifications = append(payload.Notifications, webhookPayload(item.Args, nil))
+		jobIDs[i] = item.JobID
+	}
+	if err := w.send(ctx, job, target, secrets, payload); err != nil {
+		return target, time.Time{}, err
+	}
+
+	now := internal.OrSystemClock(w.Clock).Now()
+	if err := internal.MarkWebhookBatchDelivered(ctx, tx, job.ID, jobIDs, target, now); err != nil {
+		return target, time.Time{}, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return target, time.Time{}, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	if len(items) > 0 {
+		log.Printf("Delivered %d batched webhooks with webhook job %d", len(items)+1, job.ID)
+	}
+	return target, now, nil
+}
+
+// openSecrets returns the webhook URI, token and headers of args, and the
+// target of the URI as reported by internal.WebhookTarget, or "" if the URI
+// couldn't be recovered.
+func (w *WebhookWorker) openSecrets(ctx context.Context, args internal.WebhookJobArgs) (internal.WebhookSecrets, string, error) {
+	secrets, err := args.Secrets(w.Keyring)
+	if err != nil {
+		return internal.WebhookSecrets{}, "", fmt.Errorf("failed to open webhook secrets: %w", err)
+	}
+	target := internal.WebhookTarget(secrets.URI)
+
+	// Re-check the target in case the policy changed since the job was created
+	if err := w.URLPolicy.CheckString(ctx, secrets.URI); err != nil {
+		return secrets, target, river.JobCancel(fmt.Errorf("webhook URI rejected: %w", err))
+	}
+	return secrets, target, nil
+}
+
+// webhookPayload returns the notification for the webhook job with args.
+func webhookPayload(args internal.WebhookJobArgs, token []byte) WebhookPayload {
 	payload := WebhookPayload{
-		Token: job.Args.Token,
-		Uuid:  job.Args.Uuid,
+		Token:      token,
+		Uuid:       args.Uuid,
+		Status:     args.NotificationStatus(),
+		ExternalID: args.ExternalID,
+		Labels:     args.Labels,
+	}
+	if args.Status != nil {
+		payload.Result = args.Status.Result.RESTMediaInfo()
+		payload.Error = args.Status.Error
+		payload.ErrorCode = args.Status.ErrorCode
+		payload.SignedResult = args.Status.Signed.RESTSignedPayload()
+	}
+	if args.Changes != nil {
+		payload.Changes = &WebhookChanges{
+			PreviousUuid:  args.Changes.PreviousUUID,
+			ChangedFields: args.Changes.ChangedFields,
+		}
+	}
+	return payload
+}
+
+// send posts payload as secrets describe, unless target's rate limit has
+// been reached or its circuit breaker is open, in which case the job is
+// snoozed until it may be delivered.
+func (w *WebhookWorker) send(ctx context.Context, job *river.Job[internal.WebhookJobArgs], target string, secrets internal.WebhookSecrets, payload any) error {
+	if w.DBPool == nil || target == "" {
+		return w.post(ctx, secrets, payload)
 	}
-	if job.Args.Status != nil {
-		payload.Result = job.Args.Status.Result.RESTVideoInfo()
-		payload.Error = job.Args.Status.Error
+	clock := internal.OrSystemClock(w.Clock)
+	if perMinute := w.RateLimits.PerMinute(target); perMinute > 0 {
+		wait, err := internal.ReserveWebhookDelivery(ctx, w.DBPool, target, perMinute, job.ID, clock.Now())
+		if err != nil {
+			return err
+		}
+		if wait > 0 {
+			// The job keeps the slot it reserved while it waits
+			return river.JobSnooze(wait)
+		}
+	}
+	if !w.Breaker.Enabled() {
+		return w.post(ctx, secrets, payload)
+	}
+
+	retryAt, err := internal.AcquireWebhookBreaker(ctx, w.DBPool, target, clock.Now(), w.Timeout(job))
+	if err != nil {
+		return err
+	}
+	if !retryAt.IsZero() {
+		// Snoozing doesn't use up an attempt
+		return river.JobSnooze(internal.WebhookBreakerJitter(max(retryAt.Sub(clock.Now()), time.Second)))
 	}
 
+	err = w.post(ctx, secrets, payload)
+	// Record the outcome even if the delivery timed out
+	recordCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 10*time.Second)
+	defer cancel()
+	if recordErr := internal.RecordWebhookDelivery(recordCtx, w.DBPool, w.Breaker, target, err == nil, clock.Now()); recordErr != nil {
+		log.Printf("Failed to update circuit breaker of %s: %v", target, recordErr)
+	}
+	return err
+}
+
+// post sends payload as JSON to the webhook URI, with the webhook's headers.
+func (w *WebhookWorker) post(ctx context.Context, secrets internal.WebhookSecrets, payload any) error {
 	body, err := json.Marshal(payload)
 	if err != nil {
 		return fmt.Errorf("failed to marshal webhook payload: %w", err)
 	}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, job.Args.URI, bytes.NewReader(body))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, secrets.URI, bytes.NewReader(body))
 	if err != nil {
 		return fmt.Errorf("failed to create webhook request: %w", err)
 	}
+	for name, value := range secrets.Headers {
+		req.Header.Set(name, value)
+	}
 	req.Header.Set("Content-Type", "application/json")
+	if signature := w.Signer.SignatureHeaderValue(body); signature != "" {
+		req.Header.Set(internal.SignatureHeader, signature)
+	}
 
 	client := w.HTTPClient
 	if client == nil {
-		client = &http.Client{Timeout: 30 * time.Second}
+		client = internal.NewHTTPClient(nil, w.URLPolicy, 0)
 	}
 
-	log.Printf("Sending webhook request to %q", req.URL.String())
+	// Receivers often take a token in the query, so it isn't logged
+	logged := *req.URL
+	logged.RawQuery, logged.Fragment = "", ""
+	log.Printf("Sending webhook request to %q", logged.Redacted())
 	resp, err := client.Do(req)
 	if err != nil {
 		return fmt.Errorf("failed to send webhook request: %w", err)
@@ -66,6 +396,5 @@ func (w *WebhookWorker) Work(ctx context.Context, job *river.Job[internal.Webhoo
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
 		return fmt.Errorf("webhook request failed with status %d", resp.StatusCode)
 	}
-
 	return nil
 }
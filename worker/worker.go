@@ -3,12 +3,25 @@ package main
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"log"
+	"net/url"
+	"os"
 	"os/exec"
+	"path"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
 
+	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/krelinga/video-info/internal"
+	"github.com/krelinga/video-info/virest"
 	"github.com/riverqueue/river"
 	"github.com/riverqueue/river/riverdriver/riverpgxv5"
 )
@@ -17,99 +30,786 @@ import (
 type ffprobeOutput struct {
 	Format   ffprobeFormat    `json:"format"`
 	Chapters []ffprobeChapter `json:"chapters"`
+	Streams  []ffprobeStream  `json:"streams"`
+
+	// raw is the complete output, including fields not decoded above
+	raw map[string]any
 }
 
 type ffprobeFormat struct {
-	Duration string `json:"duration"`
+	FormatName     string            `json:"format_name"`
+	FormatLongName string            `json:"format_long_name"`
+	Duration       string            `json:"duration"`
+	Size           string            `json:"size"`
+	BitRate        string            `json:"bit_rate"`
+	Tags           map[string]string `json:"tags"`
+}
+
+// container converts the format reported by ffprobe.  Values ffprobe reports
+// as unknown are left unset.
+func (f ffprobeFormat) container() *internal.Container {
+	c := &internal.Container{FormatName: f.FormatName}
+	if f.FormatLongName != "" {
+		c.FormatLongName = &f.FormatLongName
+	}
+	if n, err := strconv.ParseInt(f.BitRate, 10, 64); err == nil && n > 0 {
+		c.BitRate = &n
+	}
+	if n, err := strconv.ParseInt(f.Size, 10, 64); err == nil {
+		c.SizeBytes = n
+	}
+	c.Title = f.tag("title")
+	c.Encoder = f.tag("encoder")
+	if value := f.tag("creation_time"); value != nil {
+		if t, err := time.Parse(time.RFC3339Nano, *value); err == nil {
+			c.CreationTime = &t
+		}
+	}
+	return c
+}
+
+// tag returns the value of the named format tag, or nil if it isn't set.
+// Tag names are matched regardless of case, since containers differ in how
+// they spell them.
+func (f ffprobeFormat) tag(name string) *string {
+	for key, value := range f.Tags {
+		if strings.EqualFold(key, name) && value != "" {
+			return &value
+		}
+	}
+	return nil
 }
 
 type ffprobeChapter struct {
-	StartTime string `json:"start_time"`
-	EndTime   string `json:"end_time"`
+	StartTime string            `json:"start_time"`
+	EndTime   string            `json:"end_time"`
+	Tags      map[string]string `json:"tags"`
+}
+
+type ffprobeStream struct {
+	Index         int               `json:"index"`
+	CodecType     string            `json:"codec_type"`
+	CodecName     string            `json:"codec_name"`
+	Profile       string            `json:"profile"`
+	Level         *int              `json:"level"`
+	Width         int               `json:"width"`
+	Height        int               `json:"height"`
+	PixFmt        string            `json:"pix_fmt"`
+	AvgFrameRate  string            `json:"avg_frame_rate"`
+	RFrameRate    string            `json:"r_frame_rate"`
+	BitRate       string            `json:"bit_rate"`
+	Channels      int               `json:"channels"`
+	ChannelLayout string            `json:"channel_layout"`
+	SampleRate    string            `json:"sample_rate"`
+	Disposition   map[string]int    `json:"disposition"`
+	Tags          map[string]string `json:"tags"`
+}
+
+// streams returns the video streams, audio tracks and subtitle tracks
+// reported by ffprobe.  Attached pictures, which ffprobe reports as video
+// streams, are skipped.
+func (o *ffprobeOutput) streams() ([]internal.VideoStream, []internal.AudioTrack, []internal.SubtitleTrack) {
+	var (
+		videoStreams   []internal.VideoStream
+		audioTracks    []internal.AudioTrack
+		subtitleTracks []internal.SubtitleTrack
+	)
+	for _, stream := range o.Streams {
+		switch {
+		case stream.CodecType == "video" && stream.Disposition["attached_pic"] == 0:
+			videoStreams = append(videoStreams, stream.videoStream())
+		case stream.CodecType == "audio":
+			audioTracks = append(audioTracks, stream.audioTrack())
+		case stream.CodecType == "subtitle":
+			subtitleTracks = append(subtitleTracks, stream.subtitleTrack())
+		}
+	}
+	return videoStreams, audioTracks, subtitleTracks
+}
+
+// videoStream converts a video stream reported by ffprobe.  Values ffprobe
+// reports as unknown are left unset.
+func (s ffprobeStream) videoStream() internal.VideoStream {
+	v := internal.VideoStream{
+		Index:     s.Index,
+		CodecName: s.CodecName,
+		Width:     s.Width,
+		Height:    s.Height,
+	}
+	if s.Profile != "" && s.Profile != "unknown" {
+		v.Profile = &s.Profile
+	}
+	// ffprobe reports a negative level when it is unknown
+	if s.Level != nil && *s.Level >= 0 {
+		v.Level = s.Level
+	}
+	if s.PixFmt != "" {
+		v.PixelFormat = &s.PixFmt
+	}
+	if rate, ok := parseFrameRate(s.AvgFrameRate); ok {
+		v.FrameRate = &rate
+	} else if rate, ok := parseFrameRate(s.RFrameRate); ok {
+		v.FrameRate = &rate
+	}
+	v.BitRate = s.bitRate()
+	return v
+}
+
+// audioTrack converts an audio stream reported by ffprobe.  Values ffprobe
+// reports as unknown are left unset.
+func (s ffprobeStream) audioTrack() internal.AudioTrack {
+	t := internal.AudioTrack{
+		Index:     s.Index,
+		CodecName: s.CodecName,
+		Channels:  s.Channels,
+		BitRate:   s.bitRate(),
+		Default:   s.Disposition["default"] != 0,
+		Forced:    s.Disposition["forced"] != 0,
+	}
+	if s.ChannelLayout != "" {
+		t.ChannelLayout = &s.ChannelLayout
+	}
+	if n, err := strconv.Atoi(s.SampleRate); err == nil && n > 0 {
+		t.SampleRate = &n
+	}
+	t.Language = s.language()
+	return t
+}
+
+// subtitleTrack converts a subtitle stream reported by ffprobe.
+func (s ffprobeStream) subtitleTrack() internal.SubtitleTrack {
+	t := internal.SubtitleTrack{
+		Index:           s.Index,
+		CodecName:       s.CodecName,
+		Language:        s.language(),
+		Default:         s.Disposition["default"] != 0,
+		Forced:          s.Disposition["forced"] != 0,
+		HearingImpaired: s.Disposition["hearing_impaired"] != 0,
+	}
+	if title := s.Tags["title"]; title != "" {
+		t.Title = &title
+	}
+	return t
+}
+
+// language returns the language tag of the stream, or nil if it is unknown.
+func (s ffprobeStream) language() *string {
+	// "und" is the ISO 639-2 code for an undetermined language
+	if language := s.Tags["language"]; language != "" && language != "und" {
+		return &language
+	}
+	return nil
+}
+
+// bitRate returns the bit rate of the stream, or nil if it is unknown.
+func (s ffprobeStream) bitRate() *int64 {
+	// Matroska usually only records the bit rate in the BPS statistics tag
+	bitRate := s.BitRate
+	if bitRate == "" {
+		bitRate = s.Tags["BPS"]
+	}
+	if n, err := strconv.ParseInt(bitRate, 10, 64); err == nil && n > 0 {
+		return &n
+	}
+	return nil
+}
+
+// parseFrameRate parses a frame rate reported by ffprobe as a fraction, such
+// as "24000/1001".  ffprobe reports "0/0" when the rate is unknown.
+func parseFrameRate(s string) (float64, bool) {
+	num, den, ok := strings.Cut(s, "/")
+	if !ok {
+		return 0, false
+	}
+	n, err := strconv.ParseFloat(num, 64)
+	if err != nil || n <= 0 {
+		return 0, false
+	}
+	d, err := strconv.ParseFloat(den, 64)
+	if err != nil || d <= 0 {
+		return 0, false
+	}
+	return n / d, true
 }
 
 // InfoWorker handles video information extraction jobs.
 type InfoWorker struct {
 	river.WorkerDefaults[internal.InfoJobArgs]
 	DBPool *pgxpool.Pool
+	Signer *internal.Signer
+	Prober *Prober
+
+	// Events, if set, publishes the job's outcome.
+	Events *internal.EventPublisher
+
+	// Clock stamps the job's events and webhooks.  Nil means the system
+	// clock.
+	Clock internal.Clock
+}
+
+// infoJobTimeoutGrace is how much longer than a job's own timeout River
+// lets it run, so the job records its timeout before River cancels it.
+const infoJobTimeoutGrace = 30 * time.Second
+
+// Timeout lets jobs with their own timeout run past River's default, which
+// applies to the rest.
+func (w *InfoWorker) Timeout(job *river.Job[internal.InfoJobArgs]) time.Duration {
+	if timeout := job.Args.Timeout(); timeout > 0 {
+		return timeout + infoJobTimeoutGrace
+	}
+	return 0
 }
 
 // Work executes the video info extraction job using ffprobe.
 func (w *InfoWorker) Work(ctx context.Context, job *river.Job[internal.InfoJobArgs]) error {
-	status := internal.InfoJobStatus{}
+	if err := w.enqueueStartedWebhooks(ctx, job); err != nil {
+		return err
+	}
 
-	result, err := extractVideoInfo(ctx, job.Args.Path)
-	if err != nil {
-		errMsg := err.Error()
-		status.Error = &errMsg
+	cached, info := w.lookupProbeCache(ctx, job.Args)
+	var status internal.InfoJobStatus
+	if cached != nil {
+		status = *cached
 	} else {
-		status.Result = result
+		status = w.Prober.Probe(ctx, job.Args.UUID, job.Args.Path, job.Args.Analyses(), job.Args.Timeout())
+	}
+	if err := ctx.Err(); err != nil {
+		// The job was cancelled or timed out, so the probe's error is just
+		// a symptom.  Returning the context's error lets River finalize the
+		// job accordingly.
+		return err
+	}
+	if err := w.Signer.SignInfoJobStatus(job.Args, &status); err != nil {
+		return fmt.Errorf("failed to sign output: %w", err)
 	}
 
 	if err := river.RecordOutput(ctx, status); err != nil {
 		return fmt.Errorf("failed to record output: %w", err)
 	}
 
-	// Enqueue webhook job if webhook URI is configured
-	if job.Args.WebhookURI != nil {
-		webhookArgs := internal.WebhookJobArgs{
-			URI:    *job.Args.WebhookURI,
-			Token:  job.Args.WebhookToken,
-			Uuid:   job.Args.UUID,
-			Status: &status,
+	// Store the result, enqueue its webhook and complete the job atomically
+	tx, err := w.DBPool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if err := internal.StoreInfoResult(ctx, tx, job.ID, job.Args, &status, job.Priority, job.CreatedAt); err != nil {
+		return err
+	}
+
+	// Results with warnings are missing analyses a later job would expect,
+	// and canary results are still on trial
+	if cached == nil && info != nil && status.Result != nil && len(status.Result.Warnings) == 0 && !job.Args.Canary && job.Args.Comparison == nil {
+		if err := internal.StoreProbeCache(ctx, tx, job.Args.Path, info, job.Args.Analyses(), w.Prober.CacheSettings(), status.Result); err != nil {
+			return err
 		}
+	}
 
-		// Start a transaction to insert webhook job and complete info job atomically
-		tx, err := w.DBPool.Begin(ctx)
-		if err != nil {
-			return fmt.Errorf("failed to begin transaction: %w", err)
+	// Enqueue a webhook job for each configured webhook target
+	if webhooks := job.Args.WebhookArgs("", &status); len(webhooks) > 0 {
+		var changes *internal.ResultChanges
+		if status.Result != nil {
+			changes, err = internal.CompareWithPreviousResult(ctx, tx, job.ID, job.Args, status.Result)
+			if err != nil {
+				return err
+			}
 		}
-		defer tx.Rollback(ctx)
 
 		client := river.ClientFromContext[pgx.Tx](ctx)
 		if client == nil {
 			return fmt.Errorf("no river client in context for webhook job insertion")
 		}
 
-		if _, err := client.InsertTx(ctx, tx, webhookArgs, nil); err != nil {
-			return fmt.Errorf("failed to enqueue webhook job: %w", err)
+		now := internal.OrSystemClock(w.Clock).Now()
+		for _, webhookArgs := range webhooks {
+			webhookArgs.Changes = changes
+			if err := internal.EnqueueWebhook(ctx, tx, client, webhookArgs, now); err != nil {
+				return err
+			}
 		}
+	}
 
-		// Complete the current job within the same transaction
-		if _, err := river.JobCompleteTx[*riverpgxv5.Driver](ctx, tx, job); err != nil {
-			return fmt.Errorf("failed to complete job in transaction: %w", err)
+	// Complete the current job within the same transaction
+	if _, err := river.JobCompleteTx[*riverpgxv5.Driver](ctx, tx, job); err != nil {
+		return fmt.Errorf("failed to complete job in transaction: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	w.Events.PublishFinished(job.Args, &status, internal.OrSystemClock(w.Clock).Now())
+	return nil
+}
+
+// enqueueStartedWebhooks enqueues the webhooks of a job whose webhook events
+// include its start.  Retries start the job again, but its receivers only
+// hear of the first attempt.
+func (w *InfoWorker) enqueueStartedWebhooks(ctx context.Context, job *river.Job[internal.InfoJobArgs]) error {
+	if job.Attempt != 1 {
+		return nil
+	}
+	webhooks := job.Args.WebhookArgs(internal.WebhookStarted, nil)
+	if len(webhooks) == 0 {
+		return nil
+	}
+	client := river.ClientFromContext[pgx.Tx](ctx)
+	if client == nil {
+		return fmt.Errorf("no river client in context for webhook job insertion")
+	}
+
+	tx, err := w.DBPool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+	now := internal.OrSystemClock(w.Clock).Now()
+	for _, webhookArgs := range webhooks {
+		if err := internal.EnqueueWebhook(ctx, tx, client, webhookArgs, now); err != nil {
+			return err
 		}
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// phaseTimer records how long each phase of an info job takes.  Phases may
+// run concurrently.
+type phaseTimer struct {
+	mu      sync.Mutex
+	timings []internal.PhaseTiming
+}
+
+// time runs f as the named phase and records its duration.
+func (t *phaseTimer) time(phase string, f func() error) error {
+	start := time.Now()
+	err := f()
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.timings = append(t.timings, internal.PhaseTiming{
+		Phase:           phase,
+		DurationSeconds: time.Since(start).Seconds(),
+	})
+	return err
+}
+
+// Prober runs the phases of an info job against a video file.
+type Prober struct {
+	// AllowedRoots, if set, are the directories outside of which files are
+	// rejected without being probed.
+	AllowedRoots internal.AllowedRoots
+
+	// URLInputSchemes are the schemes of URLs that may be probed in place of
+	// files, and URLInputTimeout, if positive, bounds their network I/O.
+	URLInputSchemes []string
+	URLInputTimeout time.Duration
+
+	// URLPolicy, if set, restricts the hosts of the URLs that may be probed.
+	// ffprobe reads URLs through inputProxy, which holds redirects to it too.
+	URLPolicy  *internal.URLPolicy
+	inputProxy *inputProxy
+
+	// OutboundProxy, if set, is the HTTP proxy ffprobe reads URLs through.
+	OutboundProxy *url.URL
+
+	// FetchDir, SFTP and SMB configure fetching the files named by URLs.
+	// ffprobe reads the files named by sftp and smb URLs through
+	// rangeServer, and files are copied to FetchDir for analyses that read
+	// the whole file.
+	FetchDir    string
+	SFTP        internal.SFTPConfig
+	SMB         internal.SMBConfig
+	rangeServer *rangeServer
+
+	// MaxFileSize, if positive, is the size in bytes above which a file is
+	// rejected without being probed.
+	MaxFileSize int64
+
+	// DeepAnalysisMaxSize, if positive, is the size in bytes above which
+	// analyses that decode video are skipped with a warning.
+	DeepAnalysisMaxSize int64
+
+	// AnalysisParallelism bounds the number of a job's analyses run at once.
+	AnalysisParallelism int
+
+	// FFprobePath and FFmpegPath are the executables to run.
+	FFprobePath string
+	FFmpegPath  string
+
+	// Versions are recorded in every result the Prober probes.
+	Versions internal.ToolVersions
 
-		if err := tx.Commit(ctx); err != nil {
-			return fmt.Errorf("failed to commit transaction: %w", err)
+	// HWAccel and HWAccelDevice select hardware decoding for phases that
+	// decode video.  ffprobe only reads container metadata and ignores them.
+	HWAccel       internal.HWAccel
+	HWAccelDevice string
+
+	// ProbeAudio allows audio-only files to be probed instead of rejected.
+	ProbeAudio bool
+
+	// Cache, if set, holds results of earlier probes of unchanged files.
+	Cache *internal.ResultCache
+
+	// Presets, if set, suggest an encoding preset for each result.
+	Presets *internal.PresetRules
+
+	// Hook, if set, is run with the outcome of every probe.
+	Hook *PostProbeHook
+
+	// Analyzers are the analyzer plugins jobs may select.
+	Analyzers *internal.AnalyzerPlugins
+
+	// wasm runs the Analyzers that are WASI modules.
+	wasm *wasmAnalyzers
+}
+
+// NewProber creates a Prober from the worker configuration.
+func NewProber(cfg *internal.WorkerConfig) (*Prober, error) {
+	cache, err := internal.NewResultCache(cfg.CacheDir)
+	if err != nil {
+		return nil, err
+	}
+	presets, err := internal.LoadPresetRules(cfg.PresetRules)
+	if err != nil {
+		return nil, err
+	}
+	analyzers, err := internal.LoadAnalyzerPlugins(cfg.AnalyzerPlugins)
+	if err != nil {
+		return nil, err
+	}
+	wasm, err := newWasmAnalyzers(context.Background(), analyzers)
+	if err != nil {
+		return nil, err
+	}
+	p := &Prober{
+		AllowedRoots:        cfg.AllowedRoots,
+		URLInputSchemes:     cfg.URLInputSchemes,
+		URLInputTimeout:     cfg.URLInputTimeout,
+		URLPolicy:           cfg.WebhookPolicy,
+		OutboundProxy:       cfg.OutboundProxy,
+		FetchDir:            cfg.FetchDir,
+		SFTP:                cfg.SFTP,
+		SMB:                 cfg.SMB,
+		MaxFileSize:         cfg.MaxFileSize,
+		DeepAnalysisMaxSize: cfg.DeepAnalysisMaxSize,
+		AnalysisParallelism: cfg.AnalysisParallelism,
+		FFprobePath:         cfg.FFprobePath,
+		FFmpegPath:          cfg.FFmpegPath,
+		HWAccel:             cfg.HWAccel,
+		HWAccelDevice:       cfg.HWAccelDevice,
+		ProbeAudio:          cfg.ProbeAudio,
+		Cache:               cache,
+		Presets:             presets,
+		Analyzers:           analyzers,
+		wasm:                wasm,
+	}
+	if p.URLPolicy != nil && slices.ContainsFunc(p.URLInputSchemes, func(scheme string) bool {
+		return !internal.IsFetchedURLScheme(scheme)
+	}) {
+		p.inputProxy, err = startInputProxy(p.URLPolicy, p.OutboundProxy, p.URLInputTimeout)
+		if err != nil {
+			return nil, err
 		}
 	}
+	if cfg.PostProbeHook != "" {
+		p.Hook = &PostProbeHook{Path: cfg.PostProbeHook, Timeout: cfg.PostProbeHookTimeout}
+	}
+	if p.FFprobePath == "" {
+		p.FFprobePath = "ffprobe"
+	}
+	if p.FFmpegPath == "" {
+		p.FFmpegPath = "ffmpeg"
+	}
+	p.Versions = internal.ToolVersions{
+		Worker:  internal.BuildVersion(),
+		FFprobe: toolVersion(context.Background(), p.FFprobePath),
+		FFmpeg:  toolVersion(context.Background(), p.FFmpegPath),
+	}
+	return p, nil
+}
 
-	return nil
+// ffmpegArgs returns the arguments for an ffmpeg run that decodes videoPath,
+// enabling hardware decoding if configured.  inputArgs precede the input and
+// args follow it.
+func (p *Prober) ffmpegArgs(inputArgs []string, videoPath string, args ...string) []string {
+	full := []string{"-hide_banner", "-nostats"}
+	full = append(full, p.HWAccel.InputArgs(p.HWAccelDevice)...)
+	full = append(full, inputArgs...)
+	full = append(full, "-i", videoPath)
+	return append(full, args...)
 }
 
-// extractVideoInfo uses ffprobe to extract video duration and chapter information.
-func extractVideoInfo(ctx context.Context, videoPath string) (*internal.InfoJobResult, error) {
-	// Run ffprobe to get format and chapter information in JSON format
-	cmd := exec.CommandContext(ctx, "ffprobe",
-		"-v", "quiet",
-		"-print_format", "json",
-		"-show_format",
-		"-show_chapters",
-		videoPath,
-	)
+// Probe runs every phase of the info job jobUUID against videoPath,
+// including the requested analyses and the post-probe hook, and returns its
+// outcome.  If timeout is positive, the job fails with internal.ErrJobTimeout
+// once it has passed; the hook isn't bound by it.  Errors are redacted since
+// they are stored with the job.
+func (p *Prober) Probe(ctx context.Context, jobUUID uuid.UUID, videoPath string, analyses internal.Analyses, timeout time.Duration) internal.InfoJobStatus {
+	result, timer, err := p.extractVideoInfoWithin(ctx, videoPath, analyses, timeout)
 
-	output, err := cmd.Output()
+	status := internal.InfoJobStatus{}
 	if err != nil {
-		if exitErr, ok := err.(*exec.ExitError); ok {
-			return nil, fmt.Errorf("ffprobe failed: %s", string(exitErr.Stderr))
+		errMsg := internal.Redact(err.Error())
+		status.Error = &errMsg
+		status.ErrorCode = internal.ErrorCode(err)
+	} else {
+		status.Result = result
+		internal.EnforceOutputSchema(&status)
+	}
+
+	p.runHook(ctx, jobUUID, videoPath, &status, timer)
+	status.Timings = timer.timings
+	return status
+}
+
+// runHook runs the post-probe hook, if there is one, with status, the
+// outcome of the info job jobUUID for videoPath.
+func (p *Prober) runHook(ctx context.Context, jobUUID uuid.UUID, videoPath string, status *internal.InfoJobStatus, timer *phaseTimer) {
+	// The hook is the operator's own code, so its failures don't fail the job
+	if p.Hook == nil || ctx.Err() != nil {
+		return
+	}
+	err := timer.time(internal.PhaseHook, func() error {
+		return p.Hook.Run(ctx, jobUUID, videoPath, status)
+	})
+	if err != nil {
+		log.Printf("Post-probe hook for job %s failed: %v", jobUUID, internal.Redact(err.Error()))
+	}
+}
+
+// CacheSettings returns the settings that key the results the Prober
+// caches in the database.
+func (p *Prober) CacheSettings() internal.ProbeCacheSettings {
+	return internal.ProbeCacheSettings{
+		FFprobeVersion: p.Versions.FFprobe,
+		FFmpegVersion:  p.Versions.FFmpeg,
+		ProbeAudio:     p.ProbeAudio,
+		HWAccel:        p.HWAccel,
+	}
+}
+
+// extractVideoInfoWithin runs extractVideoInfo, giving up once timeout has
+// passed if it is positive, and returns the timer holding its phase timings.
+// Calls blocked on an unresponsive network mount can't be interrupted, so
+// they are left to finish in the background.
+func (p *Prober) extractVideoInfoWithin(ctx context.Context, videoPath string, analyses internal.Analyses, timeout time.Duration) (*internal.InfoJobResult, *phaseTimer, error) {
+	timer := &phaseTimer{}
+	if timeout <= 0 {
+		result, err := p.extractVideoInfo(ctx, videoPath, analyses, timer)
+		return result, timer, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	type outcome struct {
+		result *internal.InfoJobResult
+		err    error
+	}
+	done := make(chan outcome, 1)
+	go func() {
+		result, err := p.extractVideoInfo(ctx, videoPath, analyses, timer)
+		done <- outcome{result, err}
+	}()
+
+	select {
+	case o := <-done:
+		// Analyses cut short by the deadline only leave warnings, so check
+		// for it rather than relying on the error
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return nil, timer, fmt.Errorf("%w after %s", internal.ErrJobTimeout, timeout)
 		}
-		return nil, fmt.Errorf("failed to run ffprobe: %w", err)
+		return o.result, timer, o.err
+	case <-ctx.Done():
+		// The abandoned call still writes to timer
+		err := ctx.Err()
+		if errors.Is(err, context.DeadlineExceeded) {
+			err = fmt.Errorf("%w after %s", internal.ErrJobTimeout, timeout)
+		}
+		return nil, &phaseTimer{}, err
 	}
+}
 
-	var probeResult ffprobeOutput
-	if err := json.Unmarshal(output, &probeResult); err != nil {
-		return nil, fmt.Errorf("failed to parse ffprobe output: %w", err)
+// extractVideoInfo checks videoPath and returns its cached result if there is
+// one, or otherwise probes it, then runs the requested analyses.  Files that
+// are recognizably not video fail with internal.ErrUnsupportedFormat.  A
+// directory is probed as an image sequence, and a URL is either probed
+// remotely or fetched first.
+func (p *Prober) extractVideoInfo(ctx context.Context, videoPath string, analyses internal.Analyses, timer *phaseTimer) (*internal.InfoJobResult, error) {
+	if internal.IsInputURL(videoPath) {
+		// ffprobe reads container metadata by range, so only analyses that
+		// read the media itself need the whole file
+		if analyses.ReadsContent() {
+			return p.extractFetchedInfo(ctx, videoPath, analyses, timer)
+		}
+		return p.extractURLInfo(ctx, videoPath, analyses, timer)
+	}
+
+	// Jobs may have been queued by anyone with database access, so the
+	// server's check can't be relied on
+	if err := p.AllowedRoots.CheckResolved(videoPath); err != nil {
+		return nil, err
+	}
+
+	// Check the file up front so a missing, unreadable or oversized file
+	// fails fast with a clear error
+	var info os.FileInfo
+	err := timer.time(internal.PhaseStat, func() error {
+		var err error
+		info, err = os.Stat(videoPath)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to stat video file: %w", err)
+	}
+
+	if !info.IsDir() {
+		if p.MaxFileSize > 0 && info.Size() > p.MaxFileSize {
+			return nil, fmt.Errorf("%w: video file is %d bytes, over the %d byte limit", internal.ErrFileTooLarge, info.Size(), p.MaxFileSize)
+		}
+
+		// Skip ffprobe for files that are obviously not video, such as
+		// artwork and NFO files sitting next to videos
+		var (
+			class    internal.MediaClass
+			mimeType string
+		)
+		err = timer.time(internal.PhaseSniff, func() error {
+			var err error
+			class, mimeType, err = internal.SniffMediaClass(videoPath)
+			return err
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to sniff video file: %w", err)
+		}
+		if class == internal.MediaOther || (class == internal.MediaAudio && !p.ProbeAudio) {
+			return nil, fmt.Errorf("%w: file looks like %s", internal.ErrUnsupportedFormat, mimeType)
+		}
+	}
+
+	var result *internal.InfoJobResult
+	if p.Cache != nil {
+		// A cache problem shouldn't fail the job, so fall back to probing
+		timer.time(internal.PhaseCache, func() error {
+			var err error
+			result, err = p.Cache.Get(info)
+			if err != nil {
+				log.Printf("Result cache lookup for %q failed: %v", videoPath, err)
+			}
+			return nil
+		})
+	}
+
+	if result == nil {
+		if info.IsDir() {
+			result, err = p.probeImageSequence(ctx, videoPath, timer)
+		} else {
+			result, err = p.probeMedia(ctx, videoPath, timer)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if err := p.Cache.Put(info, result); err != nil {
+			log.Printf("Failed to cache result for %q: %v", videoPath, err)
+		}
+	}
+
+	p.findLinkedSegments(videoPath, result, timer)
+
+	// Audio formats without a recognizable signature, such as FLAC, are only
+	// caught once probed
+	if result.MediaKind == virest.Audio && !p.ProbeAudio {
+		return nil, fmt.Errorf("%w: file has no video streams", internal.ErrUnsupportedFormat)
+	}
+
+	// The raw output is cached with the result so it is available to later
+	// jobs that ask for it, but only returned when asked for
+	if !analyses.Raw {
+		result.RawProbe = nil
+	}
+
+	// Analyses depend on the job's options, so they are never cached
+	p.runAnalyses(ctx, videoPath, info, result, analyses, timer)
+
+	// Suggest a preset even for cached results, so rule changes take effect
+	// without reprobing
+	result.SuggestedPreset = p.Presets.Suggest(result)
+	return result, nil
+}
+
+// findLinkedSegments looks for the segments linked from videoPath that
+// result lists.  Linked segments can be added or removed without touching
+// the file, so they are looked for even when the result was cached.
+func (p *Prober) findLinkedSegments(videoPath string, result *internal.InfoJobResult, timer *phaseTimer) {
+	if len(result.LinkedSegments) == 0 {
+		return
+	}
+	err := timer.time(internal.PhaseSegments, func() error {
+		return internal.FindLinkedSegments(videoPath, result.LinkedSegments)
+	})
+	if err != nil {
+		log.Printf("Failed to look for segments linked from %q: %v", videoPath, err)
+	}
+}
+
+// extractURLInfo probes the media at videoURL with ffprobe, which reads
+// only the byte ranges it needs over the network.  ffprobe reads http and
+// https URLs itself, and the files named by sftp and smb URLs through a
+// rangeServer.  Results are not cached, since there is no cheap way to tell
+// whether the media has changed.
+func (p *Prober) extractURLInfo(ctx context.Context, videoURL string, analyses internal.Analyses, timer *phaseTimer) (*internal.InfoJobResult, error) {
+	if err := internal.CheckInputURL(ctx, videoURL, p.URLInputSchemes, p.URLPolicy); err != nil {
+		return nil, err
+	}
+	prober, probeURL := p, videoURL
+	if internal.IsFetchedInputURL(videoURL) {
+		u, err := url.Parse(videoURL)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", internal.ErrPathNotAllowed, err)
+		}
+		var file *remoteFile
+		err = timer.time(internal.PhaseFetch, func() error {
+			var err error
+			file, err = p.openRemote(ctx, u)
+			return err
+		})
+		if err != nil {
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return nil, ctxErr
+			}
+			return nil, fmt.Errorf("failed to open %s: %w", u.Redacted(), err)
+		}
+		defer file.close()
+		server, err := serveRanges(file, path.Base(u.Path))
+		if err != nil {
+			return nil, err
+		}
+		defer server.Close()
+		local := *p
+		local.rangeServer = server
+		prober, probeURL = &local, server.url
+	}
+	result, err := prober.probeMedia(ctx, probeURL, timer)
+	if err != nil {
+		return nil, err
+	}
+	if result.MediaKind == virest.Audio && !p.ProbeAudio {
+		return nil, fmt.Errorf("%w: media has no video streams", internal.ErrUnsupportedFormat)
+	}
+	if !analyses.Raw {
+		result.RawProbe = nil
+	}
+	result.SuggestedPreset = p.Presets.Suggest(result)
+	return result, nil
+}
+
+// probeMedia uses ffprobe to extract the duration, chapters and streams of an
+// audio or video file.
+func (p *Prober) probeMedia(ctx context.Context, videoPath string, timer *phaseTimer) (*internal.InfoJobResult, error) {
+	probeResult, err := p.runFFprobe(ctx, videoPath, timer)
+	if err != nil {
+		return nil, err
 	}
 
 	// Parse total duration
@@ -118,8 +818,9 @@ func extractVideoInfo(ctx context.Context, videoPath string) (*internal.InfoJobR
 		return nil, fmt.Errorf("failed to parse duration: %w", err)
 	}
 
-	// Parse chapter durations
+	// Parse chapters
 	chapterDurations := make([]float64, 0, len(probeResult.Chapters))
+	var chapters []internal.Chapter
 	for _, chapter := range probeResult.Chapters {
 		var startTime, endTime float64
 		if _, err := fmt.Sscanf(chapter.StartTime, "%f", &startTime); err != nil {
@@ -129,10 +830,178 @@ func extractVideoInfo(ctx context.Context, videoPath string) (*internal.InfoJobR
 			return nil, fmt.Errorf("failed to parse chapter end time: %w", err)
 		}
 		chapterDurations = append(chapterDurations, endTime-startTime)
+		c := internal.Chapter{StartSeconds: startTime, EndSeconds: endTime}
+		if title := chapter.Tags["title"]; title != "" {
+			c.Title = &title
+		}
+		chapters = append(chapters, c)
 	}
 
-	return &internal.InfoJobResult{
+	result := &internal.InfoJobResult{
 		DurationSeconds:         totalDuration,
 		ChapterDurationsSeconds: chapterDurations,
+		Chapters:                chapters,
+		Container:               probeResult.Format.container(),
+		RawProbe:                probeResult.raw,
+		Versions:                p.resultVersions(),
+	}
+	result.VideoStreams, result.AudioTracks, result.SubtitleTracks = probeResult.streams()
+
+	// ffprobe only reports the chapters of one edition and ignores ordering,
+	// so read editions from the file itself, if it is local.  The rest of the
+	// result is still useful if that fails.
+	if strings.Contains(probeResult.Format.FormatName, "matroska") && !internal.IsInputURL(videoPath) {
+		var mkv *internal.MatroskaInfo
+		err := timer.time(internal.PhaseMatroska, func() error {
+			var err error
+			mkv, err = internal.ReadMatroska(videoPath)
+			return err
+		})
+		if err != nil {
+			log.Printf("Failed to read Matroska editions of %q: %v", videoPath, err)
+		} else {
+			result.Editions = mkv.MediaEditions()
+			result.TimelineDurationSeconds = mkv.TimelineSeconds()
+			result.LinkedSegments = mkv.LinkedSegments()
+		}
+	}
+
+	switch {
+	case len(result.VideoStreams) > 0:
+		result.MediaKind = virest.Video
+	case len(result.AudioTracks) > 0:
+		result.MediaKind = virest.Audio
+	default:
+		return nil, fmt.Errorf("%w: file has no audio or video streams", internal.ErrUnsupportedFormat)
+	}
+	return result, nil
+}
+
+// probeImageSequence describes a directory of numbered images by probing the
+// first of them.  Hidden files are ignored, and every other file must share
+// the first image's extension.
+func (p *Prober) probeImageSequence(ctx context.Context, dir string, timer *phaseTimer) (*internal.InfoJobResult, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list image sequence: %w", err)
+	}
+	var images []string
+	for _, entry := range entries {
+		if entry.Type().IsRegular() && !strings.HasPrefix(entry.Name(), ".") {
+			images = append(images, entry.Name())
+		}
+	}
+	if len(images) == 0 {
+		return nil, fmt.Errorf("%w: directory contains no images", internal.ErrUnsupportedFormat)
+	}
+	ext := strings.ToLower(filepath.Ext(images[0]))
+	for _, image := range images {
+		if strings.ToLower(filepath.Ext(image)) != ext {
+			return nil, fmt.Errorf("%w: directory mixes %q and %q files", internal.ErrUnsupportedFormat, ext, filepath.Ext(image))
+		}
+	}
+
+	// os.ReadDir sorts by name, so this is the first frame
+	probeResult, err := p.runFFprobe(ctx, filepath.Join(dir, images[0]), timer)
+	if err != nil {
+		return nil, err
+	}
+	videoStreams, _, _ := probeResult.streams()
+	if len(videoStreams) == 0 {
+		return nil, fmt.Errorf("%w: %s is not an image", internal.ErrUnsupportedFormat, images[0])
+	}
+
+	frameCount := len(images)
+	return &internal.InfoJobResult{
+		MediaKind:               virest.ImageSequence,
+		ChapterDurationsSeconds: []float64{},
+		VideoStreams:            videoStreams[:1],
+		FrameCount:              &frameCount,
+		RawProbe:                probeResult.raw,
+		Versions:                p.resultVersions(),
 	}, nil
 }
+
+// runFFprobe runs ffprobe to get format, chapter and stream information.
+func (p *Prober) runFFprobe(ctx context.Context, path string, timer *phaseTimer) (*ffprobeOutput, error) {
+	args := []string{
+		"-v", "quiet",
+		"-print_format", "json",
+		"-show_format",
+		"-show_chapters",
+		"-show_streams",
+	}
+	switch {
+	case p.rangeServer != nil:
+		args = append(args, p.rangeServer.inputArgs()...)
+	case internal.IsInputURL(path):
+		args = append(args, p.urlInputArgs()...)
+	}
+	cmd := exec.CommandContext(ctx, p.FFprobePath, append(args, path)...)
+
+	var output []byte
+	err := timer.time(internal.PhaseFFprobe, func() error {
+		var err error
+		output, err = cmd.Output()
+		return err
+	})
+	if err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			return nil, fmt.Errorf("ffprobe failed: %s", string(exitErr.Stderr))
+		}
+		return nil, fmt.Errorf("failed to run ffprobe: %w", err)
+	}
+
+	var probeResult ffprobeOutput
+	if err := json.Unmarshal(output, &probeResult); err != nil {
+		return nil, fmt.Errorf("failed to parse ffprobe output: %w", err)
+	}
+	if err := json.Unmarshal(output, &probeResult.raw); err != nil {
+		return nil, fmt.Errorf("failed to parse ffprobe output: %w", err)
+	}
+	return &probeResult, nil
+}
+
+// urlInputArgs returns the ffprobe arguments for reading a URL.  Only the
+// allowed streamed schemes and the transports beneath them may be used, so a
+// playlist can't make ffprobe read local files or other protocols.  If there
+// is a URL policy, ffprobe reads through inputProxy, and otherwise through
+// the outbound proxy if one is configured, tunnelling https.
+func (p *Prober) urlInputArgs() []string {
+	protocols := []string{"tcp", "tls"}
+	for _, scheme := range p.URLInputSchemes {
+		if !internal.IsFetchedURLScheme(scheme) {
+			protocols = append(protocols, scheme)
+		}
+	}
+	proxy := p.OutboundProxy
+	if p.inputProxy != nil {
+		proxy = p.inputProxy.URL()
+	}
+	var args []string
+	if proxy != nil {
+		protocols = append(protocols, "httpproxy")
+		args = append(args, "-http_proxy", proxy.String())
+	}
+	args = append(args, "-protocol_whitelist", strings.Join(protocols, ","))
+	if p.URLInputTimeout > 0 {
+		args = append(args, "-rw_timeout", strconv.FormatInt(p.URLInputTimeout.Microseconds(), 10))
+	}
+	return args
+}
+
+// toolVersion returns the version reported by the ffprobe or ffmpeg
+// executable at path, or "unknown" if it can't be determined.
+func toolVersion(ctx context.Context, path string) string {
+	output, err := exec.CommandContext(ctx, path, "-version").Output()
+	if err != nil {
+		return "unknown"
+	}
+	return internal.ParseToolVersion(string(output))
+}
+
+// resultVersions returns a copy of p.Versions for a new result.
+func (p *Prober) resultVersions() *internal.ToolVersions {
+	versions := p.Versions
+	return &versions
+}